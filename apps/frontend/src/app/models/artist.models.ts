@@ -2,15 +2,139 @@ export interface Artist {
   id: string;
   name: string;
   biography: string;
+  biographyAttribution?: Attribution;
   genres: string[] | null;
   albums: Album[] | null;
-  related: string[] | null;
-  imageUrl: string;
+  albumsTruncated?: boolean;
+  related: RelatedArtist[] | null;
+  images: Image[] | null;
+  links?: Links;
   country?: string;
   type?: string;
   disambiguation?: string;
   aliases?: string[];
   lifeSpan: LifeSpan;
+  members?: Membership[];
+  memberOf?: Membership[];
+  stats?: DiscographyStats;
+  listening?: ListeningStats;
+  awards?: Award[];
+  localizedNames?: Record<string, string>;
+  localizedName?: string;
+  locale?: Locale;
+  warnings?: Warning[];
+}
+
+/** An artist linked by a MusicBrainz relationship or called similar by Last.fm. */
+export interface RelatedArtist {
+  id?: string;
+  name: string;
+  relation?: string;
+  match?: number;
+  source: string;
+}
+
+/** Credit for licensed text such as a Wikipedia biography; text is a ready-made credit line. */
+export interface Attribution {
+  source: string;
+  title: string;
+  url: string;
+  revision?: string;
+  license: string;
+  licenseUrl: string;
+  text: string;
+}
+
+/** A field an optional source failed to fill; retryable ones may fill on a later fetch. */
+export interface Warning {
+  field: string;
+  source: string;
+  reason: 'unavailable' | 'timeout' | 'rate_limited' | 'unauthorized' | 'failed';
+  retryable: boolean;
+}
+
+/** Language a response was localized to via Accept-Language, with a date rendering hint. */
+export interface Locale {
+  tag: string;
+  dateFormat: string;
+}
+
+/** Award received, from Wikidata; category is absent for awards without one. */
+export interface Award {
+  id: string;
+  name: string;
+  category?: string;
+  year?: number;
+}
+
+export interface DiscographyStats {
+  albums: number;
+  eps: number;
+  singles: number;
+  other: number;
+  firstYear?: number;
+  lastYear?: number;
+  activeYears?: number;
+  averageRating?: number;
+  ratedAlbums?: number;
+  collaborators?: Collaborator[];
+}
+
+export interface Collaborator {
+  artistId: string;
+  name: string;
+  count: number;
+}
+
+/** Artists sharing release-group credits with the subject, weighted by shared releases. */
+export interface CollaborationGraph {
+  artistId: string;
+  artistName: string;
+  releaseGroups: number;
+  edges: CollaborationEdge[];
+}
+
+export interface CollaborationEdge {
+  artistId: string;
+  name: string;
+  weight: number;
+  shared: SharedRelease[];
+}
+
+export interface SharedRelease {
+  id: string;
+  title: string;
+  year?: number;
+}
+
+export interface Discography {
+  artistId: string;
+  artistName: string;
+  studioAlbums: Album[];
+  liveAlbums: Album[];
+  compilations: Album[];
+  eps: Album[];
+  singles: Album[];
+  other: Album[];
+}
+
+/** A page of an artist's release groups from GET /artists/{id}/albums. */
+export interface AlbumPage {
+  artistId: string;
+  albums: Album[];
+  offset: number;
+  limit: number;
+  total: number;
+  nextOffset?: number;
+}
+
+export interface Membership {
+  artistId: string;
+  name: string;
+  roles?: string[];
+  begin: string;
+  end: string;
+  current: boolean;
 }
 
 export interface Album {
@@ -23,16 +147,181 @@ export interface Album {
   firstReleaseDate?: string;
   year: number;
   genre: string;
+  styles?: string[];
   label: string;
+  labelId?: string;
+  catalogNumber?: string;
   tracks: Track[];
-  review: Review;
-  coverUrl: string;
+  runtime?: AlbumRuntime;
+  reviews: Review[] | null;
+  rating?: AggregateRating;
+  marketplace?: Marketplace;
+  listening?: ListeningStats;
+  description?: string;
+  descriptionAttribution?: Attribution;
+  /** @deprecated Mirrors reviews[0]; use reviews instead. */
+  review?: Review;
+  images: Image[] | null;
+  links?: Links;
+  credits?: ArtistCredit[];
+  /** Full credit as printed, join phrases included ("JAY Z & Kanye West"). */
+  artistCredit?: string;
+  productionCredits?: ProductionCredit[];
+  editions?: Edition[];
+  charts?: ChartPosition[];
+  certifications?: Certification[];
+  awards?: Award[];
+  warnings?: Warning[];
+}
+
+/** Totals derived from track lengths; averages cover only the timed tracks. */
+export interface AlbumRuntime {
+  trackCount: number;
+  timedTracks: number;
+  totalMs: number;
+  total?: string;
+  averageTrackMs?: number;
+  averageTrack?: string;
+}
+
+/** Producer or engineer credit; tracks is absent when the role covers the whole album. */
+export interface ProductionCredit {
+  artistId: string;
+  name: string;
+  role: string;
+  tracks?: number[];
+}
+
+/** Sales award such as "3× Platinum"; date is the year it was certified when known. */
+export interface Certification {
+  region: string;
+  country?: string;
+  body?: string;
+  level: string;
+  date: string;
+}
+
+/** Peak position on one chart, parsed from the album's Wikipedia article. */
+export interface ChartPosition {
+  chart: string;
+  country?: string;
+  peak: number;
+}
+
+export interface Edition {
+  releaseId: string;
+  title: string;
+  status?: string;
+  format?: string;
+  country?: string;
+  date: string;
+  label?: string;
+  labelId?: string;
+  catalogNumber?: string;
+  barcode?: string;
+  trackCount?: number;
+}
+
+export interface Label {
+  id: string;
+  name: string;
+  type?: string;
+  country?: string;
+  disambiguation?: string;
+  labelCode?: number;
+  lifeSpan: LifeSpan;
+  links?: Links;
+  albums: Album[] | null;
+}
+
+export interface ArtistCredit {
+  artistId: string;
+  name: string;
+  joinPhrase?: string;
+}
+
+/** Service key (homepage, bandcamp, spotify, discogs, lastfm, youtube, ...) to URL. */
+export type Links = Record<string, string>;
+
+/** Listener and play counts from Last.fm. */
+export interface ListeningStats {
+  listeners: number;
+  playcount: number;
+  source: string;
+  url?: string;
+}
+
+export interface Marketplace {
+  currency?: string;
+  lowestPrice?: number;
+  medianPrice?: number;
+  numForSale: number;
+  blockedFromSale?: boolean;
+  url: string;
+}
+
+export interface AggregateRating {
+  score: number;
+  count: number;
+  sources: string[];
+}
+
+export interface Image {
+  type: 'front' | 'back' | 'logo' | 'banner' | 'photo' | string;
+  url: string;
+  width?: number;
+  height?: number;
+  source: string;
+  colors?: ImageColors;
+  blurhash?: string;
+}
+
+export interface ImageColors {
+  dominant: string;
+  accent?: string;
 }
 
 export interface Track {
   number: number;
   title: string;
-  length: string;
+  lengthMs: number;
+  length?: string;
+  recordingId?: string;
+  credits?: ArtistCredit[];
+  artistCredit?: string;
+}
+
+/** The recording behind an album track, served by GET /tracks/{recordingId}. */
+export interface Recording {
+  id: string;
+  title: string;
+  disambiguation?: string;
+  lengthMs: number;
+  isrcs: string[];
+  firstReleaseDate?: string;
+  credits: ArtistCredit[];
+  artistCredit: string;
+}
+
+/** Song lineage for one recording: what it covers, who covered it, and sampling links. */
+export interface RecordingRelationships {
+  id: string;
+  title: string;
+  artistName?: string;
+  cover: boolean;
+  coverOf: RelatedRecording[];
+  coveredBy: RelatedRecording[];
+  samples: RelatedRecording[];
+  sampledBy: RelatedRecording[];
+}
+
+export interface RelatedRecording {
+  id: string;
+  title: string;
+  artistName?: string;
+  workId?: string;
+  workTitle?: string;
+  date?: string;
 }
 
 export interface Review {
@@ -42,6 +331,7 @@ export interface Review {
   summary: string;
   text: string;
   url: string;
+  language?: string;
 }
 
 export interface LifeSpan {
@@ -6,6 +6,7 @@ export interface Artist {
   disambiguation?: string;
   aliases?: string[];
   lifeSpan: LifeSpan;
+  score?: number;
 }
 
 export interface LifeSpan {
@@ -18,6 +19,8 @@ export interface SearchResult {
   artists: Artist[];
   offset: number;
   count: number;
+  /** Close artist names, present when nothing matched convincingly. */
+  didYouMean?: string[];
 }
 
 export interface SearchParams {
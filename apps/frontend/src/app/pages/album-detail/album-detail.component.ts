@@ -3,7 +3,8 @@ import { CommonModule } from '@angular/common';
 import { ActivatedRoute, Router, RouterLink } from '@angular/router';
 import { Subject, takeUntil, switchMap, EMPTY } from 'rxjs';
 import { AlbumService } from '../../services/album.service';
-import { Album } from '../../models/artist.models';
+import { RecordingService } from '../../services/recording.service';
+import { Album, RecordingRelationships, Track } from '../../models/artist.models';
 
 @Component({
   selector: 'app-album-detail',
@@ -16,12 +17,15 @@ export class AlbumDetailComponent implements OnInit, OnDestroy {
   album: Album | null = null;
   isLoading = false;
   error: string | null = null;
+  lineage: Record<string, RecordingRelationships | null> = {};
+  expandedRecordingId: string | null = null;
   private destroy$ = new Subject<void>();
 
   constructor(
     private route: ActivatedRoute,
     private router: Router,
-    private albumService: AlbumService
+    private albumService: AlbumService,
+    private recordingService: RecordingService
   ) {}
 
   ngOnInit(): void {
@@ -73,6 +77,50 @@ export class AlbumDetailComponent implements OnInit, OnDestroy {
     return 'Unknown';
   }
 
+  // Tints the header with the cover's colors when the backend analyzed it.
+  getHeaderBackground(): string | null {
+    const colors = this.album?.images?.[0]?.colors;
+    if (!colors) {
+      return null;
+    }
+    return `linear-gradient(135deg, ${colors.dominant}66, ${colors.accent ?? colors.dominant}26)`;
+  }
+
+  toggleLineage(track: Track): void {
+    const recordingId = track.recordingId;
+    if (!recordingId) {
+      return;
+    }
+    if (this.expandedRecordingId === recordingId) {
+      this.expandedRecordingId = null;
+      return;
+    }
+    this.expandedRecordingId = recordingId;
+    if (recordingId in this.lineage) {
+      return;
+    }
+    this.lineage[recordingId] = null;
+    this.recordingService
+      .getRelationships(recordingId)
+      .pipe(takeUntil(this.destroy$))
+      .subscribe({
+        next: relationships => {
+          this.lineage[recordingId] = relationships;
+        },
+        error: error => {
+          console.error('Error loading recording relationships:', error);
+          delete this.lineage[recordingId];
+          this.expandedRecordingId = null;
+        }
+      });
+  }
+
+  hasLineage(relationships: RecordingRelationships): boolean {
+    return (
+      relationships.coverOf.length + relationships.coveredBy.length + relationships.samples.length + relationships.sampledBy.length > 0
+    );
+  }
+
   trackByTrackNumber(index: number, track: any): number {
     return track.number;
   }
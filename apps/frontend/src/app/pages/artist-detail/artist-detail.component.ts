@@ -3,7 +3,7 @@ import { CommonModule } from '@angular/common';
 import { ActivatedRoute, Router, RouterLink } from '@angular/router';
 import { Subject, takeUntil, switchMap, EMPTY } from 'rxjs';
 import { ArtistService } from '../../services/artist.service';
-import { Artist } from '../../models/artist.models';
+import { Artist, CollaborationEdge, RelatedArtist } from '../../models/artist.models';
 
 @Component({
   selector: 'app-artist-detail',
@@ -14,6 +14,7 @@ import { Artist } from '../../models/artist.models';
 })
 export class ArtistDetailComponent implements OnInit, OnDestroy {
   artist: Artist | null = null;
+  collaborations: CollaborationEdge[] = [];
   isLoading = false;
   error: string | null = null;
   private destroy$ = new Subject<void>();
@@ -42,6 +43,7 @@ export class ArtistDetailComponent implements OnInit, OnDestroy {
         next: (artist: Artist) => {
           this.artist = artist;
           this.isLoading = false;
+          this.loadCollaborations(artist.id);
         },
         error: (error: any) => {
           console.error('Error loading artist:', error);
@@ -51,6 +53,21 @@ export class ArtistDetailComponent implements OnInit, OnDestroy {
       });
   }
 
+  private loadCollaborations(artistId: string): void {
+    this.collaborations = [];
+    this.artistService
+      .getCollaborations(artistId)
+      .pipe(takeUntil(this.destroy$))
+      .subscribe({
+        next: graph => {
+          this.collaborations = graph.edges;
+        },
+        error: (error: any) => {
+          console.error('Error loading collaborations:', error);
+        }
+      });
+  }
+
   ngOnDestroy(): void {
     this.destroy$.next();
     this.destroy$.complete();
@@ -92,8 +109,8 @@ export class ArtistDetailComponent implements OnInit, OnDestroy {
     return album.id;
   }
 
-  trackByRelatedId(index: number, relatedId: string): string {
-    return relatedId;
+  trackByRelatedId(index: number, related: RelatedArtist): string {
+    return related.id || related.name;
   }
 
   onAlbumClick(album: any): void {
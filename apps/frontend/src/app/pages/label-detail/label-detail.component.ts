@@ -0,0 +1,66 @@
+import { Component, OnInit, OnDestroy } from '@angular/core';
+import { CommonModule } from '@angular/common';
+import { ActivatedRoute, Router, RouterLink } from '@angular/router';
+import { Subject, takeUntil, switchMap, EMPTY } from 'rxjs';
+import { LabelService } from '../../services/label.service';
+import { Album, Label } from '../../models/artist.models';
+
+@Component({
+  selector: 'app-label-detail',
+  standalone: true,
+  imports: [CommonModule, RouterLink],
+  templateUrl: './label-detail.component.html',
+  styleUrl: './label-detail.component.css'
+})
+export class LabelDetailComponent implements OnInit, OnDestroy {
+  label: Label | null = null;
+  isLoading = false;
+  error: string | null = null;
+  private destroy$ = new Subject<void>();
+
+  constructor(
+    private route: ActivatedRoute,
+    private router: Router,
+    private labelService: LabelService
+  ) {}
+
+  ngOnInit(): void {
+    this.route.paramMap
+      .pipe(
+        takeUntil(this.destroy$),
+        switchMap(params => {
+          const labelId = params.get('id');
+          if (labelId) {
+            this.isLoading = true;
+            this.error = null;
+            return this.labelService.getLabel(labelId);
+          }
+          return EMPTY;
+        })
+      )
+      .subscribe({
+        next: (label: Label) => {
+          this.label = label;
+          this.isLoading = false;
+        },
+        error: (error: any) => {
+          console.error('Error loading label:', error);
+          this.error = 'Failed to load label information.';
+          this.isLoading = false;
+        }
+      });
+  }
+
+  ngOnDestroy(): void {
+    this.destroy$.next();
+    this.destroy$.complete();
+  }
+
+  goBack(): void {
+    this.router.navigate(['/']);
+  }
+
+  trackByAlbumId(index: number, album: Album): string {
+    return album.id;
+  }
+}
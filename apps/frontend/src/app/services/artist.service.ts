@@ -1,7 +1,7 @@
 import { Injectable } from '@angular/core';
 import { HttpClient } from '@angular/common/http';
 import { Observable } from 'rxjs';
-import { Artist } from '../models/artist.models';
+import { Artist, CollaborationGraph } from '../models/artist.models';
 
 @Injectable({
   providedIn: 'root'
@@ -14,4 +14,8 @@ export class ArtistService {
   getArtist(id: string): Observable<Artist> {
     return this.http.get<Artist>(`${this.apiUrl}/artists/${id}`);
   }
+
+  getCollaborations(id: string): Observable<CollaborationGraph> {
+    return this.http.get<CollaborationGraph>(`${this.apiUrl}/artists/${id}/collaborations`);
+  }
 }
\ No newline at end of file
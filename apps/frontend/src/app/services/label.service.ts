@@ -0,0 +1,17 @@
+import { Injectable } from '@angular/core';
+import { HttpClient } from '@angular/common/http';
+import { Observable } from 'rxjs';
+import { Label } from '../models/artist.models';
+
+@Injectable({
+  providedIn: 'root'
+})
+export class LabelService {
+  private apiUrl = 'http://localhost:8080';
+
+  constructor(private http: HttpClient) {}
+
+  getLabel(id: string): Observable<Label> {
+    return this.http.get<Label>(`${this.apiUrl}/labels/${id}`);
+  }
+}
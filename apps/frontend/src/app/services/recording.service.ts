@@ -0,0 +1,21 @@
+import { Injectable } from '@angular/core';
+import { HttpClient } from '@angular/common/http';
+import { Observable } from 'rxjs';
+import { Recording, RecordingRelationships } from '../models/artist.models';
+
+@Injectable({
+  providedIn: 'root'
+})
+export class RecordingService {
+  private apiUrl = 'http://localhost:8080';
+
+  constructor(private http: HttpClient) {}
+
+  getTrack(id: string): Observable<Recording> {
+    return this.http.get<Recording>(`${this.apiUrl}/tracks/${id}`);
+  }
+
+  getRelationships(id: string): Observable<RecordingRelationships> {
+    return this.http.get<RecordingRelationships>(`${this.apiUrl}/recordings/${id}/relationships`);
+  }
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/config"
+)
+
+// runConfigCheck validates the environment the way the server would at startup and reports
+// every problem at once, exiting non-zero if there are any. Nothing is opened or dialled, so
+// it is safe to run in a deploy pipeline before the new version starts.
+func runConfigCheck() {
+	if _, err := config.Load(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println("configuration ok")
+}
+
+// runConfig handles `config show`, which prints the effective configuration with credentials
+// redacted, and `config check`, an alias for config-check.
+func runConfig(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: config show|check")
+		os.Exit(2)
+	}
+	switch args[0] {
+	case "check":
+		runConfigCheck()
+	case "show":
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(cfg.Redacted()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown config command %q; usage: config show|check\n", args[0])
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package main
+
+import (
+	"context"
+	"log/slog"
+)
+
+// cycleLogLevelOnSignal does nothing where SIGUSR1 doesn't exist; PUT /admin/loglevel still
+// changes the level.
+func cycleLogLevelOnSignal(ctx context.Context, level *slog.LevelVar) {}
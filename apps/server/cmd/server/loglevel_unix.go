@@ -0,0 +1,32 @@
+//go:build unix
+
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+)
+
+// cycleLogLevelOnSignal steps the log level through debug, info, warn, and error on each
+// SIGUSR1 (kill -USR1 <pid>), for hosts where the admin API isn't reachable.
+func cycleLogLevelOnSignal(ctx context.Context, level *slog.LevelVar) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			next := logging.NextLevel(level.Level())
+			level.Set(next)
+			slog.Log(ctx, max(next, slog.LevelInfo), "log level changed by SIGUSR1", "level", next)
+		}
+	}
+}
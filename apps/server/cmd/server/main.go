@@ -2,24 +2,63 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/adamlacasse/freq-show/apps/server/pkg/api"
 	"github.com/adamlacasse/freq-show/apps/server/pkg/config"
 	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/chaos"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/coverart"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpcache"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/images"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/lastfm"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/localfiles"
 	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
 	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/reviews"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/spotify"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/upstreamlog"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/wikidata"
 	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/wikipedia"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/wikitext"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/useragent"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/webhook"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "mockupstream":
+			runMockUpstream(os.Args[2:])
+			return
+		case "config-check":
+			runConfigCheck()
+			return
+		case "config":
+			runConfig(os.Args[2:])
+			return
+		}
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("config load failed: %v", err)
 	}
+	// From here on the standard log package writes through this handler too, so the remaining
+	// log.Fatalf calls share the configured format. The level stays adjustable at runtime.
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(cfg.LogLevel)
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogFormat, logLevel))
 
 	baseCtx := context.Background()
 
@@ -29,6 +68,12 @@ func main() {
 		store, err = db.NewMemoryStore(baseCtx)
 	case "sqlite":
 		store, err = db.NewSQLiteStore(baseCtx, cfg.Database.URL)
+	case "postgres":
+		store, err = db.NewPostgresStore(baseCtx, cfg.Database.URL, db.PoolConfig{
+			MaxOpenConns:    cfg.Database.MaxOpenConns,
+			MaxIdleConns:    cfg.Database.MaxIdleConns,
+			ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		})
 	default:
 		log.Fatalf("unsupported database driver: %s", cfg.Database.Driver)
 	}
@@ -37,53 +82,326 @@ func main() {
 	}
 	defer func() {
 		if err := store.Close(context.Background()); err != nil {
-			log.Printf("store close failed: %v", err)
+			slog.Error("store close failed", "error", err)
 		}
 	}()
 
+	upstreamlog.SetEnabled(cfg.UpstreamDebug)
+
+	// Fault injection is development-only; config refuses to enable it anywhere else.
+	if cfg.Chaos.Enabled {
+		chaos.Configure(chaos.Config{
+			Enabled:     true,
+			Latency:     cfg.Chaos.Latency,
+			LatencyRate: cfg.Chaos.LatencyRate,
+			ErrorRate:   cfg.Chaos.ErrorRate,
+			ErrorStatus: cfg.Chaos.ErrorStatus,
+			RetryAfter:  cfg.Chaos.RetryAfter,
+			Sources:     cfg.Chaos.Sources,
+		})
+		slog.Warn("chaos enabled",
+			"latency", cfg.Chaos.Latency, "latency_rate", cfg.Chaos.LatencyRate,
+			"error_status", cfg.Chaos.ErrorStatus, "error_rate", cfg.Chaos.ErrorRate)
+	}
+
+	// Upstream responses are cached on disk when configured so restarts keep warm caches.
+	var responseCache httpcache.Cache
+	if cfg.HTTPCacheDir != "" {
+		diskCache, err := httpcache.NewDiskCache(cfg.HTTPCacheDir)
+		if err != nil {
+			log.Fatalf("http cache init failed: %v", err)
+		}
+		responseCache = diskCache
+	}
+
+	// Every upstream sees the same app name, build version, and contact unless a source overrides it.
+	userAgent := useragent.Info{
+		Name:    cfg.MusicBrainz.AppName,
+		Version: cfg.MusicBrainz.AppVersion,
+		Contact: cfg.MusicBrainz.Contact,
+	}.String()
+
+	mbValidation, err := musicbrainz.ParseValidationMode(cfg.MusicBrainz.Validation)
+	if err != nil {
+		log.Fatalf("musicbrainz config invalid: %v", err)
+	}
+
 	mbClient, err := musicbrainz.New(baseCtx, musicbrainz.Config{
 		BaseURL:    cfg.MusicBrainz.BaseURL,
 		AppName:    cfg.MusicBrainz.AppName,
 		AppVersion: cfg.MusicBrainz.AppVersion,
 		Contact:    cfg.MusicBrainz.Contact,
-		Timeout:    cfg.MusicBrainz.Timeout,
+		Validation: mbValidation,
+		HTTP:       clientOptions(cfg.MusicBrainz.SourceConfig, responseCache),
 	})
 	if err != nil {
 		log.Fatalf("musicbrainz client init failed: %v", err)
 	}
 
+	wikidataClient, err := wikidata.New(baseCtx, wikidata.Config{
+		BaseURL:   cfg.Wikidata.BaseURL,
+		UserAgent: userAgent,
+		HTTP:      clientOptions(cfg.Wikidata.SourceConfig, responseCache),
+	})
+	if err != nil {
+		log.Fatalf("wikidata client init failed: %v", err)
+	}
+
+	// The summary and wikitext clients both call Wikipedia, so they draw on one rate budget.
+	wikipediaHTTP := clientOptions(cfg.Wikipedia.SourceConfig, responseCache)
+	wikipediaHTTP.Limiter = httpclient.NewLimiter(cfg.Wikipedia.RateLimit)
+
 	wikiClient, err := wikipedia.New(baseCtx, wikipedia.Config{
 		BaseURL:   cfg.Wikipedia.BaseURL,
-		UserAgent: cfg.Wikipedia.UserAgent,
-		Timeout:   cfg.Wikipedia.Timeout,
+		ActionURL: cfg.Wikipedia.ActionURL,
+		Sitelinks: wikidataClient,
+		UserAgent: firstNonEmpty(cfg.Wikipedia.UserAgent, userAgent),
+		HTTP:      wikipediaHTTP,
 	})
 	if err != nil {
 		log.Fatalf("wikipedia client init failed: %v", err)
 	}
 
+	wikitextClient, err := wikitext.New(baseCtx, wikitext.Config{
+		BaseURL:   cfg.Wikipedia.SourceURL,
+		UserAgent: firstNonEmpty(cfg.Wikipedia.UserAgent, userAgent),
+		HTTP:      wikipediaHTTP,
+	})
+	if err != nil {
+		log.Fatalf("wikitext client init failed: %v", err)
+	}
+
 	reviewsClient := reviews.NewClient(reviews.Config{
-		UserAgent:             cfg.Reviews.UserAgent,
-		Timeout:               cfg.Reviews.Timeout,
+		UserAgent:             firstNonEmpty(cfg.Reviews.UserAgent, userAgent),
 		DiscogsToken:          cfg.Reviews.DiscogsToken,
 		DiscogsConsumerKey:    cfg.Reviews.DiscogsConsumerKey,
 		DiscogsConsumerSecret: cfg.Reviews.DiscogsConsumerSecret,
+		DiscogsBaseURL:        cfg.Reviews.DiscogsBaseURL,
+		HTTP:                  clientOptions(cfg.Reviews.SourceConfig, responseCache),
 	})
 
-	router := api.NewRouter(api.RouterConfig{
+	coverArtClient, err := coverart.New(baseCtx, coverart.Config{
+		BaseURL:   cfg.CoverArt.BaseURL,
+		UserAgent: userAgent,
+		HTTP:      clientOptions(cfg.CoverArt.SourceConfig, responseCache),
+	})
+	if err != nil {
+		log.Fatalf("cover art client init failed: %v", err)
+	}
+
+	// Images fall back through sources in priority order: the Cover Art Archive is keyed by
+	// release group ID so it is tried before Discogs' fuzzy artist/title search.
+	imageChain := images.NewChain(
+		[]images.ArtistSource{wikiClient},
+		[]images.AlbumSource{coverArtClient, reviewsClient},
+	)
+	if cfg.ImageAnalysis.Active() {
+		// Image bytes stay out of the response cache; only what is derived from them is kept.
+		imageChain.WithAnalyzer(images.NewAnalyzer(images.AnalyzerConfig{
+			UserAgent: userAgent,
+			HTTP:      httpclient.Options{Timeout: cfg.ImageAnalysis.Timeout},
+			Colors:    cfg.ImageAnalysis.Colors,
+			BlurHash:  cfg.ImageAnalysis.BlurHash,
+		}))
+	}
+
+	var imageProxy api.ImageProxy
+	if cfg.ImageProxy.Enabled {
+		// Variants go to S3 when a bucket is configured, so every instance shares them, and
+		// otherwise to a local directory when one is set.
+		var cache images.VariantCache
+		var err error
+		switch s3 := cfg.ImageProxy.S3; {
+		case s3.Bucket != "":
+			cache, err = images.NewS3Cache(images.S3Config{
+				Bucket:          s3.Bucket,
+				Region:          s3.Region,
+				Endpoint:        s3.Endpoint,
+				Prefix:          s3.Prefix,
+				AccessKeyID:     s3.AccessKeyID,
+				SecretAccessKey: s3.SecretAccessKey,
+				SessionToken:    s3.SessionToken,
+			})
+		case cfg.ImageProxy.CacheDir != "":
+			cache, err = images.NewDiskCache(cfg.ImageProxy.CacheDir)
+		}
+		if err != nil {
+			log.Fatalf("image proxy init failed: %v", err)
+		}
+		imageProxy = images.NewProxy(images.ProxyConfig{
+			UserAgent: userAgent,
+			HTTP:      httpclient.Options{Timeout: cfg.ImageProxy.Timeout},
+			Hosts:     cfg.ImageProxy.Hosts,
+			Cache:     cache,
+		})
+	}
+
+	// Disabled sources stay wired in but report unhealthy, so callers skip them; readiness
+	// leaves them out rather than calling the service degraded.
+	dependencies := []api.Dependency{{Name: "musicbrainz", Required: true, Checker: mbClient}}
+	for _, dep := range []struct {
+		api.Dependency
+		enabled bool
+	}{
+		{api.Dependency{Name: "wikipedia", Checker: wikiClient}, cfg.Wikipedia.Enabled},
+		{api.Dependency{Name: "wikitext", Checker: wikitextClient}, cfg.Wikipedia.Enabled},
+		{api.Dependency{Name: "wikidata", Checker: wikidataClient}, cfg.Wikidata.Enabled},
+		{api.Dependency{Name: "discogs", Checker: reviewsClient}, cfg.Reviews.Enabled},
+		{api.Dependency{Name: "coverartarchive", Checker: coverArtClient}, cfg.CoverArt.Enabled},
+	} {
+		if dep.enabled {
+			dependencies = append(dependencies, dep.Dependency)
+		}
+	}
+
+	// Spotify is optional; playlist import responds 503 when it is disabled or credentials are absent.
+	var spotifyClient api.SpotifyClient
+	if cfg.Spotify.Active() {
+		client, err := spotify.New(baseCtx, spotify.Config{
+			BaseURL:      cfg.Spotify.BaseURL,
+			AuthURL:      cfg.Spotify.AuthURL,
+			ClientID:     cfg.Spotify.ClientID,
+			ClientSecret: cfg.Spotify.ClientSecret,
+			UserAgent:    userAgent,
+			HTTP:         clientOptions(cfg.Spotify.SourceConfig, nil),
+		})
+		if err != nil {
+			log.Fatalf("spotify client init failed: %v", err)
+		}
+		spotifyClient = client
+		dependencies = append(dependencies, api.Dependency{Name: "spotify", Checker: client})
+	}
+
+	// Marketplace stats cost a Discogs request or two per album, so they are opt-in.
+	var marketplaceClient api.MarketplaceClient
+	if cfg.Reviews.Marketplace {
+		marketplaceClient = reviewsClient
+	}
+
+	// Last.fm is optional and needs an API key; without one artists and albums simply go
+	// without listening stats.
+	var lastfmClient api.LastfmClient
+	if cfg.Lastfm.Active() {
+		client, err := lastfm.New(baseCtx, lastfm.Config{
+			BaseURL:   cfg.Lastfm.BaseURL,
+			APIKey:    cfg.Lastfm.APIKey,
+			UserAgent: userAgent,
+			HTTP:      clientOptions(cfg.Lastfm.SourceConfig, responseCache),
+		})
+		if err != nil {
+			log.Fatalf("lastfm client init failed: %v", err)
+		}
+		lastfmClient = client
+		dependencies = append(dependencies, api.Dependency{Name: "lastfm", Checker: client})
+	}
+
+	var libraryScanner api.LibraryScanner
+	if cfg.Library.Path != "" {
+		scanner, err := localfiles.New(localfiles.Config{Root: cfg.Library.Path})
+		if err != nil {
+			log.Fatalf("library scanner init failed: %v", err)
+		}
+		libraryScanner = scanner
+	}
+
+	ratingWebhooks := webhook.New(webhook.Config{
+		URLs:      cfg.RatingWebhooks.URLs,
+		Secret:    cfg.RatingWebhooks.Secret,
+		UserAgent: userAgent,
+	})
+	ratingEvents := service.RatingEvents{Threshold: cfg.RatingWebhooks.Threshold}
+	var ratingNotifier api.RatingNotifier
+	if ratingWebhooks != nil {
+		ratingNotifier = ratingWebhooks
+		ratingEvents.Notifier = ratingWebhooks
+	}
+
+	// The re-enrichment job is shared by the admin trigger and the background schedule so only
+	// one run proceeds at a time.
+	reenricher := service.NewReenricher(service.Deps{
+		Artists:     store,
+		Albums:      store,
 		MusicBrainz: mbClient,
 		Wikipedia:   wikiClient,
 		Reviews:     reviewsClient,
-		Artists:     store,
-		Albums:      store,
+		Images:      imageChain,
+		Lastfm:      lastfmClient,
+
+		RatingEvents:    ratingEvents,
+		MaxArtistAlbums: cfg.MaxArtistAlbums,
+	}, store, cfg.Reenrich.Delay)
+
+	router := api.NewRouter(api.RouterConfig{
+		MusicBrainz:   mbClient,
+		Wikipedia:     wikiClient,
+		AlbumFacts:    wikitextClient,
+		Awards:        wikidataClient,
+		Reviews:       reviewsClient,
+		Spotify:       spotifyClient,
+		Images:        imageChain,
+		Library:       libraryScanner,
+		Marketplace:   marketplaceClient,
+		Lastfm:        lastfmClient,
+		Artists:       store,
+		Albums:        store,
+		Labels:        store,
+		Playlists:     store,
+		Owned:         store,
+		Aliases:       store,
+		LocalSearch:   store,
+		AlbumBrowser:  store,
+		ArtistBrowser: store,
+		Modified:      store,
+		Cache:         store,
+		Records:       store,
+		Merger:        store,
+		Reenricher:    reenricher,
+
+		RatingNotifier:        ratingNotifier,
+		RatingChangeThreshold: cfg.RatingWebhooks.Threshold,
+		ImageProxy:            imageProxy,
+
+		RequestLog: api.RequestLogConfig{
+			SampleRate:    cfg.LogSampleRate,
+			SlowThreshold: cfg.SlowRequest,
+		},
+		Deadlines: api.RouteDeadlines{
+			Read:   cfg.Deadlines.Read,
+			Enrich: cfg.Deadlines.Enrich,
+			Batch:  cfg.Deadlines.Batch,
+		},
+		CacheControl: api.CachePolicies{
+			Entity:  cfg.CacheControl.Entity,
+			Listing: cfg.CacheControl.Listing,
+		},
+		ClientRateLimit: api.ClientRateLimit{
+			RPS:      cfg.ClientRateLimit.RPS,
+			Burst:    cfg.ClientRateLimit.Burst,
+			IPHeader: cfg.ClientRateLimit.IPHeader,
+		},
+		CacheTTL: api.CacheTTLs{
+			Artists:  cfg.CacheTTL.Artists,
+			Albums:   cfg.CacheTTL.Albums,
+			Reviews:  cfg.CacheTTL.Reviews,
+			Searches: cfg.CacheTTL.Searches,
+			NotFound: cfg.CacheTTL.NotFound,
+		},
+		EnrichmentBudget: cfg.EnrichmentBudget,
+		MaxArtistAlbums:  cfg.MaxArtistAlbums,
+		AdminToken:       cfg.AdminToken,
+		Auth:             authConfig(cfg.Auth),
+		LogLevel:         logLevel,
+		EffectiveConfig:  cfg.Redacted(),
+		BasePath:         cfg.HTTP.BasePath,
+		AllowedHosts:     cfg.HTTP.AllowedHosts,
+		CORSOrigins:      cfg.HTTP.CORSOrigins,
+		Dependencies:     dependencies,
 	})
 
-	srv := &http.Server{
-		Addr:    cfg.Address(),
-		Handler: router,
-	}
+	srv := newHTTPServer(cfg, router)
 
 	go func() {
-		log.Printf("freqshow backend listening on %s (env=%s)", srv.Addr, cfg.Env)
+		slog.Info("freqshow backend listening", "version", useragent.Version, "addr", srv.Addr, "env", cfg.Env)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("server error: %v", err)
 		}
@@ -91,13 +409,120 @@ func main() {
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
+
+	go purgeTombstones(ctx, store, cfg.TombstoneRetention)
+	go cycleLogLevelOnSignal(ctx, logLevel)
+	if cfg.Reenrich.Interval > 0 {
+		go reenrichPeriodically(ctx, reenricher, cfg.Reenrich)
+	}
 	<-ctx.Done()
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
 	if err := srv.Shutdown(shutdownCtx); err != nil {
-		log.Printf("graceful shutdown failed: %v", err)
+		slog.Error("graceful shutdown failed", "error", err)
+	}
+	if ratingWebhooks != nil {
+		if err := ratingWebhooks.Wait(shutdownCtx); err != nil {
+			slog.Warn("pending rating webhooks abandoned", "error", err)
+		}
+	}
+	slog.Info("freqshow backend exiting")
+}
+
+// purgeTombstones permanently removes invalidated records once they outlive the retention
+// window, checking hourly until ctx is cancelled.
+func purgeTombstones(ctx context.Context, store db.CacheInvalidator, retention time.Duration) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		purged, err := store.PurgeTombstones(ctx, time.Now().Add(-retention))
+		if err != nil {
+			slog.Error("tombstone purge failed", "error", err)
+		} else if purged > 0 {
+			slog.Info("purged tombstoned records", "count", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reenrichPeriodically fills fields missing from a batch of cached records each interval,
+// starting one interval after boot so startup traffic is not competing with it.
+func reenrichPeriodically(ctx context.Context, reenricher *service.Reenricher, cfg config.ReenrichConfig) {
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		run, err := reenricher.Run(ctx, service.ReenrichRequest{Limit: cfg.BatchSize})
+		switch {
+		case errors.Is(err, service.ErrJobRunning):
+			slog.Info("scheduled re-enrichment skipped: a run is already in progress")
+		case err != nil:
+			slog.Error("scheduled re-enrichment failed", "error", err)
+		case run.Filled > 0 || run.Failed > 0:
+			slog.Info("re-enrichment finished", "scanned", run.Scanned, "filled", run.Filled, "failed", run.Failed)
+		}
+	}
+}
+
+// newHTTPServer applies the connection limits from cfg, so slow clients can't hold
+// connections open indefinitely, and enables h2c when configured.
+func newHTTPServer(cfg *config.Config, handler http.Handler) *http.Server {
+	srv := &http.Server{
+		Addr:              cfg.Address(),
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+		MaxHeaderBytes:    cfg.HTTP.MaxHeaderBytes,
+	}
+	if cfg.HTTP.H2C {
+		srv.Protocols = new(http.Protocols)
+		srv.Protocols.SetHTTP1(true)
+		srv.Protocols.SetUnencryptedHTTP2(true)
+	}
+	return srv
+}
+
+// clientOptions maps a source's config block onto the options every source client accepts.
+func clientOptions(src config.SourceConfig, cache httpcache.Cache) httpclient.Options {
+	return httpclient.Options{
+		Timeout: src.Timeout,
+		Retry: retry.Policy{
+			MaxAttempts: src.Retry.MaxAttempts,
+			BaseDelay:   src.Retry.BaseDelay,
+			MaxDelay:    src.Retry.MaxDelay,
+		},
+		RateLimit: src.RateLimit,
+		Cache:     cache,
+		Disabled:  !src.Enabled,
+	}
+}
+
+// authConfig maps the configured API keys onto the router's auth settings.
+func authConfig(auth config.AuthConfig) api.AuthConfig {
+	keys := make([]api.APIKey, 0, len(auth.Keys))
+	for _, key := range auth.Keys {
+		keys = append(keys, api.APIKey{Name: key.Name, Key: key.Key, Enabled: key.Enabled})
+	}
+	return api.AuthConfig{Keys: keys, PublicPaths: auth.PublicPaths}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
 	}
-	log.Println("freqshow backend exiting")
+	return ""
 }
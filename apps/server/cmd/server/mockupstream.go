@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"log/slog"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/mockupstream"
+)
+
+// runMockUpstream serves fixture MusicBrainz, Wikipedia, and Discogs endpoints for offline
+// development until interrupted.
+func runMockUpstream(args []string) {
+	flags := flag.NewFlagSet("mockupstream", flag.ExitOnError)
+	addr := flags.String("addr", "127.0.0.1:8090", "listen address")
+	latency := flags.Duration("latency", 0, "delay added to every response")
+	jitter := flags.Duration("jitter", 0, "random extra delay, up to this much, added to every response")
+	errorRate := flags.Float64("error-rate", 0, "fraction of requests (0-1) answered with -error-status")
+	errorStatus := flags.Int("error-status", http.StatusServiceUnavailable, "status returned for injected failures")
+	retryAfter := flags.Duration("retry-after", 0, "Retry-After sent with injected 429 and 503 responses")
+	_ = flags.Parse(args)
+
+	if *errorRate < 0 || *errorRate > 1 {
+		log.Fatalf("mockupstream: -error-rate must be between 0 and 1, got %v", *errorRate)
+	}
+
+	handler, err := mockupstream.NewHandler(mockupstream.Config{
+		Latency:     *latency,
+		Jitter:      *jitter,
+		ErrorRate:   *errorRate,
+		ErrorStatus: *errorStatus,
+		RetryAfter:  *retryAfter,
+	})
+	if err != nil {
+		log.Fatalf("mockupstream init failed: %v", err)
+	}
+
+	srv := &http.Server{Addr: *addr, Handler: handler}
+	go func() {
+		base := "http://" + *addr
+		// The attributes double as the settings that point the server at this mock.
+		slog.Info("freqshow mock upstream listening",
+			"addr", *addr,
+			"MUSICBRAINZ_BASE_URL", base+mockupstream.MusicBrainzPrefix,
+			"WIKIPEDIA_BASE_URL", base+mockupstream.WikipediaPrefix,
+			"REVIEWS_DISCOGS_BASE_URL", base+mockupstream.DiscogsPrefix)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("mockupstream server error: %v", err)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("mockupstream shutdown failed", "error", err)
+	}
+}
@@ -1,8 +1,12 @@
 module github.com/adamlacasse/freq-show/apps/server
 
-go 1.22
+go 1.24
 
-require modernc.org/sqlite v1.28.0
+require (
+	github.com/lib/pq v1.10.9
+	golang.org/x/image v0.25.0
+	modernc.org/sqlite v1.28.0
+)
 
 require (
 	github.com/dustin/go-humanize v1.0.1 // indirect
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/upstreamlog"
+)
+
+type upstreamDebugRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+type upstreamDebugResponse struct {
+	Enabled bool `json:"enabled"`
+}
+
+type logLevelRequest struct {
+	Level string `json:"level"`
+}
+
+type logLevelResponse struct {
+	Level string `json:"level"`
+}
+
+type artistInvalidationResponse struct {
+	ArtistID string `json:"artistId"`
+	db.Invalidation
+}
+
+// adminMiddleware protects operational endpoints. With a token configured, requests must send
+// it as a bearer token. Without one, only loopback clients are accepted, and only to read:
+// behind a reverse proxy on the same host every request arrives from loopback, so deletes,
+// merges, and other changes always need the token.
+func adminMiddleware(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" {
+			supplied, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(supplied), []byte(token)) != 1 {
+				writeJSON(w, http.StatusUnauthorized, errorResponse{"admin token required"})
+				return
+			}
+		} else if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			writeJSON(w, http.StatusForbidden, errorResponse{"admin changes require ADMIN_TOKEN to be configured"})
+			return
+		} else if !isLoopback(r.RemoteAddr) {
+			writeJSON(w, http.StatusForbidden, errorResponse{"admin endpoints are restricted to localhost"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isLoopback(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// upstreamDebugHandler reports (GET) or toggles (PUT) upstream request logging.
+func upstreamDebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var body upstreamDebugRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Enabled == nil {
+				writeJSON(w, http.StatusBadRequest, errorResponse{"request body must include 'enabled'"})
+				return
+			}
+			upstreamlog.SetEnabled(*body.Enabled)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, upstreamDebugResponse{Enabled: upstreamlog.Enabled()})
+	})
+}
+
+// logLevelHandler reports (GET) or changes (PUT) the minimum log level for every logger at
+// once, so production can be debugged without a restart. The change lasts until the next
+// restart or SIGUSR1.
+func logLevelHandler(level *slog.LevelVar) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if level == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"log level is not adjustable"})
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var body logLevelRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				writeJSON(w, http.StatusBadRequest, errorResponse{"request body must include 'level'"})
+				return
+			}
+			parsed, ok := logging.ParseLevel(body.Level)
+			if !ok {
+				writeJSON(w, http.StatusBadRequest, errorResponse{"'level' must be debug, info, warn, or error"})
+				return
+			}
+			level.Set(parsed)
+			// Logged at the new level or above so the change itself always shows up.
+			logging.FromContext(r.Context()).Log(r.Context(), max(parsed, slog.LevelInfo), "log level changed", "level", parsed)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, logLevelResponse{Level: strings.ToLower(level.Level().String())})
+	})
+}
+
+// configHandler serves the configuration the server loaded at startup, with credentials
+// already redacted, so operators can confirm what a running instance picked up.
+func configHandler(effective map[string]any) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+		if effective == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"configuration unavailable"})
+			return
+		}
+		writeJSON(w, http.StatusOK, effective)
+	})
+}
+
+// artistInvalidationHandler tombstones a cached artist and its albums (DELETE) so a bad record
+// can be corrected with one call; the next lookup refetches everything from upstream. A POST to
+// .../{id}/restore undoes the invalidation until the tombstones are purged.
+func artistInvalidationHandler(invalidator db.CacheInvalidator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if invalidator == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"cache storage unavailable"})
+			return
+		}
+
+		id, err := parseResourceID(r.URL.Path, "/admin/cache/artists/", "artist id required")
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+
+		var result db.Invalidation
+		switch {
+		case r.Method == http.MethodDelete:
+			result, err = invalidator.InvalidateArtist(r.Context(), id)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/"+id+"/restore"):
+			result, err = invalidator.RestoreArtist(r.Context(), id)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"cache update failed"})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, artistInvalidationResponse{ArtistID: id, Invalidation: result})
+	})
+}
+
+// deletableEntity serves DELETE /artists/{id} and /albums/{id} with del, behind the admin
+// guard, and every other request to path with lookup.
+func deletableEntity(token string, del, lookup http.Handler) http.Handler {
+	guarded := adminMiddleware(token, cacheControlMiddleware(cacheNoStore, del))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			guarded.ServeHTTP(w, r)
+			return
+		}
+		lookup.ServeHTTP(w, r)
+	})
+}
+
+// cacheDeleteHandler evicts one cached artist or album (kind) so operators can drop a bad record
+// without touching the database; the next lookup refetches it. Like artist invalidation, the
+// record is tombstoned and listed under /admin/cache/tombstones until purged.
+func cacheDeleteHandler(invalidator db.CacheInvalidator, kind string) http.Handler {
+	prefix := "/albums/"
+	if kind == db.KindArtist {
+		prefix = "/artists/"
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodDelete) {
+			return
+		}
+		// Only the entity itself can be deleted, not a sub-resource such as /discography.
+		if strings.Contains(strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/"), "/") {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if invalidator == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"cache storage unavailable"})
+			return
+		}
+
+		id, err := parseResourceID(r.URL.Path, prefix, kind+" id required")
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+
+		var found bool
+		if kind == db.KindArtist {
+			found, err = invalidator.DeleteArtist(r.Context(), id)
+		} else {
+			found, err = invalidator.DeleteAlbum(r.Context(), id)
+		}
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"cache update failed"})
+			return
+		}
+		if !found {
+			writeJSON(w, http.StatusNotFound, errorResponse{kind + " not cached"})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// tombstonesHandler lists records hidden by invalidation, optionally only those tombstoned at
+// or after ?since=<RFC 3339 timestamp>.
+func tombstonesHandler(invalidator db.CacheInvalidator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+		if invalidator == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"cache storage unavailable"})
+			return
+		}
+
+		var since time.Time
+		if raw := r.URL.Query().Get("since"); raw != "" {
+			parsed, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, errorResponse{"'since' must be an RFC 3339 timestamp"})
+				return
+			}
+			since = parsed
+		}
+
+		tombstones, err := invalidator.ListTombstones(r.Context(), since)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"tombstone lookup failed"})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{
+			"tombstones": tombstones,
+			"count":      len(tombstones),
+		})
+	})
+}
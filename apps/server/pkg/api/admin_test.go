@@ -0,0 +1,267 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/upstreamlog"
+)
+
+func TestUpstreamDebugToggle(t *testing.T) {
+	defer upstreamlog.SetEnabled(false)
+	handler := adminMiddleware("s3cret", upstreamDebugHandler())
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/debug/upstream", strings.NewReader(`{"enabled":true}`))
+	req.Header.Set("Authorization", "Bearer s3cret")
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	if !upstreamlog.Enabled() {
+		t.Error("expected upstream debug logging to be enabled")
+	}
+}
+
+func TestConfigHandlerServesEffectiveConfig(t *testing.T) {
+	router := NewRouter(RouterConfig{
+		AdminToken:      "s3cret",
+		EffectiveConfig: map[string]any{"AdminToken": "REDACTED", "Port": "8080"},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/config", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(res.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["Port"] != "8080" || body["AdminToken"] != "REDACTED" {
+		t.Errorf("unexpected config %v", body)
+	}
+	if got := res.Header().Get("Cache-Control"); got != cacheNoStore {
+		t.Errorf("expected config to be uncacheable, got %q", got)
+	}
+}
+
+func TestAdminMiddlewareRejectsMissingToken(t *testing.T) {
+	handler := adminMiddleware("s3cret", upstreamDebugHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/debug/upstream", nil)
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+
+	if res.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", res.Code)
+	}
+}
+
+func TestAdminMiddlewareWithoutTokenRequiresLoopback(t *testing.T) {
+	handler := adminMiddleware("", upstreamDebugHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/debug/upstream", nil)
+	req.RemoteAddr = "203.0.113.7:5555"
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+	if res.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for remote client, got %d", res.Code)
+	}
+
+	req.RemoteAddr = "127.0.0.1:5555"
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+}
+
+func TestAdminMiddlewareWithoutTokenRefusesChanges(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	if err := store.SaveArtist(context.Background(), &data.Artist{ID: testArtistID}); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+	router := NewRouter(RouterConfig{Cache: store})
+
+	// A reverse proxy on the same host makes every client look like loopback.
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodDelete, artistPath, nil),
+		httptest.NewRequest(http.MethodPut, "/admin/debug/upstream", strings.NewReader(`{"enabled":true}`)),
+	} {
+		req.RemoteAddr = "127.0.0.1:5555"
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		if res.Code != http.StatusForbidden {
+			t.Errorf("expected 403 for %s %s without an admin token, got %d", req.Method, req.URL.Path, res.Code)
+		}
+	}
+	if artist, _ := store.GetArtist(context.Background(), testArtistID); artist == nil {
+		t.Fatal("expected the artist to stay cached")
+	}
+}
+
+func TestArtistInvalidationHandler(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	if err := store.SaveArtist(context.Background(), &data.Artist{ID: testArtistID, Albums: []data.Album{{ID: testAlbumID}}}); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+	if err := store.SaveAlbum(context.Background(), &data.Album{ID: testAlbumID, ArtistID: testArtistID}); err != nil {
+		t.Fatalf("SaveAlbum: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/cache/artists/"+testArtistID, nil)
+	res := httptest.NewRecorder()
+	artistInvalidationHandler(store).ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var payload artistInvalidationResponse
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if payload.ArtistID != testArtistID || payload.Artists != 1 || payload.Albums != 1 {
+		t.Fatalf("unexpected invalidation response %+v", payload)
+	}
+	if album, _ := store.GetAlbum(context.Background(), testAlbumID); album != nil {
+		t.Fatalf("expected album to be invalidated")
+	}
+}
+
+func TestArtistInvalidationHandlerRestore(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	if err := store.SaveArtist(context.Background(), &data.Artist{ID: testArtistID}); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+	handler := artistInvalidationHandler(store)
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodDelete, "/admin/cache/artists/"+testArtistID, nil))
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+
+	res = httptest.NewRecorder()
+	tombstonesHandler(store).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/admin/cache/tombstones", nil))
+	if res.Code != http.StatusOK || !strings.Contains(res.Body.String(), testArtistID) {
+		t.Fatalf("expected tombstone listing to include the artist, got %d %s", res.Code, res.Body.String())
+	}
+
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/admin/cache/artists/"+testArtistID+"/restore", nil))
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	if artist, _ := store.GetArtist(context.Background(), testArtistID); artist == nil {
+		t.Fatalf("expected artist to be restored")
+	}
+
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/admin/cache/artists/"+testArtistID, nil))
+	if res.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405 for POST without restore, got %d", res.Code)
+	}
+}
+
+func TestLogLevelHandler(t *testing.T) {
+	level := new(slog.LevelVar)
+	handler := logLevelHandler(level)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/loglevel", strings.NewReader(`{"level":"debug"}`))
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+	if res.Code != http.StatusOK || !strings.Contains(res.Body.String(), `"level":"debug"`) {
+		t.Fatalf("expected the level to change, got %d %s", res.Code, res.Body.String())
+	}
+	if level.Level() != slog.LevelDebug {
+		t.Errorf("level = %v, want debug", level.Level())
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/admin/loglevel", strings.NewReader(`{"level":"chatty"}`))
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+	if res.Code != http.StatusBadRequest || level.Level() != slog.LevelDebug {
+		t.Fatalf("expected an unknown level to be rejected, got %d (level %v)", res.Code, level.Level())
+	}
+
+	res = httptest.NewRecorder()
+	logLevelHandler(nil).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/admin/loglevel", nil))
+	if res.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 without an adjustable level, got %d", res.Code)
+	}
+}
+
+func TestRouterDeletesCachedEntities(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	if err := store.SaveArtist(context.Background(), &data.Artist{ID: testArtistID, Albums: []data.Album{{ID: testAlbumID}}}); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+	if err := store.SaveAlbum(context.Background(), &data.Album{ID: testAlbumID, ArtistID: testArtistID}); err != nil {
+		t.Fatalf("SaveAlbum: %v", err)
+	}
+	router := NewRouter(RouterConfig{AdminToken: "s3cret", Cache: store})
+
+	deleteAs := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	if res := deleteAs(artistPath, ""); res.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without the admin token, got %d", res.Code)
+	}
+	if res := deleteAs(artistPath+"/discography", "s3cret"); res.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected sub-resources to refuse DELETE, got %d", res.Code)
+	}
+	if res := deleteAs(artistPath, "s3cret"); res.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", res.Code, res.Body.String())
+	}
+	if artist, _ := store.GetArtist(context.Background(), testArtistID); artist != nil {
+		t.Fatal("expected the artist to be evicted")
+	}
+	if album, _ := store.GetAlbum(context.Background(), testAlbumID); album == nil {
+		t.Fatal("expected the artist's album to stay cached")
+	}
+	if res := deleteAs(artistPath, "s3cret"); res.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an artist no longer cached, got %d", res.Code)
+	}
+
+	res := deleteAs("/albums/"+testAlbumID, "s3cret")
+	if res.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", res.Code, res.Body.String())
+	}
+	if got := res.Header().Get("Cache-Control"); got != cacheNoStore {
+		t.Errorf("expected deletes to be uncacheable, got %q", got)
+	}
+	if album, _ := store.GetAlbum(context.Background(), testAlbumID); album != nil {
+		t.Fatal("expected the album to be evicted")
+	}
+}
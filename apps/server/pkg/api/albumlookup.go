@@ -0,0 +1,220 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+// minTitleSimilarity is the lowest normalized title similarity treated as a plausible match.
+const minTitleSimilarity = 0.8
+
+// albumLookupSearchLimit bounds how many release groups are considered for one lookup.
+const albumLookupSearchLimit = 15
+
+// editionSuffixPattern strips trailing qualifiers such as "(Remastered)" or "[Deluxe Edition]"
+// so they do not count against an otherwise exact title.
+var editionSuffixPattern = regexp.MustCompile(`\s*[\(\[][^\)\]]*[\)\]]\s*$`)
+
+// albumCandidate is one plausible release group returned when a lookup is ambiguous.
+type albumCandidate struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	ArtistID    string   `json:"artistId"`
+	ArtistName  string   `json:"artistName"`
+	PrimaryType string   `json:"primaryType"`
+	Secondary   []string `json:"secondaryTypes,omitempty"`
+	ReleaseDate string   `json:"releaseDate,omitempty"`
+	Similarity  float64  `json:"similarity"`
+}
+
+type albumDisambiguationResponse struct {
+	Error   string           `json:"error"`
+	Matches []albumCandidate `json:"matches"`
+}
+
+// albumMatchHandler resolves GET /albums/lookup?artist=...&title=... to a single enriched album.
+// When several release groups match equally well it responds 300 with the candidates.
+func albumMatchHandler(client MusicBrainzClient, albums service.AlbumService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		artist := strings.TrimSpace(r.URL.Query().Get("artist"))
+		title := strings.TrimSpace(r.URL.Query().Get("title"))
+		if artist == "" || title == "" {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"query parameters 'artist' and 'title' are required"})
+			return
+		}
+		if client == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"musicbrainz client unavailable"})
+			return
+		}
+
+		query := `releasegroup:"` + escapeLuceneTerm(title) + `" AND artist:"` + escapeLuceneTerm(artist) + `"`
+		result, err := client.SearchReleaseGroups(r.Context(), query, albumLookupSearchLimit, 0)
+		if err != nil {
+			handleAPIError(w, r, upstreamFailure(err, newAPIError(http.StatusBadGateway, "musicbrainz lookup failed")))
+			return
+		}
+
+		matches := matchAlbumCandidates(result.ReleaseGroups, artist, title)
+		switch len(matches) {
+		case 0:
+			writeJSON(w, http.StatusNotFound, errorResponse{"album not found"})
+			return
+		case 1:
+		default:
+			writeJSON(w, http.StatusMultipleChoices, albumDisambiguationResponse{
+				Error:   "multiple albums match",
+				Matches: matches,
+			})
+			return
+		}
+
+		ctx := service.WithLanguages(r.Context(), parseAcceptLanguage(r.Header.Get("Accept-Language")))
+		album, err := albums.GetAlbum(ctx, matches[0].ID)
+		var moved *service.MovedError
+		if errors.As(err, &moved) {
+			album, err = albums.GetAlbum(ctx, moved.CanonicalID)
+		}
+		if err != nil {
+			handleAPIError(w, r, err)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Language")
+
+		writeJSON(w, http.StatusOK, album)
+	})
+}
+
+// matchAlbumCandidates keeps release groups credited to the requested artist whose titles are
+// plausible matches, narrowing to the best tier: exact normalized titles beat fuzzy ones, and
+// among exact matches a single studio album beats singles, live records, and compilations.
+func matchAlbumCandidates(releaseGroups []musicbrainz.ReleaseGroup, artist, title string) []albumCandidate {
+	wantArtist := normalizeMatchTitle(artist)
+	wantTitle := normalizeMatchTitle(title)
+
+	var exact, fuzzy []albumCandidate
+	for i := range releaseGroups {
+		rg := &releaseGroups[i]
+		if !creditsArtist(rg, wantArtist) {
+			continue
+		}
+		similarity := titleSimilarity(wantTitle, normalizeMatchTitle(rg.Title))
+		if similarity < minTitleSimilarity {
+			continue
+		}
+		candidate := albumCandidate{
+			ID:          rg.ID,
+			Title:       rg.Title,
+			ArtistID:    rg.PrimaryArtistID(),
+			ArtistName:  rg.PrimaryArtistName(),
+			PrimaryType: rg.PrimaryType,
+			Secondary:   rg.SecondaryTypes,
+			ReleaseDate: rg.FirstReleaseDate,
+			Similarity:  similarity,
+		}
+		if similarity == 1 {
+			exact = append(exact, candidate)
+		} else {
+			fuzzy = append(fuzzy, candidate)
+		}
+	}
+
+	if len(exact) > 0 {
+		var studio []albumCandidate
+		for _, candidate := range exact {
+			if strings.EqualFold(candidate.PrimaryType, "Album") && len(candidate.Secondary) == 0 {
+				studio = append(studio, candidate)
+			}
+		}
+		if len(studio) == 1 {
+			return studio
+		}
+		return exact
+	}
+
+	sort.SliceStable(fuzzy, func(i, j int) bool {
+		return fuzzy[i].Similarity > fuzzy[j].Similarity
+	})
+	return fuzzy
+}
+
+// creditsArtist reports whether any credited artist plausibly matches the normalized name.
+func creditsArtist(rg *musicbrainz.ReleaseGroup, want string) bool {
+	for _, credit := range rg.ArtistCredit {
+		for _, name := range []string{credit.Artist.Name, credit.Name} {
+			if name != "" && titleSimilarity(want, normalizeMatchTitle(name)) >= minTitleSimilarity {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeMatchTitle lowercases a title, drops trailing edition qualifiers, a leading "the",
+// and punctuation, and collapses whitespace.
+func normalizeMatchTitle(title string) string {
+	normalized := strings.ToLower(strings.TrimSpace(title))
+	if stripped := editionSuffixPattern.ReplaceAllString(normalized, ""); stripped != "" {
+		normalized = stripped
+	}
+	normalized = strings.ReplaceAll(normalized, "&", "and")
+
+	var b strings.Builder
+	for _, r := range normalized {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		}
+	}
+	normalized = strings.Join(strings.Fields(b.String()), " ")
+	return strings.TrimPrefix(normalized, "the ")
+}
+
+// titleSimilarity returns 1 minus the edit distance relative to the longer title.
+func titleSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func releaseGroupBy(id, title, primaryType, artist string, secondary ...string) musicbrainz.ReleaseGroup {
+	return musicbrainz.ReleaseGroup{
+		ID:             id,
+		Title:          title,
+		PrimaryType:    primaryType,
+		SecondaryTypes: secondary,
+		ArtistCredit: []musicbrainz.ArtistCredit{
+			{Name: artist, Artist: musicbrainz.ReleaseGroupArtist{ID: "artist-" + id, Name: artist}},
+		},
+	}
+}
+
+func TestAlbumMatchHandlerReturnsSingleMatch(t *testing.T) {
+	mb := &stubMusicBrainz{
+		searchReleaseGroupsFunc: func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			want := `releasegroup:"nevermind" AND artist:"Nirvana"`
+			if query != want {
+				t.Fatalf("unexpected query %q", query)
+			}
+			return &musicbrainz.ReleaseGroupSearchResult{ReleaseGroups: []musicbrainz.ReleaseGroup{
+				releaseGroupBy("nevermind", "Nevermind", "Album", "Nirvana"),
+				releaseGroupBy("nevermind-live", "Nevermind (Live)", "Album", "Nirvana", "Live"),
+				releaseGroupBy("tribute", "Nevermind", "Album", "Various Artists", "Compilation"),
+			}}, nil
+		},
+		lookupReleaseGroupFunc: func(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error) {
+			if id != "nevermind" {
+				t.Fatalf("unexpected lookup id %q", id)
+			}
+			rg := releaseGroupBy(id, "Nevermind", "Album", "Nirvana")
+			return &rg, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/albums/lookup?artist=Nirvana&title=nevermind", nil)
+	res := httptest.NewRecorder()
+
+	albumMatchHandler(mb, service.NewAlbumService(service.Deps{Albums: &stubAlbumRepo{}, MusicBrainz: mb, Reviews: &stubReviews{}})).ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var payload data.Album
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if payload.ID != "nevermind" {
+		t.Fatalf("expected studio album, got %q", payload.ID)
+	}
+}
+
+func TestAlbumMatchHandlerReturnsDisambiguation(t *testing.T) {
+	mb := &stubMusicBrainz{
+		searchReleaseGroupsFunc: func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			return &musicbrainz.ReleaseGroupSearchResult{ReleaseGroups: []musicbrainz.ReleaseGroup{
+				releaseGroupBy("weezer-blue", "Weezer", "Album", "Weezer"),
+				releaseGroupBy("weezer-green", "Weezer", "Album", "Weezer"),
+			}}, nil
+		},
+		lookupReleaseGroupFunc: func(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error) {
+			t.Fatalf(unexpectedCall)
+			return nil, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/albums/lookup?artist=Weezer&title=Weezer", nil)
+	res := httptest.NewRecorder()
+
+	albumMatchHandler(mb, service.NewAlbumService(service.Deps{Albums: &stubAlbumRepo{}, MusicBrainz: mb, Reviews: &stubReviews{}})).ServeHTTP(res, req)
+
+	if res.Code != http.StatusMultipleChoices {
+		t.Fatalf("expected status 300, got %d", res.Code)
+	}
+	var payload albumDisambiguationResponse
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if len(payload.Matches) != 2 {
+		t.Fatalf("expected 2 candidates, got %d", len(payload.Matches))
+	}
+}
+
+func TestAlbumMatchHandlerNotFound(t *testing.T) {
+	mb := &stubMusicBrainz{
+		searchReleaseGroupsFunc: func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			return &musicbrainz.ReleaseGroupSearchResult{ReleaseGroups: []musicbrainz.ReleaseGroup{
+				releaseGroupBy("in-utero", "In Utero", "Album", "Nirvana"),
+			}}, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/albums/lookup?artist=Nirvana&title=Bleach", nil)
+	res := httptest.NewRecorder()
+
+	albumMatchHandler(mb, service.NewAlbumService(service.Deps{Albums: &stubAlbumRepo{}, MusicBrainz: mb, Reviews: &stubReviews{}})).ServeHTTP(res, req)
+
+	if res.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", res.Code)
+	}
+}
+
+func TestAlbumMatchHandlerRequiresParams(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/albums/lookup?artist=Nirvana", nil)
+	res := httptest.NewRecorder()
+
+	albumMatchHandler(&stubMusicBrainz{}, service.NewAlbumService(service.Deps{Albums: &stubAlbumRepo{}})).ServeHTTP(res, req)
+
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf(status400Fmt, res.Code)
+	}
+}
+
+func TestNormalizeMatchTitle(t *testing.T) {
+	cases := map[string]string{
+		"The Bends":                       "bends",
+		"OK Computer (Remastered)":        "ok computer",
+		"Rock & Roll":                     "rock and roll",
+		"  Sgt. Pepper's Lonely Hearts  ": "sgt peppers lonely hearts",
+	}
+	for input, want := range cases {
+		if got := normalizeMatchTitle(input); got != want {
+			t.Fatalf("normalizeMatchTitle(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+// albumSearchHit is one release group matching an album search. ID is the album MBID, so the
+// UI can link straight to /albums/{id}.
+type albumSearchHit struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	ArtistID    string   `json:"artistId,omitempty"`
+	ArtistName  string   `json:"artistName"`
+	PrimaryType string   `json:"primaryType,omitempty"`
+	Secondary   []string `json:"secondaryTypes,omitempty"`
+	ReleaseDate string   `json:"releaseDate,omitempty"`
+	Score       int      `json:"score"`
+}
+
+// albumSearchResult mirrors the artist search payload for release groups.
+type albumSearchResult struct {
+	Albums []albumSearchHit `json:"albums"`
+	Offset int              `json:"offset"`
+	Count  int              `json:"count"`
+}
+
+// albumSearchHandler serves GET /search/albums?q=..., searching MusicBrainz release groups by
+// title. Like /search, q accepts Lucene syntax, e.g. `nevermind AND artist:nirvana`.
+func albumSearchHandler(client MusicBrainzClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		query := r.URL.Query().Get("q")
+		if strings.TrimSpace(query) == "" {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"search query parameter 'q' is required"})
+			return
+		}
+		if client == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"musicbrainz client unavailable"})
+			return
+		}
+
+		limit := parseSearchLimit(r.URL.Query().Get("limit"))
+		offset := parseSearchOffset(r.URL.Query().Get("offset"))
+
+		result, err := client.SearchReleaseGroups(r.Context(), query, limit, offset)
+		if err != nil {
+			handleAPIError(w, r, upstreamFailure(err, newAPIError(http.StatusInternalServerError, "search failed")))
+			return
+		}
+
+		response := albumSearchResult{Albums: make([]albumSearchHit, 0, len(result.ReleaseGroups)), Offset: result.Offset, Count: result.Count}
+		for _, rg := range result.ReleaseGroups {
+			response.Albums = append(response.Albums, newAlbumSearchHit(rg))
+		}
+		writeJSON(w, http.StatusOK, response)
+	}
+}
+
+func newAlbumSearchHit(rg musicbrainz.ReleaseGroup) albumSearchHit {
+	hit := albumSearchHit{
+		ID:          rg.ID,
+		Title:       rg.Title,
+		ArtistName:  musicbrainz.JoinCredits(rg.ArtistCredit),
+		PrimaryType: rg.PrimaryType,
+		Secondary:   rg.SecondaryTypes,
+		ReleaseDate: rg.FirstReleaseDate,
+		Score:       rg.Score,
+	}
+	if len(rg.ArtistCredit) > 0 {
+		hit.ArtistID = rg.ArtistCredit[0].Artist.ID
+	}
+	return hit
+}
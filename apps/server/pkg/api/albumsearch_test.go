@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func TestAlbumSearchHandlerReturnsAlbums(t *testing.T) {
+	mb := &stubMusicBrainz{
+		searchReleaseGroupsFunc: func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			if query != "nevermind" || limit != 10 || offset != 20 {
+				t.Fatalf("unexpected search %q limit=%d offset=%d", query, limit, offset)
+			}
+			return &musicbrainz.ReleaseGroupSearchResult{
+				ReleaseGroups: []musicbrainz.ReleaseGroup{{
+					ID:               "rg-1",
+					Title:            "Nevermind",
+					PrimaryType:      "Album",
+					FirstReleaseDate: "1991-09-24",
+					ArtistCredit:     []musicbrainz.ArtistCredit{{Artist: musicbrainz.ReleaseGroupArtist{ID: "artist-1", Name: "Nirvana"}}},
+					Score:            100,
+				}},
+				Offset: 20,
+				Count:  21,
+			}, nil
+		},
+	}
+
+	resp := httptest.NewRecorder()
+	albumSearchHandler(mb).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/search/albums?q=nevermind&limit=10&offset=20", nil))
+	if resp.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, resp.Code)
+	}
+
+	var result albumSearchResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if result.Count != 21 || result.Offset != 20 || len(result.Albums) != 1 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if hit := result.Albums[0]; hit.ID != "rg-1" || hit.ArtistName != "Nirvana" || hit.ArtistID != "artist-1" || hit.ReleaseDate != "1991-09-24" {
+		t.Fatalf("unexpected hit %+v", hit)
+	}
+}
+
+func TestAlbumSearchHandlerRequiresQuery(t *testing.T) {
+	resp := httptest.NewRecorder()
+	albumSearchHandler(&stubMusicBrainz{}).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/search/albums?q=+", nil))
+	if resp.Code != http.StatusBadRequest {
+		t.Fatalf(status400Fmt, resp.Code)
+	}
+}
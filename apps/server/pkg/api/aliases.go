@@ -0,0 +1,29 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+)
+
+// handleLookupError answers a failed artist or album lookup, sending clients to the surviving
+// record when the requested MBID was merged.
+func handleLookupError(w http.ResponseWriter, r *http.Request, err error) {
+	var moved *service.MovedError
+	if errors.As(err, &moved) {
+		redirectCanonical(w, r, moved.ID, moved.CanonicalID)
+		return
+	}
+	handleAPIError(w, r, err)
+}
+
+// redirectCanonical rewrites the first occurrence of id in the request path, keeping any
+// trailing path segments and the query string.
+func redirectCanonical(w http.ResponseWriter, r *http.Request, id, canonicalID string) {
+	target := *r.URL
+	target.Path = basePath(r.Context()) + strings.Replace(r.URL.Path, id, canonicalID, 1)
+	target.RawPath = ""
+	http.Redirect(w, r, target.RequestURI(), http.StatusMovedPermanently)
+}
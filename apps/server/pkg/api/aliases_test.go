@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func TestArtistLookupHandlerRedirectsMergedID(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+
+	mb := &stubMusicBrainz{
+		lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			return &musicbrainz.Artist{ID: "canonical-id", Name: remoteArtist}, nil
+		},
+		getArtistReleaseGroupsFunc: func(ctx context.Context, artistID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			if artistID != "canonical-id" {
+				t.Fatalf("expected browse by canonical id, got %q", artistID)
+			}
+			return &musicbrainz.ReleaseGroupSearchResult{}, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/artists/merged-id?fields=albums", nil)
+	res := httptest.NewRecorder()
+	artistLookupHandler(service.NewArtistService(service.Deps{Artists: store, Albums: store, Aliases: store, MusicBrainz: mb}), nil).ServeHTTP(res, req)
+
+	if res.Code != http.StatusMovedPermanently {
+		t.Fatalf("expected status 301, got %d", res.Code)
+	}
+	if got := res.Header().Get("Location"); got != "/artists/canonical-id?fields=albums" {
+		t.Fatalf("unexpected redirect location %q", got)
+	}
+
+	canonical, err := store.ResolveAlias(context.Background(), db.KindArtist, "merged-id")
+	if err != nil {
+		t.Fatalf("ResolveAlias: %v", err)
+	}
+	if canonical != "canonical-id" {
+		t.Fatalf("expected alias to be recorded, got %q", canonical)
+	}
+
+	// Later requests redirect from the alias table without asking MusicBrainz.
+	mb.lookupArtistFunc = func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+		t.Fatalf(unexpectedCall)
+		return nil, nil
+	}
+	res = httptest.NewRecorder()
+	artistLookupHandler(service.NewArtistService(service.Deps{Artists: store, Albums: store, Aliases: store, MusicBrainz: mb}), nil).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/artists/merged-id", nil))
+	if res.Code != http.StatusMovedPermanently {
+		t.Fatalf("expected status 301 from alias table, got %d", res.Code)
+	}
+}
+
+func TestAlbumLookupHandlerRedirectsKnownAlias(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	if err := store.SaveAlias(context.Background(), db.KindAlbum, "old-album", testAlbumID); err != nil {
+		t.Fatalf("SaveAlias: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/albums/old-album", nil)
+	res := httptest.NewRecorder()
+	albumLookupHandler(service.NewAlbumService(service.Deps{Albums: store, Aliases: store, MusicBrainz: &stubMusicBrainz{}, Reviews: &stubReviews{}}), nil).ServeHTTP(res, req)
+
+	if res.Code != http.StatusMovedPermanently {
+		t.Fatalf("expected status 301, got %d", res.Code)
+	}
+	if got := res.Header().Get("Location"); got != albumPath {
+		t.Fatalf("unexpected redirect location %q", got)
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	cfg := AuthConfig{
+		Keys: []APIKey{
+			{Name: "web", Key: "k-web", Enabled: true},
+			{Name: "retired", Key: "k-old"},
+		},
+		PublicPaths: []string{"/healthz"},
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		key        string
+		wantStatus int
+	}{
+		{"enabled key", "/search", "k-web", http.StatusNoContent},
+		{"missing key", "/search", "", http.StatusUnauthorized},
+		{"unknown key", "/search", "k-guess", http.StatusUnauthorized},
+		{"disabled key", "/search", "k-old", http.StatusForbidden},
+		{"public path", "/healthz", "", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.key != "" {
+				req.Header.Set(apiKeyHeader, tt.key)
+			}
+			resp := httptest.NewRecorder()
+			apiKeyMiddleware(cfg, next).ServeHTTP(resp, req)
+			if resp.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRouterRequiresAPIKeysUnderBasePath(t *testing.T) {
+	router := NewRouter(RouterConfig{
+		BasePath: "/api",
+		Auth:     AuthConfig{Keys: []APIKey{{Name: "web", Key: "k-web", Enabled: true}}, PublicPaths: []string{"/healthz"}},
+	})
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/healthz", nil))
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected the public health check to need no key, got %d", resp.Code)
+	}
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/albums", nil))
+	if resp.Code != http.StatusUnauthorized || resp.Header().Get("Cache-Control") != "no-store" {
+		t.Fatalf("expected an uncacheable 401 without a key, got %d (Cache-Control %q)", resp.Code, resp.Header().Get("Cache-Control"))
+	}
+}
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+// albumBrowseResult lists cached albums matching a decade/genre browse. NextCursor is set when
+// a full page was returned and more albums may follow.
+type albumBrowseResult struct {
+	Albums     []data.Album `json:"albums"`
+	Offset     int          `json:"offset"`
+	Count      int          `json:"count"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+}
+
+// artistBrowseResult lists cached artists matching a country/type browse.
+type artistBrowseResult struct {
+	Artists    []data.Artist `json:"artists"`
+	Offset     int           `json:"offset"`
+	Count      int           `json:"count"`
+	Source     string        `json:"source"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+}
+
+// albumBrowseHandler serves GET /albums?decade=1990s&genre=shoegaze over cached albums. A single
+// ?year= may be given instead of a decade, and ?type=album,live narrows by release group type
+// (see musicbrainz.ReleaseGroupTypes; all types by default). Limit and offset page through the
+// results, or
+// ?cursor= with the previous page's nextCursor, which stays stable while albums are cached.
+func albumBrowseHandler(browser db.AlbumBrowser) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+		if browser == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"album browsing unavailable"})
+			return
+		}
+
+		query := r.URL.Query()
+		filter, err := parseAlbumFilter(query.Get("decade"), query.Get("year"))
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+		filter.Genre = strings.TrimSpace(query.Get("genre"))
+		if raw := query.Get("type"); raw != "" {
+			types, err := musicbrainz.ParseReleaseGroupTypes(raw)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, errorResponse{"query parameter 'type' must list release group types such as album,ep,live"})
+				return
+			}
+			filter.Types = types
+		}
+		filter.Limit = parseSearchLimit(query.Get("limit"))
+		filter.Offset = parseSearchOffset(query.Get("offset"))
+		if cursor := query.Get("cursor"); cursor != "" {
+			filter.After = &db.AlbumKey{}
+			if err := decodeCursor(cursor, filter.After); err != nil {
+				writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+				return
+			}
+			filter.Offset = 0
+		}
+
+		albums, err := browser.BrowseAlbums(r.Context(), filter)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"album browse failed"})
+			return
+		}
+
+		result := albumBrowseResult{Albums: albums, Offset: filter.Offset, Count: len(albums)}
+		if len(albums) == filter.Limit {
+			result.NextCursor = encodeCursor(db.AlbumKeyOf(&albums[len(albums)-1]))
+		}
+		writeJSON(w, http.StatusOK, result)
+	})
+}
+
+// artistTypes are the MusicBrainz artist types, keyed by their lowercase form.
+var artistTypes = map[string]string{
+	"person":    "Person",
+	"group":     "Group",
+	"orchestra": "Orchestra",
+	"choir":     "Choir",
+	"character": "Character",
+	"other":     "Other",
+}
+
+// artistBrowseHandler serves GET /artists?country=SE&type=Group over cached artists. With
+// ?source=musicbrainz the same filter is passed through to a MusicBrainz search instead, for
+// scenes the cache has not seen yet; limit and offset page through either, and cached results
+// also accept ?cursor= as albumBrowseHandler does.
+func artistBrowseHandler(browser db.ArtistBrowser, client MusicBrainzClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		query := r.URL.Query()
+		filter, err := parseArtistFilter(query.Get("country"), query.Get("type"))
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+		filter.Limit = parseSearchLimit(query.Get("limit"))
+		filter.Offset = parseSearchOffset(query.Get("offset"))
+
+		if query.Get("source") == "musicbrainz" {
+			browseMusicBrainzArtists(w, r, client, filter)
+			return
+		}
+
+		if browser == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"artist browsing unavailable"})
+			return
+		}
+		if cursor := query.Get("cursor"); cursor != "" {
+			filter.After = &db.ArtistKey{}
+			if err := decodeCursor(cursor, filter.After); err != nil {
+				writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+				return
+			}
+			filter.Offset = 0
+		}
+		artists, err := browser.BrowseArtists(r.Context(), filter)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"artist browse failed"})
+			return
+		}
+
+		result := artistBrowseResult{Artists: artists, Offset: filter.Offset, Count: len(artists), Source: "local"}
+		if len(artists) == filter.Limit {
+			result.NextCursor = encodeCursor(db.ArtistKeyOf(&artists[len(artists)-1]))
+		}
+		writeJSON(w, http.StatusOK, result)
+	})
+}
+
+// browseMusicBrainzArtists runs filter as a MusicBrainz artist search. MusicBrainz cannot list
+// every artist, so at least one of country and type is required.
+func browseMusicBrainzArtists(w http.ResponseWriter, r *http.Request, client MusicBrainzClient, filter db.ArtistFilter) {
+	if client == nil {
+		writeJSON(w, http.StatusServiceUnavailable, errorResponse{"musicbrainz client unavailable"})
+		return
+	}
+
+	var terms []string
+	if filter.Country != "" {
+		terms = append(terms, "country:"+filter.Country)
+	}
+	if filter.Type != "" {
+		terms = append(terms, "type:"+strings.ToLower(filter.Type))
+	}
+	if len(terms) == 0 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{"query parameter 'country' or 'type' is required with source=musicbrainz"})
+		return
+	}
+
+	result, err := client.SearchArtists(r.Context(), strings.Join(terms, " AND "), filter.Limit, filter.Offset)
+	if err != nil {
+		handleAPIError(w, r, upstreamFailure(err, newAPIError(http.StatusBadGateway, "musicbrainz browse failed")))
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// parseArtistFilter validates ?country= as a two-letter ISO 3166-1 code and ?type= as a
+// MusicBrainz artist type, in any case.
+func parseArtistFilter(country, artistType string) (db.ArtistFilter, error) {
+	var filter db.ArtistFilter
+	if country = strings.ToUpper(strings.TrimSpace(country)); country != "" {
+		if len(country) != 2 || strings.Trim(country, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+			return db.ArtistFilter{}, errors.New("query parameter 'country' must be a two-letter country code such as SE")
+		}
+		filter.Country = country
+	}
+	if artistType = strings.TrimSpace(artistType); artistType != "" {
+		canonical, ok := artistTypes[strings.ToLower(artistType)]
+		if !ok {
+			return db.ArtistFilter{}, errors.New("query parameter 'type' must be Person, Group, Orchestra, Choir, Character, or Other")
+		}
+		filter.Type = canonical
+	}
+	return filter, nil
+}
+
+// parseAlbumFilter turns ?decade= (1990s, 1990, or 90s) or ?year= into an inclusive year range.
+func parseAlbumFilter(decade, year string) (db.AlbumFilter, error) {
+	decade = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(decade)), "s")
+	year = strings.TrimSpace(year)
+	if decade != "" && year != "" {
+		return db.AlbumFilter{}, errors.New("use either 'decade' or 'year', not both")
+	}
+
+	switch {
+	case year != "":
+		parsed, err := strconv.Atoi(year)
+		if err != nil || parsed <= 0 {
+			return db.AlbumFilter{}, errors.New("query parameter 'year' must be a year such as 1991")
+		}
+		return db.AlbumFilter{FromYear: parsed, ToYear: parsed}, nil
+	case decade != "":
+		parsed, err := strconv.Atoi(decade)
+		if err != nil || parsed < 0 || parsed%10 != 0 || (len(decade) != 2 && len(decade) != 4) {
+			return db.AlbumFilter{}, errors.New("query parameter 'decade' must look like 1990s or 90s")
+		}
+		if len(decade) == 2 {
+			// Two-digit decades read as the most recent century that is not in the future.
+			parsed += 1900
+			if parsed < 1930 {
+				parsed += 100
+			}
+		}
+		return db.AlbumFilter{FromYear: parsed, ToYear: parsed + 9}, nil
+	default:
+		return db.AlbumFilter{}, nil
+	}
+}
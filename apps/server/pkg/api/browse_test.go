@@ -0,0 +1,238 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func TestAlbumBrowseHandlerFiltersByDecadeAndGenre(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	for _, album := range []*data.Album{
+		{ID: "loveless", Title: "Loveless", Year: 1991, Genre: "shoegaze"},
+		{ID: "nevermind", Title: "Nevermind", Year: 1991, Genre: "grunge"},
+		{ID: "m-b-v", Title: "m b v", Year: 2013, Genre: "shoegaze"},
+	} {
+		if err := store.SaveAlbum(context.Background(), album); err != nil {
+			t.Fatalf("SaveAlbum: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/albums?decade=1990s&genre=Shoegaze", nil)
+	res := httptest.NewRecorder()
+
+	albumBrowseHandler(store).ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var payload albumBrowseResult
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if payload.Count != 1 || payload.Albums[0].ID != "loveless" {
+		t.Fatalf("unexpected albums %+v", payload.Albums)
+	}
+}
+
+func TestAlbumBrowseHandlerFiltersByType(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	for _, album := range []*data.Album{
+		{ID: "nevermind", Title: "Nevermind", Year: 1991, PrimaryType: "Album"},
+		{ID: "unplugged", Title: "MTV Unplugged in New York", Year: 1994, PrimaryType: "Album", SecondaryTypes: []string{"Live"}},
+		{ID: "lithium", Title: "Lithium", Year: 1992, PrimaryType: "Single"},
+	} {
+		if err := store.SaveAlbum(context.Background(), album); err != nil {
+			t.Fatalf("SaveAlbum: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/albums?type=Album,Live", nil)
+	res := httptest.NewRecorder()
+
+	albumBrowseHandler(store).ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var payload albumBrowseResult
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if payload.Count != 2 || payload.Albums[0].ID != "nevermind" || payload.Albums[1].ID != "unplugged" {
+		t.Fatalf("unexpected albums %+v", payload.Albums)
+	}
+
+	res = httptest.NewRecorder()
+	albumBrowseHandler(store).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/albums?type=bootleg", nil))
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf(status400Fmt, res.Code)
+	}
+}
+
+func TestAlbumBrowseHandlerRejectsBadDecade(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/albums?decade=1995s", nil)
+	res := httptest.NewRecorder()
+
+	albumBrowseHandler(&db.MemoryStore{}).ServeHTTP(res, req)
+
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf(status400Fmt, res.Code)
+	}
+}
+
+func TestAlbumBrowseHandlerCursorSurvivesConcurrentInserts(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	for _, album := range []*data.Album{
+		{ID: "a", Title: "A", Year: 1991},
+		{ID: "b", Title: "B", Year: 1992},
+		{ID: "c", Title: "C", Year: 1993},
+	} {
+		if err := store.SaveAlbum(context.Background(), album); err != nil {
+			t.Fatalf("SaveAlbum: %v", err)
+		}
+	}
+	browse := func(target string) albumBrowseResult {
+		t.Helper()
+		res := httptest.NewRecorder()
+		albumBrowseHandler(store).ServeHTTP(res, httptest.NewRequest(http.MethodGet, target, nil))
+		if res.Code != http.StatusOK {
+			t.Fatalf(status200Fmt, res.Code)
+		}
+		var payload albumBrowseResult
+		if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+			t.Fatalf(decodeErrFmt, err)
+		}
+		return payload
+	}
+
+	first := browse("/albums?limit=2")
+	if first.Count != 2 || first.NextCursor == "" {
+		t.Fatalf("expected a full first page with a cursor, got %+v", first)
+	}
+
+	// An album sorting before the cursor would shift an offset-based second page.
+	if err := store.SaveAlbum(context.Background(), &data.Album{ID: "early", Title: "Early", Year: 1980}); err != nil {
+		t.Fatalf("SaveAlbum: %v", err)
+	}
+
+	second := browse("/albums?limit=2&cursor=" + first.NextCursor)
+	if second.Count != 1 || second.Albums[0].ID != "c" || second.NextCursor != "" {
+		t.Fatalf("expected only the remaining album, got %+v", second)
+	}
+}
+
+func TestAlbumBrowseHandlerRejectsBadCursor(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/albums?cursor=not-a-cursor", nil)
+	res := httptest.NewRecorder()
+
+	albumBrowseHandler(&db.MemoryStore{}).ServeHTTP(res, req)
+
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf(status400Fmt, res.Code)
+	}
+}
+
+func TestArtistBrowseHandlerFiltersByCountryAndType(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	for _, artist := range []*data.Artist{
+		{ID: "abba", Name: "ABBA", Country: "SE", Type: "Group"},
+		{ID: "robyn", Name: "Robyn", Country: "SE", Type: "Person"},
+		{ID: "mbv", Name: "My Bloody Valentine", Country: "IE", Type: "Group"},
+	} {
+		if err := store.SaveArtist(context.Background(), artist); err != nil {
+			t.Fatalf("SaveArtist: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/artists?country=se&type=group", nil)
+	res := httptest.NewRecorder()
+
+	artistBrowseHandler(store, nil).ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var payload artistBrowseResult
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if payload.Count != 1 || payload.Artists[0].ID != "abba" || payload.Source != "local" {
+		t.Fatalf("unexpected artists %+v", payload)
+	}
+}
+
+func TestArtistBrowseHandlerPassesThroughToMusicBrainz(t *testing.T) {
+	var gotQuery string
+	mb := &stubMusicBrainz{
+		searchArtistsFunc: func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.SearchResult, error) {
+			gotQuery = query
+			return &musicbrainz.SearchResult{Artists: []musicbrainz.Artist{{ID: "abba", Name: "ABBA"}}, Count: 1}, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/artists?country=SE&type=Group&source=musicbrainz", nil)
+	res := httptest.NewRecorder()
+
+	artistBrowseHandler(nil, mb).ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	if gotQuery != "country:SE AND type:group" {
+		t.Fatalf("unexpected musicbrainz query %q", gotQuery)
+	}
+}
+
+func TestArtistBrowseHandlerRejectsBadFilters(t *testing.T) {
+	for _, target := range []string{"/artists?country=Sweden", "/artists?type=band", "/artists?source=musicbrainz"} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		res := httptest.NewRecorder()
+
+		artistBrowseHandler(&db.MemoryStore{}, &stubMusicBrainz{}).ServeHTTP(res, req)
+
+		if res.Code != http.StatusBadRequest {
+			t.Errorf("%s: "+status400Fmt, target, res.Code)
+		}
+	}
+}
+
+func TestParseAlbumFilter(t *testing.T) {
+	cases := map[string][2]int{
+		"1990s": {1990, 1999},
+		"1960":  {1960, 1969},
+		"90s":   {1990, 1999},
+		"00s":   {2000, 2009},
+		"20s":   {2020, 2029},
+	}
+	for decade, want := range cases {
+		filter, err := parseAlbumFilter(decade, "")
+		if err != nil {
+			t.Fatalf("parseAlbumFilter(%q) returned error: %v", decade, err)
+		}
+		if filter.FromYear != want[0] || filter.ToYear != want[1] {
+			t.Errorf("parseAlbumFilter(%q) = %d-%d, want %d-%d", decade, filter.FromYear, filter.ToYear, want[0], want[1])
+		}
+	}
+	if _, err := parseAlbumFilter("1990s", "1991"); err == nil {
+		t.Error("expected an error when decade and year are both set")
+	}
+}
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+)
+
+// enrichmentBudgetMiddleware gives each request its own enrichment budget.
+func enrichmentBudgetMiddleware(total time.Duration, next http.Handler) http.Handler {
+	if total <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(service.WithEnrichmentBudget(r.Context(), total)))
+	})
+}
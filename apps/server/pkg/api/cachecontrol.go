@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// CachePolicies sets how long clients and shared caches may reuse responses, by route class.
+// Zero makes them revalidate every time, which Last-Modified keeps cheap. Image variants are
+// always cached as immutable, since a URL and size always produce the same image. Routes
+// outside these classes (user data, admin, health) are never stored.
+type CachePolicies struct {
+	// Entity covers artist, album, label, and recording lookups.
+	Entity time.Duration
+	// Listing covers search and browse results, which change as the cache fills.
+	Listing time.Duration
+}
+
+const (
+	cacheNoStore = "no-store"
+	// cacheImmutable lets clients keep a response for a year without revalidating it.
+	cacheImmutable = "public, max-age=31536000, immutable"
+)
+
+// publicCache renders the Cache-Control value for a shared, reusable response.
+func publicCache(maxAge time.Duration) string {
+	if maxAge <= 0 {
+		return "no-cache"
+	}
+	return "public, max-age=" + strconv.Itoa(int(maxAge/time.Second))
+}
+
+// cacheControlMiddleware sends policy as Cache-Control on successful responses that don't set
+// their own. Errors are never stored, so a transient upstream failure isn't replayed from a
+// cache.
+func cacheControlMiddleware(policy string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(&cacheControlWriter{ResponseWriter: w, policy: policy}, r)
+	})
+}
+
+type cacheControlWriter struct {
+	http.ResponseWriter
+	policy      string
+	wroteHeader bool
+}
+
+func (w *cacheControlWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if w.Header().Get("Cache-Control") == "" {
+			policy := w.policy
+			if status >= http.StatusBadRequest {
+				policy = cacheNoStore
+			}
+			w.Header().Set("Cache-Control", policy)
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *cacheControlWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
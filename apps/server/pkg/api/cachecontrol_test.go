@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+)
+
+func TestCacheControlMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		handler http.HandlerFunc
+		want    string
+	}{
+		{
+			name:   "success gets the route policy",
+			policy: publicCache(5 * time.Minute),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+			},
+			want: "public, max-age=300",
+		},
+		{
+			name:   "implicit 200 gets the route policy",
+			policy: publicCache(time.Minute),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("ok"))
+			},
+			want: "public, max-age=60",
+		},
+		{
+			name:   "not modified keeps the route policy",
+			policy: publicCache(time.Minute),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotModified)
+			},
+			want: "public, max-age=60",
+		},
+		{
+			name:    "zero max age revalidates",
+			policy:  publicCache(0),
+			handler: func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) },
+			want:    "no-cache",
+		},
+		{
+			name:   "errors are never stored",
+			policy: publicCache(5 * time.Minute),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, http.StatusBadGateway, errorResponse{"upstream failed"})
+			},
+			want: cacheNoStore,
+		},
+		{
+			name:   "handler policy wins",
+			policy: publicCache(5 * time.Minute),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Cache-Control", "private")
+				w.WriteHeader(http.StatusOK)
+			},
+			want: "private",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			cacheControlMiddleware(tc.policy, tc.handler).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/", nil))
+			if got := res.Header().Get("Cache-Control"); got != tc.want {
+				t.Errorf("Cache-Control = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRouterAppliesCachePolicyByRouteClass(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	router := NewRouter(RouterConfig{
+		AlbumBrowser: store,
+		Owned:        store,
+		CacheControl: CachePolicies{Entity: 10 * time.Minute, Listing: time.Minute},
+	})
+
+	for path, want := range map[string]string{
+		"/albums":        "public, max-age=60",
+		"/healthz":       cacheNoStore,
+		"/library/owned": cacheNoStore,
+	} {
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+		if got := res.Header().Get("Cache-Control"); got != want {
+			t.Errorf("%s Cache-Control = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+)
+
+const collaborationsSuffix = "/collaborations"
+
+// artistRoutes sends /artists/{id}/collaborations to the collaboration graph,
+// /artists/{id}/discography to the grouped discography, /artists/{id}/albums to the paged album
+// browse, /artists/{id}/related to the related artists, and every other /artists/ path to the
+// artist lookup.
+func artistRoutes(lookup, collaborations, discography, albums, related http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(r.URL.Path, "/")
+		switch {
+		case strings.HasSuffix(path, collaborationsSuffix):
+			collaborations.ServeHTTP(w, r)
+		case strings.HasSuffix(path, discographySuffix):
+			discography.ServeHTTP(w, r)
+		case strings.HasSuffix(path, artistAlbumsSuffix):
+			albums.ServeHTTP(w, r)
+		case strings.HasSuffix(path, relatedSuffix):
+			related.ServeHTTP(w, r)
+		default:
+			lookup.ServeHTTP(w, r)
+		}
+	})
+}
+
+// collaborationsHandler serves GET /artists/{id}/collaborations: artists sharing release-group
+// credits with the subject, weighted by shared releases. ?limit= caps the edges returned.
+func collaborationsHandler(collaborations service.CollaborationService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		id, err := parseArtistID(r.URL.Path)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+
+		graph, err := collaborations.GetCollaborations(r.Context(), id)
+		if err != nil {
+			handleLookupError(w, r, err)
+			return
+		}
+
+		if limit := parseSearchLimit(r.URL.Query().Get("limit")); len(graph.Edges) > limit {
+			graph.Edges = graph.Edges[:limit]
+		}
+		writeJSON(w, http.StatusOK, graph)
+	})
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func TestCollaborationsHandlerWeighsSharedReleases(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	if err := store.SaveArtist(context.Background(), &data.Artist{
+		ID:   "self",
+		Name: "Self",
+		Albums: []data.Album{
+			{ID: "rg-1", Title: "Duets", Credits: []data.ArtistCredit{{ArtistID: "self", Name: "Self"}, {ArtistID: "guest", Name: "Guest"}}},
+		},
+	}); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+
+	credit := func(id string) musicbrainz.ArtistCredit {
+		return musicbrainz.ArtistCredit{Name: id, Artist: musicbrainz.ReleaseGroupArtist{ID: id, Name: id}}
+	}
+	mb := &stubMusicBrainz{
+		getArtistReleaseGroupsFunc: func(ctx context.Context, artistID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			if offset > 0 {
+				t.Fatalf("unexpected second page at offset %d", offset)
+			}
+			return &musicbrainz.ReleaseGroupSearchResult{Count: 3, ReleaseGroups: []musicbrainz.ReleaseGroup{
+				{ID: "rg-1", Title: "Duets", ArtistCredit: []musicbrainz.ArtistCredit{credit("self"), credit("guest")}},
+				{ID: "rg-2", Title: "More Duets", ArtistCredit: []musicbrainz.ArtistCredit{credit("self"), credit("guest")}},
+				{ID: "rg-3", Title: "Split", ArtistCredit: []musicbrainz.ArtistCredit{credit("self"), credit("other")}},
+			}}, nil
+		},
+	}
+
+	deps := service.Deps{Artists: store, Albums: store, MusicBrainz: mb}
+	handler := artistRoutes(artistLookupHandler(service.NewArtistService(deps), nil), collaborationsHandler(service.NewCollaborationService(deps)), http.NotFoundHandler(), http.NotFoundHandler(), http.NotFoundHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/artists/self/collaborations", nil)
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var payload data.CollaborationGraph
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if payload.ReleaseGroups != 3 || len(payload.Edges) != 2 {
+		t.Fatalf("unexpected graph %+v", payload)
+	}
+	if payload.Edges[0].ArtistID != "guest" || payload.Edges[0].Weight != 2 {
+		t.Fatalf("expected guest with weight 2, got %+v", payload.Edges[0])
+	}
+}
+
+func TestArtistRoutesKeepsArtistLookup(t *testing.T) {
+	var hit string
+	handler := artistRoutes(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hit = "lookup" }),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hit = "collaborations" }),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hit = "discography" }),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hit = "albums" }),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hit = "related" }),
+	)
+
+	for path, want := range map[string]string{
+		"/artists/self":                 "lookup",
+		"/artists/self/collaborations":  "collaborations",
+		"/artists/self/collaborations/": "collaborations",
+		"/artists/self/discography":     "discography",
+		"/artists/self/albums":          "albums",
+		"/artists/self/related":         "related",
+	} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		if hit != want {
+			t.Errorf("%s: expected %s handler, got %s", path, want, hit)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+)
+
+// modifiedFunc returns when a cached record last changed, or the zero time when unknown.
+type modifiedFunc func(ctx context.Context, id string) (time.Time, error)
+
+// notModified sets Last-Modified from the record's stored modification time and answers 304
+// when the request's If-Modified-Since is no older, reporting whether it did. Lookup failures
+// only cost the header, since the body is already in hand.
+func notModified(w http.ResponseWriter, r *http.Request, lookup modifiedFunc, id string) bool {
+	if lookup == nil {
+		return false
+	}
+	modified, err := lookup(r.Context(), id)
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("last-modified lookup failed", "id", id, "error", err)
+		return false
+	}
+	if modified.IsZero() {
+		return false
+	}
+
+	// HTTP dates have whole-second precision.
+	modified = modified.UTC().Truncate(time.Second)
+	w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+
+	// If-None-Match takes precedence when present (RFC 9110 §13.1.3).
+	if r.Header.Get("If-None-Match") != "" {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || modified.After(since) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+)
+
+func TestAlbumLookupHandlerHonorsIfModifiedSince(t *testing.T) {
+	modifiedAt := time.Date(2024, 5, 1, 12, 0, 0, 500, time.UTC)
+	repo := &stubAlbumRepo{
+		getFunc: func(ctx context.Context, id string) (*data.Album, error) {
+			return &data.Album{ID: id, Title: "Cached"}, nil
+		},
+	}
+	modified := func(ctx context.Context, id string) (time.Time, error) {
+		if id != testAlbumID {
+			t.Fatalf("unexpected id %q", id)
+		}
+		return modifiedAt, nil
+	}
+	handler := albumLookupHandler(service.NewAlbumService(service.Deps{Albums: repo, MusicBrainz: &stubMusicBrainz{}, Reviews: &stubReviews{}}), modified)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+	}{
+		{name: "unconditional", status: http.StatusOK},
+		{name: "unchanged since", headers: map[string]string{"If-Modified-Since": "Wed, 01 May 2024 12:00:00 GMT"}, status: http.StatusNotModified},
+		{name: "changed since", headers: map[string]string{"If-Modified-Since": "Wed, 01 May 2024 11:59:59 GMT"}, status: http.StatusOK},
+		{name: "unparseable date", headers: map[string]string{"If-Modified-Since": "yesterday"}, status: http.StatusOK},
+		{name: "entity tag wins", headers: map[string]string{"If-Modified-Since": "Wed, 01 May 2024 12:00:00 GMT", "If-None-Match": `"abc"`}, status: http.StatusOK},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, albumPath, nil)
+			for name, value := range tc.headers {
+				req.Header.Set(name, value)
+			}
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, req)
+
+			if res.Code != tc.status {
+				t.Fatalf("expected status %d, got %d", tc.status, res.Code)
+			}
+			if got := res.Header().Get("Last-Modified"); got != "Wed, 01 May 2024 12:00:00 GMT" {
+				t.Errorf("unexpected Last-Modified %q", got)
+			}
+			if tc.status == http.StatusNotModified && res.Body.Len() != 0 {
+				t.Errorf("expected an empty 304 body, got %q", res.Body.String())
+			}
+		})
+	}
+}
+
+func TestLookupOmitsLastModifiedWhenUnknown(t *testing.T) {
+	repo := &stubAlbumRepo{
+		getFunc: func(ctx context.Context, id string) (*data.Album, error) {
+			return &data.Album{ID: id, Title: "Cached"}, nil
+		},
+	}
+	modified := func(ctx context.Context, id string) (time.Time, error) { return time.Time{}, nil }
+	handler := albumLookupHandler(service.NewAlbumService(service.Deps{Albums: repo, MusicBrainz: &stubMusicBrainz{}, Reviews: &stubReviews{}}), modified)
+
+	req := httptest.NewRequest(http.MethodGet, albumPath, nil)
+	req.Header.Set("If-Modified-Since", "Wed, 01 May 2024 12:00:00 GMT")
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	if got := res.Header().Get("Last-Modified"); got != "" {
+		t.Errorf("expected no Last-Modified, got %q", got)
+	}
+}
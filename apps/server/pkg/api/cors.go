@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+)
+
+// corsMiddleware lets browsers on the allowed origins call the API. A "*" entry allows any
+// origin; otherwise a listed Origin is echoed back with Vary: Origin so shared caches keep
+// the responses apart. Preflights from allowed origins are answered here, and with no
+// origins configured no CORS headers are sent at all.
+func corsMiddleware(origins []string, next http.Handler) http.Handler {
+	anyOrigin := slices.Contains(origins, "*")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		allowed := anyOrigin || (origin != "" && slices.Contains(origins, origin))
+		if !allowed {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if anyOrigin {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Add("Vary", "Origin")
+		}
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+		w.Header().Set("Access-Control-Max-Age", "86400")
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
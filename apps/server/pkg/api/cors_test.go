@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORSMiddlewareAllowsListedOrigins(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name       string
+		origins    []string
+		origin     string
+		method     string
+		wantOrigin string
+		wantStatus int
+	}{
+		{"any origin", []string{"*"}, "https://elsewhere.example", http.MethodGet, "*", http.StatusNoContent},
+		{"listed origin", []string{"https://app.example"}, "https://app.example", http.MethodGet, "https://app.example", http.StatusNoContent},
+		{"unlisted origin", []string{"https://app.example"}, "https://evil.example", http.MethodGet, "", http.StatusNoContent},
+		{"no origins configured", nil, "https://app.example", http.MethodGet, "", http.StatusNoContent},
+		{"preflight", []string{"https://app.example"}, "https://app.example", http.MethodOptions, "https://app.example", http.StatusOK},
+		{"unlisted preflight", []string{"https://app.example"}, "https://evil.example", http.MethodOptions, "", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/search", nil)
+			req.Header.Set("Origin", tt.origin)
+			resp := httptest.NewRecorder()
+			corsMiddleware(tt.origins, next).ServeHTTP(resp, req)
+
+			if got := resp.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if resp.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// errInvalidCursor is reported for a ?cursor= this server did not issue.
+var errInvalidCursor = errors.New("query parameter 'cursor' is invalid")
+
+// encodeCursor packs a browse sort key into the opaque token clients pass back as ?cursor=.
+func encodeCursor(key any) string {
+	encoded, err := json.Marshal(key)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// decodeCursor unpacks a token from encodeCursor into key.
+func decodeCursor(raw string, key any) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return errInvalidCursor
+	}
+	if err := json.Unmarshal(decoded, key); err != nil {
+		return errInvalidCursor
+	}
+	return nil
+}
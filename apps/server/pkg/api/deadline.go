@@ -0,0 +1,30 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RouteDeadlines bounds how long handlers may run, by route class. Zero leaves a class unbounded.
+type RouteDeadlines struct {
+	// Read covers routes served from the local store.
+	Read time.Duration
+	// Enrich covers artist and album lookups and searches that may go upstream.
+	Enrich time.Duration
+	// Batch covers playlist imports and library scans.
+	Batch time.Duration
+}
+
+// deadlineMiddleware cancels the request context after timeout, so upstream calls stop on
+// their own rather than running until the client disconnects.
+func deadlineMiddleware(timeout time.Duration, next http.Handler) http.Handler {
+	if timeout <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
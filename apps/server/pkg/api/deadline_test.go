@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func TestDeadlineMiddlewareSetsDeadline(t *testing.T) {
+	var deadline time.Time
+	var ok bool
+	handler := deadlineMiddleware(time.Second, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, ok = r.Context().Deadline()
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/artists/x", nil))
+	if !ok || time.Until(deadline) > time.Second {
+		t.Fatalf("expected a deadline within 1s, got %v (set=%v)", deadline, ok)
+	}
+}
+
+func TestDeadlineMiddlewareDisabled(t *testing.T) {
+	handler := deadlineMiddleware(0, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); ok {
+			t.Error("expected no deadline when disabled")
+		}
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/artists/x", nil))
+}
+
+func TestArtistLookupReportsExpiredDeadline(t *testing.T) {
+	mb := &stubMusicBrainz{
+		lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	handler := deadlineMiddleware(10*time.Millisecond, artistLookupHandler(service.NewArtistService(service.Deps{MusicBrainz: mb}), nil))
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/artists/"+testArtistID, nil))
+	if res.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected status 504, got %d", res.Code)
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+const (
+	discographySuffix  = "/discography"
+	artistAlbumsSuffix = "/albums"
+)
+
+// discographyHandler serves GET /artists/{id}/discography: the artist's release groups grouped
+// into studio albums, live albums, compilations, EPs, singles, and everything else.
+func discographyHandler(discography service.DiscographyService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		id, err := parseArtistID(r.URL.Path)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+
+		grouped, err := discography.GetDiscography(r.Context(), id)
+		if err != nil {
+			handleLookupError(w, r, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, grouped)
+	})
+}
+
+// artistAlbumsHandler serves GET /artists/{id}/albums?limit=&offset=&type=: a page of the
+// artist's release groups browsed from MusicBrainz, for paging past the albums embedded in the
+// artist. ?type= takes release group types as /albums does (albums and EPs by default); pass
+// the response's nextOffset back as ?offset= for the following page.
+func artistAlbumsHandler(discography service.DiscographyService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		id, err := parseArtistID(r.URL.Path)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+		query := r.URL.Query()
+		types, err := musicbrainz.ParseReleaseGroupTypes(query.Get("type"))
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"query parameter 'type' must list release group types such as album,ep,live"})
+			return
+		}
+
+		page, err := discography.GetArtistAlbums(r.Context(), id, types, parseSearchLimit(query.Get("limit")), parseSearchOffset(query.Get("offset")))
+		if err != nil {
+			handleLookupError(w, r, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, page)
+	})
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func newDiscographyTestStore(t *testing.T) *db.MemoryStore {
+	t.Helper()
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	if err := store.SaveArtist(context.Background(), &data.Artist{
+		ID:     "self",
+		Name:   "Self",
+		Albums: []data.Album{{ID: "cached", Title: "Cached", PrimaryType: "Album"}},
+	}); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+	return store
+}
+
+func TestDiscographyHandlerGroupsBrowsedReleaseGroups(t *testing.T) {
+	store := newDiscographyTestStore(t)
+	mb := &stubMusicBrainz{
+		getArtistReleaseGroupsOfTypesFunc: func(ctx context.Context, artistID string, types musicbrainz.ReleaseGroupTypes, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			if !types.Includes("Single", nil) {
+				t.Fatalf("expected singles to be browsed, got %v", types)
+			}
+			return &musicbrainz.ReleaseGroupSearchResult{Count: 4, ReleaseGroups: []musicbrainz.ReleaseGroup{
+				{ID: "studio", Title: "Studio", PrimaryType: "Album"},
+				{ID: "live", Title: "Live", PrimaryType: "Album", SecondaryTypes: []string{"Live"}},
+				{ID: "best", Title: "Best Of", PrimaryType: "Album", SecondaryTypes: []string{"Compilation"}},
+				{ID: "single", Title: "Single", PrimaryType: "Single"},
+			}}, nil
+		},
+	}
+
+	handler := discographyHandler(service.NewDiscographyService(service.Deps{Artists: store, MusicBrainz: mb}))
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/artists/self/discography", nil))
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var payload data.Discography
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if len(payload.StudioAlbums) != 1 || payload.StudioAlbums[0].ID != "studio" {
+		t.Errorf("unexpected studio albums %+v", payload.StudioAlbums)
+	}
+	if len(payload.LiveAlbums) != 1 || len(payload.Compilations) != 1 || len(payload.Singles) != 1 {
+		t.Errorf("unexpected groups %+v", payload)
+	}
+}
+
+func TestDiscographyHandlerFallsBackToCachedAlbums(t *testing.T) {
+	store := newDiscographyTestStore(t)
+	mb := &stubMusicBrainz{
+		getArtistReleaseGroupsOfTypesFunc: func(ctx context.Context, artistID string, types musicbrainz.ReleaseGroupTypes, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			return nil, errors.New("unavailable")
+		},
+	}
+
+	handler := discographyHandler(service.NewDiscographyService(service.Deps{Artists: store, MusicBrainz: mb}))
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/artists/self/discography", nil))
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var payload data.Discography
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if len(payload.StudioAlbums) != 1 || payload.StudioAlbums[0].ID != "cached" {
+		t.Errorf("expected the cached album, got %+v", payload.StudioAlbums)
+	}
+}
+
+func TestArtistAlbumsHandlerPagesReleaseGroups(t *testing.T) {
+	mb := &stubMusicBrainz{
+		getArtistReleaseGroupsOfTypesFunc: func(ctx context.Context, artistID string, types musicbrainz.ReleaseGroupTypes, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			if artistID != "self" || limit != 2 || offset != 4 {
+				t.Fatalf("unexpected browse of %q limit %d offset %d", artistID, limit, offset)
+			}
+			if !types.Includes("Album", []string{"Live"}) || types.Includes("EP", nil) {
+				t.Fatalf("expected studio and live albums only, got %v", types)
+			}
+			return &musicbrainz.ReleaseGroupSearchResult{Count: 9, Offset: offset, ReleaseGroups: []musicbrainz.ReleaseGroup{
+				{ID: "fifth", Title: "Fifth", PrimaryType: "Album"},
+				{ID: "sixth", Title: "Sixth", PrimaryType: "Album", SecondaryTypes: []string{"Live"}},
+			}}, nil
+		},
+	}
+
+	handler := artistAlbumsHandler(service.NewDiscographyService(service.Deps{MusicBrainz: mb}))
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/artists/self/albums?limit=2&offset=4&type=album,live", nil))
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var page data.AlbumPage
+	if err := json.Unmarshal(res.Body.Bytes(), &page); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if page.ArtistID != "self" || len(page.Albums) != 2 || page.Total != 9 || page.NextOffset != 6 {
+		t.Errorf("unexpected page %+v", page)
+	}
+}
+
+func TestArtistAlbumsHandlerLastPageAndErrors(t *testing.T) {
+	mb := &stubMusicBrainz{
+		getArtistReleaseGroupsOfTypesFunc: func(ctx context.Context, artistID string, types musicbrainz.ReleaseGroupTypes, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			if artistID == "missing" {
+				return nil, musicbrainz.ErrNotFound
+			}
+			return &musicbrainz.ReleaseGroupSearchResult{Count: 1, ReleaseGroups: []musicbrainz.ReleaseGroup{{ID: "only", PrimaryType: "Album"}}}, nil
+		},
+	}
+	handler := artistAlbumsHandler(service.NewDiscographyService(service.Deps{MusicBrainz: mb}))
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/artists/self/albums", nil))
+	if res.Code != http.StatusOK || !json.Valid(res.Body.Bytes()) {
+		t.Fatalf("expected a page, got %d %s", res.Code, res.Body.String())
+	}
+	var page map[string]any
+	_ = json.Unmarshal(res.Body.Bytes(), &page)
+	if _, ok := page["nextOffset"]; ok {
+		t.Errorf("expected no nextOffset on the last page, got %v", page["nextOffset"])
+	}
+
+	for path, want := range map[string]int{
+		"/artists/missing/albums":        http.StatusNotFound,
+		"/artists/self/albums?type=live": http.StatusBadRequest,
+	} {
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+		if res.Code != want {
+			t.Errorf("%s: expected %d, got %d", path, want, res.Code)
+		}
+	}
+}
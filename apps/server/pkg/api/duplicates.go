@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+)
+
+type duplicatesResponse struct {
+	Groups []db.DuplicateGroup `json:"groups"`
+	Count  int                 `json:"count"`
+}
+
+type mergeRequest struct {
+	Kind   string `json:"kind"`
+	FromID string `json:"fromId"`
+	IntoID string `json:"intoId"`
+}
+
+// duplicatesHandler scans the cache for probable duplicate artists and albums: records stored
+// under merged MBIDs, and records sharing a normalized name and start date or release year.
+func duplicatesHandler(records db.RecordLister, aliases db.AliasRepository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+		if records == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"cache storage unavailable"})
+			return
+		}
+
+		groups, err := db.FindDuplicates(r.Context(), records, aliases)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"duplicate scan failed"})
+			return
+		}
+		if groups == nil {
+			groups = []db.DuplicateGroup{}
+		}
+		writeJSON(w, http.StatusOK, duplicatesResponse{Groups: groups, Count: len(groups)})
+	})
+}
+
+// mergeHandler consolidates one duplicate into its survivor (POST {"kind", "fromId", "intoId"}).
+// The duplicate's MBID keeps resolving through an alias, and owned albums follow the merge.
+func mergeHandler(merger db.Merger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodPost) {
+			return
+		}
+		if merger == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"cache storage unavailable"})
+			return
+		}
+
+		var body mergeRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"request body must be JSON"})
+			return
+		}
+		body.FromID, body.IntoID = strings.TrimSpace(body.FromID), strings.TrimSpace(body.IntoID)
+		if body.FromID == "" || body.IntoID == "" || body.FromID == body.IntoID {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"'fromId' and 'intoId' must be two different ids"})
+			return
+		}
+
+		var result db.MergeResult
+		var err error
+		switch body.Kind {
+		case db.KindArtist:
+			result, err = merger.MergeArtists(r.Context(), body.FromID, body.IntoID)
+		case db.KindAlbum:
+			result, err = merger.MergeAlbums(r.Context(), body.FromID, body.IntoID)
+		default:
+			writeJSON(w, http.StatusBadRequest, errorResponse{"'kind' must be artist or album"})
+			return
+		}
+		switch {
+		case errors.Is(err, db.ErrNotCached):
+			writeJSON(w, http.StatusNotFound, errorResponse{"both records must be cached"})
+			return
+		case err != nil:
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"merge failed"})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, result)
+	})
+}
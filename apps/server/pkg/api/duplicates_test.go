@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+)
+
+func TestDuplicatesHandlerAndMerge(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.NewMemoryStore(ctx)
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	begin := data.LifeSpan{Begin: data.PartialDate{Year: 1987}}
+	_ = store.SaveArtist(ctx, &data.Artist{ID: "nirvana", Name: "Nirvana", LifeSpan: begin, Albums: []data.Album{{ID: "bleach"}}})
+	_ = store.SaveArtist(ctx, &data.Artist{ID: "nirvana-dupe", Name: "The Nirvana", LifeSpan: begin})
+
+	res := httptest.NewRecorder()
+	duplicatesHandler(store, store).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/admin/duplicates", nil))
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var payload duplicatesResponse
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if payload.Count != 1 || payload.Groups[0].IntoID != "nirvana" {
+		t.Fatalf("unexpected duplicates %+v", payload)
+	}
+
+	res = httptest.NewRecorder()
+	body := `{"kind":"artist","fromId":"nirvana-dupe","intoId":"nirvana"}`
+	mergeHandler(store).ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/admin/duplicates/merge", strings.NewReader(body)))
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	if canonical, _ := store.ResolveAlias(ctx, db.KindArtist, "nirvana-dupe"); canonical != "nirvana" {
+		t.Fatalf("expected merged id to alias the survivor, got %q", canonical)
+	}
+
+	// Merging again finds nothing left to consolidate.
+	res = httptest.NewRecorder()
+	mergeHandler(store).ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/admin/duplicates/merge", strings.NewReader(body)))
+	if res.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", res.Code)
+	}
+}
+
+func TestMergeHandlerValidatesRequest(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	for _, body := range []string{
+		`not json`,
+		`{"kind":"label","fromId":"a","intoId":"b"}`,
+		`{"kind":"artist","fromId":"a","intoId":"a"}`,
+	} {
+		res := httptest.NewRecorder()
+		mergeHandler(store).ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/admin/duplicates/merge", strings.NewReader(body)))
+		if res.Code != http.StatusBadRequest {
+			t.Fatalf("body %s: "+status400Fmt, body, res.Code)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// maxPooledBuffer caps the buffers returned to bufferPool so one huge response doesn't pin
+// its memory for the life of the process.
+const maxPooledBuffer = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	writeEncoded(w, status, "application/json", payload)
+}
+
+// writeEncoded encodes payload into a pooled buffer before writing so the response carries a
+// Content-Length and an encoding failure becomes a 500 instead of a truncated body.
+func writeEncoded(w http.ResponseWriter, status int, contentType string, payload any) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			bufferPool.Put(buf)
+		}
+	}()
+
+	if err := json.NewEncoder(buf).Encode(payload); err != nil {
+		slog.Error("encode response failed", "error", err)
+		body := []byte(`{"error":"failed to encode response"}` + "\n")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write(body)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
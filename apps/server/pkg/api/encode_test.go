@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWriteJSONSetsContentLength(t *testing.T) {
+	res := httptest.NewRecorder()
+	writeJSON(res, http.StatusCreated, map[string]string{"name": "Radiohead"})
+
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", res.Code)
+	}
+	if got := res.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected application/json, got %q", got)
+	}
+	if got := res.Header().Get("Content-Length"); got != strconv.Itoa(res.Body.Len()) {
+		t.Errorf("Content-Length = %q, body is %d bytes", got, res.Body.Len())
+	}
+	if got := res.Body.String(); got != "{\"name\":\"Radiohead\"}\n" {
+		t.Errorf("unexpected body %q", got)
+	}
+}
+
+func TestWriteJSONReusesBuffersAcrossResponses(t *testing.T) {
+	large := strings.Repeat("x", maxPooledBuffer)
+	writeJSON(httptest.NewRecorder(), http.StatusOK, map[string]string{"blob": large})
+
+	res := httptest.NewRecorder()
+	writeJSON(res, http.StatusOK, []int{1, 2, 3})
+	if got := res.Body.String(); got != "[1,2,3]\n" {
+		t.Fatalf("expected a fresh body after a large response, got %q", got)
+	}
+}
+
+func TestWriteJSONAnswers500WhenEncodingFails(t *testing.T) {
+	res := httptest.NewRecorder()
+	writeJSON(res, http.StatusOK, map[string]float64{"bad": math.NaN()})
+
+	if res.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", res.Code)
+	}
+	var body errorResponse
+	if err := json.Unmarshal(res.Body.Bytes(), &body); err != nil || body.Error == "" {
+		t.Fatalf("expected an error body, got %q (%v)", res.Body.String(), err)
+	}
+}
+
+func BenchmarkWriteJSON(b *testing.B) {
+	payload := make([]map[string]string, 200)
+	for i := range payload {
+		payload[i] = map[string]string{"id": strconv.Itoa(i), "title": "OK Computer"}
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		writeJSON(httptest.NewRecorder(), http.StatusOK, payload)
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/images"
+)
+
+// defaultImageVariantSize is the size served when a request doesn't name one.
+const defaultImageVariantSize = 256
+
+// imageVariantHandler serves GET /images?url={image url}&size={64|256|640}: the image scaled to
+// fit the size, as WebP when the Accept header allows it and JPEG otherwise. Only URLs on the
+// proxy's allowed hosts are fetched.
+func imageVariantHandler(proxy ImageProxy) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+		if proxy == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"image proxy disabled"})
+			return
+		}
+
+		query := r.URL.Query()
+		imageURL := strings.TrimSpace(query.Get("url"))
+		if imageURL == "" {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"url parameter required"})
+			return
+		}
+		size := defaultImageVariantSize
+		if raw := query.Get("size"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, errorResponse{sizeMessage()})
+				return
+			}
+			size = parsed
+		}
+
+		// The format follows Accept, so shared caches must keep one copy per Accept value.
+		w.Header().Add("Vary", "Accept")
+		format := images.NegotiateFormat(r.Header.Get("Accept"))
+		variant, err := proxy.Variant(r.Context(), imageURL, size, format)
+		switch {
+		case errors.Is(err, images.ErrInvalidSize):
+			writeJSON(w, http.StatusBadRequest, errorResponse{sizeMessage()})
+			return
+		case errors.Is(err, images.ErrHostNotAllowed):
+			writeJSON(w, http.StatusBadRequest, errorResponse{"image host not allowed"})
+			return
+		case err != nil:
+			handleAPIError(w, r, upstreamFailure(err, newAPIError(http.StatusBadGateway, "image fetch failed")))
+			return
+		}
+
+		w.Header().Set("Content-Type", string(format))
+		w.Header().Set("Content-Length", strconv.Itoa(len(variant)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(variant)
+	})
+}
+
+func sizeMessage() string {
+	sizes := make([]string, len(images.VariantSizes))
+	for i, size := range images.VariantSizes {
+		sizes[i] = strconv.Itoa(size)
+	}
+	return fmt.Sprintf("size must be one of %s", strings.Join(sizes, ", "))
+}
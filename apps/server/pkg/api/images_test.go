@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/images"
+)
+
+type stubImageProxy struct {
+	variantFunc func(ctx context.Context, imageURL string, size int, format images.Format) ([]byte, error)
+}
+
+func (s *stubImageProxy) Variant(ctx context.Context, imageURL string, size int, format images.Format) ([]byte, error) {
+	return s.variantFunc(ctx, imageURL, size, format)
+}
+
+func TestImageVariantHandler(t *testing.T) {
+	proxy := &stubImageProxy{variantFunc: func(ctx context.Context, imageURL string, size int, format images.Format) ([]byte, error) {
+		switch {
+		case !slices.Contains(images.VariantSizes, size):
+			return nil, images.ErrInvalidSize
+		case !strings.HasPrefix(imageURL, "https://coverartarchive.org/"):
+			return nil, images.ErrHostNotAllowed
+		case strings.HasSuffix(imageURL, "/missing.jpg"):
+			return nil, errors.New("images: fetch returned 404")
+		}
+		return []byte(string(format) + " bytes"), nil
+	}}
+	handler := imageVariantHandler(proxy)
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/images?url=https://coverartarchive.org/front.jpg", nil))
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	if got := res.Header().Get("Content-Type"); got != "image/jpeg" {
+		t.Errorf("expected image/jpeg, got %q", got)
+	}
+	if res.Body.String() != "image/jpeg bytes" {
+		t.Errorf("unexpected body %q", res.Body.String())
+	}
+	if got := res.Header().Get("Vary"); got != "Accept" {
+		t.Errorf("expected Vary: Accept, got %q", got)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/images?url=https://coverartarchive.org/front.jpg&size=64", nil)
+	req.Header.Set("Accept", "image/avif,image/webp,image/apng,image/*,*/*;q=0.8")
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+	if got := res.Header().Get("Content-Type"); got != "image/webp" || res.Body.String() != "image/webp bytes" {
+		t.Errorf("expected a WebP variant for a browser accepting it, got %q with %q", got, res.Body.String())
+	}
+
+	for _, tc := range []struct {
+		name, target string
+		status       int
+	}{
+		{"missing url", "/images", http.StatusBadRequest},
+		{"unsupported size", "/images?url=https://coverartarchive.org/front.jpg&size=100", http.StatusBadRequest},
+		{"non-numeric size", "/images?url=https://coverartarchive.org/front.jpg&size=big", http.StatusBadRequest},
+		{"host not allowed", "/images?url=http://169.254.169.254/latest", http.StatusBadRequest},
+		{"upstream failure", "/images?url=https://coverartarchive.org/missing.jpg", http.StatusBadGateway},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, tc.target, nil))
+			if res.Code != tc.status {
+				t.Errorf("expected status %d, got %d", tc.status, res.Code)
+			}
+		})
+	}
+}
+
+func TestImageVariantHandlerDisabled(t *testing.T) {
+	res := httptest.NewRecorder()
+	imageVariantHandler(nil).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/images?url=https://coverartarchive.org/front.jpg", nil))
+	if res.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", res.Code)
+	}
+}
+
+func TestImageVariantsAreCachedAsImmutable(t *testing.T) {
+	proxy := &stubImageProxy{variantFunc: func(ctx context.Context, imageURL string, size int, format images.Format) ([]byte, error) {
+		if strings.HasSuffix(imageURL, "/missing.jpg") {
+			return nil, errors.New("images: fetch returned 404")
+		}
+		return []byte("image bytes"), nil
+	}}
+	router := NewRouter(RouterConfig{ImageProxy: proxy, CacheControl: CachePolicies{Entity: time.Minute}})
+
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/images?url=https://coverartarchive.org/front.jpg", nil))
+	if got := res.Header().Get("Cache-Control"); got != "public, max-age=31536000, immutable" {
+		t.Errorf("expected an immutable Cache-Control, got %q", got)
+	}
+
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/images?url=https://coverartarchive.org/missing.jpg", nil))
+	if got := res.Header().Get("Cache-Control"); got != "no-store" {
+		t.Errorf("expected a failed fetch kept out of caches, got %q", got)
+	}
+}
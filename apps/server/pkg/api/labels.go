@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+)
+
+// labelLookupHandler serves GET /labels/{id}: the label's details and the albums released on it.
+func labelLookupHandler(labels service.LabelService, modified modifiedFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		id, err := parseLabelID(r.URL.Path)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+
+		label, err := labels.GetLabel(r.Context(), id)
+		if err != nil {
+			handleAPIError(w, r, err)
+			return
+		}
+
+		if notModified(w, r, modified, label.ID) {
+			return
+		}
+		writeJSON(w, http.StatusOK, label)
+	})
+}
+
+func parseLabelID(path string) (string, error) {
+	return parseResourceID(path, "/labels/", "label id required")
+}
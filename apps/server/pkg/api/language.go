@@ -0,0 +1,52 @@
+package api
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxPreferredLanguages bounds how many Accept-Language entries a lookup tries, since each
+// non-English one may cost a Wikipedia request.
+const maxPreferredLanguages = 3
+
+// parseAcceptLanguage returns the language tags from an Accept-Language header in order of
+// preference, dropping the wildcard, entries with q=0, and malformed weights.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var entries []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				continue
+			}
+			q = parsed
+		}
+		if q == 0 {
+			continue
+		}
+		entries = append(entries, weighted{tag, q})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].q > entries[j].q
+	})
+	if len(entries) > maxPreferredLanguages {
+		entries = entries[:maxPreferredLanguages]
+	}
+	tags := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		tags = append(tags, entry.tag)
+	}
+	return tags
+}
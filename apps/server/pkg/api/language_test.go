@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	cases := map[string][]string{
+		"":                                   {},
+		"de":                                 {"de"},
+		"fr;q=0.5, ja-JP, *;q=0.1":           {"ja-JP", "fr"},
+		"en-GB;q=0, de;q=abc, es":            {"es"},
+		"a, b;q=0.9, c;q=0.8, d;q=0.7, e":    {"a", "e", "b"},
+		" pt-BR ; q=0.8 , it ; q=0.9 , nl  ": {"nl", "it", "pt-BR"},
+	}
+	for header, want := range cases {
+		if got := parseAcceptLanguage(header); !slices.Equal(got, want) {
+			t.Errorf("parseAcceptLanguage(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
+
+func TestArtistLookupHandlerEchoesLocale(t *testing.T) {
+	repo := &stubArtistRepo{
+		getFunc: func(ctx context.Context, id string) (*data.Artist, error) {
+			return &data.Artist{ID: testArtistID, Name: "Cached"}, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, artistPath, nil)
+	req.Header.Set("Accept-Language", "en-GB, fr;q=0.5")
+	res := httptest.NewRecorder()
+	artistLookupHandler(service.NewArtistService(service.Deps{Artists: repo}), nil).ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	if got := res.Header().Get("Content-Language"); got != "en-GB" {
+		t.Errorf("expected Content-Language en-GB, got %q", got)
+	}
+	if got := res.Header().Get("Vary"); got != "Accept-Language" {
+		t.Errorf("expected Vary: Accept-Language, got %q", got)
+	}
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/localfiles"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+// minReleaseGroupScore is the lowest MusicBrainz search score accepted for an artist + title match.
+const minReleaseGroupScore = 90
+
+// LibraryScanner captures the local music folder operations the router relies on.
+type LibraryScanner interface {
+	Scan(ctx context.Context) ([]localfiles.AlbumTags, error)
+}
+
+type libraryScanResponse struct {
+	Scanned   int                  `json:"scanned"`
+	Matched   int                  `json:"matched"`
+	Unmatched []unmatchedLocalFile `json:"unmatched"`
+}
+
+type unmatchedLocalFile struct {
+	Path       string `json:"path"`
+	ArtistName string `json:"artistName"`
+	Title      string `json:"title"`
+	Reason     string `json:"reason"`
+}
+
+func ownedAlbumsHandler(repo db.LibraryRepository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+		if repo == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"library storage unavailable"})
+			return
+		}
+
+		owned, err := repo.ListOwnedAlbums(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"owned album lookup failed"})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{
+			"albums": owned,
+			"count":  len(owned),
+		})
+	})
+}
+
+func libraryScanHandler(repo db.LibraryRepository, scanner LibraryScanner, mbClient MusicBrainzClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodPost) {
+			return
+		}
+
+		result, err := scanLibrary(r.Context(), repo, scanner, mbClient)
+		if err != nil {
+			handleAPIError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, result)
+	})
+}
+
+func scanLibrary(ctx context.Context, repo db.LibraryRepository, scanner LibraryScanner, mbClient MusicBrainzClient) (*libraryScanResponse, error) {
+	if scanner == nil {
+		return nil, newAPIError(http.StatusServiceUnavailable, "library scanner not configured")
+	}
+	if repo == nil {
+		return nil, newAPIError(http.StatusServiceUnavailable, "library storage unavailable")
+	}
+
+	albums, err := scanner.Scan(ctx)
+	if err != nil {
+		return nil, newAPIError(http.StatusInternalServerError, "library scan failed")
+	}
+
+	result := &libraryScanResponse{
+		Scanned:   len(albums),
+		Unmatched: make([]unmatchedLocalFile, 0),
+	}
+	scannedAt := time.Now().UTC()
+
+	for _, local := range albums {
+		owned, reason := matchLocalAlbum(ctx, mbClient, local)
+		if owned == nil {
+			result.Unmatched = append(result.Unmatched, unmatchedLocalFile{
+				Path:       local.Path,
+				ArtistName: local.Artist,
+				Title:      local.Album,
+				Reason:     reason,
+			})
+			continue
+		}
+
+		owned.Path = local.Path
+		owned.TrackCount = local.TrackCount
+		owned.ScannedAt = scannedAt
+		if err := repo.MarkAlbumOwned(ctx, owned); err != nil {
+			return nil, newAPIError(http.StatusInternalServerError, "owned album save failed")
+		}
+		result.Matched++
+	}
+
+	return result, nil
+}
+
+// matchLocalAlbum resolves a scanned album to a release group. Embedded release group IDs are
+// trusted as-is; release IDs and bare artist/title tags go through a MusicBrainz search.
+func matchLocalAlbum(ctx context.Context, client MusicBrainzClient, local localfiles.AlbumTags) (*data.OwnedAlbum, string) {
+	if local.ReleaseGroupID != "" {
+		return &data.OwnedAlbum{
+			AlbumID:    local.ReleaseGroupID,
+			Title:      local.Album,
+			ArtistID:   local.ArtistID,
+			ArtistName: local.Artist,
+		}, ""
+	}
+
+	if client == nil {
+		return nil, "musicbrainz client unavailable"
+	}
+
+	var query string
+	switch {
+	case local.ReleaseID != "":
+		query = "reid:" + local.ReleaseID
+	case strings.TrimSpace(local.Album) != "" && strings.TrimSpace(local.Artist) != "":
+		query = `releasegroup:"` + escapeLuceneTerm(local.Album) + `" AND artist:"` + escapeLuceneTerm(local.Artist) + `"`
+	default:
+		return nil, "missing artist or album tags"
+	}
+
+	result, err := client.SearchReleaseGroups(ctx, query, 5, 0)
+	if err != nil {
+		return nil, "musicbrainz lookup failed"
+	}
+
+	for i := range result.ReleaseGroups {
+		rg := &result.ReleaseGroups[i]
+		if local.ReleaseID == "" && rg.Score < minReleaseGroupScore {
+			continue
+		}
+		return ownedFromReleaseGroup(rg, local), ""
+	}
+	return nil, "no musicbrainz match"
+}
+
+func ownedFromReleaseGroup(rg *musicbrainz.ReleaseGroup, local localfiles.AlbumTags) *data.OwnedAlbum {
+	owned := &data.OwnedAlbum{
+		AlbumID:    rg.ID,
+		Title:      rg.Title,
+		ArtistID:   rg.PrimaryArtistID(),
+		ArtistName: rg.PrimaryArtistName(),
+	}
+	if owned.ArtistID == "" {
+		owned.ArtistID = local.ArtistID
+	}
+	if owned.ArtistName == "" {
+		owned.ArtistName = local.Artist
+	}
+	return owned
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+type basePathKey struct{}
+
+// basePath returns the prefix the API is mounted under, or "" at the root. Handlers building
+// absolute links and redirects prepend it.
+func basePath(ctx context.Context) string {
+	prefix, _ := ctx.Value(basePathKey{}).(string)
+	return prefix
+}
+
+// mountMiddleware serves next under prefix, stripping it before routing, so the API can sit
+// behind a reverse proxy path such as /api. Requests outside the prefix get 404.
+func mountMiddleware(prefix string, next http.Handler) http.Handler {
+	if prefix == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, prefix)
+		if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+			writeJSON(w, http.StatusNotFound, errorResponse{"not found"})
+			return
+		}
+		if rest == "" {
+			rest = "/"
+		}
+
+		mounted := r.Clone(context.WithValue(r.Context(), basePathKey{}, prefix))
+		mounted.URL.Path = rest
+		mounted.URL.RawPath = ""
+		next.ServeHTTP(w, mounted)
+	})
+}
+
+// hostMiddleware answers 421 to requests whose Host is not listed, so a shared proxy cannot
+// route another site's traffic here. Entries without a port match any port. Health checks are
+// exempt because probes usually address the server by IP. An empty list accepts every host.
+func hostMiddleware(allowed []string, next http.Handler) http.Handler {
+	if len(allowed) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" || r.URL.Path == "/readyz" || hostAllowed(allowed, r.Host) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		writeJSON(w, http.StatusMisdirectedRequest, errorResponse{"host not served here"})
+	})
+}
+
+func hostAllowed(allowed []string, host string) bool {
+	host = strings.ToLower(host)
+	if slices.Contains(allowed, host) {
+		return true
+	}
+	hostname, _, err := net.SplitHostPort(host)
+	if err != nil {
+		return false
+	}
+	return slices.Contains(allowed, hostname)
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+)
+
+func TestMountMiddlewareServesUnderPrefix(t *testing.T) {
+	var seen string
+	handler := mountMiddleware("/api", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		path   string
+		status int
+		routed string
+	}{
+		{path: "/api/artists/123", status: http.StatusNoContent, routed: "/artists/123"},
+		{path: "/api", status: http.StatusNoContent, routed: "/"},
+		{path: "/artists/123", status: http.StatusNotFound},
+		{path: "/apiary/artists", status: http.StatusNotFound},
+	}
+	for _, tc := range tests {
+		seen = ""
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if res.Code != tc.status || seen != tc.routed {
+			t.Errorf("%s: status %d routed to %q, want %d and %q", tc.path, res.Code, seen, tc.status, tc.routed)
+		}
+	}
+}
+
+func TestMountedAliasRedirectKeepsPrefix(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	if err := store.SaveAlias(context.Background(), db.KindAlbum, "old-album", testAlbumID); err != nil {
+		t.Fatalf("SaveAlias: %v", err)
+	}
+	lookup := albumLookupHandler(service.NewAlbumService(service.Deps{Albums: store, Aliases: store, MusicBrainz: &stubMusicBrainz{}, Reviews: &stubReviews{}}), nil)
+
+	res := httptest.NewRecorder()
+	mountMiddleware("/api", lookup).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/albums/old-album", nil))
+
+	if res.Code != http.StatusMovedPermanently {
+		t.Fatalf("expected status 301, got %d", res.Code)
+	}
+	if got := res.Header().Get("Location"); got != "/api"+albumPath {
+		t.Fatalf("unexpected redirect location %q", got)
+	}
+}
+
+func TestHostMiddleware(t *testing.T) {
+	handler := hostMiddleware([]string{"freqshow.example.com", "localhost:8080"}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		host   string
+		path   string
+		status int
+	}{
+		{host: "freqshow.example.com", path: "/search", status: http.StatusNoContent},
+		{host: "FreqShow.example.com:443", path: "/search", status: http.StatusNoContent},
+		{host: "localhost:8080", path: "/search", status: http.StatusNoContent},
+		{host: "localhost:9090", path: "/search", status: http.StatusMisdirectedRequest},
+		{host: "evil.example.com", path: "/search", status: http.StatusMisdirectedRequest},
+		{host: "10.0.0.7:8080", path: "/healthz", status: http.StatusNoContent},
+	}
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		req.Host = tc.host
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+		if res.Code != tc.status {
+			t.Errorf("%s%s: status %d, want %d", tc.host, tc.path, res.Code, tc.status)
+		}
+	}
+}
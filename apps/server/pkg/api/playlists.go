@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/spotify"
+)
+
+// minRecordingScore is the lowest MusicBrainz search score accepted for a title/artist match.
+const minRecordingScore = 90
+
+// SpotifyClient captures the Spotify operations the router relies on.
+type SpotifyClient interface {
+	GetPlaylist(ctx context.Context, reference string) (*spotify.Playlist, error)
+}
+
+type spotifyImportRequest struct {
+	Playlist string `json:"playlist"`
+}
+
+type playlistImportResponse struct {
+	Playlist  *data.Playlist   `json:"playlist"`
+	Matched   int              `json:"matched"`
+	Unmatched []unmatchedTrack `json:"unmatched"`
+}
+
+type unmatchedTrack struct {
+	Position   int    `json:"position"`
+	Title      string `json:"title"`
+	ArtistName string `json:"artistName"`
+	AlbumTitle string `json:"albumTitle,omitempty"`
+	ISRC       string `json:"isrc,omitempty"`
+	Reason     string `json:"reason"`
+}
+
+func playlistLookupHandler(repo db.PlaylistRepository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		id, err := parseResourceID(r.URL.Path, "/playlists/", "playlist id required")
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+
+		if repo == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"playlist storage unavailable"})
+			return
+		}
+
+		playlist, err := repo.GetPlaylist(r.Context(), id)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"playlist lookup failed"})
+			return
+		}
+		if playlist == nil {
+			writeJSON(w, http.StatusNotFound, errorResponse{"playlist not found"})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, playlist)
+	})
+}
+
+func spotifyImportHandler(repo db.PlaylistRepository, spotifyClient SpotifyClient, mbClient MusicBrainzClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodPost) {
+			return
+		}
+
+		var body spotifyImportRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || strings.TrimSpace(body.Playlist) == "" {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"request body must include a 'playlist' URL or ID"})
+			return
+		}
+
+		result, err := importSpotifyPlaylist(r.Context(), repo, spotifyClient, mbClient, body.Playlist)
+		if err != nil {
+			handleAPIError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, result)
+	})
+}
+
+func importSpotifyPlaylist(ctx context.Context, repo db.PlaylistRepository, spotifyClient SpotifyClient, mbClient MusicBrainzClient, reference string) (*playlistImportResponse, error) {
+	if spotifyClient == nil {
+		return nil, newAPIError(http.StatusServiceUnavailable, "spotify client unavailable")
+	}
+	if mbClient == nil {
+		return nil, newAPIError(http.StatusServiceUnavailable, "musicbrainz client unavailable")
+	}
+
+	remote, err := spotifyClient.GetPlaylist(ctx, reference)
+	if err != nil {
+		switch {
+		case errors.Is(err, spotify.ErrInvalidPlaylist):
+			return nil, newAPIError(http.StatusBadRequest, "invalid spotify playlist reference")
+		case errors.Is(err, spotify.ErrNotFound):
+			return nil, newAPIError(http.StatusNotFound, "spotify playlist not found")
+		default:
+			return nil, newAPIError(http.StatusBadGateway, "spotify lookup failed")
+		}
+	}
+
+	playlist := &data.Playlist{
+		ID:          "spotify-" + remote.ID,
+		Name:        remote.Name,
+		Description: remote.Description,
+		Source:      "spotify",
+		SourceID:    remote.ID,
+		SourceURL:   remote.URL,
+	}
+	unmatched := make([]unmatchedTrack, 0)
+
+	for i, track := range remote.Tracks {
+		position := i + 1
+		recording, err := matchRecording(ctx, mbClient, track)
+		if err != nil || recording == nil {
+			reason := "no musicbrainz match"
+			if err != nil {
+				reason = "musicbrainz lookup failed"
+			}
+			unmatched = append(unmatched, unmatchedTrack{
+				Position:   position,
+				Title:      track.Name,
+				ArtistName: strings.Join(track.Artists, ", "),
+				AlbumTitle: track.Album,
+				ISRC:       track.ISRC,
+				Reason:     reason,
+			})
+			continue
+		}
+
+		artistName := recording.PrimaryArtistName()
+		if artistName == "" {
+			artistName = track.PrimaryArtist()
+		}
+		playlist.Tracks = append(playlist.Tracks, data.PlaylistTrack{
+			Position:    position,
+			RecordingID: recording.ID,
+			Title:       recording.Title,
+			ArtistName:  artistName,
+			AlbumTitle:  track.Album,
+			ISRC:        track.ISRC,
+		})
+	}
+
+	if repo != nil {
+		if err := repo.SavePlaylist(ctx, playlist); err != nil {
+			return nil, newAPIError(http.StatusInternalServerError, "playlist save failed")
+		}
+	}
+
+	return &playlistImportResponse{
+		Playlist:  playlist,
+		Matched:   len(playlist.Tracks),
+		Unmatched: unmatched,
+	}, nil
+}
+
+// matchRecording resolves a Spotify track to a MusicBrainz recording, preferring an exact
+// ISRC match and falling back to a scored title + artist search.
+func matchRecording(ctx context.Context, client MusicBrainzClient, track spotify.Track) (*musicbrainz.Recording, error) {
+	if track.ISRC != "" {
+		result, err := client.SearchRecordings(ctx, "isrc:"+track.ISRC, 1, 0)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Recordings) > 0 {
+			return &result.Recordings[0], nil
+		}
+	}
+
+	artist := track.PrimaryArtist()
+	if strings.TrimSpace(track.Name) == "" || strings.TrimSpace(artist) == "" {
+		return nil, nil
+	}
+
+	query := `recording:"` + escapeLuceneTerm(track.Name) + `" AND artist:"` + escapeLuceneTerm(artist) + `"`
+	result, err := client.SearchRecordings(ctx, query, 5, 0)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Recordings {
+		if result.Recordings[i].Score >= minRecordingScore {
+			return &result.Recordings[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// escapeLuceneTerm escapes characters that would terminate a quoted Lucene phrase.
+func escapeLuceneTerm(term string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return replacer.Replace(strings.TrimSpace(term))
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/spotify"
+)
+
+const spotifyImportPath = "/playlists/import/spotify"
+
+type stubSpotify struct {
+	getPlaylistFunc func(ctx context.Context, reference string) (*spotify.Playlist, error)
+}
+
+func (s *stubSpotify) GetPlaylist(ctx context.Context, reference string) (*spotify.Playlist, error) {
+	if s.getPlaylistFunc != nil {
+		return s.getPlaylistFunc(ctx, reference)
+	}
+	return nil, spotify.ErrNotFound
+}
+
+type stubPlaylistRepo struct {
+	saved *data.Playlist
+}
+
+func (s *stubPlaylistRepo) GetPlaylist(ctx context.Context, id string) (*data.Playlist, error) {
+	if s.saved != nil && s.saved.ID == id {
+		return s.saved, nil
+	}
+	return nil, nil
+}
+
+func (s *stubPlaylistRepo) SavePlaylist(ctx context.Context, playlist *data.Playlist) error {
+	s.saved = playlist
+	return nil
+}
+
+func (s *stubPlaylistRepo) ListPlaylists(ctx context.Context) ([]data.Playlist, error) {
+	if s.saved == nil {
+		return []data.Playlist{}, nil
+	}
+	return []data.Playlist{*s.saved}, nil
+}
+
+func TestSpotifyImportMatchesAndReportsUnmatched(t *testing.T) {
+	sp := &stubSpotify{
+		getPlaylistFunc: func(ctx context.Context, reference string) (*spotify.Playlist, error) {
+			return &spotify.Playlist{
+				ID:   "abc123",
+				Name: "Road Trip",
+				Tracks: []spotify.Track{
+					{Name: "Smells Like Teen Spirit", Artists: []string{"Nirvana"}, ISRC: "USGF19942501"},
+					{Name: "Obscure B-Side", Artists: []string{"Nobody"}},
+				},
+			}, nil
+		},
+	}
+	mb := &stubMusicBrainz{
+		searchRecordingsFunc: func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.RecordingSearchResult, error) {
+			if query == "isrc:USGF19942501" {
+				return &musicbrainz.RecordingSearchResult{Recordings: []musicbrainz.Recording{{ID: "rec-1", Title: "Smells Like Teen Spirit", Score: 100}}}, nil
+			}
+			return &musicbrainz.RecordingSearchResult{Recordings: []musicbrainz.Recording{{ID: "rec-x", Title: "Something Else", Score: 40}}}, nil
+		},
+	}
+	repo := &stubPlaylistRepo{}
+
+	req := httptest.NewRequest(http.MethodPost, spotifyImportPath, strings.NewReader(`{"playlist":"https://open.spotify.com/playlist/abc123"}`))
+	res := httptest.NewRecorder()
+
+	spotifyImportHandler(repo, sp, mb).ServeHTTP(res, req)
+
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", res.Code)
+	}
+
+	var payload playlistImportResponse
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if payload.Matched != 1 || len(payload.Unmatched) != 1 {
+		t.Fatalf("expected 1 matched and 1 unmatched, got %d/%d", payload.Matched, len(payload.Unmatched))
+	}
+	if payload.Unmatched[0].Position != 2 {
+		t.Errorf("expected unmatched position 2, got %d", payload.Unmatched[0].Position)
+	}
+	if repo.saved == nil || repo.saved.ID != "spotify-abc123" {
+		t.Fatalf("expected playlist to be saved, got %#v", repo.saved)
+	}
+	if repo.saved.Tracks[0].RecordingID != "rec-1" {
+		t.Errorf("expected matched recording id, got %q", repo.saved.Tracks[0].RecordingID)
+	}
+}
+
+func TestSpotifyImportUnavailableWithoutClient(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, spotifyImportPath, strings.NewReader(`{"playlist":"abc123"}`))
+	res := httptest.NewRecorder()
+
+	spotifyImportHandler(&stubPlaylistRepo{}, nil, &stubMusicBrainz{}).ServeHTTP(res, req)
+
+	if res.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", res.Code)
+	}
+}
+
+func TestSpotifyImportRequiresPlaylist(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, spotifyImportPath, strings.NewReader(`{}`))
+	res := httptest.NewRecorder()
+
+	spotifyImportHandler(&stubPlaylistRepo{}, &stubSpotify{}, &stubMusicBrainz{}).ServeHTTP(res, req)
+
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf(status400Fmt, res.Code)
+	}
+}
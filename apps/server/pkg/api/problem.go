@@ -0,0 +1,52 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+)
+
+// problemUpstreamRateLimited is the problem code sent when a required upstream rate-limits a
+// lookup.
+const problemUpstreamRateLimited = "upstream_rate_limited"
+
+// problemResponse is an RFC 9457 problem document. Code identifies the problem for clients;
+// Error repeats Detail so clients reading the usual errorResponse shape keep working.
+type problemResponse struct {
+	Type       string `json:"type"`
+	Title      string `json:"title"`
+	Status     int    `json:"status"`
+	Detail     string `json:"detail,omitempty"`
+	Code       string `json:"code"`
+	RetryAfter int    `json:"retryAfter,omitempty"`
+	Error      string `json:"error"`
+}
+
+// writeRateLimited answers 503 with Retry-After so clients back off for as long as the upstream
+// asked, or a second when it didn't say.
+func writeRateLimited(w http.ResponseWriter, detail string, wait time.Duration) {
+	seconds := max(retry.Seconds(wait), 1)
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	writeEncoded(w, http.StatusServiceUnavailable, "application/problem+json", problemResponse{
+		Type:       "about:blank",
+		Title:      http.StatusText(http.StatusServiceUnavailable),
+		Status:     http.StatusServiceUnavailable,
+		Detail:     detail,
+		Code:       problemUpstreamRateLimited,
+		RetryAfter: seconds,
+		Error:      detail,
+	})
+}
+
+// upstreamFailure keeps a source's rate-limit error so handleAPIError can answer 503 with
+// Retry-After; any other failure becomes fallback.
+func upstreamFailure(err, fallback error) error {
+	var limited *retry.RateLimitedError
+	if errors.As(err, &limited) {
+		return limited
+	}
+	return fallback
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+)
+
+func TestArtistLookupSurfacesUpstreamRateLimit(t *testing.T) {
+	mb := &stubMusicBrainz{
+		lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			return nil, &retry.RateLimitedError{Source: "musicbrainz", RetryAfter: 2500 * time.Millisecond}
+		},
+	}
+	handler := artistLookupHandler(service.NewArtistService(service.Deps{MusicBrainz: mb}), nil)
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/artists/5b11f4ce-a62d-471e-81fc-a69a8278c7da", nil))
+
+	if res.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", res.Code)
+	}
+	if got := res.Header().Get("Retry-After"); got != "3" {
+		t.Errorf("expected Retry-After 3, got %q", got)
+	}
+	if got := res.Header().Get("Content-Type"); got != "application/problem+json" {
+		t.Errorf("expected a problem document, got %q", got)
+	}
+	var problem problemResponse
+	if err := json.Unmarshal(res.Body.Bytes(), &problem); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if problem.Code != problemUpstreamRateLimited || problem.Status != http.StatusServiceUnavailable || problem.Error == "" {
+		t.Errorf("unexpected problem %+v", problem)
+	}
+}
+
+func TestSearchSurfacesUpstreamRateLimitWithoutRetryAfter(t *testing.T) {
+	mb := &stubMusicBrainz{
+		searchArtistsFunc: func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.SearchResult, error) {
+			return nil, &retry.RateLimitedError{Source: "musicbrainz"}
+		},
+	}
+
+	res := httptest.NewRecorder()
+	searchHandler(mb, nil).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/search?q=nirvana", nil))
+
+	if res.Code != http.StatusServiceUnavailable || res.Header().Get("Retry-After") != "1" {
+		t.Errorf("expected 503 with a one-second Retry-After, got %d %q", res.Code, res.Header().Get("Retry-After"))
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+)
+
+// qualityDrillDown lists the cached records behind one line of the quality report.
+type qualityDrillDown struct {
+	Entity  string                `json:"entity"`
+	Missing string                `json:"missing,omitempty"`
+	Total   int                   `json:"total"`
+	Offset  int                   `json:"offset"`
+	Records []db.IncompleteRecord `json:"records"`
+}
+
+// qualityHandler serves GET /admin/quality, the share of cached artists and albums missing a
+// biography, image, cover, tracks, reviews, and so on. Adding ?entity=artist|album lists the
+// incomplete records themselves, narrowed by ?missing=<field> and paged with limit and offset,
+// so re-enrichment can target exactly the records that need it.
+func qualityHandler(records db.RecordLister) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+		if records == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"cache storage unavailable"})
+			return
+		}
+
+		query := r.URL.Query()
+		entity := strings.ToLower(strings.TrimSpace(query.Get("entity")))
+		if entity == "" {
+			report, err := db.BuildQualityReport(r.Context(), records)
+			if err != nil {
+				writeJSON(w, http.StatusInternalServerError, errorResponse{"quality report failed"})
+				return
+			}
+			writeJSON(w, http.StatusOK, report)
+			return
+		}
+
+		fields := db.QualityFields(entity)
+		if fields == nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"query parameter 'entity' must be artist or album"})
+			return
+		}
+		missing := strings.ToLower(strings.TrimSpace(query.Get("missing")))
+		if missing != "" && !slices.Contains(fields, missing) {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"query parameter 'missing' must be one of: " + strings.Join(fields, ", ")})
+			return
+		}
+
+		incomplete, err := db.IncompleteRecords(r.Context(), records, entity, missing)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"quality report failed"})
+			return
+		}
+
+		limit := parseSearchLimit(query.Get("limit"))
+		offset := parseSearchOffset(query.Get("offset"))
+		page := []db.IncompleteRecord{}
+		if offset < len(incomplete) {
+			page = incomplete[offset:min(offset+limit, len(incomplete))]
+		}
+
+		writeJSON(w, http.StatusOK, qualityDrillDown{
+			Entity:  entity,
+			Missing: missing,
+			Total:   len(incomplete),
+			Offset:  offset,
+			Records: page,
+		})
+	})
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+)
+
+func TestQualityHandlerDrillDown(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.NewMemoryStore(ctx)
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	_ = store.SaveArtist(ctx, &data.Artist{ID: "a1", Name: "Has Bio", Biography: "bio"})
+	_ = store.SaveArtist(ctx, &data.Artist{ID: "a2", Name: "No Bio"})
+	_ = store.SaveArtist(ctx, &data.Artist{ID: "a3", Name: "Also No Bio"})
+
+	res := httptest.NewRecorder()
+	qualityHandler(store).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/admin/quality?entity=artist&missing=biography&limit=1&offset=1", nil))
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var payload qualityDrillDown
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if payload.Total != 2 || len(payload.Records) != 1 || payload.Records[0].ID != "a3" {
+		t.Fatalf("unexpected drill-down %+v", payload)
+	}
+
+	for _, target := range []string{"/admin/quality?entity=label", "/admin/quality?entity=album&missing=biography"} {
+		res = httptest.NewRecorder()
+		qualityHandler(store).ServeHTTP(res, httptest.NewRequest(http.MethodGet, target, nil))
+		if res.Code != http.StatusBadRequest {
+			t.Fatalf("%s: "+status400Fmt, target, res.Code)
+		}
+	}
+}
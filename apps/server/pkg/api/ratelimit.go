@@ -0,0 +1,146 @@
+package api
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// problemClientRateLimited is the problem code sent when a client exceeds its own rate limit.
+const problemClientRateLimited = "rate_limited"
+
+// maxClientBuckets bounds the per-client state; past it, buckets that have refilled are
+// dropped, since a new bucket starts full anyway.
+const maxClientBuckets = 10000
+
+// ClientRateLimit caps how fast each client may call the API.
+type ClientRateLimit struct {
+	// RPS is the sustained requests per second allowed per client; zero disables limiting.
+	RPS float64
+	// Burst is how many requests a client may make at once; below one it is RPS rounded up.
+	Burst int
+	// IPHeader names the header a trusted reverse proxy puts the caller's IP in. Empty uses
+	// the connection's address.
+	IPHeader string
+}
+
+// clientRateLimitMiddleware gives each client a token bucket, keyed by API key name when the
+// request carried one and by IP otherwise, and answers 429 with Retry-After once it is empty.
+// Public paths such as the health checks are never limited.
+func clientRateLimitMiddleware(cfg ClientRateLimit, publicPaths []string, next http.Handler) http.Handler {
+	if cfg.RPS <= 0 {
+		return next
+	}
+	limiter := newClientLimiter(cfg.RPS, cfg.Burst, time.Now)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !slices.Contains(publicPaths, r.URL.Path) {
+			if wait := limiter.take(clientKey(r, cfg.IPHeader)); wait > 0 {
+				writeTooManyRequests(w, wait)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientKey identifies the caller for rate limiting.
+func clientKey(r *http.Request, ipHeader string) string {
+	if name := clientName(r.Context()); name != "" {
+		return "key:" + name
+	}
+	if ipHeader != "" {
+		// Proxies that append to the header put the address they saw last.
+		values := strings.Split(r.Header.Get(ipHeader), ",")
+		if ip := strings.TrimSpace(values[len(values)-1]); ip != "" {
+			return "ip:" + ip
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// writeTooManyRequests answers 429 with Retry-After rounded up to whole seconds.
+func writeTooManyRequests(w http.ResponseWriter, wait time.Duration) {
+	seconds := max(int(math.Ceil(wait.Seconds())), 1)
+	detail := "too many requests; retry after " + strconv.Itoa(seconds) + "s"
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	writeEncoded(w, http.StatusTooManyRequests, "application/problem+json", problemResponse{
+		Type:       "about:blank",
+		Title:      http.StatusText(http.StatusTooManyRequests),
+		Status:     http.StatusTooManyRequests,
+		Detail:     detail,
+		Code:       problemClientRateLimited,
+		RetryAfter: seconds,
+		Error:      detail,
+	})
+}
+
+// clientLimiter holds one token bucket per client, each refilled at rate tokens per second up
+// to burst.
+type clientLimiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*clientBucket
+}
+
+type clientBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func newClientLimiter(rate float64, burst int, now func() time.Time) *clientLimiter {
+	if burst < 1 {
+		burst = int(math.Ceil(rate))
+	}
+	return &clientLimiter{rate: rate, burst: float64(burst), now: now, buckets: make(map[string]*clientBucket)}
+}
+
+// take spends one of key's tokens, returning zero, or how long until a token is available
+// when the bucket is empty.
+func (l *clientLimiter) take(key string) time.Duration {
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxClientBuckets {
+			l.dropRefilled(now)
+		}
+		bucket = &clientBucket{tokens: l.burst, updated: now}
+		l.buckets[key] = bucket
+	} else {
+		bucket.tokens = min(l.burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*l.rate)
+		bucket.updated = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0
+	}
+	return time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+}
+
+// dropRefilled forgets buckets that would be full by now. If every client is still active the
+// map is cleared, trading a moment of leniency for bounded memory.
+func (l *clientLimiter) dropRefilled(now time.Time) {
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.updated).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+	if len(l.buckets) >= maxClientBuckets {
+		clear(l.buckets)
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientLimiterRefillsAtRate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newClientLimiter(2, 3, func() time.Time { return now })
+
+	for i := range 3 {
+		if wait := limiter.take("a"); wait != 0 {
+			t.Fatalf("request %d: expected the burst to be allowed, got wait %v", i, wait)
+		}
+	}
+	if wait := limiter.take("a"); wait != 500*time.Millisecond {
+		t.Fatalf("expected to wait half a second for the next token, got %v", wait)
+	}
+	if wait := limiter.take("b"); wait != 0 {
+		t.Fatalf("expected other clients to have their own bucket, got wait %v", wait)
+	}
+
+	now = now.Add(time.Second)
+	for i := range 2 {
+		if wait := limiter.take("a"); wait != 0 {
+			t.Fatalf("refilled request %d: expected a token after a second, got wait %v", i, wait)
+		}
+	}
+	if limiter.take("a") == 0 {
+		t.Fatal("expected the bucket to be empty again")
+	}
+}
+
+func TestClientRateLimitMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := clientRateLimitMiddleware(ClientRateLimit{RPS: 0.5, Burst: 1, IPHeader: "Fly-Client-Ip"}, []string{"/healthz"}, next)
+
+	serve := func(path, ip string, ctx context.Context) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+		req.Header.Set("Fly-Client-IP", ip)
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+		return res
+	}
+
+	if res := serve("/search", "203.0.113.7", context.Background()); res.Code != http.StatusNoContent {
+		t.Fatalf("expected the first request through, got %d", res.Code)
+	}
+	res := serve("/search", "203.0.113.7", context.Background())
+	if res.Code != http.StatusTooManyRequests || res.Header().Get("Retry-After") != "2" {
+		t.Fatalf("expected 429 with Retry-After 2, got %d (Retry-After %q)", res.Code, res.Header().Get("Retry-After"))
+	}
+	if res := serve("/search", "198.51.100.4", context.Background()); res.Code != http.StatusNoContent {
+		t.Fatalf("expected another IP to have its own limit, got %d", res.Code)
+	}
+	if res := serve("/healthz", "203.0.113.7", context.Background()); res.Code != http.StatusNoContent {
+		t.Fatalf("expected public paths to be exempt, got %d", res.Code)
+	}
+
+	keyed := context.WithValue(context.Background(), clientNameKey{}, "web")
+	if res := serve("/search", "203.0.113.7", keyed); res.Code != http.StatusNoContent {
+		t.Fatalf("expected an API key client to be limited separately from its IP, got %d", res.Code)
+	}
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+)
+
+// readinessTimeout bounds how long /readyz waits on dependency pings.
+const readinessTimeout = 3 * time.Second
+
+// HealthChecker is implemented by source clients that track upstream availability.
+type HealthChecker interface {
+	Ping(ctx context.Context) error
+	Health() health.Status
+}
+
+// Dependency names an upstream the service relies on. A failing required dependency makes
+// the service unready; a failing optional one only marks it degraded.
+type Dependency struct {
+	Name     string
+	Required bool
+	Checker  HealthChecker
+}
+
+type readinessResponse struct {
+	Status       string             `json:"status"`
+	Dependencies []dependencyStatus `json:"dependencies"`
+}
+
+type dependencyStatus struct {
+	Name     string        `json:"name"`
+	Required bool          `json:"required"`
+	Healthy  bool          `json:"healthy"`
+	Error    string        `json:"error,omitempty"`
+	Health   health.Status `json:"health"`
+}
+
+func readinessHandler(deps []Dependency) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+
+		result := checkDependencies(ctx, deps)
+		status := http.StatusOK
+		if result.Status == "unavailable" {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, result)
+	})
+}
+
+// checkDependencies pings every dependency concurrently.
+func checkDependencies(ctx context.Context, deps []Dependency) readinessResponse {
+	statuses := make([]dependencyStatus, len(deps))
+	var wg sync.WaitGroup
+	for i, dep := range deps {
+		wg.Add(1)
+		go func(i int, dep Dependency) {
+			defer wg.Done()
+			entry := dependencyStatus{Name: dep.Name, Required: dep.Required}
+			if dep.Checker == nil {
+				entry.Error = "not configured"
+				statuses[i] = entry
+				return
+			}
+			if err := dep.Checker.Ping(ctx); err != nil {
+				entry.Error = err.Error()
+			} else {
+				entry.Healthy = true
+			}
+			entry.Health = dep.Checker.Health()
+			statuses[i] = entry
+		}(i, dep)
+	}
+	wg.Wait()
+
+	overall := "ready"
+	for _, entry := range statuses {
+		if entry.Healthy {
+			continue
+		}
+		if entry.Required {
+			overall = "unavailable"
+			break
+		}
+		overall = "degraded"
+	}
+	return readinessResponse{Status: overall, Dependencies: statuses}
+}
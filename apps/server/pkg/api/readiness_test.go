@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+)
+
+type stubChecker struct {
+	err error
+}
+
+func (s *stubChecker) Ping(ctx context.Context) error { return s.err }
+
+func (s *stubChecker) Health() health.Status { return health.Status{Healthy: s.err == nil} }
+
+func TestReadinessHandler(t *testing.T) {
+	down := &stubChecker{err: errors.New("connection refused")}
+	up := &stubChecker{}
+
+	tests := []struct {
+		name       string
+		deps       []Dependency
+		wantCode   int
+		wantStatus string
+	}{
+		{"all healthy", []Dependency{{Name: "musicbrainz", Required: true, Checker: up}}, http.StatusOK, "ready"},
+		{"optional down", []Dependency{{Name: "musicbrainz", Required: true, Checker: up}, {Name: "wikipedia", Checker: down}}, http.StatusOK, "degraded"},
+		{"required down", []Dependency{{Name: "musicbrainz", Required: true, Checker: down}}, http.StatusServiceUnavailable, "unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			res := httptest.NewRecorder()
+
+			readinessHandler(tt.deps).ServeHTTP(res, req)
+
+			if res.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d", tt.wantCode, res.Code)
+			}
+			var body readinessResponse
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+			if body.Status != tt.wantStatus {
+				t.Errorf("expected %q, got %q", tt.wantStatus, body.Status)
+			}
+		})
+	}
+}
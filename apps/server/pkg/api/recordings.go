@@ -0,0 +1,47 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+const recordingRelationshipsSuffix = "/relationships"
+
+// recordingRelationshipsHandler serves GET /recordings/{id}/relationships: the covers, originals,
+// and samples linked to a recording, for a song-lineage view.
+func recordingRelationshipsHandler(client MusicBrainzClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		if !strings.HasSuffix(r.URL.Path, recordingRelationshipsSuffix) {
+			writeJSON(w, http.StatusNotFound, errorResponse{"not found"})
+			return
+		}
+		id, err := parseResourceID(strings.TrimSuffix(r.URL.Path, recordingRelationshipsSuffix), "/recordings/", "recording id required")
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+		if client == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"musicbrainz client unavailable"})
+			return
+		}
+
+		relationships, err := client.GetRecordingRelationships(r.Context(), id)
+		switch {
+		case errors.Is(err, musicbrainz.ErrNotFound):
+			writeJSON(w, http.StatusNotFound, errorResponse{"recording not found"})
+			return
+		case err != nil:
+			handleAPIError(w, r, upstreamFailure(err, newAPIError(http.StatusBadGateway, "musicbrainz lookup failed")))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, relationships)
+	})
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func TestRecordingRelationshipsHandler(t *testing.T) {
+	mb := &stubMusicBrainz{
+		getRecordingRelationshipsFunc: func(ctx context.Context, recordingID string) (*musicbrainz.RecordingRelationships, error) {
+			if recordingID != "rec-1" {
+				t.Fatalf("unexpected recording id %q", recordingID)
+			}
+			return &musicbrainz.RecordingRelationships{
+				ID:      recordingID,
+				Title:   "Song",
+				CoverOf: []musicbrainz.RelatedRecording{{ID: "rec-0", Title: "Song", ArtistName: "Original"}},
+			}, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/recordings/rec-1/relationships", nil)
+	res := httptest.NewRecorder()
+
+	recordingRelationshipsHandler(mb).ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var payload musicbrainz.RecordingRelationships
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if len(payload.CoverOf) != 1 || payload.CoverOf[0].ID != "rec-0" {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+}
+
+func TestRecordingRelationshipsHandlerNotFound(t *testing.T) {
+	for _, path := range []string{"/recordings/missing/relationships", "/recordings/rec-1"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		res := httptest.NewRecorder()
+
+		recordingRelationshipsHandler(&stubMusicBrainz{}).ServeHTTP(res, req)
+
+		if res.Code != http.StatusNotFound {
+			t.Fatalf("%s: expected status 404, got %d", path, res.Code)
+		}
+	}
+}
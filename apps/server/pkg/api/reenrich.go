@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+)
+
+// Reenricher runs the targeted re-enrichment job behind /admin/reenrich.
+type Reenricher interface {
+	Start(ctx context.Context, req service.ReenrichRequest) (service.ReenrichRun, error)
+	Status() (service.ReenrichRun, bool)
+}
+
+// reenrichHandler serves /admin/reenrich. POST {"entity", "missing", "limit"} starts a run that
+// revisits cached records missing a field and asks only the sources that supply it; every part
+// of the body is optional. GET reports the current or most recent run.
+func reenrichHandler(reenricher Reenricher) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if reenricher == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"re-enrichment unavailable"})
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			run, ok := reenricher.Status()
+			if !ok {
+				writeJSON(w, http.StatusNotFound, errorResponse{"no re-enrichment run yet"})
+				return
+			}
+			writeJSON(w, http.StatusOK, run)
+			return
+		}
+
+		var req service.ReenrichRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"request body must be JSON"})
+			return
+		}
+		if err := validateReenrichRequest(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+
+		run, err := reenricher.Start(r.Context(), req)
+		if errors.Is(err, service.ErrJobRunning) {
+			writeJSON(w, http.StatusConflict, errorResponse{err.Error()})
+			return
+		}
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"re-enrichment failed to start"})
+			return
+		}
+		writeJSON(w, http.StatusAccepted, run)
+	})
+}
+
+// validateReenrichRequest normalizes the entity and field and checks they are ones the
+// quality report knows about.
+func validateReenrichRequest(req *service.ReenrichRequest) error {
+	req.Kind = strings.ToLower(strings.TrimSpace(req.Kind))
+	req.Field = strings.ToLower(strings.TrimSpace(req.Field))
+	if req.Limit < 0 {
+		return errors.New("'limit' must not be negative")
+	}
+
+	fields := append(db.QualityFields(db.KindArtist), db.QualityFields(db.KindAlbum)...)
+	if req.Kind != "" {
+		fields = db.QualityFields(req.Kind)
+		if fields == nil {
+			return errors.New("'entity' must be artist or album")
+		}
+	}
+	if req.Field != "" && !slices.Contains(fields, req.Field) {
+		return errors.New("'missing' must be one of: " + strings.Join(fields, ", "))
+	}
+	return nil
+}
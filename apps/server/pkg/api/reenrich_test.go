@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+)
+
+type stubReenricher struct {
+	started []service.ReenrichRequest
+	err     error
+}
+
+func (s *stubReenricher) Start(ctx context.Context, req service.ReenrichRequest) (service.ReenrichRun, error) {
+	if s.err != nil {
+		return service.ReenrichRun{}, s.err
+	}
+	s.started = append(s.started, req)
+	return service.ReenrichRun{ReenrichRequest: req, Running: true}, nil
+}
+
+func (s *stubReenricher) Status() (service.ReenrichRun, bool) {
+	return service.ReenrichRun{}, len(s.started) > 0
+}
+
+func TestReenrichHandlerStartsRun(t *testing.T) {
+	stub := &stubReenricher{}
+
+	res := httptest.NewRecorder()
+	reenrichHandler(stub).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/admin/reenrich", nil))
+	if res.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 before any run, got %d", res.Code)
+	}
+
+	res = httptest.NewRecorder()
+	body := strings.NewReader(`{"entity":"Album","missing":"cover","limit":10}`)
+	reenrichHandler(stub).ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/admin/reenrich", body))
+	if res.Code != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d", res.Code)
+	}
+	if len(stub.started) != 1 || stub.started[0] != (service.ReenrichRequest{Kind: "album", Field: "cover", Limit: 10}) {
+		t.Fatalf("unexpected run request %+v", stub.started)
+	}
+
+	stub.err = service.ErrJobRunning
+	res = httptest.NewRecorder()
+	reenrichHandler(stub).ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/admin/reenrich", nil))
+	if res.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", res.Code)
+	}
+}
+
+func TestReenrichHandlerValidatesRequest(t *testing.T) {
+	for _, body := range []string{
+		`{"entity":"label"}`,
+		`{"entity":"album","missing":"biography"}`,
+		`{"missing":"lyrics"}`,
+		`{"limit":-1}`,
+	} {
+		res := httptest.NewRecorder()
+		reenrichHandler(&stubReenricher{}).ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/admin/reenrich", strings.NewReader(body)))
+		if res.Code != http.StatusBadRequest {
+			t.Fatalf("body %s: "+status400Fmt, body, res.Code)
+		}
+	}
+}
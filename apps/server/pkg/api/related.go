@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+)
+
+const relatedSuffix = "/related"
+
+// relatedHandler serves GET /artists/{id}/related: artists MusicBrainz relates the subject to,
+// then Last.fm's similar artists. ?limit= caps the artists returned.
+func relatedHandler(related service.RelatedService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		id, err := parseArtistID(r.URL.Path)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+
+		listing, err := related.GetRelated(r.Context(), id)
+		if err != nil {
+			handleLookupError(w, r, err)
+			return
+		}
+
+		if limit := parseSearchLimit(r.URL.Query().Get("limit")); len(listing.Related) > limit {
+			listing.Related = listing.Related[:limit]
+		}
+		writeJSON(w, http.StatusOK, listing)
+	})
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+)
+
+func TestRelatedHandlerServesCachedRelatedArtists(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	if err := store.SaveArtist(context.Background(), &data.Artist{
+		ID:     "self",
+		Name:   "Self",
+		Albums: []data.Album{{ID: "rg-1", Title: "Debut"}},
+		Related: []data.RelatedArtist{
+			{ID: "band", Name: "Band", Relation: "member of band", Source: "musicbrainz"},
+			{ID: "peer", Name: "Peer", Match: 0.8, Source: "lastfm"},
+		},
+	}); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+	handler := relatedHandler(service.NewRelatedService(service.Deps{Artists: store}))
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/artists/self/related?limit=1", nil))
+
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var payload data.RelatedArtists
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if payload.ArtistID != "self" || len(payload.Related) != 1 || payload.Related[0].ID != "band" {
+		t.Errorf("expected the first related artist only, got %+v", payload)
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/upstreamlog"
+)
+
+// RequestLogConfig controls per-request access logging.
+type RequestLogConfig struct {
+	// SampleRate is the fraction (0-1) of fast, non-5xx requests that are logged.
+	SampleRate float64
+	// SlowThreshold always logs requests at least this slow, with their upstream timing
+	// breakdown. Zero disables slow-request logging.
+	SlowThreshold time.Duration
+}
+
+// statusRecorder remembers the status code written by the wrapped handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// requestIDHeader carries the request ID in both directions: a proxy may supply one, and every
+// response echoes the ID its log lines were tagged with.
+const requestIDHeader = "X-Request-ID"
+
+// requestLogMiddleware tags each request with an ID and a logger carrying it, then logs slow
+// requests and server errors every time and samples the rest, so high-volume successes don't
+// drown out the requests worth investigating.
+func requestLogMiddleware(cfg RequestLogConfig, next http.Handler) http.Handler {
+	return logRequests(cfg, rand.Float64, next)
+}
+
+func logRequests(cfg RequestLogConfig, sample func() float64, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, id := logging.Request(r.Context(), r.Header.Get(requestIDHeader))
+		w.Header().Set(requestIDHeader, id)
+		if cfg.SampleRate <= 0 && cfg.SlowThreshold <= 0 {
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
+		ctx, timings := upstreamlog.WithTimings(ctx)
+		rec := &statusRecorder{ResponseWriter: w}
+		start := time.Now()
+		next.ServeHTTP(rec, r.WithContext(ctx))
+		elapsed := time.Since(start)
+
+		status := rec.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		logger := logging.FromContext(ctx)
+		attrs := []any{
+			"method", r.Method,
+			"path", upstreamlog.Redact(r.URL),
+			"status", status,
+			"duration", elapsed.Round(time.Millisecond),
+		}
+		switch {
+		case cfg.SlowThreshold > 0 && elapsed >= cfg.SlowThreshold:
+			logger.Warn("slow request", append(attrs, "upstream", timings.String())...)
+		case status >= http.StatusInternalServerError:
+			logger.Error("request failed", append(attrs, "upstream", timings.String())...)
+		case cfg.SampleRate >= 1 || (cfg.SampleRate > 0 && sample() < cfg.SampleRate):
+			logger.Info("request", attrs...)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+)
+
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&buf, logging.FormatText, slog.LevelInfo))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func TestRequestLogSamplesSuccesses(t *testing.T) {
+	buf := captureLog(t)
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+
+	logRequests(RequestLogConfig{SampleRate: 0.5}, func() float64 { return 0.9 }, ok).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/artists/x", nil))
+	if buf.Len() != 0 {
+		t.Fatalf("expected unsampled request to be skipped, got %q", buf.String())
+	}
+
+	logRequests(RequestLogConfig{SampleRate: 0.5}, func() float64 { return 0.1 }, ok).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/artists/x", nil))
+	if !strings.Contains(buf.String(), "method=GET path=/artists/x status=200") {
+		t.Fatalf("expected sampled request to be logged, got %q", buf.String())
+	}
+}
+
+func TestRequestLogAlwaysLogsSlowRequestsAndErrors(t *testing.T) {
+	buf := captureLog(t)
+	never := func() float64 { return 1 }
+
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+	})
+	logRequests(RequestLogConfig{SlowThreshold: time.Millisecond}, never, slow).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/albums/y", nil))
+	if !strings.Contains(buf.String(), `msg="slow request" request_id=`) || !strings.Contains(buf.String(), "upstream=none") {
+		t.Fatalf("expected slow request log with upstream breakdown, got %q", buf.String())
+	}
+
+	buf.Reset()
+	failing := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusBadGateway, errorResponse{"musicbrainz lookup failed"})
+	})
+	logRequests(RequestLogConfig{SlowThreshold: time.Hour}, never, failing).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/albums/y", nil))
+	if !strings.Contains(buf.String(), "status=502") {
+		t.Fatalf("expected server error to be logged, got %q", buf.String())
+	}
+}
+
+func TestRequestLogTagsRequestsWithAnID(t *testing.T) {
+	buf := captureLog(t)
+	var seen string
+	handler := logRequests(RequestLogConfig{SampleRate: 1}, nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logging.FromContext(r.Context()).Info("inside handler")
+		seen = w.Header().Get(requestIDHeader)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/artists/x", nil))
+	id := rec.Header().Get(requestIDHeader)
+	if id == "" || seen != id {
+		t.Fatalf("expected a generated request ID on the response, got %q (handler saw %q)", id, seen)
+	}
+	if strings.Count(buf.String(), "request_id="+id) != 2 {
+		t.Fatalf("expected the handler and access log lines to share the request ID, got %q", buf.String())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/artists/x", nil)
+	req.Header.Set(requestIDHeader, "edge-42")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if got := rec.Header().Get(requestIDHeader); got != "edge-42" {
+		t.Fatalf("expected the incoming request ID to be kept, got %q", got)
+	}
+
+	req.Header.Set(requestIDHeader, "bad id\nforged=1")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if got := rec.Header().Get(requestIDHeader); got == "" || strings.Contains(got, " ") {
+		t.Fatalf("expected an unusable incoming ID to be replaced, got %q", got)
+	}
+}
@@ -2,15 +2,22 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
 	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/images"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/lastfm"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/metrics"
 	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/wikitext"
 )
 
 // MusicBrainzClient captures the MusicBrainz operations the router relies on.
@@ -19,7 +26,16 @@ type MusicBrainzClient interface {
 	LookupReleaseGroup(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error)
 	SearchArtists(ctx context.Context, query string, limit int, offset int) (*musicbrainz.SearchResult, error)
 	GetArtistReleaseGroups(ctx context.Context, artistID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
+	GetArtistReleaseGroupsOfTypes(ctx context.Context, artistID string, types musicbrainz.ReleaseGroupTypes, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
 	GetReleaseGroupTracks(ctx context.Context, releaseGroupID string) ([]musicbrainz.Track, error)
+	GetReleaseGroupEditions(ctx context.Context, releaseGroupID string) ([]musicbrainz.Edition, error)
+	GetReleaseGroupCredits(ctx context.Context, releaseGroupID string) ([]musicbrainz.Credit, error)
+	GetRecordingRelationships(ctx context.Context, recordingID string) (*musicbrainz.RecordingRelationships, error)
+	LookupRecording(ctx context.Context, id string) (*musicbrainz.Recording, error)
+	LookupLabel(ctx context.Context, id string) (*musicbrainz.Label, error)
+	GetLabelReleaseGroups(ctx context.Context, labelID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
+	SearchRecordings(ctx context.Context, query string, limit int, offset int) (*musicbrainz.RecordingSearchResult, error)
+	SearchReleaseGroups(ctx context.Context, query string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
 }
 
 // WikipediaClient captures the Wikipedia operations the router relies on.
@@ -27,28 +43,220 @@ type WikipediaClient interface {
 	GetArtistBiography(ctx context.Context, artistName string) (string, error)
 }
 
+// AlbumFactsClient captures the album article parsing the router relies on.
+type AlbumFactsClient interface {
+	GetAlbumFacts(ctx context.Context, artistName, albumTitle, articleURL string) (*wikitext.AlbumFacts, error)
+}
+
+// AwardsClient captures the award lookups the router relies on.
+type AwardsClient interface {
+	GetAwards(ctx context.Context, entityURL string) ([]data.Award, error)
+}
+
 // ReviewsClient captures the reviews operations the router relies on.
 type ReviewsClient interface {
-	GetAlbumReview(ctx context.Context, artistName, albumTitle string) (*data.Review, error)
+	GetAlbumReviews(ctx context.Context, artistName, albumTitle string) ([]data.Review, error)
+}
+
+// MarketplaceClient captures the Discogs marketplace stats the router relies on.
+type MarketplaceClient interface {
+	GetMarketplaceStats(ctx context.Context, releaseID int) (*data.Marketplace, error)
+}
+
+// LastfmClient captures the Last.fm tag, listening, wiki, and similar-artist lookups the router
+// relies on.
+type LastfmClient interface {
+	GetArtistInfo(ctx context.Context, mbid, name string) (*lastfm.ArtistInfo, error)
+	GetAlbumInfo(ctx context.Context, mbid, artistName, albumTitle string) (*lastfm.AlbumInfo, error)
+	GetSimilarArtists(ctx context.Context, mbid, name string, limit int) ([]lastfm.SimilarArtist, error)
+}
+
+// RatingNotifier captures the rating change notifications the router relies on.
+type RatingNotifier interface {
+	NotifyRatingChange(ctx context.Context, change data.RatingChange)
+}
+
+// ImageProxy captures the resized image variants the router serves.
+type ImageProxy interface {
+	Variant(ctx context.Context, imageURL string, size int, format images.Format) ([]byte, error)
+}
+
+// ImageResolver captures the image fallback chain the router relies on.
+type ImageResolver interface {
+	ArtistImages(ctx context.Context, artistID, artistName string) []data.Image
+	AlbumImages(ctx context.Context, albumID, artistName, albumTitle string) []data.Image
 }
 
 // RouterConfig captures dependencies required by the HTTP router.
 type RouterConfig struct {
 	MusicBrainz MusicBrainzClient
 	Wikipedia   WikipediaClient
+	AlbumFacts  AlbumFactsClient
+	Awards      AwardsClient
 	Reviews     ReviewsClient
-	Artists     db.ArtistRepository
-	Albums      db.AlbumRepository
+	Spotify     SpotifyClient
+	Images      ImageResolver
+	Library     LibraryScanner
+	// Marketplace adds Discogs price stats to albums; nil leaves them out.
+	Marketplace MarketplaceClient
+	// Lastfm adds tags, listening stats, similar artists, and fallback wiki text; nil leaves
+	// them out.
+	Lastfm LastfmClient
+	// RatingNotifier is told when a cached album's average rating moves by at least
+	// RatingChangeThreshold or a new source rates it; nil sends nothing.
+	RatingNotifier        RatingNotifier
+	RatingChangeThreshold float64
+	// ImageProxy serves /images; nil answers it with 503.
+	ImageProxy ImageProxy
+	Artists    db.ArtistRepository
+	Albums     db.AlbumRepository
+	Labels     db.LabelRepository
+	Playlists  db.PlaylistRepository
+	Owned      db.LibraryRepository
+	// Aliases maps merged MusicBrainz IDs to their canonical records.
+	Aliases db.AliasRepository
+	// LocalSearch serves /search?source=local from cached artists.
+	LocalSearch db.ArtistSearcher
+	// AlbumBrowser serves /albums?decade=&genre= from cached albums.
+	AlbumBrowser db.AlbumBrowser
+	// ArtistBrowser serves /artists?country=&type= from cached artists.
+	ArtistBrowser db.ArtistBrowser
+	// Modified reports when cached artists, albums, and labels last changed, so their lookups
+	// send Last-Modified and answer If-Modified-Since; nil skips both.
+	Modified db.ModificationTracker
+	// Cache backs the admin invalidation endpoints and DELETE /artists/{id} and /albums/{id}.
+	Cache db.CacheInvalidator
+	// Records and Merger back the admin duplicate scan, merge, and quality report endpoints.
+	Records db.RecordLister
+	Merger  db.Merger
+	// Reenricher backs the admin trigger for targeted re-enrichment of incomplete records.
+	Reenricher Reenricher
+	// RequestLog controls access log sampling and slow-request logging.
+	RequestLog RequestLogConfig
+	// Deadlines bound how long each class of route may run.
+	Deadlines RouteDeadlines
+	// CacheControl sets the Cache-Control policy sent by each class of route.
+	CacheControl CachePolicies
+	// EnrichmentBudget caps time spent on optional sources per artist or album lookup.
+	EnrichmentBudget time.Duration
+	// MaxArtistAlbums caps the albums fetched with an artist; zero uses the service default.
+	MaxArtistAlbums int
+	// CacheTTL bounds how long cached records, reviews, searches, and not-found answers are
+	// served before being fetched again.
+	CacheTTL CacheTTLs
+	// BasePath mounts every route under a prefix such as /api; empty serves them at the root.
+	BasePath string
+	// AllowedHosts restricts the Host headers served; empty accepts any host.
+	AllowedHosts []string
+	// CORSOrigins lists the origins browsers may call the API from; "*" allows any, and empty
+	// sends no CORS headers.
+	CORSOrigins []string
+	// AdminToken guards /admin endpoints, /metrics, and DELETE on /artists/{id} and
+	// /albums/{id}. When empty, loopback clients may still read admin endpoints with GET or
+	// HEAD, and every other admin call is refused with 403.
+	AdminToken string
+	// Auth requires an API key on every route outside its public paths; with no keys the API
+	// is open. Admin endpoints need the key in addition to the admin token.
+	Auth AuthConfig
+	// ClientRateLimit caps how fast each client, by API key or IP, may call non-public routes.
+	ClientRateLimit ClientRateLimit
+	// LogLevel is the level /admin/loglevel reads and changes; nil makes it report 503.
+	LogLevel *slog.LevelVar
+	// EffectiveConfig is the redacted configuration the server started with, served at
+	// /admin/config.
+	EffectiveConfig map[string]any
+	// Dependencies are probed by /readyz. Optional dependencies only degrade readiness.
+	Dependencies []Dependency
 }
 
 // NewRouter wires the top-level HTTP routes for the backend.
 func NewRouter(cfg RouterConfig) http.Handler {
+	deps := service.Deps{
+		Artists:     cfg.Artists,
+		Albums:      cfg.Albums,
+		Labels:      cfg.Labels,
+		Aliases:     cfg.Aliases,
+		MusicBrainz: cfg.MusicBrainz,
+		Wikipedia:   cfg.Wikipedia,
+		AlbumFacts:  cfg.AlbumFacts,
+		Awards:      cfg.Awards,
+		Reviews:     cfg.Reviews,
+		Images:      cfg.Images,
+		Marketplace: cfg.Marketplace,
+		Lastfm:      cfg.Lastfm,
+		RatingEvents: service.RatingEvents{
+			Notifier:  cfg.RatingNotifier,
+			Threshold: cfg.RatingChangeThreshold,
+		},
+
+		MaxArtistAlbums: cfg.MaxArtistAlbums,
+		Modified:        cfg.Modified,
+		CacheTTL: service.CacheTTLs{
+			Artists:  cfg.CacheTTL.Artists,
+			Albums:   cfg.CacheTTL.Albums,
+			Reviews:  cfg.CacheTTL.Reviews,
+			NotFound: cfg.CacheTTL.NotFound,
+		},
+	}
+	artists := service.NewArtistService(deps)
+	albums := service.NewAlbumService(deps)
+	labels := service.NewLabelService(deps)
+	collaborations := service.NewCollaborationService(deps)
+	discography := service.NewDiscographyService(deps)
+	related := service.NewRelatedService(deps)
+	tracks := service.NewTrackService(deps)
+	searches := newSearchCache(cfg.MusicBrainz, cfg.CacheTTL.Searches)
+
+	var artistModified, albumModified, labelModified modifiedFunc
+	if cfg.Modified != nil {
+		artistModified, albumModified, labelModified = cfg.Modified.ArtistModified, cfg.Modified.AlbumModified, cfg.Modified.LabelModified
+	}
+
+	read := func(h http.Handler) http.Handler { return deadlineMiddleware(cfg.Deadlines.Read, h) }
+	enrich := func(h http.Handler) http.Handler {
+		return deadlineMiddleware(cfg.Deadlines.Enrich, enrichmentBudgetMiddleware(cfg.EnrichmentBudget, h))
+	}
+	batch := func(h http.Handler) http.Handler { return deadlineMiddleware(cfg.Deadlines.Batch, h) }
+	entity := func(h http.Handler) http.Handler {
+		return cacheControlMiddleware(publicCache(cfg.CacheControl.Entity), h)
+	}
+	listing := func(h http.Handler) http.Handler {
+		return cacheControlMiddleware(publicCache(cfg.CacheControl.Listing), h)
+	}
+
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz", healthHandler)
-	mux.Handle("/artists/", artistLookupHandler(cfg.Artists, cfg.MusicBrainz, cfg.Wikipedia))
-	mux.Handle("/albums/", albumLookupHandler(cfg.Albums, cfg.MusicBrainz, cfg.Reviews))
-	mux.HandleFunc("/search", searchHandler(cfg.MusicBrainz))
-	return corsMiddleware(mux)
+	mux.Handle("/readyz", readinessHandler(cfg.Dependencies))
+	mux.Handle("/artists", listing(enrich(artistBrowseHandler(cfg.ArtistBrowser, searches))))
+	mux.Handle("/artists/", deletableEntity(cfg.AdminToken, read(cacheDeleteHandler(cfg.Cache, db.KindArtist)), entity(enrich(artistRoutes(artistLookupHandler(artists, artistModified), collaborationsHandler(collaborations), discographyHandler(discography), artistAlbumsHandler(discography), relatedHandler(related))))))
+	mux.Handle("/albums", listing(read(albumBrowseHandler(cfg.AlbumBrowser))))
+	mux.Handle("/albums/", deletableEntity(cfg.AdminToken, read(cacheDeleteHandler(cfg.Cache, db.KindAlbum)), entity(enrich(albumLookupHandler(albums, albumModified)))))
+	mux.Handle("/albums/lookup", listing(enrich(albumMatchHandler(cfg.MusicBrainz, albums))))
+	mux.Handle("/labels/", entity(enrich(labelLookupHandler(labels, labelModified))))
+	mux.Handle("/recordings/", entity(enrich(recordingRelationshipsHandler(cfg.MusicBrainz))))
+	mux.Handle("/tracks/", entity(enrich(trackLookupHandler(tracks))))
+	mux.Handle("/images", cacheControlMiddleware(cacheImmutable, enrich(imageVariantHandler(cfg.ImageProxy))))
+	mux.Handle("/search", listing(enrich(searchHandler(searches, cfg.LocalSearch))))
+	mux.Handle("/search/albums", listing(enrich(albumSearchHandler(searches))))
+	mux.Handle("/playlists/import/spotify", batch(spotifyImportHandler(cfg.Playlists, cfg.Spotify, cfg.MusicBrainz)))
+	mux.Handle("/playlists/", read(playlistLookupHandler(cfg.Playlists)))
+	mux.Handle("/library/owned", read(ownedAlbumsHandler(cfg.Owned)))
+	mux.Handle("/me/export", read(userExportHandler(cfg.Playlists, cfg.Owned)))
+	mux.Handle("/me/import", batch(userImportHandler(cfg.Playlists, cfg.Owned)))
+	mux.Handle("/library/scan", batch(libraryScanHandler(cfg.Owned, cfg.Library, cfg.MusicBrainz)))
+	mux.Handle("/metrics", adminMiddleware(cfg.AdminToken, metrics.Default.Handler()))
+	mux.Handle("/admin/debug/upstream", adminMiddleware(cfg.AdminToken, upstreamDebugHandler()))
+	mux.Handle("/admin/loglevel", adminMiddleware(cfg.AdminToken, logLevelHandler(cfg.LogLevel)))
+	mux.Handle("/admin/config", adminMiddleware(cfg.AdminToken, configHandler(cfg.EffectiveConfig)))
+	mux.Handle("/admin/cache/artists/", adminMiddleware(cfg.AdminToken, read(artistInvalidationHandler(cfg.Cache))))
+	mux.Handle("/admin/cache/tombstones", adminMiddleware(cfg.AdminToken, read(tombstonesHandler(cfg.Cache))))
+	mux.Handle("/admin/duplicates", adminMiddleware(cfg.AdminToken, batch(duplicatesHandler(cfg.Records, cfg.Aliases))))
+	mux.Handle("/admin/duplicates/merge", adminMiddleware(cfg.AdminToken, batch(mergeHandler(cfg.Merger))))
+	mux.Handle("/admin/quality", adminMiddleware(cfg.AdminToken, batch(qualityHandler(cfg.Records))))
+	mux.Handle("/admin/reenrich", adminMiddleware(cfg.AdminToken, read(reenrichHandler(cfg.Reenricher))))
+	// Anything not classed above is user data, admin, or health output that caches must not keep.
+	handler := corsMiddleware(cfg.CORSOrigins, cacheControlMiddleware(cacheNoStore, apiKeyMiddleware(cfg.Auth, clientRateLimitMiddleware(cfg.ClientRateLimit, cfg.Auth.PublicPaths, mux))))
+	return requestLogMiddleware(cfg.RequestLog, mountMiddleware(cfg.BasePath, hostMiddleware(cfg.AllowedHosts, handler)))
 }
 
 func healthHandler(w http.ResponseWriter, r *http.Request) {
@@ -59,7 +267,7 @@ func healthHandler(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
 
-func artistLookupHandler(repo db.ArtistRepository, mbClient MusicBrainzClient, wikiClient WikipediaClient) http.Handler {
+func artistLookupHandler(artists service.ArtistService, modified modifiedFunc) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !assertMethod(w, r, http.MethodGet) {
 			return
@@ -70,18 +278,41 @@ func artistLookupHandler(repo db.ArtistRepository, mbClient MusicBrainzClient, w
 			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
 			return
 		}
+		depth, err := service.ParseDepth(r.URL.Query().Get("depth"))
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"query parameter 'depth' must be basic, standard, or full"})
+			return
+		}
+
+		refresh, err := parseRefresh(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
 
-		artist, err := getOrFetchArtist(r.Context(), repo, mbClient, wikiClient, id)
+		ctx := service.WithDepth(r.Context(), depth)
+		ctx = service.WithLanguages(ctx, parseAcceptLanguage(r.Header.Get("Accept-Language")))
+		if refresh {
+			ctx = service.WithForceRefresh(ctx)
+		}
+		artist, err := artists.GetArtist(ctx, id)
 		if err != nil {
-			handleAPIError(w, err)
+			handleLookupError(w, r, err)
 			return
 		}
 
+		w.Header().Add("Vary", "Accept-Language")
+		if artist.Locale != nil {
+			w.Header().Set("Content-Language", artist.Locale.Tag)
+		}
+		if notModified(w, r, modified, artist.ID) {
+			return
+		}
 		writeJSON(w, http.StatusOK, artist)
 	})
 }
 
-func albumLookupHandler(repo db.AlbumRepository, client MusicBrainzClient, reviewsClient ReviewsClient) http.Handler {
+func albumLookupHandler(albums service.AlbumService, modified modifiedFunc) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !assertMethod(w, r, http.MethodGet) {
 			return
@@ -93,24 +324,47 @@ func albumLookupHandler(repo db.AlbumRepository, client MusicBrainzClient, revie
 			return
 		}
 
-		album, err := getOrFetchAlbum(r.Context(), repo, client, reviewsClient, id)
+		refresh, err := parseRefresh(r)
 		if err != nil {
-			handleAPIError(w, err)
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
 			return
 		}
 
+		ctx := service.WithLanguages(r.Context(), parseAcceptLanguage(r.Header.Get("Accept-Language")))
+		if refresh {
+			ctx = service.WithForceRefresh(ctx)
+		}
+		album, err := albums.GetAlbum(ctx, id)
+		if err != nil {
+			handleLookupError(w, r, err)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Language")
+
+		if notModified(w, r, modified, album.ID) {
+			return
+		}
 		writeJSON(w, http.StatusOK, album)
 	})
 }
 
-type errorResponse struct {
-	Error string `json:"error"`
+// parseRefresh reads ?refresh=true, which makes a lookup skip the cache and overwrite the
+// stored record with a fresh fetch.
+func parseRefresh(r *http.Request) (bool, error) {
+	raw := r.URL.Query().Get("refresh")
+	if raw == "" {
+		return false, nil
+	}
+	refresh, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, errors.New("query parameter 'refresh' must be true or false")
+	}
+	return refresh, nil
 }
 
-func writeJSON(w http.ResponseWriter, status int, payload any) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(payload)
+type errorResponse struct {
+	Error string `json:"error"`
 }
 
 func parseArtistID(path string) (string, error) {
@@ -157,225 +411,58 @@ func newAPIError(status int, msg string) error {
 	return apiError{status: status, msg: msg}
 }
 
-func handleAPIError(w http.ResponseWriter, err error) {
+func handleAPIError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+		// Whatever failed downstream, the route deadline is what ended the request.
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{"request deadline exceeded"})
+		return
+	}
+	var limited *retry.RateLimitedError
+	if errors.As(err, &limited) {
+		writeRateLimited(w, limited.Source+" rate limited", limited.RetryAfter)
+		return
+	}
 	var apiErr apiError
 	if errors.As(err, &apiErr) {
 		writeJSON(w, apiErr.status, errorResponse{apiErr.msg})
 		return
 	}
-	writeJSON(w, http.StatusInternalServerError, errorResponse{"request failed"})
-}
-
-func getOrFetchArtist(ctx context.Context, repo db.ArtistRepository, mbClient MusicBrainzClient, wikiClient WikipediaClient, id string) (*data.Artist, error) {
-	if repo != nil {
-		artist, err := repo.GetArtist(ctx, id)
-		if err != nil {
-			return nil, newAPIError(http.StatusInternalServerError, "artist lookup failed")
-		}
-		if artist != nil {
-			// If cached artist has no albums, fetch them
-			if artist.Albums == nil || len(artist.Albums) == 0 {
-				if mbClient != nil {
-					releaseGroups, err := mbClient.GetArtistReleaseGroups(ctx, id, 50, 0)
-					if err == nil {
-						artist.Albums = transformReleaseGroupsToAlbums(releaseGroups.ReleaseGroups)
-						// Update the cached artist with albums
-						_ = repo.SaveArtist(ctx, artist)
-					}
-				}
-			}
-			return artist, nil
-		}
-	}
-
-	if mbClient == nil {
-		return nil, newAPIError(http.StatusServiceUnavailable, "musicbrainz client unavailable")
-	}
-
-	remote, err := mbClient.LookupArtist(ctx, id)
-	if err != nil {
-		switch {
-		case errors.Is(err, musicbrainz.ErrNotFound):
-			return nil, newAPIError(http.StatusNotFound, "artist not found")
-		default:
-			return nil, newAPIError(http.StatusBadGateway, "musicbrainz lookup failed")
-		}
-	}
-
-	domainArtist := transformArtist(remote)
-
-	// Fetch biography from Wikipedia
-	if wikiClient != nil {
-		biography, err := wikiClient.GetArtistBiography(ctx, remote.Name)
-		if err == nil {
-			domainArtist.Biography = biography
-		}
-		// Continue even if biography fetch fails
-	}
-
-	// Fetch artist's albums/release groups
-	releaseGroups, err := mbClient.GetArtistReleaseGroups(ctx, id, 50, 0)
-	if err != nil {
-		// Don't fail the artist lookup if albums can't be fetched
-		// Just log and continue with empty albums
-		domainArtist.Albums = nil
-	} else {
-		domainArtist.Albums = transformReleaseGroupsToAlbums(releaseGroups.ReleaseGroups)
-	}
-
-	if repo != nil {
-		if err := repo.SaveArtist(ctx, domainArtist); err != nil {
-			return nil, newAPIError(http.StatusInternalServerError, "artist cache failed")
-		}
-	}
-
-	return domainArtist, nil
-}
-
-func getOrFetchAlbum(ctx context.Context, repo db.AlbumRepository, client MusicBrainzClient, reviewsClient ReviewsClient, id string) (*data.Album, error) {
-	if repo != nil {
-		album, err := repo.GetAlbum(ctx, id)
-		if err != nil {
-			return nil, newAPIError(http.StatusInternalServerError, "album lookup failed")
-		}
-		if album != nil {
-			return album, nil
-		}
-	}
-
-	if client == nil {
-		return nil, newAPIError(http.StatusServiceUnavailable, "musicbrainz client unavailable")
-	}
-
-	remote, err := client.LookupReleaseGroup(ctx, id)
-	if err != nil {
-		switch {
-		case errors.Is(err, musicbrainz.ErrNotFound):
-			return nil, newAPIError(http.StatusNotFound, "album not found")
-		default:
-			return nil, newAPIError(http.StatusBadGateway, "musicbrainz lookup failed")
-		}
-	}
-
-	domainAlbum := transformAlbum(remote)
-
-	// Fetch track listings
-	tracks, err := client.GetReleaseGroupTracks(ctx, id)
-	if err == nil {
-		domainAlbum.Tracks = transformTracks(tracks)
-	}
-	// If track fetching fails, we continue without tracks rather than failing the whole request
-
-	// Fetch review data
-	if reviewsClient != nil {
-		review, err := reviewsClient.GetAlbumReview(ctx, domainAlbum.ArtistName, domainAlbum.Title)
-		if err == nil && review != nil {
-			domainAlbum.Review = *review
-		}
-	}
-	// If review fetching fails, we continue without reviews rather than failing the whole request
-
-	if repo != nil {
-		if err := repo.SaveAlbum(ctx, domainAlbum); err != nil {
-			return nil, newAPIError(http.StatusInternalServerError, "album cache failed")
+	var svcErr *service.Error
+	if errors.As(err, &svcErr) {
+		if errors.Is(svcErr.Kind, service.ErrRateLimited) {
+			writeRateLimited(w, svcErr.Message, svcErr.RetryAfter)
+			return
 		}
+		writeJSON(w, serviceErrorStatus(svcErr.Kind), errorResponse{svcErr.Message})
+		return
 	}
-
-	return domainAlbum, nil
-}
-
-func transformArtist(src *musicbrainz.Artist) *data.Artist {
-	if src == nil {
-		return nil
-	}
-	return &data.Artist{
-		ID:             src.ID,
-		Name:           src.Name,
-		Biography:      "",
-		Genres:         append([]string(nil), src.Tags...),
-		Albums:         nil,
-		Related:        nil,
-		ImageURL:       "",
-		Country:        src.Country,
-		Type:           src.Type,
-		Disambiguation: src.Disambiguation,
-		Aliases:        append([]string(nil), src.Aliases...),
-		LifeSpan: data.LifeSpan{
-			Begin: src.LifeSpan.Begin,
-			End:   src.LifeSpan.End,
-			Ended: src.LifeSpan.Ended,
-		},
-	}
+	writeJSON(w, http.StatusInternalServerError, errorResponse{"request failed"})
 }
 
-func transformAlbum(src *musicbrainz.ReleaseGroup) *data.Album {
-	if src == nil {
-		return nil
-	}
-
-	album := &data.Album{
-		ID:               src.ID,
-		Title:            src.Title,
-		ArtistID:         src.PrimaryArtistID(),
-		ArtistName:       src.PrimaryArtistName(),
-		PrimaryType:      src.PrimaryType,
-		SecondaryTypes:   append([]string(nil), src.SecondaryTypes...),
-		FirstReleaseDate: src.FirstReleaseDate,
-		Year:             src.ReleaseYear(),
-		Genre:            "",
-		Label:            "",
-		Tracks:           nil,
-		Review:           data.Review{},
-		CoverURL:         "",
+// serviceErrorStatus maps service error kinds onto HTTP statuses.
+func serviceErrorStatus(kind error) int {
+	switch {
+	case errors.Is(kind, service.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(kind, service.ErrUnavailable), errors.Is(kind, service.ErrRateLimited):
+		return http.StatusServiceUnavailable
+	case errors.Is(kind, service.ErrUpstream):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
 	}
-	return album
 }
 
-func transformTracks(mbTracks []musicbrainz.Track) []data.Track {
-	if len(mbTracks) == 0 {
-		return nil
-	}
-
-	tracks := make([]data.Track, 0, len(mbTracks))
-	for _, mbTrack := range mbTracks {
-		track := data.Track{
-			Number: mbTrack.Number,
-			Title:  mbTrack.Title,
-			Length: mbTrack.Length,
-		}
-		tracks = append(tracks, track)
-	}
-	return tracks
+// localSearchResult mirrors the MusicBrainz search payload for artists served from the cache.
+type localSearchResult struct {
+	Artists    []data.Artist `json:"artists"`
+	Offset     int           `json:"offset"`
+	Count      int           `json:"count"`
+	Source     string        `json:"source"`
+	DidYouMean []string      `json:"didYouMean,omitempty"`
 }
 
-func transformReleaseGroupsToAlbums(releaseGroups []musicbrainz.ReleaseGroup) []data.Album {
-	if len(releaseGroups) == 0 {
-		return nil
-	}
-
-	albums := make([]data.Album, 0, len(releaseGroups))
-	for _, rg := range releaseGroups {
-		album := data.Album{
-			ID:               rg.ID,
-			Title:            rg.Title,
-			ArtistID:         rg.PrimaryArtistID(),
-			ArtistName:       rg.PrimaryArtistName(),
-			PrimaryType:      rg.PrimaryType,
-			SecondaryTypes:   append([]string(nil), rg.SecondaryTypes...),
-			FirstReleaseDate: rg.FirstReleaseDate,
-			Year:             rg.ReleaseYear(),
-			Genre:            "",
-			Label:            "",
-			Tracks:           nil,
-			Review:           data.Review{},
-			CoverURL:         "",
-		}
-		albums = append(albums, album)
-	}
-	return albums
-}
-
-func searchHandler(client MusicBrainzClient) http.HandlerFunc {
+func searchHandler(client MusicBrainzClient, local db.ArtistSearcher) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if !assertMethod(w, r, http.MethodGet) {
 			return
@@ -390,13 +477,35 @@ func searchHandler(client MusicBrainzClient) http.HandlerFunc {
 		limit := parseSearchLimit(r.URL.Query().Get("limit"))
 		offset := parseSearchOffset(r.URL.Query().Get("offset"))
 
+		if r.URL.Query().Get("source") == "local" {
+			if local == nil {
+				writeJSON(w, http.StatusServiceUnavailable, errorResponse{"local search unavailable"})
+				return
+			}
+			artists, err := local.SearchArtists(r.Context(), query, limit)
+			if err != nil {
+				writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "search failed"})
+				return
+			}
+			response := localSearchResult{Artists: artists, Count: len(artists), Source: "local"}
+			if len(artists) == 0 {
+				response.DidYouMean = didYouMean(query, nil, cachedArtistNames(r.Context(), local))
+			}
+			writeJSON(w, http.StatusOK, response)
+			return
+		}
+
 		result, err := client.SearchArtists(r.Context(), query, limit, offset)
 		if err != nil {
-			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "search failed"})
+			handleAPIError(w, r, upstreamFailure(err, newAPIError(http.StatusInternalServerError, "search failed")))
 			return
 		}
 
-		writeJSON(w, http.StatusOK, result)
+		response := artistSearchResult{SearchResult: result}
+		if needsSuggestions(result) {
+			response.DidYouMean = didYouMean(query, result.Artists, cachedArtistNames(r.Context(), local))
+		}
+		writeJSON(w, http.StatusOK, response)
 	}
 }
 
@@ -419,22 +528,3 @@ func parseSearchOffset(offsetStr string) int {
 	}
 	return 0
 }
-
-// corsMiddleware adds CORS headers for local development
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Allow requests from Angular dev server
-		w.Header().Set("Access-Control-Allow-Origin", "http://localhost:4200")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		w.Header().Set("Access-Control-Max-Age", "86400")
-
-		// Handle preflight requests
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
-
-		next.ServeHTTP(w, r)
-	})
-}
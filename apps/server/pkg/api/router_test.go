@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
 	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
 )
 
@@ -49,11 +51,20 @@ func (s *stubArtistRepo) SaveArtist(ctx context.Context, artist *data.Artist) er
 }
 
 type stubMusicBrainz struct {
-	lookupArtistFunc           func(ctx context.Context, id string) (*musicbrainz.Artist, error)
-	lookupReleaseGroupFunc     func(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error)
-	searchArtistsFunc          func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.SearchResult, error)
-	getArtistReleaseGroupsFunc func(ctx context.Context, artistID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
-	getReleaseGroupTracksFunc  func(ctx context.Context, releaseGroupID string) ([]musicbrainz.Track, error)
+	lookupArtistFunc                  func(ctx context.Context, id string) (*musicbrainz.Artist, error)
+	lookupReleaseGroupFunc            func(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error)
+	searchArtistsFunc                 func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.SearchResult, error)
+	getArtistReleaseGroupsFunc        func(ctx context.Context, artistID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
+	getArtistReleaseGroupsOfTypesFunc func(ctx context.Context, artistID string, types musicbrainz.ReleaseGroupTypes, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
+	getReleaseGroupTracksFunc         func(ctx context.Context, releaseGroupID string) ([]musicbrainz.Track, error)
+	getReleaseGroupEditionsFunc       func(ctx context.Context, releaseGroupID string) ([]musicbrainz.Edition, error)
+	getReleaseGroupCreditsFunc        func(ctx context.Context, releaseGroupID string) ([]musicbrainz.Credit, error)
+	getRecordingRelationshipsFunc     func(ctx context.Context, recordingID string) (*musicbrainz.RecordingRelationships, error)
+	searchRecordingsFunc              func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.RecordingSearchResult, error)
+	searchReleaseGroupsFunc           func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
+	lookupLabelFunc                   func(ctx context.Context, id string) (*musicbrainz.Label, error)
+	getLabelReleaseGroupsFunc         func(ctx context.Context, labelID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
+	lookupRecordingFunc               func(ctx context.Context, id string) (*musicbrainz.Recording, error)
 }
 
 func (s *stubMusicBrainz) LookupArtist(ctx context.Context, id string) (*musicbrainz.Artist, error) {
@@ -84,6 +95,13 @@ func (s *stubMusicBrainz) GetArtistReleaseGroups(ctx context.Context, artistID s
 	return nil, errors.New(unexpectedCall)
 }
 
+func (s *stubMusicBrainz) GetArtistReleaseGroupsOfTypes(ctx context.Context, artistID string, types musicbrainz.ReleaseGroupTypes, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+	if s.getArtistReleaseGroupsOfTypesFunc != nil {
+		return s.getArtistReleaseGroupsOfTypesFunc(ctx, artistID, types, limit, offset)
+	}
+	return s.GetArtistReleaseGroups(ctx, artistID, limit, offset)
+}
+
 func (s *stubMusicBrainz) GetReleaseGroupTracks(ctx context.Context, releaseGroupID string) ([]musicbrainz.Track, error) {
 	if s.getReleaseGroupTracksFunc != nil {
 		return s.getReleaseGroupTracksFunc(ctx, releaseGroupID)
@@ -91,6 +109,62 @@ func (s *stubMusicBrainz) GetReleaseGroupTracks(ctx context.Context, releaseGrou
 	return nil, nil // Return empty tracks by default for tests
 }
 
+func (s *stubMusicBrainz) GetReleaseGroupEditions(ctx context.Context, releaseGroupID string) ([]musicbrainz.Edition, error) {
+	if s.getReleaseGroupEditionsFunc != nil {
+		return s.getReleaseGroupEditionsFunc(ctx, releaseGroupID)
+	}
+	return nil, nil
+}
+
+func (s *stubMusicBrainz) LookupLabel(ctx context.Context, id string) (*musicbrainz.Label, error) {
+	if s.lookupLabelFunc != nil {
+		return s.lookupLabelFunc(ctx, id)
+	}
+	return nil, errors.New(unexpectedCall)
+}
+
+func (s *stubMusicBrainz) GetLabelReleaseGroups(ctx context.Context, labelID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+	if s.getLabelReleaseGroupsFunc != nil {
+		return s.getLabelReleaseGroupsFunc(ctx, labelID, limit, offset)
+	}
+	return &musicbrainz.ReleaseGroupSearchResult{}, nil
+}
+
+func (s *stubMusicBrainz) GetReleaseGroupCredits(ctx context.Context, releaseGroupID string) ([]musicbrainz.Credit, error) {
+	if s.getReleaseGroupCreditsFunc != nil {
+		return s.getReleaseGroupCreditsFunc(ctx, releaseGroupID)
+	}
+	return nil, nil
+}
+
+func (s *stubMusicBrainz) GetRecordingRelationships(ctx context.Context, recordingID string) (*musicbrainz.RecordingRelationships, error) {
+	if s.getRecordingRelationshipsFunc != nil {
+		return s.getRecordingRelationshipsFunc(ctx, recordingID)
+	}
+	return nil, musicbrainz.ErrNotFound
+}
+
+func (s *stubMusicBrainz) LookupRecording(ctx context.Context, id string) (*musicbrainz.Recording, error) {
+	if s.lookupRecordingFunc != nil {
+		return s.lookupRecordingFunc(ctx, id)
+	}
+	return nil, musicbrainz.ErrNotFound
+}
+
+func (s *stubMusicBrainz) SearchRecordings(ctx context.Context, query string, limit int, offset int) (*musicbrainz.RecordingSearchResult, error) {
+	if s.searchRecordingsFunc != nil {
+		return s.searchRecordingsFunc(ctx, query, limit, offset)
+	}
+	return nil, errors.New(unexpectedCall)
+}
+
+func (s *stubMusicBrainz) SearchReleaseGroups(ctx context.Context, query string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+	if s.searchReleaseGroupsFunc != nil {
+		return s.searchReleaseGroupsFunc(ctx, query, limit, offset)
+	}
+	return nil, errors.New(unexpectedCall)
+}
+
 type stubWikipedia struct {
 	getArtistBiographyFunc func(ctx context.Context, artistName string) (string, error)
 }
@@ -103,14 +177,14 @@ func (s *stubWikipedia) GetArtistBiography(ctx context.Context, artistName strin
 }
 
 type stubReviews struct {
-	getAlbumReviewFunc func(ctx context.Context, artistName, albumTitle string) (*data.Review, error)
+	getAlbumReviewsFunc func(ctx context.Context, artistName, albumTitle string) ([]data.Review, error)
 }
 
-func (s *stubReviews) GetAlbumReview(ctx context.Context, artistName, albumTitle string) (*data.Review, error) {
-	if s.getAlbumReviewFunc != nil {
-		return s.getAlbumReviewFunc(ctx, artistName, albumTitle)
+func (s *stubReviews) GetAlbumReviews(ctx context.Context, artistName, albumTitle string) ([]data.Review, error) {
+	if s.getAlbumReviewsFunc != nil {
+		return s.getAlbumReviewsFunc(ctx, artistName, albumTitle)
 	}
-	return &data.Review{}, nil // Return empty review by default
+	return nil, nil // No reviews by default
 }
 
 type stubAlbumRepo struct {
@@ -160,7 +234,7 @@ func TestArtistLookupHandlerReturnsCachedArtist(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, artistPath, nil)
 	res := httptest.NewRecorder()
 
-	artistLookupHandler(repo, mb, wiki).ServeHTTP(res, req)
+	artistLookupHandler(service.NewArtistService(service.Deps{Artists: repo, MusicBrainz: mb, Wikipedia: wiki}), nil).ServeHTTP(res, req)
 
 	if res.Code != http.StatusOK {
 		t.Fatalf(status200Fmt, res.Code)
@@ -204,7 +278,7 @@ func TestArtistLookupHandlerFetchesAndCaches(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, artistPath, nil)
 	res := httptest.NewRecorder()
 
-	artistLookupHandler(repo, mb, wiki).ServeHTTP(res, req)
+	artistLookupHandler(service.NewArtistService(service.Deps{Artists: repo, MusicBrainz: mb, Wikipedia: wiki}), nil).ServeHTTP(res, req)
 
 	if res.Code != http.StatusOK {
 		t.Fatalf(status200Fmt, res.Code)
@@ -232,7 +306,7 @@ func TestArtistLookupHandlerHandlesNotFound(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, missingPath, nil)
 	res := httptest.NewRecorder()
 
-	artistLookupHandler(repo, mb, wiki).ServeHTTP(res, req)
+	artistLookupHandler(service.NewArtistService(service.Deps{Artists: repo, MusicBrainz: mb, Wikipedia: wiki}), nil).ServeHTTP(res, req)
 
 	if res.Code != http.StatusNotFound {
 		t.Fatalf("expected status 404, got %d", res.Code)
@@ -247,7 +321,7 @@ func TestArtistLookupHandlerMethodNotAllowed(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, artistPath, strings.NewReader(""))
 	res := httptest.NewRecorder()
 
-	artistLookupHandler(repo, mb, wiki).ServeHTTP(res, req)
+	artistLookupHandler(service.NewArtistService(service.Deps{Artists: repo, MusicBrainz: mb, Wikipedia: wiki}), nil).ServeHTTP(res, req)
 
 	if res.Code != http.StatusMethodNotAllowed {
 		t.Fatalf("expected status 405, got %d", res.Code)
@@ -262,7 +336,22 @@ func TestArtistLookupHandlerBadRequest(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, baseArtistPath, nil)
 	res := httptest.NewRecorder()
 
-	artistLookupHandler(repo, mb, wiki).ServeHTTP(res, req)
+	artistLookupHandler(service.NewArtistService(service.Deps{Artists: repo, MusicBrainz: mb, Wikipedia: wiki}), nil).ServeHTTP(res, req)
+
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf(status400Fmt, res.Code)
+	}
+}
+
+func TestArtistLookupHandlerRejectsInvalidRefresh(t *testing.T) {
+	repo := &stubArtistRepo{}
+	mb := &stubMusicBrainz{}
+	wiki := &stubWikipedia{}
+
+	req := httptest.NewRequest(http.MethodGet, artistPath+"?refresh=sometimes", nil)
+	res := httptest.NewRecorder()
+
+	artistLookupHandler(service.NewArtistService(service.Deps{Artists: repo, MusicBrainz: mb, Wikipedia: wiki}), nil).ServeHTTP(res, req)
 
 	if res.Code != http.StatusBadRequest {
 		t.Fatalf(status400Fmt, res.Code)
@@ -281,7 +370,7 @@ func TestArtistLookupHandlerRepositoryError(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, artistPath, nil)
 	res := httptest.NewRecorder()
 
-	artistLookupHandler(repo, mb, wiki).ServeHTTP(res, req)
+	artistLookupHandler(service.NewArtistService(service.Deps{Artists: repo, MusicBrainz: mb, Wikipedia: wiki}), nil).ServeHTTP(res, req)
 
 	if res.Code != http.StatusInternalServerError {
 		t.Fatalf("expected status 500, got %d", res.Code)
@@ -301,7 +390,7 @@ func TestArtistLookupHandlerMusicBrainzError(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, artistPath, nil)
 	res := httptest.NewRecorder()
 
-	artistLookupHandler(repo, mb, wiki).ServeHTTP(res, req)
+	artistLookupHandler(service.NewArtistService(service.Deps{Artists: repo, MusicBrainz: mb, Wikipedia: wiki}), nil).ServeHTTP(res, req)
 
 	if res.Code != http.StatusBadGateway {
 		t.Fatalf("expected status 502, got %d", res.Code)
@@ -327,7 +416,7 @@ func TestAlbumLookupHandlerReturnsCachedAlbum(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, albumPath, nil)
 	res := httptest.NewRecorder()
 
-	albumLookupHandler(repo, mb, &stubReviews{}).ServeHTTP(res, req)
+	albumLookupHandler(service.NewAlbumService(service.Deps{Albums: repo, MusicBrainz: mb, Reviews: &stubReviews{}}), nil).ServeHTTP(res, req)
 
 	if res.Code != http.StatusOK {
 		t.Fatalf(status200Fmt, res.Code)
@@ -384,7 +473,7 @@ func TestAlbumLookupHandlerFetchesAndCaches(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, albumPath, nil)
 	res := httptest.NewRecorder()
 
-	albumLookupHandler(repo, mb, &stubReviews{}).ServeHTTP(res, req)
+	albumLookupHandler(service.NewAlbumService(service.Deps{Albums: repo, MusicBrainz: mb, Reviews: &stubReviews{}}), nil).ServeHTTP(res, req)
 
 	if res.Code != http.StatusOK {
 		t.Fatalf(status200Fmt, res.Code)
@@ -413,7 +502,7 @@ func TestAlbumLookupHandlerNotFound(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, missingAlbum, nil)
 	res := httptest.NewRecorder()
 
-	albumLookupHandler(repo, mb, &stubReviews{}).ServeHTTP(res, req)
+	albumLookupHandler(service.NewAlbumService(service.Deps{Albums: repo, MusicBrainz: mb, Reviews: &stubReviews{}}), nil).ServeHTTP(res, req)
 
 	if res.Code != http.StatusNotFound {
 		t.Fatalf("expected status 404, got %d", res.Code)
@@ -427,7 +516,7 @@ func TestAlbumLookupHandlerBadRequest(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, baseAlbumPath, nil)
 	res := httptest.NewRecorder()
 
-	albumLookupHandler(repo, mb, &stubReviews{}).ServeHTTP(res, req)
+	albumLookupHandler(service.NewAlbumService(service.Deps{Albums: repo, MusicBrainz: mb, Reviews: &stubReviews{}}), nil).ServeHTTP(res, req)
 
 	if res.Code != http.StatusBadRequest {
 		t.Fatalf(status400Fmt, res.Code)
@@ -459,7 +548,7 @@ func TestSearchHandlerReturnsResults(t *testing.T) {
 		},
 	}
 
-	handler := searchHandler(mb)
+	handler := searchHandler(mb, nil)
 	req := httptest.NewRequest(http.MethodGet, "/search?q=test+query", nil)
 	resp := httptest.NewRecorder()
 	handler.ServeHTTP(resp, req)
@@ -483,7 +572,7 @@ func TestSearchHandlerReturnsResults(t *testing.T) {
 
 func TestSearchHandlerRequiresQuery(t *testing.T) {
 	mb := &stubMusicBrainz{}
-	handler := searchHandler(mb)
+	handler := searchHandler(mb, nil)
 	req := httptest.NewRequest(http.MethodGet, "/search", nil)
 	resp := httptest.NewRecorder()
 	handler.ServeHTTP(resp, req)
@@ -492,3 +581,35 @@ func TestSearchHandlerRequiresQuery(t *testing.T) {
 		t.Fatalf(status400Fmt, resp.Code)
 	}
 }
+
+func TestSearchHandlerLocalSource(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	if err := store.SaveArtist(context.Background(), &data.Artist{ID: "pumpkins", Name: "The Smashing Pumpkins"}); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+
+	mb := &stubMusicBrainz{
+		searchArtistsFunc: func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.SearchResult, error) {
+			t.Fatalf(unexpectedCall)
+			return nil, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/search?q=smashing+pumpkins&source=local", nil)
+	resp := httptest.NewRecorder()
+	searchHandler(mb, store).ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, resp.Code)
+	}
+	var result localSearchResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if result.Count != 1 || result.Artists[0].ID != "pumpkins" {
+		t.Fatalf("unexpected local search result %+v", result)
+	}
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+// maxSearchEntries bounds the search cache; past it, expired entries are dropped and, if that
+// frees nothing, the whole cache is cleared.
+const maxSearchEntries = 1000
+
+// CacheTTLs bounds how long each kind of cached data is served before it is fetched again. A
+// zero TTL keeps that kind indefinitely, except Searches and NotFound, where zero turns that
+// cache off.
+type CacheTTLs struct {
+	// Artists and Albums are how long cached records are served before MusicBrainz is asked
+	// again. Staleness is judged by Modified.
+	Artists time.Duration
+	Albums  time.Duration
+	// Reviews is how long a cached album's reviews are served before they alone are refetched.
+	Reviews time.Duration
+	// Searches is how long a MusicBrainz search result is reused for the same query and page.
+	Searches time.Duration
+	// NotFound is how long an ID MusicBrainz reported missing is answered as not found.
+	NotFound time.Duration
+}
+
+// searchCache answers repeated MusicBrainz searches from memory, so a popular query or a user
+// paging back and forth doesn't spend the rate limit. Everything else passes straight through.
+// Cached results are shared between requests and must not be modified.
+type searchCache struct {
+	MusicBrainzClient
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]searchEntry
+}
+
+type searchEntry struct {
+	result  any
+	expires time.Time
+}
+
+// newSearchCache wraps client with a search cache holding results for ttl. A nil client or a
+// non-positive ttl returns client unchanged.
+func newSearchCache(client MusicBrainzClient, ttl time.Duration) MusicBrainzClient {
+	if client == nil || ttl <= 0 {
+		return client
+	}
+	return &searchCache{MusicBrainzClient: client, ttl: ttl, entries: make(map[string]searchEntry)}
+}
+
+func (c *searchCache) SearchArtists(ctx context.Context, query string, limit int, offset int) (*musicbrainz.SearchResult, error) {
+	return cachedSearch(c, searchKey("artist", query, limit, offset), func() (*musicbrainz.SearchResult, error) {
+		return c.MusicBrainzClient.SearchArtists(ctx, query, limit, offset)
+	})
+}
+
+func (c *searchCache) SearchReleaseGroups(ctx context.Context, query string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+	return cachedSearch(c, searchKey("release-group", query, limit, offset), func() (*musicbrainz.ReleaseGroupSearchResult, error) {
+		return c.MusicBrainzClient.SearchReleaseGroups(ctx, query, limit, offset)
+	})
+}
+
+func (c *searchCache) SearchRecordings(ctx context.Context, query string, limit int, offset int) (*musicbrainz.RecordingSearchResult, error) {
+	return cachedSearch(c, searchKey("recording", query, limit, offset), func() (*musicbrainz.RecordingSearchResult, error) {
+		return c.MusicBrainzClient.SearchRecordings(ctx, query, limit, offset)
+	})
+}
+
+func searchKey(entity, query string, limit, offset int) string {
+	return fmt.Sprintf("%s\x00%s\x00%d\x00%d", entity, query, limit, offset)
+}
+
+// cachedSearch serves key from c while fresh and otherwise runs search, caching only
+// successful results so a failure is retried on the next request.
+func cachedSearch[T any](c *searchCache, key string, search func() (*T, error)) (*T, error) {
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.result.(*T), nil
+	}
+
+	result, err := search()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxSearchEntries {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxSearchEntries {
+			clear(c.entries)
+		}
+	}
+	c.entries[key] = searchEntry{result: result, expires: now.Add(c.ttl)}
+	return result, nil
+}
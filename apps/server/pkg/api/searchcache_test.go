@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func TestSearchCacheReusesSuccessfulResults(t *testing.T) {
+	calls := 0
+	fail := true
+	client := newSearchCache(&stubMusicBrainz{
+		searchArtistsFunc: func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.SearchResult, error) {
+			calls++
+			if fail {
+				return nil, errors.New("upstream down")
+			}
+			return &musicbrainz.SearchResult{Count: calls}, nil
+		},
+	}, time.Hour)
+
+	if _, err := client.SearchArtists(context.Background(), "nirvana", 25, 0); err == nil {
+		t.Fatal("expected the upstream failure to surface")
+	}
+	fail = false
+	first, _ := client.SearchArtists(context.Background(), "nirvana", 25, 0)
+	second, _ := client.SearchArtists(context.Background(), "nirvana", 25, 0)
+	if calls != 2 || first != second {
+		t.Fatalf("expected the failure to be retried and the success reused, got %d calls", calls)
+	}
+
+	if _, err := client.SearchArtists(context.Background(), "nirvana", 25, 25); err != nil || calls != 3 {
+		t.Fatalf("expected another page to miss the cache, got %d calls (%v)", calls, err)
+	}
+}
+
+func TestNewSearchCacheDisabled(t *testing.T) {
+	stub := &stubMusicBrainz{}
+	if got := newSearchCache(stub, 0); got != MusicBrainzClient(stub) {
+		t.Fatal("expected a zero TTL to leave the client unwrapped")
+	}
+	if got := newSearchCache(nil, time.Hour); got != nil {
+		t.Fatal("expected a nil client to stay nil so handlers report it unavailable")
+	}
+}
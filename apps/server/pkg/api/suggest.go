@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"sort"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+// lowSearchScore is the best MusicBrainz relevance score below which a search is treated as a
+// likely misspelling and offered suggestions.
+const lowSearchScore = 80
+
+// maxSuggestions caps the didYouMean list.
+const maxSuggestions = 3
+
+// minSuggestionSimilarity is the lowest normalized name similarity offered as a suggestion.
+const minSuggestionSimilarity = 0.6
+
+// artistSearchResult adds spelling suggestions to a MusicBrainz artist search.
+type artistSearchResult struct {
+	*musicbrainz.SearchResult
+	DidYouMean []string `json:"didYouMean,omitempty"`
+}
+
+// needsSuggestions reports whether a MusicBrainz search found nothing convincing.
+func needsSuggestions(result *musicbrainz.SearchResult) bool {
+	return len(result.Artists) == 0 || result.Artists[0].Score < lowSearchScore
+}
+
+// cachedArtistNames lists cached names for suggestions. Failures only cost the suggestions.
+func cachedArtistNames(ctx context.Context, local db.ArtistSearcher) []string {
+	if local == nil {
+		return nil
+	}
+	names, err := local.ArtistNames(ctx)
+	if err != nil {
+		return nil
+	}
+	return names
+}
+
+// didYouMean proposes artist names close to query. MusicBrainz results score by the closer of
+// their name and aliases, so a query matching an alias suggests the artist's main name; cached
+// names score by name alone. Names equal to the query are never suggested.
+func didYouMean(query string, results []musicbrainz.Artist, cached []string) []string {
+	want := normalizeMatchTitle(query)
+	if want == "" {
+		return nil
+	}
+
+	best := make(map[string]float64)
+	consider := func(name string, similarity float64) {
+		if similarity < minSuggestionSimilarity || normalizeMatchTitle(name) == want {
+			return
+		}
+		if similarity > best[name] {
+			best[name] = similarity
+		}
+	}
+	for _, artist := range results {
+		similarity := titleSimilarity(want, normalizeMatchTitle(artist.Name))
+		for _, alias := range artist.Aliases {
+			similarity = max(similarity, titleSimilarity(want, normalizeMatchTitle(alias)))
+		}
+		consider(artist.Name, similarity)
+	}
+	for _, name := range cached {
+		consider(name, titleSimilarity(want, normalizeMatchTitle(name)))
+	}
+
+	suggestions := make([]string, 0, len(best))
+	for name := range best {
+		suggestions = append(suggestions, name)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if best[a] != best[b] {
+			return best[a] > best[b]
+		}
+		return a < b
+	})
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return suggestions
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func TestDidYouMean(t *testing.T) {
+	results := []musicbrainz.Artist{
+		{Name: "Prince", Aliases: []string{"Prince Rogers Nelson"}},
+		{Name: "Princess Chelsea"},
+	}
+	cached := []string{"Nirvana", "Portishead", "Prince"}
+
+	if got := didYouMean("Prince Rogers Nelsen", results, cached); !slices.Equal(got, []string{"Prince"}) {
+		t.Errorf("expected alias match to suggest Prince, got %v", got)
+	}
+	if got := didYouMean("Nirvanna", nil, cached); !slices.Equal(got, []string{"Nirvana"}) {
+		t.Errorf("expected cached near-miss, got %v", got)
+	}
+	if got := didYouMean("Nirvana", nil, cached); len(got) != 0 {
+		t.Errorf("expected no suggestion for an exact name, got %v", got)
+	}
+}
+
+func TestSearchHandlerSuggestsOnLowScores(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	if err := store.SaveArtist(context.Background(), &data.Artist{ID: "mbv", Name: "My Bloody Valentine"}); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+
+	mb := &stubMusicBrainz{
+		searchArtistsFunc: func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.SearchResult, error) {
+			return &musicbrainz.SearchResult{Artists: []musicbrainz.Artist{{ID: "x", Name: "Valentine", Score: 55}}, Count: 1}, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/search?q=my+bloddy+valentine", nil)
+	resp := httptest.NewRecorder()
+	searchHandler(mb, store).ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, resp.Code)
+	}
+	var result struct {
+		Artists    []musicbrainz.Artist `json:"artists"`
+		DidYouMean []string             `json:"didYouMean"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if len(result.Artists) != 1 || !slices.Equal(result.DidYouMean, []string{"My Bloody Valentine"}) {
+		t.Fatalf("unexpected search result %+v", result)
+	}
+}
+
+func TestSearchHandlerSkipsSuggestionsOnConfidentResults(t *testing.T) {
+	mb := &stubMusicBrainz{
+		searchArtistsFunc: func(ctx context.Context, query string, limit int, offset int) (*musicbrainz.SearchResult, error) {
+			return &musicbrainz.SearchResult{Artists: []musicbrainz.Artist{{ID: "x", Name: "Nirvana", Score: 100}}, Count: 1}, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/search?q=nirvan", nil)
+	resp := httptest.NewRecorder()
+	searchHandler(mb, nil).ServeHTTP(resp, req)
+
+	var result artistSearchResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if result.DidYouMean != nil {
+		t.Fatalf("expected no suggestions, got %v", result.DidYouMean)
+	}
+}
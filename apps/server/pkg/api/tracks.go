@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+)
+
+// trackLookupHandler serves GET /tracks/{id}: the recording behind an album track, with its
+// length, ISRCs, first release date, and credited artists.
+func trackLookupHandler(tracks service.TrackService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		id, err := parseResourceID(r.URL.Path, "/tracks/", "track id required")
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+
+		track, err := tracks.GetTrack(r.Context(), id)
+		if err != nil {
+			handleAPIError(w, r, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, track)
+	})
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/service"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func TestTrackLookupHandler(t *testing.T) {
+	mb := &stubMusicBrainz{
+		lookupRecordingFunc: func(ctx context.Context, id string) (*musicbrainz.Recording, error) {
+			if id != "rec-1" {
+				return nil, musicbrainz.ErrNotFound
+			}
+			return &musicbrainz.Recording{
+				ID:               id,
+				Title:            "Under Pressure",
+				Length:           248000,
+				ISRCs:            []string{"GBUM71029604"},
+				FirstReleaseDate: "1981-10-26",
+				ArtistCredit: []musicbrainz.ArtistCredit{
+					{Name: "Queen", JoinPhrase: " & ", Artist: musicbrainz.ReleaseGroupArtist{ID: "queen", Name: "Queen"}},
+					{Name: "David Bowie", Artist: musicbrainz.ReleaseGroupArtist{ID: "bowie", Name: "David Bowie"}},
+				},
+			}, nil
+		},
+	}
+	handler := trackLookupHandler(service.NewTrackService(service.Deps{MusicBrainz: mb}))
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/tracks/rec-1", nil))
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var payload struct {
+		data.Recording
+		Length string `json:"length"`
+	}
+	if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if payload.LengthMs != 248000 || payload.Length != "4:08" || payload.ArtistCredit != "Queen & David Bowie" {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+	if payload.FirstReleaseDate != (data.PartialDate{Year: 1981, Month: 10, Day: 26}) {
+		t.Errorf("expected the first release date, got %+v", payload.FirstReleaseDate)
+	}
+	if len(payload.Credits) != 2 || payload.Credits[1].ArtistID != "bowie" || len(payload.ISRCs) != 1 {
+		t.Errorf("expected both credits and the ISRC, got %+v", payload)
+	}
+
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/tracks/missing", nil))
+	if res.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown recording, got %d", res.Code)
+	}
+}
+
+func TestTrackLookupKeepsPartialReleaseDates(t *testing.T) {
+	for _, date := range []string{"1981", "1981-10"} {
+		mb := &stubMusicBrainz{
+			lookupRecordingFunc: func(ctx context.Context, id string) (*musicbrainz.Recording, error) {
+				return &musicbrainz.Recording{ID: id, Title: "Under Pressure", FirstReleaseDate: date}, nil
+			},
+		}
+		handler := trackLookupHandler(service.NewTrackService(service.Deps{MusicBrainz: mb}))
+
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/tracks/rec-1", nil))
+		if res.Code != http.StatusOK {
+			t.Fatalf(status200Fmt, res.Code)
+		}
+		var payload map[string]any
+		if err := json.Unmarshal(res.Body.Bytes(), &payload); err != nil {
+			t.Fatalf(decodeErrFmt, err)
+		}
+		if payload["firstReleaseDate"] != date {
+			t.Errorf("expected firstReleaseDate %q, got %v", date, payload["firstReleaseDate"])
+		}
+	}
+}
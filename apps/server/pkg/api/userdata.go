@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+)
+
+// userExportVersion is bumped whenever the export document changes incompatibly.
+const userExportVersion = 1
+
+// maxUserImportBytes bounds the size of an uploaded export document.
+const maxUserImportBytes = 32 << 20
+
+// userExport is the portable document produced by /me/export and accepted by /me/import. It
+// carries user-owned data only; cached MusicBrainz metadata is rebuilt on the target instance.
+type userExport struct {
+	Version     int               `json:"version"`
+	ExportedAt  time.Time         `json:"exportedAt"`
+	Playlists   []data.Playlist   `json:"playlists"`
+	OwnedAlbums []data.OwnedAlbum `json:"ownedAlbums"`
+}
+
+type userImportResponse struct {
+	Playlists   int `json:"playlists"`
+	OwnedAlbums int `json:"ownedAlbums"`
+}
+
+// userExportHandler serves GET /me/export as a downloadable JSON document.
+func userExportHandler(playlists db.PlaylistRepository, owned db.LibraryRepository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodGet) {
+			return
+		}
+		if playlists == nil || owned == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"user data storage unavailable"})
+			return
+		}
+
+		export := userExport{Version: userExportVersion, ExportedAt: time.Now().UTC()}
+		var err error
+		if export.Playlists, err = playlists.ListPlaylists(r.Context()); err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"playlist export failed"})
+			return
+		}
+		if export.OwnedAlbums, err = owned.ListOwnedAlbums(r.Context()); err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{"owned album export failed"})
+			return
+		}
+
+		w.Header().Set("Content-Disposition", `attachment; filename="freqshow-export.json"`)
+		writeJSON(w, http.StatusOK, export)
+	})
+}
+
+// userImportHandler accepts POST /me/import with a document from /me/export. Records are
+// upserted by ID, so importing the same document twice is harmless.
+func userImportHandler(playlists db.PlaylistRepository, owned db.LibraryRepository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assertMethod(w, r, http.MethodPost) {
+			return
+		}
+		if playlists == nil || owned == nil {
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{"user data storage unavailable"})
+			return
+		}
+
+		var doc userExport
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUserImportBytes)).Decode(&doc); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"request body must be a freqshow export document"})
+			return
+		}
+		if err := validateUserExport(&doc); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+
+		// Everything is validated up front so a bad record can't leave a half-applied import.
+		for i := range doc.Playlists {
+			if err := playlists.SavePlaylist(r.Context(), &doc.Playlists[i]); err != nil {
+				writeJSON(w, http.StatusInternalServerError, errorResponse{"playlist import failed"})
+				return
+			}
+		}
+		for i := range doc.OwnedAlbums {
+			if err := owned.MarkAlbumOwned(r.Context(), &doc.OwnedAlbums[i]); err != nil {
+				writeJSON(w, http.StatusInternalServerError, errorResponse{"owned album import failed"})
+				return
+			}
+		}
+
+		writeJSON(w, http.StatusOK, userImportResponse{
+			Playlists:   len(doc.Playlists),
+			OwnedAlbums: len(doc.OwnedAlbums),
+		})
+	})
+}
+
+func validateUserExport(doc *userExport) error {
+	if doc.Version != userExportVersion {
+		return fmt.Errorf("unsupported export version %d", doc.Version)
+	}
+	for i, playlist := range doc.Playlists {
+		if strings.TrimSpace(playlist.ID) == "" {
+			return fmt.Errorf("playlists[%d]: id required", i)
+		}
+	}
+	for i, album := range doc.OwnedAlbums {
+		if strings.TrimSpace(album.AlbumID) == "" {
+			return fmt.Errorf("ownedAlbums[%d]: albumId required", i)
+		}
+	}
+	return nil
+}
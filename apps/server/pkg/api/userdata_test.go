@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+)
+
+func TestUserExportImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source, err := db.NewMemoryStore(ctx)
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	if err := source.SavePlaylist(ctx, &data.Playlist{ID: "road-trip", Name: "Road Trip", Tracks: []data.PlaylistTrack{{Position: 1, Title: "Lithium"}}}); err != nil {
+		t.Fatalf("SavePlaylist: %v", err)
+	}
+	if err := source.MarkAlbumOwned(ctx, &data.OwnedAlbum{AlbumID: testAlbumID, Title: "Nevermind", ArtistName: "Nirvana"}); err != nil {
+		t.Fatalf("MarkAlbumOwned: %v", err)
+	}
+
+	res := httptest.NewRecorder()
+	userExportHandler(source, source).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/me/export", nil))
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+	var export userExport
+	if err := json.Unmarshal(res.Body.Bytes(), &export); err != nil {
+		t.Fatalf(decodeErrFmt, err)
+	}
+	if export.Version != userExportVersion || len(export.Playlists) != 1 || len(export.OwnedAlbums) != 1 {
+		t.Fatalf("unexpected export %+v", export)
+	}
+
+	target, err := db.NewMemoryStore(ctx)
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	res = httptest.NewRecorder()
+	body, _ := json.Marshal(export)
+	userImportHandler(target, target).ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/me/import", strings.NewReader(string(body))))
+	if res.Code != http.StatusOK {
+		t.Fatalf(status200Fmt, res.Code)
+	}
+
+	playlist, err := target.GetPlaylist(ctx, "road-trip")
+	if err != nil || playlist == nil || len(playlist.Tracks) != 1 {
+		t.Fatalf("expected imported playlist, got %+v (err %v)", playlist, err)
+	}
+	owned, err := target.ListOwnedAlbums(ctx)
+	if err != nil || len(owned) != 1 || owned[0].AlbumID != testAlbumID {
+		t.Fatalf("expected imported owned album, got %+v (err %v)", owned, err)
+	}
+}
+
+func TestUserImportRejectsInvalidDocument(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+
+	body := `{"version":1,"playlists":[{"id":"ok","name":"Fine"},{"id":" ","name":"Broken"}]}`
+	res := httptest.NewRecorder()
+	userImportHandler(store, store).ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/me/import", strings.NewReader(body)))
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf(status400Fmt, res.Code)
+	}
+	if playlists, _ := store.ListPlaylists(context.Background()); len(playlists) != 0 {
+		t.Fatalf("expected nothing imported from an invalid document, got %d playlists", len(playlists))
+	}
+}
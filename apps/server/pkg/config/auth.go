@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	apiKeysEnv         = "API_KEYS"
+	apiKeysDisabledEnv = "API_KEYS_DISABLED"
+	publicPathsEnv     = "API_PUBLIC_PATHS"
+
+	defaultPublicPaths = "/healthz,/readyz"
+)
+
+// AuthConfig controls which clients may call the API.
+type AuthConfig struct {
+	// Keys are the API keys clients send in X-API-Key. With none configured the API is open.
+	Keys []APIKey
+	// PublicPaths are served without a key so load balancers and orchestrators can probe the
+	// server.
+	PublicPaths []string
+}
+
+// APIKey is one named client credential.
+type APIKey struct {
+	// Name identifies the client in logs and in API_KEYS_DISABLED.
+	Name string
+	// Key is the secret the client sends.
+	Key string
+	// Enabled is false for keys listed in API_KEYS_DISABLED, which are refused without having
+	// to be deleted (and later reissued).
+	Enabled bool
+}
+
+// resolveAuth reads API_KEYS as comma-separated name:key pairs, API_KEYS_DISABLED as key
+// names, and API_PUBLIC_PATHS as paths served without a key. Problems never echo a key back.
+func resolveAuth() (AuthConfig, error) {
+	var errs []error
+	cfg := AuthConfig{}
+
+	index := make(map[string]int)
+	for _, entry := range strings.Split(envOrDefault(apiKeysEnv, ""), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, key, ok := strings.Cut(entry, ":")
+		name, key = strings.TrimSpace(name), strings.TrimSpace(key)
+		switch {
+		case !ok || name == "":
+			errs = append(errs, invalid(apiKeysEnv, redacted, "comma-separated name:key pairs"))
+		case key == "":
+			errs = append(errs, invalid(apiKeysEnv, name+":", "a non-empty key for every name"))
+		default:
+			if _, dup := index[name]; dup {
+				errs = append(errs, invalid(apiKeysEnv, name, "unique key names"))
+				continue
+			}
+			index[name] = len(cfg.Keys)
+			cfg.Keys = append(cfg.Keys, APIKey{Name: name, Key: key, Enabled: true})
+		}
+	}
+
+	for _, name := range strings.Split(envOrDefault(apiKeysDisabledEnv, ""), ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		i, ok := index[name]
+		if !ok {
+			errs = append(errs, invalid(apiKeysDisabledEnv, name, "names of keys listed in "+apiKeysEnv))
+			continue
+		}
+		cfg.Keys[i].Enabled = false
+	}
+
+	for _, path := range strings.Split(envOrDefault(publicPathsEnv, defaultPublicPaths), ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		if !strings.HasPrefix(path, "/") {
+			errs = append(errs, invalid(publicPathsEnv, path, "paths starting with /"))
+			continue
+		}
+		cfg.PublicPaths = append(cfg.PublicPaths, path)
+	}
+	return cfg, errors.Join(errs...)
+}
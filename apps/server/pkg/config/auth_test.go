@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveAuthReadsNamedKeys(t *testing.T) {
+	t.Setenv(apiKeysEnv, "web:k-web, cli : k-cli")
+	t.Setenv(apiKeysDisabledEnv, "cli")
+
+	cfg, err := resolveAuth()
+	if err != nil {
+		t.Fatalf("resolveAuth returned error: %v", err)
+	}
+	want := []APIKey{{Name: "web", Key: "k-web", Enabled: true}, {Name: "cli", Key: "k-cli"}}
+	if !slices.Equal(cfg.Keys, want) {
+		t.Errorf("Keys = %+v, want %+v", cfg.Keys, want)
+	}
+	if !slices.Equal(cfg.PublicPaths, []string{"/healthz", "/readyz"}) {
+		t.Errorf("expected the health checks to be public by default, got %v", cfg.PublicPaths)
+	}
+}
+
+func TestResolveAuthRejectsMalformedKeysWithoutEchoingThem(t *testing.T) {
+	t.Setenv(apiKeysEnv, "bare-secret,web:,ok:k,ok:k2")
+	t.Setenv(apiKeysDisabledEnv, "ghost")
+	t.Setenv(publicPathsEnv, "healthz")
+
+	_, err := resolveAuth()
+	var problems problems
+	problems.add(err)
+	if len(problems) != 5 {
+		t.Fatalf("expected 5 problems, got %v", err)
+	}
+	if strings.Contains(err.Error(), "bare-secret") {
+		t.Errorf("problem leaks a key: %v", err)
+	}
+	var problem *Problem
+	if !errors.As(problems[4], &problem) || problem.Var != publicPathsEnv {
+		t.Errorf("expected the relative path to be reported, got %v", problems[4])
+	}
+}
@@ -1,49 +1,155 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
 )
 
 const (
 	defaultPort                      = "8080"
 	defaultEnv                       = "development"
 	defaultShutdownSeconds           = 10
+	defaultReadHeaderTimeoutMillis   = 5000
+	defaultIdleTimeoutSeconds        = 120
+	defaultMaxHeaderBytes            = 1 << 20
 	defaultDatabaseDriver            = "sqlite"
 	defaultDatabaseURL               = "file:freqshow.db?_fk=1"
+	defaultDatabaseMaxOpenConns      = 10
+	defaultDatabaseMaxIdleConns      = 5
+	defaultDatabaseLifetimeSeconds   = 1800
 	defaultMusicBrainzBase           = "https://musicbrainz.org/ws/2"
 	defaultMusicBrainzApp            = "freq-show"
-	defaultMusicBrainzVer            = "dev"
 	defaultMusicBrainzContact        = "adamlacasse@outlook.com"
 	defaultMusicBrainzTimeoutSeconds = 6
+	defaultMusicBrainzRateLimit      = 1.0
 	defaultWikipediaBase             = "https://en.wikipedia.org/api/rest_v1"
-	defaultWikipediaUserAgent        = "FreqShow/1.0 (https://github.com/adamlacasse/freq-show)"
+	defaultWikipediaSourceBase       = "https://en.wikipedia.org/w/rest.php/v1"
 	defaultWikipediaTimeoutSeconds   = 8
-	defaultReviewsUserAgent          = "FreqShow/1.0 (https://github.com/adamlacasse/freq-show)"
 	defaultReviewsTimeoutSeconds     = 10
+	defaultReviewsRateLimit          = 1.0
+	defaultChaosErrorStatus          = 503
+	defaultDiscogsBase               = "https://api.discogs.com"
+	defaultCoverArtBase              = "https://coverartarchive.org"
+	defaultCoverArtTimeoutSeconds    = 8
+	defaultWikidataBase              = "https://www.wikidata.org/w/api.php"
+	defaultWikidataTimeoutSeconds    = 8
+	defaultSpotifyBase               = "https://api.spotify.com/v1"
+	defaultSpotifyAuthURL            = "https://accounts.spotify.com/api/token"
+	defaultSpotifyTimeoutSeconds     = 10
+	defaultLastfmBase                = "https://ws.audioscrobbler.com/2.0/"
+	defaultLastfmTimeoutSeconds      = 8
+	defaultLastfmRateLimit           = 4.0
+	defaultEnrichmentBudgetMillis    = 2000
+	defaultTombstoneRetentionHours   = 168
+	defaultReadDeadlineMillis        = 2000
+	defaultEnrichDeadlineMillis      = 15000
+	defaultBatchDeadlineMillis       = 120000
+	defaultLogSampleRate             = 0.1
+	defaultSlowRequestMillis         = 1000
+	defaultReenrichIntervalHours     = 24
+	defaultReenrichDelayMillis       = 1000
+	defaultReenrichBatchSize         = 50
+	defaultRetryMaxAttempts          = 3
+	defaultRetryBaseDelayMillis      = 200
+	defaultRetryMaxDelayMillis       = 2000
+	defaultMaxArtistAlbums           = 200
+	defaultEntityMaxAgeSeconds       = 300
+	defaultListingMaxAgeSeconds      = 60
 
 	shutdownTimeoutEnv              = "SHUTDOWN_TIMEOUT_SECONDS"
+	readHeaderTimeoutEnv            = "HTTP_READ_HEADER_TIMEOUT_MS"
+	idleTimeoutEnv                  = "HTTP_IDLE_TIMEOUT_SECONDS"
+	maxHeaderBytesEnv               = "HTTP_MAX_HEADER_BYTES"
+	h2cEnv                          = "HTTP_H2C"
+	basePathEnv                     = "API_BASE_PATH"
+	allowedHostsEnv                 = "ALLOWED_HOSTS"
+	corsOriginsEnv                  = "CORS_ALLOWED_ORIGINS"
 	portEnv                         = "PORT"
 	httpPortEnv                     = "HTTP_PORT"
 	environmentEnv                  = "APP_ENV"
 	databaseDriverEnv               = "DATABASE_DRIVER"
 	databaseURLEnv                  = "DATABASE_URL"
+	databaseMaxOpenConnsEnv         = "DATABASE_MAX_OPEN_CONNS"
+	databaseMaxIdleConnsEnv         = "DATABASE_MAX_IDLE_CONNS"
+	databaseConnMaxLifetimeEnv      = "DATABASE_CONN_MAX_LIFETIME_SECONDS"
 	musicBrainzBaseURLEnv           = "MUSICBRAINZ_BASE_URL"
-	musicBrainzTimeoutEnv           = "MUSICBRAINZ_TIMEOUT_SECONDS"
 	musicBrainzAppNameEnv           = "MUSICBRAINZ_APP_NAME"
 	musicBrainzAppVersionEnv        = "MUSICBRAINZ_APP_VERSION"
 	musicBrainzContactEnv           = "MUSICBRAINZ_CONTACT"
+	musicBrainzValidationEnv        = "MUSICBRAINZ_VALIDATION"
 	wikipediaBaseURLEnv             = "WIKIPEDIA_BASE_URL"
-	wikipediaTimeoutEnv             = "WIKIPEDIA_TIMEOUT_SECONDS"
+	wikipediaSourceURLEnv           = "WIKIPEDIA_SOURCE_URL"
+	wikipediaActionURLEnv           = "WIKIPEDIA_ACTION_URL"
 	wikipediaUserAgentEnv           = "WIKIPEDIA_USER_AGENT"
 	reviewsUserAgentEnv             = "REVIEWS_USER_AGENT"
-	reviewsTimeoutEnv               = "REVIEWS_TIMEOUT_SECONDS"
 	reviewsDiscogsTokenEnv          = "REVIEWS_DISCOGS_TOKEN"
 	reviewsDiscogsConsumerKeyEnv    = "REVIEWS_DISCOGS_CONSUMER_KEY"
 	reviewsDiscogsConsumerSecretEnv = "REVIEWS_DISCOGS_CONSUMER_SECRET"
+	reviewsDiscogsBaseURLEnv        = "REVIEWS_DISCOGS_BASE_URL"
+	reviewsDiscogsMarketplaceEnv    = "REVIEWS_DISCOGS_MARKETPLACE"
+	coverArtBaseURLEnv              = "COVERART_BASE_URL"
+	wikidataBaseURLEnv              = "WIKIDATA_BASE_URL"
+	spotifyBaseURLEnv               = "SPOTIFY_BASE_URL"
+	spotifyAuthURLEnv               = "SPOTIFY_AUTH_URL"
+	spotifyClientIDEnv              = "SPOTIFY_CLIENT_ID"
+	spotifyClientSecretEnv          = "SPOTIFY_CLIENT_SECRET"
+	lastfmBaseURLEnv                = "LASTFM_BASE_URL"
+	lastfmAPIKeyEnv                 = "LASTFM_API_KEY"
+	libraryPathEnv                  = "LIBRARY_PATH"
+	enrichmentBudgetEnv             = "ENRICHMENT_BUDGET_MS"
+	httpCacheDirEnv                 = "HTTP_CACHE_DIR"
+	upstreamDebugEnv                = "UPSTREAM_DEBUG"
+	adminTokenEnv                   = "ADMIN_TOKEN"
+	tombstoneRetentionEnv           = "TOMBSTONE_RETENTION_HOURS"
+	cacheTTLEnv                     = "CACHE_TTL_HOURS"
+	artistsTTLEnv                   = "CACHE_TTL_ARTISTS_HOURS"
+	albumsTTLEnv                    = "CACHE_TTL_ALBUMS_HOURS"
+	reviewsTTLEnv                   = "CACHE_TTL_REVIEWS_HOURS"
+	searchesTTLEnv                  = "CACHE_TTL_SEARCHES_MINUTES"
+	notFoundTTLEnv                  = "CACHE_TTL_NOT_FOUND_MINUTES"
+	readDeadlineEnv                 = "DEADLINE_READ_MS"
+	enrichDeadlineEnv               = "DEADLINE_ENRICH_MS"
+	batchDeadlineEnv                = "DEADLINE_BATCH_MS"
+	logSampleRateEnv                = "LOG_SAMPLE_RATE"
+	logLevelEnv                     = "LOG_LEVEL"
+	logFormatEnv                    = "LOG_FORMAT"
+	slowRequestEnv                  = "SLOW_REQUEST_MS"
+	reenrichIntervalEnv             = "REENRICH_INTERVAL_HOURS"
+	reenrichDelayEnv                = "REENRICH_DELAY_MS"
+	reenrichBatchSizeEnv            = "REENRICH_BATCH_SIZE"
+	maxArtistAlbumsEnv              = "MAX_ARTIST_ALBUMS"
+	entityMaxAgeEnv                 = "CACHE_ENTITY_MAX_AGE_SECONDS"
+	listingMaxAgeEnv                = "CACHE_LISTING_MAX_AGE_SECONDS"
+	chaosEnabledEnv                 = "CHAOS_ENABLED"
+	chaosLatencyEnv                 = "CHAOS_LATENCY_MS"
+	chaosLatencyRateEnv             = "CHAOS_LATENCY_RATE"
+	chaosErrorRateEnv               = "CHAOS_ERROR_RATE"
+	chaosErrorStatusEnv             = "CHAOS_ERROR_STATUS"
+	chaosRetryAfterEnv              = "CHAOS_RETRY_AFTER_SECONDS"
+	chaosSourcesEnv                 = "CHAOS_SOURCES"
+
+	// Every source reads the same settings under its own prefix; see SourceConfig. Retry
+	// settings also read RETRY_* as the shared default.
+	enabledSuffix          = "ENABLED"
+	timeoutSuffix          = "TIMEOUT_SECONDS"
+	rateLimitSuffix        = "RATE_LIMIT"
+	retryMaxAttemptsSuffix = "RETRY_MAX_ATTEMPTS"
+	retryBaseDelaySuffix   = "RETRY_BASE_DELAY_MS"
+	retryMaxDelaySuffix    = "RETRY_MAX_DELAY_MS"
+	musicBrainzPrefix      = "MUSICBRAINZ_"
+	wikipediaPrefix        = "WIKIPEDIA_"
+	reviewsPrefix          = "REVIEWS_"
+	coverArtPrefix         = "COVERART_"
+	wikidataPrefix         = "WIKIDATA_"
+	spotifyPrefix          = "SPOTIFY_"
+	lastfmPrefix           = "LASTFM_"
 )
 
 // Config captures runtime configuration derived from environment variables.
@@ -54,7 +160,147 @@ type Config struct {
 	MusicBrainz     MusicBrainzConfig
 	Wikipedia       WikipediaConfig
 	Reviews         ReviewsConfig
+	CoverArt        CoverArtConfig
+	Wikidata        WikidataConfig
+	Spotify         SpotifyConfig
+	Lastfm          LastfmConfig
+	Library         LibraryConfig
 	Database        DatabaseConfig
+	// HTTP tunes the listener's connection handling.
+	HTTP HTTPConfig
+	// EnrichmentBudget caps the total time spent on optional sources (Wikipedia, reviews,
+	// images) per artist or album lookup. Zero disables the budget.
+	EnrichmentBudget time.Duration
+	// HTTPCacheDir is where upstream responses are cached on disk. Empty disables the cache.
+	HTTPCacheDir string
+	// UpstreamDebug starts the server with upstream request logging on; it can be toggled
+	// later via PUT /admin/debug/upstream.
+	UpstreamDebug bool
+	// AdminToken is the bearer token for /admin endpoints. Empty restricts them to localhost.
+	AdminToken string
+	// Auth lists the API keys clients must send and the paths that need none.
+	Auth AuthConfig
+	// ClientRateLimit caps how fast each client may call the API.
+	ClientRateLimit ClientRateLimitConfig
+	// RatingWebhooks are told when a cached album's aggregate rating changes.
+	RatingWebhooks RatingWebhookConfig
+	// ImageAnalysis derives theme colors and a placeholder from each record's primary image.
+	ImageAnalysis ImageAnalysisConfig
+	// ImageProxy serves resized copies of upstream images.
+	ImageProxy ImageProxyConfig
+	// TombstoneRetention is how long invalidated records stay restorable before being purged.
+	TombstoneRetention time.Duration
+	// Deadlines bound how long each class of route may run before its context is cancelled.
+	Deadlines DeadlineConfig
+	// LogLevel drops log records below it.
+	LogLevel slog.Level
+	// LogFormat is "text" for human-readable lines or "json" for log collectors.
+	LogFormat string
+	// LogSampleRate is the fraction (0-1) of fast, successful requests written to the access log.
+	LogSampleRate float64
+	// SlowRequest always logs requests at least this slow, with upstream timings. Zero disables it.
+	SlowRequest time.Duration
+	// Reenrich schedules the background job that fills fields missing from cached records.
+	Reenrich ReenrichConfig
+	// MaxArtistAlbums caps the albums and EPs fetched with an artist, paging MusicBrainz 100
+	// at a time; artists with more are flagged as truncated.
+	MaxArtistAlbums int
+	// Chaos injects upstream faults during development; see ChaosConfig.
+	Chaos ChaosConfig
+	// CacheControl sets the max-age clients may reuse responses for, by route class.
+	CacheControl CacheControlConfig
+	// CacheTTL bounds how long each kind of cached data is served before it is fetched again.
+	CacheTTL CacheTTLConfig
+}
+
+// Default cache lifetimes. Artists and albums change rarely, so a week between refetches keeps
+// MusicBrainz traffic low; reviews accumulate faster and are refetched on their own every three
+// days. Search results are reused just long enough to cover a user paging back and forth, and
+// a mistyped or deleted ID is answered as missing for an hour in case it was just added.
+const (
+	defaultCacheTTLHours      = 168
+	defaultReviewsTTLHours    = 72
+	defaultSearchesTTLMinutes = 10
+	defaultNotFoundTTLMinutes = 60
+)
+
+// CacheTTLConfig holds the cache lifetime of each kind of data. Zero keeps records and reviews
+// indefinitely and turns the search and not-found caches off.
+type CacheTTLConfig struct {
+	// Artists and Albums are how long cached records are served before they are refetched from
+	// MusicBrainz; the stale copy is still served if the refetch fails.
+	Artists time.Duration
+	Albums  time.Duration
+	// Reviews is how long an album's reviews are served before they alone are refetched.
+	Reviews time.Duration
+	// Searches is how long a MusicBrainz search result is reused for the same query and page.
+	Searches time.Duration
+	// NotFound is how long an ID MusicBrainz reported missing is answered as not found.
+	NotFound time.Duration
+}
+
+// CacheControlConfig holds the Cache-Control max-age per route class. Zero makes clients
+// revalidate every time.
+type CacheControlConfig struct {
+	// Entity covers artist, album, label, and recording lookups.
+	Entity time.Duration
+	// Listing covers search and browse results.
+	Listing time.Duration
+}
+
+// HTTPConfig bounds how long and how much a client may send before the server gives up on a
+// connection, so slow or oversized requests can't tie up the listener.
+type HTTPConfig struct {
+	// ReadHeaderTimeout caps the time to read request headers. Zero leaves it unbounded.
+	ReadHeaderTimeout time.Duration
+	// IdleTimeout closes keep-alive connections left idle this long.
+	IdleTimeout time.Duration
+	// MaxHeaderBytes caps the size of request headers.
+	MaxHeaderBytes int
+	// H2C serves HTTP/2 over cleartext alongside HTTP/1.1, for proxies that speak h2c.
+	H2C bool
+	// BasePath mounts the API under a prefix such as /api; empty serves it at the root.
+	BasePath string
+	// AllowedHosts lists the lowercase Host headers served, with or without a port; empty
+	// accepts any host.
+	AllowedHosts []string
+	// CORSOrigins lists the origins allowed to call the API from a browser; "*" allows any,
+	// and empty sends no CORS headers.
+	CORSOrigins []string
+}
+
+// ChaosConfig injects latency and failures into upstream calls for resilience testing. It can
+// only be enabled in the development environment.
+type ChaosConfig struct {
+	Enabled     bool
+	Latency     time.Duration
+	LatencyRate float64
+	ErrorRate   float64
+	// ErrorStatus is the status of injected failures; zero fails them as dropped connections.
+	ErrorStatus int
+	RetryAfter  time.Duration
+	// Sources limits injection to the named upstreams; empty means all of them.
+	Sources []string
+}
+
+// ReenrichConfig controls the background re-enrichment job.
+type ReenrichConfig struct {
+	// Interval is how often the job runs. Zero disables the schedule; the admin trigger still works.
+	Interval time.Duration
+	// Delay spaces out the records revisited so upstream rate limits are respected.
+	Delay time.Duration
+	// BatchSize caps the artists and albums revisited per scheduled run.
+	BatchSize int
+}
+
+// DeadlineConfig holds per-route-class handler deadlines. Zero leaves a class unbounded.
+type DeadlineConfig struct {
+	// Read covers routes served from the local store.
+	Read time.Duration
+	// Enrich covers lookups that may go to MusicBrainz and the enrichment sources.
+	Enrich time.Duration
+	// Batch covers imports and library scans.
+	Batch time.Duration
 }
 
 // MusicBrainzConfig describes how the MusicBrainz client should connect.
@@ -63,73 +309,208 @@ type MusicBrainzConfig struct {
 	AppName    string
 	AppVersion string
 	Contact    string
-	Timeout    time.Duration
+	// Validation is one of "off", "log", or "reject".
+	Validation string
+	SourceConfig
+}
+
+// RetryConfig describes how a source client retries transient upstream failures.
+// MaxAttempts of 1 disables retries.
+type RetryConfig struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
 }
 
 // WikipediaConfig describes how the Wikipedia client should connect.
 type WikipediaConfig struct {
-	BaseURL   string
+	BaseURL string
+	// SourceURL is the core REST API used to read article wikitext for album facts.
+	SourceURL string
+	// ActionURL is the MediaWiki Action API used when the REST summary endpoint fails; empty
+	// derives it from BaseURL.
+	ActionURL string
+	// UserAgent overrides the shared user agent built from the MusicBrainz app settings.
 	UserAgent string
-	Timeout   time.Duration
+	SourceConfig
 }
 
 // ReviewsConfig describes how the reviews client should connect.
 type ReviewsConfig struct {
+	// UserAgent overrides the shared user agent built from the MusicBrainz app settings.
 	UserAgent             string
-	Timeout               time.Duration
 	DiscogsToken          string
 	DiscogsConsumerKey    string
 	DiscogsConsumerSecret string
+	DiscogsBaseURL        string
+	// Marketplace adds Discogs marketplace price stats to albums.
+	Marketplace bool
+	SourceConfig
+}
+
+// WikidataConfig describes how the Wikidata client should connect.
+type WikidataConfig struct {
+	BaseURL string
+	SourceConfig
+}
+
+// CoverArtConfig describes how the Cover Art Archive client should connect.
+type CoverArtConfig struct {
+	BaseURL string
+	SourceConfig
+}
+
+// SpotifyConfig describes how the Spotify client should connect. The client is only
+// built when it is enabled and both ClientID and ClientSecret are set.
+type SpotifyConfig struct {
+	BaseURL      string
+	AuthURL      string
+	ClientID     string
+	ClientSecret string
+	SourceConfig
+}
+
+// Active reports whether Spotify is enabled and its credentials were supplied.
+func (c SpotifyConfig) Active() bool {
+	return c.Enabled && c.ClientID != "" && c.ClientSecret != ""
 }
 
-// DatabaseConfig describes how application persistence should be configured.
+// LastfmConfig describes how the Last.fm client should connect. The client is only built when
+// it is enabled and APIKey is set.
+type LastfmConfig struct {
+	BaseURL string
+	APIKey  string
+	SourceConfig
+}
+
+// Active reports whether Last.fm is enabled and an API key was supplied.
+func (c LastfmConfig) Active() bool {
+	return c.Enabled && c.APIKey != ""
+}
+
+// LibraryConfig points at the local music folder scanned for owned albums. Scanning is
+// disabled when Path is empty.
+type LibraryConfig struct {
+	Path string
+}
+
+// DatabaseConfig describes how application persistence should be configured. The pool
+// settings only apply to the postgres driver.
 type DatabaseConfig struct {
-	Driver string
-	URL    string
+	Driver          string
+	URL             string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
 }
 
 // Load reads environment variables and assembles a Config instance.
 func Load() (*Config, error) {
-	port, err := resolvePort()
-	if err != nil {
-		return nil, err
-	}
+	var errs problems
 
-	shutdownTimeout, err := resolveShutdownTimeout()
-	if err != nil {
-		return nil, err
-	}
+	env := strings.TrimSpace(envOrDefault(environmentEnv, defaultEnv))
+	preset := profileFor(env)
 
+	port, err := resolvePort()
+	errs.add(err)
+	shutdownTimeout, err := resolveShutdownTimeout()
+	errs.add(err)
+	httpConfig, err := resolveHTTP(preset)
+	errs.add(err)
+	auth, err := resolveAuth()
+	errs.add(err)
+	clientRateLimit, err := resolveClientRateLimit()
+	errs.add(err)
+	ratingWebhooks, err := resolveRatingWebhooks()
+	errs.add(err)
+	imageAnalysis, err := resolveImageAnalysis()
+	errs.add(err)
+	imageProxy, err := resolveImageProxy()
+	errs.add(err)
 	musicBrainz, err := resolveMusicBrainz()
-	if err != nil {
-		return nil, err
-	}
-
+	errs.add(err)
 	wikipedia, err := resolveWikipedia()
-	if err != nil {
-		return nil, err
-	}
-
+	errs.add(err)
 	reviews, err := resolveReviews()
-	if err != nil {
-		return nil, err
-	}
+	errs.add(err)
+	coverArt, err := resolveCoverArt()
+	errs.add(err)
+	wikidata, err := resolveWikidata()
+	errs.add(err)
+	spotify, err := resolveSpotify()
+	errs.add(err)
+	lastfm, err := resolveLastfm()
+	errs.add(err)
+	database, err := resolveDatabase(preset)
+	errs.add(err)
+	upstreamDebug, err := resolveBool(upstreamDebugEnv, false)
+	errs.add(err)
+	enrichmentBudget, err := resolveEnrichmentBudget()
+	errs.add(err)
+	tombstoneRetention, err := resolveTombstoneRetention()
+	errs.add(err)
+	deadlines, err := resolveDeadlines()
+	errs.add(err)
+	logSampleRate, err := resolveFraction(logSampleRateEnv, preset.logSampleRate)
+	errs.add(err)
+	logLevel, err := resolveLogLevel(preset.logLevel)
+	errs.add(err)
+	logFormat, err := resolveLogFormat(preset.logFormat)
+	errs.add(err)
+	slowRequest, err := resolveMillis(slowRequestEnv, defaultSlowRequestMillis)
+	errs.add(err)
+	reenrich, err := resolveReenrich()
+	errs.add(err)
+	maxArtistAlbums, err := resolvePositiveInt(maxArtistAlbumsEnv, defaultMaxArtistAlbums)
+	errs.add(err)
+	cacheControl, err := resolveCacheControl()
+	errs.add(err)
+	cacheTTL, err := resolveCacheTTL()
+	errs.add(err)
 
-	database, err := resolveDatabase()
-	if err != nil {
+	chaos, err := resolveChaos(env)
+	errs.add(err)
+
+	if err := errs.err(); err != nil {
 		return nil, err
 	}
 
-	env := strings.TrimSpace(envOrDefault(environmentEnv, defaultEnv))
-
 	return &Config{
 		Env:             env,
 		Port:            port,
 		ShutdownTimeout: shutdownTimeout,
+		HTTP:            httpConfig,
 		MusicBrainz:     musicBrainz,
 		Wikipedia:       wikipedia,
 		Reviews:         reviews,
+		CoverArt:        coverArt,
+		Wikidata:        wikidata,
+		Spotify:         spotify,
+		Lastfm:          lastfm,
+		Library:         LibraryConfig{Path: strings.TrimSpace(envOrDefault(libraryPathEnv, ""))},
 		Database:        database,
+
+		EnrichmentBudget: enrichmentBudget,
+		HTTPCacheDir:     strings.TrimSpace(envOrDefault(httpCacheDirEnv, "")),
+		UpstreamDebug:    upstreamDebug,
+		AdminToken:       strings.TrimSpace(envOrDefault(adminTokenEnv, "")),
+		Auth:             auth,
+		ClientRateLimit:  clientRateLimit,
+		RatingWebhooks:   ratingWebhooks,
+		ImageAnalysis:    imageAnalysis,
+		ImageProxy:       imageProxy,
+
+		TombstoneRetention: tombstoneRetention,
+		Deadlines:          deadlines,
+		LogLevel:           logLevel,
+		LogFormat:          logFormat,
+		LogSampleRate:      logSampleRate,
+		SlowRequest:        slowRequest,
+		Reenrich:           reenrich,
+		MaxArtistAlbums:    maxArtistAlbums,
+		Chaos:              chaos,
+		CacheControl:       cacheControl,
+		CacheTTL:           cacheTTL,
 	}, nil
 }
 
@@ -151,7 +532,11 @@ func envOrDefault(key, fallback string) string {
 func resolvePort() (string, error) {
 	for _, key := range []string{portEnv, httpPortEnv} {
 		if val, ok := lookupNonEmpty(key); ok {
-			return normalizePort(val)
+			port, err := normalizePort(val)
+			if err != nil {
+				return "", invalid(key, val, "a port number or host:port")
+			}
+			return port, nil
 		}
 	}
 	return normalizePort(defaultPort)
@@ -163,14 +548,351 @@ func resolveShutdownTimeout() (time.Duration, error) {
 		return time.Duration(defaultShutdownSeconds) * time.Second, nil
 	}
 
-	seconds, err := strconv.Atoi(val)
+	timeout, ok := parseDuration(val, time.Second)
+	if !ok {
+		return 0, invalid(shutdownTimeoutEnv, val, "a "+durationIn("seconds"))
+	}
+	if timeout <= 0 {
+		timeout = time.Duration(defaultShutdownSeconds) * time.Second
+	}
+	return timeout, nil
+}
+
+func resolveHTTP(preset profile) (HTTPConfig, error) {
+	var errs []error
+	readHeader, err := resolveMillis(readHeaderTimeoutEnv, defaultReadHeaderTimeoutMillis)
+	errs = append(errs, err)
+	idle, err := resolveSeconds(idleTimeoutEnv, defaultIdleTimeoutSeconds)
+	errs = append(errs, err)
+	maxHeaderBytes, err := resolvePositiveInt(maxHeaderBytesEnv, defaultMaxHeaderBytes)
+	errs = append(errs, err)
+	h2c, err := resolveBool(h2cEnv, false)
+	errs = append(errs, err)
+	basePath, err := resolveBasePath()
+	errs = append(errs, err)
+
+	cfg := HTTPConfig{ReadHeaderTimeout: readHeader, IdleTimeout: idle, MaxHeaderBytes: maxHeaderBytes, H2C: h2c, BasePath: basePath}
+	for _, host := range strings.Split(envOrDefault(allowedHostsEnv, ""), ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			cfg.AllowedHosts = append(cfg.AllowedHosts, host)
+		}
+	}
+	for _, origin := range strings.Split(envOrDefault(corsOriginsEnv, preset.corsOrigins), ",") {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			cfg.CORSOrigins = append(cfg.CORSOrigins, origin)
+		}
+	}
+	return cfg, errors.Join(errs...)
+}
+
+// resolveBasePath normalizes the mount prefix to a leading slash and no trailing slash, so
+// "api/", "/api", and "/api/" all mount at /api.
+func resolveBasePath() (string, error) {
+	raw := strings.TrimSpace(envOrDefault(basePathEnv, ""))
+	trimmed := strings.Trim(raw, "/")
+	if trimmed == "" {
+		return "", nil
+	}
+	if strings.ContainsAny(trimmed, "?# ") {
+		return "", invalid(basePathEnv, raw, "a URL path such as /api")
+	}
+	return "/" + trimmed, nil
+}
+
+func resolveBool(key string, fallback bool) (bool, error) {
+	val, ok := lookupNonEmpty(key)
+	if !ok {
+		return fallback, nil
+	}
+	parsed, err := strconv.ParseBool(val)
 	if err != nil {
-		return 0, fmt.Errorf("invalid %s value %q: %w", shutdownTimeoutEnv, val, err)
+		return false, invalid(key, val, "true or false")
 	}
-	if seconds <= 0 {
-		seconds = defaultShutdownSeconds
+	return parsed, nil
+}
+
+func resolveEnrichmentBudget() (time.Duration, error) {
+	return resolveMillis(enrichmentBudgetEnv, defaultEnrichmentBudgetMillis)
+}
+
+func resolveDeadlines() (DeadlineConfig, error) {
+	read, readErr := resolveMillis(readDeadlineEnv, defaultReadDeadlineMillis)
+	enrich, enrichErr := resolveMillis(enrichDeadlineEnv, defaultEnrichDeadlineMillis)
+	batch, batchErr := resolveMillis(batchDeadlineEnv, defaultBatchDeadlineMillis)
+	return DeadlineConfig{Read: read, Enrich: enrich, Batch: batch}, errors.Join(readErr, enrichErr, batchErr)
+}
+
+// resolveFraction reads a rate between 0 and 1.
+func resolveFraction(key string, fallback float64) (float64, error) {
+	val, ok := lookupNonEmpty(key)
+	if !ok {
+		return fallback, nil
+	}
+
+	rate, err := strconv.ParseFloat(val, 64)
+	if err != nil || rate < 0 || rate > 1 {
+		return 0, invalid(key, val, "a number between 0 and 1")
+	}
+	return rate, nil
+}
+
+// resolveLogLevel reads the minimum log level: debug, info, warn, or error.
+func resolveLogLevel(fallback slog.Level) (slog.Level, error) {
+	val, ok := lookupNonEmpty(logLevelEnv)
+	if !ok {
+		return fallback, nil
+	}
+	level, ok := logging.ParseLevel(val)
+	if !ok {
+		return fallback, invalid(logLevelEnv, val, "debug, info, warn, or error")
+	}
+	return level, nil
+}
+
+// resolveLogFormat reads the log output format: text or json.
+func resolveLogFormat(fallback string) (string, error) {
+	val, ok := lookupNonEmpty(logFormatEnv)
+	if !ok {
+		return fallback, nil
+	}
+	switch format := strings.ToLower(val); format {
+	case logging.FormatText, logging.FormatJSON:
+		return format, nil
+	}
+	return fallback, invalid(logFormatEnv, val, "text or json")
+}
+
+// resolveMillis reads a millisecond duration, treating negative values as zero (disabled).
+func resolveMillis(key string, fallback int) (time.Duration, error) {
+	val, ok := lookupNonEmpty(key)
+	if !ok {
+		return time.Duration(fallback) * time.Millisecond, nil
+	}
+
+	d, ok := parseDuration(val, time.Millisecond)
+	if !ok {
+		return 0, invalid(key, val, "a "+durationIn("milliseconds"))
 	}
-	return time.Duration(seconds) * time.Second, nil
+	return max(d, 0), nil
+}
+
+func resolveTombstoneRetention() (time.Duration, error) {
+	val, ok := lookupNonEmpty(tombstoneRetentionEnv)
+	if !ok {
+		return time.Duration(defaultTombstoneRetentionHours) * time.Hour, nil
+	}
+
+	retention, ok := parseDuration(val, time.Hour)
+	if !ok || retention <= 0 {
+		return 0, invalid(tombstoneRetentionEnv, val, "a positive "+durationIn("hours"))
+	}
+	return retention, nil
+}
+
+// resolveCacheTTL reads the per-kind cache lifetimes. CACHE_TTL_HOURS sets artists and albums
+// together; the per-kind variables override it.
+func resolveCacheTTL() (CacheTTLConfig, error) {
+	records, recordsErr := resolveTTL(cacheTTLEnv, time.Duration(defaultCacheTTLHours)*time.Hour, time.Hour, "hours")
+	artists, artistsErr := resolveTTL(artistsTTLEnv, records, time.Hour, "hours")
+	albums, albumsErr := resolveTTL(albumsTTLEnv, records, time.Hour, "hours")
+	reviews, reviewsErr := resolveTTL(reviewsTTLEnv, time.Duration(defaultReviewsTTLHours)*time.Hour, time.Hour, "hours")
+	searches, searchesErr := resolveTTL(searchesTTLEnv, time.Duration(defaultSearchesTTLMinutes)*time.Minute, time.Minute, "minutes")
+	notFound, notFoundErr := resolveTTL(notFoundTTLEnv, time.Duration(defaultNotFoundTTLMinutes)*time.Minute, time.Minute, "minutes")
+	return CacheTTLConfig{Artists: artists, Albums: albums, Reviews: reviews, Searches: searches, NotFound: notFound},
+		errors.Join(recordsErr, artistsErr, albumsErr, reviewsErr, searchesErr, notFoundErr)
+}
+
+// resolveTTL reads a non-negative cache lifetime, with bare numbers counted in unit.
+func resolveTTL(key string, fallback, unit time.Duration, unitName string) (time.Duration, error) {
+	val, ok := lookupNonEmpty(key)
+	if !ok {
+		return fallback, nil
+	}
+
+	ttl, ok := parseDuration(val, unit)
+	if !ok || ttl < 0 {
+		return fallback, invalid(key, val, "a non-negative "+durationIn(unitName))
+	}
+	return ttl, nil
+}
+
+func resolveCacheControl() (CacheControlConfig, error) {
+	entity, entityErr := resolveSeconds(entityMaxAgeEnv, defaultEntityMaxAgeSeconds)
+	listing, listingErr := resolveSeconds(listingMaxAgeEnv, defaultListingMaxAgeSeconds)
+	return CacheControlConfig{Entity: entity, Listing: listing}, errors.Join(entityErr, listingErr)
+}
+
+// resolveSeconds reads a non-negative duration in seconds.
+func resolveSeconds(key string, fallback int) (time.Duration, error) {
+	val, ok := lookupNonEmpty(key)
+	if !ok {
+		return time.Duration(fallback) * time.Second, nil
+	}
+
+	d, ok := parseDuration(val, time.Second)
+	if !ok || d < 0 {
+		return 0, invalid(key, val, "a non-negative "+durationIn("seconds"))
+	}
+	return d, nil
+}
+
+// resolveTimeout reads an upstream timeout in seconds, keeping the default for values of zero
+// or less.
+func resolveTimeout(key string, fallback int) (time.Duration, error) {
+	timeout := time.Duration(fallback) * time.Second
+	val, ok := lookupNonEmpty(key)
+	if !ok {
+		return timeout, nil
+	}
+
+	d, ok := parseDuration(val, time.Second)
+	if !ok {
+		return timeout, invalid(key, val, "a "+durationIn("seconds"))
+	}
+	if d > 0 {
+		timeout = d
+	}
+	return timeout, nil
+}
+
+// parseDuration reads a Go duration string such as "500ms" or "2m", or a bare integer counted
+// in unit, which is how these settings were written before durations were accepted.
+func parseDuration(val string, unit time.Duration) (time.Duration, bool) {
+	if n, err := strconv.Atoi(val); err == nil {
+		return time.Duration(n) * unit, true
+	}
+	d, err := time.ParseDuration(val)
+	return d, err == nil
+}
+
+// durationIn describes what parseDuration accepts for a setting whose bare numbers count unit.
+func durationIn(unit string) string {
+	return `duration such as "1500ms" or "2m", or a whole number of ` + unit
+}
+
+// resolvePositiveInt reads an integer that must be at least one.
+func resolvePositiveInt(key string, fallback int) (int, error) {
+	val, ok := lookupNonEmpty(key)
+	if !ok {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(val)
+	if err != nil || n <= 0 {
+		return 0, invalid(key, val, "a positive integer")
+	}
+	return n, nil
+}
+
+// resolveChaos reads the fault injection settings, refusing to enable them outside development
+// so a stray variable cannot degrade a real deployment.
+func resolveChaos(env string) (ChaosConfig, error) {
+	enabled, err := resolveBool(chaosEnabledEnv, false)
+	if err != nil || !enabled {
+		return ChaosConfig{}, err
+	}
+	if env != defaultEnv {
+		return ChaosConfig{}, invalid(chaosEnabledEnv, envOrDefault(chaosEnabledEnv, ""), "false unless "+environmentEnv+"="+defaultEnv)
+	}
+
+	var errs []error
+	cfg := ChaosConfig{Enabled: true, ErrorStatus: defaultChaosErrorStatus}
+	cfg.Latency, err = resolveMillis(chaosLatencyEnv, 0)
+	errs = append(errs, err)
+	cfg.LatencyRate, err = resolveFraction(chaosLatencyRateEnv, 1)
+	errs = append(errs, err)
+	cfg.ErrorRate, err = resolveFraction(chaosErrorRateEnv, 0)
+	errs = append(errs, err)
+	if val, ok := lookupNonEmpty(chaosErrorStatusEnv); ok {
+		status, err := strconv.Atoi(val)
+		if err != nil || (status != 0 && (status < 400 || status > 599)) {
+			errs = append(errs, invalid(chaosErrorStatusEnv, val, "an HTTP error status (400-599) or 0"))
+		} else {
+			cfg.ErrorStatus = status
+		}
+	}
+	cfg.RetryAfter, err = resolveSeconds(chaosRetryAfterEnv, 0)
+	errs = append(errs, err)
+	for _, source := range strings.Split(envOrDefault(chaosSourcesEnv, ""), ",") {
+		if source = strings.ToLower(strings.TrimSpace(source)); source != "" {
+			cfg.Sources = append(cfg.Sources, source)
+		}
+	}
+	return cfg, errors.Join(errs...)
+}
+
+func resolveReenrich() (ReenrichConfig, error) {
+	var errs []error
+	cfg := ReenrichConfig{
+		Interval:  time.Duration(defaultReenrichIntervalHours) * time.Hour,
+		BatchSize: defaultReenrichBatchSize,
+	}
+	if val, ok := lookupNonEmpty(reenrichIntervalEnv); ok {
+		interval, ok := parseDuration(val, time.Hour)
+		if !ok || interval < 0 {
+			errs = append(errs, invalid(reenrichIntervalEnv, val, "a non-negative "+durationIn("hours")))
+		} else {
+			cfg.Interval = interval
+		}
+	}
+
+	var err error
+	cfg.BatchSize, err = resolvePositiveInt(reenrichBatchSizeEnv, defaultReenrichBatchSize)
+	errs = append(errs, err)
+	cfg.Delay, err = resolveMillis(reenrichDelayEnv, defaultReenrichDelayMillis)
+	errs = append(errs, err)
+	return cfg, errors.Join(errs...)
+}
+
+// resolveRetry reads the shared RETRY_* settings and applies any overrides carrying prefix.
+func resolveRetry(prefix string) (RetryConfig, error) {
+	attempts, attemptsErr := retryInt(prefix, retryMaxAttemptsSuffix, defaultRetryMaxAttempts)
+	baseDelay, baseErr := retryDelay(prefix, retryBaseDelaySuffix, defaultRetryBaseDelayMillis)
+	maxDelay, maxErr := retryDelay(prefix, retryMaxDelaySuffix, defaultRetryMaxDelayMillis)
+
+	return RetryConfig{
+		MaxAttempts: attempts,
+		BaseDelay:   baseDelay,
+		MaxDelay:    maxDelay,
+	}, errors.Join(attemptsErr, baseErr, maxErr)
+}
+
+// retryDelay reads a retry delay like retryInt, keeping the default for values of zero or less.
+func retryDelay(prefix, suffix string, fallbackMillis int) (time.Duration, error) {
+	fallback := time.Duration(fallbackMillis) * time.Millisecond
+	for _, key := range []string{prefix + suffix, suffix} {
+		val, ok := lookupNonEmpty(key)
+		if !ok {
+			continue
+		}
+		d, ok := parseDuration(val, time.Millisecond)
+		if !ok {
+			return fallback, invalid(key, val, "a "+durationIn("milliseconds"))
+		}
+		if d <= 0 {
+			return fallback, nil
+		}
+		return d, nil
+	}
+	return fallback, nil
+}
+
+func retryInt(prefix, suffix string, fallback int) (int, error) {
+	for _, key := range []string{prefix + suffix, suffix} {
+		val, ok := lookupNonEmpty(key)
+		if !ok {
+			continue
+		}
+		parsed, err := strconv.Atoi(val)
+		if err != nil {
+			return fallback, invalid(key, val, "a whole number")
+		}
+		if parsed <= 0 {
+			return fallback, nil
+		}
+		return parsed, nil
+	}
+	return fallback, nil
 }
 
 func normalizePort(raw string) (string, error) {
@@ -213,97 +935,147 @@ func lookupNonEmpty(key string) (string, bool) {
 	return trimmed, true
 }
 
-func resolveDatabase() (DatabaseConfig, error) {
-	driver := strings.TrimSpace(envOrDefault(databaseDriverEnv, defaultDatabaseDriver))
-	if driver == "" {
-		driver = defaultDatabaseDriver
-	}
-	driver = strings.ToLower(driver)
+func resolveDatabase(preset profile) (DatabaseConfig, error) {
+	driver := strings.ToLower(strings.TrimSpace(envOrDefault(databaseDriverEnv, preset.databaseDriver)))
 
 	switch driver {
 	case "sqlite":
 		url := strings.TrimSpace(envOrDefault(databaseURLEnv, defaultDatabaseURL))
-		if url == "" {
-			return DatabaseConfig{}, fmt.Errorf("database url required for sqlite driver")
-		}
 		return DatabaseConfig{Driver: driver, URL: url}, nil
+	case "postgres":
+		var errs []error
+		// The default URL points at a SQLite file, so PostgreSQL needs one spelled out.
+		url, ok := lookupNonEmpty(databaseURLEnv)
+		if !ok {
+			errs = append(errs, &Problem{Var: databaseURLEnv, Expected: "a PostgreSQL connection URL when " + databaseDriverEnv + "=postgres"})
+		}
+		cfg := DatabaseConfig{Driver: driver, URL: url}
+		var err error
+		cfg.MaxOpenConns, err = resolvePositiveInt(databaseMaxOpenConnsEnv, defaultDatabaseMaxOpenConns)
+		errs = append(errs, err)
+		cfg.MaxIdleConns, err = resolvePositiveInt(databaseMaxIdleConnsEnv, defaultDatabaseMaxIdleConns)
+		errs = append(errs, err)
+		cfg.ConnMaxLifetime, err = resolveSeconds(databaseConnMaxLifetimeEnv, defaultDatabaseLifetimeSeconds)
+		errs = append(errs, err)
+		return cfg, errors.Join(errs...)
 	case "memory":
 		return DatabaseConfig{Driver: driver, URL: ""}, nil
 	default:
-		return DatabaseConfig{}, fmt.Errorf("unsupported database driver %q", driver)
+		return DatabaseConfig{}, invalid(databaseDriverEnv, driver, "memory, sqlite, or postgres")
 	}
 }
 
 func resolveMusicBrainz() (MusicBrainzConfig, error) {
+	var errs []error
 	baseURL := envOrDefault(musicBrainzBaseURLEnv, defaultMusicBrainzBase)
-	timeout := time.Duration(defaultMusicBrainzTimeoutSeconds) * time.Second
-	if rawTimeout, ok := lookupNonEmpty(musicBrainzTimeoutEnv); ok {
-		seconds, err := strconv.Atoi(rawTimeout)
-		if err != nil {
-			return MusicBrainzConfig{}, fmt.Errorf("invalid %s value %q: %w", musicBrainzTimeoutEnv, rawTimeout, err)
-		}
-		if seconds > 0 {
-			timeout = time.Duration(seconds) * time.Second
-		}
+	source, err := resolveSource(musicBrainzPrefix, defaultMusicBrainzTimeoutSeconds, defaultMusicBrainzRateLimit)
+	errs = append(errs, err)
+	if !source.Enabled {
+		// Every lookup starts at MusicBrainz, so there is nothing to serve without it.
+		errs = append(errs, invalid(musicBrainzPrefix+enabledSuffix, envOrDefault(musicBrainzPrefix+enabledSuffix, ""), "true; MusicBrainz cannot be disabled"))
 	}
 
 	appName := envOrDefault(musicBrainzAppNameEnv, defaultMusicBrainzApp)
-	appVersion := envOrDefault(musicBrainzAppVersionEnv, defaultMusicBrainzVer)
+	appVersion := envOrDefault(musicBrainzAppVersionEnv, "")
 	contact := envOrDefault(musicBrainzContactEnv, defaultMusicBrainzContact)
 
+	validation := strings.ToLower(strings.TrimSpace(envOrDefault(musicBrainzValidationEnv, "off")))
+	switch validation {
+	case "off", "log", "reject":
+	default:
+		errs = append(errs, invalid(musicBrainzValidationEnv, validation, "off, log, or reject"))
+	}
+
 	return MusicBrainzConfig{
-		BaseURL:    strings.TrimRight(baseURL, "/"),
-		AppName:    strings.TrimSpace(appName),
-		AppVersion: strings.TrimSpace(appVersion),
-		Contact:    strings.TrimSpace(contact),
-		Timeout:    timeout,
-	}, nil
+		BaseURL:      strings.TrimRight(baseURL, "/"),
+		AppName:      strings.TrimSpace(appName),
+		AppVersion:   strings.TrimSpace(appVersion),
+		Contact:      strings.TrimSpace(contact),
+		Validation:   validation,
+		SourceConfig: source,
+	}, errors.Join(errs...)
 }
 
 func resolveWikipedia() (WikipediaConfig, error) {
 	baseURL := envOrDefault(wikipediaBaseURLEnv, defaultWikipediaBase)
-	userAgent := envOrDefault(wikipediaUserAgentEnv, defaultWikipediaUserAgent)
-	timeout := time.Duration(defaultWikipediaTimeoutSeconds) * time.Second
-
-	if rawTimeout, ok := lookupNonEmpty(wikipediaTimeoutEnv); ok {
-		seconds, err := strconv.Atoi(rawTimeout)
-		if err != nil {
-			return WikipediaConfig{}, fmt.Errorf("invalid %s value %q: %w", wikipediaTimeoutEnv, rawTimeout, err)
-		}
-		if seconds > 0 {
-			timeout = time.Duration(seconds) * time.Second
-		}
-	}
+	sourceURL := envOrDefault(wikipediaSourceURLEnv, defaultWikipediaSourceBase)
+	actionURL := envOrDefault(wikipediaActionURLEnv, "")
+	userAgent := envOrDefault(wikipediaUserAgentEnv, "")
+	source, err := resolveSource(wikipediaPrefix, defaultWikipediaTimeoutSeconds, 0)
 
 	return WikipediaConfig{
-		BaseURL:   strings.TrimRight(baseURL, "/"),
-		UserAgent: strings.TrimSpace(userAgent),
-		Timeout:   timeout,
-	}, nil
+		BaseURL:      strings.TrimRight(baseURL, "/"),
+		SourceURL:    strings.TrimRight(sourceURL, "/"),
+		ActionURL:    strings.TrimSpace(actionURL),
+		UserAgent:    strings.TrimSpace(userAgent),
+		SourceConfig: source,
+	}, err
 }
 
 func resolveReviews() (ReviewsConfig, error) {
-	userAgent := envOrDefault(reviewsUserAgentEnv, defaultReviewsUserAgent)
+	userAgent := envOrDefault(reviewsUserAgentEnv, "")
 	discogsToken := envOrDefault(reviewsDiscogsTokenEnv, "")
 	discogsConsumerKey := envOrDefault(reviewsDiscogsConsumerKeyEnv, "")
 	discogsConsumerSecret := envOrDefault(reviewsDiscogsConsumerSecretEnv, "")
-	timeout := time.Duration(defaultReviewsTimeoutSeconds) * time.Second
-
-	if rawTimeout, ok := lookupNonEmpty(reviewsTimeoutEnv); ok {
-		seconds, err := strconv.Atoi(rawTimeout)
-		if err != nil {
-			return ReviewsConfig{}, fmt.Errorf("invalid %s value %q: %w", reviewsTimeoutEnv, rawTimeout, err)
-		}
-		if seconds > 0 {
-			timeout = time.Duration(seconds) * time.Second
-		}
-	}
+	discogsBaseURL := envOrDefault(reviewsDiscogsBaseURLEnv, defaultDiscogsBase)
+	marketplace, marketplaceErr := resolveBool(reviewsDiscogsMarketplaceEnv, false)
+	source, sourceErr := resolveSource(reviewsPrefix, defaultReviewsTimeoutSeconds, defaultReviewsRateLimit)
 
 	return ReviewsConfig{
 		UserAgent:             strings.TrimSpace(userAgent),
 		DiscogsToken:          strings.TrimSpace(discogsToken),
 		DiscogsConsumerKey:    strings.TrimSpace(discogsConsumerKey),
 		DiscogsConsumerSecret: strings.TrimSpace(discogsConsumerSecret),
-		Timeout:               timeout,
-	}, nil
+		DiscogsBaseURL:        strings.TrimRight(discogsBaseURL, "/"),
+		Marketplace:           marketplace,
+		SourceConfig:          source,
+	}, errors.Join(marketplaceErr, sourceErr)
+}
+
+func resolveCoverArt() (CoverArtConfig, error) {
+	baseURL := envOrDefault(coverArtBaseURLEnv, defaultCoverArtBase)
+	source, err := resolveSource(coverArtPrefix, defaultCoverArtTimeoutSeconds, 0)
+
+	return CoverArtConfig{
+		BaseURL:      strings.TrimRight(strings.TrimSpace(baseURL), "/"),
+		SourceConfig: source,
+	}, err
+}
+
+func resolveWikidata() (WikidataConfig, error) {
+	baseURL := envOrDefault(wikidataBaseURLEnv, defaultWikidataBase)
+	source, err := resolveSource(wikidataPrefix, defaultWikidataTimeoutSeconds, 0)
+
+	return WikidataConfig{
+		BaseURL:      strings.TrimSpace(baseURL),
+		SourceConfig: source,
+	}, err
+}
+
+func resolveSpotify() (SpotifyConfig, error) {
+	baseURL := envOrDefault(spotifyBaseURLEnv, defaultSpotifyBase)
+	authURL := envOrDefault(spotifyAuthURLEnv, defaultSpotifyAuthURL)
+	clientID := envOrDefault(spotifyClientIDEnv, "")
+	clientSecret := envOrDefault(spotifyClientSecretEnv, "")
+	source, err := resolveSource(spotifyPrefix, defaultSpotifyTimeoutSeconds, 0)
+
+	return SpotifyConfig{
+		BaseURL:      strings.TrimRight(strings.TrimSpace(baseURL), "/"),
+		AuthURL:      strings.TrimSpace(authURL),
+		ClientID:     strings.TrimSpace(clientID),
+		ClientSecret: strings.TrimSpace(clientSecret),
+		SourceConfig: source,
+	}, err
+}
+
+func resolveLastfm() (LastfmConfig, error) {
+	baseURL := envOrDefault(lastfmBaseURLEnv, defaultLastfmBase)
+	apiKey := envOrDefault(lastfmAPIKeyEnv, "")
+	source, err := resolveSource(lastfmPrefix, defaultLastfmTimeoutSeconds, defaultLastfmRateLimit)
+
+	return LastfmConfig{
+		BaseURL:      strings.TrimSpace(baseURL),
+		APIKey:       strings.TrimSpace(apiKey),
+		SourceConfig: source,
+	}, err
 }
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadAcceptsDurationStrings(t *testing.T) {
+	t.Setenv(shutdownTimeoutEnv, "1m30s")
+	t.Setenv(musicBrainzPrefix+timeoutSuffix, "2500ms")
+	t.Setenv(wikipediaPrefix+timeoutSuffix, "4")
+	t.Setenv(cacheTTLEnv, "90m")
+	t.Setenv(readDeadlineEnv, "750")
+	t.Setenv(musicBrainzPrefix+retryBaseDelaySuffix, "1s")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	checks := []struct {
+		name string
+		got  time.Duration
+		want time.Duration
+	}{
+		{shutdownTimeoutEnv, cfg.ShutdownTimeout, 90 * time.Second},
+		{musicBrainzPrefix + timeoutSuffix, cfg.MusicBrainz.Timeout, 2500 * time.Millisecond},
+		{wikipediaPrefix + timeoutSuffix + " (bare seconds)", cfg.Wikipedia.Timeout, 4 * time.Second},
+		{cacheTTLEnv, cfg.CacheTTL.Artists, 90 * time.Minute},
+		{readDeadlineEnv + " (bare milliseconds)", cfg.Deadlines.Read, 750 * time.Millisecond},
+		{musicBrainzPrefix + retryBaseDelaySuffix, cfg.MusicBrainz.Retry.BaseDelay, time.Second},
+	}
+	for _, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s = %v, want %v", check.name, check.got, check.want)
+		}
+	}
+}
+
+func TestLoadReadsPerKindCacheTTLs(t *testing.T) {
+	t.Setenv(cacheTTLEnv, "48")
+	t.Setenv(albumsTTLEnv, "12h")
+	t.Setenv(searchesTTLEnv, "0")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	want := CacheTTLConfig{
+		Artists:  48 * time.Hour,
+		Albums:   12 * time.Hour,
+		Reviews:  defaultReviewsTTLHours * time.Hour,
+		NotFound: defaultNotFoundTTLMinutes * time.Minute,
+	}
+	if cfg.CacheTTL != want {
+		t.Errorf("CacheTTL = %+v, want %+v", cfg.CacheTTL, want)
+	}
+}
+
+func TestLoadRejectsNegativeTTL(t *testing.T) {
+	t.Setenv(cacheTTLEnv, "-1h")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected a negative cache TTL to be rejected")
+	}
+}
+
+func TestLoadReadsSourceBlocks(t *testing.T) {
+	t.Setenv(coverArtPrefix+enabledSuffix, "false")
+	t.Setenv(wikidataPrefix+rateLimitSuffix, "2")
+	t.Setenv(spotifyPrefix+timeoutSuffix, "3s")
+	t.Setenv(retryMaxAttemptsSuffix, "5")
+	t.Setenv(reviewsPrefix+retryMaxAttemptsSuffix, "1")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.CoverArt.Enabled || !cfg.Wikidata.Enabled {
+		t.Errorf("expected only cover art to be disabled, got coverart=%v wikidata=%v", cfg.CoverArt.Enabled, cfg.Wikidata.Enabled)
+	}
+	if cfg.Wikidata.RateLimit != 2 || cfg.MusicBrainz.RateLimit != defaultMusicBrainzRateLimit {
+		t.Errorf("unexpected rate limits: wikidata=%v musicbrainz=%v", cfg.Wikidata.RateLimit, cfg.MusicBrainz.RateLimit)
+	}
+	if cfg.Spotify.Timeout != 3*time.Second {
+		t.Errorf("Spotify timeout = %v, want 3s", cfg.Spotify.Timeout)
+	}
+	if cfg.Wikipedia.Retry.MaxAttempts != 5 || cfg.Reviews.Retry.MaxAttempts != 1 {
+		t.Errorf("expected the shared retry default with a per-source override, got wikipedia=%d reviews=%d", cfg.Wikipedia.Retry.MaxAttempts, cfg.Reviews.Retry.MaxAttempts)
+	}
+}
+
+func TestLoadReadsDiscogsMarketplaceFlag(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Reviews.Marketplace {
+		t.Error("expected marketplace stats to be off by default")
+	}
+
+	t.Setenv(reviewsDiscogsMarketplaceEnv, "true")
+	if cfg, err = Load(); err != nil || !cfg.Reviews.Marketplace {
+		t.Errorf("expected marketplace stats enabled, got %v (%v)", cfg.Reviews.Marketplace, err)
+	}
+
+	t.Setenv(reviewsDiscogsMarketplaceEnv, "sometimes")
+	if _, err := Load(); err == nil {
+		t.Error("expected an invalid marketplace flag to be rejected")
+	}
+}
+
+func TestLoadActivatesLastfmOnlyWithAPIKey(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Lastfm.Active() {
+		t.Error("expected Last.fm to stay inactive without an API key")
+	}
+	if cfg.Lastfm.BaseURL != defaultLastfmBase {
+		t.Errorf("Lastfm.BaseURL = %q, want %q", cfg.Lastfm.BaseURL, defaultLastfmBase)
+	}
+
+	t.Setenv(lastfmAPIKeyEnv, " abc123 ")
+	if cfg, err = Load(); err != nil || !cfg.Lastfm.Active() || cfg.Lastfm.APIKey != "abc123" {
+		t.Errorf("expected Last.fm active with the trimmed key, got %+v (%v)", cfg.Lastfm, err)
+	}
+
+	t.Setenv(lastfmPrefix+enabledSuffix, "false")
+	if cfg, err = Load(); err != nil || cfg.Lastfm.Active() {
+		t.Errorf("expected LASTFM_ENABLED=false to deactivate Last.fm, got %+v (%v)", cfg.Lastfm, err)
+	}
+}
+
+func TestLoadRejectsDisablingMusicBrainz(t *testing.T) {
+	t.Setenv(musicBrainzPrefix+enabledSuffix, "false")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected MusicBrainz to be required")
+	}
+}
+
+func TestLoadReadsClientRateLimit(t *testing.T) {
+	t.Setenv(clientRateLimitEnv, "0.5")
+	t.Setenv(clientIPHeaderEnv, "fly-client-ip")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	want := ClientRateLimitConfig{RPS: 0.5, Burst: defaultClientRateBurst, IPHeader: "Fly-Client-Ip"}
+	if cfg.ClientRateLimit != want {
+		t.Errorf("ClientRateLimit = %+v, want %+v", cfg.ClientRateLimit, want)
+	}
+
+	t.Setenv(clientRateBurstEnv, "0")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected a zero burst to be rejected")
+	}
+}
+
+func TestLoadReadsImageAnalysis(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.ImageAnalysis.Active() || cfg.ImageAnalysis.Timeout != defaultImageTimeoutSeconds*time.Second {
+		t.Errorf("expected image analysis off by default, got %+v", cfg.ImageAnalysis)
+	}
+
+	t.Setenv(imageBlurHashEnv, "true")
+	t.Setenv(imageTimeoutEnv, "4")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !cfg.ImageAnalysis.Active() || cfg.ImageAnalysis.Colors || cfg.ImageAnalysis.Timeout != 4*time.Second {
+		t.Errorf("ImageAnalysis = %+v, want BlurHash alone with a 4s timeout", cfg.ImageAnalysis)
+	}
+}
+
+func TestLoadReadsImageProxy(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.ImageProxy.Enabled || !slices.Contains(cfg.ImageProxy.Hosts, "coverartarchive.org") || cfg.ImageProxy.Timeout != 10*time.Second {
+		t.Errorf("expected the proxy off with the source hosts allowed, got %+v", cfg.ImageProxy)
+	}
+
+	t.Setenv(imageProxyEnv, "true")
+	t.Setenv(imageProxyHostsEnv, " images.example.com ,")
+	t.Setenv(imageProxyCacheDirEnv, "/var/cache/freqshow/images")
+	t.Setenv(imageProxyTimeoutEnv, "4")
+	t.Setenv(imageTimeoutEnv, "30")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	want := ImageProxyConfig{Enabled: true, Hosts: []string{"images.example.com"}, CacheDir: "/var/cache/freqshow/images", Timeout: 4 * time.Second}
+	if cfg.ImageProxy.Enabled != want.Enabled || !slices.Equal(cfg.ImageProxy.Hosts, want.Hosts) || cfg.ImageProxy.CacheDir != want.CacheDir || cfg.ImageProxy.Timeout != want.Timeout {
+		t.Errorf("ImageProxy = %+v, want %+v", cfg.ImageProxy, want)
+	}
+}
+
+func TestLoadReadsImageProxyS3(t *testing.T) {
+	t.Setenv(imageProxyEnv, "true")
+	t.Setenv(imageProxyS3BucketEnv, "covers")
+	t.Setenv(imageProxyS3PrefixEnv, "variants/")
+	t.Setenv(awsAccessKeyIDEnv, "AKID")
+	t.Setenv(awsSecretAccessKeyEnv, "secret")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	want := ImageProxyS3Config{Bucket: "covers", Region: "us-east-1", Prefix: "variants/", AccessKeyID: "AKID", SecretAccessKey: "secret"}
+	if cfg.ImageProxy.S3 != want {
+		t.Errorf("ImageProxy.S3 = %+v, want %+v", cfg.ImageProxy.S3, want)
+	}
+
+	t.Setenv(awsSecretAccessKeyEnv, "")
+	t.Setenv(imageProxyCacheDirEnv, "/var/cache/freqshow/images")
+	_, err = Load()
+	var validation *ValidationError
+	if !errors.As(err, &validation) || len(validation.Problems) != 2 {
+		t.Fatalf("expected the missing secret and the conflicting cache dir reported, got %v", err)
+	}
+	for _, name := range []string{awsSecretAccessKeyEnv, imageProxyCacheDirEnv} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("expected %s in %v", name, err)
+		}
+	}
+}
+
+func TestLoadReadsRatingWebhooks(t *testing.T) {
+	t.Setenv(ratingWebhookURLsEnv, " https://hooks.example.com/ratings , http://localhost:9000/hook")
+	t.Setenv(ratingWebhookThresholdEnv, "1.5")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	want := []string{"https://hooks.example.com/ratings", "http://localhost:9000/hook"}
+	if !slices.Equal(cfg.RatingWebhooks.URLs, want) || cfg.RatingWebhooks.Threshold != 1.5 {
+		t.Errorf("RatingWebhooks = %+v, want URLs %v and threshold 1.5", cfg.RatingWebhooks, want)
+	}
+
+	t.Setenv(ratingWebhookURLsEnv, "ftp://example.com/hook")
+	t.Setenv(ratingWebhookThresholdEnv, "0")
+	_, err = Load()
+	var validation *ValidationError
+	if !errors.As(err, &validation) || len(validation.Problems) != 2 {
+		t.Errorf("expected the URL and threshold both rejected, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	imageColorsEnv   = "IMAGE_COLORS"
+	imageBlurHashEnv = "IMAGE_BLURHASH"
+	imageTimeoutEnv  = "IMAGE_TIMEOUT_SECONDS"
+
+	imageProxyEnv           = "IMAGE_PROXY"
+	imageProxyHostsEnv      = "IMAGE_PROXY_HOSTS"
+	imageProxyCacheDirEnv   = "IMAGE_PROXY_CACHE_DIR"
+	imageProxyTimeoutEnv    = "IMAGE_PROXY_TIMEOUT_SECONDS"
+	imageProxyS3BucketEnv   = "IMAGE_PROXY_S3_BUCKET"
+	imageProxyS3RegionEnv   = "IMAGE_PROXY_S3_REGION"
+	imageProxyS3EndpointEnv = "IMAGE_PROXY_S3_ENDPOINT"
+	imageProxyS3PrefixEnv   = "IMAGE_PROXY_S3_PREFIX"
+	awsAccessKeyIDEnv       = "AWS_ACCESS_KEY_ID"
+	awsSecretAccessKeyEnv   = "AWS_SECRET_ACCESS_KEY"
+	awsSessionTokenEnv      = "AWS_SESSION_TOKEN"
+
+	defaultImageTimeoutSeconds      = 10
+	defaultImageProxyTimeoutSeconds = 10
+	// The hosts the image sources link to; the Cover Art Archive redirects to archive.org.
+	defaultImageProxyHosts    = "coverartarchive.org,archive.org,upload.wikimedia.org,i.discogs.com"
+	defaultImageProxyS3Region = "us-east-1"
+)
+
+// ImageAnalysisConfig controls the metadata derived from each record's primary image, which
+// is downloaded once when the record is enriched.
+type ImageAnalysisConfig struct {
+	// Colors adds dominant and accent colors to the image.
+	Colors bool
+	// BlurHash adds a BlurHash placeholder to the image.
+	BlurHash bool
+	// Timeout bounds each image download.
+	Timeout time.Duration
+}
+
+// Active reports whether any analysis is switched on, and so whether images are downloaded.
+func (c ImageAnalysisConfig) Active() bool {
+	return c.Colors || c.BlurHash
+}
+
+// ImageProxyConfig controls GET /images, which serves resized copies of upstream images.
+type ImageProxyConfig struct {
+	Enabled bool
+	// Hosts are the only hosts images are fetched from; subdomains match too.
+	Hosts []string
+	// CacheDir keeps resized images on disk; empty resizes on every request unless S3 is set.
+	CacheDir string
+	// Timeout bounds each fetch of a source image.
+	Timeout time.Duration
+	// S3 keeps resized images in a bucket shared by every instance instead of on disk.
+	S3 ImageProxyS3Config
+}
+
+// ImageProxyS3Config locates the bucket resized images are kept in; an empty Bucket leaves
+// S3 unused.
+type ImageProxyS3Config struct {
+	Bucket string
+	Region string
+	// Endpoint addresses an S3-compatible service such as MinIO; empty uses AWS.
+	Endpoint        string
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+func resolveImageAnalysis() (ImageAnalysisConfig, error) {
+	colors, colorsErr := resolveBool(imageColorsEnv, false)
+	blurHash, blurHashErr := resolveBool(imageBlurHashEnv, false)
+	timeout, timeoutErr := resolveTimeout(imageTimeoutEnv, defaultImageTimeoutSeconds)
+	cfg := ImageAnalysisConfig{Colors: colors, BlurHash: blurHash, Timeout: timeout}
+	return cfg, errors.Join(colorsErr, blurHashErr, timeoutErr)
+}
+
+func resolveImageProxy() (ImageProxyConfig, error) {
+	enabled, err := resolveBool(imageProxyEnv, false)
+	cfg := ImageProxyConfig{
+		Enabled:  enabled,
+		CacheDir: strings.TrimSpace(envOrDefault(imageProxyCacheDirEnv, "")),
+	}
+	for _, host := range strings.Split(envOrDefault(imageProxyHostsEnv, defaultImageProxyHosts), ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			cfg.Hosts = append(cfg.Hosts, host)
+		}
+	}
+	timeout, timeoutErr := resolveTimeout(imageProxyTimeoutEnv, defaultImageProxyTimeoutSeconds)
+	cfg.Timeout = timeout
+	s3, s3Err := resolveImageProxyS3(cfg.CacheDir)
+	cfg.S3 = s3
+	return cfg, errors.Join(err, timeoutErr, s3Err)
+}
+
+func resolveImageProxyS3(cacheDir string) (ImageProxyS3Config, error) {
+	cfg := ImageProxyS3Config{
+		Bucket:          strings.TrimSpace(envOrDefault(imageProxyS3BucketEnv, "")),
+		Region:          strings.TrimSpace(envOrDefault(imageProxyS3RegionEnv, defaultImageProxyS3Region)),
+		Endpoint:        strings.TrimSpace(envOrDefault(imageProxyS3EndpointEnv, "")),
+		Prefix:          strings.TrimSpace(envOrDefault(imageProxyS3PrefixEnv, "")),
+		AccessKeyID:     strings.TrimSpace(envOrDefault(awsAccessKeyIDEnv, "")),
+		SecretAccessKey: strings.TrimSpace(envOrDefault(awsSecretAccessKeyEnv, "")),
+		SessionToken:    strings.TrimSpace(envOrDefault(awsSessionTokenEnv, "")),
+	}
+	if cfg.Bucket == "" {
+		return cfg, nil
+	}
+	var errs []error
+	if cacheDir != "" {
+		errs = append(errs, invalid(imageProxyCacheDirEnv, cacheDir, "unset when "+imageProxyS3BucketEnv+" is set"))
+	}
+	if cfg.Endpoint != "" {
+		parsed, err := url.Parse(cfg.Endpoint)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			errs = append(errs, invalid(imageProxyS3EndpointEnv, cfg.Endpoint, "an http or https URL"))
+		}
+	}
+	if cfg.AccessKeyID == "" {
+		errs = append(errs, &Problem{Var: awsAccessKeyIDEnv, Expected: "an access key ID when " + imageProxyS3BucketEnv + " is set"})
+	}
+	if cfg.SecretAccessKey == "" {
+		errs = append(errs, &Problem{Var: awsSecretAccessKeyEnv, Expected: "a secret access key when " + imageProxyS3BucketEnv + " is set"})
+	}
+	return cfg, errors.Join(errs...)
+}
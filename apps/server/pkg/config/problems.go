@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Problem is one environment variable whose value could not be used.
+type Problem struct {
+	// Var names the environment variable.
+	Var string
+	// Value is what was set; empty when a required variable is missing.
+	Value string
+	// Expected describes an acceptable value.
+	Expected string
+}
+
+func (p *Problem) Error() string {
+	if p.Value == "" {
+		return fmt.Sprintf("%s is required: must be %s", p.Var, p.Expected)
+	}
+	return fmt.Sprintf("invalid %s value %q: must be %s", p.Var, p.Value, p.Expected)
+}
+
+func invalid(key, val, expected string) error {
+	return &Problem{Var: key, Value: val, Expected: expected}
+}
+
+// ValidationError reports every configuration problem Load found, so an operator can fix them
+// in one pass instead of restarting once per mistake.
+type ValidationError struct {
+	Problems []error
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0].Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d configuration problems:", len(e.Problems))
+	for _, problem := range e.Problems {
+		b.WriteString("\n  - ")
+		b.WriteString(problem.Error())
+	}
+	return b.String()
+}
+
+// Unwrap exposes the individual problems to errors.As.
+func (e *ValidationError) Unwrap() []error {
+	return e.Problems
+}
+
+// problems collects resolver errors instead of stopping at the first, flattening the joined
+// errors composite resolvers return.
+type problems []error
+
+func (ps *problems) add(err error) {
+	if err == nil {
+		return
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, inner := range joined.Unwrap() {
+			ps.add(inner)
+		}
+		return
+	}
+	*ps = append(*ps, err)
+}
+
+func (ps problems) err() error {
+	if len(ps) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: ps}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	t.Setenv(portEnv, "eighty")
+	t.Setenv(shutdownTimeoutEnv, "soon")
+	t.Setenv(h2cEnv, "maybe")
+	t.Setenv(musicBrainzPrefix+timeoutSuffix, "six")
+	t.Setenv(musicBrainzPrefix+retryMaxAttemptsSuffix, "three")
+	t.Setenv(databaseDriverEnv, "postgres")
+	t.Setenv(databaseURLEnv, "")
+
+	_, err := Load()
+	var invalidConfig *ValidationError
+	if !errors.As(err, &invalidConfig) {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+
+	var vars []string
+	for _, problem := range invalidConfig.Problems {
+		var p *Problem
+		if !errors.As(problem, &p) {
+			t.Fatalf("expected a Problem, got %v", problem)
+		}
+		vars = append(vars, p.Var)
+	}
+	want := []string{portEnv, shutdownTimeoutEnv, h2cEnv, musicBrainzPrefix + timeoutSuffix, musicBrainzPrefix + retryMaxAttemptsSuffix, databaseURLEnv}
+	if strings.Join(vars, ",") != strings.Join(want, ",") {
+		t.Fatalf("problems for %v, want %v", vars, want)
+	}
+
+	msg := err.Error()
+	for _, fragment := range []string{"6 configuration problems", `invalid SHUTDOWN_TIMEOUT_SECONDS value "soon": must be a duration such as "1500ms" or "2m", or a whole number of seconds`, "DATABASE_URL is required"} {
+		if !strings.Contains(msg, fragment) {
+			t.Errorf("error %q does not mention %q", msg, fragment)
+		}
+	}
+}
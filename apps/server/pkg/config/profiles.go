@@ -0,0 +1,53 @@
+package config
+
+import (
+	"log/slog"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+)
+
+const productionEnv = "production"
+
+// profile holds the defaults APP_ENV selects. Each one is only a starting point: the matching
+// environment variable still overrides it.
+type profile struct {
+	// databaseDriver is the DATABASE_DRIVER default.
+	databaseDriver string
+	// logSampleRate is the LOG_SAMPLE_RATE default.
+	logSampleRate float64
+	// logLevel is the LOG_LEVEL default.
+	logLevel slog.Level
+	// logFormat is the LOG_FORMAT default.
+	logFormat string
+	// corsOrigins is the CORS_ALLOWED_ORIGINS default.
+	corsOrigins string
+}
+
+// profiles maps APP_ENV to its presets. Development keeps nothing between restarts, logs every
+// request with debug detail as readable text, and lets any origin call the API; production
+// persists to SQLite, samples the access log, writes JSON at info level, and sends no CORS
+// headers until origins are listed.
+var profiles = map[string]profile{
+	defaultEnv: {
+		databaseDriver: "memory",
+		logSampleRate:  1,
+		logLevel:       slog.LevelDebug,
+		logFormat:      logging.FormatText,
+		corsOrigins:    "*",
+	},
+	productionEnv: {
+		databaseDriver: defaultDatabaseDriver,
+		logSampleRate:  defaultLogSampleRate,
+		logLevel:       slog.LevelInfo,
+		logFormat:      logging.FormatJSON,
+	},
+}
+
+// profileFor returns the presets for env. Environments other than development (staging, say)
+// get the production presets, so a new deployment starts out strict.
+func profileFor(env string) profile {
+	if preset, ok := profiles[env]; ok {
+		return preset
+	}
+	return profiles[productionEnv]
+}
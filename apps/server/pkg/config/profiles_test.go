@@ -0,0 +1,53 @@
+package config
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+func TestLoadAppliesEnvironmentPresets(t *testing.T) {
+	t.Setenv(environmentEnv, defaultEnv)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Database.Driver != "memory" || cfg.LogSampleRate != 1 || !slices.Equal(cfg.HTTP.CORSOrigins, []string{"*"}) {
+		t.Errorf("unexpected development presets: driver=%q rate=%v origins=%v", cfg.Database.Driver, cfg.LogSampleRate, cfg.HTTP.CORSOrigins)
+	}
+
+	t.Setenv(environmentEnv, "staging")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Database.Driver != "sqlite" || cfg.LogSampleRate != defaultLogSampleRate || len(cfg.HTTP.CORSOrigins) != 0 {
+		t.Errorf("unexpected production presets: driver=%q rate=%v origins=%v", cfg.Database.Driver, cfg.LogSampleRate, cfg.HTTP.CORSOrigins)
+	}
+	if cfg.LogLevel != slog.LevelInfo || cfg.LogFormat != "json" {
+		t.Errorf("unexpected production log presets: level=%v format=%q", cfg.LogLevel, cfg.LogFormat)
+	}
+}
+
+func TestLoadLetsVariablesOverridePresets(t *testing.T) {
+	t.Setenv(environmentEnv, productionEnv)
+	t.Setenv(databaseDriverEnv, "memory")
+	t.Setenv(logSampleRateEnv, "0.5")
+	t.Setenv(logLevelEnv, "WARN")
+	t.Setenv(logFormatEnv, "text")
+	t.Setenv(corsOriginsEnv, "https://app.example/, https://admin.example")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Database.Driver != "memory" || cfg.LogSampleRate != 0.5 {
+		t.Errorf("expected overrides to win, got driver=%q rate=%v", cfg.Database.Driver, cfg.LogSampleRate)
+	}
+	if cfg.LogLevel != slog.LevelWarn || cfg.LogFormat != "text" {
+		t.Errorf("expected log overrides to win, got level=%v format=%q", cfg.LogLevel, cfg.LogFormat)
+	}
+	if want := []string{"https://app.example", "https://admin.example"}; !slices.Equal(cfg.HTTP.CORSOrigins, want) {
+		t.Errorf("CORSOrigins = %v, want %v", cfg.HTTP.CORSOrigins, want)
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	clientRateLimitEnv = "CLIENT_RATE_LIMIT"
+	clientRateBurstEnv = "CLIENT_RATE_BURST"
+	clientIPHeaderEnv  = "CLIENT_IP_HEADER"
+
+	// Five requests a second with bursts of twenty covers a person clicking through the UI
+	// (each page fans out into a handful of calls) while stopping a script from draining the
+	// upstream quota everyone shares.
+	defaultClientRateLimit = 5
+	defaultClientRateBurst = 20
+)
+
+// ClientRateLimitConfig caps how fast each client may call the API. Clients are told apart by
+// API key name when one was sent, and otherwise by IP address.
+type ClientRateLimitConfig struct {
+	// RPS is the sustained requests per second allowed per client; zero disables limiting.
+	RPS float64
+	// Burst is how many requests a client may make at once after being idle.
+	Burst int
+	// IPHeader names the header a reverse proxy puts the caller's IP in, such as
+	// Fly-Client-IP or X-Real-IP. Empty uses the connection's address, which behind a proxy
+	// is the proxy's.
+	IPHeader string
+}
+
+func resolveClientRateLimit() (ClientRateLimitConfig, error) {
+	var errs []error
+	cfg := ClientRateLimitConfig{RPS: defaultClientRateLimit, Burst: defaultClientRateBurst}
+
+	if raw, ok := lookupNonEmpty(clientRateLimitEnv); ok {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed < 0 {
+			errs = append(errs, invalid(clientRateLimitEnv, raw, "a non-negative number of requests per second"))
+		} else {
+			cfg.RPS = parsed
+		}
+	}
+	burst, err := resolvePositiveInt(clientRateBurstEnv, defaultClientRateBurst)
+	errs = append(errs, err)
+	cfg.Burst = burst
+	cfg.IPHeader = http.CanonicalHeaderKey(strings.TrimSpace(envOrDefault(clientIPHeaderEnv, "")))
+	return cfg, errors.Join(errs...)
+}
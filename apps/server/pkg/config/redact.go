@@ -0,0 +1,133 @@
+package config
+
+import (
+	"maps"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const redacted = "REDACTED"
+
+// secretFieldWords mark config fields holding credentials.
+var secretFieldWords = []string{"Token", "Secret", "Password", "Key"}
+
+// dsnPassword matches the password in a key=value connection string.
+var dsnPassword = regexp.MustCompile(`(?i)(password\s*=\s*)('[^']*'|\S+)`)
+
+// Redacted returns the effective configuration as nested maps keyed by field name, ready to
+// encode as JSON for operators. Set credentials read REDACTED (unset ones stay empty, so it is
+// still clear whether one was loaded), passwords inside URLs and connection strings are
+// masked, URLs in lists keep only their scheme and host, and durations render as strings such
+// as "6s".
+func (c *Config) Redacted() map[string]any {
+	return redactStruct(reflect.ValueOf(*c))
+}
+
+func redactStruct(v reflect.Value) map[string]any {
+	out := make(map[string]any, v.NumField())
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		switch {
+		case !field.IsExported():
+		case field.Anonymous && field.Type.Kind() == reflect.Struct:
+			// Embedded blocks such as SourceConfig read as part of the enclosing section.
+			maps.Copy(out, redactStruct(v.Field(i)))
+		default:
+			out[field.Name] = redactValue(field.Name, v.Field(i))
+		}
+	}
+	return out
+}
+
+func redactValue(name string, v reflect.Value) any {
+	switch {
+	case v.Type() == reflect.TypeFor[time.Duration]():
+		return time.Duration(v.Int()).String()
+	case v.Kind() == reflect.Struct:
+		return redactStruct(v)
+	case v.Kind() == reflect.String:
+		s := v.String()
+		if s != "" && isSecretField(name) {
+			return redacted
+		}
+		return redactConnection(s)
+	case v.Kind() == reflect.Slice && v.IsNil():
+		return []any{}
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		items := make([]any, v.Len())
+		for i := range v.Len() {
+			items[i] = redactListItem(name, v.Index(i).String())
+		}
+		return items
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Struct:
+		items := make([]any, v.Len())
+		for i := range v.Len() {
+			items[i] = redactStruct(v.Index(i))
+		}
+		return items
+	}
+	return v.Interface()
+}
+
+func isSecretField(name string) bool {
+	for _, word := range secretFieldWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactListItem masks one entry of a list setting. URLs in lists are endpoints the server
+// calls, such as webhooks, which commonly carry a secret in the path or query, so only their
+// scheme and host are kept.
+func redactListItem(name, s string) string {
+	if s != "" && isSecretField(name) {
+		return redacted
+	}
+	if !strings.Contains(s, "://") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return redacted
+	}
+	u.User = nil
+	if u.Path != "" && u.Path != "/" {
+		u.Path, u.RawPath = "/"+redacted, ""
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = redacted
+	}
+	u.Fragment, u.RawFragment = "", ""
+	return u.String()
+}
+
+// redactConnection masks passwords in URLs (user info and sensitive query parameters) and in
+// key=value DSNs such as "host=db password=hunter2".
+func redactConnection(s string) string {
+	if !strings.Contains(s, "://") {
+		return dsnPassword.ReplaceAllString(s, "${1}"+redacted)
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return s
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	if u.RawQuery != "" {
+		query := u.Query()
+		for name := range query {
+			lower := strings.ToLower(name)
+			if strings.Contains(lower, "password") || strings.Contains(lower, "secret") || strings.Contains(lower, "token") || strings.HasSuffix(lower, "key") {
+				query[name] = []string{redacted}
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// SourceConfig holds the settings every upstream source shares, each read under the source's
+// prefix: MUSICBRAINZ_TIMEOUT_SECONDS, WIKIDATA_ENABLED, SPOTIFY_RATE_LIMIT, and so on.
+type SourceConfig struct {
+	// Enabled is false when <SOURCE>_ENABLED switched the source off; it is then never called.
+	Enabled bool
+	// Timeout bounds each request from <SOURCE>_TIMEOUT_SECONDS, retries included.
+	Timeout time.Duration
+	// Retry comes from <SOURCE>_RETRY_*, falling back to the shared RETRY_* settings.
+	Retry RetryConfig
+	// RateLimit caps requests per second from <SOURCE>_RATE_LIMIT; zero disables pacing.
+	RateLimit float64
+}
+
+// resolveSource reads the shared source settings under prefix.
+func resolveSource(prefix string, timeoutSeconds int, rateLimit float64) (SourceConfig, error) {
+	var errs []error
+	cfg := SourceConfig{RateLimit: rateLimit}
+
+	var err error
+	cfg.Enabled, err = resolveBool(prefix+enabledSuffix, true)
+	errs = append(errs, err)
+	cfg.Timeout, err = resolveTimeout(prefix+timeoutSuffix, timeoutSeconds)
+	errs = append(errs, err)
+	cfg.Retry, err = resolveRetry(prefix)
+	errs = append(errs, err)
+
+	if raw, ok := lookupNonEmpty(prefix + rateLimitSuffix); ok {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed < 0 {
+			errs = append(errs, invalid(prefix+rateLimitSuffix, raw, "a non-negative number of requests per second"))
+		} else {
+			cfg.RateLimit = parsed
+		}
+	}
+	return cfg, errors.Join(errs...)
+}
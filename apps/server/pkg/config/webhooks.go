@@ -0,0 +1,60 @@
+package config
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	ratingWebhookURLsEnv      = "RATING_WEBHOOK_URLS"
+	ratingWebhookSecretEnv    = "RATING_WEBHOOK_SECRET"
+	ratingWebhookThresholdEnv = "RATING_WEBHOOK_THRESHOLD"
+
+	// Half a point is a whole star on a five-star scale averaged over two reviews, so a single
+	// new review rarely nudges a well-reviewed album past it.
+	defaultRatingWebhookThreshold = 0.5
+)
+
+// RatingWebhookConfig lists the endpoints told when a cached album's aggregate rating changes.
+type RatingWebhookConfig struct {
+	// URLs receive a POST per change; empty disables the webhooks.
+	URLs []string
+	// Secret, when set, signs each payload with HMAC-SHA256 in the X-FreqShow-Signature header.
+	Secret string
+	// Threshold is how far the average score must move to count as a change. A source rating
+	// the album for the first time always counts.
+	Threshold float64
+}
+
+func resolveRatingWebhooks() (RatingWebhookConfig, error) {
+	var errs []error
+	cfg := RatingWebhookConfig{
+		Secret:    strings.TrimSpace(envOrDefault(ratingWebhookSecretEnv, "")),
+		Threshold: defaultRatingWebhookThreshold,
+	}
+
+	for _, raw := range strings.Split(envOrDefault(ratingWebhookURLsEnv, ""), ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		parsed, err := url.Parse(raw)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			errs = append(errs, invalid(ratingWebhookURLsEnv, raw, "comma-separated http or https URLs"))
+			continue
+		}
+		cfg.URLs = append(cfg.URLs, raw)
+	}
+
+	if raw, ok := lookupNonEmpty(ratingWebhookThresholdEnv); ok {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed <= 0 {
+			errs = append(errs, invalid(ratingWebhookThresholdEnv, raw, "a positive change in average score"))
+		} else {
+			cfg.Threshold = parsed
+		}
+	}
+	return cfg, errors.Join(errs...)
+}
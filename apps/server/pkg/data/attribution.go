@@ -0,0 +1,60 @@
+package data
+
+import "fmt"
+
+// Wikipedia text is published under CC BY-SA 4.0, which requires crediting the article and
+// naming the license wherever the text is reused.
+const (
+	LicenseCCBYSA    = "CC BY-SA 4.0"
+	LicenseCCBYSAURL = "https://creativecommons.org/licenses/by-sa/4.0/"
+)
+
+// Last.fm wiki text is published under CC BY-SA 3.0.
+const (
+	LicenseCCBYSA3    = "CC BY-SA 3.0"
+	LicenseCCBYSA3URL = "https://creativecommons.org/licenses/by-sa/3.0/"
+)
+
+// Attribution credits the source of licensed text, such as a biography taken from Wikipedia.
+// Revision pins the exact version of the page the text was copied from.
+type Attribution struct {
+	Source     string `json:"source"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Revision   string `json:"revision,omitempty"`
+	License    string `json:"license"`
+	LicenseURL string `json:"licenseUrl"`
+	// Text is a ready-to-display credit line naming the article, its authors, and the license.
+	Text string `json:"text"`
+}
+
+// NewWikipediaAttribution credits the Wikipedia article title at pageURL, optionally pinned
+// to revision.
+func NewWikipediaAttribution(title, pageURL, revision string) *Attribution {
+	text := fmt.Sprintf("This biography uses material from the Wikipedia article %q (%s)", title, pageURL)
+	if revision != "" {
+		text += ", revision " + revision
+	}
+	text += ", by Wikipedia contributors, licensed under " + LicenseCCBYSA + " (" + LicenseCCBYSAURL + ")."
+	return &Attribution{
+		Source:     "wikipedia",
+		Title:      title,
+		URL:        pageURL,
+		Revision:   revision,
+		License:    LicenseCCBYSA,
+		LicenseURL: LicenseCCBYSAURL,
+		Text:       text,
+	}
+}
+
+// NewLastfmAttribution credits the Last.fm wiki page for title at pageURL.
+func NewLastfmAttribution(title, pageURL string) *Attribution {
+	return &Attribution{
+		Source:     "lastfm",
+		Title:      title,
+		URL:        pageURL,
+		License:    LicenseCCBYSA3,
+		LicenseURL: LicenseCCBYSA3URL,
+		Text:       fmt.Sprintf("This text uses material from the Last.fm wiki for %q (%s), by Last.fm contributors, licensed under %s (%s).", title, pageURL, LicenseCCBYSA3, LicenseCCBYSA3URL),
+	}
+}
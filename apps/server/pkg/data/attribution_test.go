@@ -0,0 +1,22 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewWikipediaAttribution(t *testing.T) {
+	attribution := NewWikipediaAttribution("Nirvana (band)", "https://en.wikipedia.org/wiki/Nirvana_(band)", "1234")
+	if attribution.License != LicenseCCBYSA || attribution.LicenseURL != LicenseCCBYSAURL || attribution.Revision != "1234" {
+		t.Fatalf("unexpected attribution: %+v", attribution)
+	}
+	for _, want := range []string{`"Nirvana (band)"`, "https://en.wikipedia.org/wiki/Nirvana_(band)", "revision 1234", LicenseCCBYSA} {
+		if !strings.Contains(attribution.Text, want) {
+			t.Errorf("expected %q in %q", want, attribution.Text)
+		}
+	}
+
+	if unpinned := NewWikipediaAttribution("Nirvana (band)", "https://en.wikipedia.org/wiki/Nirvana_(band)", ""); strings.Contains(unpinned.Text, "revision") {
+		t.Errorf("expected no revision in %q", unpinned.Text)
+	}
+}
@@ -0,0 +1,68 @@
+package data
+
+import (
+	"sort"
+	"strings"
+)
+
+// CollaborationGraph lists the artists who share release-group credits with the subject. Each
+// edge is weighted by the number of release groups both are credited on; ReleaseGroups counts
+// how many of the subject's release groups were considered.
+type CollaborationGraph struct {
+	ArtistID      string              `json:"artistId"`
+	ArtistName    string              `json:"artistName"`
+	ReleaseGroups int                 `json:"releaseGroups"`
+	Edges         []CollaborationEdge `json:"edges"`
+}
+
+// CollaborationEdge connects the subject to one collaborator.
+type CollaborationEdge struct {
+	ArtistID string          `json:"artistId"`
+	Name     string          `json:"name"`
+	Weight   int             `json:"weight"`
+	Shared   []SharedRelease `json:"shared"`
+}
+
+// SharedRelease is a release group credited to both ends of a collaboration edge.
+type SharedRelease struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Year  int    `json:"year,omitempty"`
+}
+
+// ComputeCollaborationEdges builds weighted edges from the artist credits on albums, strongest
+// first. Albums are assumed distinct; an artist credited twice on one album counts once.
+func ComputeCollaborationEdges(artistID string, albums []Album) []CollaborationEdge {
+	edges := make(map[string]*CollaborationEdge)
+	for _, album := range albums {
+		seen := make(map[string]bool, len(album.Credits))
+		for _, credit := range album.Credits {
+			if credit.ArtistID == "" || credit.ArtistID == artistID || seen[credit.ArtistID] {
+				continue
+			}
+			seen[credit.ArtistID] = true
+			edge, ok := edges[credit.ArtistID]
+			if !ok {
+				edge = &CollaborationEdge{ArtistID: credit.ArtistID, Name: credit.Name}
+				edges[credit.ArtistID] = edge
+			}
+			edge.Weight++
+			edge.Shared = append(edge.Shared, SharedRelease{ID: album.ID, Title: album.Title, Year: album.FirstReleaseDate.Year})
+		}
+	}
+
+	result := make([]CollaborationEdge, 0, len(edges))
+	for _, edge := range edges {
+		sort.SliceStable(edge.Shared, func(i, j int) bool {
+			return edge.Shared[i].Year < edge.Shared[j].Year
+		})
+		result = append(result, *edge)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Weight != result[j].Weight {
+			return result[i].Weight > result[j].Weight
+		}
+		return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
+	})
+	return result
+}
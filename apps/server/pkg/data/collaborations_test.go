@@ -0,0 +1,30 @@
+package data
+
+import "testing"
+
+func TestComputeCollaborationEdges(t *testing.T) {
+	albums := []Album{
+		{ID: "a", Title: "Solo", Credits: []ArtistCredit{{ArtistID: "self", Name: "Self"}}},
+		{ID: "b", Title: "Duets", FirstReleaseDate: PartialDate{Year: 1995}, Credits: []ArtistCredit{
+			{ArtistID: "self", Name: "Self"}, {ArtistID: "guest", Name: "Guest"}, {ArtistID: "guest", Name: "Guest"},
+		}},
+		{ID: "c", Title: "Trio", FirstReleaseDate: PartialDate{Year: 1990}, Credits: []ArtistCredit{
+			{ArtistID: "self", Name: "Self"}, {ArtistID: "guest", Name: "Guest"}, {ArtistID: "other", Name: "Other"},
+		}},
+	}
+
+	edges := ComputeCollaborationEdges("self", albums)
+
+	if len(edges) != 2 {
+		t.Fatalf("expected 2 edges, got %+v", edges)
+	}
+	if edges[0].ArtistID != "guest" || edges[0].Weight != 2 {
+		t.Errorf("expected guest to be the strongest edge, got %+v", edges[0])
+	}
+	if edges[0].Shared[0].ID != "c" || edges[0].Shared[1].ID != "b" {
+		t.Errorf("expected shared releases oldest first, got %+v", edges[0].Shared)
+	}
+	if edges[1].ArtistID != "other" || edges[1].Weight != 1 {
+		t.Errorf("unexpected second edge %+v", edges[1])
+	}
+}
@@ -0,0 +1,104 @@
+package data
+
+import (
+	"sort"
+	"strings"
+)
+
+// Discography groups an artist's release groups the way record shops shelve them. Release
+// groups that fit none of the named groups (broadcasts, soundtracks, remix albums, and so on)
+// land in Other.
+type Discography struct {
+	ArtistID     string  `json:"artistId"`
+	ArtistName   string  `json:"artistName"`
+	StudioAlbums []Album `json:"studioAlbums"`
+	LiveAlbums   []Album `json:"liveAlbums"`
+	Compilations []Album `json:"compilations"`
+	EPs          []Album `json:"eps"`
+	Singles      []Album `json:"singles"`
+	Other        []Album `json:"other"`
+}
+
+// AlbumPage is one page of an artist's release groups browsed from MusicBrainz. Total counts
+// the release groups of the requested primary types; secondary types are filtered per page, so
+// a page can hold fewer than Limit albums before the end. NextOffset is where the next page
+// starts, or zero on the last page.
+type AlbumPage struct {
+	ArtistID   string  `json:"artistId"`
+	Albums     []Album `json:"albums"`
+	Offset     int     `json:"offset"`
+	Limit      int     `json:"limit"`
+	Total      int     `json:"total"`
+	NextOffset int     `json:"nextOffset,omitempty"`
+}
+
+// GroupDiscography sorts albums into discography groups by primary and secondary type, each
+// group in release order. A compilation secondary type wins over every other type, so a live
+// compilation is a compilation, and albums with a live secondary type are live albums.
+func GroupDiscography(artistID, artistName string, albums []Album) *Discography {
+	discography := &Discography{
+		ArtistID:     artistID,
+		ArtistName:   artistName,
+		StudioAlbums: []Album{},
+		LiveAlbums:   []Album{},
+		Compilations: []Album{},
+		EPs:          []Album{},
+		Singles:      []Album{},
+		Other:        []Album{},
+	}
+
+	for _, album := range albums {
+		group := discography.groupFor(album)
+		*group = append(*group, album)
+	}
+
+	for _, group := range []*[]Album{
+		&discography.StudioAlbums, &discography.LiveAlbums, &discography.Compilations,
+		&discography.EPs, &discography.Singles, &discography.Other,
+	} {
+		sortByRelease(*group)
+	}
+	return discography
+}
+
+func (d *Discography) groupFor(album Album) *[]Album {
+	switch {
+	case hasSecondaryType(album, "compilation"):
+		return &d.Compilations
+	case strings.EqualFold(album.PrimaryType, "album"):
+		switch {
+		case hasSecondaryType(album, "live"):
+			return &d.LiveAlbums
+		case len(album.SecondaryTypes) == 0:
+			return &d.StudioAlbums
+		}
+	case strings.EqualFold(album.PrimaryType, "ep"):
+		return &d.EPs
+	case strings.EqualFold(album.PrimaryType, "single"):
+		return &d.Singles
+	}
+	return &d.Other
+}
+
+func hasSecondaryType(album Album, kind string) bool {
+	for _, secondary := range album.SecondaryTypes {
+		if strings.EqualFold(secondary, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortByRelease orders albums by first release date, undated ones last, then by title.
+func sortByRelease(albums []Album) {
+	sort.SliceStable(albums, func(i, j int) bool {
+		a, b := albums[i].FirstReleaseDate, albums[j].FirstReleaseDate
+		if a.IsZero() != b.IsZero() {
+			return b.IsZero()
+		}
+		if c := a.Compare(b); c != 0 {
+			return c < 0
+		}
+		return albums[i].Title < albums[j].Title
+	})
+}
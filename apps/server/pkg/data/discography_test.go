@@ -0,0 +1,50 @@
+package data
+
+import "testing"
+
+func TestGroupDiscography(t *testing.T) {
+	albums := []Album{
+		{ID: "second", Title: "Second", PrimaryType: "Album", FirstReleaseDate: PartialDate{Year: 1994}},
+		{ID: "first", Title: "First", PrimaryType: "Album", FirstReleaseDate: PartialDate{Year: 1991}},
+		{ID: "undated", Title: "Undated", PrimaryType: "Album"},
+		{ID: "live", Title: "Unplugged", PrimaryType: "Album", SecondaryTypes: []string{"Live"}},
+		{ID: "live-best", Title: "Live Hits", PrimaryType: "Album", SecondaryTypes: []string{"Compilation", "Live"}},
+		{ID: "ep", Title: "Hormoaning", PrimaryType: "EP"},
+		{ID: "single", Title: "Lithium", PrimaryType: "Single"},
+		{ID: "soundtrack", Title: "Score", PrimaryType: "Album", SecondaryTypes: []string{"Soundtrack"}},
+		{ID: "broadcast", Title: "Session", PrimaryType: "Broadcast"},
+	}
+
+	discography := GroupDiscography("self", "Self", albums)
+
+	ids := func(albums []Album) []string {
+		out := make([]string, len(albums))
+		for i, album := range albums {
+			out[i] = album.ID
+		}
+		return out
+	}
+	for name, tc := range map[string]struct {
+		got  []Album
+		want []string
+	}{
+		"studio":       {discography.StudioAlbums, []string{"first", "second", "undated"}},
+		"live":         {discography.LiveAlbums, []string{"live"}},
+		"compilations": {discography.Compilations, []string{"live-best"}},
+		"eps":          {discography.EPs, []string{"ep"}},
+		"singles":      {discography.Singles, []string{"single"}},
+		"other":        {discography.Other, []string{"soundtrack", "broadcast"}},
+	} {
+		got := ids(tc.got)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: expected %v, got %v", name, tc.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: expected %v, got %v", name, tc.want, got)
+				break
+			}
+		}
+	}
+}
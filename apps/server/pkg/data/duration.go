@@ -0,0 +1,85 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatDuration renders milliseconds as M:SS, or H:MM:SS once the duration reaches an hour.
+// Non-positive durations render as an empty string.
+func FormatDuration(ms int) string {
+	if ms <= 0 {
+		return ""
+	}
+	total := ms / 1000
+	hours := total / 3600
+	minutes := (total % 3600) / 60
+	seconds := total % 60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
+}
+
+// ParseDuration converts an M:SS or H:MM:SS string into milliseconds.
+func ParseDuration(value string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("data: invalid duration %q", value)
+	}
+	total := 0
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("data: invalid duration %q", value)
+		}
+		total = total*60 + n
+	}
+	return total * 1000, nil
+}
+
+// trackFields has Track's fields without its JSON methods, so every field, including ones
+// added later, is encoded without being listed here.
+type trackFields Track
+
+type trackJSON struct {
+	trackFields
+	Length string `json:"length,omitempty"`
+}
+
+// MarshalJSON adds a preformatted "length" alongside the canonical millisecond value.
+func (t Track) MarshalJSON() ([]byte, error) {
+	return json.Marshal(trackJSON{trackFields: trackFields(t), Length: FormatDuration(t.LengthMs)})
+}
+
+// UnmarshalJSON accepts both the current payload and older cached payloads that only
+// carried a formatted "length" string.
+func (t *Track) UnmarshalJSON(b []byte) error {
+	var raw trackJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*t = Track(raw.trackFields)
+	if t.LengthMs == 0 && raw.Length != "" {
+		if ms, err := ParseDuration(raw.Length); err == nil {
+			t.LengthMs = ms
+		}
+	}
+	return nil
+}
+
+// recordingFields has Recording's fields without its JSON method, as trackFields does for Track.
+type recordingFields Recording
+
+type recordingJSON struct {
+	recordingFields
+	Length string `json:"length,omitempty"`
+}
+
+// MarshalJSON adds a preformatted "length" alongside the canonical millisecond value, as
+// Track does.
+func (r Recording) MarshalJSON() ([]byte, error) {
+	return json.Marshal(recordingJSON{recordingFields: recordingFields(r), Length: FormatDuration(r.LengthMs)})
+}
@@ -0,0 +1,82 @@
+package data
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFormatDuration(t *testing.T) {
+	cases := map[int]string{
+		0:        "",
+		-5:       "",
+		999:      "0:00",
+		61000:    "1:01",
+		3599000:  "59:59",
+		3600000:  "1:00:00",
+		4521000:  "1:15:21",
+		36005000: "10:00:05",
+	}
+	for ms, want := range cases {
+		if got := FormatDuration(ms); got != want {
+			t.Errorf("FormatDuration(%d) = %q, want %q", ms, got, want)
+		}
+	}
+}
+
+func TestTrackJSONRoundTrip(t *testing.T) {
+	encoded, err := json.Marshal(Track{Number: 1, Title: "Epic", LengthMs: 3725000, RecordingID: "rec-1"})
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(encoded, &raw); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if raw["length"] != "1:02:05" {
+		t.Errorf("expected formatted length, got %v", raw["length"])
+	}
+	if raw["lengthMs"] != float64(3725000) {
+		t.Errorf("expected lengthMs, got %v", raw["lengthMs"])
+	}
+
+	var decoded Track
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if decoded.RecordingID != "rec-1" {
+		t.Errorf("expected recordingId to round-trip, got %q", decoded.RecordingID)
+	}
+}
+
+func TestTrackJSONKeepsCredits(t *testing.T) {
+	track := Track{
+		Number:       3,
+		Title:        "Under Pressure",
+		Credits:      []ArtistCredit{{ArtistID: "queen", Name: "Queen", JoinPhrase: " & "}, {ArtistID: "bowie", Name: "David Bowie"}},
+		ArtistCredit: "Queen & David Bowie",
+	}
+	encoded, err := json.Marshal(track)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	var decoded Track
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, track) {
+		t.Errorf("expected %+v to round-trip, got %+v from %s", track, decoded, encoded)
+	}
+}
+
+func TestTrackUnmarshalLegacyLength(t *testing.T) {
+	var track Track
+	if err := json.Unmarshal([]byte(`{"number":2,"title":"Old","length":"4:05"}`), &track); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if track.LengthMs != 245000 {
+		t.Errorf("expected 245000ms from legacy length, got %d", track.LengthMs)
+	}
+}
@@ -0,0 +1,58 @@
+package data
+
+import "strings"
+
+// Locale echoes the language a response was localized to, with a hint for rendering dates.
+// Tag is a BCP 47 language tag such as "de" or "en-GB".
+type Locale struct {
+	Tag        string `json:"tag"`
+	DateFormat string `json:"dateFormat"`
+}
+
+// dateFormats maps language tags, and bare languages as a fallback, to conventional date
+// patterns. Unlisted languages use ISO 8601.
+var dateFormats = map[string]string{
+	"en":    "MM/DD/YYYY",
+	"en-gb": "DD/MM/YYYY",
+	"en-au": "DD/MM/YYYY",
+	"en-ie": "DD/MM/YYYY",
+	"en-nz": "DD/MM/YYYY",
+	"en-in": "DD/MM/YYYY",
+	"en-ca": "YYYY-MM-DD",
+	"fr":    "DD/MM/YYYY",
+	"es":    "DD/MM/YYYY",
+	"it":    "DD/MM/YYYY",
+	"pt":    "DD/MM/YYYY",
+	"el":    "DD/MM/YYYY",
+	"nl":    "DD-MM-YYYY",
+	"de":    "DD.MM.YYYY",
+	"ru":    "DD.MM.YYYY",
+	"pl":    "DD.MM.YYYY",
+	"cs":    "DD.MM.YYYY",
+	"fi":    "DD.MM.YYYY",
+	"nb":    "DD.MM.YYYY",
+	"no":    "DD.MM.YYYY",
+	"da":    "DD.MM.YYYY",
+	"tr":    "DD.MM.YYYY",
+	"uk":    "DD.MM.YYYY",
+	"ja":    "YYYY/MM/DD",
+	"zh":    "YYYY/MM/DD",
+	"ko":    "YYYY.MM.DD",
+	"hu":    "YYYY.MM.DD",
+	"sv":    "YYYY-MM-DD",
+}
+
+// NewLocale builds the Locale for tag, picking the date format for the full tag, then for its
+// language, then ISO 8601.
+func NewLocale(tag string) *Locale {
+	key := strings.ToLower(tag)
+	format, ok := dateFormats[key]
+	if !ok {
+		language, _, _ := strings.Cut(key, "-")
+		format, ok = dateFormats[language]
+	}
+	if !ok {
+		format = "YYYY-MM-DD"
+	}
+	return &Locale{Tag: tag, DateFormat: format}
+}
@@ -0,0 +1,12 @@
+package data
+
+import "testing"
+
+func TestNewLocaleDateFormats(t *testing.T) {
+	for tag, want := range map[string]string{"en": "MM/DD/YYYY", "en-GB": "DD/MM/YYYY", "de-AT": "DD.MM.YYYY", "xx": "YYYY-MM-DD"} {
+		locale := NewLocale(tag)
+		if locale.Tag != tag || locale.DateFormat != want {
+			t.Errorf("NewLocale(%q) = %+v, want date format %q", tag, locale, want)
+		}
+	}
+}
@@ -1,46 +1,260 @@
 package data
 
+import "time"
+
 type Artist struct {
-	ID             string   `json:"id"`
-	Name           string   `json:"name"`
-	Biography      string   `json:"biography"`
-	Genres         []string `json:"genres"`
-	Albums         []Album  `json:"albums"`
-	Related        []string `json:"related"`
-	ImageURL       string   `json:"imageUrl"`
-	Country        string   `json:"country,omitempty"`
-	Type           string   `json:"type,omitempty"`
-	Disambiguation string   `json:"disambiguation,omitempty"`
-	Aliases        []string `json:"aliases,omitempty"`
-	LifeSpan       LifeSpan `json:"lifeSpan"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Biography string `json:"biography"`
+	// BiographyAttribution credits the page Biography was taken from.
+	BiographyAttribution *Attribution `json:"biographyAttribution,omitempty"`
+	Genres               []string     `json:"genres"`
+	Albums               []Album      `json:"albums"`
+	// AlbumsTruncated is set when MusicBrainz lists more albums than the configured maximum.
+	AlbumsTruncated bool `json:"albumsTruncated,omitempty"`
+	// Related lists artists linked by MusicBrainz relationships, then Last.fm's similar artists.
+	Related        []RelatedArtist   `json:"related"`
+	Images         []Image           `json:"images"`
+	Links          map[string]string `json:"links,omitempty"`
+	Country        string            `json:"country,omitempty"`
+	Type           string            `json:"type,omitempty"`
+	Disambiguation string            `json:"disambiguation,omitempty"`
+	Aliases        []string          `json:"aliases,omitempty"`
+	LifeSpan       LifeSpan          `json:"lifeSpan"`
+	Members        []Membership      `json:"members,omitempty"`
+	MemberOf       []Membership      `json:"memberOf,omitempty"`
+	Stats          *DiscographyStats `json:"stats,omitempty"`
+	Awards         []Award           `json:"awards,omitempty"`
+	Listening      *ListeningStats   `json:"listening,omitempty"`
+	// LocalizedNames are the artist's names by language code, from MusicBrainz locale aliases.
+	LocalizedNames map[string]string `json:"localizedNames,omitempty"`
+	// LocalizedName and Locale are set on read for requests that ask for another language.
+	LocalizedName string  `json:"localizedName,omitempty"`
+	Locale        *Locale `json:"locale,omitempty"`
+	// Warnings list fields an optional source failed to fill when the artist was fetched.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// ListeningStats counts the listeners and plays an artist or album has on Last.fm.
+type ListeningStats struct {
+	Listeners int64  `json:"listeners"`
+	Playcount int64  `json:"playcount"`
+	Source    string `json:"source"`
+	URL       string `json:"url,omitempty"`
+}
+
+// Award is an award received by an artist or album, as recorded on Wikidata. ID is the
+// Wikidata item for the award; Category is empty for awards without one.
+type Award struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Year     int    `json:"year,omitempty"`
+}
+
+// RelatedArtist is an artist related to another. Relation is the MusicBrainz relationship type
+// ("member of band", "collaboration", ...) and is empty for artists only Last.fm calls similar.
+// Match is Last.fm's similarity from 0 to 1. ID is empty when Last.fm knows no MBID.
+type RelatedArtist struct {
+	ID       string  `json:"id,omitempty"`
+	Name     string  `json:"name"`
+	Relation string  `json:"relation,omitempty"`
+	Match    float64 `json:"match,omitempty"`
+	Source   string  `json:"source"`
+}
+
+// RelatedArtists is the related-artists listing for one artist.
+type RelatedArtists struct {
+	ArtistID   string          `json:"artistId"`
+	ArtistName string          `json:"artistName"`
+	Related    []RelatedArtist `json:"related"`
+}
+
+// Membership is one tenure of a person in a group. On a group it names the member;
+// on a person (MemberOf) it names the group.
+type Membership struct {
+	ArtistID string      `json:"artistId"`
+	Name     string      `json:"name"`
+	Roles    []string    `json:"roles,omitempty"`
+	Begin    PartialDate `json:"begin"`
+	End      PartialDate `json:"end"`
+	Current  bool        `json:"current"`
 }
 
 type LifeSpan struct {
-	Begin string `json:"begin,omitempty"`
-	End   string `json:"end,omitempty"`
-	Ended bool   `json:"ended,omitempty"`
+	Begin PartialDate `json:"begin"`
+	End   PartialDate `json:"end"`
+	Ended bool        `json:"ended,omitempty"`
 }
 
 type Album struct {
-	ID               string   `json:"id"`
-	Title            string   `json:"title"`
-	ArtistID         string   `json:"artistId"`
-	ArtistName       string   `json:"artistName,omitempty"`
-	PrimaryType      string   `json:"primaryType,omitempty"`
-	SecondaryTypes   []string `json:"secondaryTypes,omitempty"`
-	FirstReleaseDate string   `json:"firstReleaseDate,omitempty"`
-	Year             int      `json:"year"`
-	Genre            string   `json:"genre"`
-	Label            string   `json:"label"`
-	Tracks           []Track  `json:"tracks"`
-	Review           Review   `json:"review"`
-	CoverURL         string   `json:"coverUrl"`
+	ID               string      `json:"id"`
+	Title            string      `json:"title"`
+	ArtistID         string      `json:"artistId"`
+	ArtistName       string      `json:"artistName,omitempty"`
+	PrimaryType      string      `json:"primaryType,omitempty"`
+	SecondaryTypes   []string    `json:"secondaryTypes,omitempty"`
+	FirstReleaseDate PartialDate `json:"firstReleaseDate"`
+	Year             int         `json:"year"`
+	Genre            string      `json:"genre"`
+	// Styles are Discogs' finer-grained genres, such as "Art Rock".
+	Styles  []string `json:"styles,omitempty"`
+	Label   string   `json:"label"`
+	LabelID string   `json:"labelId,omitempty"`
+	// CatalogNumber is the label's number for the album, as credited alongside Label.
+	CatalogNumber string  `json:"catalogNumber,omitempty"`
+	Tracks        []Track `json:"tracks"`
+	// Runtime is derived from Tracks on read.
+	Runtime *AlbumRuntime    `json:"runtime,omitempty"`
+	Reviews []Review         `json:"reviews"`
+	Rating  *AggregateRating `json:"rating,omitempty"`
+	// Marketplace is only filled when marketplace stats are enabled.
+	Marketplace *Marketplace    `json:"marketplace,omitempty"`
+	Listening   *ListeningStats `json:"listening,omitempty"`
+	// Description is a short introduction to the album; DescriptionAttribution credits its source.
+	Description            string            `json:"description,omitempty"`
+	DescriptionAttribution *Attribution      `json:"descriptionAttribution,omitempty"`
+	Images                 []Image           `json:"images"`
+	Links                  map[string]string `json:"links,omitempty"`
+	Credits                []ArtistCredit    `json:"credits,omitempty"`
+	// ArtistCredit is the full credit as printed, join phrases included ("Jay-Z & Kanye West").
+	ArtistCredit string `json:"artistCredit,omitempty"`
+	// ProductionCredits name the producers, engineers, and mixers behind the album.
+	ProductionCredits []ProductionCredit `json:"productionCredits,omitempty"`
+	Editions          []Edition          `json:"editions,omitempty"`
+	Charts            []ChartPosition    `json:"charts,omitempty"`
+	Certifications    []Certification    `json:"certifications,omitempty"`
+	Awards            []Award            `json:"awards,omitempty"`
+	// Warnings list fields an optional source failed to fill when the album was fetched.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// Marketplace is what Discogs sellers are asking for the release an album's reviews came
+// from. Prices are in Currency; MedianPrice is the median of the prices Discogs suggests for
+// each media condition and is zero when they weren't available.
+type Marketplace struct {
+	Currency        string  `json:"currency,omitempty"`
+	LowestPrice     float64 `json:"lowestPrice,omitempty"`
+	MedianPrice     float64 `json:"medianPrice,omitempty"`
+	NumForSale      int     `json:"numForSale"`
+	BlockedFromSale bool    `json:"blockedFromSale,omitempty"`
+	URL             string  `json:"url"`
+}
+
+// ChartPosition is an album's peak position on one national or genre chart.
+type ChartPosition struct {
+	Chart   string `json:"chart"`
+	Country string `json:"country,omitempty"`
+	Peak    int    `json:"peak"`
+}
+
+// Edition is a concrete release of an album: a particular pressing, reissue, or digital version.
+type Edition struct {
+	ReleaseID     string      `json:"releaseId"`
+	Title         string      `json:"title"`
+	Status        string      `json:"status,omitempty"`
+	Format        string      `json:"format,omitempty"`
+	Country       string      `json:"country,omitempty"`
+	Date          PartialDate `json:"date"`
+	Label         string      `json:"label,omitempty"`
+	LabelID       string      `json:"labelId,omitempty"`
+	CatalogNumber string      `json:"catalogNumber,omitempty"`
+	Barcode       string      `json:"barcode,omitempty"`
+	TrackCount    int         `json:"trackCount,omitempty"`
+}
+
+// Certification is a sales award such as RIAA Platinum. Country and Body are empty for regions
+// the parser doesn't recognize; Date holds the year the award was made when known.
+type Certification struct {
+	Region  string      `json:"region"`
+	Country string      `json:"country,omitempty"`
+	Body    string      `json:"body,omitempty"`
+	Level   string      `json:"level"`
+	Date    PartialDate `json:"date"`
+}
+
+// ProductionCredit is one artist's production or engineering role on an album. Tracks lists
+// the track numbers the role applies to and is empty when it covers the whole album.
+type ProductionCredit struct {
+	ArtistID string `json:"artistId"`
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+	Tracks   []int  `json:"tracks,omitempty"`
+}
+
+// Label is a record label and the albums released on it.
+type Label struct {
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	Type           string            `json:"type,omitempty"`
+	Country        string            `json:"country,omitempty"`
+	Disambiguation string            `json:"disambiguation,omitempty"`
+	LabelCode      int               `json:"labelCode,omitempty"`
+	LifeSpan       LifeSpan          `json:"lifeSpan"`
+	Links          map[string]string `json:"links,omitempty"`
+	Albums         []Album           `json:"albums"`
+}
+
+// ArtistCredit names one artist credited on a release group or track. Credits are kept in
+// credit order; JoinPhrase is the text (" feat. ", " & ") that follows this artist.
+type ArtistCredit struct {
+	ArtistID   string `json:"artistId"`
+	Name       string `json:"name"`
+	JoinPhrase string `json:"joinPhrase,omitempty"`
+}
+
+const (
+	ImageTypeFront  = "front"
+	ImageTypeBack   = "back"
+	ImageTypeLogo   = "logo"
+	ImageTypeBanner = "banner"
+	ImageTypePhoto  = "photo"
+)
+
+// Image describes a single piece of artwork. Width and Height are zero when the source doesn't report them.
+type Image struct {
+	Type   string `json:"type"`
+	URL    string `json:"url"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+	Source string `json:"source"`
+	// Colors are taken from the image's pixels when image analysis is on; nil otherwise.
+	Colors *ImageColors `json:"colors,omitempty"`
+	// BlurHash is a https://blurha.sh placeholder to show while the image loads, when image
+	// analysis is on.
+	BlurHash string `json:"blurhash,omitempty"`
+}
+
+// ImageColors are "#rrggbb" colors for theming a page around an image. Dominant is the most
+// common color; Accent is the most prominent vivid color distinct from it, empty for images
+// without one.
+type ImageColors struct {
+	Dominant string `json:"dominant"`
+	Accent   string `json:"accent,omitempty"`
 }
 
 type Track struct {
-	Number int    `json:"number"`
-	Title  string `json:"title"`
-	Length string `json:"length"`
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	LengthMs    int    `json:"lengthMs"`
+	RecordingID string `json:"recordingId,omitempty"`
+	// Credits and ArtistCredit are the track's own credit, which may name featured artists.
+	Credits      []ArtistCredit `json:"credits,omitempty"`
+	ArtistCredit string         `json:"artistCredit,omitempty"`
+}
+
+// Recording is the recorded performance behind album tracks, linked by Track.RecordingID.
+type Recording struct {
+	ID             string   `json:"id"`
+	Title          string   `json:"title"`
+	Disambiguation string   `json:"disambiguation,omitempty"`
+	LengthMs       int      `json:"lengthMs"`
+	ISRCs          []string `json:"isrcs"`
+	// FirstReleaseDate is the earliest date the recording was released, as precise as
+	// MusicBrainz knows it.
+	FirstReleaseDate PartialDate    `json:"firstReleaseDate"`
+	Credits          []ArtistCredit `json:"credits"`
+	ArtistCredit     string         `json:"artistCredit"`
 }
 
 type Review struct {
@@ -50,4 +264,36 @@ type Review struct {
 	Summary string  `json:"summary"`
 	Text    string  `json:"text"`
 	URL     string  `json:"url"`
+	// Language is the ISO 639-1 code Text is written in, "mul" when it mixes languages, or empty
+	// when it couldn't be detected.
+	Language string `json:"language,omitempty"`
+}
+
+type Playlist struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Source      string          `json:"source,omitempty"`
+	SourceID    string          `json:"sourceId,omitempty"`
+	SourceURL   string          `json:"sourceUrl,omitempty"`
+	Tracks      []PlaylistTrack `json:"tracks"`
+}
+
+type PlaylistTrack struct {
+	Position    int    `json:"position"`
+	RecordingID string `json:"recordingId"`
+	Title       string `json:"title"`
+	ArtistName  string `json:"artistName"`
+	AlbumTitle  string `json:"albumTitle,omitempty"`
+	ISRC        string `json:"isrc,omitempty"`
+}
+
+type OwnedAlbum struct {
+	AlbumID    string    `json:"albumId"`
+	Title      string    `json:"title"`
+	ArtistID   string    `json:"artistId,omitempty"`
+	ArtistName string    `json:"artistName"`
+	Path       string    `json:"path"`
+	TrackCount int       `json:"trackCount"`
+	ScannedAt  time.Time `json:"scannedAt"`
 }
@@ -0,0 +1,131 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PartialDate models the variable-precision dates MusicBrainz uses: "1999", "1999-06", or "1999-06-01".
+// Month and Day are zero when unknown.
+type PartialDate struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// ParsePartialDate parses YYYY, YYYY-MM, or YYYY-MM-DD. An empty string yields the zero value.
+func ParsePartialDate(value string) (PartialDate, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return PartialDate{}, nil
+	}
+
+	parts := strings.Split(trimmed, "-")
+	if len(parts) > 3 || len(parts[0]) != 4 {
+		return PartialDate{}, fmt.Errorf("data: invalid partial date %q", value)
+	}
+
+	var date PartialDate
+	fields := []*int{&date.Year, &date.Month, &date.Day}
+	for i, part := range parts {
+		if i > 0 && len(part) != 2 {
+			return PartialDate{}, fmt.Errorf("data: invalid partial date %q", value)
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return PartialDate{}, fmt.Errorf("data: invalid partial date %q", value)
+		}
+		*fields[i] = n
+	}
+
+	if date.Month > 12 || (len(parts) > 1 && date.Month == 0) {
+		return PartialDate{}, fmt.Errorf("data: invalid month in %q", value)
+	}
+	if len(parts) == 3 && (date.Day == 0 || date.Day > daysIn(date.Year, date.Month)) {
+		return PartialDate{}, fmt.Errorf("data: invalid day in %q", value)
+	}
+	return date, nil
+}
+
+// PartialDateOf parses value, returning the zero PartialDate when it is malformed.
+func PartialDateOf(value string) PartialDate {
+	date, err := ParsePartialDate(value)
+	if err != nil {
+		return PartialDate{}
+	}
+	return date
+}
+
+func daysIn(year, month int) int {
+	switch month {
+	case 2:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	default:
+		return 31
+	}
+}
+
+// IsZero reports whether the date is unknown.
+func (d PartialDate) IsZero() bool {
+	return d.Year == 0 && d.Month == 0 && d.Day == 0
+}
+
+// String renders the date at its known precision.
+func (d PartialDate) String() string {
+	switch {
+	case d.IsZero():
+		return ""
+	case d.Month == 0:
+		return fmt.Sprintf("%04d", d.Year)
+	case d.Day == 0:
+		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	default:
+		return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+	}
+}
+
+// Compare returns -1, 0, or 1. Unknown dates sort first, and a less precise date sorts before a more
+// precise one in the same period ("1999" < "1999-01" < "1999-01-01").
+func (d PartialDate) Compare(other PartialDate) int {
+	for _, pair := range [][2]int{{d.Year, other.Year}, {d.Month, other.Month}, {d.Day, other.Day}} {
+		switch {
+		case pair[0] < pair[1]:
+			return -1
+		case pair[0] > pair[1]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// Before reports whether d sorts before other.
+func (d PartialDate) Before(other PartialDate) bool {
+	return d.Compare(other) < 0
+}
+
+// MarshalJSON encodes the date as its string form; unknown dates encode as "".
+func (d PartialDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts a date string. Malformed values decode to the zero date rather than failing
+// the surrounding payload, since upstream data is not always clean.
+func (d *PartialDate) UnmarshalJSON(b []byte) error {
+	var raw string
+	if err := json.Unmarshal(b, &raw); err != nil {
+		if string(b) == "null" {
+			*d = PartialDate{}
+			return nil
+		}
+		return err
+	}
+	*d = PartialDateOf(raw)
+	return nil
+}
@@ -0,0 +1,72 @@
+package data
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParsePartialDate(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    PartialDate
+		wantErr bool
+	}{
+		{in: "", want: PartialDate{}},
+		{in: "1999", want: PartialDate{Year: 1999}},
+		{in: "1999-06", want: PartialDate{Year: 1999, Month: 6}},
+		{in: "1999-06-01", want: PartialDate{Year: 1999, Month: 6, Day: 1}},
+		{in: "2000-02-29", want: PartialDate{Year: 2000, Month: 2, Day: 29}},
+		{in: "1999-02-29", wantErr: true},
+		{in: "1999-13", wantErr: true},
+		{in: "99", wantErr: true},
+		{in: "1999-6-1", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		got, err := ParsePartialDate(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("ParsePartialDate(%q) expected error", tc.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePartialDate(%q) returned error: %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParsePartialDate(%q) = %+v, want %+v", tc.in, got, tc.want)
+		}
+		if got.String() != tc.in {
+			t.Errorf("String() round trip = %q, want %q", got.String(), tc.in)
+		}
+	}
+}
+
+func TestPartialDateCompare(t *testing.T) {
+	ordered := []string{"", "1998-12-31", "1999", "1999-01", "1999-01-01", "1999-02"}
+	for i := 0; i+1 < len(ordered); i++ {
+		a, b := PartialDateOf(ordered[i]), PartialDateOf(ordered[i+1])
+		if !a.Before(b) {
+			t.Errorf("expected %q before %q", ordered[i], ordered[i+1])
+		}
+	}
+}
+
+func TestPartialDateJSON(t *testing.T) {
+	var span LifeSpan
+	if err := json.Unmarshal([]byte(`{"begin":"1987","end":"1994-04-05","ended":true}`), &span); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if span.Begin.Year != 1987 || span.End.Day != 5 {
+		t.Fatalf("unexpected life span %+v", span)
+	}
+
+	encoded, err := json.Marshal(span)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if string(encoded) != `{"begin":"1987","end":"1994-04-05","ended":true}` {
+		t.Errorf("unexpected encoding %s", encoded)
+	}
+}
@@ -0,0 +1,85 @@
+package data
+
+import "encoding/json"
+
+// AggregateRating summarizes the rated reviews attached to an album.
+type AggregateRating struct {
+	Score   float64  `json:"score"`
+	Count   int      `json:"count"`
+	Sources []string `json:"sources"`
+}
+
+// NewAggregateRating averages the ratings of reviews that carry one. Ratings are averaged
+// as reported by each source. It returns nil when no review is rated.
+func NewAggregateRating(reviews []Review) *AggregateRating {
+	var (
+		total float64
+		agg   AggregateRating
+		seen  = make(map[string]bool)
+	)
+	for _, review := range reviews {
+		if review.Rating <= 0 {
+			continue
+		}
+		total += review.Rating
+		agg.Count++
+		if review.Source != "" && !seen[review.Source] {
+			seen[review.Source] = true
+			agg.Sources = append(agg.Sources, review.Source)
+		}
+	}
+	if agg.Count == 0 {
+		return nil
+	}
+	agg.Score = total / float64(agg.Count)
+	return &agg
+}
+
+// RatingChange reports that a cached album's aggregate rating moved, or that a source rated it
+// for the first time. Previous is nil when the album had no rating before.
+type RatingChange struct {
+	AlbumID    string           `json:"albumId"`
+	Title      string           `json:"title"`
+	ArtistID   string           `json:"artistId,omitempty"`
+	ArtistName string           `json:"artistName,omitempty"`
+	Previous   *AggregateRating `json:"previous,omitempty"`
+	Current    *AggregateRating `json:"current"`
+	// NewSources are the sources in Current that had not rated the album before.
+	NewSources []string `json:"newSources,omitempty"`
+}
+
+// albumJSON is the wire shape of Album. The alias drops Album's methods to avoid recursion.
+type albumJSON struct {
+	albumAlias
+	// Review mirrors Reviews[0] for clients written against the single-review payload.
+	// Deprecated: read Reviews instead; this field will be removed.
+	Review *Review `json:"review,omitempty"`
+}
+
+type albumAlias Album
+
+// MarshalJSON emits the deprecated "review" field alongside "reviews".
+func (a Album) MarshalJSON() ([]byte, error) {
+	payload := albumJSON{albumAlias: albumAlias(a)}
+	if len(a.Reviews) > 0 {
+		payload.Review = &a.Reviews[0]
+	}
+	return json.Marshal(payload)
+}
+
+// UnmarshalJSON accepts cached payloads written before Reviews existed, promoting a
+// non-empty legacy "review" into Reviews.
+func (a *Album) UnmarshalJSON(b []byte) error {
+	var payload albumJSON
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return err
+	}
+	*a = Album(payload.albumAlias)
+	if len(a.Reviews) == 0 && payload.Review != nil && *payload.Review != (Review{}) {
+		a.Reviews = []Review{*payload.Review}
+		if a.Rating == nil {
+			a.Rating = NewAggregateRating(a.Reviews)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package data
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewAggregateRating(t *testing.T) {
+	rating := NewAggregateRating([]Review{
+		{Source: "Discogs", Rating: 4},
+		{Source: "Discogs", Rating: 5},
+		{Source: "Editorial", Rating: 0},
+	})
+	if rating == nil {
+		t.Fatal("expected a rating")
+	}
+	if rating.Score != 4.5 || rating.Count != 2 {
+		t.Errorf("unexpected rating %+v", rating)
+	}
+	if len(rating.Sources) != 1 || rating.Sources[0] != "Discogs" {
+		t.Errorf("unexpected sources %v", rating.Sources)
+	}
+
+	if NewAggregateRating([]Review{{Source: "Editorial"}}) != nil {
+		t.Error("expected nil rating when nothing is rated")
+	}
+}
+
+func TestAlbumJSONKeepsDeprecatedReview(t *testing.T) {
+	album := Album{ID: "rg", Reviews: []Review{{Source: "Discogs", Text: "Loud."}}}
+	encoded, err := json.Marshal(album)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"review":{"source":"Discogs"`) {
+		t.Errorf("expected deprecated review field, got %s", encoded)
+	}
+
+	var decoded Album
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(decoded.Reviews) != 1 {
+		t.Errorf("expected round-trip to keep one review, got %d", len(decoded.Reviews))
+	}
+}
+
+func TestAlbumJSONPromotesLegacyReview(t *testing.T) {
+	legacy := `{"id":"rg","title":"Nevermind","review":{"source":"Discogs","rating":4.5,"text":"Loud."}}`
+
+	var album Album
+	if err := json.Unmarshal([]byte(legacy), &album); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(album.Reviews) != 1 || album.Reviews[0].Text != "Loud." {
+		t.Fatalf("expected legacy review to be promoted, got %+v", album.Reviews)
+	}
+	if album.Rating == nil || album.Rating.Score != 4.5 {
+		t.Errorf("expected rating derived from legacy review, got %+v", album.Rating)
+	}
+}
@@ -0,0 +1,136 @@
+package data
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxCollaborators caps the collaborator list in DiscographyStats.
+const maxCollaborators = 5
+
+// DiscographyStats summarizes an artist's known release groups.
+type DiscographyStats struct {
+	Albums        int            `json:"albums"`
+	EPs           int            `json:"eps"`
+	Singles       int            `json:"singles"`
+	Other         int            `json:"other"`
+	FirstYear     int            `json:"firstYear,omitempty"`
+	LastYear      int            `json:"lastYear,omitempty"`
+	ActiveYears   int            `json:"activeYears,omitempty"`
+	AverageRating float64        `json:"averageRating,omitempty"`
+	RatedAlbums   int            `json:"ratedAlbums,omitempty"`
+	Collaborators []Collaborator `json:"collaborators,omitempty"`
+}
+
+// Collaborator is another artist credited alongside the subject, with the number of shared release groups.
+type Collaborator struct {
+	ArtistID string `json:"artistId"`
+	Name     string `json:"name"`
+	Count    int    `json:"count"`
+}
+
+// ComputeDiscographyStats derives stats for artistID from its albums. Ratings are read from each
+// album's aggregate rating, so callers should merge in cached album details first.
+func ComputeDiscographyStats(artistID string, albums []Album) *DiscographyStats {
+	if len(albums) == 0 {
+		return nil
+	}
+
+	stats := &DiscographyStats{}
+	var ratingTotal float64
+	collaborators := make(map[string]*Collaborator)
+
+	for _, album := range albums {
+		switch strings.ToLower(album.PrimaryType) {
+		case "album":
+			stats.Albums++
+		case "ep":
+			stats.EPs++
+		case "single":
+			stats.Singles++
+		default:
+			stats.Other++
+		}
+
+		if year := album.FirstReleaseDate.Year; year > 0 {
+			if stats.FirstYear == 0 || year < stats.FirstYear {
+				stats.FirstYear = year
+			}
+			if year > stats.LastYear {
+				stats.LastYear = year
+			}
+		}
+
+		if album.Rating != nil && album.Rating.Count > 0 {
+			ratingTotal += album.Rating.Score
+			stats.RatedAlbums++
+		}
+
+		for _, credit := range album.Credits {
+			if credit.ArtistID == "" || credit.ArtistID == artistID {
+				continue
+			}
+			entry, ok := collaborators[credit.ArtistID]
+			if !ok {
+				entry = &Collaborator{ArtistID: credit.ArtistID, Name: credit.Name}
+				collaborators[credit.ArtistID] = entry
+			}
+			entry.Count++
+		}
+	}
+
+	if stats.FirstYear > 0 {
+		stats.ActiveYears = stats.LastYear - stats.FirstYear + 1
+	}
+	if stats.RatedAlbums > 0 {
+		stats.AverageRating = ratingTotal / float64(stats.RatedAlbums)
+	}
+
+	for _, entry := range collaborators {
+		stats.Collaborators = append(stats.Collaborators, *entry)
+	}
+	sort.Slice(stats.Collaborators, func(i, j int) bool {
+		a, b := stats.Collaborators[i], stats.Collaborators[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Name < b.Name
+	})
+	if len(stats.Collaborators) > maxCollaborators {
+		stats.Collaborators = stats.Collaborators[:maxCollaborators]
+	}
+
+	return stats
+}
+
+// AlbumRuntime summarizes an album's track lengths. Tracks without a known length count toward
+// TrackCount but not the average, so Total is a lower bound when TimedTracks < TrackCount.
+type AlbumRuntime struct {
+	TrackCount     int    `json:"trackCount"`
+	TimedTracks    int    `json:"timedTracks"`
+	TotalMs        int    `json:"totalMs"`
+	Total          string `json:"total,omitempty"`
+	AverageTrackMs int    `json:"averageTrackMs,omitempty"`
+	AverageTrack   string `json:"averageTrack,omitempty"`
+}
+
+// ComputeAlbumRuntime derives runtime totals from tracks' millisecond lengths.
+func ComputeAlbumRuntime(tracks []Track) *AlbumRuntime {
+	if len(tracks) == 0 {
+		return nil
+	}
+
+	runtime := &AlbumRuntime{TrackCount: len(tracks)}
+	for _, track := range tracks {
+		if track.LengthMs > 0 {
+			runtime.TimedTracks++
+			runtime.TotalMs += track.LengthMs
+		}
+	}
+	if runtime.TimedTracks > 0 {
+		runtime.AverageTrackMs = runtime.TotalMs / runtime.TimedTracks
+	}
+	runtime.Total = FormatDuration(runtime.TotalMs)
+	runtime.AverageTrack = FormatDuration(runtime.AverageTrackMs)
+	return runtime
+}
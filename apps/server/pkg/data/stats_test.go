@@ -0,0 +1,69 @@
+package data
+
+import "testing"
+
+func TestComputeDiscographyStats(t *testing.T) {
+	credits := func(ids ...string) []ArtistCredit {
+		var out []ArtistCredit
+		for _, id := range ids {
+			out = append(out, ArtistCredit{ArtistID: id, Name: id})
+		}
+		return out
+	}
+	albums := []Album{
+		{PrimaryType: "Album", FirstReleaseDate: PartialDate{Year: 1989}, Credits: credits("self"), Rating: &AggregateRating{Score: 3, Count: 1}},
+		{PrimaryType: "Album", FirstReleaseDate: PartialDate{Year: 1991}, Credits: credits("self", "guest"), Rating: &AggregateRating{Score: 5, Count: 10}},
+		{PrimaryType: "EP", FirstReleaseDate: PartialDate{Year: 1992}, Credits: credits("self", "guest", "other")},
+		{PrimaryType: "Single"},
+	}
+
+	stats := ComputeDiscographyStats("self", albums)
+
+	if stats.Albums != 2 || stats.EPs != 1 || stats.Singles != 1 {
+		t.Errorf("unexpected counts %+v", stats)
+	}
+	if stats.FirstYear != 1989 || stats.LastYear != 1992 || stats.ActiveYears != 4 {
+		t.Errorf("unexpected year span %d-%d (%d)", stats.FirstYear, stats.LastYear, stats.ActiveYears)
+	}
+	if stats.AverageRating != 4 || stats.RatedAlbums != 2 {
+		t.Errorf("unexpected rating %f over %d", stats.AverageRating, stats.RatedAlbums)
+	}
+	if len(stats.Collaborators) != 2 || stats.Collaborators[0].ArtistID != "guest" || stats.Collaborators[0].Count != 2 {
+		t.Errorf("unexpected collaborators %+v", stats.Collaborators)
+	}
+}
+
+func TestComputeDiscographyStatsEmpty(t *testing.T) {
+	if ComputeDiscographyStats("self", nil) != nil {
+		t.Error("expected nil stats without albums")
+	}
+}
+
+func TestComputeAlbumRuntime(t *testing.T) {
+	runtime := ComputeAlbumRuntime([]Track{
+		{Number: 1, LengthMs: 301000},
+		{Number: 2, LengthMs: 219000},
+		{Number: 3},
+		{Number: 4, LengthMs: 3080000},
+	})
+
+	if runtime.TrackCount != 4 || runtime.TimedTracks != 3 {
+		t.Errorf("expected 4 tracks with 3 timed, got %+v", runtime)
+	}
+	if runtime.TotalMs != 3600000 || runtime.Total != "1:00:00" {
+		t.Errorf("expected a one hour total, got %d (%q)", runtime.TotalMs, runtime.Total)
+	}
+	if runtime.AverageTrackMs != 1200000 || runtime.AverageTrack != "20:00" {
+		t.Errorf("expected a 20 minute average, got %d (%q)", runtime.AverageTrackMs, runtime.AverageTrack)
+	}
+}
+
+func TestComputeAlbumRuntimeEmpty(t *testing.T) {
+	if runtime := ComputeAlbumRuntime(nil); runtime != nil {
+		t.Errorf("expected nil runtime, got %+v", runtime)
+	}
+	untimed := ComputeAlbumRuntime([]Track{{Number: 1}})
+	if untimed.TrackCount != 1 || untimed.TotalMs != 0 || untimed.Total != "" || untimed.AverageTrack != "" {
+		t.Errorf("expected a count without lengths, got %+v", untimed)
+	}
+}
@@ -0,0 +1,32 @@
+package data
+
+// Warning reasons.
+const (
+	WarningUnavailable  = "unavailable"
+	WarningTimeout      = "timeout"
+	WarningRateLimited  = "rate_limited"
+	WarningUnauthorized = "unauthorized"
+	WarningFailed       = "failed"
+)
+
+// Warning notes a field left incomplete because an optional source failed during enrichment.
+// Retryable warnings clear once a later re-enrichment or refresh fills the field.
+type Warning struct {
+	Field     string `json:"field"`
+	Source    string `json:"source"`
+	Reason    string `json:"reason"`
+	Retryable bool   `json:"retryable"`
+	// RetryAfter is the wait in seconds a rate-limiting source asked for, when it said.
+	RetryAfter int `json:"retryAfter,omitempty"`
+}
+
+// ClearWarnings drops the warnings for field, returning nil when none remain.
+func ClearWarnings(warnings []Warning, field string) []Warning {
+	var kept []Warning
+	for _, warning := range warnings {
+		if warning.Field != field {
+			kept = append(kept, warning)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// benchmarkArtist builds a fully enriched artist with albums albums of tracks tracks each,
+// the shape of a prolific artist's cached discography.
+func benchmarkArtist(albums, tracks int) *data.Artist {
+	artist := &data.Artist{
+		ID:        "bench-artist",
+		Name:      "Benchmark Artist",
+		Biography: "A long-running benchmark artist.",
+		Genres:    []string{"rock", "alternative rock", "art rock"},
+		Aliases:   []string{"The Benchmarks"},
+		Images:    []data.Image{{Type: data.ImageTypePhoto, URL: "https://example.com/artist.jpg", Source: "wikipedia"}},
+		Links:     map[string]string{"wikipedia": "https://en.wikipedia.org/wiki/Benchmark", "discogs": "https://www.discogs.com/artist/1"},
+	}
+	for a := 0; a < albums; a++ {
+		album := data.Album{
+			ID:          fmt.Sprintf("bench-album-%d", a),
+			Title:       fmt.Sprintf("Album %d", a),
+			ArtistID:    artist.ID,
+			ArtistName:  artist.Name,
+			PrimaryType: "Album",
+			Year:        1990 + a%30,
+			Genre:       "rock",
+			Images:      []data.Image{{Type: data.ImageTypeFront, URL: fmt.Sprintf("https://example.com/cover-%d.jpg", a), Source: "coverartarchive"}},
+			Links:       map[string]string{"wikipedia": fmt.Sprintf("https://en.wikipedia.org/wiki/Album_%d", a)},
+			Credits:     []data.ArtistCredit{{ArtistID: artist.ID, Name: artist.Name}},
+			Reviews:     []data.Review{{Source: "discogs", Rating: 4.2, Summary: "Community rating"}},
+		}
+		for t := 1; t <= tracks; t++ {
+			album.Tracks = append(album.Tracks, data.Track{
+				Number:      t,
+				Title:       fmt.Sprintf("Track %d", t),
+				LengthMs:    200000 + t,
+				RecordingID: fmt.Sprintf("rec-%d-%d", a, t),
+				Credits:     []data.ArtistCredit{{ArtistID: artist.ID, Name: artist.Name}},
+			})
+		}
+		artist.Albums = append(artist.Albums, album)
+	}
+	return artist
+}
+
+func BenchmarkCloneArtist(b *testing.B) {
+	artist := benchmarkArtist(50, 12)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if clone := cloneArtist(artist); len(clone.Albums) != 50 {
+			b.Fatalf("got %d albums, want 50", len(clone.Albums))
+		}
+	}
+}
+
+func BenchmarkMemoryStoreGetArtist(b *testing.B) {
+	ctx := context.Background()
+	store, err := NewMemoryStore(ctx)
+	if err != nil {
+		b.Fatalf("NewMemoryStore returned error: %v", err)
+	}
+	if err := store.SaveArtist(ctx, benchmarkArtist(50, 12)); err != nil {
+		b.Fatalf("SaveArtist returned error: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := store.GetArtist(ctx, "bench-artist"); err != nil {
+			b.Fatalf("GetArtist returned error: %v", err)
+		}
+	}
+}
+
+// BenchmarkMemoryStoreSaveArtist measures the copy-on-write cost that saves pay so reads
+// don't have to.
+func BenchmarkMemoryStoreSaveArtist(b *testing.B) {
+	ctx := context.Background()
+	store, err := NewMemoryStore(ctx)
+	if err != nil {
+		b.Fatalf("NewMemoryStore returned error: %v", err)
+	}
+	artist := benchmarkArtist(50, 12)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := store.SaveArtist(ctx, artist); err != nil {
+			b.Fatalf("SaveArtist returned error: %v", err)
+		}
+	}
+}
+
+func newBenchmarkSQLiteStore(b *testing.B) *SQLiteStore {
+	b.Helper()
+	dsn := "file:" + filepath.Join(b.TempDir(), sqliteDBName) + sqliteQuerySuffix
+	store, err := NewSQLiteStore(context.Background(), dsn)
+	if err != nil {
+		b.Fatalf(sqliteNewErrFmt, err)
+	}
+	b.Cleanup(func() {
+		if err := store.Close(context.Background()); err != nil {
+			b.Fatalf(sqliteCloseErrFmt, err)
+		}
+	})
+	return store
+}
+
+// BenchmarkSQLiteStoreUpsertArtist rewrites the same artist each iteration, changing the
+// biography so every save is a real update rather than a no-op.
+func BenchmarkSQLiteStoreUpsertArtist(b *testing.B) {
+	ctx := context.Background()
+	store := newBenchmarkSQLiteStore(b)
+	artist := benchmarkArtist(50, 12)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		artist.Biography = fmt.Sprintf("Revision %d", i)
+		if err := store.SaveArtist(ctx, artist); err != nil {
+			b.Fatalf("SaveArtist returned error: %v", err)
+		}
+	}
+}
+
+func BenchmarkSQLiteStoreUpsertAlbum(b *testing.B) {
+	ctx := context.Background()
+	store := newBenchmarkSQLiteStore(b)
+	album := benchmarkArtist(1, 12).Albums[0]
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		album.Genre = fmt.Sprintf("genre-%d", i%10)
+		if err := store.SaveAlbum(ctx, &album); err != nil {
+			b.Fatalf("SaveAlbum returned error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package db
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// AlbumFilter narrows a browse over cached albums. Zero values match everything; FromYear and
+// ToYear are inclusive and albums without a known year only match when neither is set. Types,
+// lowercase MusicBrainz release group types, match albums whose primary type and every
+// secondary type are listed. After, when set, resumes strictly after that sort position and
+// takes the place of Offset.
+type AlbumFilter struct {
+	FromYear int
+	ToYear   int
+	Genre    string
+	Types    []string
+	Limit    int
+	Offset   int
+	After    *AlbumKey
+}
+
+// AlbumKey is an album's position in browse order. Paging by key rather than offset keeps
+// pages stable while albums are cached concurrently.
+type AlbumKey struct {
+	Year  int    `json:"y"`
+	Title string `json:"t"`
+	ID    string `json:"i"`
+}
+
+// AlbumKeyOf returns album's browse position.
+func AlbumKeyOf(album *data.Album) AlbumKey {
+	return AlbumKey{Year: album.Year, Title: album.Title, ID: album.ID}
+}
+
+func (k AlbumKey) less(other AlbumKey) bool {
+	if k.Year != other.Year {
+		return k.Year < other.Year
+	}
+	if k.Title != other.Title {
+		return k.Title < other.Title
+	}
+	return k.ID < other.ID
+}
+
+// AlbumBrowser lists cached albums by release year and genre without asking MusicBrainz.
+type AlbumBrowser interface {
+	// BrowseAlbums returns matching albums ordered by year, then title.
+	BrowseAlbums(ctx context.Context, filter AlbumFilter) ([]data.Album, error)
+}
+
+// ArtistFilter narrows a browse over cached artists. Country is an ISO 3166-1 code and Type a
+// MusicBrainz artist type such as Group; both match case-insensitively and empty matches all.
+type ArtistFilter struct {
+	Country string
+	Type    string
+	Limit   int
+	Offset  int
+	After   *ArtistKey
+}
+
+// ArtistKey is an artist's position in browse order; see AlbumKey.
+type ArtistKey struct {
+	Name string `json:"n"`
+	ID   string `json:"i"`
+}
+
+// ArtistKeyOf returns artist's browse position.
+func ArtistKeyOf(artist *data.Artist) ArtistKey {
+	return ArtistKey{Name: artist.Name, ID: artist.ID}
+}
+
+func (k ArtistKey) less(other ArtistKey) bool {
+	if k.Name != other.Name {
+		return k.Name < other.Name
+	}
+	return k.ID < other.ID
+}
+
+// ArtistBrowser lists cached artists by country and type without asking MusicBrainz.
+type ArtistBrowser interface {
+	// BrowseArtists returns matching artists ordered by name.
+	BrowseArtists(ctx context.Context, filter ArtistFilter) ([]data.Artist, error)
+}
+
+// normalizeCountry and normalizeArtistType fold the browse columns for indexing and comparison.
+func normalizeCountry(country string) string {
+	return strings.ToUpper(strings.TrimSpace(country))
+}
+
+func normalizeArtistType(artistType string) string {
+	return strings.ToLower(strings.TrimSpace(artistType))
+}
+
+func (f ArtistFilter) matches(artist *data.Artist) bool {
+	if country := normalizeCountry(f.Country); country != "" && normalizeCountry(artist.Country) != country {
+		return false
+	}
+	if artistType := normalizeArtistType(f.Type); artistType != "" && normalizeArtistType(artist.Type) != artistType {
+		return false
+	}
+	return f.After == nil || f.After.less(ArtistKeyOf(artist))
+}
+
+// BrowseArtists scans cached artists for those matching filter.
+func (s *MemoryStore) BrowseArtists(ctx context.Context, filter ArtistFilter) ([]data.Artist, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matches := make([]*data.Artist, 0)
+	for _, artist := range s.artists {
+		if filter.matches(artist) {
+			matches = append(matches, artist)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return ArtistKeyOf(matches[i]).less(ArtistKeyOf(matches[j]))
+	})
+
+	if filter.After == nil {
+		if filter.Offset >= len(matches) {
+			return []data.Artist{}, nil
+		}
+		matches = matches[filter.Offset:]
+	}
+	if filter.Limit > 0 && len(matches) > filter.Limit {
+		matches = matches[:filter.Limit]
+	}
+
+	artists := make([]data.Artist, 0, len(matches))
+	for _, artist := range matches {
+		artists = append(artists, *cloneArtist(artist))
+	}
+	return artists, nil
+}
+
+// normalizeGenre folds a genre for indexing and comparison.
+func normalizeGenre(genre string) string {
+	return strings.ToLower(strings.TrimSpace(genre))
+}
+
+func (f AlbumFilter) matches(album *data.Album) bool {
+	if f.FromYear > 0 && album.Year < f.FromYear {
+		return false
+	}
+	if f.ToYear > 0 && (album.Year == 0 || album.Year > f.ToYear) {
+		return false
+	}
+	if genre := normalizeGenre(f.Genre); genre != "" && normalizeGenre(album.Genre) != genre {
+		return false
+	}
+	if len(f.Types) > 0 {
+		if !slices.Contains(f.Types, strings.ToLower(album.PrimaryType)) {
+			return false
+		}
+		for _, secondary := range album.SecondaryTypes {
+			if !slices.Contains(f.Types, strings.ToLower(secondary)) {
+				return false
+			}
+		}
+	}
+	return f.After == nil || f.After.less(AlbumKeyOf(album))
+}
+
+// BrowseAlbums scans cached albums for those matching filter.
+func (s *MemoryStore) BrowseAlbums(ctx context.Context, filter AlbumFilter) ([]data.Album, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matches := make([]*data.Album, 0)
+	for _, album := range s.albums {
+		if filter.matches(album) {
+			matches = append(matches, album)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return AlbumKeyOf(matches[i]).less(AlbumKeyOf(matches[j]))
+	})
+
+	if filter.After == nil {
+		if filter.Offset >= len(matches) {
+			return []data.Album{}, nil
+		}
+		matches = matches[filter.Offset:]
+	}
+	if filter.Limit > 0 && len(matches) > filter.Limit {
+		matches = matches[:filter.Limit]
+	}
+
+	albums := make([]data.Album, 0, len(matches))
+	for _, album := range matches {
+		albums = append(albums, *cloneAlbum(album))
+	}
+	return albums, nil
+}
@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+func seedBrowseAlbums(t *testing.T, store Store) {
+	t.Helper()
+	albums := []*data.Album{
+		{ID: "loveless", Title: "Loveless", ArtistID: "mbv", Year: 1991, Genre: "Shoegaze"},
+		{ID: "souvlaki", Title: "Souvlaki", ArtistID: "slowdive", Year: 1993, Genre: "shoegaze"},
+		{ID: "nevermind", Title: "Nevermind", ArtistID: "nirvana", Year: 1991, Genre: "grunge"},
+		{ID: "isnt-anything", Title: "Isn't Anything", ArtistID: "mbv", Year: 1988, Genre: "shoegaze"},
+		{ID: "undated", Title: "Undated", ArtistID: "mbv", Genre: "shoegaze"},
+	}
+	for _, album := range albums {
+		if err := store.SaveAlbum(context.Background(), album); err != nil {
+			t.Fatalf("SaveAlbum returned error: %v", err)
+		}
+	}
+}
+
+func assertBrowseFinds(t *testing.T, store Store, filter AlbumFilter, wantIDs ...string) {
+	t.Helper()
+	albums, err := store.BrowseAlbums(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("BrowseAlbums(%+v) returned error: %v", filter, err)
+	}
+	if len(albums) != len(wantIDs) {
+		t.Fatalf("BrowseAlbums(%+v) returned %d albums, want %d", filter, len(albums), len(wantIDs))
+	}
+	for i, id := range wantIDs {
+		if albums[i].ID != id {
+			t.Fatalf("BrowseAlbums(%+v)[%d] = %q, want %q", filter, i, albums[i].ID, id)
+		}
+	}
+}
+
+func assertBrowseFilters(t *testing.T, store Store) {
+	t.Helper()
+	nineties := AlbumFilter{FromYear: 1990, ToYear: 1999}
+	assertBrowseFinds(t, store, nineties, "loveless", "nevermind", "souvlaki")
+	assertBrowseFinds(t, store, AlbumFilter{FromYear: 1990, ToYear: 1999, Genre: "Shoegaze"}, "loveless", "souvlaki")
+	assertBrowseFinds(t, store, AlbumFilter{Genre: "shoegaze"}, "undated", "isnt-anything", "loveless", "souvlaki")
+	assertBrowseFinds(t, store, AlbumFilter{FromYear: 1990, ToYear: 1999, Limit: 1, Offset: 1}, "nevermind")
+	assertBrowseFinds(t, store, AlbumFilter{FromYear: 2000, ToYear: 2009})
+
+	// Keyset paging resumes after the last album seen, ignoring any offset.
+	after := &AlbumKey{Year: 1991, Title: "Loveless", ID: "loveless"}
+	assertBrowseFinds(t, store, AlbumFilter{FromYear: 1990, ToYear: 1999, Offset: 5, After: after}, "nevermind", "souvlaki")
+}
+
+// assertBrowseTypes adds typed 1970s albums and filters them by release group type.
+func assertBrowseTypes(t *testing.T, store Store) {
+	t.Helper()
+	albums := []*data.Album{
+		{ID: "studio", Title: "Studio", Year: 1971, Genre: "rock", PrimaryType: "Album"},
+		{ID: "live", Title: "Live", Year: 1972, Genre: "rock", PrimaryType: "Album", SecondaryTypes: []string{"Live"}},
+		{ID: "hits", Title: "Hits", Year: 1973, Genre: "rock", PrimaryType: "Album", SecondaryTypes: []string{"Compilation", "Live"}},
+		{ID: "single", Title: "Single", Year: 1974, Genre: "rock", PrimaryType: "Single"},
+	}
+	for _, album := range albums {
+		if err := store.SaveAlbum(context.Background(), album); err != nil {
+			t.Fatalf("SaveAlbum returned error: %v", err)
+		}
+	}
+	seventies := AlbumFilter{FromYear: 1970, ToYear: 1979}
+	seventies.Types = []string{"album"}
+	assertBrowseFinds(t, store, seventies, "studio")
+	seventies.Types = []string{"album", "live"}
+	assertBrowseFinds(t, store, seventies, "studio", "live")
+	seventies.Types = []string{"album", "single", "live", "compilation"}
+	assertBrowseFinds(t, store, seventies, "studio", "live", "hits", "single")
+}
+
+func TestMemoryStoreBrowseAlbums(t *testing.T) {
+	store, err := NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf(newStoreErrFmt, err)
+	}
+	seedBrowseAlbums(t, store)
+	assertBrowseFilters(t, store)
+	assertBrowseTypes(t, store)
+}
+
+func TestSQLiteStoreBrowseAlbums(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dsn := "file:" + filepath.Join(dir, sqliteDBName) + sqliteQuerySuffix
+
+	store, err := NewSQLiteStore(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf(sqliteNewErrFmt, err)
+	}
+	defer func() {
+		if err := store.Close(context.Background()); err != nil {
+			t.Fatalf(sqliteCloseErrFmt, err)
+		}
+	}()
+	seedBrowseAlbums(t, store)
+	assertBrowseFilters(t, store)
+	assertBrowseTypes(t, store)
+
+	// Re-saving moves the album between genres rather than leaving a stale index entry.
+	if err := store.SaveAlbum(context.Background(), &data.Album{ID: "nevermind", Title: "Nevermind", ArtistID: "nirvana", Year: 1991, Genre: "shoegaze"}); err != nil {
+		t.Fatalf("SaveAlbum returned error: %v", err)
+	}
+	assertBrowseFinds(t, store, AlbumFilter{Genre: "grunge"})
+}
+
+func seedBrowseArtists(t *testing.T, store Store) {
+	t.Helper()
+	artists := []*data.Artist{
+		{ID: "abba", Name: "ABBA", Country: "SE", Type: "Group"},
+		{ID: "robyn", Name: "Robyn", Country: "SE", Type: "Person"},
+		{ID: "the-knife", Name: "The Knife", Country: "se", Type: "group"},
+		{ID: "mbv", Name: "My Bloody Valentine", Country: "IE", Type: "Group"},
+		{ID: "unknown", Name: "Unknown"},
+	}
+	for _, artist := range artists {
+		if err := store.SaveArtist(context.Background(), artist); err != nil {
+			t.Fatalf("SaveArtist returned error: %v", err)
+		}
+	}
+}
+
+func assertArtistBrowseFinds(t *testing.T, store Store, filter ArtistFilter, wantIDs ...string) {
+	t.Helper()
+	artists, err := store.BrowseArtists(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("BrowseArtists(%+v) returned error: %v", filter, err)
+	}
+	if len(artists) != len(wantIDs) {
+		t.Fatalf("BrowseArtists(%+v) returned %d artists, want %d", filter, len(artists), len(wantIDs))
+	}
+	for i, id := range wantIDs {
+		if artists[i].ID != id {
+			t.Fatalf("BrowseArtists(%+v)[%d] = %q, want %q", filter, i, artists[i].ID, id)
+		}
+	}
+}
+
+func assertArtistBrowseFilters(t *testing.T, store Store) {
+	t.Helper()
+	assertArtistBrowseFinds(t, store, ArtistFilter{Country: "se"}, "abba", "robyn", "the-knife")
+	assertArtistBrowseFinds(t, store, ArtistFilter{Country: "SE", Type: "Group"}, "abba", "the-knife")
+	assertArtistBrowseFinds(t, store, ArtistFilter{Type: "group"}, "abba", "mbv", "the-knife")
+	assertArtistBrowseFinds(t, store, ArtistFilter{Country: "SE", Limit: 1, Offset: 1}, "robyn")
+	assertArtistBrowseFinds(t, store, ArtistFilter{Country: "JP"})
+	assertArtistBrowseFinds(t, store, ArtistFilter{Country: "SE", After: &ArtistKey{Name: "ABBA", ID: "abba"}}, "robyn", "the-knife")
+}
+
+func TestMemoryStoreBrowseArtists(t *testing.T) {
+	store, err := NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf(newStoreErrFmt, err)
+	}
+	seedBrowseArtists(t, store)
+	assertArtistBrowseFilters(t, store)
+}
+
+func TestSQLiteStoreBrowseArtists(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dsn := "file:" + filepath.Join(dir, sqliteDBName) + sqliteQuerySuffix
+
+	store, err := NewSQLiteStore(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf(sqliteNewErrFmt, err)
+	}
+	defer func() {
+		if err := store.Close(context.Background()); err != nil {
+			t.Fatalf(sqliteCloseErrFmt, err)
+		}
+	}()
+	seedBrowseArtists(t, store)
+	assertArtistBrowseFilters(t, store)
+
+	// Tombstoned artists drop out of browse results.
+	if _, err := store.InvalidateArtist(context.Background(), "robyn"); err != nil {
+		t.Fatalf("InvalidateArtist returned error: %v", err)
+	}
+	assertArtistBrowseFinds(t, store, ArtistFilter{Country: "SE"}, "abba", "the-knife")
+}
@@ -3,44 +3,183 @@ package db
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
 )
 
-// ArtistRepository defines persistence operations for artist entities.
+// ArtistRepository defines persistence operations for artist entities. The artist GetArtist
+// returns may be a snapshot shared with other readers, so callers copy it before changing it.
 type ArtistRepository interface {
 	GetArtist(ctx context.Context, id string) (*data.Artist, error)
 	SaveArtist(ctx context.Context, artist *data.Artist) error
 }
 
-// AlbumRepository defines persistence operations for album entities.
+// AlbumRepository defines persistence operations for album entities. Like GetArtist, GetAlbum
+// may return a shared snapshot.
 type AlbumRepository interface {
 	GetAlbum(ctx context.Context, id string) (*data.Album, error)
 	SaveAlbum(ctx context.Context, album *data.Album) error
 }
 
+// AlbumBatchReader reads many cached albums in one query, for summaries that span a whole
+// discography.
+type AlbumBatchReader interface {
+	// GetAlbums returns the live cached albums among ids, in no particular order; IDs that are
+	// not cached are skipped. Like GetAlbum, the results may be shared snapshots.
+	GetAlbums(ctx context.Context, ids []string) ([]*data.Album, error)
+}
+
+// LabelRepository defines persistence operations for record label entities. GetLabel may
+// return a shared snapshot.
+type LabelRepository interface {
+	GetLabel(ctx context.Context, id string) (*data.Label, error)
+	SaveLabel(ctx context.Context, label *data.Label) error
+}
+
+// PlaylistRepository defines persistence operations for locally stored playlists.
+type PlaylistRepository interface {
+	GetPlaylist(ctx context.Context, id string) (*data.Playlist, error)
+	SavePlaylist(ctx context.Context, playlist *data.Playlist) error
+	// ListPlaylists returns every stored playlist sorted by name.
+	ListPlaylists(ctx context.Context) ([]data.Playlist, error)
+}
+
+// LibraryRepository tracks which albums are present in the user's local music collection.
+type LibraryRepository interface {
+	MarkAlbumOwned(ctx context.Context, owned *data.OwnedAlbum) error
+	ListOwnedAlbums(ctx context.Context) ([]data.OwnedAlbum, error)
+}
+
+// Entity kinds distinguish artist and album records in the alias and tombstone tables.
+const (
+	KindArtist = "artist"
+	KindAlbum  = "album"
+)
+
+// AliasRepository maps MBIDs that MusicBrainz has merged away to the surviving canonical MBID.
+type AliasRepository interface {
+	SaveAlias(ctx context.Context, kind, id, canonicalID string) error
+	// ResolveAlias returns the canonical MBID for id, or "" when id is not a known alias.
+	ResolveAlias(ctx context.Context, kind, id string) (string, error)
+}
+
+// Invalidation counts the cached records tombstoned or restored by an operation.
+type Invalidation struct {
+	Artists int `json:"artists"`
+	Albums  int `json:"albums"`
+}
+
+// Tombstone marks a cached record hidden from reads until it is restored, refetched, or
+// purged once the retention window passes.
+type Tombstone struct {
+	Kind      string    `json:"kind"`
+	ID        string    `json:"id"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+// CacheInvalidator drops cached upstream data so the next read refetches it. Records are
+// tombstoned rather than deleted so mistakes can be undone; saving a record clears its tombstone.
+type CacheInvalidator interface {
+	// InvalidateArtist tombstones an artist along with every cached album credited to it or
+	// listed in its discography. Tracks and reviews live inside album records and go with them.
+	InvalidateArtist(ctx context.Context, id string) (Invalidation, error)
+	// RestoreArtist undoes InvalidateArtist for records that have not been refetched or purged.
+	RestoreArtist(ctx context.Context, id string) (Invalidation, error)
+	// DeleteArtist tombstones one cached artist, leaving its albums cached, and reports whether
+	// a live record was found. RestoreArtist undoes it.
+	DeleteArtist(ctx context.Context, id string) (bool, error)
+	// DeleteAlbum tombstones one cached album and reports whether a live record was found.
+	DeleteAlbum(ctx context.Context, id string) (bool, error)
+	// ListTombstones returns tombstones created at or after since, oldest first.
+	ListTombstones(ctx context.Context, since time.Time) ([]Tombstone, error)
+	// PurgeTombstones permanently removes records tombstoned before the cutoff.
+	PurgeTombstones(ctx context.Context, before time.Time) (int, error)
+}
+
+// Repos are the entity repositories available inside a transaction.
+type Repos interface {
+	ArtistRepository
+	AlbumRepository
+	AliasRepository
+}
+
+// Transactor persists multi-entity results atomically, so a crash mid-enrichment never leaves a
+// half-written artist page behind.
+type Transactor interface {
+	// WithTx runs fn against transaction-bound repositories. Writes become visible together
+	// when fn returns nil and are discarded when it returns an error.
+	WithTx(ctx context.Context, fn func(Repos) error) error
+}
+
 // Store encapsulates repository behavior with lifecycle management.
 type Store interface {
 	ArtistRepository
 	AlbumRepository
+	AlbumBatchReader
+	LabelRepository
+	PlaylistRepository
+	LibraryRepository
+	AliasRepository
+	ArtistSearcher
+	AlbumBrowser
+	ArtistBrowser
+	RecordLister
+	Merger
+	CacheInvalidator
+	ModificationTracker
+	Transactor
 	Close(ctx context.Context) error
 }
 
 // MemoryStore is an in-memory persistence layer backing the application during early development.
+// Artists, albums, and labels are held as immutable snapshots: saves store a deep copy that is
+// never modified afterwards (writes replace it instead), so reads hand it out without copying.
 type MemoryStore struct {
-	mu      sync.RWMutex
-	artists map[string]*data.Artist
-	albums  map[string]*data.Album
+	mu        sync.RWMutex
+	artists   map[string]*data.Artist
+	albums    map[string]*data.Album
+	labels    map[string]*data.Label
+	playlists map[string]*data.Playlist
+	owned     map[string]data.OwnedAlbum
+	aliases   map[string]string
+	modified  map[recordKey]time.Time
+
+	deletedArtists map[string]deletedArtist
+	deletedAlbums  map[string]deletedAlbum
+}
+
+type deletedArtist struct {
+	artist    *data.Artist
+	deletedAt time.Time
+}
+
+// deletedAlbum remembers which artist invalidation tombstoned the album so a restore only
+// brings back what it removed.
+type deletedAlbum struct {
+	album     *data.Album
+	artistID  string
+	deletedAt time.Time
 }
 
 // NewMemoryStore constructs an in-memory store instance.
 func NewMemoryStore(ctx context.Context) (*MemoryStore, error) {
 	_ = ctx
 	return &MemoryStore{
-		artists: make(map[string]*data.Artist),
-		albums:  make(map[string]*data.Album),
+		artists:   make(map[string]*data.Artist),
+		albums:    make(map[string]*data.Album),
+		labels:    make(map[string]*data.Label),
+		playlists: make(map[string]*data.Playlist),
+		owned:     make(map[string]data.OwnedAlbum),
+		aliases:   make(map[string]string),
+		modified:  make(map[recordKey]time.Time),
+
+		deletedArtists: make(map[string]deletedArtist),
+		deletedAlbums:  make(map[string]deletedAlbum),
 	}, nil
 }
 
@@ -50,73 +189,426 @@ func (s *MemoryStore) Close(ctx context.Context) error {
 	return nil
 }
 
-// GetArtist retrieves an artist by ID if present.
+// GetArtist retrieves an artist by ID if present. The result is the stored snapshot and must
+// not be modified.
 func (s *MemoryStore) GetArtist(ctx context.Context, id string) (*data.Artist, error) {
 	_ = ctx
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	return s.artists[id], nil
+}
 
-	artist, ok := s.artists[id]
+// SaveArtist persists (or updates) an artist record.
+func (s *MemoryStore) SaveArtist(ctx context.Context, artist *data.Artist) error {
+	_ = ctx
+	if err := validateArtist(artist); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.artists[artist.ID] = cloneArtist(artist)
+	s.touch(KindArtist, artist.ID)
+	delete(s.deletedArtists, artist.ID)
+	return nil
+}
+
+// GetAlbum retrieves an album by ID if present. The result is the stored snapshot and must not
+// be modified.
+func (s *MemoryStore) GetAlbum(ctx context.Context, id string) (*data.Album, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.albums[id], nil
+}
+
+// GetAlbums retrieves the cached albums among ids. The results are stored snapshots and must
+// not be modified.
+func (s *MemoryStore) GetAlbums(ctx context.Context, ids []string) ([]*data.Album, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	albums := make([]*data.Album, 0, len(ids))
+	for _, id := range ids {
+		if album, ok := s.albums[id]; ok {
+			albums = append(albums, album)
+		}
+	}
+	return albums, nil
+}
+
+// SaveAlbum persists (or updates) an album record.
+func (s *MemoryStore) SaveAlbum(ctx context.Context, album *data.Album) error {
+	_ = ctx
+	if err := validateAlbum(album); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.albums[album.ID] = cloneAlbum(album)
+	s.touch(KindAlbum, album.ID)
+	delete(s.deletedAlbums, album.ID)
+	return nil
+}
+
+// GetLabel retrieves a label by ID if present. The result is the stored snapshot and must not
+// be modified.
+func (s *MemoryStore) GetLabel(ctx context.Context, id string) (*data.Label, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.labels[id], nil
+}
+
+// SaveLabel persists (or updates) a label record.
+func (s *MemoryStore) SaveLabel(ctx context.Context, label *data.Label) error {
+	_ = ctx
+	if err := validateLabel(label); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.labels[label.ID] = cloneLabel(label)
+	s.touch(kindLabel, label.ID)
+	return nil
+}
+
+// GetPlaylist retrieves a playlist by ID if present.
+func (s *MemoryStore) GetPlaylist(ctx context.Context, id string) (*data.Playlist, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	playlist, ok := s.playlists[id]
 	if !ok {
 		return nil, nil
 	}
-	return cloneArtist(artist), nil
+	return clonePlaylist(playlist), nil
 }
 
-// SaveArtist persists (or updates) an artist record.
-func (s *MemoryStore) SaveArtist(ctx context.Context, artist *data.Artist) error {
+// SavePlaylist persists (or updates) a playlist record.
+func (s *MemoryStore) SavePlaylist(ctx context.Context, playlist *data.Playlist) error {
 	_ = ctx
-	if artist == nil {
-		return errors.New("db: artist cannot be nil")
+	if playlist == nil {
+		return errors.New("db: playlist cannot be nil")
 	}
-	if strings.TrimSpace(artist.ID) == "" {
-		return errors.New("db: artist id required")
+	if strings.TrimSpace(playlist.ID) == "" {
+		return errors.New("db: playlist id required")
 	}
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.artists[artist.ID] = cloneArtist(artist)
+	s.playlists[playlist.ID] = clonePlaylist(playlist)
 	return nil
 }
 
-// GetAlbum retrieves an album by ID if present.
-func (s *MemoryStore) GetAlbum(ctx context.Context, id string) (*data.Album, error) {
+// ListPlaylists returns every stored playlist sorted by name.
+func (s *MemoryStore) ListPlaylists(ctx context.Context) ([]data.Playlist, error) {
 	_ = ctx
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	playlists := make([]data.Playlist, 0, len(s.playlists))
+	for _, playlist := range s.playlists {
+		playlists = append(playlists, *clonePlaylist(playlist))
+	}
+	sortPlaylists(playlists)
+	return playlists, nil
+}
+
+// MarkAlbumOwned records (or refreshes) an album as present in the local library.
+func (s *MemoryStore) MarkAlbumOwned(ctx context.Context, owned *data.OwnedAlbum) error {
+	_ = ctx
+	if owned == nil {
+		return errors.New("db: owned album cannot be nil")
+	}
+	if strings.TrimSpace(owned.AlbumID) == "" {
+		return errors.New("db: owned album id required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.owned[owned.AlbumID] = *owned
+	return nil
+}
+
+// ListOwnedAlbums returns every owned album sorted by artist then title.
+func (s *MemoryStore) ListOwnedAlbums(ctx context.Context) ([]data.OwnedAlbum, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	owned := make([]data.OwnedAlbum, 0, len(s.owned))
+	for _, album := range s.owned {
+		owned = append(owned, album)
+	}
+	sortOwnedAlbums(owned)
+	return owned, nil
+}
+
+// SaveAlias records that id was merged into canonicalID.
+func (s *MemoryStore) SaveAlias(ctx context.Context, kind, id, canonicalID string) error {
+	_ = ctx
+	if err := validateAlias(kind, id, canonicalID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.aliases[kind+":"+id] = canonicalID
+	return nil
+}
+
+// ResolveAlias returns the canonical MBID for id, or "" when id is not a known alias.
+func (s *MemoryStore) ResolveAlias(ctx context.Context, kind, id string) (string, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.aliases[kind+":"+id], nil
+}
+
+// InvalidateArtist tombstones an artist and its cached albums.
+func (s *MemoryStore) InvalidateArtist(ctx context.Context, id string) (Invalidation, error) {
+	_ = ctx
+	if strings.TrimSpace(id) == "" {
+		return Invalidation{}, errors.New("db: artist id required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	var result Invalidation
+	tombstoneAlbum := func(albumID string) {
+		if album, cached := s.albums[albumID]; cached {
+			s.deletedAlbums[albumID] = deletedAlbum{album: album, artistID: id, deletedAt: now}
+			delete(s.albums, albumID)
+			result.Albums++
+		}
+	}
+
+	if artist, ok := s.artists[id]; ok {
+		for _, album := range artist.Albums {
+			tombstoneAlbum(album.ID)
+		}
+		s.deletedArtists[id] = deletedArtist{artist: artist, deletedAt: now}
+		delete(s.artists, id)
+		result.Artists++
+	}
+	for albumID, album := range s.albums {
+		if album.ArtistID == id {
+			tombstoneAlbum(albumID)
+		}
+	}
+	return result, nil
+}
+
+// RestoreArtist brings back an artist and the albums its invalidation tombstoned.
+func (s *MemoryStore) RestoreArtist(ctx context.Context, id string) (Invalidation, error) {
+	_ = ctx
+	if strings.TrimSpace(id) == "" {
+		return Invalidation{}, errors.New("db: artist id required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result Invalidation
+	if deleted, ok := s.deletedArtists[id]; ok {
+		s.artists[id] = deleted.artist
+		delete(s.deletedArtists, id)
+		result.Artists++
+	}
+	for albumID, deleted := range s.deletedAlbums {
+		if deleted.artistID == id {
+			s.albums[albumID] = deleted.album
+			delete(s.deletedAlbums, albumID)
+			result.Albums++
+		}
+	}
+	return result, nil
+}
+
+// DeleteArtist tombstones a cached artist without touching its albums.
+func (s *MemoryStore) DeleteArtist(ctx context.Context, id string) (bool, error) {
+	_ = ctx
+	if strings.TrimSpace(id) == "" {
+		return false, errors.New("db: artist id required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	artist, ok := s.artists[id]
+	if !ok {
+		return false, nil
+	}
+	s.deletedArtists[id] = deletedArtist{artist: artist, deletedAt: time.Now().UTC()}
+	delete(s.artists, id)
+	return true, nil
+}
+
+// DeleteAlbum tombstones a cached album. No artist invalidation owns the tombstone, so
+// RestoreArtist leaves it alone.
+func (s *MemoryStore) DeleteAlbum(ctx context.Context, id string) (bool, error) {
+	_ = ctx
+	if strings.TrimSpace(id) == "" {
+		return false, errors.New("db: album id required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	album, ok := s.albums[id]
 	if !ok {
-		return nil, nil
+		return false, nil
 	}
-	return cloneAlbum(album), nil
+	s.deletedAlbums[id] = deletedAlbum{album: album, deletedAt: time.Now().UTC()}
+	delete(s.albums, id)
+	return true, nil
 }
 
-// SaveAlbum persists (or updates) an album record.
-func (s *MemoryStore) SaveAlbum(ctx context.Context, album *data.Album) error {
+// ListTombstones returns tombstones created at or after since, oldest first.
+func (s *MemoryStore) ListTombstones(ctx context.Context, since time.Time) ([]Tombstone, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tombstones := make([]Tombstone, 0)
+	for id, deleted := range s.deletedArtists {
+		if !deleted.deletedAt.Before(since) {
+			tombstones = append(tombstones, Tombstone{Kind: KindArtist, ID: id, DeletedAt: deleted.deletedAt})
+		}
+	}
+	for id, deleted := range s.deletedAlbums {
+		if !deleted.deletedAt.Before(since) {
+			tombstones = append(tombstones, Tombstone{Kind: KindAlbum, ID: id, DeletedAt: deleted.deletedAt})
+		}
+	}
+	sortTombstones(tombstones)
+	return tombstones, nil
+}
+
+// PurgeTombstones permanently removes records tombstoned before the cutoff.
+func (s *MemoryStore) PurgeTombstones(ctx context.Context, before time.Time) (int, error) {
 	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := 0
+	for id, deleted := range s.deletedArtists {
+		if deleted.deletedAt.Before(before) {
+			delete(s.deletedArtists, id)
+			purged++
+		}
+	}
+	for id, deleted := range s.deletedAlbums {
+		if deleted.deletedAt.Before(before) {
+			delete(s.deletedAlbums, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func sortTombstones(tombstones []Tombstone) {
+	sort.Slice(tombstones, func(i, j int) bool {
+		if !tombstones[i].DeletedAt.Equal(tombstones[j].DeletedAt) {
+			return tombstones[i].DeletedAt.Before(tombstones[j].DeletedAt)
+		}
+		if tombstones[i].Kind != tombstones[j].Kind {
+			return tombstones[i].Kind == KindArtist
+		}
+		return tombstones[i].ID < tombstones[j].ID
+	})
+}
+
+func validateArtist(artist *data.Artist) error {
+	if artist == nil {
+		return errors.New("db: artist cannot be nil")
+	}
+	if strings.TrimSpace(artist.ID) == "" {
+		return errors.New("db: artist id required")
+	}
+	return nil
+}
+
+func validateLabel(label *data.Label) error {
+	if label == nil {
+		return errors.New("db: label cannot be nil")
+	}
+	if strings.TrimSpace(label.ID) == "" {
+		return errors.New("db: label id required")
+	}
+	return nil
+}
+
+func validateAlbum(album *data.Album) error {
 	if album == nil {
 		return errors.New("db: album cannot be nil")
 	}
 	if strings.TrimSpace(album.ID) == "" {
 		return errors.New("db: album id required")
 	}
+	return nil
+}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.albums[album.ID] = cloneAlbum(album)
+func validateAlias(kind, id, canonicalID string) error {
+	if kind != KindArtist && kind != KindAlbum {
+		return fmt.Errorf("db: unknown alias kind %q", kind)
+	}
+	if strings.TrimSpace(id) == "" || strings.TrimSpace(canonicalID) == "" {
+		return errors.New("db: alias ids required")
+	}
+	if id == canonicalID {
+		return errors.New("db: alias cannot point to itself")
+	}
 	return nil
 }
 
+func sortPlaylists(playlists []data.Playlist) {
+	sort.Slice(playlists, func(i, j int) bool {
+		a, b := strings.ToLower(playlists[i].Name), strings.ToLower(playlists[j].Name)
+		if a != b {
+			return a < b
+		}
+		return playlists[i].ID < playlists[j].ID
+	})
+}
+
+func sortOwnedAlbums(owned []data.OwnedAlbum) {
+	sort.Slice(owned, func(i, j int) bool {
+		a, b := strings.ToLower(owned[i].ArtistName), strings.ToLower(owned[j].ArtistName)
+		if a != b {
+			return a < b
+		}
+		return strings.ToLower(owned[i].Title) < strings.ToLower(owned[j].Title)
+	})
+}
+
 func cloneArtist(src *data.Artist) *data.Artist {
 	if src == nil {
 		return nil
 	}
 	copyArtist := *src
 	copyArtist.Genres = append([]string(nil), src.Genres...)
-	copyArtist.Related = append([]string(nil), src.Related...)
+	copyArtist.Related = append([]data.RelatedArtist(nil), src.Related...)
 	copyArtist.Aliases = append([]string(nil), src.Aliases...)
+	copyArtist.Images = cloneImages(src.Images)
+	copyArtist.Links = cloneLinks(src.Links)
+	copyArtist.Members = cloneMemberships(src.Members)
+	copyArtist.MemberOf = cloneMemberships(src.MemberOf)
 	copyArtist.Albums = cloneAlbums(src.Albums)
+	copyArtist.Stats = cloneStats(src.Stats)
+	copyArtist.Awards = append([]data.Award(nil), src.Awards...)
+	copyArtist.LocalizedNames = cloneLinks(src.LocalizedNames)
+	copyArtist.Warnings = append([]data.Warning(nil), src.Warnings...)
+	if src.Locale != nil {
+		locale := *src.Locale
+		copyArtist.Locale = &locale
+	}
 	return &copyArtist
 }
 
@@ -138,19 +630,133 @@ func cloneAlbum(src *data.Album) *data.Album {
 	copyAlbum := *src
 	copyAlbum.SecondaryTypes = append([]string(nil), src.SecondaryTypes...)
 	copyAlbum.Tracks = cloneTracks(src.Tracks)
-	copyAlbum.Review = cloneReview(src.Review)
+	copyAlbum.Reviews = cloneReviews(src.Reviews)
+	copyAlbum.Rating = cloneRating(src.Rating)
+	copyAlbum.Images = cloneImages(src.Images)
+	copyAlbum.Links = cloneLinks(src.Links)
+	copyAlbum.Credits = append([]data.ArtistCredit(nil), src.Credits...)
+	copyAlbum.ProductionCredits = cloneProductionCredits(src.ProductionCredits)
+	copyAlbum.Editions = append([]data.Edition(nil), src.Editions...)
+	copyAlbum.Charts = append([]data.ChartPosition(nil), src.Charts...)
+	copyAlbum.Certifications = append([]data.Certification(nil), src.Certifications...)
+	copyAlbum.Awards = append([]data.Award(nil), src.Awards...)
+	copyAlbum.Warnings = append([]data.Warning(nil), src.Warnings...)
+	if src.Runtime != nil {
+		runtime := *src.Runtime
+		copyAlbum.Runtime = &runtime
+	}
 	return &copyAlbum
 }
 
+func cloneLabel(src *data.Label) *data.Label {
+	if src == nil {
+		return nil
+	}
+	copyLabel := *src
+	copyLabel.Links = cloneLinks(src.Links)
+	copyLabel.Albums = cloneAlbums(src.Albums)
+	return &copyLabel
+}
+
+func cloneProductionCredits(src []data.ProductionCredit) []data.ProductionCredit {
+	if len(src) == 0 {
+		return nil
+	}
+	credits := make([]data.ProductionCredit, len(src))
+	for i, credit := range src {
+		credits[i] = credit
+		credits[i].Tracks = append([]int(nil), credit.Tracks...)
+	}
+	return credits
+}
+
+func cloneStats(src *data.DiscographyStats) *data.DiscographyStats {
+	if src == nil {
+		return nil
+	}
+	stats := *src
+	stats.Collaborators = append([]data.Collaborator(nil), src.Collaborators...)
+	return &stats
+}
+
 func cloneTracks(src []data.Track) []data.Track {
 	if len(src) == 0 {
 		return nil
 	}
 	tracks := make([]data.Track, len(src))
 	copy(tracks, src)
+	for i := range tracks {
+		tracks[i].Credits = append([]data.ArtistCredit(nil), src[i].Credits...)
+	}
 	return tracks
 }
 
-func cloneReview(src data.Review) data.Review {
-	return src
+func cloneImages(src []data.Image) []data.Image {
+	if len(src) == 0 {
+		return nil
+	}
+	images := make([]data.Image, len(src))
+	copy(images, src)
+	for i := range images {
+		if src[i].Colors != nil {
+			colors := *src[i].Colors
+			images[i].Colors = &colors
+		}
+	}
+	return images
+}
+
+func cloneLinks(src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return nil
+	}
+	links := make(map[string]string, len(src))
+	for k, v := range src {
+		links[k] = v
+	}
+	return links
+}
+
+func cloneMemberships(src []data.Membership) []data.Membership {
+	if len(src) == 0 {
+		return nil
+	}
+	memberships := make([]data.Membership, len(src))
+	for i, m := range src {
+		memberships[i] = m
+		memberships[i].Roles = append([]string(nil), m.Roles...)
+	}
+	return memberships
+}
+
+func cloneReviews(src []data.Review) []data.Review {
+	if len(src) == 0 {
+		return nil
+	}
+	reviews := make([]data.Review, len(src))
+	copy(reviews, src)
+	return reviews
+}
+
+func cloneRating(src *data.AggregateRating) *data.AggregateRating {
+	if src == nil {
+		return nil
+	}
+	rating := *src
+	rating.Sources = append([]string(nil), src.Sources...)
+	return &rating
+}
+
+func clonePlaylist(src *data.Playlist) *data.Playlist {
+	if src == nil {
+		return nil
+	}
+	copyPlaylist := *src
+	if len(src.Tracks) > 0 {
+		copyPlaylist.Tracks = make([]data.PlaylistTrack, len(src.Tracks))
+		copy(copyPlaylist.Tracks, src.Tracks)
+	} else {
+		copyPlaylist.Tracks = nil
+	}
+	return &copyPlaylist
 }
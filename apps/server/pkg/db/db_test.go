@@ -22,10 +22,10 @@ func TestStoreSaveAndGetArtist(t *testing.T) {
 		ID:       testArtistID,
 		Name:     "Test Artist",
 		Genres:   []string{"rock"},
-		Related:  []string{"other"},
+		Related:  []data.RelatedArtist{{ID: "other", Name: "Other", Source: "musicbrainz"}},
 		Aliases:  []string{"Alias"},
 		Albums:   []data.Album{{ID: "album-1", Tracks: []data.Track{{Number: 1, Title: "Intro"}}}},
-		LifeSpan: data.LifeSpan{Begin: "2000-01-01"},
+		LifeSpan: data.LifeSpan{Begin: data.PartialDate{Year: 2000, Month: 1, Day: 1}},
 	}
 
 	if err := store.SaveArtist(context.Background(), artist); err != nil {
@@ -46,16 +46,19 @@ func TestStoreSaveAndGetArtist(t *testing.T) {
 		t.Errorf("expected genres to be preserved, got %#v", fetched.Genres)
 	}
 
-	// Mutate the fetched copy to ensure the stored record is not modified.
-	fetched.Genres[0] = "pop"
-	fetched.Related = append(fetched.Related, "new")
-	fetched.Aliases[0] = "Changed"
-	fetched.Albums[0].Tracks[0].Title = "Changed"
+	// Mutate the saved input to ensure the stored snapshot is not modified.
+	artist.Genres[0] = "pop"
+	artist.Related = append(artist.Related, data.RelatedArtist{ID: "new", Name: "New"})
+	artist.Aliases[0] = "Changed"
+	artist.Albums[0].Tracks[0].Title = "Changed"
 
 	fetchedAgain, err := store.GetArtist(context.Background(), testArtistID)
 	if err != nil {
 		t.Fatalf("second GetArtist returned error: %v", err)
 	}
+	if fetchedAgain != fetched {
+		t.Error("expected reads to share the stored snapshot")
+	}
 	if fetchedAgain.Genres[0] != "rock" {
 		t.Errorf("expected stored genres untouched, got %#v", fetchedAgain.Genres)
 	}
@@ -68,6 +71,13 @@ func TestStoreSaveAndGetArtist(t *testing.T) {
 	if fetchedAgain.Albums[0].Tracks[0].Title != "Intro" {
 		t.Errorf("expected album tracks untouched, got %#v", fetchedAgain.Albums[0].Tracks)
 	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = store.GetArtist(context.Background(), testArtistID)
+	})
+	if allocs != 0 {
+		t.Errorf("expected reads not to allocate, got %v allocations", allocs)
+	}
 }
 
 func TestStoreSaveArtistValidation(t *testing.T) {
@@ -126,10 +136,10 @@ func TestMemoryStoreAlbumCRUD(t *testing.T) {
 		t.Fatal("expected album to be returned")
 	}
 	if retrieved == album {
-		t.Error("expected a cloned album instance, got original reference")
+		t.Error("expected a stored snapshot, got the saved reference")
 	}
-	retrieved.Title = "Changed"
-	retrieved.SecondaryTypes[0] = "Studio"
+	album.Title = "Changed"
+	album.SecondaryTypes[0] = "Studio"
 	if stored := store.albums[albumID].Title; stored == "Changed" {
 		t.Errorf("expected stored album to remain unchanged, got %q", stored)
 	}
@@ -137,3 +147,35 @@ func TestMemoryStoreAlbumCRUD(t *testing.T) {
 		t.Errorf("expected stored album secondary types to remain unchanged, got %q", stored)
 	}
 }
+
+func TestMemoryStoreInvalidateArtist(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewMemoryStore(ctx)
+	if err != nil {
+		t.Fatalf(newStoreErrFmt, err)
+	}
+
+	if err := store.SaveArtist(ctx, &data.Artist{ID: "artist-1", Albums: []data.Album{{ID: "listed"}}}); err != nil {
+		t.Fatalf("SaveArtist returned error: %v", err)
+	}
+	for _, album := range []*data.Album{
+		{ID: "credited", ArtistID: "artist-1"},
+		{ID: "listed"},
+		{ID: "unrelated", ArtistID: "artist-2"},
+	} {
+		if err := store.SaveAlbum(ctx, album); err != nil {
+			t.Fatalf("SaveAlbum returned error: %v", err)
+		}
+	}
+
+	result, err := store.InvalidateArtist(ctx, "artist-1")
+	if err != nil {
+		t.Fatalf("InvalidateArtist returned error: %v", err)
+	}
+	if result.Artists != 1 || result.Albums != 2 {
+		t.Fatalf("unexpected invalidation counts %+v", result)
+	}
+	if _, ok := store.albums["unrelated"]; !ok {
+		t.Fatalf("expected unrelated album to remain cached")
+	}
+}
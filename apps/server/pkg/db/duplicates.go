@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// ErrNotCached is returned by merges when either record is missing from the store.
+var ErrNotCached = errors.New("db: record not cached")
+
+// Reasons a DuplicateGroup was reported.
+const (
+	// DuplicateReasonAlias marks a record cached under an MBID MusicBrainz has since merged.
+	DuplicateReasonAlias = "merged-mbid"
+	// DuplicateReasonName marks records with the same normalized name and start date (artists)
+	// or title, artist, and year (albums).
+	DuplicateReasonName = "same-name"
+)
+
+// RecordLister walks every live cached artist and album, for admin reports and maintenance.
+type RecordLister interface {
+	ListArtists(ctx context.Context) ([]data.Artist, error)
+	ListAlbums(ctx context.Context) ([]data.Album, error)
+}
+
+// MergeResult counts the references rewritten by a merge.
+type MergeResult struct {
+	Kind        string `json:"kind"`
+	FromID      string `json:"fromId"`
+	IntoID      string `json:"intoId"`
+	Albums      int    `json:"albums,omitempty"`
+	Artists     int    `json:"artists,omitempty"`
+	OwnedAlbums int    `json:"ownedAlbums,omitempty"`
+}
+
+// Merger consolidates a duplicate record into its survivor. The survivor keeps its own data and
+// gains any fields only the duplicate had; the duplicate is removed and recorded as an alias, and
+// references to it (credited albums, discographies, owned albums) are repointed.
+type Merger interface {
+	MergeArtists(ctx context.Context, fromID, intoID string) (MergeResult, error)
+	MergeAlbums(ctx context.Context, fromID, intoID string) (MergeResult, error)
+}
+
+// DuplicateGroup is a set of cached records that probably describe the same entity. IntoID is
+// the suggested survivor and is also listed in IDs.
+type DuplicateGroup struct {
+	Kind   string   `json:"kind"`
+	Reason string   `json:"reason"`
+	Name   string   `json:"name"`
+	IntoID string   `json:"intoId"`
+	IDs    []string `json:"ids"`
+}
+
+// FindDuplicates reports probable duplicate artists and albums among cached records.
+func FindDuplicates(ctx context.Context, lister RecordLister, aliases AliasRepository) ([]DuplicateGroup, error) {
+	artists, err := lister.ListArtists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	albums, err := lister.ListAlbums(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []DuplicateGroup
+
+	artistIDs := make(map[string]bool, len(artists))
+	for _, artist := range artists {
+		artistIDs[artist.ID] = true
+	}
+	albumIDs := make(map[string]bool, len(albums))
+	for _, album := range albums {
+		albumIDs[album.ID] = true
+	}
+
+	if aliases != nil {
+		for _, artist := range artists {
+			canonical, err := aliases.ResolveAlias(ctx, KindArtist, artist.ID)
+			if err != nil {
+				return nil, err
+			}
+			if canonical != "" && canonical != artist.ID && artistIDs[canonical] {
+				groups = append(groups, DuplicateGroup{Kind: KindArtist, Reason: DuplicateReasonAlias, Name: artist.Name, IntoID: canonical, IDs: []string{canonical, artist.ID}})
+			}
+		}
+		for _, album := range albums {
+			canonical, err := aliases.ResolveAlias(ctx, KindAlbum, album.ID)
+			if err != nil {
+				return nil, err
+			}
+			if canonical != "" && canonical != album.ID && albumIDs[canonical] {
+				groups = append(groups, DuplicateGroup{Kind: KindAlbum, Reason: DuplicateReasonAlias, Name: album.Title, IntoID: canonical, IDs: []string{canonical, album.ID}})
+			}
+		}
+	}
+
+	byName := make(map[string][]*data.Artist)
+	for i := range artists {
+		artist := &artists[i]
+		name := normalizedName(artist.Name)
+		if name == "" || artist.LifeSpan.Begin.IsZero() {
+			continue
+		}
+		key := name + "|" + artist.LifeSpan.Begin.String()
+		byName[key] = append(byName[key], artist)
+	}
+	for _, members := range byName {
+		if len(members) < 2 {
+			continue
+		}
+		sort.Slice(members, func(i, j int) bool {
+			if len(members[i].Albums) != len(members[j].Albums) {
+				return len(members[i].Albums) > len(members[j].Albums)
+			}
+			return members[i].ID < members[j].ID
+		})
+		group := DuplicateGroup{Kind: KindArtist, Reason: DuplicateReasonName, Name: members[0].Name, IntoID: members[0].ID}
+		for _, artist := range members {
+			group.IDs = append(group.IDs, artist.ID)
+		}
+		groups = append(groups, group)
+	}
+
+	byTitle := make(map[string][]*data.Album)
+	for i := range albums {
+		album := &albums[i]
+		title := normalizedName(album.Title)
+		if title == "" || album.ArtistID == "" || album.Year == 0 {
+			continue
+		}
+		key := title + "|" + album.ArtistID + "|" + strconv.Itoa(album.Year)
+		byTitle[key] = append(byTitle[key], album)
+	}
+	for _, members := range byTitle {
+		if len(members) < 2 {
+			continue
+		}
+		sort.Slice(members, func(i, j int) bool {
+			if len(members[i].Tracks) != len(members[j].Tracks) {
+				return len(members[i].Tracks) > len(members[j].Tracks)
+			}
+			return members[i].ID < members[j].ID
+		})
+		group := DuplicateGroup{Kind: KindAlbum, Reason: DuplicateReasonName, Name: members[0].Title, IntoID: members[0].ID}
+		for _, album := range members {
+			group.IDs = append(group.IDs, album.ID)
+		}
+		groups = append(groups, group)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Kind != groups[j].Kind {
+			return groups[i].Kind > groups[j].Kind
+		}
+		return strings.ToLower(groups[i].Name) < strings.ToLower(groups[j].Name)
+	})
+	return groups, nil
+}
+
+// normalizedName folds a name the way artist search does, so "The Band" and "Band!" collide.
+func normalizedName(name string) string {
+	return strings.Join(searchTerms(name), " ")
+}
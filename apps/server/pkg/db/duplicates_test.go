@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+func seedDuplicates(t *testing.T, store Store) {
+	t.Helper()
+	ctx := context.Background()
+	for _, artist := range []*data.Artist{
+		{ID: "nirvana", Name: "Nirvana", LifeSpan: data.LifeSpan{Begin: data.PartialDate{Year: 1987}}, Albums: []data.Album{{ID: "bleach"}, {ID: "bleach-dupe"}}},
+		{ID: "nirvana-dupe", Name: "NIRVANA", LifeSpan: data.LifeSpan{Begin: data.PartialDate{Year: 1987}}, Biography: "Seattle band", Albums: []data.Album{{ID: "in-utero"}}},
+		{ID: "nirvana-uk", Name: "Nirvana", LifeSpan: data.LifeSpan{Begin: data.PartialDate{Year: 1967}}},
+		{ID: "old-mbid", Name: "Mudhoney"},
+		{ID: "mudhoney", Name: "Mudhoney"},
+	} {
+		if err := store.SaveArtist(ctx, artist); err != nil {
+			t.Fatalf("SaveArtist returned error: %v", err)
+		}
+	}
+	for _, album := range []*data.Album{
+		{ID: "bleach", Title: "Bleach", ArtistID: "nirvana", Year: 1989, Tracks: []data.Track{{Number: 1, Title: "Blew"}}},
+		{ID: "bleach-dupe", Title: "Bleach", ArtistID: "nirvana", Year: 1989, Genre: "grunge"},
+		{ID: "in-utero", Title: "In Utero", ArtistID: "nirvana-dupe", Year: 1993, Credits: []data.ArtistCredit{{ArtistID: "nirvana-dupe", Name: "Nirvana"}}},
+	} {
+		if err := store.SaveAlbum(ctx, album); err != nil {
+			t.Fatalf("SaveAlbum returned error: %v", err)
+		}
+	}
+	if err := store.SaveAlias(ctx, KindArtist, "old-mbid", "mudhoney"); err != nil {
+		t.Fatalf("SaveAlias returned error: %v", err)
+	}
+	if err := store.MarkAlbumOwned(ctx, &data.OwnedAlbum{AlbumID: "bleach-dupe", ArtistID: "nirvana", Title: "Bleach"}); err != nil {
+		t.Fatalf("MarkAlbumOwned returned error: %v", err)
+	}
+	if err := store.MarkAlbumOwned(ctx, &data.OwnedAlbum{AlbumID: "in-utero", ArtistID: "nirvana-dupe", Title: "In Utero"}); err != nil {
+		t.Fatalf("MarkAlbumOwned returned error: %v", err)
+	}
+}
+
+func assertDuplicatesAndMerge(t *testing.T, store Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	groups, err := FindDuplicates(ctx, store, store)
+	if err != nil {
+		t.Fatalf("FindDuplicates returned error: %v", err)
+	}
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 duplicate groups, got %+v", groups)
+	}
+	want := []DuplicateGroup{
+		{Kind: KindArtist, Reason: DuplicateReasonAlias, IntoID: "mudhoney"},
+		{Kind: KindArtist, Reason: DuplicateReasonName, IntoID: "nirvana"},
+		{Kind: KindAlbum, Reason: DuplicateReasonName, IntoID: "bleach"},
+	}
+	for i, group := range want {
+		if groups[i].Kind != group.Kind || groups[i].Reason != group.Reason || groups[i].IntoID != group.IntoID || len(groups[i].IDs) != 2 {
+			t.Errorf("group %d = %+v, want %+v", i, groups[i], group)
+		}
+	}
+
+	result, err := store.MergeArtists(ctx, "nirvana-dupe", "nirvana")
+	if err != nil {
+		t.Fatalf("MergeArtists returned error: %v", err)
+	}
+	if result.Albums != 1 || result.OwnedAlbums != 1 {
+		t.Errorf("unexpected artist merge result %+v", result)
+	}
+	artist, _ := store.GetArtist(ctx, "nirvana")
+	if artist.Biography != "Seattle band" || len(artist.Albums) != 3 {
+		t.Errorf("expected survivor to gain the duplicate's biography and albums, got %+v", artist)
+	}
+	if gone, _ := store.GetArtist(ctx, "nirvana-dupe"); gone != nil {
+		t.Error("expected the duplicate artist to be removed")
+	}
+	if canonical, _ := store.ResolveAlias(ctx, KindArtist, "nirvana-dupe"); canonical != "nirvana" {
+		t.Errorf("expected an alias to the survivor, got %q", canonical)
+	}
+	album, _ := store.GetAlbum(ctx, "in-utero")
+	if album.ArtistID != "nirvana" || album.Credits[0].ArtistID != "nirvana" {
+		t.Errorf("expected album credits to follow the merge, got %+v", album)
+	}
+
+	result, err = store.MergeAlbums(ctx, "bleach-dupe", "bleach")
+	if err != nil {
+		t.Fatalf("MergeAlbums returned error: %v", err)
+	}
+	if result.Artists != 1 || result.OwnedAlbums != 1 {
+		t.Errorf("unexpected album merge result %+v", result)
+	}
+	album, _ = store.GetAlbum(ctx, "bleach")
+	if album.Genre != "grunge" || len(album.Tracks) != 1 {
+		t.Errorf("expected the survivor to keep its tracks and gain the genre, got %+v", album)
+	}
+	artist, _ = store.GetArtist(ctx, "nirvana")
+	if len(artist.Albums) != 2 {
+		t.Errorf("expected the duplicate album to leave the discography, got %+v", artist.Albums)
+	}
+	owned, _ := store.ListOwnedAlbums(ctx)
+	ownedIDs := map[string]string{}
+	for _, o := range owned {
+		ownedIDs[o.AlbumID] = o.ArtistID
+	}
+	if len(owned) != 2 || ownedIDs["bleach"] != "nirvana" || ownedIDs["in-utero"] != "nirvana" {
+		t.Errorf("expected owned albums to follow both merges, got %+v", owned)
+	}
+
+	if _, err := store.MergeAlbums(ctx, "missing", "bleach"); !errors.Is(err, ErrNotCached) {
+		t.Errorf("expected ErrNotCached, got %v", err)
+	}
+}
+
+func TestMemoryStoreDuplicatesAndMerge(t *testing.T) {
+	store, err := NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf(newStoreErrFmt, err)
+	}
+	seedDuplicates(t, store)
+	assertDuplicatesAndMerge(t, store)
+}
+
+func TestSQLiteStoreDuplicatesAndMerge(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dsn := "file:" + filepath.Join(dir, sqliteDBName) + sqliteQuerySuffix
+
+	store, err := NewSQLiteStore(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf(sqliteNewErrFmt, err)
+	}
+	defer func() {
+		if err := store.Close(context.Background()); err != nil {
+			t.Fatalf(sqliteCloseErrFmt, err)
+		}
+	}()
+	seedDuplicates(t, store)
+	assertDuplicatesAndMerge(t, store)
+
+	// The merged artist leaves the search index with its row.
+	artists, err := store.SearchArtists(context.Background(), "nirvana", 10)
+	if err != nil {
+		t.Fatalf("SearchArtists returned error: %v", err)
+	}
+	for _, artist := range artists {
+		if artist.ID == "nirvana-dupe" {
+			t.Error("expected the merged artist to leave the search index")
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// consolidateArtist fills fields missing from into with the duplicate's values.
+func consolidateArtist(into, from *data.Artist) {
+	if into.Biography == "" {
+		into.Biography, into.BiographyAttribution = from.Biography, from.BiographyAttribution
+	}
+	if len(into.Genres) == 0 {
+		into.Genres = from.Genres
+	}
+	if len(into.Images) == 0 {
+		into.Images = from.Images
+	}
+	if len(into.Awards) == 0 {
+		into.Awards = from.Awards
+	}
+	if into.Listening == nil {
+		into.Listening = from.Listening
+	}
+	if len(into.Related) == 0 {
+		into.Related = from.Related
+	}
+	into.Links = mergeLinks(into.Links, from.Links)
+
+	known := make(map[string]bool, len(into.Albums))
+	for _, album := range into.Albums {
+		known[album.ID] = true
+	}
+	for _, album := range from.Albums {
+		if !known[album.ID] {
+			into.Albums = append(into.Albums, album)
+		}
+	}
+}
+
+// consolidateAlbum fills fields missing from into with the duplicate's values.
+func consolidateAlbum(into, from *data.Album) {
+	if into.Genre == "" {
+		into.Genre = from.Genre
+	}
+	if len(into.Styles) == 0 {
+		into.Styles = from.Styles
+	}
+	if into.Label == "" {
+		into.Label, into.LabelID, into.CatalogNumber = from.Label, from.LabelID, from.CatalogNumber
+	}
+	if len(into.Tracks) == 0 {
+		into.Tracks = from.Tracks
+	}
+	if len(into.Reviews) == 0 {
+		into.Reviews, into.Rating = from.Reviews, from.Rating
+	}
+	if into.Marketplace == nil {
+		into.Marketplace = from.Marketplace
+	}
+	if into.Listening == nil {
+		into.Listening = from.Listening
+	}
+	if into.Description == "" {
+		into.Description, into.DescriptionAttribution = from.Description, from.DescriptionAttribution
+	}
+	if len(into.Images) == 0 {
+		into.Images = from.Images
+	}
+	if len(into.ProductionCredits) == 0 {
+		into.ProductionCredits = from.ProductionCredits
+	}
+	if len(into.Editions) == 0 {
+		into.Editions = from.Editions
+	}
+	if len(into.Charts) == 0 {
+		into.Charts = from.Charts
+	}
+	if len(into.Certifications) == 0 {
+		into.Certifications = from.Certifications
+	}
+	if len(into.Awards) == 0 {
+		into.Awards = from.Awards
+	}
+	into.Links = mergeLinks(into.Links, from.Links)
+}
+
+func mergeLinks(into, from map[string]string) map[string]string {
+	for key, value := range from {
+		if _, ok := into[key]; ok {
+			continue
+		}
+		if into == nil {
+			into = make(map[string]string, len(from))
+		}
+		into[key] = value
+	}
+	return into
+}
+
+// repointAlbumArtist moves an album's credits from one artist to another, reporting whether
+// anything changed.
+func repointAlbumArtist(album *data.Album, fromID, intoID string) bool {
+	changed := false
+	if album.ArtistID == fromID {
+		album.ArtistID = intoID
+		changed = true
+	}
+	for i := range album.Credits {
+		if album.Credits[i].ArtistID == fromID {
+			album.Credits[i].ArtistID = intoID
+			changed = true
+		}
+	}
+	return changed
+}
+
+// repointDiscography replaces fromID with intoID in an artist's album list, dropping it instead
+// when intoID is already listed. It reports whether anything changed.
+func repointDiscography(artist *data.Artist, fromID, intoID string) bool {
+	index, hasInto := -1, false
+	for i, album := range artist.Albums {
+		switch album.ID {
+		case fromID:
+			index = i
+		case intoID:
+			hasInto = true
+		}
+	}
+	if index < 0 {
+		return false
+	}
+	if hasInto {
+		artist.Albums = append(artist.Albums[:index], artist.Albums[index+1:]...)
+	} else {
+		artist.Albums[index].ID = intoID
+	}
+	return true
+}
+
+// albumArtistIDs lists the cached artists whose discographies may reference either album.
+func albumArtistIDs(albums ...*data.Album) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, album := range albums {
+		candidates := []string{album.ArtistID}
+		for _, credit := range album.Credits {
+			candidates = append(candidates, credit.ArtistID)
+		}
+		for _, id := range candidates {
+			if id != "" && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func validateMerge(fromID, intoID string) error {
+	if strings.TrimSpace(fromID) == "" || strings.TrimSpace(intoID) == "" {
+		return fmt.Errorf("db: merge ids required")
+	}
+	if fromID == intoID {
+		return fmt.Errorf("db: cannot merge %s into itself", fromID)
+	}
+	return nil
+}
+
+// ListArtists returns every cached artist.
+func (s *MemoryStore) ListArtists(ctx context.Context) ([]data.Artist, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	artists := make([]data.Artist, 0, len(s.artists))
+	for _, artist := range s.artists {
+		artists = append(artists, *cloneArtist(artist))
+	}
+	sort.Slice(artists, func(i, j int) bool { return artists[i].ID < artists[j].ID })
+	return artists, nil
+}
+
+// ListAlbums returns every cached album.
+func (s *MemoryStore) ListAlbums(ctx context.Context) ([]data.Album, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	albums := make([]data.Album, 0, len(s.albums))
+	for _, album := range s.albums {
+		albums = append(albums, *cloneAlbum(album))
+	}
+	sort.Slice(albums, func(i, j int) bool { return albums[i].ID < albums[j].ID })
+	return albums, nil
+}
+
+// MergeArtists folds the fromID artist into intoID.
+func (s *MemoryStore) MergeArtists(ctx context.Context, fromID, intoID string) (MergeResult, error) {
+	_ = ctx
+	if err := validateMerge(fromID, intoID); err != nil {
+		return MergeResult{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	from, into := s.artists[fromID], s.artists[intoID]
+	if from == nil || into == nil {
+		return MergeResult{}, ErrNotCached
+	}
+
+	// Stored records are snapshots that readers may hold, so changes are made to copies.
+	result := MergeResult{Kind: KindArtist, FromID: fromID, IntoID: intoID}
+	into = cloneArtist(into)
+	consolidateArtist(into, cloneArtist(from))
+	s.artists[intoID] = into
+	s.touch(KindArtist, intoID)
+	delete(s.artists, fromID)
+
+	for id, album := range s.albums {
+		if album = cloneAlbum(album); repointAlbumArtist(album, fromID, intoID) {
+			s.albums[id] = album
+			s.touch(KindAlbum, id)
+			result.Albums++
+		}
+	}
+	for id, owned := range s.owned {
+		if owned.ArtistID == fromID {
+			owned.ArtistID = intoID
+			s.owned[id] = owned
+			result.OwnedAlbums++
+		}
+	}
+	s.repointAliases(KindArtist, fromID, intoID)
+	return result, nil
+}
+
+// MergeAlbums folds the fromID album into intoID.
+func (s *MemoryStore) MergeAlbums(ctx context.Context, fromID, intoID string) (MergeResult, error) {
+	_ = ctx
+	if err := validateMerge(fromID, intoID); err != nil {
+		return MergeResult{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	from, into := s.albums[fromID], s.albums[intoID]
+	if from == nil || into == nil {
+		return MergeResult{}, ErrNotCached
+	}
+
+	// Stored records are snapshots that readers may hold, so changes are made to copies.
+	result := MergeResult{Kind: KindAlbum, FromID: fromID, IntoID: intoID}
+	into = cloneAlbum(into)
+	consolidateAlbum(into, cloneAlbum(from))
+	s.albums[intoID] = into
+	s.touch(KindAlbum, intoID)
+	delete(s.albums, fromID)
+
+	for _, artistID := range albumArtistIDs(from, into) {
+		artist, ok := s.artists[artistID]
+		if !ok {
+			continue
+		}
+		if artist = cloneArtist(artist); repointDiscography(artist, fromID, intoID) {
+			s.artists[artistID] = artist
+			s.touch(KindArtist, artistID)
+			result.Artists++
+		}
+	}
+	if owned, ok := s.owned[fromID]; ok {
+		delete(s.owned, fromID)
+		if _, exists := s.owned[intoID]; !exists {
+			owned.AlbumID = intoID
+			s.owned[intoID] = owned
+		}
+		result.OwnedAlbums++
+	}
+	s.repointAliases(KindAlbum, fromID, intoID)
+	return result, nil
+}
+
+// repointAliases records fromID as an alias of intoID and updates aliases that pointed at
+// fromID, so lookups never follow a chain. Callers hold s.mu.
+func (s *MemoryStore) repointAliases(kind, fromID, intoID string) {
+	prefix := kind + ":"
+	for key, canonical := range s.aliases {
+		if strings.HasPrefix(key, prefix) && canonical == fromID {
+			s.aliases[key] = intoID
+		}
+	}
+	s.aliases[prefix+fromID] = intoID
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ModificationTracker reports when cached records last changed, so HTTP responses can carry
+// Last-Modified and answer If-Modified-Since. Each method returns the zero time when the
+// record is not cached. Saves that leave a record's content unchanged keep its time.
+type ModificationTracker interface {
+	ArtistModified(ctx context.Context, id string) (time.Time, error)
+	AlbumModified(ctx context.Context, id string) (time.Time, error)
+	LabelModified(ctx context.Context, id string) (time.Time, error)
+}
+
+// kindLabel keys label modification times; labels have no alias or tombstone rows.
+const kindLabel = "label"
+
+type recordKey struct {
+	kind string
+	id   string
+}
+
+// touch records that a cached record changed now. Callers hold s.mu for writing.
+func (s *MemoryStore) touch(kind, id string) {
+	s.modified[recordKey{kind, id}] = time.Now().UTC()
+}
+
+// ArtistModified returns when the cached artist was last saved or merged.
+func (s *MemoryStore) ArtistModified(ctx context.Context, id string) (time.Time, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.artists[id] == nil {
+		return time.Time{}, nil
+	}
+	return s.modified[recordKey{KindArtist, id}], nil
+}
+
+// AlbumModified returns when the cached album was last saved or merged.
+func (s *MemoryStore) AlbumModified(ctx context.Context, id string) (time.Time, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.albums[id] == nil {
+		return time.Time{}, nil
+	}
+	return s.modified[recordKey{KindAlbum, id}], nil
+}
+
+// LabelModified returns when the cached label was last saved.
+func (s *MemoryStore) LabelModified(ctx context.Context, id string) (time.Time, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.labels[id] == nil {
+		return time.Time{}, nil
+	}
+	return s.modified[recordKey{kindLabel, id}], nil
+}
+
+// ArtistModified returns the live artist row's updated_at.
+func (s *SQLiteStore) ArtistModified(ctx context.Context, id string) (time.Time, error) {
+	return s.updatedAt(ctx, `SELECT updated_at FROM artists WHERE id = ? AND deleted_at IS NULL`, id)
+}
+
+// AlbumModified returns the live album row's updated_at.
+func (s *SQLiteStore) AlbumModified(ctx context.Context, id string) (time.Time, error) {
+	return s.updatedAt(ctx, `SELECT updated_at FROM albums WHERE id = ? AND deleted_at IS NULL`, id)
+}
+
+// LabelModified returns the label row's updated_at.
+func (s *SQLiteStore) LabelModified(ctx context.Context, id string) (time.Time, error) {
+	return s.updatedAt(ctx, `SELECT updated_at FROM labels WHERE id = ?`, id)
+}
+
+func (s *SQLiteStore) updatedAt(ctx context.Context, query, id string) (time.Time, error) {
+	var updated time.Time
+	if err := s.db.QueryRowContext(ctx, query, id).Scan(&updated); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("db: query updated_at: %w", err)
+	}
+	return updated.UTC(), nil
+}
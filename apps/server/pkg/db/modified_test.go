@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+func TestMemoryStoreTracksModification(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewMemoryStore(ctx)
+	if err != nil {
+		t.Fatalf(newStoreErrFmt, err)
+	}
+
+	if modified, err := store.ArtistModified(ctx, "a1"); err != nil || !modified.IsZero() {
+		t.Fatalf("ArtistModified(uncached) = %v, %v; want the zero time", modified, err)
+	}
+
+	before := time.Now().UTC()
+	if err := store.SaveArtist(ctx, &data.Artist{ID: "a1", Name: "Nirvana"}); err != nil {
+		t.Fatalf("SaveArtist returned error: %v", err)
+	}
+	if err := store.SaveLabel(ctx, &data.Label{ID: "l1", Name: "Sub Pop"}); err != nil {
+		t.Fatalf("SaveLabel returned error: %v", err)
+	}
+	if err := store.WithTx(ctx, func(repos Repos) error {
+		return repos.SaveAlbum(ctx, &data.Album{ID: "b1", Title: "Bleach", ArtistID: "a1"})
+	}); err != nil {
+		t.Fatalf("WithTx returned error: %v", err)
+	}
+
+	for name, lookup := range map[string]func(context.Context, string) (time.Time, error){
+		"a1": store.ArtistModified,
+		"b1": store.AlbumModified,
+		"l1": store.LabelModified,
+	} {
+		modified, err := lookup(ctx, name)
+		if err != nil || modified.Before(before) {
+			t.Errorf("modified(%s) = %v, %v; want a time after %v", name, modified, err, before)
+		}
+	}
+
+	if _, err := store.InvalidateArtist(ctx, "a1"); err != nil {
+		t.Fatalf("InvalidateArtist returned error: %v", err)
+	}
+	if modified, _ := store.ArtistModified(ctx, "a1"); !modified.IsZero() {
+		t.Errorf("expected a tombstoned artist to have no modification time, got %v", modified)
+	}
+}
+
+func TestSQLiteStoreReportsUpdatedAt(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, err := NewSQLiteStore(ctx, "file:"+filepath.Join(t.TempDir(), sqliteDBName)+sqliteQuerySuffix)
+	if err != nil {
+		t.Fatalf(sqliteNewErrFmt, err)
+	}
+	defer func() {
+		if err := store.Close(ctx); err != nil {
+			t.Fatalf(sqliteCloseErrFmt, err)
+		}
+	}()
+
+	if modified, err := store.AlbumModified(ctx, sqliteAlbumID); err != nil || !modified.IsZero() {
+		t.Fatalf("AlbumModified(uncached) = %v, %v; want the zero time", modified, err)
+	}
+
+	artist := &data.Artist{ID: "a1", Name: "Nirvana"}
+	if err := store.SaveArtist(ctx, artist); err != nil {
+		t.Fatalf("SaveArtist returned error: %v", err)
+	}
+	stale := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := store.db.ExecContext(ctx, `UPDATE artists SET updated_at = ? WHERE id = ?`, stale, "a1"); err != nil {
+		t.Fatalf("backdate artist: %v", err)
+	}
+	if modified, err := store.ArtistModified(ctx, "a1"); err != nil || !modified.Equal(stale) {
+		t.Fatalf("ArtistModified = %v, %v; want %v", modified, err, stale)
+	}
+
+	// An identical save keeps the old time; a changed one moves it forward.
+	if err := store.SaveArtist(ctx, artist); err != nil {
+		t.Fatalf("SaveArtist returned error: %v", err)
+	}
+	if modified, _ := store.ArtistModified(ctx, "a1"); !modified.Equal(stale) {
+		t.Errorf("expected an unchanged save to keep %v, got %v", stale, modified)
+	}
+	artist.Biography = "Seattle band"
+	if err := store.SaveArtist(ctx, artist); err != nil {
+		t.Fatalf("SaveArtist returned error: %v", err)
+	}
+	if modified, _ := store.ArtistModified(ctx, "a1"); !modified.After(stale) {
+		t.Errorf("expected a changed save to move past %v, got %v", stale, modified)
+	}
+
+	if err := store.SaveLabel(ctx, &data.Label{ID: "l1", Name: "Sub Pop"}); err != nil {
+		t.Fatalf("SaveLabel returned error: %v", err)
+	}
+	if modified, err := store.LabelModified(ctx, "l1"); err != nil || modified.IsZero() {
+		t.Errorf("LabelModified = %v, %v; want the save time", modified, err)
+	}
+}
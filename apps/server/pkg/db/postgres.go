@@ -0,0 +1,1071 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+
+	"github.com/lib/pq"
+)
+
+// PoolConfig bounds the connections a PostgresStore keeps open. Zero values fall back to
+// defaults sized for a single API instance.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+const (
+	defaultPostgresMaxOpenConns    = 10
+	defaultPostgresMaxIdleConns    = 5
+	defaultPostgresConnMaxLifetime = 30 * time.Minute
+
+	// postgresMigrationLock serializes schema migrations when several instances start at once.
+	postgresMigrationLock = 0x66726571
+)
+
+// PostgresStore persists records in PostgreSQL as JSONB payloads, with the columns used for
+// browsing and search copied out so they can be indexed.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore connects to the PostgreSQL database at dsn, sizes its connection pool, and
+// applies migrations.
+func NewPostgresStore(ctx context.Context, dsn string, pool PoolConfig) (*PostgresStore, error) {
+	if strings.TrimSpace(dsn) == "" {
+		return nil, errors.New("db: database url required")
+	}
+
+	database, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("db: open postgres: %w", err)
+	}
+
+	maxOpen := pool.MaxOpenConns
+	if maxOpen <= 0 {
+		maxOpen = defaultPostgresMaxOpenConns
+	}
+	maxIdle := pool.MaxIdleConns
+	if maxIdle <= 0 {
+		maxIdle = defaultPostgresMaxIdleConns
+	}
+	lifetime := pool.ConnMaxLifetime
+	if lifetime <= 0 {
+		lifetime = defaultPostgresConnMaxLifetime
+	}
+	database.SetMaxOpenConns(maxOpen)
+	database.SetMaxIdleConns(min(maxIdle, maxOpen))
+	database.SetConnMaxLifetime(lifetime)
+
+	if err := database.PingContext(ctx); err != nil {
+		_ = database.Close()
+		return nil, fmt.Errorf("db: ping postgres: %w", err)
+	}
+
+	store := &PostgresStore{db: database}
+	if err := store.migrate(ctx); err != nil {
+		_ = database.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// Close releases database resources.
+func (s *PostgresStore) Close(ctx context.Context) error {
+	_ = ctx
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+func (s *PostgresStore) migrate(ctx context.Context) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db: begin migration: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, postgresMigrationLock); err != nil {
+		return fmt.Errorf("db: lock migration: %w", err)
+	}
+
+	for _, step := range []struct{ name, stmt string }{
+		{"artists", `CREATE TABLE IF NOT EXISTS artists (
+            id TEXT PRIMARY KEY,
+            payload JSONB NOT NULL,
+            updated_at TIMESTAMPTZ NOT NULL,
+            content_hash TEXT,
+            country TEXT NOT NULL DEFAULT '',
+            type TEXT NOT NULL DEFAULT '',
+            search TSVECTOR NOT NULL DEFAULT ''::tsvector,
+            deleted_at TIMESTAMPTZ
+        )`},
+		{"albums", `CREATE TABLE IF NOT EXISTS albums (
+            id TEXT PRIMARY KEY,
+            payload JSONB NOT NULL,
+            updated_at TIMESTAMPTZ NOT NULL,
+            content_hash TEXT,
+            year INTEGER NOT NULL DEFAULT 0,
+            genre TEXT NOT NULL DEFAULT '',
+            deleted_at TIMESTAMPTZ,
+            deleted_by TEXT
+        )`},
+		{"labels", `CREATE TABLE IF NOT EXISTS labels (
+            id TEXT PRIMARY KEY,
+            payload JSONB NOT NULL,
+            updated_at TIMESTAMPTZ NOT NULL,
+            content_hash TEXT
+        )`},
+		{"playlists", `CREATE TABLE IF NOT EXISTS playlists (
+            id TEXT PRIMARY KEY,
+            payload JSONB NOT NULL,
+            updated_at TIMESTAMPTZ NOT NULL
+        )`},
+		{"owned albums", `CREATE TABLE IF NOT EXISTS owned_albums (
+            id TEXT PRIMARY KEY,
+            payload JSONB NOT NULL,
+            updated_at TIMESTAMPTZ NOT NULL
+        )`},
+		{"aliases", `CREATE TABLE IF NOT EXISTS mbid_aliases (
+            kind TEXT NOT NULL,
+            id TEXT NOT NULL,
+            canonical_id TEXT NOT NULL,
+            updated_at TIMESTAMPTZ NOT NULL,
+            PRIMARY KEY (kind, id)
+        )`},
+		{"artist search index", `CREATE INDEX IF NOT EXISTS artists_search_idx ON artists USING GIN (search)`},
+		{"browse indexes", `CREATE INDEX IF NOT EXISTS albums_year_idx ON albums (year)`},
+		{"browse indexes", `CREATE INDEX IF NOT EXISTS albums_genre_year_idx ON albums (genre, year)`},
+		{"browse indexes", `CREATE INDEX IF NOT EXISTS artists_country_type_idx ON artists (country, type)`},
+		{"tombstone indexes", `CREATE INDEX IF NOT EXISTS albums_deleted_by_idx ON albums (deleted_by) WHERE deleted_by IS NOT NULL`},
+	} {
+		if _, err := tx.ExecContext(ctx, step.stmt); err != nil {
+			return fmt.Errorf("db: migrate %s: %w", step.name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("db: commit migration: %w", err)
+	}
+	return nil
+}
+
+// GetArtist retrieves an artist by ID if present.
+func (s *PostgresStore) GetArtist(ctx context.Context, id string) (*data.Artist, error) {
+	return postgresRepos{q: s.db}.GetArtist(ctx, id)
+}
+
+// SaveArtist upserts an artist record in the database.
+func (s *PostgresStore) SaveArtist(ctx context.Context, artist *data.Artist) error {
+	return postgresRepos{q: s.db}.SaveArtist(ctx, artist)
+}
+
+// ArtistNames lists the names of every live cached artist.
+func (s *PostgresStore) ArtistNames(ctx context.Context) ([]string, error) {
+	names, err := queryPayloads(ctx, s.db, `SELECT COALESCE(payload->>'name', '') FROM artists WHERE deleted_at IS NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("db: list artist names: %w", err)
+	}
+	if names == nil {
+		names = make([]string, 0)
+	}
+	return names, nil
+}
+
+// SearchArtists queries the weighted text index over cached artist names, aliases, and
+// disambiguation strings. Name hits outrank alias hits.
+func (s *PostgresStore) SearchArtists(ctx context.Context, query string, limit int) ([]data.Artist, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return []data.Artist{}, nil
+	}
+	if limit <= 0 {
+		limit = 25
+	}
+
+	// Terms contain only letters and digits, so they can be used as prefix lexemes directly.
+	match := make([]string, len(terms))
+	for i, term := range terms {
+		match[i] = term + ":*"
+	}
+
+	payloads, err := queryPayloads(
+		ctx,
+		s.db,
+		`SELECT payload FROM artists, to_tsquery('simple', $1::text) AS q
+         WHERE search @@ q AND deleted_at IS NULL
+         ORDER BY ts_rank(search, q, 1) DESC, id
+         LIMIT $2`,
+		strings.Join(match, " & "),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("db: search artists: %w", err)
+	}
+	return decodeArtists(payloads)
+}
+
+// BrowseAlbums lists cached albums matching filter using the indexed year and genre columns.
+func (s *PostgresStore) BrowseAlbums(ctx context.Context, filter AlbumFilter) ([]data.Album, error) {
+	query := `SELECT payload FROM albums WHERE deleted_at IS NULL`
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if filter.FromYear > 0 {
+		query += ` AND year >= ` + arg(filter.FromYear)
+	}
+	if filter.ToYear > 0 {
+		query += ` AND year BETWEEN 1 AND ` + arg(filter.ToYear)
+	}
+	if genre := normalizeGenre(filter.Genre); genre != "" {
+		query += ` AND genre = ` + arg(genre)
+	}
+	if len(filter.Types) > 0 {
+		types := arg(pq.Array(filter.Types))
+		query += ` AND lower(COALESCE(payload->>'primaryType', '')) = ANY(` + types + `)
+            AND NOT EXISTS (SELECT 1 FROM jsonb_array_elements_text(COALESCE(payload->'secondaryTypes', '[]'::jsonb)) AS t(value)
+                WHERE lower(t.value) <> ALL(` + types + `))`
+	}
+	// Titles and IDs compare bytewise, matching the other stores whatever the database collation.
+	const title = `COALESCE(payload->>'title', '') COLLATE "C"`
+	offset := filter.Offset
+	if after := filter.After; after != nil {
+		query += ` AND (year, ` + title + `, id COLLATE "C") > (` + arg(after.Year) + `, ` + arg(after.Title) + `, ` + arg(after.ID) + `)`
+		offset = 0
+	}
+	query += ` ORDER BY year, ` + title + `, id COLLATE "C" LIMIT ` + arg(postgresLimit(filter.Limit)) + ` OFFSET ` + arg(offset)
+
+	payloads, err := queryPayloads(ctx, s.db, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("db: browse albums: %w", err)
+	}
+	return decodeAlbums(payloads)
+}
+
+// BrowseArtists lists cached artists matching filter using the indexed country and type columns.
+func (s *PostgresStore) BrowseArtists(ctx context.Context, filter ArtistFilter) ([]data.Artist, error) {
+	query := `SELECT payload FROM artists WHERE deleted_at IS NULL`
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if country := normalizeCountry(filter.Country); country != "" {
+		query += ` AND country = ` + arg(country)
+	}
+	if artistType := normalizeArtistType(filter.Type); artistType != "" {
+		query += ` AND type = ` + arg(artistType)
+	}
+	const name = `COALESCE(payload->>'name', '') COLLATE "C"`
+	offset := filter.Offset
+	if after := filter.After; after != nil {
+		query += ` AND (` + name + `, id COLLATE "C") > (` + arg(after.Name) + `, ` + arg(after.ID) + `)`
+		offset = 0
+	}
+	query += ` ORDER BY ` + name + `, id COLLATE "C" LIMIT ` + arg(postgresLimit(filter.Limit)) + ` OFFSET ` + arg(offset)
+
+	payloads, err := queryPayloads(ctx, s.db, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("db: browse artists: %w", err)
+	}
+	return decodeArtists(payloads)
+}
+
+// postgresLimit maps a non-positive limit to NULL, which PostgreSQL treats as LIMIT ALL.
+func postgresLimit(limit int) any {
+	if limit <= 0 {
+		return nil
+	}
+	return limit
+}
+
+// GetAlbum retrieves an album by ID if present.
+func (s *PostgresStore) GetAlbum(ctx context.Context, id string) (*data.Album, error) {
+	return postgresRepos{q: s.db}.GetAlbum(ctx, id)
+}
+
+// GetAlbums retrieves the live cached albums among ids in one query.
+func (s *PostgresStore) GetAlbums(ctx context.Context, ids []string) ([]*data.Album, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	payloads, err := queryPayloads(ctx, s.db, `SELECT payload FROM albums WHERE id = ANY($1) AND deleted_at IS NULL`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("db: query albums: %w", err)
+	}
+	return decodeAlbumPointers(payloads)
+}
+
+// SaveAlbum upserts an album record in the database.
+func (s *PostgresStore) SaveAlbum(ctx context.Context, album *data.Album) error {
+	return postgresRepos{q: s.db}.SaveAlbum(ctx, album)
+}
+
+// GetLabel retrieves a label by ID if present.
+func (s *PostgresStore) GetLabel(ctx context.Context, id string) (*data.Label, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT payload FROM labels WHERE id = $1`, id)
+
+	var payload string
+	if err := row.Scan(&payload); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("db: query label: %w", err)
+	}
+
+	var label data.Label
+	upgraded, err := labelSchema.decode([]byte(payload), &label)
+	if err != nil {
+		return nil, fmt.Errorf("db: decode label: %w", err)
+	}
+	if upgraded {
+		rewritePostgresPayload(ctx, s.db, "labels", id, labelSchema, &label)
+	}
+
+	return &label, nil
+}
+
+// SaveLabel upserts a label record in the database.
+func (s *PostgresStore) SaveLabel(ctx context.Context, label *data.Label) error {
+	if err := validateLabel(label); err != nil {
+		return err
+	}
+
+	payload, err := labelSchema.encode(label)
+	if err != nil {
+		return fmt.Errorf("db: encode label: %w", err)
+	}
+
+	_, err = s.db.ExecContext(
+		ctx,
+		`INSERT INTO labels (id, payload, updated_at, content_hash)
+         VALUES ($1, $2, $3, $4)
+         ON CONFLICT (id) DO UPDATE SET payload = excluded.payload, updated_at = excluded.updated_at,
+             content_hash = excluded.content_hash
+         WHERE labels.content_hash IS DISTINCT FROM excluded.content_hash`,
+		label.ID,
+		string(payload),
+		time.Now().UTC(),
+		contentHash(payload),
+	)
+	if err != nil {
+		return fmt.Errorf("db: upsert label: %w", err)
+	}
+	return nil
+}
+
+// GetPlaylist retrieves a playlist by ID if present.
+func (s *PostgresStore) GetPlaylist(ctx context.Context, id string) (*data.Playlist, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT payload FROM playlists WHERE id = $1`, id)
+
+	var payload string
+	if err := row.Scan(&payload); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("db: query playlist: %w", err)
+	}
+
+	var playlist data.Playlist
+	if err := json.Unmarshal([]byte(payload), &playlist); err != nil {
+		return nil, fmt.Errorf("db: decode playlist: %w", err)
+	}
+
+	return &playlist, nil
+}
+
+// SavePlaylist upserts a playlist record in the database.
+func (s *PostgresStore) SavePlaylist(ctx context.Context, playlist *data.Playlist) error {
+	if playlist == nil {
+		return errors.New("db: playlist cannot be nil")
+	}
+	if strings.TrimSpace(playlist.ID) == "" {
+		return errors.New("db: playlist id required")
+	}
+
+	payload, err := json.Marshal(playlist)
+	if err != nil {
+		return fmt.Errorf("db: encode playlist: %w", err)
+	}
+
+	_, err = s.db.ExecContext(
+		ctx,
+		`INSERT INTO playlists (id, payload, updated_at)
+         VALUES ($1, $2, $3)
+         ON CONFLICT (id) DO UPDATE SET payload = excluded.payload, updated_at = excluded.updated_at`,
+		playlist.ID,
+		string(payload),
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("db: upsert playlist: %w", err)
+	}
+	return nil
+}
+
+// ListPlaylists returns every stored playlist sorted by name.
+func (s *PostgresStore) ListPlaylists(ctx context.Context) ([]data.Playlist, error) {
+	payloads, err := queryPayloads(ctx, s.db, `SELECT payload FROM playlists`)
+	if err != nil {
+		return nil, fmt.Errorf("db: query playlists: %w", err)
+	}
+
+	playlists := make([]data.Playlist, len(payloads))
+	for i, payload := range payloads {
+		if err := json.Unmarshal([]byte(payload), &playlists[i]); err != nil {
+			return nil, fmt.Errorf("db: decode playlist: %w", err)
+		}
+	}
+
+	sortPlaylists(playlists)
+	return playlists, nil
+}
+
+// MarkAlbumOwned upserts an owned-album record.
+func (s *PostgresStore) MarkAlbumOwned(ctx context.Context, owned *data.OwnedAlbum) error {
+	if owned == nil {
+		return errors.New("db: owned album cannot be nil")
+	}
+	if strings.TrimSpace(owned.AlbumID) == "" {
+		return errors.New("db: owned album id required")
+	}
+	return savePostgresOwnedAlbum(ctx, s.db, owned)
+}
+
+// ListOwnedAlbums returns every owned album sorted by artist then title.
+func (s *PostgresStore) ListOwnedAlbums(ctx context.Context) ([]data.OwnedAlbum, error) {
+	payloads, err := queryPayloads(ctx, s.db, `SELECT payload FROM owned_albums`)
+	if err != nil {
+		return nil, fmt.Errorf("db: query owned albums: %w", err)
+	}
+
+	owned := make([]data.OwnedAlbum, len(payloads))
+	for i, payload := range payloads {
+		if err := json.Unmarshal([]byte(payload), &owned[i]); err != nil {
+			return nil, fmt.Errorf("db: decode owned album: %w", err)
+		}
+	}
+
+	sortOwnedAlbums(owned)
+	return owned, nil
+}
+
+// SaveAlias records that id was merged into canonicalID.
+func (s *PostgresStore) SaveAlias(ctx context.Context, kind, id, canonicalID string) error {
+	return postgresRepos{q: s.db}.SaveAlias(ctx, kind, id, canonicalID)
+}
+
+// ResolveAlias returns the canonical MBID for id, or "" when id is not a known alias.
+func (s *PostgresStore) ResolveAlias(ctx context.Context, kind, id string) (string, error) {
+	return postgresRepos{q: s.db}.ResolveAlias(ctx, kind, id)
+}
+
+// InvalidateArtist tombstones an artist and its cached albums in one transaction.
+func (s *PostgresStore) InvalidateArtist(ctx context.Context, id string) (Invalidation, error) {
+	if strings.TrimSpace(id) == "" {
+		return Invalidation{}, errors.New("db: artist id required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("db: begin invalidation: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC()
+	albums, err := tx.ExecContext(
+		ctx,
+		`UPDATE albums SET deleted_at = $1, deleted_by = $2
+         WHERE deleted_at IS NULL
+           AND (payload->>'artistId' = $2
+            OR id IN (
+                SELECT album->>'id'
+                FROM artists, jsonb_array_elements(COALESCE(artists.payload->'albums', '[]'::jsonb)) AS album
+                WHERE artists.id = $2
+            ))`,
+		now,
+		id,
+	)
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("db: invalidate albums: %w", err)
+	}
+	artists, err := tx.ExecContext(ctx, `UPDATE artists SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`, now, id)
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("db: invalidate artist: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return Invalidation{}, fmt.Errorf("db: commit invalidation: %w", err)
+	}
+
+	return invalidationCounts(artists, albums), nil
+}
+
+// RestoreArtist clears the tombstones InvalidateArtist set for an artist and its albums.
+func (s *PostgresStore) RestoreArtist(ctx context.Context, id string) (Invalidation, error) {
+	if strings.TrimSpace(id) == "" {
+		return Invalidation{}, errors.New("db: artist id required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("db: begin restore: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	albums, err := tx.ExecContext(ctx, `UPDATE albums SET deleted_at = NULL, deleted_by = NULL WHERE deleted_by = $1`, id)
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("db: restore albums: %w", err)
+	}
+	artists, err := tx.ExecContext(ctx, `UPDATE artists SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("db: restore artist: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return Invalidation{}, fmt.Errorf("db: commit restore: %w", err)
+	}
+
+	return invalidationCounts(artists, albums), nil
+}
+
+// DeleteArtist tombstones a cached artist without touching its albums.
+func (s *PostgresStore) DeleteArtist(ctx context.Context, id string) (bool, error) {
+	if strings.TrimSpace(id) == "" {
+		return false, errors.New("db: artist id required")
+	}
+	result, err := s.db.ExecContext(ctx, `UPDATE artists SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`, time.Now().UTC(), id)
+	if err != nil {
+		return false, fmt.Errorf("db: delete artist: %w", err)
+	}
+	return tombstoned(result), nil
+}
+
+// DeleteAlbum tombstones a cached album. Leaving deleted_by empty keeps RestoreArtist from
+// bringing it back.
+func (s *PostgresStore) DeleteAlbum(ctx context.Context, id string) (bool, error) {
+	if strings.TrimSpace(id) == "" {
+		return false, errors.New("db: album id required")
+	}
+	result, err := s.db.ExecContext(ctx, `UPDATE albums SET deleted_at = $1, deleted_by = NULL WHERE id = $2 AND deleted_at IS NULL`, time.Now().UTC(), id)
+	if err != nil {
+		return false, fmt.Errorf("db: delete album: %w", err)
+	}
+	return tombstoned(result), nil
+}
+
+// ListTombstones returns tombstones created at or after since, oldest first.
+func (s *PostgresStore) ListTombstones(ctx context.Context, since time.Time) ([]Tombstone, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT 'artist', id, deleted_at FROM artists WHERE deleted_at >= $1
+         UNION ALL
+         SELECT 'album', id, deleted_at FROM albums WHERE deleted_at >= $1`,
+		since.UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("db: query tombstones: %w", err)
+	}
+	defer rows.Close()
+
+	tombstones := make([]Tombstone, 0)
+	for rows.Next() {
+		var tombstone Tombstone
+		if err := rows.Scan(&tombstone.Kind, &tombstone.ID, &tombstone.DeletedAt); err != nil {
+			return nil, fmt.Errorf("db: scan tombstone: %w", err)
+		}
+		tombstone.DeletedAt = tombstone.DeletedAt.UTC()
+		tombstones = append(tombstones, tombstone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: iterate tombstones: %w", err)
+	}
+
+	sortTombstones(tombstones)
+	return tombstones, nil
+}
+
+// PurgeTombstones permanently removes records tombstoned before the cutoff.
+func (s *PostgresStore) PurgeTombstones(ctx context.Context, before time.Time) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("db: begin purge: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	albums, err := tx.ExecContext(ctx, `DELETE FROM albums WHERE deleted_at < $1`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("db: purge albums: %w", err)
+	}
+	artists, err := tx.ExecContext(ctx, `DELETE FROM artists WHERE deleted_at < $1`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("db: purge artists: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("db: commit purge: %w", err)
+	}
+
+	counts := invalidationCounts(artists, albums)
+	return counts.Artists + counts.Albums, nil
+}
+
+// ListArtists returns every live cached artist.
+func (s *PostgresStore) ListArtists(ctx context.Context) ([]data.Artist, error) {
+	payloads, err := queryPayloads(ctx, s.db, `SELECT payload FROM artists WHERE deleted_at IS NULL ORDER BY id COLLATE "C"`)
+	if err != nil {
+		return nil, fmt.Errorf("db: list artists: %w", err)
+	}
+	return decodeArtists(payloads)
+}
+
+// ListAlbums returns every live cached album.
+func (s *PostgresStore) ListAlbums(ctx context.Context) ([]data.Album, error) {
+	payloads, err := queryPayloads(ctx, s.db, `SELECT payload FROM albums WHERE deleted_at IS NULL ORDER BY id COLLATE "C"`)
+	if err != nil {
+		return nil, fmt.Errorf("db: list albums: %w", err)
+	}
+	return decodeAlbums(payloads)
+}
+
+// MergeArtists folds the fromID artist into intoID in one transaction.
+func (s *PostgresStore) MergeArtists(ctx context.Context, fromID, intoID string) (MergeResult, error) {
+	if err := validateMerge(fromID, intoID); err != nil {
+		return MergeResult{}, err
+	}
+
+	result := MergeResult{Kind: KindArtist, FromID: fromID, IntoID: intoID}
+	err := s.withMergeTx(ctx, func(tx *sql.Tx, repos postgresRepos) error {
+		from, err := repos.GetArtist(ctx, fromID)
+		if err != nil {
+			return err
+		}
+		into, err := repos.GetArtist(ctx, intoID)
+		if err != nil {
+			return err
+		}
+		if from == nil || into == nil {
+			return ErrNotCached
+		}
+
+		consolidateArtist(into, from)
+		if err := repos.SaveArtist(ctx, into); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM artists WHERE id = $1`, fromID); err != nil {
+			return fmt.Errorf("db: delete artist: %w", err)
+		}
+
+		// MBIDs are unique strings, so a substring match narrows the rows to decode.
+		payloads, err := queryPayloads(ctx, tx, `SELECT payload FROM albums WHERE deleted_at IS NULL AND strpos(payload::text, $1) > 0`, fromID)
+		if err != nil {
+			return fmt.Errorf("db: query credited albums: %w", err)
+		}
+		for _, payload := range payloads {
+			var album data.Album
+			if _, err := albumSchema.decode([]byte(payload), &album); err != nil {
+				return fmt.Errorf("db: decode album: %w", err)
+			}
+			if !repointAlbumArtist(&album, fromID, intoID) {
+				continue
+			}
+			if err := repos.SaveAlbum(ctx, &album); err != nil {
+				return err
+			}
+			result.Albums++
+		}
+
+		payloads, err = queryPayloads(ctx, tx, `SELECT payload FROM owned_albums WHERE payload->>'artistId' = $1`, fromID)
+		if err != nil {
+			return fmt.Errorf("db: query owned albums: %w", err)
+		}
+		for _, payload := range payloads {
+			var owned data.OwnedAlbum
+			if err := json.Unmarshal([]byte(payload), &owned); err != nil {
+				return fmt.Errorf("db: decode owned album: %w", err)
+			}
+			owned.ArtistID = intoID
+			if err := savePostgresOwnedAlbum(ctx, tx, &owned); err != nil {
+				return err
+			}
+			result.OwnedAlbums++
+		}
+
+		return repointPostgresAliases(ctx, tx, repos, KindArtist, fromID, intoID)
+	})
+	if err != nil {
+		return MergeResult{}, err
+	}
+	return result, nil
+}
+
+// MergeAlbums folds the fromID album into intoID in one transaction.
+func (s *PostgresStore) MergeAlbums(ctx context.Context, fromID, intoID string) (MergeResult, error) {
+	if err := validateMerge(fromID, intoID); err != nil {
+		return MergeResult{}, err
+	}
+
+	result := MergeResult{Kind: KindAlbum, FromID: fromID, IntoID: intoID}
+	err := s.withMergeTx(ctx, func(tx *sql.Tx, repos postgresRepos) error {
+		from, err := repos.GetAlbum(ctx, fromID)
+		if err != nil {
+			return err
+		}
+		into, err := repos.GetAlbum(ctx, intoID)
+		if err != nil {
+			return err
+		}
+		if from == nil || into == nil {
+			return ErrNotCached
+		}
+
+		artistIDs := albumArtistIDs(from, into)
+		consolidateAlbum(into, from)
+		if err := repos.SaveAlbum(ctx, into); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM albums WHERE id = $1`, fromID); err != nil {
+			return fmt.Errorf("db: delete album: %w", err)
+		}
+
+		for _, artistID := range artistIDs {
+			artist, err := repos.GetArtist(ctx, artistID)
+			if err != nil {
+				return err
+			}
+			if artist == nil || !repointDiscography(artist, fromID, intoID) {
+				continue
+			}
+			if err := repos.SaveArtist(ctx, artist); err != nil {
+				return err
+			}
+			result.Artists++
+		}
+
+		payloads, err := queryPayloads(ctx, tx, `DELETE FROM owned_albums WHERE id = $1 RETURNING payload`, fromID)
+		if err != nil {
+			return fmt.Errorf("db: delete owned album: %w", err)
+		}
+		if len(payloads) > 0 {
+			var owned data.OwnedAlbum
+			if err := json.Unmarshal([]byte(payloads[0]), &owned); err != nil {
+				return fmt.Errorf("db: decode owned album: %w", err)
+			}
+			existing, err := queryPayloads(ctx, tx, `SELECT payload FROM owned_albums WHERE id = $1`, intoID)
+			if err != nil {
+				return fmt.Errorf("db: query owned album: %w", err)
+			}
+			if len(existing) == 0 {
+				owned.AlbumID = intoID
+				if err := savePostgresOwnedAlbum(ctx, tx, &owned); err != nil {
+					return err
+				}
+			}
+			result.OwnedAlbums++
+		}
+
+		return repointPostgresAliases(ctx, tx, repos, KindAlbum, fromID, intoID)
+	})
+	if err != nil {
+		return MergeResult{}, err
+	}
+	return result, nil
+}
+
+func (s *PostgresStore) withMergeTx(ctx context.Context, fn func(*sql.Tx, postgresRepos) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db: begin merge: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(tx, postgresRepos{q: tx}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("db: commit merge: %w", err)
+	}
+	return nil
+}
+
+// WithTx runs fn against repositories bound to one transaction, committing only if fn succeeds.
+func (s *PostgresStore) WithTx(ctx context.Context, fn func(Repos) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(postgresRepos{q: tx}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("db: commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ArtistModified returns the live artist row's updated_at.
+func (s *PostgresStore) ArtistModified(ctx context.Context, id string) (time.Time, error) {
+	return postgresUpdatedAt(ctx, s.db, `SELECT updated_at FROM artists WHERE id = $1 AND deleted_at IS NULL`, id)
+}
+
+// AlbumModified returns the live album row's updated_at.
+func (s *PostgresStore) AlbumModified(ctx context.Context, id string) (time.Time, error) {
+	return postgresUpdatedAt(ctx, s.db, `SELECT updated_at FROM albums WHERE id = $1 AND deleted_at IS NULL`, id)
+}
+
+// LabelModified returns the label row's updated_at.
+func (s *PostgresStore) LabelModified(ctx context.Context, id string) (time.Time, error) {
+	return postgresUpdatedAt(ctx, s.db, `SELECT updated_at FROM labels WHERE id = $1`, id)
+}
+
+func postgresUpdatedAt(ctx context.Context, q sqlQuerier, query, id string) (time.Time, error) {
+	var updated time.Time
+	if err := q.QueryRowContext(ctx, query, id).Scan(&updated); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("db: query updated_at: %w", err)
+	}
+	return updated.UTC(), nil
+}
+
+// postgresRepos implements the entity repositories against the database or an open transaction.
+type postgresRepos struct {
+	q sqlQuerier
+}
+
+// GetArtist retrieves an artist by ID if present.
+func (r postgresRepos) GetArtist(ctx context.Context, id string) (*data.Artist, error) {
+	row := r.q.QueryRowContext(ctx, `SELECT payload FROM artists WHERE id = $1 AND deleted_at IS NULL`, id)
+
+	var payload string
+	if err := row.Scan(&payload); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("db: query artist: %w", err)
+	}
+
+	var artist data.Artist
+	upgraded, err := artistSchema.decode([]byte(payload), &artist)
+	if err != nil {
+		return nil, fmt.Errorf("db: decode artist: %w", err)
+	}
+	if upgraded {
+		rewritePostgresPayload(ctx, r.q, "artists", id, artistSchema, &artist)
+	}
+
+	return &artist, nil
+}
+
+// SaveArtist upserts an artist record along with its search vector. A refresh that produced the
+// same content leaves the row and its updated_at untouched.
+func (r postgresRepos) SaveArtist(ctx context.Context, artist *data.Artist) error {
+	if err := validateArtist(artist); err != nil {
+		return err
+	}
+
+	payload, err := artistSchema.encode(artist)
+	if err != nil {
+		return fmt.Errorf("db: encode artist: %w", err)
+	}
+
+	_, err = r.q.ExecContext(
+		ctx,
+		`INSERT INTO artists (id, payload, updated_at, country, type, content_hash, search)
+         VALUES ($1, $2, $3, $4, $5, $6,
+             setweight(to_tsvector('simple', $7::text), 'A') ||
+             setweight(to_tsvector('simple', $8::text), 'B') ||
+             setweight(to_tsvector('simple', $9::text), 'C'))
+         ON CONFLICT (id) DO UPDATE SET payload = excluded.payload, updated_at = excluded.updated_at,
+             country = excluded.country, type = excluded.type, content_hash = excluded.content_hash,
+             search = excluded.search, deleted_at = NULL
+         WHERE artists.content_hash IS DISTINCT FROM excluded.content_hash OR artists.deleted_at IS NOT NULL`,
+		artist.ID,
+		string(payload),
+		time.Now().UTC(),
+		normalizeCountry(artist.Country),
+		normalizeArtistType(artist.Type),
+		contentHash(payload),
+		artist.Name,
+		strings.Join(artist.Aliases, " | "),
+		artist.Disambiguation,
+	)
+	if err != nil {
+		return fmt.Errorf("db: upsert artist: %w", err)
+	}
+	return nil
+}
+
+// GetAlbum retrieves an album by ID if present.
+func (r postgresRepos) GetAlbum(ctx context.Context, id string) (*data.Album, error) {
+	row := r.q.QueryRowContext(ctx, `SELECT payload FROM albums WHERE id = $1 AND deleted_at IS NULL`, id)
+
+	var payload string
+	if err := row.Scan(&payload); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("db: query album: %w", err)
+	}
+
+	var album data.Album
+	upgraded, err := albumSchema.decode([]byte(payload), &album)
+	if err != nil {
+		return nil, fmt.Errorf("db: decode album: %w", err)
+	}
+	if upgraded {
+		rewritePostgresPayload(ctx, r.q, "albums", id, albumSchema, &album)
+	}
+
+	return &album, nil
+}
+
+// SaveAlbum upserts an album record in the database.
+func (r postgresRepos) SaveAlbum(ctx context.Context, album *data.Album) error {
+	if err := validateAlbum(album); err != nil {
+		return err
+	}
+
+	payload, err := albumSchema.encode(album)
+	if err != nil {
+		return fmt.Errorf("db: encode album: %w", err)
+	}
+
+	_, err = r.q.ExecContext(
+		ctx,
+		`INSERT INTO albums (id, payload, updated_at, year, genre, content_hash)
+         VALUES ($1, $2, $3, $4, $5, $6)
+         ON CONFLICT (id) DO UPDATE SET payload = excluded.payload, updated_at = excluded.updated_at,
+             year = excluded.year, genre = excluded.genre, content_hash = excluded.content_hash,
+             deleted_at = NULL, deleted_by = NULL
+         WHERE albums.content_hash IS DISTINCT FROM excluded.content_hash OR albums.deleted_at IS NOT NULL`,
+		album.ID,
+		string(payload),
+		time.Now().UTC(),
+		album.Year,
+		normalizeGenre(album.Genre),
+		contentHash(payload),
+	)
+	if err != nil {
+		return fmt.Errorf("db: upsert album: %w", err)
+	}
+	return nil
+}
+
+// SaveAlias records that id was merged into canonicalID.
+func (r postgresRepos) SaveAlias(ctx context.Context, kind, id, canonicalID string) error {
+	if err := validateAlias(kind, id, canonicalID); err != nil {
+		return err
+	}
+
+	_, err := r.q.ExecContext(
+		ctx,
+		`INSERT INTO mbid_aliases (kind, id, canonical_id, updated_at)
+         VALUES ($1, $2, $3, $4)
+         ON CONFLICT (kind, id) DO UPDATE SET canonical_id = excluded.canonical_id, updated_at = excluded.updated_at`,
+		kind,
+		id,
+		canonicalID,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("db: upsert alias: %w", err)
+	}
+	return nil
+}
+
+// ResolveAlias returns the canonical MBID for id, or "" when id is not a known alias.
+func (r postgresRepos) ResolveAlias(ctx context.Context, kind, id string) (string, error) {
+	row := r.q.QueryRowContext(ctx, `SELECT canonical_id FROM mbid_aliases WHERE kind = $1 AND id = $2`, kind, id)
+
+	var canonicalID string
+	if err := row.Scan(&canonicalID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("db: query alias: %w", err)
+	}
+	return canonicalID, nil
+}
+
+// repointPostgresAliases records fromID as an alias of intoID and updates aliases that pointed
+// at fromID, so lookups never follow a chain.
+func repointPostgresAliases(ctx context.Context, tx *sql.Tx, repos postgresRepos, kind, fromID, intoID string) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE mbid_aliases SET canonical_id = $1, updated_at = $2 WHERE kind = $3 AND canonical_id = $4`, intoID, time.Now().UTC(), kind, fromID); err != nil {
+		return fmt.Errorf("db: repoint aliases: %w", err)
+	}
+	return repos.SaveAlias(ctx, kind, fromID, intoID)
+}
+
+// rewritePostgresPayload is rewritePayload for PostgreSQL placeholders.
+func rewritePostgresPayload(ctx context.Context, q sqlQuerier, table, id string, schema payloadSchema, v any) {
+	payload, err := schema.encode(v)
+	if err != nil {
+		return
+	}
+	_, _ = q.ExecContext(ctx, `UPDATE `+table+` SET payload = $1, content_hash = $2 WHERE id = $3`, string(payload), contentHash(payload), id)
+}
+
+func savePostgresOwnedAlbum(ctx context.Context, q sqlQuerier, owned *data.OwnedAlbum) error {
+	payload, err := json.Marshal(owned)
+	if err != nil {
+		return fmt.Errorf("db: encode owned album: %w", err)
+	}
+
+	_, err = q.ExecContext(
+		ctx,
+		`INSERT INTO owned_albums (id, payload, updated_at)
+         VALUES ($1, $2, $3)
+         ON CONFLICT (id) DO UPDATE SET payload = excluded.payload, updated_at = excluded.updated_at`,
+		owned.AlbumID,
+		string(payload),
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("db: upsert owned album: %w", err)
+	}
+	return nil
+}
+
+func decodeArtists(payloads []string) ([]data.Artist, error) {
+	artists := make([]data.Artist, len(payloads))
+	for i, payload := range payloads {
+		if _, err := artistSchema.decode([]byte(payload), &artists[i]); err != nil {
+			return nil, fmt.Errorf("db: decode artist: %w", err)
+		}
+	}
+	return artists, nil
+}
+
+func decodeAlbumPointers(payloads []string) ([]*data.Album, error) {
+	albums := make([]*data.Album, len(payloads))
+	for i, payload := range payloads {
+		albums[i] = &data.Album{}
+		if _, err := albumSchema.decode([]byte(payload), albums[i]); err != nil {
+			return nil, fmt.Errorf("db: decode album: %w", err)
+		}
+	}
+	return albums, nil
+}
+
+func decodeAlbums(payloads []string) ([]data.Album, error) {
+	albums := make([]data.Album, len(payloads))
+	for i, payload := range payloads {
+		if _, err := albumSchema.decode([]byte(payload), &albums[i]); err != nil {
+			return nil, fmt.Errorf("db: decode album: %w", err)
+		}
+	}
+	return albums, nil
+}
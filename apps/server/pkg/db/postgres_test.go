@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// postgresTestURLEnv names a disposable PostgreSQL database for the store tests. They are
+// skipped when it is unset, and every table in it is emptied between subtests.
+const postgresTestURLEnv = "FREQSHOW_TEST_POSTGRES_URL"
+
+func TestPostgresStore(t *testing.T) {
+	dsn := os.Getenv(postgresTestURLEnv)
+	if dsn == "" {
+		t.Skipf("set %s to run PostgreSQL store tests", postgresTestURLEnv)
+	}
+
+	ctx := context.Background()
+	store, err := NewPostgresStore(ctx, dsn, PoolConfig{MaxOpenConns: 4})
+	if err != nil {
+		t.Fatalf("NewPostgresStore returned error: %v", err)
+	}
+	defer func() {
+		if err := store.Close(ctx); err != nil {
+			t.Fatalf("Close returned error: %v", err)
+		}
+	}()
+
+	// Migrations must be safe to run again against an existing schema.
+	if err := store.migrate(ctx); err != nil {
+		t.Fatalf("repeated migrate returned error: %v", err)
+	}
+
+	run := func(name string, fn func(t *testing.T)) {
+		t.Run(name, func(t *testing.T) {
+			if _, err := store.db.ExecContext(ctx, `TRUNCATE artists, albums, labels, playlists, owned_albums, mbid_aliases`); err != nil {
+				t.Fatalf("truncate: %v", err)
+			}
+			fn(t)
+		})
+	}
+
+	run("save and get", func(t *testing.T) {
+		artist := &data.Artist{ID: "artist-1", Name: "Artist", Aliases: []string{"Alias"}}
+		if err := store.SaveArtist(ctx, artist); err != nil {
+			t.Fatalf("SaveArtist returned error: %v", err)
+		}
+		got, err := store.GetArtist(ctx, "artist-1")
+		if err != nil || got == nil || got.Name != "Artist" || len(got.Aliases) != 1 {
+			t.Fatalf("unexpected artist %+v (%v)", got, err)
+		}
+		if missing, err := store.GetArtist(ctx, "missing"); err != nil || missing != nil {
+			t.Fatalf("expected a miss, got %+v (%v)", missing, err)
+		}
+
+		label := &data.Label{ID: "label-1", Name: "Label"}
+		if err := store.SaveLabel(ctx, label); err != nil {
+			t.Fatalf("SaveLabel returned error: %v", err)
+		}
+		if got, err := store.GetLabel(ctx, "label-1"); err != nil || got == nil || got.Name != "Label" {
+			t.Fatalf("unexpected label %+v (%v)", got, err)
+		}
+	})
+
+	run("batch album reads", func(t *testing.T) {
+		for _, id := range []string{"album-1", "album-2"} {
+			if err := store.SaveAlbum(ctx, &data.Album{ID: id, Title: id, ArtistID: "artist-1"}); err != nil {
+				t.Fatalf("SaveAlbum returned error: %v", err)
+			}
+		}
+		if _, err := store.DeleteAlbum(ctx, "album-2"); err != nil {
+			t.Fatalf("DeleteAlbum returned error: %v", err)
+		}
+		albums, err := store.GetAlbums(ctx, []string{"album-1", "album-2", "missing"})
+		if err != nil || len(albums) != 1 || albums[0].ID != "album-1" {
+			t.Fatalf("expected only the live album, got %+v (%v)", albums, err)
+		}
+	})
+
+	run("unchanged saves keep updated_at", func(t *testing.T) {
+		album := &data.Album{ID: "album-1", Title: "Album", ArtistID: "artist-1"}
+		if err := store.SaveAlbum(ctx, album); err != nil {
+			t.Fatalf("SaveAlbum returned error: %v", err)
+		}
+		first, err := store.AlbumModified(ctx, "album-1")
+		if err != nil || first.IsZero() {
+			t.Fatalf("expected an updated_at, got %v (%v)", first, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+		if err := store.SaveAlbum(ctx, album); err != nil {
+			t.Fatalf("SaveAlbum returned error: %v", err)
+		}
+		if again, _ := store.AlbumModified(ctx, "album-1"); !again.Equal(first) {
+			t.Fatalf("expected unchanged save to keep %v, got %v", first, again)
+		}
+	})
+
+	run("search", func(t *testing.T) {
+		seedSearchArtists(t, store)
+		assertArtistNames(t, store)
+		assertSearchFinds(t, store, "The Smashing Pumpkins", "pumpkins")
+		assertSearchFinds(t, store, "smashing pump", "pumpkins")
+		assertSearchFinds(t, store, "nirvana")
+	})
+
+	run("browse albums", func(t *testing.T) {
+		seedBrowseAlbums(t, store)
+		assertBrowseFilters(t, store)
+		assertBrowseTypes(t, store)
+	})
+
+	run("browse artists", func(t *testing.T) {
+		seedBrowseArtists(t, store)
+		assertArtistBrowseFilters(t, store)
+	})
+
+	run("duplicates and merge", func(t *testing.T) {
+		seedDuplicates(t, store)
+		assertDuplicatesAndMerge(t, store)
+	})
+
+	run("tombstones", func(t *testing.T) {
+		exerciseTombstones(t, store)
+	})
+
+	run("transactions", func(t *testing.T) {
+		exerciseWithTx(t, store)
+	})
+}
@@ -0,0 +1,184 @@
+package db
+
+import (
+	"context"
+	"math"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// Completeness fields reported by the quality report.
+const (
+	QualityBiography = "biography"
+	QualityImage     = "image"
+	QualityGenres    = "genres"
+	QualityAlbums    = "albums"
+	QualityCover     = "cover"
+	QualityTracks    = "tracks"
+	QualityReviews   = "reviews"
+)
+
+type artistCheck struct {
+	field   string
+	missing func(*data.Artist) bool
+}
+
+type albumCheck struct {
+	field   string
+	missing func(*data.Album) bool
+}
+
+var artistChecks = []artistCheck{
+	{QualityBiography, func(a *data.Artist) bool { return a.Biography == "" }},
+	{QualityImage, func(a *data.Artist) bool { return len(a.Images) == 0 }},
+	{QualityGenres, func(a *data.Artist) bool { return len(a.Genres) == 0 }},
+	{QualityAlbums, func(a *data.Artist) bool { return len(a.Albums) == 0 }},
+}
+
+var albumChecks = []albumCheck{
+	{QualityCover, func(a *data.Album) bool { return len(a.Images) == 0 }},
+	{QualityTracks, func(a *data.Album) bool { return len(a.Tracks) == 0 }},
+	{QualityReviews, func(a *data.Album) bool { return len(a.Reviews) == 0 }},
+	{QualityGenres, func(a *data.Album) bool { return a.Genre == "" }},
+}
+
+// FieldQuality counts cached records missing one field.
+type FieldQuality struct {
+	Field   string  `json:"field"`
+	Missing int     `json:"missing"`
+	Percent float64 `json:"percent"`
+}
+
+// EntityQuality summarizes completeness for one kind of record.
+type EntityQuality struct {
+	Total  int            `json:"total"`
+	Fields []FieldQuality `json:"fields"`
+}
+
+// QualityReport summarizes how complete the cached artists and albums are.
+type QualityReport struct {
+	Artists EntityQuality `json:"artists"`
+	Albums  EntityQuality `json:"albums"`
+}
+
+// IncompleteRecord is one cached record missing at least one reported field.
+type IncompleteRecord struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Missing []string `json:"missing"`
+}
+
+// QualityFields lists the fields checked for kind, or nil for an unknown kind.
+func QualityFields(kind string) []string {
+	var fields []string
+	switch kind {
+	case KindArtist:
+		for _, check := range artistChecks {
+			fields = append(fields, check.field)
+		}
+	case KindAlbum:
+		for _, check := range albumChecks {
+			fields = append(fields, check.field)
+		}
+	}
+	return fields
+}
+
+// BuildQualityReport counts, per field, the cached records that lack it.
+func BuildQualityReport(ctx context.Context, lister RecordLister) (QualityReport, error) {
+	artists, err := lister.ListArtists(ctx)
+	if err != nil {
+		return QualityReport{}, err
+	}
+	albums, err := lister.ListAlbums(ctx)
+	if err != nil {
+		return QualityReport{}, err
+	}
+	return QualityReport{
+		Artists: summarizeQuality(len(artists), QualityFields(KindArtist), incompleteArtists(artists, "")),
+		Albums:  summarizeQuality(len(albums), QualityFields(KindAlbum), incompleteAlbums(albums, "")),
+	}, nil
+}
+
+// IncompleteRecords lists cached records of kind missing field, or missing any checked field
+// when field is empty. Records come back in the lister's order.
+func IncompleteRecords(ctx context.Context, lister RecordLister, kind, field string) ([]IncompleteRecord, error) {
+	switch kind {
+	case KindArtist:
+		artists, err := lister.ListArtists(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return incompleteArtists(artists, field), nil
+	case KindAlbum:
+		albums, err := lister.ListAlbums(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return incompleteAlbums(albums, field), nil
+	}
+	return nil, nil
+}
+
+func incompleteArtists(artists []data.Artist, field string) []IncompleteRecord {
+	var records []IncompleteRecord
+	for i := range artists {
+		var missing []string
+		for _, check := range artistChecks {
+			if check.missing(&artists[i]) {
+				missing = append(missing, check.field)
+			}
+		}
+		if reportsMissing(missing, field) {
+			records = append(records, IncompleteRecord{ID: artists[i].ID, Name: artists[i].Name, Missing: missing})
+		}
+	}
+	return records
+}
+
+func incompleteAlbums(albums []data.Album, field string) []IncompleteRecord {
+	var records []IncompleteRecord
+	for i := range albums {
+		var missing []string
+		for _, check := range albumChecks {
+			if check.missing(&albums[i]) {
+				missing = append(missing, check.field)
+			}
+		}
+		if reportsMissing(missing, field) {
+			records = append(records, IncompleteRecord{ID: albums[i].ID, Name: albums[i].Title, Missing: missing})
+		}
+	}
+	return records
+}
+
+func reportsMissing(missing []string, field string) bool {
+	if field == "" {
+		return len(missing) > 0
+	}
+	for _, m := range missing {
+		if m == field {
+			return true
+		}
+	}
+	return false
+}
+
+func summarizeQuality(total int, fields []string, records []IncompleteRecord) EntityQuality {
+	counts := make(map[string]int, len(fields))
+	for _, record := range records {
+		for _, field := range record.Missing {
+			counts[field]++
+		}
+	}
+
+	summary := EntityQuality{Total: total, Fields: make([]FieldQuality, 0, len(fields))}
+	for _, field := range fields {
+		quality := FieldQuality{Field: field, Missing: counts[field]}
+		if total > 0 {
+			quality.Percent = math.Round(float64(counts[field])*1000/float64(total)) / 10
+		}
+		summary.Fields = append(summary.Fields, quality)
+	}
+	return summary
+}
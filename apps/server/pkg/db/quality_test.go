@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+func TestBuildQualityReport(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewMemoryStore(ctx)
+	if err != nil {
+		t.Fatalf(newStoreErrFmt, err)
+	}
+	_ = store.SaveArtist(ctx, &data.Artist{ID: "a1", Name: "Complete", Biography: "bio", Genres: []string{"rock"}, Images: []data.Image{{URL: "x"}}, Albums: []data.Album{{ID: "b1"}}})
+	_ = store.SaveArtist(ctx, &data.Artist{ID: "a2", Name: "Sparse"})
+	_ = store.SaveAlbum(ctx, &data.Album{ID: "b1", Title: "Full", Genre: "rock", Tracks: []data.Track{{Number: 1}}, Images: []data.Image{{URL: "x"}}, Reviews: []data.Review{{Source: "x"}}})
+	_ = store.SaveAlbum(ctx, &data.Album{ID: "b2", Title: "No Tracks", Genre: "rock", Images: []data.Image{{URL: "x"}}, Reviews: []data.Review{{Source: "x"}}})
+	_ = store.SaveAlbum(ctx, &data.Album{ID: "b3", Title: "Bare"})
+
+	report, err := BuildQualityReport(ctx, store)
+	if err != nil {
+		t.Fatalf("BuildQualityReport returned error: %v", err)
+	}
+	if report.Artists.Total != 2 || report.Artists.Fields[0] != (FieldQuality{Field: QualityBiography, Missing: 1, Percent: 50}) {
+		t.Errorf("unexpected artist quality %+v", report.Artists)
+	}
+	if report.Albums.Total != 3 {
+		t.Fatalf("expected 3 albums, got %d", report.Albums.Total)
+	}
+	for _, field := range report.Albums.Fields {
+		if field.Field == QualityTracks && (field.Missing != 2 || field.Percent != 66.7) {
+			t.Errorf("unexpected tracks quality %+v", field)
+		}
+		if field.Field == QualityCover && field.Missing != 1 {
+			t.Errorf("unexpected cover quality %+v", field)
+		}
+	}
+
+	records, err := IncompleteRecords(ctx, store, KindAlbum, QualityTracks)
+	if err != nil {
+		t.Fatalf("IncompleteRecords returned error: %v", err)
+	}
+	if len(records) != 2 || records[0].ID != "b2" || len(records[0].Missing) != 1 || len(records[1].Missing) != 4 {
+		t.Errorf("unexpected incomplete albums %+v", records)
+	}
+}
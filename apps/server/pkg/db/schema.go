@@ -0,0 +1,104 @@
+package db
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// payloadMigration upgrades a decoded payload by one schema version in place.
+type payloadMigration func(payload map[string]any)
+
+// payloadSchema versions one kind of persisted JSON payload. migrations[i] upgrades a payload
+// from version i to i+1, so the current version is len(migrations). Model changes that old
+// blobs cannot simply decode into append a migration rather than invalidating the cache.
+type payloadSchema struct {
+	migrations []payloadMigration
+}
+
+var (
+	artistSchema = payloadSchema{migrations: []payloadMigration{migrateArtistImages}}
+	albumSchema  = payloadSchema{migrations: []payloadMigration{migrateAlbumImages}}
+	// Labels arrived with versioning; version 1 only adds the stamp.
+	labelSchema = payloadSchema{migrations: []payloadMigration{func(map[string]any) {}}}
+)
+
+func (s payloadSchema) version() int {
+	return len(s.migrations)
+}
+
+// encode marshals v with the current schemaVersion stamped first in the object.
+func (s payloadSchema) encode(v any) ([]byte, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(encoded) < 2 || encoded[0] != '{' {
+		return encoded, nil
+	}
+	stamp := `{"schemaVersion":` + strconv.Itoa(s.version())
+	if len(encoded) > 2 {
+		stamp += ","
+	}
+	return append([]byte(stamp), encoded[1:]...), nil
+}
+
+// decode unmarshals payload into v, first migrating it if it predates the current version.
+// It reports whether a migration ran so callers can persist the upgraded form. Payloads from a
+// newer version (after a rollback) decode as-is.
+func (s payloadSchema) decode(payload []byte, v any) (bool, error) {
+	var header struct {
+		SchemaVersion int `json:"schemaVersion"`
+	}
+	if err := json.Unmarshal(payload, &header); err != nil {
+		return false, err
+	}
+	if header.SchemaVersion >= s.version() || header.SchemaVersion < 0 {
+		return false, json.Unmarshal(payload, v)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return false, err
+	}
+	for _, migrate := range s.migrations[header.SchemaVersion:] {
+		migrate(raw)
+	}
+	raw["schemaVersion"] = s.version()
+
+	upgraded, err := json.Marshal(raw)
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(upgraded, v)
+}
+
+// migrateArtistImages is version 1: the single imageUrl (and each album's coverUrl) became an
+// images list.
+func migrateArtistImages(payload map[string]any) {
+	moveImageURL(payload, "imageUrl", data.ImageTypePhoto)
+	albums, _ := payload["albums"].([]any)
+	for _, album := range albums {
+		if album, ok := album.(map[string]any); ok {
+			migrateAlbumImages(album)
+		}
+	}
+}
+
+// migrateAlbumImages is version 1: the single coverUrl became an images list.
+func migrateAlbumImages(payload map[string]any) {
+	moveImageURL(payload, "coverUrl", data.ImageTypeFront)
+}
+
+func moveImageURL(payload map[string]any, key, imageType string) {
+	url, _ := payload[key].(string)
+	delete(payload, key)
+	if url == "" {
+		return
+	}
+	if images, _ := payload["images"].([]any); len(images) > 0 {
+		return
+	}
+	payload["images"] = []any{map[string]any{"type": imageType, "url": url}}
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+func TestPayloadSchemaStampsVersion(t *testing.T) {
+	encoded, err := artistSchema.encode(&data.Artist{ID: "a1", Name: "Nirvana"})
+	if err != nil {
+		t.Fatalf("encode returned error: %v", err)
+	}
+	if !strings.HasPrefix(string(encoded), `{"schemaVersion":1,"id":"a1"`) {
+		t.Fatalf("expected a version stamp, got %s", encoded)
+	}
+
+	var artist data.Artist
+	upgraded, err := artistSchema.decode(encoded, &artist)
+	if err != nil {
+		t.Fatalf("decode returned error: %v", err)
+	}
+	if upgraded || artist.Name != "Nirvana" {
+		t.Fatalf("expected a current payload to decode unchanged, got upgraded=%v %+v", upgraded, artist)
+	}
+}
+
+func TestPayloadSchemaMigratesLegacyImages(t *testing.T) {
+	legacy := `{"id":"a1","name":"Nirvana","imageUrl":"https://img/a1.jpg",
+		"albums":[{"id":"b1","title":"Bleach","coverUrl":"https://img/b1.jpg"}]}`
+
+	var artist data.Artist
+	upgraded, err := artistSchema.decode([]byte(legacy), &artist)
+	if err != nil {
+		t.Fatalf("decode returned error: %v", err)
+	}
+	if !upgraded {
+		t.Fatal("expected an unversioned payload to be upgraded")
+	}
+	if len(artist.Images) != 1 || artist.Images[0] != (data.Image{Type: data.ImageTypePhoto, URL: "https://img/a1.jpg"}) {
+		t.Errorf("expected imageUrl to become an image, got %+v", artist.Images)
+	}
+	if len(artist.Albums) != 1 || len(artist.Albums[0].Images) != 1 || artist.Albums[0].Images[0].Type != data.ImageTypeFront {
+		t.Errorf("expected nested coverUrl to become a front image, got %+v", artist.Albums)
+	}
+}
+
+func TestSQLiteStoreUpgradesPayloadOnRead(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dsn := "file:" + filepath.Join(dir, sqliteDBName) + sqliteQuerySuffix
+
+	ctx := context.Background()
+	store, err := NewSQLiteStore(ctx, dsn)
+	if err != nil {
+		t.Fatalf(sqliteNewErrFmt, err)
+	}
+	defer func() {
+		if err := store.Close(ctx); err != nil {
+			t.Fatalf(sqliteCloseErrFmt, err)
+		}
+	}()
+
+	legacy := `{"id":"b1","title":"Bleach","artistId":"a1","coverUrl":"https://img/b1.jpg","tracks":[],"reviews":[],"images":null}`
+	if _, err := store.db.ExecContext(ctx, `INSERT INTO albums (id, payload, updated_at) VALUES (?, ?, '2020-01-01 00:00:00')`, "b1", legacy); err != nil {
+		t.Fatalf("insert legacy payload: %v", err)
+	}
+
+	album, err := store.GetAlbum(ctx, "b1")
+	if err != nil {
+		t.Fatalf("GetAlbum returned error: %v", err)
+	}
+	if album == nil || len(album.Images) != 1 || album.Images[0].URL != "https://img/b1.jpg" {
+		t.Fatalf("expected the legacy cover to be migrated, got %+v", album)
+	}
+
+	var payload, updatedAt string
+	if err := store.db.QueryRowContext(ctx, `SELECT payload, updated_at FROM albums WHERE id = ?`, "b1").Scan(&payload, &updatedAt); err != nil {
+		t.Fatalf("query payload: %v", err)
+	}
+	var stored map[string]any
+	if err := json.Unmarshal([]byte(payload), &stored); err != nil {
+		t.Fatalf("decode stored payload: %v", err)
+	}
+	if stored["schemaVersion"] != float64(albumSchema.version()) || stored["coverUrl"] != nil {
+		t.Errorf("expected the upgraded payload to be persisted, got %s", payload)
+	}
+	if !strings.HasPrefix(updatedAt, "2020-01-01") {
+		t.Errorf("expected updated_at to be left alone, got %q", updatedAt)
+	}
+}
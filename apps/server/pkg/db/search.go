@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// ArtistSearcher finds cached artists by name, alias, or disambiguation without asking MusicBrainz.
+type ArtistSearcher interface {
+	SearchArtists(ctx context.Context, query string, limit int) ([]data.Artist, error)
+	// ArtistNames lists the names of every cached artist, for spelling suggestions.
+	ArtistNames(ctx context.Context) ([]string, error)
+}
+
+// searchTerms splits a query into lowercase word tokens. A leading article is dropped so
+// "The Smashing Pumpkins" and "Smashing Pumpkins" search alike.
+func searchTerms(query string) []string {
+	terms := tokenize(query)
+	if len(terms) > 1 && terms[0] == "the" {
+		terms = terms[1:]
+	}
+	return terms
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// artistSearchRank reports how well an artist matches every term as a word prefix: 0 when the
+// name alone matches, 1 when aliases or the disambiguation are needed, and -1 for no match.
+func artistSearchRank(artist *data.Artist, terms []string) int {
+	if len(terms) == 0 {
+		return -1
+	}
+	nameTokens := tokenize(artist.Name)
+	if matchesAllTerms(nameTokens, terms) {
+		return 0
+	}
+
+	tokens := nameTokens
+	for _, alias := range artist.Aliases {
+		tokens = append(tokens, tokenize(alias)...)
+	}
+	tokens = append(tokens, tokenize(artist.Disambiguation)...)
+	if matchesAllTerms(tokens, terms) {
+		return 1
+	}
+	return -1
+}
+
+func matchesAllTerms(tokens, terms []string) bool {
+	for _, term := range terms {
+		found := false
+		for _, token := range tokens {
+			if strings.HasPrefix(token, term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchArtists scans cached artists, ranking name matches ahead of alias matches.
+func (s *MemoryStore) SearchArtists(ctx context.Context, query string, limit int) ([]data.Artist, error) {
+	_ = ctx
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return []data.Artist{}, nil
+	}
+
+	type ranked struct {
+		artist *data.Artist
+		rank   int
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matches := make([]ranked, 0)
+	for _, artist := range s.artists {
+		if rank := artistSearchRank(artist, terms); rank >= 0 {
+			matches = append(matches, ranked{artist: artist, rank: rank})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return strings.ToLower(matches[i].artist.Name) < strings.ToLower(matches[j].artist.Name)
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	artists := make([]data.Artist, 0, len(matches))
+	for _, match := range matches {
+		artists = append(artists, *cloneArtist(match.artist))
+	}
+	return artists, nil
+}
+
+// ArtistNames lists the names of every cached artist.
+func (s *MemoryStore) ArtistNames(ctx context.Context) ([]string, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.artists))
+	for _, artist := range s.artists {
+		names = append(names, artist.Name)
+	}
+	return names, nil
+}
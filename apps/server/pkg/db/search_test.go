@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+func seedSearchArtists(t *testing.T, store Store) {
+	t.Helper()
+	artists := []*data.Artist{
+		{ID: "pumpkins", Name: "The Smashing Pumpkins", Aliases: []string{"Smashing Pumpkins"}},
+		{ID: "sigur-ros", Name: "Sigur Rós", Aliases: []string{"Sigur Ros"}, Disambiguation: "Icelandic post-rock band"},
+		{ID: "bjork", Name: "Björk", Aliases: []string{"Бьорк"}, Disambiguation: "Icelandic singer"},
+	}
+	for _, artist := range artists {
+		if err := store.SaveArtist(context.Background(), artist); err != nil {
+			t.Fatalf("SaveArtist returned error: %v", err)
+		}
+	}
+}
+
+func assertArtistNames(t *testing.T, store Store) {
+	t.Helper()
+	names, err := store.ArtistNames(context.Background())
+	if err != nil {
+		t.Fatalf("ArtistNames returned error: %v", err)
+	}
+	sort.Strings(names)
+	if want := []string{"Björk", "Sigur Rós", "The Smashing Pumpkins"}; !slices.Equal(names, want) {
+		t.Fatalf("ArtistNames = %v, want %v", names, want)
+	}
+}
+
+func assertSearchFinds(t *testing.T, store Store, query string, wantIDs ...string) {
+	t.Helper()
+	artists, err := store.SearchArtists(context.Background(), query, 10)
+	if err != nil {
+		t.Fatalf("SearchArtists(%q) returned error: %v", query, err)
+	}
+	if len(artists) != len(wantIDs) {
+		t.Fatalf("SearchArtists(%q) returned %d artists, want %d", query, len(artists), len(wantIDs))
+	}
+	for i, id := range wantIDs {
+		if artists[i].ID != id {
+			t.Fatalf("SearchArtists(%q)[%d] = %q, want %q", query, i, artists[i].ID, id)
+		}
+	}
+}
+
+func TestMemoryStoreSearchArtists(t *testing.T) {
+	store, err := NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf(newStoreErrFmt, err)
+	}
+	seedSearchArtists(t, store)
+	assertArtistNames(t, store)
+
+	assertSearchFinds(t, store, "The Smashing Pumpkins", "pumpkins")
+	assertSearchFinds(t, store, "smashing pump", "pumpkins")
+	assertSearchFinds(t, store, "Sigur Ros", "sigur-ros")
+	assertSearchFinds(t, store, "Бьорк", "bjork")
+	assertSearchFinds(t, store, "icelandic", "bjork", "sigur-ros")
+	assertSearchFinds(t, store, "nirvana")
+}
+
+func TestSQLiteStoreSearchArtists(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dsn := "file:" + filepath.Join(dir, sqliteDBName) + sqliteQuerySuffix
+
+	store, err := NewSQLiteStore(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf(sqliteNewErrFmt, err)
+	}
+	defer func() {
+		if err := store.Close(context.Background()); err != nil {
+			t.Fatalf(sqliteCloseErrFmt, err)
+		}
+	}()
+	seedSearchArtists(t, store)
+	assertArtistNames(t, store)
+
+	assertSearchFinds(t, store, "The Smashing Pumpkins", "pumpkins")
+	assertSearchFinds(t, store, "smashing pump", "pumpkins")
+	assertSearchFinds(t, store, "Sigur Rós", "sigur-ros")
+	assertSearchFinds(t, store, "Бьорк", "bjork")
+	assertSearchFinds(t, store, "nirvana")
+
+	// Re-saving replaces the indexed row rather than duplicating it.
+	if err := store.SaveArtist(context.Background(), &data.Artist{ID: "pumpkins", Name: "The Smashing Pumpkins"}); err != nil {
+		t.Fatalf("SaveArtist returned error: %v", err)
+	}
+	assertSearchFinds(t, store, "pumpkins", "pumpkins")
+}
@@ -2,7 +2,9 @@ package db
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -55,102 +57,581 @@ func (s *SQLiteStore) Close(ctx context.Context) error {
 
 // GetArtist retrieves an artist by ID if present.
 func (s *SQLiteStore) GetArtist(ctx context.Context, id string) (*data.Artist, error) {
-	row := s.db.QueryRowContext(ctx, `SELECT payload FROM artists WHERE id = ?`, id)
+	return sqliteRepos{q: s.db}.GetArtist(ctx, id)
+}
+
+// SaveArtist upserts an artist record in the database.
+func (s *SQLiteStore) SaveArtist(ctx context.Context, artist *data.Artist) error {
+	return s.WithTx(ctx, func(repos Repos) error {
+		return repos.SaveArtist(ctx, artist)
+	})
+}
+
+// ArtistNames lists the names of every live cached artist.
+func (s *SQLiteStore) ArtistNames(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT COALESCE(json_extract(payload, '$.name'), '') FROM artists WHERE deleted_at IS NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("db: list artist names: %w", err)
+	}
+	defer rows.Close()
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("db: scan artist name: %w", err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: iterate artist names: %w", err)
+	}
+	return names, nil
+}
+
+// SearchArtists queries the full-text index over cached artist names, aliases, and
+// disambiguation strings. Name hits outrank alias hits.
+func (s *SQLiteStore) SearchArtists(ctx context.Context, query string, limit int) ([]data.Artist, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return []data.Artist{}, nil
+	}
+	if limit <= 0 {
+		limit = 25
+	}
+
+	// Terms contain only letters and digits, so quoting them is enough to keep FTS syntax out.
+	match := make([]string, len(terms))
+	for i, term := range terms {
+		match[i] = `"` + term + `"*`
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT a.payload FROM artists_fts f
+         JOIN artists a ON a.id = f.id
+         WHERE artists_fts MATCH ? AND a.deleted_at IS NULL
+         ORDER BY bm25(artists_fts, 0, 10.0, 2.0, 1.0)
+         LIMIT ?`,
+		strings.Join(match, " "),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("db: search artists: %w", err)
+	}
+	defer rows.Close()
+
+	artists := make([]data.Artist, 0)
+	for rows.Next() {
+		var payload string
+		if err := rows.Scan(&payload); err != nil {
+			return nil, fmt.Errorf("db: scan artist: %w", err)
+		}
+		var artist data.Artist
+		if _, err := artistSchema.decode([]byte(payload), &artist); err != nil {
+			return nil, fmt.Errorf("db: decode artist: %w", err)
+		}
+		artists = append(artists, artist)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: iterate artists: %w", err)
+	}
+	return artists, nil
+}
+
+// BrowseAlbums lists cached albums matching filter using the indexed year and genre columns.
+func (s *SQLiteStore) BrowseAlbums(ctx context.Context, filter AlbumFilter) ([]data.Album, error) {
+	query := `SELECT payload FROM albums WHERE deleted_at IS NULL`
+	var args []any
+	if filter.FromYear > 0 {
+		query += ` AND year >= ?`
+		args = append(args, filter.FromYear)
+	}
+	if filter.ToYear > 0 {
+		query += ` AND year BETWEEN 1 AND ?`
+		args = append(args, filter.ToYear)
+	}
+	if genre := normalizeGenre(filter.Genre); genre != "" {
+		query += ` AND genre = ?`
+		args = append(args, genre)
+	}
+	if len(filter.Types) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(filter.Types)), ", ")
+		query += ` AND lower(COALESCE(json_extract(payload, '$.primaryType'), '')) IN (` + placeholders + `)
+            AND NOT EXISTS (SELECT 1 FROM json_each(payload, '$.secondaryTypes')
+                WHERE lower(value) NOT IN (` + placeholders + `))`
+		for range 2 {
+			for _, kind := range filter.Types {
+				args = append(args, kind)
+			}
+		}
+	}
+	offset := filter.Offset
+	if after := filter.After; after != nil {
+		query += ` AND (year, COALESCE(json_extract(payload, '$.title'), ''), id) > (?, ?, ?)`
+		args = append(args, after.Year, after.Title, after.ID)
+		offset = 0
+	}
+	query += ` ORDER BY year, COALESCE(json_extract(payload, '$.title'), ''), id LIMIT ? OFFSET ?`
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+	args = append(args, limit, offset)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("db: browse albums: %w", err)
+	}
+	defer rows.Close()
+
+	albums := make([]data.Album, 0)
+	for rows.Next() {
+		var payload string
+		if err := rows.Scan(&payload); err != nil {
+			return nil, fmt.Errorf("db: scan album: %w", err)
+		}
+		var album data.Album
+		if _, err := albumSchema.decode([]byte(payload), &album); err != nil {
+			return nil, fmt.Errorf("db: decode album: %w", err)
+		}
+		albums = append(albums, album)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: iterate albums: %w", err)
+	}
+	return albums, nil
+}
+
+// BrowseArtists lists cached artists matching filter using the indexed country and type columns.
+func (s *SQLiteStore) BrowseArtists(ctx context.Context, filter ArtistFilter) ([]data.Artist, error) {
+	query := `SELECT payload FROM artists WHERE deleted_at IS NULL`
+	var args []any
+	if country := normalizeCountry(filter.Country); country != "" {
+		query += ` AND country = ?`
+		args = append(args, country)
+	}
+	if artistType := normalizeArtistType(filter.Type); artistType != "" {
+		query += ` AND type = ?`
+		args = append(args, artistType)
+	}
+	offset := filter.Offset
+	if after := filter.After; after != nil {
+		query += ` AND (COALESCE(json_extract(payload, '$.name'), ''), id) > (?, ?)`
+		args = append(args, after.Name, after.ID)
+		offset = 0
+	}
+	query += ` ORDER BY COALESCE(json_extract(payload, '$.name'), ''), id LIMIT ? OFFSET ?`
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+	args = append(args, limit, offset)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("db: browse artists: %w", err)
+	}
+	defer rows.Close()
+
+	artists := make([]data.Artist, 0)
+	for rows.Next() {
+		var payload string
+		if err := rows.Scan(&payload); err != nil {
+			return nil, fmt.Errorf("db: scan artist: %w", err)
+		}
+		var artist data.Artist
+		if _, err := artistSchema.decode([]byte(payload), &artist); err != nil {
+			return nil, fmt.Errorf("db: decode artist: %w", err)
+		}
+		artists = append(artists, artist)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: iterate artists: %w", err)
+	}
+	return artists, nil
+}
+
+// GetAlbum retrieves an album by ID if present.
+func (s *SQLiteStore) GetAlbum(ctx context.Context, id string) (*data.Album, error) {
+	return sqliteRepos{q: s.db}.GetAlbum(ctx, id)
+}
+
+// GetAlbums retrieves the live cached albums among ids in one query.
+func (s *SQLiteStore) GetAlbums(ctx context.Context, ids []string) ([]*data.Album, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	payloads, err := queryPayloads(ctx, s.db, `SELECT payload FROM albums WHERE id IN (`+placeholders+`) AND deleted_at IS NULL`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("db: query albums: %w", err)
+	}
+	return decodeAlbumPointers(payloads)
+}
+
+// SaveAlbum upserts an album record in the database.
+func (s *SQLiteStore) SaveAlbum(ctx context.Context, album *data.Album) error {
+	return sqliteRepos{q: s.db}.SaveAlbum(ctx, album)
+}
+
+// GetLabel retrieves a label by ID if present.
+func (s *SQLiteStore) GetLabel(ctx context.Context, id string) (*data.Label, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT payload FROM labels WHERE id = ?`, id)
 
 	var payload string
 	if err := row.Scan(&payload); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("db: query artist: %w", err)
+		return nil, fmt.Errorf("db: query label: %w", err)
 	}
 
-	var artist data.Artist
-	if err := json.Unmarshal([]byte(payload), &artist); err != nil {
-		return nil, fmt.Errorf("db: decode artist: %w", err)
+	var label data.Label
+	upgraded, err := labelSchema.decode([]byte(payload), &label)
+	if err != nil {
+		return nil, fmt.Errorf("db: decode label: %w", err)
+	}
+	if upgraded {
+		rewritePayload(ctx, s.db, "labels", id, labelSchema, &label)
 	}
 
-	return &artist, nil
+	return &label, nil
 }
 
-// SaveArtist upserts an artist record in the database.
-func (s *SQLiteStore) SaveArtist(ctx context.Context, artist *data.Artist) error {
-	if artist == nil {
-		return errors.New("db: artist cannot be nil")
-	}
-	if strings.TrimSpace(artist.ID) == "" {
-		return errors.New("db: artist id required")
+// SaveLabel upserts a label record in the database.
+func (s *SQLiteStore) SaveLabel(ctx context.Context, label *data.Label) error {
+	if err := validateLabel(label); err != nil {
+		return err
 	}
 
-	payload, err := json.Marshal(artist)
+	payload, err := labelSchema.encode(label)
 	if err != nil {
-		return fmt.Errorf("db: encode artist: %w", err)
+		return fmt.Errorf("db: encode label: %w", err)
 	}
 
 	_, err = s.db.ExecContext(
 		ctx,
-		`INSERT INTO artists (id, payload, updated_at)
-         VALUES (?, ?, ?)
-         ON CONFLICT(id) DO UPDATE SET payload = excluded.payload, updated_at = excluded.updated_at`,
-		artist.ID,
+		`INSERT INTO labels (id, payload, updated_at, content_hash)
+         VALUES (?, ?, ?, ?)
+         ON CONFLICT(id) DO UPDATE SET payload = excluded.payload, updated_at = excluded.updated_at,
+             content_hash = excluded.content_hash
+         WHERE labels.content_hash IS NOT excluded.content_hash`,
+		label.ID,
 		string(payload),
 		time.Now().UTC(),
+		contentHash(payload),
 	)
 	if err != nil {
-		return fmt.Errorf("db: upsert artist: %w", err)
+		return fmt.Errorf("db: upsert label: %w", err)
 	}
 	return nil
 }
 
-// GetAlbum retrieves an album by ID if present.
-func (s *SQLiteStore) GetAlbum(ctx context.Context, id string) (*data.Album, error) {
-	row := s.db.QueryRowContext(ctx, `SELECT payload FROM albums WHERE id = ?`, id)
+// GetPlaylist retrieves a playlist by ID if present.
+func (s *SQLiteStore) GetPlaylist(ctx context.Context, id string) (*data.Playlist, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT payload FROM playlists WHERE id = ?`, id)
 
 	var payload string
 	if err := row.Scan(&payload); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("db: query album: %w", err)
+		return nil, fmt.Errorf("db: query playlist: %w", err)
 	}
 
-	var album data.Album
-	if err := json.Unmarshal([]byte(payload), &album); err != nil {
-		return nil, fmt.Errorf("db: decode album: %w", err)
+	var playlist data.Playlist
+	if err := json.Unmarshal([]byte(payload), &playlist); err != nil {
+		return nil, fmt.Errorf("db: decode playlist: %w", err)
 	}
 
-	return &album, nil
+	return &playlist, nil
 }
 
-// SaveAlbum upserts an album record in the database.
-func (s *SQLiteStore) SaveAlbum(ctx context.Context, album *data.Album) error {
-	if album == nil {
-		return errors.New("db: album cannot be nil")
+// SavePlaylist upserts a playlist record in the database.
+func (s *SQLiteStore) SavePlaylist(ctx context.Context, playlist *data.Playlist) error {
+	if playlist == nil {
+		return errors.New("db: playlist cannot be nil")
 	}
-	if strings.TrimSpace(album.ID) == "" {
-		return errors.New("db: album id required")
+	if strings.TrimSpace(playlist.ID) == "" {
+		return errors.New("db: playlist id required")
 	}
 
-	payload, err := json.Marshal(album)
+	payload, err := json.Marshal(playlist)
 	if err != nil {
-		return fmt.Errorf("db: encode album: %w", err)
+		return fmt.Errorf("db: encode playlist: %w", err)
 	}
 
 	_, err = s.db.ExecContext(
 		ctx,
-		`INSERT INTO albums (id, payload, updated_at)
+		`INSERT INTO playlists (id, payload, updated_at)
          VALUES (?, ?, ?)
          ON CONFLICT(id) DO UPDATE SET payload = excluded.payload, updated_at = excluded.updated_at`,
-		album.ID,
+		playlist.ID,
 		string(payload),
 		time.Now().UTC(),
 	)
 	if err != nil {
-		return fmt.Errorf("db: upsert album: %w", err)
+		return fmt.Errorf("db: upsert playlist: %w", err)
 	}
 	return nil
 }
 
+// ListPlaylists returns every stored playlist sorted by name.
+func (s *SQLiteStore) ListPlaylists(ctx context.Context) ([]data.Playlist, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT payload FROM playlists`)
+	if err != nil {
+		return nil, fmt.Errorf("db: query playlists: %w", err)
+	}
+	defer rows.Close()
+
+	playlists := make([]data.Playlist, 0)
+	for rows.Next() {
+		var payload string
+		if err := rows.Scan(&payload); err != nil {
+			return nil, fmt.Errorf("db: scan playlist: %w", err)
+		}
+		var playlist data.Playlist
+		if err := json.Unmarshal([]byte(payload), &playlist); err != nil {
+			return nil, fmt.Errorf("db: decode playlist: %w", err)
+		}
+		playlists = append(playlists, playlist)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: iterate playlists: %w", err)
+	}
+
+	sortPlaylists(playlists)
+	return playlists, nil
+}
+
+// MarkAlbumOwned upserts an owned-album record.
+func (s *SQLiteStore) MarkAlbumOwned(ctx context.Context, owned *data.OwnedAlbum) error {
+	if owned == nil {
+		return errors.New("db: owned album cannot be nil")
+	}
+	if strings.TrimSpace(owned.AlbumID) == "" {
+		return errors.New("db: owned album id required")
+	}
+	return saveOwnedAlbum(ctx, s.db, owned)
+}
+
+// ListOwnedAlbums returns every owned album sorted by artist then title.
+func (s *SQLiteStore) ListOwnedAlbums(ctx context.Context) ([]data.OwnedAlbum, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT payload FROM owned_albums`)
+	if err != nil {
+		return nil, fmt.Errorf("db: query owned albums: %w", err)
+	}
+	defer rows.Close()
+
+	owned := make([]data.OwnedAlbum, 0)
+	for rows.Next() {
+		var payload string
+		if err := rows.Scan(&payload); err != nil {
+			return nil, fmt.Errorf("db: scan owned album: %w", err)
+		}
+		var album data.OwnedAlbum
+		if err := json.Unmarshal([]byte(payload), &album); err != nil {
+			return nil, fmt.Errorf("db: decode owned album: %w", err)
+		}
+		owned = append(owned, album)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: iterate owned albums: %w", err)
+	}
+
+	sortOwnedAlbums(owned)
+	return owned, nil
+}
+
+// SaveAlias records that id was merged into canonicalID.
+func (s *SQLiteStore) SaveAlias(ctx context.Context, kind, id, canonicalID string) error {
+	return sqliteRepos{q: s.db}.SaveAlias(ctx, kind, id, canonicalID)
+}
+
+// ResolveAlias returns the canonical MBID for id, or "" when id is not a known alias.
+func (s *SQLiteStore) ResolveAlias(ctx context.Context, kind, id string) (string, error) {
+	return sqliteRepos{q: s.db}.ResolveAlias(ctx, kind, id)
+}
+
+// InvalidateArtist tombstones an artist and its cached albums in one transaction.
+func (s *SQLiteStore) InvalidateArtist(ctx context.Context, id string) (Invalidation, error) {
+	if strings.TrimSpace(id) == "" {
+		return Invalidation{}, errors.New("db: artist id required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("db: begin invalidation: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC().UnixMilli()
+	albums, err := tx.ExecContext(
+		ctx,
+		`UPDATE albums SET deleted_at = ?, deleted_by = ?
+         WHERE deleted_at IS NULL
+           AND (json_extract(payload, '$.artistId') = ?
+            OR id IN (
+                SELECT json_extract(album.value, '$.id')
+                FROM artists, json_each(artists.payload, '$.albums') AS album
+                WHERE artists.id = ?
+            ))`,
+		now,
+		id,
+		id,
+		id,
+	)
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("db: invalidate albums: %w", err)
+	}
+	artists, err := tx.ExecContext(ctx, `UPDATE artists SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`, now, id)
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("db: invalidate artist: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return Invalidation{}, fmt.Errorf("db: commit invalidation: %w", err)
+	}
+
+	return invalidationCounts(artists, albums), nil
+}
+
+// RestoreArtist clears the tombstones InvalidateArtist set for an artist and its albums.
+func (s *SQLiteStore) RestoreArtist(ctx context.Context, id string) (Invalidation, error) {
+	if strings.TrimSpace(id) == "" {
+		return Invalidation{}, errors.New("db: artist id required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("db: begin restore: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	albums, err := tx.ExecContext(ctx, `UPDATE albums SET deleted_at = NULL, deleted_by = NULL WHERE deleted_by = ?`, id)
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("db: restore albums: %w", err)
+	}
+	artists, err := tx.ExecContext(ctx, `UPDATE artists SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return Invalidation{}, fmt.Errorf("db: restore artist: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return Invalidation{}, fmt.Errorf("db: commit restore: %w", err)
+	}
+
+	return invalidationCounts(artists, albums), nil
+}
+
+// DeleteArtist tombstones a cached artist without touching its albums.
+func (s *SQLiteStore) DeleteArtist(ctx context.Context, id string) (bool, error) {
+	if strings.TrimSpace(id) == "" {
+		return false, errors.New("db: artist id required")
+	}
+	result, err := s.db.ExecContext(ctx, `UPDATE artists SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`, time.Now().UTC().UnixMilli(), id)
+	if err != nil {
+		return false, fmt.Errorf("db: delete artist: %w", err)
+	}
+	return tombstoned(result), nil
+}
+
+// DeleteAlbum tombstones a cached album. Leaving deleted_by empty keeps RestoreArtist from
+// bringing it back.
+func (s *SQLiteStore) DeleteAlbum(ctx context.Context, id string) (bool, error) {
+	if strings.TrimSpace(id) == "" {
+		return false, errors.New("db: album id required")
+	}
+	result, err := s.db.ExecContext(ctx, `UPDATE albums SET deleted_at = ?, deleted_by = NULL WHERE id = ? AND deleted_at IS NULL`, time.Now().UTC().UnixMilli(), id)
+	if err != nil {
+		return false, fmt.Errorf("db: delete album: %w", err)
+	}
+	return tombstoned(result), nil
+}
+
+// tombstoned reports whether a single-record tombstone update matched a live row.
+func tombstoned(result sql.Result) bool {
+	n, err := result.RowsAffected()
+	return err == nil && n > 0
+}
+
+func invalidationCounts(artists, albums sql.Result) Invalidation {
+	var result Invalidation
+	if n, err := albums.RowsAffected(); err == nil {
+		result.Albums = int(n)
+	}
+	if n, err := artists.RowsAffected(); err == nil {
+		result.Artists = int(n)
+	}
+	return result
+}
+
+// ListTombstones returns tombstones created at or after since, oldest first.
+func (s *SQLiteStore) ListTombstones(ctx context.Context, since time.Time) ([]Tombstone, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT 'artist', id, deleted_at FROM artists WHERE deleted_at >= ?
+         UNION ALL
+         SELECT 'album', id, deleted_at FROM albums WHERE deleted_at >= ?`,
+		since.UnixMilli(),
+		since.UnixMilli(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("db: query tombstones: %w", err)
+	}
+	defer rows.Close()
+
+	tombstones := make([]Tombstone, 0)
+	for rows.Next() {
+		var (
+			tombstone Tombstone
+			deletedAt int64
+		)
+		if err := rows.Scan(&tombstone.Kind, &tombstone.ID, &deletedAt); err != nil {
+			return nil, fmt.Errorf("db: scan tombstone: %w", err)
+		}
+		tombstone.DeletedAt = time.UnixMilli(deletedAt).UTC()
+		tombstones = append(tombstones, tombstone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: iterate tombstones: %w", err)
+	}
+
+	sortTombstones(tombstones)
+	return tombstones, nil
+}
+
+// PurgeTombstones permanently removes records tombstoned before the cutoff.
+func (s *SQLiteStore) PurgeTombstones(ctx context.Context, before time.Time) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("db: begin purge: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	cutoff := before.UnixMilli()
+	albums, err := tx.ExecContext(ctx, `DELETE FROM albums WHERE deleted_at < ?`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("db: purge albums: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM artists_fts WHERE id IN (SELECT id FROM artists WHERE deleted_at < ?)`, cutoff); err != nil {
+		return 0, fmt.Errorf("db: purge artist index: %w", err)
+	}
+	artists, err := tx.ExecContext(ctx, `DELETE FROM artists WHERE deleted_at < ?`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("db: purge artists: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("db: commit purge: %w", err)
+	}
+
+	counts := invalidationCounts(artists, albums)
+	return counts.Artists + counts.Albums, nil
+}
+
 func (s *SQLiteStore) migrate(ctx context.Context) error {
 	const createArtists = `CREATE TABLE IF NOT EXISTS artists (
         id TEXT PRIMARY KEY,
@@ -162,6 +643,31 @@ func (s *SQLiteStore) migrate(ctx context.Context) error {
 		return fmt.Errorf("db: migrate artists: %w", err)
 	}
 
+	const createArtistIndex = `CREATE VIRTUAL TABLE IF NOT EXISTS artists_fts USING fts5(
+        id UNINDEXED,
+        name,
+        aliases,
+        disambiguation,
+        tokenize = 'unicode61 remove_diacritics 2'
+    )`
+
+	if _, err := s.db.ExecContext(ctx, createArtistIndex); err != nil {
+		return fmt.Errorf("db: migrate artist index: %w", err)
+	}
+
+	// Index artists cached before the full-text table existed.
+	const backfillArtistIndex = `INSERT INTO artists_fts (id, name, aliases, disambiguation)
+        SELECT id,
+               COALESCE(json_extract(payload, '$.name'), ''),
+               COALESCE(json_extract(payload, '$.aliases'), ''),
+               COALESCE(json_extract(payload, '$.disambiguation'), '')
+        FROM artists
+        WHERE id NOT IN (SELECT id FROM artists_fts)`
+
+	if _, err := s.db.ExecContext(ctx, backfillArtistIndex); err != nil {
+		return fmt.Errorf("db: backfill artist index: %w", err)
+	}
+
 	const createAlbums = `CREATE TABLE IF NOT EXISTS albums (
         id TEXT PRIMARY KEY,
         payload TEXT NOT NULL,
@@ -171,5 +677,572 @@ func (s *SQLiteStore) migrate(ctx context.Context) error {
 	if _, err := s.db.ExecContext(ctx, createAlbums); err != nil {
 		return fmt.Errorf("db: migrate albums: %w", err)
 	}
+
+	const createLabels = `CREATE TABLE IF NOT EXISTS labels (
+        id TEXT PRIMARY KEY,
+        payload TEXT NOT NULL,
+        updated_at TIMESTAMP NOT NULL
+    )`
+
+	if _, err := s.db.ExecContext(ctx, createLabels); err != nil {
+		return fmt.Errorf("db: migrate labels: %w", err)
+	}
+
+	const createPlaylists = `CREATE TABLE IF NOT EXISTS playlists (
+        id TEXT PRIMARY KEY,
+        payload TEXT NOT NULL,
+        updated_at TIMESTAMP NOT NULL
+    )`
+
+	if _, err := s.db.ExecContext(ctx, createPlaylists); err != nil {
+		return fmt.Errorf("db: migrate playlists: %w", err)
+	}
+
+	const createOwnedAlbums = `CREATE TABLE IF NOT EXISTS owned_albums (
+        id TEXT PRIMARY KEY,
+        payload TEXT NOT NULL,
+        updated_at TIMESTAMP NOT NULL
+    )`
+
+	if _, err := s.db.ExecContext(ctx, createOwnedAlbums); err != nil {
+		return fmt.Errorf("db: migrate owned albums: %w", err)
+	}
+
+	const createAliases = `CREATE TABLE IF NOT EXISTS mbid_aliases (
+        kind TEXT NOT NULL,
+        id TEXT NOT NULL,
+        canonical_id TEXT NOT NULL,
+        updated_at TIMESTAMP NOT NULL,
+        PRIMARY KEY (kind, id)
+    )`
+
+	if _, err := s.db.ExecContext(ctx, createAliases); err != nil {
+		return fmt.Errorf("db: migrate aliases: %w", err)
+	}
+
+	// Tombstone columns hold unix milliseconds so retention comparisons are plain integers.
+	for _, column := range []struct{ table, name, decl string }{
+		{"artists", "deleted_at", "INTEGER"},
+		{"albums", "deleted_at", "INTEGER"},
+		{"albums", "deleted_by", "TEXT"},
+		{"albums", "year", "INTEGER"},
+		{"albums", "genre", "TEXT"},
+		{"artists", "content_hash", "TEXT"},
+		{"albums", "content_hash", "TEXT"},
+		{"labels", "content_hash", "TEXT"},
+		{"artists", "country", "TEXT"},
+		{"artists", "type", "TEXT"},
+	} {
+		if err := s.addColumnIfMissing(ctx, column.table, column.name, column.decl); err != nil {
+			return err
+		}
+	}
+
+	// Year and genre are copied out of the payload so decade and genre browsing can use indexes.
+	const backfillAlbumColumns = `UPDATE albums
+        SET year = COALESCE(json_extract(payload, '$.year'), 0),
+            genre = lower(trim(COALESCE(json_extract(payload, '$.genre'), '')))
+        WHERE year IS NULL`
+
+	if _, err := s.db.ExecContext(ctx, backfillAlbumColumns); err != nil {
+		return fmt.Errorf("db: backfill album columns: %w", err)
+	}
+
+	// Country and type are copied out the same way for scene browsing.
+	const backfillArtistColumns = `UPDATE artists
+        SET country = upper(trim(COALESCE(json_extract(payload, '$.country'), ''))),
+            type = lower(trim(COALESCE(json_extract(payload, '$.type'), '')))
+        WHERE country IS NULL`
+
+	if _, err := s.db.ExecContext(ctx, backfillArtistColumns); err != nil {
+		return fmt.Errorf("db: backfill artist columns: %w", err)
+	}
+
+	for _, index := range []string{
+		`CREATE INDEX IF NOT EXISTS albums_year_idx ON albums (year)`,
+		`CREATE INDEX IF NOT EXISTS albums_genre_year_idx ON albums (genre, year)`,
+		`CREATE INDEX IF NOT EXISTS artists_country_type_idx ON artists (country, type)`,
+	} {
+		if _, err := s.db.ExecContext(ctx, index); err != nil {
+			return fmt.Errorf("db: migrate browse indexes: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *SQLiteStore) addColumnIfMissing(ctx context.Context, table, column, decl string) error {
+	var count int
+	row := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column)
+	if err := row.Scan(&count); err != nil {
+		return fmt.Errorf("db: inspect %s: %w", table, err)
+	}
+	if count > 0 {
+		return nil
+	}
+	if _, err := s.db.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, decl)); err != nil {
+		return fmt.Errorf("db: migrate %s.%s: %w", table, column, err)
+	}
+	return nil
+}
+
+// WithTx runs fn against repositories bound to one transaction, committing only if fn succeeds.
+func (s *SQLiteStore) WithTx(ctx context.Context, fn func(Repos) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(sqliteRepos{q: tx}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("db: commit transaction: %w", err)
+	}
+	return nil
+}
+
+// sqlQuerier is satisfied by both *sql.DB and *sql.Tx.
+type sqlQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// rewritePayload persists a payload upgraded on read so the migration runs once per record. It
+// leaves updated_at alone, since the record's content is no fresher than before. Failures only
+// mean the migration runs again on the next read, so they are not surfaced.
+func rewritePayload(ctx context.Context, q sqlQuerier, table, id string, schema payloadSchema, v any) {
+	payload, err := schema.encode(v)
+	if err != nil {
+		return
+	}
+	_, _ = q.ExecContext(ctx, `UPDATE `+table+` SET payload = ?, content_hash = ? WHERE id = ?`, string(payload), contentHash(payload), id)
+}
+
+// contentHash fingerprints an encoded payload so saves can skip rewriting unchanged records.
+// Payloads encode deterministically (struct fields in order, map keys sorted).
+func contentHash(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// sqliteRepos implements the entity repositories against the database or an open transaction.
+type sqliteRepos struct {
+	q sqlQuerier
+}
+
+// GetArtist retrieves an artist by ID if present.
+func (r sqliteRepos) GetArtist(ctx context.Context, id string) (*data.Artist, error) {
+	row := r.q.QueryRowContext(ctx, `SELECT payload FROM artists WHERE id = ? AND deleted_at IS NULL`, id)
+
+	var payload string
+	if err := row.Scan(&payload); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("db: query artist: %w", err)
+	}
+
+	var artist data.Artist
+	upgraded, err := artistSchema.decode([]byte(payload), &artist)
+	if err != nil {
+		return nil, fmt.Errorf("db: decode artist: %w", err)
+	}
+	if upgraded {
+		rewritePayload(ctx, r.q, "artists", id, artistSchema, &artist)
+	}
+
+	return &artist, nil
+}
+
+// SaveArtist upserts an artist record and its search index entry.
+func (r sqliteRepos) SaveArtist(ctx context.Context, artist *data.Artist) error {
+	if err := validateArtist(artist); err != nil {
+		return err
+	}
+
+	payload, err := artistSchema.encode(artist)
+	if err != nil {
+		return fmt.Errorf("db: encode artist: %w", err)
+	}
+
+	// A refresh that produced the same content leaves the row, its updated_at, and the search
+	// index untouched.
+	res, err := r.q.ExecContext(
+		ctx,
+		`INSERT INTO artists (id, payload, updated_at, country, type, content_hash)
+         VALUES (?, ?, ?, ?, ?, ?)
+         ON CONFLICT(id) DO UPDATE SET payload = excluded.payload, updated_at = excluded.updated_at,
+             country = excluded.country, type = excluded.type, content_hash = excluded.content_hash,
+             deleted_at = NULL
+         WHERE artists.content_hash IS NOT excluded.content_hash OR artists.deleted_at IS NOT NULL`,
+		artist.ID,
+		string(payload),
+		time.Now().UTC(),
+		normalizeCountry(artist.Country),
+		normalizeArtistType(artist.Type),
+		contentHash(payload),
+	)
+	if err != nil {
+		return fmt.Errorf("db: upsert artist: %w", err)
+	}
+	if written, err := res.RowsAffected(); err == nil && written == 0 {
+		return nil
+	}
+
+	if _, err := r.q.ExecContext(ctx, `DELETE FROM artists_fts WHERE id = ?`, artist.ID); err != nil {
+		return fmt.Errorf("db: clear artist index: %w", err)
+	}
+	_, err = r.q.ExecContext(
+		ctx,
+		`INSERT INTO artists_fts (id, name, aliases, disambiguation) VALUES (?, ?, ?, ?)`,
+		artist.ID,
+		artist.Name,
+		strings.Join(artist.Aliases, " | "),
+		artist.Disambiguation,
+	)
+	if err != nil {
+		return fmt.Errorf("db: index artist: %w", err)
+	}
+	return nil
+}
+
+// GetAlbum retrieves an album by ID if present.
+func (r sqliteRepos) GetAlbum(ctx context.Context, id string) (*data.Album, error) {
+	row := r.q.QueryRowContext(ctx, `SELECT payload FROM albums WHERE id = ? AND deleted_at IS NULL`, id)
+
+	var payload string
+	if err := row.Scan(&payload); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("db: query album: %w", err)
+	}
+
+	var album data.Album
+	upgraded, err := albumSchema.decode([]byte(payload), &album)
+	if err != nil {
+		return nil, fmt.Errorf("db: decode album: %w", err)
+	}
+	if upgraded {
+		rewritePayload(ctx, r.q, "albums", id, albumSchema, &album)
+	}
+
+	return &album, nil
+}
+
+// SaveAlbum upserts an album record in the database.
+func (r sqliteRepos) SaveAlbum(ctx context.Context, album *data.Album) error {
+	if err := validateAlbum(album); err != nil {
+		return err
+	}
+
+	payload, err := albumSchema.encode(album)
+	if err != nil {
+		return fmt.Errorf("db: encode album: %w", err)
+	}
+
+	_, err = r.q.ExecContext(
+		ctx,
+		`INSERT INTO albums (id, payload, updated_at, year, genre, content_hash)
+         VALUES (?, ?, ?, ?, ?, ?)
+         ON CONFLICT(id) DO UPDATE SET payload = excluded.payload, updated_at = excluded.updated_at,
+             year = excluded.year, genre = excluded.genre, content_hash = excluded.content_hash,
+             deleted_at = NULL, deleted_by = NULL
+         WHERE albums.content_hash IS NOT excluded.content_hash OR albums.deleted_at IS NOT NULL`,
+		album.ID,
+		string(payload),
+		time.Now().UTC(),
+		album.Year,
+		normalizeGenre(album.Genre),
+		contentHash(payload),
+	)
+	if err != nil {
+		return fmt.Errorf("db: upsert album: %w", err)
+	}
+	return nil
+}
+
+// SaveAlias records that id was merged into canonicalID.
+func (r sqliteRepos) SaveAlias(ctx context.Context, kind, id, canonicalID string) error {
+	if err := validateAlias(kind, id, canonicalID); err != nil {
+		return err
+	}
+
+	_, err := r.q.ExecContext(
+		ctx,
+		`INSERT INTO mbid_aliases (kind, id, canonical_id, updated_at)
+         VALUES (?, ?, ?, ?)
+         ON CONFLICT(kind, id) DO UPDATE SET canonical_id = excluded.canonical_id, updated_at = excluded.updated_at`,
+		kind,
+		id,
+		canonicalID,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("db: upsert alias: %w", err)
+	}
 	return nil
 }
+
+// ResolveAlias returns the canonical MBID for id, or "" when id is not a known alias.
+func (r sqliteRepos) ResolveAlias(ctx context.Context, kind, id string) (string, error) {
+	row := r.q.QueryRowContext(ctx, `SELECT canonical_id FROM mbid_aliases WHERE kind = ? AND id = ?`, kind, id)
+
+	var canonicalID string
+	if err := row.Scan(&canonicalID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("db: query alias: %w", err)
+	}
+	return canonicalID, nil
+}
+
+// ListArtists returns every live cached artist.
+func (s *SQLiteStore) ListArtists(ctx context.Context) ([]data.Artist, error) {
+	payloads, err := queryPayloads(ctx, s.db, `SELECT payload FROM artists WHERE deleted_at IS NULL ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("db: list artists: %w", err)
+	}
+	artists := make([]data.Artist, len(payloads))
+	for i, payload := range payloads {
+		if _, err := artistSchema.decode([]byte(payload), &artists[i]); err != nil {
+			return nil, fmt.Errorf("db: decode artist: %w", err)
+		}
+	}
+	return artists, nil
+}
+
+// ListAlbums returns every live cached album.
+func (s *SQLiteStore) ListAlbums(ctx context.Context) ([]data.Album, error) {
+	payloads, err := queryPayloads(ctx, s.db, `SELECT payload FROM albums WHERE deleted_at IS NULL ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("db: list albums: %w", err)
+	}
+	albums := make([]data.Album, len(payloads))
+	for i, payload := range payloads {
+		if _, err := albumSchema.decode([]byte(payload), &albums[i]); err != nil {
+			return nil, fmt.Errorf("db: decode album: %w", err)
+		}
+	}
+	return albums, nil
+}
+
+// MergeArtists folds the fromID artist into intoID in one transaction.
+func (s *SQLiteStore) MergeArtists(ctx context.Context, fromID, intoID string) (MergeResult, error) {
+	if err := validateMerge(fromID, intoID); err != nil {
+		return MergeResult{}, err
+	}
+
+	result := MergeResult{Kind: KindArtist, FromID: fromID, IntoID: intoID}
+	err := s.withMergeTx(ctx, func(tx *sql.Tx, repos sqliteRepos) error {
+		from, err := repos.GetArtist(ctx, fromID)
+		if err != nil {
+			return err
+		}
+		into, err := repos.GetArtist(ctx, intoID)
+		if err != nil {
+			return err
+		}
+		if from == nil || into == nil {
+			return ErrNotCached
+		}
+
+		consolidateArtist(into, from)
+		if err := repos.SaveArtist(ctx, into); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM artists WHERE id = ?`, fromID); err != nil {
+			return fmt.Errorf("db: delete artist: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM artists_fts WHERE id = ?`, fromID); err != nil {
+			return fmt.Errorf("db: delete artist index: %w", err)
+		}
+
+		// MBIDs are unique strings, so a substring match narrows the rows to decode.
+		payloads, err := queryPayloads(ctx, tx, `SELECT payload FROM albums WHERE deleted_at IS NULL AND instr(payload, ?) > 0`, fromID)
+		if err != nil {
+			return fmt.Errorf("db: query credited albums: %w", err)
+		}
+		for _, payload := range payloads {
+			var album data.Album
+			if _, err := albumSchema.decode([]byte(payload), &album); err != nil {
+				return fmt.Errorf("db: decode album: %w", err)
+			}
+			if !repointAlbumArtist(&album, fromID, intoID) {
+				continue
+			}
+			if err := repos.SaveAlbum(ctx, &album); err != nil {
+				return err
+			}
+			result.Albums++
+		}
+
+		payloads, err = queryPayloads(ctx, tx, `SELECT payload FROM owned_albums WHERE instr(payload, ?) > 0`, fromID)
+		if err != nil {
+			return fmt.Errorf("db: query owned albums: %w", err)
+		}
+		for _, payload := range payloads {
+			var owned data.OwnedAlbum
+			if err := json.Unmarshal([]byte(payload), &owned); err != nil {
+				return fmt.Errorf("db: decode owned album: %w", err)
+			}
+			if owned.ArtistID != fromID {
+				continue
+			}
+			owned.ArtistID = intoID
+			if err := saveOwnedAlbum(ctx, tx, &owned); err != nil {
+				return err
+			}
+			result.OwnedAlbums++
+		}
+
+		return repointSQLiteAliases(ctx, tx, repos, KindArtist, fromID, intoID)
+	})
+	if err != nil {
+		return MergeResult{}, err
+	}
+	return result, nil
+}
+
+// MergeAlbums folds the fromID album into intoID in one transaction.
+func (s *SQLiteStore) MergeAlbums(ctx context.Context, fromID, intoID string) (MergeResult, error) {
+	if err := validateMerge(fromID, intoID); err != nil {
+		return MergeResult{}, err
+	}
+
+	result := MergeResult{Kind: KindAlbum, FromID: fromID, IntoID: intoID}
+	err := s.withMergeTx(ctx, func(tx *sql.Tx, repos sqliteRepos) error {
+		from, err := repos.GetAlbum(ctx, fromID)
+		if err != nil {
+			return err
+		}
+		into, err := repos.GetAlbum(ctx, intoID)
+		if err != nil {
+			return err
+		}
+		if from == nil || into == nil {
+			return ErrNotCached
+		}
+
+		artistIDs := albumArtistIDs(from, into)
+		consolidateAlbum(into, from)
+		if err := repos.SaveAlbum(ctx, into); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM albums WHERE id = ?`, fromID); err != nil {
+			return fmt.Errorf("db: delete album: %w", err)
+		}
+
+		for _, artistID := range artistIDs {
+			artist, err := repos.GetArtist(ctx, artistID)
+			if err != nil {
+				return err
+			}
+			if artist == nil || !repointDiscography(artist, fromID, intoID) {
+				continue
+			}
+			if err := repos.SaveArtist(ctx, artist); err != nil {
+				return err
+			}
+			result.Artists++
+		}
+
+		payloads, err := queryPayloads(ctx, tx, `SELECT payload FROM owned_albums WHERE id = ?`, fromID)
+		if err != nil {
+			return fmt.Errorf("db: query owned album: %w", err)
+		}
+		if len(payloads) > 0 {
+			var owned data.OwnedAlbum
+			if err := json.Unmarshal([]byte(payloads[0]), &owned); err != nil {
+				return fmt.Errorf("db: decode owned album: %w", err)
+			}
+			if _, err := tx.ExecContext(ctx, `DELETE FROM owned_albums WHERE id = ?`, fromID); err != nil {
+				return fmt.Errorf("db: delete owned album: %w", err)
+			}
+			existing, err := queryPayloads(ctx, tx, `SELECT payload FROM owned_albums WHERE id = ?`, intoID)
+			if err != nil {
+				return fmt.Errorf("db: query owned album: %w", err)
+			}
+			if len(existing) == 0 {
+				owned.AlbumID = intoID
+				if err := saveOwnedAlbum(ctx, tx, &owned); err != nil {
+					return err
+				}
+			}
+			result.OwnedAlbums++
+		}
+
+		return repointSQLiteAliases(ctx, tx, repos, KindAlbum, fromID, intoID)
+	})
+	if err != nil {
+		return MergeResult{}, err
+	}
+	return result, nil
+}
+
+func (s *SQLiteStore) withMergeTx(ctx context.Context, fn func(*sql.Tx, sqliteRepos) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db: begin merge: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(tx, sqliteRepos{q: tx}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("db: commit merge: %w", err)
+	}
+	return nil
+}
+
+// repointSQLiteAliases records fromID as an alias of intoID and updates aliases that pointed at
+// fromID, so lookups never follow a chain.
+func repointSQLiteAliases(ctx context.Context, tx *sql.Tx, repos sqliteRepos, kind, fromID, intoID string) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE mbid_aliases SET canonical_id = ?, updated_at = ? WHERE kind = ? AND canonical_id = ?`, intoID, time.Now().UTC(), kind, fromID); err != nil {
+		return fmt.Errorf("db: repoint aliases: %w", err)
+	}
+	return repos.SaveAlias(ctx, kind, fromID, intoID)
+}
+
+func saveOwnedAlbum(ctx context.Context, q sqlQuerier, owned *data.OwnedAlbum) error {
+	payload, err := json.Marshal(owned)
+	if err != nil {
+		return fmt.Errorf("db: encode owned album: %w", err)
+	}
+
+	_, err = q.ExecContext(
+		ctx,
+		`INSERT INTO owned_albums (id, payload, updated_at)
+         VALUES (?, ?, ?)
+         ON CONFLICT(id) DO UPDATE SET payload = excluded.payload, updated_at = excluded.updated_at`,
+		owned.AlbumID,
+		string(payload),
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("db: upsert owned album: %w", err)
+	}
+	return nil
+}
+
+func queryPayloads(ctx context.Context, q sqlQuerier, query string, args ...any) ([]string, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payloads []string
+	for rows.Next() {
+		var payload string
+		if err := rows.Scan(&payload); err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads, rows.Err()
+}
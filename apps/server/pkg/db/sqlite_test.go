@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
@@ -127,3 +128,192 @@ func TestSQLiteStoreSaveAndGetAlbum(t *testing.T) {
 		t.Fatalf("expected updated title, got %q", updated.Title)
 	}
 }
+
+func TestSQLiteStoreSaveAndGetLabel(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dsn := "file:" + filepath.Join(dir, sqliteDBName) + sqliteQuerySuffix
+
+	store, err := NewSQLiteStore(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf(sqliteNewErrFmt, err)
+	}
+	defer func() {
+		if err := store.Close(context.Background()); err != nil {
+			t.Fatalf(sqliteCloseErrFmt, err)
+		}
+	}()
+
+	label := &data.Label{ID: "label-1", Name: "Sub Pop", Albums: []data.Album{{ID: sqliteAlbumID, Title: "Bleach"}}}
+	if err := store.SaveLabel(context.Background(), label); err != nil {
+		t.Fatalf("SaveLabel returned error: %v", err)
+	}
+
+	fetched, err := store.GetLabel(context.Background(), "label-1")
+	if err != nil {
+		t.Fatalf("GetLabel returned error: %v", err)
+	}
+	if fetched == nil || fetched.Name != "Sub Pop" || len(fetched.Albums) != 1 {
+		t.Fatalf("unexpected label payload: %#v", fetched)
+	}
+
+	missing, err := store.GetLabel(context.Background(), "missing")
+	if err != nil || missing != nil {
+		t.Fatalf("expected nil for missing label, got %#v, %v", missing, err)
+	}
+}
+
+func TestSQLiteStoreAliases(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dsn := "file:" + filepath.Join(dir, sqliteDBName) + sqliteQuerySuffix
+
+	store, err := NewSQLiteStore(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf(sqliteNewErrFmt, err)
+	}
+	defer func() {
+		if err := store.Close(context.Background()); err != nil {
+			t.Fatalf(sqliteCloseErrFmt, err)
+		}
+	}()
+
+	if err := store.SaveAlias(context.Background(), KindArtist, "old-id", "new-id"); err != nil {
+		t.Fatalf("SaveAlias returned error: %v", err)
+	}
+
+	canonical, err := store.ResolveAlias(context.Background(), KindArtist, "old-id")
+	if err != nil {
+		t.Fatalf("ResolveAlias returned error: %v", err)
+	}
+	if canonical != "new-id" {
+		t.Fatalf("expected canonical id new-id, got %q", canonical)
+	}
+
+	other, err := store.ResolveAlias(context.Background(), KindAlbum, "old-id")
+	if err != nil {
+		t.Fatalf("ResolveAlias returned error: %v", err)
+	}
+	if other != "" {
+		t.Fatalf("expected aliases to be scoped by kind, got %q", other)
+	}
+
+	if err := store.SaveAlias(context.Background(), KindArtist, "same", "same"); err == nil {
+		t.Fatalf("expected self-referencing alias to be rejected")
+	}
+}
+
+func TestSQLiteStoreInvalidateArtist(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dsn := "file:" + filepath.Join(dir, sqliteDBName) + sqliteQuerySuffix
+
+	store, err := NewSQLiteStore(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf(sqliteNewErrFmt, err)
+	}
+	defer func() {
+		if err := store.Close(context.Background()); err != nil {
+			t.Fatalf(sqliteCloseErrFmt, err)
+		}
+	}()
+
+	ctx := context.Background()
+	artist := &data.Artist{ID: "artist-1", Name: "Artist", Albums: []data.Album{{ID: "listed"}}}
+	if err := store.SaveArtist(ctx, artist); err != nil {
+		t.Fatalf("SaveArtist returned error: %v", err)
+	}
+	for _, album := range []*data.Album{
+		{ID: "credited", ArtistID: "artist-1"},
+		{ID: "listed", ArtistID: "someone-else"},
+		{ID: "unrelated", ArtistID: "artist-2"},
+	} {
+		if err := store.SaveAlbum(ctx, album); err != nil {
+			t.Fatalf("SaveAlbum returned error: %v", err)
+		}
+	}
+
+	result, err := store.InvalidateArtist(ctx, "artist-1")
+	if err != nil {
+		t.Fatalf("InvalidateArtist returned error: %v", err)
+	}
+	if result.Artists != 1 || result.Albums != 2 {
+		t.Fatalf("unexpected invalidation counts %+v", result)
+	}
+
+	if cached, _ := store.GetArtist(ctx, "artist-1"); cached != nil {
+		t.Fatalf("expected artist to be removed")
+	}
+	if cached, _ := store.GetAlbum(ctx, "unrelated"); cached == nil {
+		t.Fatalf("expected unrelated album to remain cached")
+	}
+	if found, _ := store.SearchArtists(ctx, "artist", 10); len(found) != 0 {
+		t.Fatalf("expected artist to be removed from the search index")
+	}
+}
+
+func TestSQLiteStoreSkipsUnchangedSaves(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dsn := "file:" + filepath.Join(dir, sqliteDBName) + sqliteQuerySuffix
+
+	ctx := context.Background()
+	store, err := NewSQLiteStore(ctx, dsn)
+	if err != nil {
+		t.Fatalf(sqliteNewErrFmt, err)
+	}
+	defer func() {
+		if err := store.Close(ctx); err != nil {
+			t.Fatalf(sqliteCloseErrFmt, err)
+		}
+	}()
+
+	artist := &data.Artist{ID: "a1", Name: "Nirvana", Albums: []data.Album{{ID: "b1", Title: "Bleach"}}}
+	if err := store.SaveArtist(ctx, artist); err != nil {
+		t.Fatalf("SaveArtist returned error: %v", err)
+	}
+	const stale = "2020-01-01 00:00:00"
+	updatedAt := func() string {
+		var value string
+		if err := store.db.QueryRowContext(ctx, `SELECT updated_at FROM artists WHERE id = ?`, "a1").Scan(&value); err != nil {
+			t.Fatalf("query updated_at: %v", err)
+		}
+		return value
+	}
+	if _, err := store.db.ExecContext(ctx, `UPDATE artists SET updated_at = ? WHERE id = ?`, stale, "a1"); err != nil {
+		t.Fatalf("backdate artist: %v", err)
+	}
+
+	if err := store.SaveArtist(ctx, &data.Artist{ID: "a1", Name: "Nirvana", Albums: []data.Album{{ID: "b1", Title: "Bleach"}}}); err != nil {
+		t.Fatalf("SaveArtist returned error: %v", err)
+	}
+	if got := updatedAt(); !strings.HasPrefix(got, "2020-01-01") {
+		t.Errorf("expected an identical save to be skipped, updated_at = %q", got)
+	}
+
+	artist.Biography = "Seattle band"
+	if err := store.SaveArtist(ctx, artist); err != nil {
+		t.Fatalf("SaveArtist returned error: %v", err)
+	}
+	if got := updatedAt(); strings.HasPrefix(got, "2020-01-01") {
+		t.Error("expected a changed artist to be written")
+	}
+
+	// Saving identical content still restores a tombstoned record.
+	if _, err := store.InvalidateArtist(ctx, "a1"); err != nil {
+		t.Fatalf("InvalidateArtist returned error: %v", err)
+	}
+	if err := store.SaveArtist(ctx, artist); err != nil {
+		t.Fatalf("SaveArtist returned error: %v", err)
+	}
+	if got, _ := store.GetArtist(ctx, "a1"); got == nil {
+		t.Error("expected the re-saved artist to be live again")
+	}
+	if found, _ := store.SearchArtists(ctx, "nirvana", 5); len(found) != 1 {
+		t.Errorf("expected the artist to stay searchable, got %+v", found)
+	}
+}
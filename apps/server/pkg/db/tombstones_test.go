@@ -0,0 +1,175 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+func exerciseTombstones(t *testing.T, store Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	if err := store.SaveArtist(ctx, &data.Artist{ID: "artist-1", Name: "Artist"}); err != nil {
+		t.Fatalf("SaveArtist returned error: %v", err)
+	}
+	if err := store.SaveAlbum(ctx, &data.Album{ID: "album-1", ArtistID: "artist-1"}); err != nil {
+		t.Fatalf("SaveAlbum returned error: %v", err)
+	}
+
+	start := time.Now().Add(-time.Second)
+	if _, err := store.InvalidateArtist(ctx, "artist-1"); err != nil {
+		t.Fatalf("InvalidateArtist returned error: %v", err)
+	}
+	if artist, _ := store.GetArtist(ctx, "artist-1"); artist != nil {
+		t.Fatalf("expected tombstoned artist to be hidden")
+	}
+
+	tombstones, err := store.ListTombstones(ctx, start)
+	if err != nil {
+		t.Fatalf("ListTombstones returned error: %v", err)
+	}
+	if len(tombstones) != 2 || tombstones[0].Kind != KindArtist || tombstones[1].Kind != KindAlbum {
+		t.Fatalf("unexpected tombstones %+v", tombstones)
+	}
+
+	restored, err := store.RestoreArtist(ctx, "artist-1")
+	if err != nil {
+		t.Fatalf("RestoreArtist returned error: %v", err)
+	}
+	if restored.Artists != 1 || restored.Albums != 1 {
+		t.Fatalf("unexpected restore counts %+v", restored)
+	}
+	if album, _ := store.GetAlbum(ctx, "album-1"); album == nil {
+		t.Fatalf("expected restored album to be readable")
+	}
+
+	if _, err := store.InvalidateArtist(ctx, "artist-1"); err != nil {
+		t.Fatalf("InvalidateArtist returned error: %v", err)
+	}
+	if purged, err := store.PurgeTombstones(ctx, start); err != nil || purged != 0 {
+		t.Fatalf("expected recent tombstones to survive purge, got %d (%v)", purged, err)
+	}
+	purged, err := store.PurgeTombstones(ctx, time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatalf("PurgeTombstones returned error: %v", err)
+	}
+	if purged != 2 {
+		t.Fatalf("expected 2 purged records, got %d", purged)
+	}
+	if restored, _ := store.RestoreArtist(ctx, "artist-1"); restored.Artists != 0 || restored.Albums != 0 {
+		t.Fatalf("expected purged records to be unrecoverable, got %+v", restored)
+	}
+}
+
+func TestMemoryStoreTombstones(t *testing.T) {
+	store, err := NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf(newStoreErrFmt, err)
+	}
+	exerciseTombstones(t, store)
+}
+
+func TestSQLiteStoreTombstones(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dsn := "file:" + filepath.Join(dir, sqliteDBName) + sqliteQuerySuffix
+
+	store, err := NewSQLiteStore(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf(sqliteNewErrFmt, err)
+	}
+	defer func() {
+		if err := store.Close(context.Background()); err != nil {
+			t.Fatalf(sqliteCloseErrFmt, err)
+		}
+	}()
+	exerciseTombstones(t, store)
+}
+
+func exerciseDeletes(t *testing.T, store Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	if err := store.SaveArtist(ctx, &data.Artist{ID: "artist-1", Name: "Artist"}); err != nil {
+		t.Fatalf("SaveArtist returned error: %v", err)
+	}
+	if err := store.SaveAlbum(ctx, &data.Album{ID: "album-1", ArtistID: "artist-1"}); err != nil {
+		t.Fatalf("SaveAlbum returned error: %v", err)
+	}
+
+	if found, err := store.DeleteArtist(ctx, "artist-1"); err != nil || !found {
+		t.Fatalf("expected DeleteArtist to find the artist, got %v (%v)", found, err)
+	}
+	if artist, _ := store.GetArtist(ctx, "artist-1"); artist != nil {
+		t.Fatalf("expected deleted artist to be hidden")
+	}
+	if album, _ := store.GetAlbum(ctx, "album-1"); album == nil {
+		t.Fatalf("expected the artist's album to stay cached")
+	}
+	if found, err := store.DeleteArtist(ctx, "artist-1"); err != nil || found {
+		t.Fatalf("expected a second DeleteArtist to find nothing, got %v (%v)", found, err)
+	}
+
+	if err := store.SaveAlbum(ctx, &data.Album{ID: "album-2", ArtistID: "artist-1", Rating: &data.AggregateRating{Score: 4, Count: 1}}); err != nil {
+		t.Fatalf("SaveAlbum returned error: %v", err)
+	}
+	if albums, err := store.GetAlbums(ctx, []string{"album-1", "album-2", "missing"}); err != nil || len(albums) != 2 {
+		t.Fatalf("expected both cached albums in one batch, got %+v (%v)", albums, err)
+	}
+
+	if found, err := store.DeleteAlbum(ctx, "album-1"); err != nil || !found {
+		t.Fatalf("expected DeleteAlbum to find the album, got %v (%v)", found, err)
+	}
+	if album, _ := store.GetAlbum(ctx, "album-1"); album != nil {
+		t.Fatalf("expected deleted album to be hidden")
+	}
+	albums, err := store.GetAlbums(ctx, []string{"album-1", "album-2"})
+	if err != nil || len(albums) != 1 || albums[0].ID != "album-2" || albums[0].Rating == nil || albums[0].Rating.Score != 4 {
+		t.Fatalf("expected batch reads to skip the deleted album, got %+v (%v)", albums, err)
+	}
+	if tombstones, _ := store.ListTombstones(ctx, time.Time{}); len(tombstones) != 2 {
+		t.Fatalf("expected both deletes to leave tombstones, got %+v", tombstones)
+	}
+
+	restored, err := store.RestoreArtist(ctx, "artist-1")
+	if err != nil {
+		t.Fatalf("RestoreArtist returned error: %v", err)
+	}
+	if restored.Artists != 1 || restored.Albums != 0 {
+		t.Fatalf("expected only the artist to be restored, got %+v", restored)
+	}
+	if _, err := store.DeleteAlbum(ctx, " "); err == nil {
+		t.Fatal("expected an error for a blank album id")
+	}
+}
+
+func TestMemoryStoreDeletes(t *testing.T) {
+	store, err := NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf(newStoreErrFmt, err)
+	}
+	exerciseDeletes(t, store)
+}
+
+func TestSQLiteStoreDeletes(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dsn := "file:" + filepath.Join(dir, sqliteDBName) + sqliteQuerySuffix
+
+	store, err := NewSQLiteStore(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf(sqliteNewErrFmt, err)
+	}
+	defer func() {
+		if err := store.Close(context.Background()); err != nil {
+			t.Fatalf(sqliteCloseErrFmt, err)
+		}
+	}()
+	exerciseDeletes(t, store)
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// WithTx stages writes made through the transaction and applies them under a single lock once
+// fn succeeds. Reads inside the transaction see staged writes first.
+func (s *MemoryStore) WithTx(ctx context.Context, fn func(Repos) error) error {
+	tx := &memoryTx{
+		store:   s,
+		artists: make(map[string]*data.Artist),
+		albums:  make(map[string]*data.Album),
+		aliases: make(map[string]string),
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, artist := range tx.artists {
+		s.artists[id] = artist
+		s.touch(KindArtist, id)
+		delete(s.deletedArtists, id)
+	}
+	for id, album := range tx.albums {
+		s.albums[id] = album
+		s.touch(KindAlbum, id)
+		delete(s.deletedAlbums, id)
+	}
+	for key, canonicalID := range tx.aliases {
+		s.aliases[key] = canonicalID
+	}
+	return nil
+}
+
+// memoryTx buffers writes for MemoryStore.WithTx.
+type memoryTx struct {
+	store   *MemoryStore
+	artists map[string]*data.Artist
+	albums  map[string]*data.Album
+	aliases map[string]string
+}
+
+func (t *memoryTx) GetArtist(ctx context.Context, id string) (*data.Artist, error) {
+	if artist, ok := t.artists[id]; ok {
+		return artist, nil
+	}
+	return t.store.GetArtist(ctx, id)
+}
+
+func (t *memoryTx) SaveArtist(ctx context.Context, artist *data.Artist) error {
+	if err := validateArtist(artist); err != nil {
+		return err
+	}
+	t.artists[artist.ID] = cloneArtist(artist)
+	return nil
+}
+
+func (t *memoryTx) GetAlbum(ctx context.Context, id string) (*data.Album, error) {
+	if album, ok := t.albums[id]; ok {
+		return album, nil
+	}
+	return t.store.GetAlbum(ctx, id)
+}
+
+func (t *memoryTx) SaveAlbum(ctx context.Context, album *data.Album) error {
+	if err := validateAlbum(album); err != nil {
+		return err
+	}
+	t.albums[album.ID] = cloneAlbum(album)
+	return nil
+}
+
+func (t *memoryTx) SaveAlias(ctx context.Context, kind, id, canonicalID string) error {
+	if err := validateAlias(kind, id, canonicalID); err != nil {
+		return err
+	}
+	t.aliases[kind+":"+id] = canonicalID
+	return nil
+}
+
+func (t *memoryTx) ResolveAlias(ctx context.Context, kind, id string) (string, error) {
+	if canonicalID, ok := t.aliases[kind+":"+id]; ok {
+		return canonicalID, nil
+	}
+	return t.store.ResolveAlias(ctx, kind, id)
+}
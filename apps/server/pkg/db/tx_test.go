@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+func exerciseWithTx(t *testing.T, store Store) {
+	t.Helper()
+	ctx := context.Background()
+	errAbort := errors.New("abort")
+
+	err := store.WithTx(ctx, func(repos Repos) error {
+		if err := repos.SaveArtist(ctx, &data.Artist{ID: "rolled-back", Name: "Half Written"}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("expected fn error to be returned, got %v", err)
+	}
+	if artist, _ := store.GetArtist(ctx, "rolled-back"); artist != nil {
+		t.Fatalf("expected failed transaction to leave no artist behind")
+	}
+
+	err = store.WithTx(ctx, func(repos Repos) error {
+		if err := repos.SaveArtist(ctx, &data.Artist{ID: "artist-1", Name: "Artist"}); err != nil {
+			return err
+		}
+		if artist, err := repos.GetArtist(ctx, "artist-1"); err != nil || artist == nil {
+			t.Fatalf("expected staged artist to be readable inside the transaction, got %v (%v)", artist, err)
+		}
+		if err := repos.SaveAlbum(ctx, &data.Album{ID: "album-1", ArtistID: "artist-1"}); err != nil {
+			return err
+		}
+		return repos.SaveAlias(ctx, KindArtist, "old-artist", "artist-1")
+	})
+	if err != nil {
+		t.Fatalf("WithTx returned error: %v", err)
+	}
+	if artist, _ := store.GetArtist(ctx, "artist-1"); artist == nil {
+		t.Fatalf("expected committed artist")
+	}
+	if album, _ := store.GetAlbum(ctx, "album-1"); album == nil {
+		t.Fatalf("expected committed album")
+	}
+	if canonical, _ := store.ResolveAlias(ctx, KindArtist, "old-artist"); canonical != "artist-1" {
+		t.Fatalf("expected committed alias, got %q", canonical)
+	}
+}
+
+func TestMemoryStoreWithTx(t *testing.T) {
+	store, err := NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf(newStoreErrFmt, err)
+	}
+	exerciseWithTx(t, store)
+}
+
+func TestSQLiteStoreWithTx(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dsn := "file:" + filepath.Join(dir, sqliteDBName) + sqliteQuerySuffix
+
+	store, err := NewSQLiteStore(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf(sqliteNewErrFmt, err)
+	}
+	defer func() {
+		if err := store.Close(context.Background()); err != nil {
+			t.Fatalf(sqliteCloseErrFmt, err)
+		}
+	}()
+	exerciseWithTx(t, store)
+}
@@ -0,0 +1,143 @@
+// Package langdetect guesses the language of short texts such as album notes and reviews: by
+// script for non-Latin text, and by the share of common function words for Latin-script text.
+// It favors saying nothing over guessing wrong.
+package langdetect
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Latin-script text needs at least minWords words, and its best language at least minHits
+// function words, more than any other language has, before Detect names it.
+const (
+	minWords = 4
+	minHits  = 2
+)
+
+// functionWords are frequent words distinctive enough to tell Latin-script languages apart.
+// Words shared by several languages ("de", "la", "en") still count for each of them; the
+// remaining words usually break the tie.
+var functionWords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "was", "with", "for", "on", "that", "by", "this", "as", "are", "from", "it", "his", "her", "their", "were", "an", "at", "which", "has", "have", "recorded", "released"},
+	"de": {"der", "die", "und", "das", "ist", "mit", "von", "den", "zu", "ein", "eine", "auf", "im", "nicht", "sich", "des", "für", "wurde", "auch", "als", "bei", "dem", "wird", "sind", "aus", "aufgenommen"},
+	"fr": {"le", "la", "les", "et", "de", "des", "est", "un", "une", "du", "dans", "pour", "sur", "avec", "par", "au", "qui", "que", "sont", "ce", "il", "elle", "pas", "aux", "été", "enregistré"},
+	"es": {"el", "la", "los", "las", "y", "de", "del", "en", "es", "un", "una", "con", "por", "para", "que", "se", "su", "al", "fue", "como", "más", "sus", "lo", "grabado"},
+	"it": {"il", "la", "le", "gli", "e", "di", "del", "della", "che", "è", "un", "una", "per", "con", "in", "da", "sono", "non", "si", "nel", "alla", "dei", "delle", "anche", "registrato"},
+	"pt": {"o", "a", "os", "as", "e", "de", "do", "da", "dos", "das", "em", "no", "na", "um", "uma", "com", "por", "para", "que", "é", "foi", "não", "ao", "mais", "gravado"},
+	"nl": {"de", "het", "een", "en", "van", "is", "in", "op", "met", "voor", "zijn", "dat", "die", "niet", "te", "aan", "er", "ook", "als", "werd", "door", "bij", "naar", "uit", "opgenomen"},
+	"sv": {"och", "det", "att", "en", "ett", "är", "av", "för", "med", "som", "på", "till", "den", "inte", "har", "om", "var", "från", "men", "så", "också", "inspelad"},
+}
+
+// index maps each function word to the languages it belongs to.
+var index = func() map[string][]string {
+	words := make(map[string][]string)
+	for language, list := range functionWords {
+		for _, word := range list {
+			words[word] = append(words[word], language)
+		}
+	}
+	return words
+}()
+
+// Detect returns the ISO 639-1 code of text's language, or "" when it can't tell: too little
+// text, a Latin-script language it doesn't know, or no clear winner.
+func Detect(text string) string {
+	if language := detectScript(text); language != "" {
+		return language
+	}
+	return detectLatin(text)
+}
+
+// detectScript names the language of text written mostly in a script only one language (or
+// one family Detect maps to its largest member) uses.
+func detectScript(text string) string {
+	var letters, latin, han, kana, hangul, cyrillic, ukrainian, greek, arabic, hebrew, thai int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+			if strings.ContainsRune("ЇїЄєІіҐґ", r) {
+				ukrainian++
+			}
+		case unicode.Is(unicode.Greek, r):
+			greek++
+		case unicode.Is(unicode.Arabic, r):
+			arabic++
+		case unicode.Is(unicode.Hebrew, r):
+			hebrew++
+		case unicode.Is(unicode.Thai, r):
+			thai++
+		}
+	}
+	if letters == 0 || latin*2 >= letters {
+		return ""
+	}
+
+	switch {
+	case kana > 0:
+		return "ja"
+	case han > 0 && han >= hangul:
+		return "zh"
+	case hangul > 0:
+		return "ko"
+	case cyrillic*2 > letters:
+		if ukrainian > 0 {
+			return "uk"
+		}
+		return "ru"
+	case greek*2 > letters:
+		return "el"
+	case arabic*2 > letters:
+		return "ar"
+	case hebrew*2 > letters:
+		return "he"
+	case thai*2 > letters:
+		return "th"
+	}
+	return ""
+}
+
+// detectLatin scores Latin-script text by how many of its words are each language's function
+// words.
+func detectLatin(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) < minWords {
+		return ""
+	}
+
+	scores := make(map[string]int)
+	for _, word := range words {
+		for _, language := range index[word] {
+			scores[language]++
+		}
+	}
+
+	best, bestScore, runnerUp := "", 0, 0
+	for language, score := range scores {
+		switch {
+		case score > bestScore:
+			best, bestScore, runnerUp = language, score, bestScore
+		case score > runnerUp:
+			runnerUp = score
+		}
+	}
+	if bestScore < minHits || bestScore == runnerUp {
+		return ""
+	}
+	return best
+}
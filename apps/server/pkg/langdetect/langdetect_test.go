@@ -0,0 +1,37 @@
+package langdetect
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english notes", "Recorded at Abbey Road Studios in 1969. This is the last album the band recorded together.", "en"},
+		{"german notes", "Das Album wurde 1977 in Berlin aufgenommen und ist eines der wichtigsten Werke der Band.", "de"},
+		{"french notes", "Cet album a été enregistré à Paris et il est sorti sur le label Barclay avec une pochette de Jean.", "fr"},
+		{"spanish notes", "El disco fue grabado en Madrid y es uno de los mejores trabajos de la banda en los años ochenta.", "es"},
+		{"italian notes", "Il disco è stato registrato a Milano ed è uno dei lavori più importanti della band negli anni settanta.", "it"},
+		{"portuguese notes", "O disco foi gravado no Rio de Janeiro e é um dos mais importantes da carreira do artista.", "pt"},
+		{"dutch notes", "Het album werd opgenomen in Amsterdam en is een van de beste platen van de groep.", "nl"},
+		{"swedish notes", "Skivan är inspelad i Stockholm och det är en av de bästa skivorna som bandet har gjort.", "sv"},
+		{"japanese notes", "このアルバムは1985年に東京で録音されました。", "ja"},
+		{"chinese notes", "这张专辑于一九八五年在北京录制。", "zh"},
+		{"korean notes", "이 앨범은 서울에서 녹음되었습니다.", "ko"},
+		{"russian notes", "Альбом был записан в Москве в 1985 году.", "ru"},
+		{"ukrainian notes", "Альбом був записаний у Києві в 1985 році.", "uk"},
+		{"greek notes", "Το άλμπουμ ηχογραφήθηκε στην Αθήνα.", "el"},
+		{"too short", "Limited edition", ""},
+		{"credits only", "Bass – John Smith\nDrums – Jane Doe\nMastered By – Bob Ludwig", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.text); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Package logging builds the server's structured logger and carries request-scoped loggers
+// through contexts, so handlers, services, and source clients log with the ID of the request
+// that triggered them.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Formats accepted by New.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// RequestIDKey is the attribute request-scoped loggers carry.
+const RequestIDKey = "request_id"
+
+// maxRequestIDLength caps the client-supplied request IDs Request accepts.
+const maxRequestIDLength = 128
+
+type loggerKey struct{}
+
+// New returns a logger writing to w in format ("text" or "json"; anything else is text) that
+// drops records below level.
+func New(w io.Writer, format string, level slog.Leveler) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == FormatJSON {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// ParseLevel maps "debug", "info", "warn", or "error" (any case) onto a slog level.
+func ParseLevel(raw string) (slog.Level, bool) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(raw))); err != nil {
+		return slog.LevelInfo, false
+	}
+	return level, true
+}
+
+// levels is the order NextLevel steps through.
+var levels = []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+
+// NextLevel returns the level after current in the debug, info, warn, error cycle, wrapping
+// from error back to debug.
+func NextLevel(current slog.Level) slog.Level {
+	for _, level := range levels {
+		if level > current {
+			return level
+		}
+	}
+	return levels[0]
+}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger stored in ctx, or the default logger when there is none, so
+// code running outside a request (startup, background jobs) can call it too.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// Request returns a copy of ctx whose logger is tagged with a request ID, along with the ID.
+// A usable incoming ID (from a proxy's X-Request-ID, say) is kept so log lines correlate across
+// hops; otherwise a random one is generated.
+func Request(ctx context.Context, incoming string) (context.Context, string) {
+	id := incoming
+	if !validRequestID(id) {
+		id = rand.Text()
+	}
+	return WithLogger(ctx, FromContext(ctx).With(RequestIDKey, id)), id
+}
+
+// validRequestID accepts short IDs of printable ASCII without spaces, so a client can't inject
+// line breaks or bloat every log line.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := range len(id) {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
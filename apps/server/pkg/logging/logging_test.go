@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRequestTagsTheContextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	base := WithLogger(context.Background(), New(&buf, FormatJSON, slog.LevelInfo))
+
+	ctx, id := Request(base, "")
+	if id == "" {
+		t.Fatal("expected a generated request ID")
+	}
+	FromContext(ctx).Info("hello")
+	if !strings.Contains(buf.String(), `"request_id":"`+id+`"`) {
+		t.Fatalf("expected the log line to carry the request ID, got %q", buf.String())
+	}
+
+	if _, kept := Request(base, "proxy-7"); kept != "proxy-7" {
+		t.Errorf("expected the incoming ID to be kept, got %q", kept)
+	}
+	for _, bad := range []string{"has space", "line\nbreak", strings.Repeat("x", maxRequestIDLength+1)} {
+		if _, got := Request(base, bad); got == bad {
+			t.Errorf("expected %q to be replaced", bad)
+		}
+	}
+}
+
+func TestFromContextFallsBackToDefault(t *testing.T) {
+	if FromContext(context.Background()) != slog.Default() {
+		t.Fatal("expected the default logger outside a request")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for raw, want := range map[string]slog.Level{"debug": slog.LevelDebug, "INFO": slog.LevelInfo, " warn ": slog.LevelWarn, "error": slog.LevelError} {
+		if got, ok := ParseLevel(raw); !ok || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", raw, got, ok, want)
+		}
+	}
+	if _, ok := ParseLevel("loud"); ok {
+		t.Error("expected an unknown level to be rejected")
+	}
+}
+
+func TestNextLevelCycles(t *testing.T) {
+	level := slog.LevelDebug
+	var seen []slog.Level
+	for range 5 {
+		level = NextLevel(level)
+		seen = append(seen, level)
+	}
+	want := []slog.Level{slog.LevelInfo, slog.LevelWarn, slog.LevelError, slog.LevelDebug, slog.LevelInfo}
+	if !slices.Equal(seen, want) {
+		t.Fatalf("NextLevel cycle = %v, want %v", seen, want)
+	}
+}
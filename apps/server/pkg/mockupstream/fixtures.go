@@ -0,0 +1,409 @@
+package mockupstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// fixtures holds the embedded upstream payloads. Entities are kept as raw JSON in each
+// upstream's own wire format so lookups serve them verbatim; only the fields needed to
+// index, search, and browse them are decoded.
+type fixtures struct {
+	artists       []fixtureEntity
+	releaseGroups []fixtureReleaseGroup
+	releases      map[string]json.RawMessage
+	pages         map[string]json.RawMessage
+	discogs       []discogsFixture
+}
+
+type fixtureEntity struct {
+	ID   string
+	Name string
+	Raw  json.RawMessage
+}
+
+type fixtureReleaseGroup struct {
+	fixtureEntity
+	PrimaryType string
+	ArtistIDs   []string
+	ArtistName  string
+	ReleaseIDs  []string
+	Credit      json.RawMessage
+}
+
+type discogsFixture struct {
+	ID        int
+	Title     string
+	Artist    string
+	Year      string
+	Genre     []string
+	Style     []string
+	Raw       json.RawMessage
+	Community json.RawMessage
+}
+
+func loadFixtures() (*fixtures, error) {
+	var mb struct {
+		Artists       []json.RawMessage `json:"artists"`
+		ReleaseGroups []json.RawMessage `json:"releaseGroups"`
+		Releases      []json.RawMessage `json:"releases"`
+	}
+	var wiki struct {
+		Pages []json.RawMessage `json:"pages"`
+	}
+	var discogs struct {
+		Releases []json.RawMessage `json:"releases"`
+	}
+	for name, target := range map[string]any{
+		"fixtures/musicbrainz.json": &mb,
+		"fixtures/wikipedia.json":   &wiki,
+		"fixtures/discogs.json":     &discogs,
+	} {
+		raw, err := fixtureFiles.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("mockupstream: read %s: %w", name, err)
+		}
+		if err := json.Unmarshal(raw, target); err != nil {
+			return nil, fmt.Errorf("mockupstream: decode %s: %w", name, err)
+		}
+	}
+
+	f := &fixtures{
+		releases: make(map[string]json.RawMessage),
+		pages:    make(map[string]json.RawMessage),
+	}
+	for _, raw := range mb.Artists {
+		var artist struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(raw, &artist); err != nil {
+			return nil, fmt.Errorf("mockupstream: decode artist: %w", err)
+		}
+		f.artists = append(f.artists, fixtureEntity{ID: artist.ID, Name: artist.Name, Raw: raw})
+	}
+	for _, raw := range mb.ReleaseGroups {
+		var rg struct {
+			ID           string `json:"id"`
+			Title        string `json:"title"`
+			PrimaryType  string `json:"primary-type"`
+			ArtistCredit []struct {
+				Artist struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"artist"`
+			} `json:"artist-credit"`
+			Releases []struct {
+				ID string `json:"id"`
+			} `json:"releases"`
+		}
+		var credit struct {
+			ArtistCredit json.RawMessage `json:"artist-credit"`
+		}
+		if err := json.Unmarshal(raw, &rg); err != nil {
+			return nil, fmt.Errorf("mockupstream: decode release group: %w", err)
+		}
+		if err := json.Unmarshal(raw, &credit); err != nil {
+			return nil, fmt.Errorf("mockupstream: decode release group: %w", err)
+		}
+		group := fixtureReleaseGroup{
+			fixtureEntity: fixtureEntity{ID: rg.ID, Name: rg.Title, Raw: raw},
+			PrimaryType:   rg.PrimaryType,
+			Credit:        credit.ArtistCredit,
+		}
+		for _, credit := range rg.ArtistCredit {
+			group.ArtistIDs = append(group.ArtistIDs, credit.Artist.ID)
+			if group.ArtistName == "" {
+				group.ArtistName = credit.Artist.Name
+			}
+		}
+		for _, release := range rg.Releases {
+			group.ReleaseIDs = append(group.ReleaseIDs, release.ID)
+		}
+		f.releaseGroups = append(f.releaseGroups, group)
+	}
+	for _, raw := range mb.Releases {
+		var release struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &release); err != nil {
+			return nil, fmt.Errorf("mockupstream: decode release: %w", err)
+		}
+		f.releases[release.ID] = raw
+	}
+	for _, raw := range wiki.Pages {
+		var summary struct {
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(raw, &summary); err != nil {
+			return nil, fmt.Errorf("mockupstream: decode wikipedia page: %w", err)
+		}
+		f.pages[pageKey(summary.Title)] = raw
+	}
+	for _, raw := range discogs.Releases {
+		var release struct {
+			ID      int    `json:"id"`
+			Title   string `json:"title"`
+			Artists []struct {
+				Name string `json:"name"`
+			} `json:"artists"`
+			Year      string          `json:"year"`
+			Genre     []string        `json:"genre"`
+			Style     []string        `json:"style"`
+			Community json.RawMessage `json:"community"`
+		}
+		if err := json.Unmarshal(raw, &release); err != nil {
+			return nil, fmt.Errorf("mockupstream: decode discogs release: %w", err)
+		}
+		fixture := discogsFixture{
+			ID:        release.ID,
+			Title:     release.Title,
+			Year:      release.Year,
+			Genre:     release.Genre,
+			Style:     release.Style,
+			Raw:       raw,
+			Community: release.Community,
+		}
+		if len(release.Artists) > 0 {
+			fixture.Artist = release.Artists[0].Name
+		}
+		f.discogs = append(f.discogs, fixture)
+	}
+	return f, nil
+}
+
+func (f *fixtures) lookupArtist(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	for _, artist := range f.artists {
+		if artist.ID == id {
+			writeJSON(w, http.StatusOK, artist.Raw)
+			return
+		}
+	}
+	notFound(w)
+}
+
+func (f *fixtures) searchArtists(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("query")
+	matches := []json.RawMessage{}
+	for _, artist := range f.artists {
+		if matchesQuery(query, artist.Name) {
+			matches = append(matches, withScore(artist.Raw))
+		}
+	}
+	limit, offset := page(r, 25)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"artists": window(matches, limit, offset),
+		"count":   len(matches),
+		"offset":  offset,
+	})
+}
+
+func (f *fixtures) lookupReleaseGroup(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	for _, rg := range f.releaseGroups {
+		if rg.ID == id {
+			writeJSON(w, http.StatusOK, rg.Raw)
+			return
+		}
+	}
+	notFound(w)
+}
+
+// browseReleaseGroups answers /release-group?artist=<id>&type=album|ep.
+func (f *fixtures) browseReleaseGroups(w http.ResponseWriter, r *http.Request) {
+	artistID := r.URL.Query().Get("artist")
+	var types []string
+	if raw := r.URL.Query().Get("type"); raw != "" {
+		types = strings.Split(strings.ToLower(raw), "|")
+	}
+
+	matches := []json.RawMessage{}
+	for _, rg := range f.releaseGroups {
+		if !slices.Contains(rg.ArtistIDs, artistID) {
+			continue
+		}
+		if len(types) > 0 && !slices.Contains(types, strings.ToLower(rg.PrimaryType)) {
+			continue
+		}
+		matches = append(matches, rg.Raw)
+	}
+	f.writeReleaseGroups(w, r, matches)
+}
+
+func (f *fixtures) searchReleaseGroups(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("query")
+	matches := []json.RawMessage{}
+	for _, rg := range f.releaseGroups {
+		if matchesQuery(query, rg.Name) || matchesQuery(query, rg.ArtistName) {
+			matches = append(matches, withScore(rg.Raw))
+		}
+	}
+	f.writeReleaseGroups(w, r, matches)
+}
+
+func (f *fixtures) writeReleaseGroups(w http.ResponseWriter, r *http.Request, matches []json.RawMessage) {
+	limit, offset := page(r, 25)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"release-groups":       window(matches, limit, offset),
+		"release-group-count":  len(matches),
+		"release-group-offset": offset,
+	})
+}
+
+func (f *fixtures) lookupRelease(w http.ResponseWriter, r *http.Request) {
+	release, ok := f.releases[r.PathValue("id")]
+	if !ok {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, release)
+}
+
+// searchRecordings matches recording titles across every fixture release, crediting each
+// recording to its release group's artist.
+func (f *fixtures) searchRecordings(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("query")
+	matches := []map[string]any{}
+	for _, rg := range f.releaseGroups {
+		for _, releaseID := range rg.ReleaseIDs {
+			var release struct {
+				Media []struct {
+					Tracks []struct {
+						Recording struct {
+							ID     string `json:"id"`
+							Title  string `json:"title"`
+							Length int    `json:"length"`
+						} `json:"recording"`
+					} `json:"tracks"`
+				} `json:"media"`
+			}
+			if err := json.Unmarshal(f.releases[releaseID], &release); err != nil {
+				continue
+			}
+			for _, medium := range release.Media {
+				for _, track := range medium.Tracks {
+					if !matchesQuery(query, track.Recording.Title) {
+						continue
+					}
+					matches = append(matches, map[string]any{
+						"id":            track.Recording.ID,
+						"title":         track.Recording.Title,
+						"length":        track.Recording.Length,
+						"score":         100,
+						"artist-credit": rg.Credit,
+					})
+				}
+			}
+		}
+	}
+	limit, offset := page(r, 25)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"recordings": window(matches, limit, offset),
+		"count":      len(matches),
+		"offset":     offset,
+	})
+}
+
+func (f *fixtures) pageSummary(w http.ResponseWriter, r *http.Request) {
+	summary, ok := f.pages[pageKey(r.PathValue("title"))]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{
+			"type":  "https://mediawiki.org/wiki/HyperSwitch/errors/not_found",
+			"title": "Not found.",
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// searchDiscogs answers /database/search?q=<artist> <title>, pointing cover images back at
+// the mock so they resolve offline.
+func (f *fixtures) searchDiscogs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = 50
+	}
+
+	results := []map[string]any{}
+	for _, release := range f.discogs {
+		if !matchesQuery(query, release.Artist) || !matchesQuery(query, release.Title) {
+			continue
+		}
+		cover := fmt.Sprintf("http://%s%s/images/%d.svg", r.Host, DiscogsPrefix, release.ID)
+		results = append(results, map[string]any{
+			"id":          release.ID,
+			"type":        "release",
+			"title":       release.Artist + " - " + release.Title,
+			"year":        release.Year,
+			"genre":       release.Genre,
+			"style":       release.Style,
+			"thumb":       cover,
+			"cover_image": cover,
+			"community":   release.Community,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"results": window(results, perPage, 0)})
+}
+
+func (f *fixtures) discogsRelease(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err == nil {
+		for _, release := range f.discogs {
+			if release.ID == id {
+				writeJSON(w, http.StatusOK, release.Raw)
+				return
+			}
+		}
+	}
+	writeJSON(w, http.StatusNotFound, map[string]string{"message": "Release not found."})
+}
+
+// discogsMarketplaceStats answers /marketplace/stats/{id} with a copy for sale for every
+// thousand collectors who have the release.
+func (f *fixtures) discogsMarketplaceStats(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err == nil {
+		for _, release := range f.discogs {
+			if release.ID != id {
+				continue
+			}
+			var community struct {
+				Have int `json:"have"`
+			}
+			_ = json.Unmarshal(release.Community, &community)
+			writeJSON(w, http.StatusOK, map[string]any{
+				"lowest_price":      map[string]any{"currency": "USD", "value": 14.99},
+				"num_for_sale":      community.Have / 1000,
+				"blocked_from_sale": false,
+			})
+			return
+		}
+	}
+	writeJSON(w, http.StatusNotFound, map[string]string{"message": "Release not found."})
+}
+
+// withScore adds the perfect search score MusicBrainz attaches to exact matches.
+func withScore(raw json.RawMessage) json.RawMessage {
+	var entity map[string]any
+	if err := json.Unmarshal(raw, &entity); err != nil {
+		return raw
+	}
+	entity["score"] = 100
+	scored, err := json.Marshal(entity)
+	if err != nil {
+		return raw
+	}
+	return scored
+}
+
+// pageKey normalizes a Wikipedia title the way the REST API does: underscores and spaces are
+// interchangeable and matching is case-insensitive here for convenience.
+func pageKey(title string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(title), "_", " "))
+}
@@ -0,0 +1,162 @@
+// Package mockupstream serves canned MusicBrainz, Wikipedia, and Discogs responses so the
+// server and frontend can be developed offline. Each source lives under its own prefix
+// (/musicbrainz/ws/2, /wikipedia/api/rest_v1, /discogs) so the real clients only need their
+// base URLs pointed at the mock. Latency and failures can be injected to exercise retries,
+// deadlines, and degraded responses.
+package mockupstream
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed fixtures/*.json
+var fixtureFiles embed.FS
+
+// Path prefixes the real clients' base URLs should point at.
+const (
+	MusicBrainzPrefix = "/musicbrainz/ws/2"
+	WikipediaPrefix   = "/wikipedia/api/rest_v1"
+	DiscogsPrefix     = "/discogs"
+)
+
+// Config controls injected latency and failures. ErrorRate is the fraction of requests, from 0
+// to 1, answered with ErrorStatus (default 503) instead of fixture data; rate-limit statuses
+// carry a Retry-After of RetryAfter.
+type Config struct {
+	Latency     time.Duration
+	Jitter      time.Duration
+	ErrorRate   float64
+	ErrorStatus int
+	RetryAfter  time.Duration
+}
+
+// NewHandler returns a handler serving every mocked upstream from the embedded fixtures.
+func NewHandler(cfg Config) (http.Handler, error) {
+	return newHandler(cfg, rand.Float64)
+}
+
+func newHandler(cfg Config, random func() float64) (http.Handler, error) {
+	f, err := loadFixtures()
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+MusicBrainzPrefix+"/artist/{$}", f.searchArtists)
+	mux.HandleFunc("GET "+MusicBrainzPrefix+"/artist/{id}", f.lookupArtist)
+	mux.HandleFunc("GET "+MusicBrainzPrefix+"/release-group", f.browseReleaseGroups)
+	mux.HandleFunc("GET "+MusicBrainzPrefix+"/release-group/{$}", f.searchReleaseGroups)
+	mux.HandleFunc("GET "+MusicBrainzPrefix+"/release-group/{id}", f.lookupReleaseGroup)
+	mux.HandleFunc("GET "+MusicBrainzPrefix+"/release/{id}", f.lookupRelease)
+	mux.HandleFunc("GET "+MusicBrainzPrefix+"/recording/{$}", f.searchRecordings)
+	mux.HandleFunc("GET "+MusicBrainzPrefix+"/genre/all", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"genres": []any{}, "genre-count": 0})
+	})
+	mux.HandleFunc("GET "+WikipediaPrefix+"/page/summary/{title}", f.pageSummary)
+	mux.HandleFunc("GET "+DiscogsPrefix+"/{$}", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"hello": "Welcome to the freqshow mock Discogs API."})
+	})
+	mux.HandleFunc("GET "+DiscogsPrefix+"/database/search", f.searchDiscogs)
+	mux.HandleFunc("GET "+DiscogsPrefix+"/releases/{id}", f.discogsRelease)
+	mux.HandleFunc("GET "+DiscogsPrefix+"/marketplace/stats/{id}", f.discogsMarketplaceStats)
+	mux.HandleFunc("GET "+DiscogsPrefix+"/images/{id}", coverImage)
+
+	return inject(cfg, random, mux), nil
+}
+
+// inject delays each request by the configured latency and fails the configured fraction.
+func inject(cfg Config, random func() float64, next http.Handler) http.Handler {
+	status := cfg.ErrorStatus
+	if status == 0 {
+		status = http.StatusServiceUnavailable
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delay := cfg.Latency
+		if cfg.Jitter > 0 {
+			delay += time.Duration(random() * float64(cfg.Jitter))
+		}
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+
+		if cfg.ErrorRate > 0 && random() < cfg.ErrorRate {
+			if (status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable) && cfg.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(cfg.RetryAfter.Round(time.Second)/time.Second)))
+			}
+			writeJSON(w, status, map[string]string{"error": "injected failure"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func notFound(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+}
+
+// page parses MusicBrainz/Discogs style limit and offset parameters.
+func page(r *http.Request, defaultLimit int) (int, int) {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultLimit
+	}
+	offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+func window[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+	return items[offset:min(offset+limit, len(items))]
+}
+
+// matchesQuery reports whether every word of name appears in the query, which is enough to
+// match the Lucene queries and free-text searches the clients send for fixture entities.
+func matchesQuery(query, name string) bool {
+	query = strings.ToLower(query)
+	words := strings.Fields(strings.ToLower(name))
+	if len(words) == 0 {
+		return false
+	}
+	for _, word := range words {
+		if !strings.Contains(query, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// coverImage serves a placeholder SVG so Discogs cover URLs resolve offline.
+func coverImage(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(strings.TrimSuffix(r.PathValue("id"), ".svg"))
+	if err != nil {
+		notFound(w)
+		return
+	}
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="600" height="600"><rect width="600" height="600" fill="#2b2d42"/><text x="300" y="310" font-family="sans-serif" font-size="36" fill="#edf2f4" text-anchor="middle">mock cover %d</text></svg>`, id)
+}
@@ -0,0 +1,149 @@
+package mockupstream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/reviews"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/wikipedia"
+)
+
+const radioheadID = "a74b1b7f-71a5-4011-9441-d0b5e4122711"
+
+func newMockServer(t *testing.T, cfg Config, random func() float64) *httptest.Server {
+	t.Helper()
+	handler, err := newHandler(cfg, random)
+	if err != nil {
+		t.Fatalf("newHandler: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMockServesRealClients(t *testing.T) {
+	server := newMockServer(t, Config{}, func() float64 { return 0 })
+	ctx := context.Background()
+
+	mb, err := musicbrainz.New(ctx, musicbrainz.Config{BaseURL: server.URL + MusicBrainzPrefix, Contact: "dev@example.com"})
+	if err != nil {
+		t.Fatalf("musicbrainz.New: %v", err)
+	}
+
+	artist, err := mb.LookupArtist(ctx, radioheadID)
+	if err != nil || artist.Name != "Radiohead" {
+		t.Fatalf("LookupArtist = %+v, %v", artist, err)
+	}
+	if _, err := mb.LookupArtist(ctx, "00000000-0000-0000-0000-000000000000"); !errors.Is(err, musicbrainz.ErrNotFound) {
+		t.Fatalf("LookupArtist(unknown) error = %v, want ErrNotFound", err)
+	}
+
+	search, err := mb.SearchArtists(ctx, "radiohead", 10, 0)
+	if err != nil || len(search.Artists) != 1 || search.Artists[0].ID != radioheadID {
+		t.Fatalf("SearchArtists = %+v, %v", search, err)
+	}
+
+	groups, err := mb.GetArtistReleaseGroups(ctx, radioheadID, 100, 0)
+	if err != nil || len(groups.ReleaseGroups) != 2 || groups.Count != 2 {
+		t.Fatalf("GetArtistReleaseGroups = %+v, %v", groups, err)
+	}
+
+	tracks, err := mb.GetReleaseGroupTracks(ctx, groups.ReleaseGroups[0].ID)
+	if err != nil || len(tracks) == 0 {
+		t.Fatalf("GetReleaseGroupTracks = %d tracks, %v", len(tracks), err)
+	}
+
+	wiki, err := wikipedia.New(ctx, wikipedia.Config{BaseURL: server.URL + WikipediaPrefix})
+	if err != nil {
+		t.Fatalf("wikipedia.New: %v", err)
+	}
+	biography, err := wiki.GetArtistBiography(ctx, "Radiohead")
+	if err != nil || !strings.Contains(biography, "English rock band") {
+		t.Fatalf("GetArtistBiography = %q, %v", biography, err)
+	}
+
+	discogs := reviews.NewClient(reviews.Config{DiscogsBaseURL: server.URL + DiscogsPrefix})
+	review, err := discogs.GetAlbumReview(ctx, "Radiohead", "OK Computer")
+	if err != nil || review.Rating == 0 {
+		t.Fatalf("GetAlbumReview = %+v, %v", review, err)
+	}
+	_, release, err := discogs.GetAlbumReviewsAndRelease(ctx, "Radiohead", "OK Computer")
+	if err != nil || release == nil || release.Label != "Parlophone" {
+		t.Fatalf("GetAlbumReviewsAndRelease release = %+v, %v", release, err)
+	}
+	marketplace, err := discogs.GetMarketplaceStats(ctx, release.ID)
+	if err != nil || marketplace.NumForSale == 0 || marketplace.LowestPrice == 0 {
+		t.Fatalf("GetMarketplaceStats = %+v, %v", marketplace, err)
+	}
+	images, err := discogs.AlbumImages(ctx, "", "Radiohead", "OK Computer")
+	if err != nil || len(images) != 1 || !strings.HasPrefix(images[0].URL, server.URL+DiscogsPrefix+"/images/") {
+		t.Fatalf("AlbumImages = %+v, %v", images, err)
+	}
+	resp, err := http.Get(images[0].URL)
+	if err != nil {
+		t.Fatalf("fetch cover: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "image/svg+xml" {
+		t.Fatalf("cover status = %d, content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+}
+
+func TestMockInjectsFailures(t *testing.T) {
+	server := newMockServer(t, Config{
+		ErrorRate:   0.5,
+		ErrorStatus: http.StatusTooManyRequests,
+		RetryAfter:  3 * time.Second,
+	}, func() float64 { return 0.25 })
+
+	resp, err := http.Get(server.URL + MusicBrainzPrefix + "/artist/" + radioheadID)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Retry-After"); got != "3" {
+		t.Fatalf("Retry-After = %q, want 3", got)
+	}
+
+	mb, err := musicbrainz.New(context.Background(), musicbrainz.Config{
+		BaseURL: server.URL + MusicBrainzPrefix,
+		Contact: "dev@example.com",
+		HTTP:    httpclient.Options{Retry: retry.Policy{MaxAttempts: 1}},
+	})
+	if err != nil {
+		t.Fatalf("musicbrainz.New: %v", err)
+	}
+	_, err = mb.LookupArtist(context.Background(), radioheadID)
+	var limited *retry.RateLimitedError
+	if !errors.As(err, &limited) || limited.RetryAfter != 3*time.Second {
+		t.Fatalf("LookupArtist error = %v, want rate limited with 3s Retry-After", err)
+	}
+}
+
+func TestMockAddsLatency(t *testing.T) {
+	server := newMockServer(t, Config{Latency: 30 * time.Millisecond}, func() float64 { return 1 })
+
+	start := time.Now()
+	resp, err := http.Get(server.URL + WikipediaPrefix + "/page/summary/Radiohead")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatalf("elapsed = %v, want at least the configured latency", elapsed)
+	}
+}
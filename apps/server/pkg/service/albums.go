@@ -0,0 +1,459 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+// AlbumService resolves albums by release group MBID, reading through the cache to MusicBrainz.
+type AlbumService interface {
+	// GetAlbum returns the enriched album with runtime totals attached. A merged MBID yields a *MovedError; other failures
+	// are *Error values wrapping one of the sentinel kinds.
+	GetAlbum(ctx context.Context, id string) (*data.Album, error)
+}
+
+type albumService struct {
+	deps    Deps
+	refresh refreshTracker
+	reviews refreshTracker
+	missing missingTracker
+}
+
+// NewAlbumService builds an AlbumService over deps.
+func NewAlbumService(deps Deps) AlbumService {
+	return &albumService{deps: deps}
+}
+
+func (s *albumService) GetAlbum(ctx context.Context, id string) (*data.Album, error) {
+	if err := resolveMoved(ctx, s.deps.Aliases, db.KindAlbum, id); err != nil {
+		return nil, err
+	}
+
+	album, err := s.getOrFetch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if album.ID != id {
+		return nil, &MovedError{Kind: db.KindAlbum, ID: id, CanonicalID: album.ID}
+	}
+	album.Runtime = data.ComputeAlbumRuntime(album.Tracks)
+	album.Reviews = localizeReviews(album.Reviews, languagesFrom(ctx))
+	return album, nil
+}
+
+func (s *albumService) getOrFetch(ctx context.Context, id string) (*data.Album, error) {
+	repo := s.deps.Albums
+	if forceRefresh(ctx) {
+		return s.fetch(ctx, id)
+	}
+	if repo != nil {
+		album, err := repo.GetAlbum(ctx, id)
+		if err != nil {
+			return nil, newError(ErrStorage, "album lookup failed")
+		}
+		if album != nil && s.refresh.stale(ctx, s.deps, db.KindAlbum, id, s.deps.CacheTTL.Albums) {
+			fresh, err := s.fetch(ctx, id)
+			if err == nil {
+				return fresh, nil
+			}
+			s.refresh.failed(id, s.deps.CacheTTL.Albums)
+		}
+		if album != nil && s.deps.Reviews != nil && s.reviews.stale(ctx, s.deps, db.KindAlbum, id, s.deps.CacheTTL.Reviews) {
+			if refreshed, err := s.refreshReviews(ctx, album); err == nil {
+				return refreshed, nil
+			}
+			s.reviews.failed(id, s.deps.CacheTTL.Reviews)
+		}
+		if album != nil {
+			// GetAlbum sets the runtime on what it returns, so leave a shared snapshot alone.
+			copied := *album
+			return &copied, nil
+		}
+	}
+
+	if s.missing.missing(id, time.Now()) {
+		return nil, newError(ErrNotFound, "album not found")
+	}
+	return s.fetch(ctx, id)
+}
+
+// refreshReviews refetches only the reviews of a cached album and saves the result. An empty
+// answer keeps the reviews already cached rather than wiping them.
+func (s *albumService) refreshReviews(ctx context.Context, album *data.Album) (*data.Album, error) {
+	if !sourceAvailable(s.deps.Reviews) {
+		return nil, newError(ErrUnavailable, "reviews source unavailable")
+	}
+	reviews, release, err := albumReviews(ctx, s.deps.Reviews, album.ArtistName, album.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed := *album
+	if len(reviews) == 0 {
+		return &refreshed, nil
+	}
+	refreshed.Links = maps.Clone(album.Links)
+	applyReviews(&refreshed, reviews)
+	applyRelease(&refreshed, release)
+	if marketplace := fetchMarketplace(ctx, s.deps.Marketplace, release, 1); marketplace != nil {
+		refreshed.Marketplace = marketplace
+	}
+	refreshed.Warnings = data.ClearWarnings(refreshed.Warnings, db.QualityReviews)
+	if err := s.deps.Albums.SaveAlbum(ctx, &refreshed); err != nil {
+		return nil, err
+	}
+	notifyRatingChange(ctx, s.deps.RatingEvents, album, &refreshed)
+	return &refreshed, nil
+}
+
+// fetch looks the album up in MusicBrainz, enriches it, and caches the result.
+func (s *albumService) fetch(ctx context.Context, id string) (*data.Album, error) {
+	repo, client := s.deps.Albums, s.deps.MusicBrainz
+	if client == nil {
+		return nil, newError(ErrUnavailable, "musicbrainz client unavailable")
+	}
+
+	remote, err := client.LookupReleaseGroup(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, musicbrainz.ErrNotFound):
+			s.missing.remember(id, s.deps.CacheTTL.NotFound, time.Now())
+			return nil, newError(ErrNotFound, "album not found")
+		default:
+			return nil, upstreamError(err, "musicbrainz lookup failed")
+		}
+	}
+
+	domainAlbum := transformAlbum(remote)
+	if domainAlbum.ID == "" {
+		domainAlbum.ID = id
+	}
+
+	// Track listings, editions, credits, and every optional source after them are best-effort:
+	// a failure leaves the field empty and a warning on the album rather than failing the request.
+	var warned warnings
+	// Reviews come from another source than the MusicBrainz lookups below, so they are fetched
+	// alongside them.
+	pendingReviews := s.startReviews(ctx, domainAlbum.ArtistName, domainAlbum.Title)
+
+	tracks, err := client.GetReleaseGroupTracks(ctx, domainAlbum.ID)
+	if err == nil {
+		domainAlbum.Tracks = transformTracks(tracks)
+	}
+	warned.add(db.QualityTracks, sourceMusicBrainz, err)
+
+	editions, err := client.GetReleaseGroupEditions(ctx, domainAlbum.ID)
+	if err == nil {
+		domainAlbum.Editions = transformEditions(editions)
+		domainAlbum.Label, domainAlbum.LabelID = originalLabel(domainAlbum.Editions)
+	}
+	warned.add(fieldEditions, sourceMusicBrainz, err)
+
+	credits, err := client.GetReleaseGroupCredits(ctx, domainAlbum.ID)
+	if err == nil {
+		domainAlbum.ProductionCredits = transformProductionCredits(credits)
+	}
+	warned.add(fieldProductionCredits, sourceMusicBrainz, err)
+
+	if reviewsClient := s.deps.Reviews; reviewsClient != nil {
+		source := sourceName(reviewsClient, sourceReviews)
+		if pendingReviews != nil {
+			fetched := <-pendingReviews
+			if fetched.err == nil {
+				applyReviews(domainAlbum, fetched.reviews)
+				applyRelease(domainAlbum, fetched.release)
+				domainAlbum.Marketplace = fetchMarketplace(ctx, s.deps.Marketplace, fetched.release, 5)
+			}
+			warned.add(db.QualityReviews, source, fetched.err)
+		} else {
+			warned.unavailable(db.QualityReviews, source)
+		}
+	}
+
+	// Articles that can't be found leave charts and certifications empty without a warning.
+	if facts := s.deps.AlbumFacts; facts != nil {
+		source := sourceName(facts, sourceWikipedia)
+		if sourceAvailable(facts) {
+			stepCtx, cancel := enrichmentStep(ctx, 4)
+			parsed, err := facts.GetAlbumFacts(stepCtx, domainAlbum.ArtistName, domainAlbum.Title, domainAlbum.Links[musicbrainz.LinkWikipedia])
+			cancel()
+			if err == nil {
+				domainAlbum.Charts = parsed.Charts
+				domainAlbum.Certifications = parsed.Certifications
+			}
+			warned.add(fieldCharts, source, err)
+		} else {
+			warned.unavailable(fieldCharts, source)
+		}
+	}
+
+	fetchAlbumInfo(ctx, s.deps.Lastfm, domainAlbum, 3, &warned)
+
+	domainAlbum.Awards = fetchAwards(ctx, s.deps.Awards, domainAlbum.Links, &warned)
+
+	if images := s.deps.Images; images != nil {
+		stepCtx, cancel := enrichmentStep(ctx, 1)
+		domainAlbum.Images = images.AlbumImages(stepCtx, domainAlbum.ID, domainAlbum.ArtistName, domainAlbum.Title)
+		cancel()
+	}
+	domainAlbum.Warnings = warned
+
+	if repo != nil {
+		// The copy being replaced is read first so a changed rating can be reported.
+		var previous *data.Album
+		if s.deps.RatingEvents.Notifier != nil {
+			previous, _ = repo.GetAlbum(ctx, domainAlbum.ID)
+		}
+		if err := s.persist(ctx, id, domainAlbum); err != nil {
+			return nil, newError(ErrStorage, "album cache failed")
+		}
+		notifyRatingChange(ctx, s.deps.RatingEvents, previous, domainAlbum)
+	}
+
+	return domainAlbum, nil
+}
+
+// persist is artistService.persist for albums.
+func (s *albumService) persist(ctx context.Context, requestedID string, album *data.Album) error {
+	if tx, ok := s.deps.Albums.(db.Transactor); ok {
+		return tx.WithTx(ctx, func(repos db.Repos) error {
+			if err := repos.SaveAlbum(ctx, album); err != nil {
+				return err
+			}
+			if album.ID != requestedID {
+				return repos.SaveAlias(ctx, db.KindAlbum, requestedID, album.ID)
+			}
+			return nil
+		})
+	}
+
+	if err := s.deps.Albums.SaveAlbum(ctx, album); err != nil {
+		return err
+	}
+	recordAlias(ctx, s.deps.Aliases, db.KindAlbum, requestedID, album.ID)
+	return nil
+}
+
+// applyReviews sets an album's reviews, tagged with their language, and aggregate rating,
+// linking the Discogs page a review came from.
+func applyReviews(album *data.Album, reviews []data.Review) {
+	tagReviewLanguages(reviews)
+	album.Reviews = reviews
+	album.Rating = data.NewAggregateRating(reviews)
+	for _, review := range reviews {
+		if review.URL != "" && strings.EqualFold(review.Source, "discogs") {
+			album.Links = mergeLink(album.Links, musicbrainz.LinkDiscogs, review.URL)
+		}
+	}
+}
+
+func transformAlbum(src *musicbrainz.ReleaseGroup) *data.Album {
+	if src == nil {
+		return nil
+	}
+
+	releaseDate := src.ReleaseDate()
+	album := &data.Album{
+		ID:               src.ID,
+		Title:            src.Title,
+		ArtistID:         src.PrimaryArtistID(),
+		ArtistName:       src.PrimaryArtistName(),
+		PrimaryType:      src.PrimaryType,
+		SecondaryTypes:   append([]string(nil), src.SecondaryTypes...),
+		FirstReleaseDate: releaseDate,
+		Year:             releaseDate.Year,
+		Genre:            "",
+		Label:            "",
+		Tracks:           nil,
+		Reviews:          nil,
+		Images:           nil,
+		Links:            musicbrainz.Links(src.Relations),
+		Credits:          transformCredits(src.ArtistCredit),
+		ArtistCredit:     musicbrainz.JoinCredits(src.ArtistCredit),
+	}
+	if len(src.Genres) > 0 {
+		album.Genre = src.Genres[0]
+	}
+	return album
+}
+
+// transformEditions flattens MusicBrainz releases into editions, oldest first with undated
+// releases last. Only the first label credit is kept.
+func transformEditions(src []musicbrainz.Edition) []data.Edition {
+	if len(src) == 0 {
+		return nil
+	}
+	editions := make([]data.Edition, 0, len(src))
+	for _, release := range src {
+		edition := data.Edition{
+			ReleaseID:  release.ID,
+			Title:      release.Title,
+			Status:     release.Status,
+			Format:     release.Format(),
+			Country:    release.Country,
+			Date:       data.PartialDateOf(release.Date),
+			Barcode:    release.Barcode,
+			TrackCount: release.TrackCount,
+		}
+		if len(release.Labels) > 0 {
+			edition.Label = release.Labels[0].Name
+			edition.LabelID = release.Labels[0].ID
+			edition.CatalogNumber = release.Labels[0].CatalogNumber
+		}
+		editions = append(editions, edition)
+	}
+	sort.SliceStable(editions, func(i, j int) bool {
+		a, b := editions[i].Date, editions[j].Date
+		if a.IsZero() != b.IsZero() {
+			return !a.IsZero()
+		}
+		return a.Before(b)
+	})
+	return editions
+}
+
+// creditRoles names MusicBrainz production relationship types for display, in the order
+// credits are listed.
+var creditRoles = []struct {
+	relationType string
+	role         string
+}{
+	{"producer", "Producer"},
+	{"arranger", "Arranger"},
+	{"recording", "Recording engineer"},
+	{"engineer", "Engineer"},
+	{"audio", "Audio engineer"},
+	{"sound", "Sound engineer"},
+	{"programming", "Programming"},
+	{"mix", "Mixer"},
+	{"mastering", "Mastering engineer"},
+}
+
+// creditQualifiers are relationship attributes that refine a role, e.g. an executive producer.
+var creditQualifiers = map[string]string{
+	"executive":  "Executive",
+	"co":         "Co-",
+	"assistant":  "Assistant",
+	"associate":  "Associate",
+	"additional": "Additional",
+}
+
+// transformProductionCredits labels MusicBrainz credits for display, grouped by role in
+// creditRoles order.
+func transformProductionCredits(src []musicbrainz.Credit) []data.ProductionCredit {
+	if len(src) == 0 {
+		return nil
+	}
+	rank := make(map[string]int, len(creditRoles))
+	for i, role := range creditRoles {
+		rank[role.relationType] = i
+	}
+
+	sorted := append([]musicbrainz.Credit(nil), src...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rank[sorted[i].Type] < rank[sorted[j].Type]
+	})
+
+	credits := make([]data.ProductionCredit, 0, len(sorted))
+	for _, credit := range sorted {
+		credits = append(credits, data.ProductionCredit{
+			ArtistID: credit.ArtistID,
+			Name:     credit.ArtistName,
+			Role:     creditRole(credit.Type, credit.Attributes),
+			Tracks:   append([]int(nil), credit.Tracks...),
+		})
+	}
+	return credits
+}
+
+// creditRole renders a relationship type and its qualifying attributes, e.g. "Executive
+// producer" or "Co-producer".
+func creditRole(relationType string, attributes []string) string {
+	role := relationType
+	for _, known := range creditRoles {
+		if known.relationType == relationType {
+			role = known.role
+			break
+		}
+	}
+	for _, attribute := range attributes {
+		qualifier, ok := creditQualifiers[strings.ToLower(attribute)]
+		if !ok {
+			continue
+		}
+		if strings.HasSuffix(qualifier, "-") {
+			return qualifier + strings.ToLower(role)
+		}
+		return qualifier + " " + strings.ToLower(role)
+	}
+	return role
+}
+
+// originalLabel returns the label of the earliest edition that credits one, preferring credits
+// linked to a MusicBrainz label so the album can point at its label page.
+func originalLabel(editions []data.Edition) (string, string) {
+	name := ""
+	for _, edition := range editions {
+		if edition.LabelID != "" {
+			return edition.Label, edition.LabelID
+		}
+		if name == "" {
+			name = edition.Label
+		}
+	}
+	return name, ""
+}
+
+func transformCredits(credits []musicbrainz.ArtistCredit) []data.ArtistCredit {
+	if len(credits) == 0 {
+		return nil
+	}
+	result := make([]data.ArtistCredit, 0, len(credits))
+	for _, credit := range credits {
+		name := credit.Artist.Name
+		if name == "" {
+			name = credit.Name
+		}
+		result = append(result, data.ArtistCredit{ArtistID: credit.Artist.ID, Name: name, JoinPhrase: credit.JoinPhrase})
+	}
+	return result
+}
+
+// mergeLink adds a link unless MusicBrainz already supplied one for that service.
+func mergeLink(links map[string]string, key, value string) map[string]string {
+	if links == nil {
+		links = make(map[string]string)
+	}
+	if _, exists := links[key]; !exists {
+		links[key] = value
+	}
+	return links
+}
+
+func transformTracks(mbTracks []musicbrainz.Track) []data.Track {
+	if len(mbTracks) == 0 {
+		return nil
+	}
+
+	tracks := make([]data.Track, 0, len(mbTracks))
+	for _, mbTrack := range mbTracks {
+		track := data.Track{
+			Number:       mbTrack.Number,
+			Title:        mbTrack.Title,
+			LengthMs:     mbTrack.Length,
+			RecordingID:  mbTrack.Recording.ID,
+			Credits:      transformCredits(mbTrack.ArtistCredit),
+			ArtistCredit: musicbrainz.JoinCredits(mbTrack.ArtistCredit),
+		}
+		tracks = append(tracks, track)
+	}
+	return tracks
+}
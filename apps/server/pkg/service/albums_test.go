@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func TestGetAlbumAttachesRuntime(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	cached := &data.Album{ID: testAlbumID, Title: "Nevermind", Tracks: []data.Track{
+		{Number: 1, Title: "Smells Like Teen Spirit", LengthMs: 301000},
+		{Number: 2, Title: "In Bloom", LengthMs: 255000},
+	}}
+	if err := store.SaveAlbum(context.Background(), cached); err != nil {
+		t.Fatalf("SaveAlbum: %v", err)
+	}
+
+	album, err := NewAlbumService(Deps{Albums: store}).GetAlbum(context.Background(), testAlbumID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if album.Runtime == nil || album.Runtime.TrackCount != 2 || album.Runtime.Total != "9:16" {
+		t.Errorf("expected runtime totals, got %+v", album.Runtime)
+	}
+}
+
+func TestTransformProductionCreditsLabelsRoles(t *testing.T) {
+	credits := transformProductionCredits([]musicbrainz.Credit{
+		{ArtistID: "andy", ArtistName: "Andy Wallace", Type: "mix", Tracks: []int{1, 2}},
+		{ArtistID: "gary", ArtistName: "Gary Gersh", Type: "producer", Attributes: []string{"executive"}},
+		{ArtistID: "butch", ArtistName: "Butch Vig", Type: "producer"},
+		{ArtistID: "craig", ArtistName: "Craig Doubet", Type: "engineer", Attributes: []string{"assistant"}},
+		{ArtistID: "kurt", ArtistName: "Kurt Cobain", Type: "producer", Attributes: []string{"co"}},
+	})
+
+	want := []string{"Executive producer", "Producer", "Co-producer", "Assistant engineer", "Mixer"}
+	if len(credits) != len(want) {
+		t.Fatalf("expected %d credits, got %+v", len(want), credits)
+	}
+	for i, role := range want {
+		if credits[i].Role != role {
+			t.Errorf("credit %d: expected role %q, got %q", i, role, credits[i].Role)
+		}
+	}
+	if len(credits[4].Tracks) != 2 {
+		t.Errorf("expected mixer tracks to be kept, got %+v", credits[4])
+	}
+}
+
+func TestTransformAlbumKeepsFullCredit(t *testing.T) {
+	album := transformAlbum(&musicbrainz.ReleaseGroup{
+		ID:    "rg",
+		Title: "Watch the Throne",
+		ArtistCredit: []musicbrainz.ArtistCredit{
+			{Name: "JAY Z", JoinPhrase: " & ", Artist: musicbrainz.ReleaseGroupArtist{ID: "jay", Name: "JAY-Z"}},
+			{Name: "Kanye West", Artist: musicbrainz.ReleaseGroupArtist{ID: "kanye", Name: "Kanye West"}},
+		},
+	})
+
+	if album.ArtistCredit != "JAY Z & Kanye West" {
+		t.Errorf("expected the full credit, got %q", album.ArtistCredit)
+	}
+	if len(album.Credits) != 2 || album.Credits[0].JoinPhrase != " & " || album.Credits[1].ArtistID != "kanye" {
+		t.Errorf("expected ordered credits, got %+v", album.Credits)
+	}
+}
@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+const (
+	// defaultMaxArtistAlbums bounds the discography fetched alongside an artist when
+	// Deps.MaxArtistAlbums is unset.
+	defaultMaxArtistAlbums = 200
+	// artistReleaseGroupPage is the page size for discography browses, MusicBrainz's maximum.
+	artistReleaseGroupPage = 100
+)
+
+// ArtistService resolves artists by MBID, reading through the cache to MusicBrainz.
+type ArtistService interface {
+	// GetArtist returns the artist with discography stats attached, enriched to the depth set
+	// on ctx with WithDepth (full by default) and localized to the languages set with
+	// WithLanguages. A merged MBID yields a *MovedError; other failures are *Error values
+	// wrapping one of the sentinel kinds.
+	GetArtist(ctx context.Context, id string) (*data.Artist, error)
+}
+
+type artistService struct {
+	deps    Deps
+	refresh refreshTracker
+	missing missingTracker
+}
+
+// NewArtistService builds an ArtistService over deps.
+func NewArtistService(deps Deps) ArtistService {
+	return &artistService{deps: deps}
+}
+
+func (s *artistService) GetArtist(ctx context.Context, id string) (*data.Artist, error) {
+	if err := resolveMoved(ctx, s.deps.Aliases, db.KindArtist, id); err != nil {
+		return nil, err
+	}
+
+	artist, err := s.getOrFetch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if artist.ID != id {
+		return nil, &MovedError{Kind: db.KindArtist, ID: id, CanonicalID: artist.ID}
+	}
+	trimArtist(artist, depthFrom(ctx))
+	s.localizeArtist(ctx, artist)
+	artist.Stats = s.discographyStats(ctx, artist)
+	return artist, nil
+}
+
+// getOrFetch serves the cached artist or fetches one to the requested depth. Only full fetches
+// are cached, so a shallow lookup never leaves a partial record behind, and only full lookups
+// refresh a cached artist past its TTL. A forced refresh always fetches in full, so the stored
+// record is replaced whatever depth was asked for.
+func (s *artistService) getOrFetch(ctx context.Context, id string) (*data.Artist, error) {
+	repo, mbClient := s.deps.Artists, s.deps.MusicBrainz
+	depth := depthFrom(ctx)
+	if forceRefresh(ctx) {
+		return s.fetch(ctx, id, DepthFull)
+	}
+	if repo != nil {
+		artist, err := repo.GetArtist(ctx, id)
+		if err != nil {
+			return nil, newError(ErrStorage, "artist lookup failed")
+		}
+		if artist != nil && depth.includes(DepthFull) && s.refresh.stale(ctx, s.deps, db.KindArtist, id, s.deps.CacheTTL.Artists) {
+			fresh, err := s.fetch(ctx, id, depth)
+			if err == nil {
+				return fresh, nil
+			}
+			s.refresh.failed(id, s.deps.CacheTTL.Artists)
+		}
+		if artist != nil {
+			// The cached artist may be a snapshot shared with other readers; everything below
+			// and in GetArtist replaces fields rather than editing them, so a shallow copy is enough.
+			copied := *artist
+			artist = &copied
+
+			// If cached artist has no albums, fetch them
+			if len(artist.Albums) == 0 && mbClient != nil && depth.includes(DepthStandard) {
+				albums, truncated, err := fetchArtistAlbums(ctx, mbClient, id, s.deps.maxArtistAlbums())
+				if err == nil {
+					artist.Albums, artist.AlbumsTruncated = albums, truncated
+					artist.Warnings = data.ClearWarnings(artist.Warnings, db.QualityAlbums)
+					// Update the cached artist with albums
+					_ = repo.SaveArtist(ctx, artist)
+				} else {
+					var warned warnings
+					warned.add(db.QualityAlbums, sourceMusicBrainz, err)
+					artist.Warnings = append(slices.Clip(artist.Warnings), warned...)
+				}
+			}
+			return artist, nil
+		}
+	}
+
+	if s.missing.missing(id, time.Now()) {
+		return nil, newError(ErrNotFound, "artist not found")
+	}
+	return s.fetch(ctx, id, depth)
+}
+
+// fetch looks the artist up in MusicBrainz and enriches it to depth, caching full fetches.
+func (s *artistService) fetch(ctx context.Context, id string, depth Depth) (*data.Artist, error) {
+	repo, mbClient := s.deps.Artists, s.deps.MusicBrainz
+	if mbClient == nil {
+		return nil, newError(ErrUnavailable, "musicbrainz client unavailable")
+	}
+
+	remote, err := mbClient.LookupArtist(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, musicbrainz.ErrNotFound):
+			s.missing.remember(id, s.deps.CacheTTL.NotFound, time.Now())
+			return nil, newError(ErrNotFound, "artist not found")
+		default:
+			return nil, upstreamError(err, "musicbrainz lookup failed")
+		}
+	}
+
+	domainArtist := transformArtist(remote)
+	if domainArtist.ID == "" {
+		domainArtist.ID = id
+	}
+
+	if !depth.includes(DepthStandard) {
+		return domainArtist, nil
+	}
+
+	// Biography, Last.fm info and similar artists, awards, and images share the enrichment
+	// budget; standard depth stops after the biography.
+	optionalSteps := 1
+	if depth.includes(DepthFull) {
+		optionalSteps = 5
+	}
+
+	// Fetch biography from Wikipedia. A failure leaves a warning rather than failing the lookup.
+	var warned warnings
+	if wikiClient := s.deps.Wikipedia; wikiClient != nil {
+		if sourceAvailable(wikiClient) {
+			stepCtx, cancel := enrichmentStep(ctx, optionalSteps)
+			biography, attribution, err := fetchBiography(stepCtx, wikiClient, remote.Name, domainArtist.Links)
+			cancel()
+			if err == nil {
+				domainArtist.Biography, domainArtist.BiographyAttribution = biography, attribution
+			}
+			warned.add(db.QualityBiography, sourceName(wikiClient, sourceWikipedia), err)
+		} else {
+			warned.unavailable(db.QualityBiography, sourceName(wikiClient, sourceWikipedia))
+		}
+	}
+
+	if depth.includes(DepthFull) {
+		fetchArtistInfo(ctx, s.deps.Lastfm, domainArtist, 4, &warned)
+		fetchSimilarArtists(ctx, s.deps.Lastfm, domainArtist, 3, &warned)
+
+		domainArtist.Awards = fetchAwards(ctx, s.deps.Awards, domainArtist.Links, &warned)
+
+		if images := s.deps.Images; images != nil {
+			stepCtx, cancel := enrichmentStep(ctx, 1)
+			domainArtist.Images = images.ArtistImages(stepCtx, domainArtist.ID, domainArtist.Name)
+			cancel()
+		}
+	}
+
+	// Fetch artist's albums/release groups. Browse requests do not follow merges, so use the
+	// canonical ID MusicBrainz returned.
+	albums, truncated, err := fetchArtistAlbums(ctx, mbClient, domainArtist.ID, s.deps.maxArtistAlbums())
+	if err != nil {
+		// Don't fail the artist lookup if albums can't be fetched; warn and continue with
+		// empty albums
+		domainArtist.Albums = nil
+		warned.add(db.QualityAlbums, sourceMusicBrainz, err)
+	} else {
+		domainArtist.Albums, domainArtist.AlbumsTruncated = albums, truncated
+	}
+	domainArtist.Warnings = warned
+
+	if repo != nil && depth.includes(DepthFull) {
+		if err := s.persist(ctx, id, domainArtist); err != nil {
+			return nil, newError(ErrStorage, "artist cache failed")
+		}
+	}
+
+	return domainArtist, nil
+}
+
+// fetchArtistAlbums pages through an artist's albums and EPs up to max. truncated reports that
+// MusicBrainz lists more than were fetched, including when a later page fails; only a failed
+// first page is an error.
+func fetchArtistAlbums(ctx context.Context, client MusicBrainzClient, artistID string, max int) ([]data.Album, bool, error) {
+	var releaseGroups []musicbrainz.ReleaseGroup
+	for offset := 0; offset < max; {
+		limit := min(artistReleaseGroupPage, max-offset)
+		result, err := client.GetArtistReleaseGroups(ctx, artistID, limit, offset)
+		if err != nil {
+			if offset == 0 {
+				return nil, false, err
+			}
+			return transformReleaseGroupsToAlbums(releaseGroups), true, nil
+		}
+		releaseGroups = append(releaseGroups, result.ReleaseGroups...)
+		offset += limit
+		if len(result.ReleaseGroups) == 0 || offset >= result.Count {
+			return transformReleaseGroupsToAlbums(releaseGroups), false, nil
+		}
+	}
+	return transformReleaseGroupsToAlbums(releaseGroups), true, nil
+}
+
+// persist saves a freshly fetched artist. Stores that support transactions write the artist
+// and any merged-ID alias atomically; others save the alias best-effort afterwards.
+func (s *artistService) persist(ctx context.Context, requestedID string, artist *data.Artist) error {
+	if tx, ok := s.deps.Artists.(db.Transactor); ok {
+		return tx.WithTx(ctx, func(repos db.Repos) error {
+			if err := repos.SaveArtist(ctx, artist); err != nil {
+				return err
+			}
+			if artist.ID != requestedID {
+				return repos.SaveAlias(ctx, db.KindArtist, requestedID, artist.ID)
+			}
+			return nil
+		})
+	}
+
+	if err := s.deps.Artists.SaveArtist(ctx, artist); err != nil {
+		return err
+	}
+	recordAlias(ctx, s.deps.Aliases, db.KindArtist, requestedID, artist.ID)
+	return nil
+}
+
+// discographyStats computes stats on read so they follow the cached discography, pulling
+// ratings from any albums that have been looked up in full. The albums are read in one batch;
+// a repository that can't batch leaves ratings out rather than costing a lookup per album.
+func (s *artistService) discographyStats(ctx context.Context, artist *data.Artist) *data.DiscographyStats {
+	if len(artist.Albums) == 0 {
+		return nil
+	}
+	albums := artist.Albums
+	if batch, ok := s.deps.Albums.(db.AlbumBatchReader); ok {
+		ids := make([]string, len(albums))
+		for i, album := range albums {
+			ids[i] = album.ID
+		}
+		cached, err := batch.GetAlbums(ctx, ids)
+		if err == nil && len(cached) > 0 {
+			ratings := make(map[string]*data.AggregateRating, len(cached))
+			for _, album := range cached {
+				ratings[album.ID] = album.Rating
+			}
+			albums = slices.Clone(albums)
+			for i := range albums {
+				if rating, ok := ratings[albums[i].ID]; ok {
+					albums[i].Rating = rating
+				}
+			}
+		}
+	}
+	return data.ComputeDiscographyStats(artist.ID, albums)
+}
+
+func transformArtist(src *musicbrainz.Artist) *data.Artist {
+	if src == nil {
+		return nil
+	}
+	members, memberOf := transformMemberships(src.Memberships)
+	return &data.Artist{
+		ID:             src.ID,
+		Name:           src.Name,
+		Biography:      "",
+		Genres:         mergeGenres(slices.Clone(src.Genres), src.Tags),
+		Albums:         nil,
+		Related:        relatedFromMusicBrainz(src),
+		Images:         nil,
+		Links:          musicbrainz.Links(src.Relations),
+		Country:        src.Country,
+		Type:           src.Type,
+		Disambiguation: src.Disambiguation,
+		Aliases:        append([]string(nil), src.Aliases...),
+		LocalizedNames: src.LocalizedNames,
+		LifeSpan: data.LifeSpan{
+			Begin: data.PartialDateOf(src.LifeSpan.Begin),
+			End:   data.PartialDateOf(src.LifeSpan.End),
+			Ended: src.LifeSpan.Ended,
+		},
+		Members:  members,
+		MemberOf: memberOf,
+	}
+}
+
+// tenureAttributes are membership qualifiers rather than roles played in the band.
+var tenureAttributes = map[string]bool{
+	"original":   true,
+	"founder":    true,
+	"additional": true,
+	"minor":      true,
+}
+
+// transformMemberships splits MusicBrainz band relations into the members of a group and the
+// groups a person belonged to, ordered by tenure start.
+func transformMemberships(relations []musicbrainz.ArtistRelation) ([]data.Membership, []data.Membership) {
+	var members, memberOf []data.Membership
+	for _, rel := range relations {
+		membership := data.Membership{
+			ArtistID: rel.ArtistID,
+			Name:     rel.ArtistName,
+			Begin:    data.PartialDateOf(rel.Begin),
+			End:      data.PartialDateOf(rel.End),
+			Current:  !rel.Ended && rel.End == "",
+		}
+		for _, attr := range rel.Attributes {
+			if !tenureAttributes[strings.ToLower(attr)] {
+				membership.Roles = append(membership.Roles, attr)
+			}
+		}
+		if rel.Group {
+			memberOf = append(memberOf, membership)
+		} else {
+			members = append(members, membership)
+		}
+	}
+	sortMemberships(members)
+	sortMemberships(memberOf)
+	return members, memberOf
+}
+
+func sortMemberships(memberships []data.Membership) {
+	sort.SliceStable(memberships, func(i, j int) bool {
+		a, b := memberships[i].Begin, memberships[j].Begin
+		// Undated tenures sort last.
+		if a.IsZero() != b.IsZero() {
+			return !a.IsZero()
+		}
+		return a.Before(b)
+	})
+}
+
+func transformReleaseGroupsToAlbums(releaseGroups []musicbrainz.ReleaseGroup) []data.Album {
+	if len(releaseGroups) == 0 {
+		return nil
+	}
+
+	albums := make([]data.Album, 0, len(releaseGroups))
+	for _, rg := range releaseGroups {
+		releaseDate := rg.ReleaseDate()
+		album := data.Album{
+			ID:               rg.ID,
+			Title:            rg.Title,
+			ArtistID:         rg.PrimaryArtistID(),
+			ArtistName:       rg.PrimaryArtistName(),
+			PrimaryType:      rg.PrimaryType,
+			SecondaryTypes:   append([]string(nil), rg.SecondaryTypes...),
+			FirstReleaseDate: releaseDate,
+			Year:             releaseDate.Year,
+			Genre:            "",
+			Label:            "",
+			Tracks:           nil,
+			Reviews:          nil,
+			Images:           nil,
+			Credits:          transformCredits(rg.ArtistCredit),
+			ArtistCredit:     musicbrainz.JoinCredits(rg.ArtistCredit),
+		}
+		albums = append(albums, album)
+	}
+	return albums
+}
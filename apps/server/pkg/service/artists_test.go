@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+type downWikipedia struct {
+	called bool
+}
+
+func (d *downWikipedia) Healthy() bool { return false }
+
+func (d *downWikipedia) GetArtistBiography(ctx context.Context, artistName string) (string, error) {
+	d.called = true
+	return "", nil
+}
+
+func TestGetArtistSkipsUnhealthySources(t *testing.T) {
+	mb := &stubMusicBrainz{
+		lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			return &musicbrainz.Artist{ID: id, Name: "Remote"}, nil
+		},
+	}
+	wiki := &downWikipedia{}
+
+	if _, err := NewArtistService(Deps{MusicBrainz: mb, Wikipedia: wiki}).GetArtist(context.Background(), testArtistID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if wiki.called {
+		t.Error("expected unhealthy wikipedia client to be skipped")
+	}
+}
+
+func TestGetArtistServesCacheWithStats(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	cached := &data.Artist{ID: testArtistID, Name: "Cached", Albums: []data.Album{{ID: testAlbumID, Title: "Nevermind", Year: 1991}}}
+	if err := store.SaveArtist(context.Background(), cached); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+
+	artist, err := NewArtistService(Deps{Artists: store, Albums: store, MusicBrainz: &stubMusicBrainz{}}).GetArtist(context.Background(), testArtistID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if artist.Name != "Cached" {
+		t.Fatalf("expected cached artist, got %q", artist.Name)
+	}
+	if artist.Stats == nil {
+		t.Fatal("expected discography stats to be attached")
+	}
+}
+
+// batchOnlyAlbums serves albums to batch reads and fails the test on per-album lookups.
+type batchOnlyAlbums struct {
+	t      *testing.T
+	albums []*data.Album
+	calls  int
+}
+
+func (b *batchOnlyAlbums) GetAlbum(ctx context.Context, id string) (*data.Album, error) {
+	b.t.Errorf("unexpected GetAlbum(%q); stats should read albums in one batch", id)
+	return nil, nil
+}
+
+func (b *batchOnlyAlbums) SaveAlbum(ctx context.Context, album *data.Album) error {
+	return nil
+}
+
+func (b *batchOnlyAlbums) GetAlbums(ctx context.Context, ids []string) ([]*data.Album, error) {
+	b.calls++
+	return b.albums, nil
+}
+
+func TestGetArtistStatsReadAlbumRatingsInOneBatch(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	cached := &data.Artist{ID: testArtistID, Name: "Cached", Albums: []data.Album{
+		{ID: "album-1", PrimaryType: "Album"},
+		{ID: "album-2", PrimaryType: "Album"},
+		{ID: "album-3", PrimaryType: "Album"},
+	}}
+	if err := store.SaveArtist(context.Background(), cached); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+	albums := &batchOnlyAlbums{t: t, albums: []*data.Album{
+		{ID: "album-1", Rating: &data.AggregateRating{Score: 3, Count: 2}},
+		{ID: "album-3", Rating: &data.AggregateRating{Score: 5, Count: 1}},
+	}}
+
+	artist, err := NewArtistService(Deps{Artists: store, Albums: albums, MusicBrainz: &stubMusicBrainz{}}).GetArtist(context.Background(), testArtistID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if albums.calls != 1 {
+		t.Errorf("expected one batch read, got %d", albums.calls)
+	}
+	if artist.Stats == nil || artist.Stats.RatedAlbums != 2 || artist.Stats.AverageRating != 4 {
+		t.Fatalf("expected ratings from the batch, got %+v", artist.Stats)
+	}
+	if stored, _ := store.GetArtist(context.Background(), testArtistID); stored.Albums[0].Rating != nil {
+		t.Error("expected the cached artist's albums to be left untouched")
+	}
+}
+
+func TestGetArtistReportsMergedID(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	mb := &stubMusicBrainz{
+		lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			return &musicbrainz.Artist{ID: "canonical-id", Name: "Remote"}, nil
+		},
+	}
+	svc := NewArtistService(Deps{Artists: store, Aliases: store, MusicBrainz: mb})
+
+	_, err = svc.GetArtist(context.Background(), "merged-id")
+	var moved *MovedError
+	if !errors.As(err, &moved) || moved.CanonicalID != "canonical-id" {
+		t.Fatalf("expected MovedError to canonical-id, got %v", err)
+	}
+	if cached, _ := store.GetArtist(context.Background(), "canonical-id"); cached == nil {
+		t.Fatal("expected canonical artist to be cached")
+	}
+
+	mb.lookupArtistFunc = nil
+	if _, err := svc.GetArtist(context.Background(), "merged-id"); !errors.As(err, &moved) {
+		t.Fatalf("expected alias table to report the merge, got %v", err)
+	}
+}
+
+func TestGetArtistErrorKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		deps Deps
+		want error
+	}{
+		{"no client", Deps{}, ErrUnavailable},
+		{"not found", Deps{MusicBrainz: &stubMusicBrainz{lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			return nil, musicbrainz.ErrNotFound
+		}}}, ErrNotFound},
+		{"upstream", Deps{MusicBrainz: &stubMusicBrainz{}}, ErrUpstream},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewArtistService(tt.deps).GetArtist(context.Background(), testArtistID)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestTransformArtistSplitsMemberships(t *testing.T) {
+	artist := transformArtist(&musicbrainz.Artist{
+		ID:   testArtistID,
+		Name: "Nirvana",
+		Memberships: []musicbrainz.ArtistRelation{
+			{ArtistID: "grohl", ArtistName: "Dave Grohl", Attributes: []string{"drums (drum set)"}, Begin: "1990-09"},
+			{ArtistID: "cobain", ArtistName: "Kurt Cobain", Attributes: []string{"original", "guitar", "lead vocals"}, Begin: "1987", End: "1994-04-05", Ended: true},
+			{ArtistID: "sweet-75", ArtistName: "Sweet 75", Group: true},
+		},
+	})
+
+	if len(artist.Members) != 2 {
+		t.Fatalf("expected 2 members, got %d", len(artist.Members))
+	}
+	first := artist.Members[0]
+	if first.ArtistID != "cobain" || first.Current {
+		t.Errorf("expected ended tenure for cobain first, got %+v", first)
+	}
+	if len(first.Roles) != 2 || first.Roles[0] != "guitar" {
+		t.Errorf("expected tenure attributes to be dropped from roles, got %v", first.Roles)
+	}
+	if !artist.Members[1].Current {
+		t.Errorf("expected open tenure to be current")
+	}
+	if len(artist.MemberOf) != 1 || artist.MemberOf[0].Name != "Sweet 75" {
+		t.Errorf("unexpected memberOf %+v", artist.MemberOf)
+	}
+}
+
+func TestTransformArtistPutsGenresBeforeTags(t *testing.T) {
+	artist := transformArtist(&musicbrainz.Artist{
+		ID:     testArtistID,
+		Name:   "Radiohead",
+		Genres: []string{"alternative rock", "art rock"},
+		Tags:   []string{"Alternative Rock", "experimental"},
+	})
+
+	if want := []string{"alternative rock", "art rock", "experimental"}; !slices.Equal(artist.Genres, want) {
+		t.Errorf("expected genres %q, got %q", want, artist.Genres)
+	}
+}
+
+func TestGetArtistPagesAlbumsUpToTheConfiguredMaximum(t *testing.T) {
+	var offsets []int
+	mb := &stubMusicBrainz{
+		lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			return &musicbrainz.Artist{ID: id, Name: "Prolific"}, nil
+		},
+		getArtistReleaseGroupsFunc: func(ctx context.Context, artistID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			offsets = append(offsets, offset)
+			page := make([]musicbrainz.ReleaseGroup, limit)
+			for i := range page {
+				page[i] = musicbrainz.ReleaseGroup{ID: fmt.Sprintf("rg-%d", offset+i), PrimaryType: "Album"}
+			}
+			return &musicbrainz.ReleaseGroupSearchResult{Count: 400, Offset: offset, ReleaseGroups: page}, nil
+		},
+	}
+
+	artist, err := NewArtistService(Deps{MusicBrainz: mb, MaxArtistAlbums: 150}).GetArtist(context.Background(), testArtistID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(artist.Albums) != 150 || !artist.AlbumsTruncated {
+		t.Errorf("expected 150 albums flagged as truncated, got %d (truncated=%v)", len(artist.Albums), artist.AlbumsTruncated)
+	}
+	if len(offsets) != 2 || offsets[1] != 100 {
+		t.Errorf("expected pages at offsets 0 and 100, got %v", offsets)
+	}
+}
+
+func TestFetchArtistAlbumsStopsAtTheLastPage(t *testing.T) {
+	mb := &stubMusicBrainz{
+		getArtistReleaseGroupsFunc: func(ctx context.Context, artistID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			if offset > 0 {
+				t.Fatalf("unexpected page at offset %d", offset)
+			}
+			return &musicbrainz.ReleaseGroupSearchResult{Count: 2, ReleaseGroups: []musicbrainz.ReleaseGroup{{ID: "a"}, {ID: "b"}}}, nil
+		},
+	}
+
+	albums, truncated, err := fetchArtistAlbums(context.Background(), mb, testArtistID, defaultMaxArtistAlbums)
+	if err != nil || len(albums) != 2 || truncated {
+		t.Errorf("expected two complete albums, got %d (truncated=%v, err=%v)", len(albums), truncated, err)
+	}
+}
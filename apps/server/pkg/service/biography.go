@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/wikidata"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/wikipedia"
+)
+
+// LinkedBiographer is implemented by Wikipedia clients that can read a known page rather than
+// guessing it from the artist's name.
+type LinkedBiographer interface {
+	// Language is the edition GetBiographyByPage reads.
+	Language() string
+	GetBiographyByPage(ctx context.Context, title string) (string, *data.Attribution, error)
+	GetBiographyByWikidataID(ctx context.Context, id string) (string, *data.Attribution, error)
+}
+
+// fetchBiography reads an artist's biography and its attribution from the Wikipedia page their
+// MusicBrainz links point at: the linked article when it is on the client's edition, otherwise
+// the article the linked Wikidata item names. Only when neither link leads to a page is the page
+// guessed from the name, since guesses like "(band)" often land on a namesake.
+func fetchBiography(ctx context.Context, wiki WikipediaClient, name string, links map[string]string) (string, *data.Attribution, error) {
+	if linked, ok := wiki.(LinkedBiographer); ok {
+		if title, language := wikipedia.PageTitle(links[musicbrainz.LinkWikipedia]); title != "" && language == linked.Language() {
+			biography, attribution, err := linked.GetBiographyByPage(ctx, title)
+			if !isNotFound(err) {
+				return biography, attribution, err
+			}
+		}
+		if id := wikidata.ItemID(links[musicbrainz.LinkWikidata]); id != "" {
+			biography, attribution, err := linked.GetBiographyByWikidataID(ctx, id)
+			if !isNotFound(err) {
+				return biography, attribution, err
+			}
+		}
+	}
+
+	biography, err := wiki.GetArtistBiography(ctx, name)
+	if err != nil {
+		return "", nil, err
+	}
+	return biography, biographyAttribution(ctx, wiki, "", name), nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/wikipedia"
+)
+
+// stubLinkedWikipedia serves biographies for known page titles and Wikidata items, counting
+// name-based guesses.
+type stubLinkedWikipedia struct {
+	stubWikipedia
+	pages map[string]string
+	items map[string]string
+}
+
+func (s *stubLinkedWikipedia) Language() string { return "en" }
+
+func (s *stubLinkedWikipedia) GetBiographyByPage(ctx context.Context, title string) (string, *data.Attribution, error) {
+	biography, ok := s.pages[title]
+	if !ok {
+		return "", nil, wikipedia.ErrNotFound
+	}
+	return biography, data.NewWikipediaAttribution(title, "https://en.wikipedia.org/wiki/"+title, ""), nil
+}
+
+func (s *stubLinkedWikipedia) GetBiographyByWikidataID(ctx context.Context, id string) (string, *data.Attribution, error) {
+	title, ok := s.items[id]
+	if !ok {
+		return "", nil, wikipedia.ErrNotFound
+	}
+	return s.GetBiographyByPage(ctx, title)
+}
+
+func TestFetchBiographyPrefersLinkedPages(t *testing.T) {
+	wiki := &stubLinkedWikipedia{
+		pages: map[string]string{"Low (American band)": "Low was an American indie rock band."},
+		items: map[string]string{"Q1477428": "Low (American band)"},
+	}
+
+	tests := []struct {
+		name    string
+		links   map[string]string
+		want    string
+		guesses int
+	}{
+		{"wikipedia link", map[string]string{musicbrainz.LinkWikipedia: "https://en.wikipedia.org/wiki/Low_(American_band)"}, "Low was an American indie rock band.", 0},
+		{"wikidata link", map[string]string{musicbrainz.LinkWikidata: "https://www.wikidata.org/wiki/Q1477428"}, "Low was an American indie rock band.", 0},
+		{"other edition falls through to wikidata", map[string]string{
+			musicbrainz.LinkWikipedia: "https://de.wikipedia.org/wiki/Low_(Band)",
+			musicbrainz.LinkWikidata:  "https://www.wikidata.org/wiki/Q1477428",
+		}, "Low was an American indie rock band.", 0},
+		{"dead link falls back to the name", map[string]string{musicbrainz.LinkWikidata: "https://www.wikidata.org/wiki/Q1"}, "Low biography", 1},
+		{"no links", nil, "Low biography", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wiki.calls = 0
+			biography, attribution, err := fetchBiography(context.Background(), wiki, "Low", tt.links)
+			if err != nil || biography != tt.want {
+				t.Fatalf("fetchBiography = %q, %v; want %q", biography, err, tt.want)
+			}
+			if wiki.calls != tt.guesses {
+				t.Errorf("expected %d name-based lookups, got %d", tt.guesses, wiki.calls)
+			}
+			if tt.guesses == 0 && (attribution == nil || attribution.Title != "Low (American band)") {
+				t.Errorf("expected the linked page to be credited, got %+v", attribution)
+			}
+		})
+	}
+}
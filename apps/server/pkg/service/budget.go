@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// enrichmentBudget spreads a fixed time allowance across the optional sources consulted
+// during a lookup. Each step gets an even share of what remains, so time a fast source
+// doesn't use rolls over to the steps after it.
+type enrichmentBudget struct {
+	mu       sync.Mutex
+	deadline time.Time
+	now      func() time.Time
+}
+
+type budgetKey struct{}
+
+// WithEnrichmentBudget attaches a budget of total to ctx. A non-positive total leaves ctx unbudgeted.
+func WithEnrichmentBudget(ctx context.Context, total time.Duration) context.Context {
+	if total <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, budgetKey{}, &enrichmentBudget{
+		deadline: time.Now().Add(total),
+		now:      time.Now,
+	})
+}
+
+// enrichmentStep returns a context for one optional source call. stepsLeft counts this step
+// and every optional step still to come in the lookup. Without a budget the parent is returned
+// unchanged; once the budget is spent the returned context is already done.
+func enrichmentStep(ctx context.Context, stepsLeft int) (context.Context, context.CancelFunc) {
+	budget, ok := ctx.Value(budgetKey{}).(*enrichmentBudget)
+	if !ok {
+		return ctx, func() {}
+	}
+	return context.WithDeadline(ctx, budget.allocate(stepsLeft))
+}
+
+func (b *enrichmentBudget) allocate(stepsLeft int) time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if stepsLeft < 1 {
+		stepsLeft = 1
+	}
+	now := b.now()
+	remaining := b.deadline.Sub(now)
+	if remaining <= 0 {
+		return now
+	}
+	return now.Add(remaining / time.Duration(stepsLeft))
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestEnrichmentStepSplitsRemainingBudget(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	budget := &enrichmentBudget{deadline: start.Add(2 * time.Second), now: func() time.Time { return now }}
+	ctx := context.WithValue(context.Background(), budgetKey{}, budget)
+
+	first, cancel := enrichmentStep(ctx, 2)
+	defer cancel()
+	if deadline, _ := first.Deadline(); !deadline.Equal(start.Add(time.Second)) {
+		t.Errorf("expected first step to get half the budget, got deadline %v", deadline.Sub(start))
+	}
+
+	// The first step finished early, so the last step inherits the unused time.
+	now = start.Add(200 * time.Millisecond)
+	last, cancel := enrichmentStep(ctx, 1)
+	defer cancel()
+	if deadline, _ := last.Deadline(); !deadline.Equal(start.Add(2 * time.Second)) {
+		t.Errorf("expected last step to get the remaining budget, got deadline %v", deadline.Sub(start))
+	}
+}
+
+func TestEnrichmentStepWithoutBudget(t *testing.T) {
+	ctx := context.Background()
+	step, cancel := enrichmentStep(ctx, 2)
+	defer cancel()
+	if _, ok := step.Deadline(); ok {
+		t.Error("expected no deadline without a budget")
+	}
+}
+
+func TestEnrichmentStepExhaustedBudget(t *testing.T) {
+	ctx := WithEnrichmentBudget(context.Background(), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	step, cancel := enrichmentStep(ctx, 1)
+	defer cancel()
+	if step.Err() == nil {
+		t.Error("expected exhausted budget to yield a done context")
+	}
+}
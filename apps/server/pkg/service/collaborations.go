@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+const (
+	// collaborationBrowseLimit is the page size for release-group browses, MusicBrainz's maximum.
+	collaborationBrowseLimit = 100
+	// collaborationBrowsePages bounds the browses made for one graph, so prolific artists are
+	// covered up to 400 release groups.
+	collaborationBrowsePages = 4
+)
+
+// CollaborationService builds artist collaboration graphs from shared release-group credits.
+type CollaborationService interface {
+	// GetCollaborations returns the artists credited alongside id, strongest first. A merged
+	// MBID yields a *MovedError; other failures are *Error values.
+	GetCollaborations(ctx context.Context, id string) (*data.CollaborationGraph, error)
+}
+
+type collaborationService struct {
+	deps    Deps
+	artists ArtistService
+}
+
+// NewCollaborationService builds a CollaborationService over deps.
+func NewCollaborationService(deps Deps) CollaborationService {
+	return &collaborationService{deps: deps, artists: NewArtistService(deps)}
+}
+
+func (s *collaborationService) GetCollaborations(ctx context.Context, id string) (*data.CollaborationGraph, error) {
+	artist, err := s.artists.GetArtist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var albums []data.Album
+	index := make(map[string]int)
+	add := func(album data.Album) {
+		if i, ok := index[album.ID]; ok {
+			if len(album.Credits) > len(albums[i].Credits) {
+				albums[i].Credits = album.Credits
+			}
+			return
+		}
+		index[album.ID] = len(albums)
+		albums = append(albums, album)
+	}
+
+	for _, album := range artist.Albums {
+		add(album)
+	}
+
+	// Fully looked-up albums carry credits from the release group itself, which may name
+	// featured artists the discography browse did not.
+	if repo := s.deps.Albums; repo != nil {
+		for _, album := range artist.Albums {
+			if cached, err := repo.GetAlbum(ctx, album.ID); err == nil && cached != nil {
+				add(*cached)
+			}
+		}
+	}
+
+	// The cached discography stops at Deps.MaxArtistAlbums, so page through the rest. A failed
+	// browse leaves the graph built from what is already known.
+	if client := s.deps.MusicBrainz; client != nil {
+		for page := 0; page < collaborationBrowsePages; page++ {
+			offset := page * collaborationBrowseLimit
+			result, err := client.GetArtistReleaseGroups(ctx, artist.ID, collaborationBrowseLimit, offset)
+			if err != nil || len(result.ReleaseGroups) == 0 {
+				break
+			}
+			for _, album := range transformReleaseGroupsToAlbums(result.ReleaseGroups) {
+				add(album)
+			}
+			if offset+len(result.ReleaseGroups) >= result.Count {
+				break
+			}
+		}
+	}
+
+	return &data.CollaborationGraph{
+		ArtistID:      artist.ID,
+		ArtistName:    artist.Name,
+		ReleaseGroups: len(albums),
+		Edges:         data.ComputeCollaborationEdges(artist.ID, albums),
+	}, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// Depth trades completeness for latency on artist lookups.
+type Depth int
+
+const (
+	// DepthFull adds images, links, and awards to DepthStandard. It is the default.
+	DepthFull Depth = iota
+	// DepthStandard adds the Wikipedia biography and the discography to DepthBasic.
+	DepthStandard
+	// DepthBasic returns only the MusicBrainz core fields.
+	DepthBasic
+)
+
+var depthNames = map[string]Depth{
+	"basic":    DepthBasic,
+	"standard": DepthStandard,
+	"full":     DepthFull,
+}
+
+// ParseDepth reads basic, standard, or full. An empty value is DepthFull.
+func ParseDepth(value string) (Depth, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return DepthFull, nil
+	}
+	depth, ok := depthNames[value]
+	if !ok {
+		return DepthFull, errors.New("depth must be basic, standard, or full")
+	}
+	return depth, nil
+}
+
+// includes reports whether lookups at d fetch what other needs.
+func (d Depth) includes(other Depth) bool {
+	return d <= other
+}
+
+type depthKey struct{}
+
+// WithDepth asks artist lookups made with ctx to enrich only to depth.
+func WithDepth(ctx context.Context, depth Depth) context.Context {
+	return context.WithValue(ctx, depthKey{}, depth)
+}
+
+func depthFrom(ctx context.Context) Depth {
+	depth, _ := ctx.Value(depthKey{}).(Depth)
+	return depth
+}
+
+// trimArtist drops what depth excludes, so a cached full record answers a shallow lookup the
+// same way a fresh shallow fetch would.
+func trimArtist(artist *data.Artist, depth Depth) {
+	if !depth.includes(DepthStandard) {
+		artist.Biography = ""
+		artist.BiographyAttribution = nil
+		artist.Albums = nil
+	}
+	if !depth.includes(DepthFull) {
+		artist.Images = nil
+		artist.Links = nil
+		artist.Awards = nil
+		artist.Listening = nil
+		artist.Related = musicBrainzRelated(artist.Related)
+	}
+}
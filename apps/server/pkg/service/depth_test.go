@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func TestGetArtistBasicDepthSkipsEnrichment(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	mb := &stubMusicBrainz{
+		lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			return &musicbrainz.Artist{ID: id, Name: "Remote", Tags: []string{"grunge"}}, nil
+		},
+		getArtistReleaseGroupsFunc: func(ctx context.Context, artistID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			t.Fatal(unexpectedCall)
+			return nil, nil
+		},
+	}
+	wiki := &stubWikipedia{}
+
+	ctx := WithDepth(context.Background(), DepthBasic)
+	artist, err := NewArtistService(Deps{Artists: store, MusicBrainz: mb, Wikipedia: wiki}).GetArtist(ctx, testArtistID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if artist.Name != "Remote" || len(artist.Genres) != 1 {
+		t.Errorf("expected core fields, got %+v", artist)
+	}
+	if wiki.calls != 0 {
+		t.Error("expected basic depth to skip the biography")
+	}
+	if cached, _ := store.GetArtist(context.Background(), testArtistID); cached != nil {
+		t.Error("expected a shallow fetch not to be cached")
+	}
+}
+
+func TestGetArtistTrimsCachedRecordToDepth(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	cached := &data.Artist{
+		ID:        testArtistID,
+		Name:      "Cached",
+		Biography: "bio",
+		Images:    []data.Image{{URL: "x"}},
+		Links:     map[string]string{"wikipedia": "https://en.wikipedia.org/wiki/Nirvana"},
+		Albums:    []data.Album{{ID: testAlbumID, Title: "Nevermind", Year: 1991}},
+	}
+	if err := store.SaveArtist(context.Background(), cached); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+	service := NewArtistService(Deps{Artists: store, MusicBrainz: &stubMusicBrainz{}})
+
+	standard, err := service.GetArtist(WithDepth(context.Background(), DepthStandard), testArtistID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if standard.Biography != "bio" || len(standard.Albums) != 1 || standard.Images != nil || standard.Links != nil {
+		t.Errorf("expected biography and albums without images or links, got %+v", standard)
+	}
+
+	basic, err := service.GetArtist(WithDepth(context.Background(), DepthBasic), testArtistID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if basic.Biography != "" || basic.Albums != nil || basic.Stats != nil {
+		t.Errorf("expected core fields only, got %+v", basic)
+	}
+
+	// Trimming works on a copy; the store's snapshot keeps everything.
+	if stored, _ := store.GetArtist(context.Background(), testArtistID); stored.Biography != "bio" || len(stored.Albums) != 1 || stored.Links == nil || stored.Stats != nil {
+		t.Errorf("expected the cached snapshot untouched, got %+v", stored)
+	}
+}
+
+func TestParseDepth(t *testing.T) {
+	for value, want := range map[string]Depth{"": DepthFull, "Basic": DepthBasic, "standard": DepthStandard, "full": DepthFull} {
+		if got, err := ParseDepth(value); err != nil || got != want {
+			t.Errorf("ParseDepth(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	if _, err := ParseDepth("deep"); err == nil {
+		t.Error("expected an unknown depth to be rejected")
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+// DiscographyService groups an artist's release groups into studio albums, live albums,
+// compilations, EPs, and singles.
+type DiscographyService interface {
+	// GetDiscography returns the grouped discography of id. A merged MBID yields a *MovedError;
+	// other failures are *Error values.
+	GetDiscography(ctx context.Context, id string) (*data.Discography, error)
+	// GetArtistAlbums browses one page of id's release groups of the given types straight
+	// from MusicBrainz, independent of the cached artist. A merged MBID yields a *MovedError;
+	// other failures are *Error values.
+	GetArtistAlbums(ctx context.Context, id string, types musicbrainz.ReleaseGroupTypes, limit, offset int) (*data.AlbumPage, error)
+}
+
+type discographyService struct {
+	deps    Deps
+	artists ArtistService
+}
+
+// NewDiscographyService builds a DiscographyService over deps.
+func NewDiscographyService(deps Deps) DiscographyService {
+	return &discographyService{deps: deps, artists: NewArtistService(deps)}
+}
+
+func (s *discographyService) GetDiscography(ctx context.Context, id string) (*data.Discography, error) {
+	artist, err := s.artists.GetArtist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The cached discography leaves out singles and stops at Deps.MaxArtistAlbums, so browse
+	// every type, paging as far as collaboration graphs do. If the browse fails outright the
+	// cached albums are grouped instead.
+	albums := artist.Albums
+	if client := s.deps.MusicBrainz; client != nil {
+		var browsed []data.Album
+		for page := 0; page < collaborationBrowsePages; page++ {
+			offset := page * collaborationBrowseLimit
+			result, err := client.GetArtistReleaseGroupsOfTypes(ctx, artist.ID, musicbrainz.AllReleaseGroupTypes, collaborationBrowseLimit, offset)
+			if err != nil || len(result.ReleaseGroups) == 0 {
+				break
+			}
+			browsed = append(browsed, transformReleaseGroupsToAlbums(result.ReleaseGroups)...)
+			if offset+len(result.ReleaseGroups) >= result.Count {
+				break
+			}
+		}
+		if len(browsed) > 0 {
+			albums = browsed
+		}
+	}
+
+	return data.GroupDiscography(artist.ID, artist.Name, albums), nil
+}
+
+func (s *discographyService) GetArtistAlbums(ctx context.Context, id string, types musicbrainz.ReleaseGroupTypes, limit, offset int) (*data.AlbumPage, error) {
+	if err := resolveMoved(ctx, s.deps.Aliases, db.KindArtist, id); err != nil {
+		return nil, err
+	}
+	client := s.deps.MusicBrainz
+	if client == nil {
+		return nil, newError(ErrUnavailable, "musicbrainz client unavailable")
+	}
+
+	result, err := client.GetArtistReleaseGroupsOfTypes(ctx, id, types, limit, offset)
+	if err != nil {
+		if errors.Is(err, musicbrainz.ErrNotFound) {
+			return nil, newError(ErrNotFound, "artist not found")
+		}
+		return nil, upstreamError(err, "musicbrainz browse failed")
+	}
+
+	page := &data.AlbumPage{
+		ArtistID: id,
+		Albums:   transformReleaseGroupsToAlbums(result.ReleaseGroups),
+		Offset:   offset,
+		Limit:    limit,
+		Total:    result.Count,
+	}
+	if page.Albums == nil {
+		page.Albums = []data.Album{}
+	}
+	if next := offset + limit; next < result.Count {
+		page.NextOffset = next
+	}
+	return page, nil
+}
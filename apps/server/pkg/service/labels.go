@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+// labelReleaseLimit bounds the releases browsed for a label's catalog. Releases collapse into
+// fewer albums, so large labels show a partial catalog.
+const labelReleaseLimit = 100
+
+// LabelService resolves record labels by MBID, reading through the cache to MusicBrainz.
+type LabelService interface {
+	// GetLabel returns the label with its catalog. Failures are *Error values wrapping one of
+	// the sentinel kinds.
+	GetLabel(ctx context.Context, id string) (*data.Label, error)
+}
+
+type labelService struct {
+	deps Deps
+}
+
+// NewLabelService builds a LabelService over deps.
+func NewLabelService(deps Deps) LabelService {
+	return &labelService{deps: deps}
+}
+
+func (s *labelService) GetLabel(ctx context.Context, id string) (*data.Label, error) {
+	repo, client := s.deps.Labels, s.deps.MusicBrainz
+	if repo != nil {
+		label, err := repo.GetLabel(ctx, id)
+		if err != nil {
+			return nil, newError(ErrStorage, "label lookup failed")
+		}
+		if label != nil {
+			return label, nil
+		}
+	}
+
+	if client == nil {
+		return nil, newError(ErrUnavailable, "musicbrainz client unavailable")
+	}
+
+	remote, err := client.LookupLabel(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, musicbrainz.ErrNotFound):
+			return nil, newError(ErrNotFound, "label not found")
+		default:
+			return nil, upstreamError(err, "musicbrainz lookup failed")
+		}
+	}
+
+	label := transformLabel(remote)
+	if label.ID == "" {
+		label.ID = id
+	}
+
+	// A label page without its catalog is still useful, so browse failures are not fatal.
+	if releaseGroups, err := client.GetLabelReleaseGroups(ctx, label.ID, labelReleaseLimit, 0); err == nil {
+		label.Albums = transformReleaseGroupsToAlbums(releaseGroups.ReleaseGroups)
+		sortAlbumsByRelease(label.Albums)
+	}
+
+	if repo != nil {
+		if err := repo.SaveLabel(ctx, label); err != nil {
+			return nil, newError(ErrStorage, "label cache failed")
+		}
+	}
+
+	return label, nil
+}
+
+func transformLabel(src *musicbrainz.Label) *data.Label {
+	return &data.Label{
+		ID:             src.ID,
+		Name:           src.Name,
+		Type:           src.Type,
+		Country:        src.Country,
+		Disambiguation: src.Disambiguation,
+		LabelCode:      src.LabelCode,
+		LifeSpan: data.LifeSpan{
+			Begin: data.PartialDateOf(src.LifeSpan.Begin),
+			End:   data.PartialDateOf(src.LifeSpan.End),
+			Ended: src.LifeSpan.Ended,
+		},
+		Links: musicbrainz.Links(src.Relations),
+	}
+}
+
+// sortAlbumsByRelease orders albums oldest first with undated albums last.
+func sortAlbumsByRelease(albums []data.Album) {
+	sort.SliceStable(albums, func(i, j int) bool {
+		a, b := albums[i].FirstReleaseDate, albums[j].FirstReleaseDate
+		if a.IsZero() != b.IsZero() {
+			return !a.IsZero()
+		}
+		return a.Before(b)
+	})
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+const testLabelID = "label-subpop"
+
+func TestGetLabelFetchesCatalogAndCaches(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	lookups := 0
+	mb := &stubMusicBrainz{
+		lookupLabelFunc: func(ctx context.Context, id string) (*musicbrainz.Label, error) {
+			lookups++
+			return &musicbrainz.Label{ID: id, Name: "Sub Pop", LifeSpan: musicbrainz.LifeSpan{Begin: "1986"}}, nil
+		},
+		getLabelReleaseGroupsFunc: func(ctx context.Context, labelID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+			return &musicbrainz.ReleaseGroupSearchResult{ReleaseGroups: []musicbrainz.ReleaseGroup{
+				{ID: "undated", Title: "Undated"},
+				{ID: "bleach", Title: "Bleach", FirstReleaseDate: "1989-06-15"},
+				{ID: "superfuzz", Title: "Superfuzz Bigmuff", FirstReleaseDate: "1988-10"},
+			}}, nil
+		},
+	}
+	labels := NewLabelService(Deps{Labels: store, MusicBrainz: mb})
+
+	label, err := labels.GetLabel(context.Background(), testLabelID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if label.Name != "Sub Pop" || label.LifeSpan.Begin.Year != 1986 {
+		t.Fatalf("unexpected label %+v", label)
+	}
+	if len(label.Albums) != 3 || label.Albums[0].ID != "superfuzz" || label.Albums[2].ID != "undated" {
+		t.Fatalf("expected albums oldest first with undated last, got %+v", label.Albums)
+	}
+
+	if _, err := labels.GetLabel(context.Background(), testLabelID); err != nil {
+		t.Fatalf("unexpected error on cached read: %v", err)
+	}
+	if lookups != 1 {
+		t.Fatalf("expected cached label on second read, got %d lookups", lookups)
+	}
+}
+
+func TestGetLabelNotFound(t *testing.T) {
+	mb := &stubMusicBrainz{
+		lookupLabelFunc: func(ctx context.Context, id string) (*musicbrainz.Label, error) {
+			return nil, musicbrainz.ErrNotFound
+		},
+	}
+
+	_, err := NewLabelService(Deps{MusicBrainz: mb}).GetLabel(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestOriginalLabelPrefersLinkedCredit(t *testing.T) {
+	name, id := originalLabel([]data.Edition{
+		{Label: "Unlinked Pressing"},
+		{Label: "DGC", LabelID: "dgc"},
+	})
+	if name != "DGC" || id != "dgc" {
+		t.Fatalf("expected linked label, got %q/%q", name, id)
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/lastfm"
+)
+
+// LastfmClient captures the Last.fm lookups the services rely on.
+type LastfmClient interface {
+	GetArtistInfo(ctx context.Context, mbid, name string) (*lastfm.ArtistInfo, error)
+	GetAlbumInfo(ctx context.Context, mbid, artistName, albumTitle string) (*lastfm.AlbumInfo, error)
+	GetSimilarArtists(ctx context.Context, mbid, name string, limit int) ([]lastfm.SimilarArtist, error)
+}
+
+// fetchArtistInfo adds Last.fm's tags, listening stats, and, when Wikipedia had none, wiki bio
+// to an artist. stepsLeft is as for enrichmentStep.
+func fetchArtistInfo(ctx context.Context, client LastfmClient, artist *data.Artist, stepsLeft int, warned *warnings) {
+	if client == nil {
+		return
+	}
+	source := sourceName(client, sourceLastfm)
+	if !sourceAvailable(client) {
+		warned.unavailable(fieldListening, source)
+		return
+	}
+	stepCtx, cancel := enrichmentStep(ctx, stepsLeft)
+	defer cancel()
+	info, err := client.GetArtistInfo(stepCtx, artist.ID, artist.Name)
+	if err != nil {
+		warned.add(fieldListening, source, err)
+		return
+	}
+
+	artist.Genres = mergeGenres(artist.Genres, info.Tags)
+	artist.Listening = listeningStats(info.Listeners, info.Playcount, info.URL)
+	if artist.Biography == "" && info.Bio != "" {
+		artist.Biography, artist.BiographyAttribution = info.Bio, data.NewLastfmAttribution(info.Name, info.URL)
+	}
+}
+
+// fetchAlbumInfo adds Last.fm's listening stats, wiki summary, and top tag, when no other source
+// gave a genre, to an album. Last.fm keys albums by release rather than release group MBID, so
+// it is looked up by artist and title.
+func fetchAlbumInfo(ctx context.Context, client LastfmClient, album *data.Album, stepsLeft int, warned *warnings) {
+	if client == nil {
+		return
+	}
+	source := sourceName(client, sourceLastfm)
+	if !sourceAvailable(client) {
+		warned.unavailable(fieldListening, source)
+		return
+	}
+	stepCtx, cancel := enrichmentStep(ctx, stepsLeft)
+	defer cancel()
+	info, err := client.GetAlbumInfo(stepCtx, "", album.ArtistName, album.Title)
+	if err != nil {
+		warned.add(fieldListening, source, err)
+		return
+	}
+
+	if album.Genre == "" && len(info.Tags) > 0 {
+		album.Genre = info.Tags[0]
+	}
+	album.Listening = listeningStats(info.Listeners, info.Playcount, info.URL)
+	if info.Wiki != "" {
+		album.Description, album.DescriptionAttribution = info.Wiki, data.NewLastfmAttribution(info.Name, info.URL)
+	}
+}
+
+func listeningStats(listeners, playcount int64, pageURL string) *data.ListeningStats {
+	if listeners == 0 && playcount == 0 {
+		return nil
+	}
+	return &data.ListeningStats{Listeners: listeners, Playcount: playcount, Source: sourceLastfm, URL: pageURL}
+}
+
+// mergeGenres appends the tags genres doesn't already have, ignoring case.
+func mergeGenres(genres, tags []string) []string {
+	seen := make(map[string]bool, len(genres))
+	for _, genre := range genres {
+		seen[strings.ToLower(genre)] = true
+	}
+	merged := slices.Clip(genres)
+	for _, tag := range tags {
+		if key := strings.ToLower(tag); !seen[key] {
+			seen[key] = true
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/lastfm"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+type stubLastfm struct {
+	artist  *lastfm.ArtistInfo
+	album   *lastfm.AlbumInfo
+	similar []lastfm.SimilarArtist
+	err     error
+}
+
+func (s *stubLastfm) GetArtistInfo(ctx context.Context, mbid, name string) (*lastfm.ArtistInfo, error) {
+	return s.artist, s.err
+}
+
+func (s *stubLastfm) GetAlbumInfo(ctx context.Context, mbid, artistName, albumTitle string) (*lastfm.AlbumInfo, error) {
+	return s.album, s.err
+}
+
+func (s *stubLastfm) GetSimilarArtists(ctx context.Context, mbid, name string, limit int) ([]lastfm.SimilarArtist, error) {
+	return s.similar, s.err
+}
+
+func TestFetchArtistInfoMergesTagsAndFallsBackToLastfmBio(t *testing.T) {
+	client := &stubLastfm{artist: &lastfm.ArtistInfo{
+		Name:      "Nirvana",
+		URL:       "https://www.last.fm/music/Nirvana",
+		Listeners: 5000000,
+		Playcount: 300000000,
+		Tags:      []string{"Grunge", "rock", "alternative"},
+		Bio:       "Nirvana was an American rock band.",
+	}}
+	artist := &data.Artist{ID: testArtistID, Name: "Nirvana", Genres: []string{"grunge", "rock"}}
+	var warned warnings
+
+	fetchArtistInfo(context.Background(), client, artist, 1, &warned)
+
+	if want := []string{"grunge", "rock", "alternative"}; !slices.Equal(artist.Genres, want) {
+		t.Errorf("Genres = %v, want %v", artist.Genres, want)
+	}
+	if artist.Listening == nil || artist.Listening.Listeners != 5000000 || artist.Listening.Source != sourceLastfm {
+		t.Errorf("expected Last.fm listening stats, got %+v", artist.Listening)
+	}
+	if artist.Biography != client.artist.Bio || artist.BiographyAttribution == nil || artist.BiographyAttribution.License != data.LicenseCCBYSA3 {
+		t.Errorf("expected the Last.fm bio with its attribution, got %q %+v", artist.Biography, artist.BiographyAttribution)
+	}
+
+	artist = &data.Artist{Name: "Nirvana", Biography: "From Wikipedia."}
+	fetchArtistInfo(context.Background(), client, artist, 1, &warned)
+	if artist.Biography != "From Wikipedia." || artist.BiographyAttribution != nil {
+		t.Errorf("expected the existing biography kept, got %q %+v", artist.Biography, artist.BiographyAttribution)
+	}
+}
+
+func TestFetchArtistInfoWarnsWhenLastfmFails(t *testing.T) {
+	artist := &data.Artist{Name: "Nirvana", Genres: []string{"grunge"}}
+	var warned warnings
+
+	fetchArtistInfo(context.Background(), &stubLastfm{err: lastfm.ErrRateLimit}, artist, 1, &warned)
+
+	if len(artist.Genres) != 1 || artist.Listening != nil {
+		t.Errorf("expected the artist left alone, got %+v", artist)
+	}
+	if len(warned) != 1 || warned[0].Field != fieldListening {
+		t.Errorf("expected one listening warning, got %+v", warned)
+	}
+}
+
+func TestMergeGenresLeavesCallerSliceAlone(t *testing.T) {
+	genres := make([]string, 1, 4)
+	genres[0] = "rock"
+
+	merged := mergeGenres(genres, []string{"Rock", "shoegaze"})
+
+	if want := []string{"rock", "shoegaze"}; !slices.Equal(merged, want) {
+		t.Errorf("mergeGenres = %v, want %v", merged, want)
+	}
+	if extended := genres[:2]; extended[1] != "" {
+		t.Errorf("expected the original backing array untouched, got %v", extended)
+	}
+}
+
+func TestGetAlbumAddsLastfmListeningAndDescription(t *testing.T) {
+	mb := &stubMusicBrainz{
+		lookupReleaseGroupFunc: func(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error) {
+			return &musicbrainz.ReleaseGroup{ID: id, Title: "Nevermind"}, nil
+		},
+	}
+	client := &stubLastfm{album: &lastfm.AlbumInfo{
+		Name:      "Nevermind",
+		URL:       "https://www.last.fm/music/Nirvana/Nevermind",
+		Listeners: 2500000,
+		Playcount: 90000000,
+		Tags:      []string{"grunge", "90s"},
+		Wiki:      "Nevermind is the second studio album by Nirvana.",
+	}}
+
+	album, err := NewAlbumService(Deps{MusicBrainz: mb, Lastfm: client}).GetAlbum(context.Background(), testAlbumID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if album.Genre != "grunge" {
+		t.Errorf("expected the top Last.fm tag as genre, got %q", album.Genre)
+	}
+	if album.Listening == nil || album.Listening.Playcount != 90000000 {
+		t.Errorf("expected Last.fm listening stats, got %+v", album.Listening)
+	}
+	if album.Description != client.album.Wiki || album.DescriptionAttribution == nil {
+		t.Errorf("expected the Last.fm wiki summary with attribution, got %q %+v", album.Description, album.DescriptionAttribution)
+	}
+}
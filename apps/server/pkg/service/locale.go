@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// defaultLanguage is what responses fall back to when no preferred language can be served.
+const defaultLanguage = "en"
+
+// LocalizedBiographer is implemented by Wikipedia clients that can read other language editions.
+type LocalizedBiographer interface {
+	GetArtistBiographyIn(ctx context.Context, language, artistName string) (string, error)
+}
+
+// BiographyAttributor is implemented by Wikipedia clients that can credit the page a biography
+// was read from, for license compliance. language is "" for the default edition.
+type BiographyAttributor interface {
+	ArtistBiographyAttribution(ctx context.Context, language, artistName string) (*data.Attribution, error)
+}
+
+// biographyAttribution credits the page wiki read artistName's biography from, or returns nil
+// when the client can't say.
+func biographyAttribution(ctx context.Context, wiki WikipediaClient, language, artistName string) *data.Attribution {
+	attributor, ok := wiki.(BiographyAttributor)
+	if !ok {
+		return nil
+	}
+	attribution, err := attributor.ArtistBiographyAttribution(ctx, language, artistName)
+	if err != nil {
+		return nil
+	}
+	return attribution
+}
+
+type languagesKey struct{}
+
+// WithLanguages asks artist lookups made with ctx to localize to the first of tags, BCP 47
+// language tags in order of preference, that a localized name or biography exists for, and
+// album lookups to keep review text in those languages.
+func WithLanguages(ctx context.Context, tags []string) context.Context {
+	if len(tags) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, languagesKey{}, tags)
+}
+
+func languagesFrom(ctx context.Context) []string {
+	tags, _ := ctx.Value(languagesKey{}).([]string)
+	return tags
+}
+
+// localizeArtist swaps in the name and biography for the caller's preferred language and
+// records the chosen locale. English needs no lookups; other languages are chosen only if
+// MusicBrainz has a name or Wikipedia a biography in them.
+func (s *artistService) localizeArtist(ctx context.Context, artist *data.Artist) {
+	tags := languagesFrom(ctx)
+	if len(tags) == 0 {
+		return
+	}
+
+	chosen := defaultLanguage
+	for _, tag := range tags {
+		language, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if language == defaultLanguage {
+			chosen = tag
+			break
+		}
+		name := artist.LocalizedNames[language]
+		if name == artist.Name {
+			name = ""
+		}
+		biography, attribution := s.localizedBiography(ctx, language, name, artist)
+		if name == "" && biography == "" {
+			continue
+		}
+		artist.LocalizedName = name
+		if biography != "" {
+			artist.Biography, artist.BiographyAttribution = biography, attribution
+		}
+		chosen = tag
+		break
+	}
+	artist.Locale = data.NewLocale(chosen)
+}
+
+// localizedBiography reads the artist's page on the language's Wikipedia edition, titled by
+// the localized name when there is one, along with the page's attribution. Lookups that
+// exclude biographies skip it.
+func (s *artistService) localizedBiography(ctx context.Context, language, name string, artist *data.Artist) (string, *data.Attribution) {
+	if !depthFrom(ctx).includes(DepthStandard) {
+		return "", nil
+	}
+	wiki, ok := s.deps.Wikipedia.(LocalizedBiographer)
+	if !ok || !sourceAvailable(s.deps.Wikipedia) {
+		return "", nil
+	}
+	if name == "" {
+		name = artist.Name
+	}
+	stepCtx, cancel := enrichmentStep(ctx, 1)
+	defer cancel()
+	biography, err := wiki.GetArtistBiographyIn(stepCtx, language, name)
+	if err != nil {
+		return "", nil
+	}
+	return biography, biographyAttribution(stepCtx, s.deps.Wikipedia, language, name)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+// stubLocalizedWikipedia serves biographies from a per-language table.
+type stubLocalizedWikipedia struct {
+	stubWikipedia
+	biographies map[string]string
+	requested   []string
+}
+
+func (s *stubLocalizedWikipedia) GetArtistBiographyIn(ctx context.Context, language, artistName string) (string, error) {
+	s.requested = append(s.requested, language+":"+artistName)
+	if biography, ok := s.biographies[language]; ok {
+		return biography, nil
+	}
+	return "", errors.New("not found")
+}
+
+func (s *stubLocalizedWikipedia) ArtistBiographyAttribution(ctx context.Context, language, artistName string) (*data.Attribution, error) {
+	return data.NewWikipediaAttribution(artistName, "https://"+language+".wikipedia.org/wiki/"+artistName, "1"), nil
+}
+
+func newLocalizedArtistService(t *testing.T, wiki WikipediaClient) ArtistService {
+	t.Helper()
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	cached := &data.Artist{
+		ID:             testArtistID,
+		Name:           "The Beatles",
+		Biography:      "English bio",
+		LocalizedNames: map[string]string{"ja": "ビートルズ"},
+	}
+	if err := store.SaveArtist(context.Background(), cached); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+	return NewArtistService(Deps{Artists: store, MusicBrainz: &stubMusicBrainz{}, Wikipedia: wiki})
+}
+
+func TestGetArtistLocalizesToPreferredLanguage(t *testing.T) {
+	wiki := &stubLocalizedWikipedia{biographies: map[string]string{"ja": "日本語の経歴"}}
+	service := newLocalizedArtistService(t, wiki)
+
+	ctx := WithLanguages(context.Background(), []string{"ja-JP", "en"})
+	artist, err := service.GetArtist(ctx, testArtistID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if artist.LocalizedName != "ビートルズ" || artist.Biography != "日本語の経歴" {
+		t.Errorf("expected Japanese name and biography, got %q / %q", artist.LocalizedName, artist.Biography)
+	}
+	if artist.Locale == nil || artist.Locale.Tag != "ja-JP" || artist.Locale.DateFormat != "YYYY/MM/DD" {
+		t.Errorf("expected ja-JP locale, got %+v", artist.Locale)
+	}
+	if len(wiki.requested) != 1 || wiki.requested[0] != "ja:ビートルズ" {
+		t.Errorf("expected the Japanese edition to be searched by localized name, got %v", wiki.requested)
+	}
+}
+
+func TestGetArtistFallsBackToEnglish(t *testing.T) {
+	wiki := &stubLocalizedWikipedia{}
+	service := newLocalizedArtistService(t, wiki)
+
+	ctx := WithLanguages(context.Background(), []string{"fr", "de"})
+	artist, err := service.GetArtist(ctx, testArtistID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if artist.LocalizedName != "" || artist.Biography != "English bio" {
+		t.Errorf("expected English content, got %q / %q", artist.LocalizedName, artist.Biography)
+	}
+	if artist.Locale == nil || artist.Locale.Tag != "en" {
+		t.Errorf("expected en locale, got %+v", artist.Locale)
+	}
+	if len(wiki.requested) != 2 {
+		t.Errorf("expected both languages to be tried, got %v", wiki.requested)
+	}
+}
+
+func TestGetArtistWithoutLanguagesIsUnlocalized(t *testing.T) {
+	wiki := &stubLocalizedWikipedia{}
+	artist, err := newLocalizedArtistService(t, wiki).GetArtist(context.Background(), testArtistID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if artist.Locale != nil || len(wiki.requested) != 0 {
+		t.Errorf("expected no localization, got %+v after %v", artist.Locale, wiki.requested)
+	}
+}
+
+func TestGetArtistAttributesLocalizedBiography(t *testing.T) {
+	wiki := &stubLocalizedWikipedia{biographies: map[string]string{"ja": "日本語の経歴"}}
+	ctx := WithLanguages(context.Background(), []string{"ja"})
+	artist, err := newLocalizedArtistService(t, wiki).GetArtist(ctx, testArtistID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if artist.BiographyAttribution == nil || artist.BiographyAttribution.URL != "https://ja.wikipedia.org/wiki/ビートルズ" {
+		t.Errorf("expected the Japanese page to be credited, got %+v", artist.BiographyAttribution)
+	}
+}
+
+func TestGetArtistStoresBiographyAttribution(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	mb := &stubMusicBrainz{
+		lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			return &musicbrainz.Artist{ID: id, Name: "Nirvana"}, nil
+		},
+	}
+	service := NewArtistService(Deps{Artists: store, MusicBrainz: mb, Wikipedia: &stubLocalizedWikipedia{}})
+
+	if _, err := service.GetArtist(context.Background(), testArtistID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cached, err := store.GetArtist(context.Background(), testArtistID)
+	if err != nil {
+		t.Fatalf("GetArtist: %v", err)
+	}
+	if cached.BiographyAttribution == nil || cached.BiographyAttribution.Title != "Nirvana" || cached.BiographyAttribution.License != data.LicenseCCBYSA {
+		t.Errorf("expected the biography's attribution to be stored, got %+v", cached.BiographyAttribution)
+	}
+}
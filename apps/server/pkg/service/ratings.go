@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"math"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// RatingNotifier is told when a cached album's aggregate rating changes.
+type RatingNotifier interface {
+	NotifyRatingChange(ctx context.Context, change data.RatingChange)
+}
+
+// RatingEvents configures rating change notifications.
+type RatingEvents struct {
+	// Notifier receives the changes; nil turns notifications off.
+	Notifier RatingNotifier
+	// Threshold is how far the average score must move to count as a change. A source rating
+	// an album for the first time always counts.
+	Threshold float64
+}
+
+// notifyRatingChange tells events.Notifier when current's rating differs enough from
+// previous, the copy of the album that was cached before. Albums cached for the first time
+// never notify, since nothing changed for anyone already following them.
+func notifyRatingChange(ctx context.Context, events RatingEvents, previous, current *data.Album) {
+	if events.Notifier == nil {
+		return
+	}
+	if change := ratingChange(previous, current, events.Threshold); change != nil {
+		events.Notifier.NotifyRatingChange(ctx, *change)
+	}
+}
+
+// ratingChange compares the ratings of two copies of an album. It returns nil when previous is
+// nil, current has no rating, or the score moved less than threshold with no new source.
+func ratingChange(previous, current *data.Album, threshold float64) *data.RatingChange {
+	if previous == nil || current == nil || current.Rating == nil {
+		return nil
+	}
+
+	known := make(map[string]bool)
+	if previous.Rating != nil {
+		for _, source := range previous.Rating.Sources {
+			known[source] = true
+		}
+	}
+	var newSources []string
+	for _, source := range current.Rating.Sources {
+		if !known[source] {
+			newSources = append(newSources, source)
+		}
+	}
+
+	moved := previous.Rating == nil || math.Abs(current.Rating.Score-previous.Rating.Score) >= threshold
+	if !moved && len(newSources) == 0 {
+		return nil
+	}
+	return &data.RatingChange{
+		AlbumID:    current.ID,
+		Title:      current.Title,
+		ArtistID:   current.ArtistID,
+		ArtistName: current.ArtistName,
+		Previous:   previous.Rating,
+		Current:    current.Rating,
+		NewSources: newSources,
+	}
+}
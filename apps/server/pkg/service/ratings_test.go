@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+type recordingNotifier struct {
+	changes []data.RatingChange
+}
+
+func (r *recordingNotifier) NotifyRatingChange(ctx context.Context, change data.RatingChange) {
+	r.changes = append(r.changes, change)
+}
+
+func TestRatingChange(t *testing.T) {
+	rated := func(score float64, sources ...string) *data.Album {
+		return &data.Album{ID: testAlbumID, Rating: &data.AggregateRating{Score: score, Count: len(sources), Sources: sources}}
+	}
+
+	cases := []struct {
+		name              string
+		previous, current *data.Album
+		wantNew           []string
+		want              bool
+	}{
+		{name: "first cached copy", current: rated(4, "discogs")},
+		{name: "still unrated", previous: &data.Album{}, current: &data.Album{}},
+		{name: "small move", previous: rated(4, "discogs"), current: rated(4.2, "discogs")},
+		{name: "move past threshold", previous: rated(4, "discogs"), current: rated(3.4, "discogs"), want: true},
+		{name: "first rating", previous: &data.Album{}, current: rated(4, "discogs"), wantNew: []string{"discogs"}, want: true},
+		{name: "new source", previous: rated(4, "discogs"), current: rated(4.1, "discogs", "allmusic"), wantNew: []string{"allmusic"}, want: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			change := ratingChange(tc.previous, tc.current, 0.5)
+			if (change != nil) != tc.want {
+				t.Fatalf("expected a change: %v, got %+v", tc.want, change)
+			}
+			if change != nil && !slices.Equal(change.NewSources, tc.wantNew) {
+				t.Errorf("expected new sources %q, got %q", tc.wantNew, change.NewSources)
+			}
+		})
+	}
+}
+
+func TestGetAlbumNotifiesWhenRefreshedReviewsChangeTheRating(t *testing.T) {
+	store := newRefreshStore(t)
+	notifier := &recordingNotifier{}
+	reviews := &countingReviews{reviews: []data.Review{{Source: "discogs", Rating: 4}}}
+	svc := NewAlbumService(Deps{
+		Albums:       store,
+		MusicBrainz:  &stubMusicBrainz{},
+		Reviews:      reviews,
+		Modified:     store,
+		CacheTTL:     CacheTTLs{Albums: time.Hour, Reviews: time.Nanosecond},
+		RatingEvents: RatingEvents{Notifier: notifier, Threshold: 0.5},
+	})
+	time.Sleep(time.Millisecond)
+
+	if _, err := svc.GetAlbum(context.Background(), testAlbumID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.changes) != 1 {
+		t.Fatalf("expected one rating change, got %+v", notifier.changes)
+	}
+	change := notifier.changes[0]
+	if change.AlbumID != testAlbumID || change.Previous != nil || change.Current == nil || change.Current.Score != 4 {
+		t.Errorf("unexpected change %+v", change)
+	}
+
+	time.Sleep(time.Millisecond)
+	if _, err := svc.GetAlbum(context.Background(), testAlbumID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.changes) != 1 {
+		t.Errorf("expected an unchanged rating to stay quiet, got %+v", notifier.changes)
+	}
+}
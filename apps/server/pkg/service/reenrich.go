@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+)
+
+// ErrJobRunning is returned when a re-enrichment run is requested while another is in progress.
+var ErrJobRunning = errors.New("re-enrichment already running")
+
+// ReenrichRequest selects the cached records a run revisits. An empty Kind covers artists and
+// albums, an empty Field every field the quality report checks, and Limit caps the records
+// revisited per kind (zero is unlimited).
+type ReenrichRequest struct {
+	Kind  string `json:"entity,omitempty"`
+	Field string `json:"missing,omitempty"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+// ReenrichRun reports the progress of one run. Scanned counts records revisited, Filled the
+// fields a source supplied, and Failed the records whose lookup or save failed.
+type ReenrichRun struct {
+	ReenrichRequest
+	Running    bool      `json:"running"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+	Scanned    int       `json:"scanned"`
+	Filled     int       `json:"filled"`
+	Failed     int       `json:"failed"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Reenricher fills in fields missing from cached records by asking only the sources that
+// supply them (Wikipedia for biographies, the image chain for covers, and so on) rather than
+// refetching whole records. Records are revisited one at a time, delay apart, to stay within
+// upstream rate limits, and only one run proceeds at a time.
+type Reenricher struct {
+	deps    Deps
+	records db.RecordLister
+	delay   time.Duration
+
+	mu      sync.Mutex
+	running bool
+	last    *ReenrichRun
+}
+
+// NewReenricher builds a Reenricher that finds incomplete records through records and reads,
+// fills, and saves them through deps.
+func NewReenricher(deps Deps, records db.RecordLister, delay time.Duration) *Reenricher {
+	return &Reenricher{deps: deps, records: records, delay: delay}
+}
+
+// Status returns the current or most recent run, if any.
+func (r *Reenricher) Status() (ReenrichRun, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.last == nil {
+		return ReenrichRun{}, false
+	}
+	return *r.last, true
+}
+
+// Start begins a run in the background and returns its initial state. The run is detached
+// from ctx's cancellation so it outlives the request that triggered it.
+func (r *Reenricher) Start(ctx context.Context, req ReenrichRequest) (ReenrichRun, error) {
+	run, err := r.begin(req)
+	if err != nil {
+		return ReenrichRun{}, err
+	}
+	go r.execute(context.WithoutCancel(ctx), req)
+	return run, nil
+}
+
+// Run performs a run synchronously and returns its final state.
+func (r *Reenricher) Run(ctx context.Context, req ReenrichRequest) (ReenrichRun, error) {
+	if _, err := r.begin(req); err != nil {
+		return ReenrichRun{}, err
+	}
+	r.execute(ctx, req)
+	run, _ := r.Status()
+	return run, nil
+}
+
+func (r *Reenricher) begin(req ReenrichRequest) (ReenrichRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running {
+		return ReenrichRun{}, ErrJobRunning
+	}
+	r.running = true
+	r.last = &ReenrichRun{ReenrichRequest: req, Running: true, StartedAt: time.Now()}
+	return *r.last, nil
+}
+
+func (r *Reenricher) execute(ctx context.Context, req ReenrichRequest) {
+	// Paced sources serve interactive lookups first, so a run never holds up visitors.
+	ctx = httpclient.WithBackground(ctx)
+	defer func() {
+		r.mu.Lock()
+		r.running = false
+		r.last.Running = false
+		r.last.FinishedAt = time.Now()
+		r.mu.Unlock()
+	}()
+
+	kinds := []string{db.KindArtist, db.KindAlbum}
+	if req.Kind != "" {
+		kinds = []string{req.Kind}
+	}
+
+	first := true
+	for _, kind := range kinds {
+		targets, err := db.IncompleteRecords(ctx, r.records, kind, req.Field)
+		if err != nil {
+			r.update(func(run *ReenrichRun) { run.Error = "listing cached records failed" })
+			logging.FromContext(ctx).Error("re-enrichment listing failed", "kind", kind, "error", err)
+			return
+		}
+		if req.Limit > 0 && len(targets) > req.Limit {
+			targets = targets[:req.Limit]
+		}
+
+		for _, target := range targets {
+			if !first && !r.wait(ctx) {
+				r.update(func(run *ReenrichRun) { run.Error = ctx.Err().Error() })
+				return
+			}
+			first = false
+
+			fields := target.Missing
+			if req.Field != "" {
+				fields = []string{req.Field}
+			}
+			var filled int
+			if kind == db.KindArtist {
+				filled, err = r.refillArtist(ctx, target.ID, fields)
+			} else {
+				filled, err = r.refillAlbum(ctx, target.ID, fields)
+			}
+			if err != nil {
+				logging.FromContext(ctx).Warn("re-enrichment failed", "kind", kind, "id", target.ID, "error", err)
+			}
+			r.update(func(run *ReenrichRun) {
+				run.Scanned++
+				run.Filled += filled
+				if err != nil {
+					run.Failed++
+				}
+			})
+		}
+	}
+}
+
+func (r *Reenricher) update(apply func(*ReenrichRun)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	apply(r.last)
+}
+
+// wait pauses between records, reporting false if ctx ends first.
+func (r *Reenricher) wait(ctx context.Context) bool {
+	if r.delay <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(r.delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// refillArtist asks the source behind each missing field and saves the artist if any filled.
+func (r *Reenricher) refillArtist(ctx context.Context, id string, fields []string) (int, error) {
+	if r.deps.Artists == nil {
+		return 0, nil
+	}
+	cached, err := r.deps.Artists.GetArtist(ctx, id)
+	if err != nil || cached == nil {
+		return 0, err
+	}
+	// Fields are only ever replaced, so a shallow copy keeps the cached snapshot intact.
+	copied := *cached
+	artist := &copied
+
+	filled := 0
+	for _, field := range fields {
+		if r.fillArtistField(ctx, artist, field) {
+			artist.Warnings = data.ClearWarnings(artist.Warnings, field)
+			filled++
+		}
+	}
+	if filled == 0 {
+		return 0, nil
+	}
+	return filled, r.deps.Artists.SaveArtist(ctx, artist)
+}
+
+func (r *Reenricher) fillArtistField(ctx context.Context, artist *data.Artist, field string) bool {
+	mb := r.deps.MusicBrainz
+	switch field {
+	case db.QualityBiography:
+		if wiki := r.deps.Wikipedia; wiki != nil && sourceAvailable(wiki) {
+			if biography, attribution, err := fetchBiography(ctx, wiki, artist.Name, artist.Links); err == nil && biography != "" {
+				artist.Biography, artist.BiographyAttribution = biography, attribution
+				return true
+			}
+		}
+	case db.QualityImage:
+		if r.deps.Images != nil {
+			if images := r.deps.Images.ArtistImages(ctx, artist.ID, artist.Name); len(images) > 0 {
+				artist.Images = images
+				return true
+			}
+		}
+	case db.QualityGenres:
+		if mb != nil {
+			if remote, err := mb.LookupArtist(ctx, artist.ID); err == nil && len(remote.Tags) > 0 {
+				artist.Genres = transformArtist(remote).Genres
+				return true
+			}
+		}
+		if lastfm := r.deps.Lastfm; lastfm != nil && sourceAvailable(lastfm) {
+			if info, err := lastfm.GetArtistInfo(ctx, artist.ID, artist.Name); err == nil && len(info.Tags) > 0 {
+				artist.Genres = mergeGenres(artist.Genres, info.Tags)
+				return true
+			}
+		}
+	case db.QualityAlbums:
+		if mb != nil {
+			if albums, truncated, err := fetchArtistAlbums(ctx, mb, artist.ID, r.deps.maxArtistAlbums()); err == nil && len(albums) > 0 {
+				artist.Albums, artist.AlbumsTruncated = albums, truncated
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// refillAlbum is refillArtist for albums.
+func (r *Reenricher) refillAlbum(ctx context.Context, id string, fields []string) (int, error) {
+	if r.deps.Albums == nil {
+		return 0, nil
+	}
+	cached, err := r.deps.Albums.GetAlbum(ctx, id)
+	if err != nil || cached == nil {
+		return 0, err
+	}
+	// applyReviews adds to the links map, so it is copied along with the album.
+	copied := *cached
+	copied.Links = maps.Clone(cached.Links)
+	album := &copied
+
+	filled := 0
+	for _, field := range fields {
+		if r.fillAlbumField(ctx, album, field) {
+			album.Warnings = data.ClearWarnings(album.Warnings, field)
+			filled++
+		}
+	}
+	if filled == 0 {
+		return 0, nil
+	}
+	if err := r.deps.Albums.SaveAlbum(ctx, album); err != nil {
+		return filled, err
+	}
+	notifyRatingChange(ctx, r.deps.RatingEvents, cached, album)
+	return filled, nil
+}
+
+func (r *Reenricher) fillAlbumField(ctx context.Context, album *data.Album, field string) bool {
+	mb := r.deps.MusicBrainz
+	switch field {
+	case db.QualityCover:
+		if r.deps.Images != nil {
+			if images := r.deps.Images.AlbumImages(ctx, album.ID, album.ArtistName, album.Title); len(images) > 0 {
+				album.Images = images
+				return true
+			}
+		}
+	case db.QualityTracks:
+		if mb != nil {
+			if tracks, err := mb.GetReleaseGroupTracks(ctx, album.ID); err == nil && len(tracks) > 0 {
+				album.Tracks = transformTracks(tracks)
+				return true
+			}
+		}
+	case db.QualityReviews:
+		if reviews := r.deps.Reviews; reviews != nil && sourceAvailable(reviews) {
+			if found, release, err := albumReviews(ctx, reviews, album.ArtistName, album.Title); err == nil && len(found) > 0 {
+				applyReviews(album, found)
+				applyRelease(album, release)
+				return true
+			}
+		}
+	case db.QualityGenres:
+		if mb != nil {
+			if remote, err := mb.LookupReleaseGroup(ctx, album.ID); err == nil && len(remote.Genres) > 0 {
+				album.Genre = transformAlbum(remote).Genre
+				return true
+			}
+		}
+	}
+	return false
+}
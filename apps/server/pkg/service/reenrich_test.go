@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+type stubWikipedia struct {
+	calls int
+}
+
+func (s *stubWikipedia) GetArtistBiography(ctx context.Context, artistName string) (string, error) {
+	s.calls++
+	return artistName + " biography", nil
+}
+
+func TestReenricherFillsOnlyRequestedField(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.NewMemoryStore(ctx)
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	_ = store.SaveArtist(ctx, &data.Artist{ID: "a1", Name: "Nirvana", Warnings: []data.Warning{
+		{Field: db.QualityBiography, Source: "wikipedia", Reason: data.WarningTimeout, Retryable: true},
+	}})
+	_ = store.SaveArtist(ctx, &data.Artist{ID: "a2", Name: "Mudhoney", Biography: "kept"})
+	_ = store.SaveAlbum(ctx, &data.Album{ID: testAlbumID, Title: "Nevermind"})
+
+	wiki := &stubWikipedia{}
+	mb := &stubMusicBrainz{
+		getReleaseGroupTracksFunc: func(ctx context.Context, releaseGroupID string) ([]musicbrainz.Track, error) {
+			t.Fatal(unexpectedCall)
+			return nil, nil
+		},
+	}
+	reenricher := NewReenricher(Deps{Artists: store, Albums: store, MusicBrainz: mb, Wikipedia: wiki}, store, 0)
+
+	run, err := reenricher.Run(ctx, ReenrichRequest{Kind: db.KindArtist, Field: db.QualityBiography})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if run.Running || run.Scanned != 1 || run.Filled != 1 || run.Failed != 0 {
+		t.Fatalf("unexpected run %+v", run)
+	}
+	if wiki.calls != 1 {
+		t.Errorf("expected one biography lookup, got %d", wiki.calls)
+	}
+	artist, _ := store.GetArtist(ctx, "a1")
+	if artist.Biography != "Nirvana biography" {
+		t.Errorf("expected biography to be filled, got %q", artist.Biography)
+	}
+	if len(artist.Warnings) != 0 {
+		t.Errorf("expected the biography warning to clear, got %+v", artist.Warnings)
+	}
+	if status, ok := reenricher.Status(); !ok || status.Filled != 1 {
+		t.Errorf("expected status to report the finished run, got %+v", status)
+	}
+}
+
+func TestReenricherFillsAlbumTracks(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.NewMemoryStore(ctx)
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	_ = store.SaveAlbum(ctx, &data.Album{ID: testAlbumID, Title: "Nevermind"})
+
+	mb := &stubMusicBrainz{
+		getReleaseGroupTracksFunc: func(ctx context.Context, releaseGroupID string) ([]musicbrainz.Track, error) {
+			if !httpclient.IsBackground(ctx) {
+				t.Error("expected re-enrichment lookups to be marked as background work")
+			}
+			return []musicbrainz.Track{{Number: 1, Title: "Smells Like Teen Spirit"}}, nil
+		},
+	}
+	reenricher := NewReenricher(Deps{Albums: store, MusicBrainz: mb}, store, 0)
+
+	run, err := reenricher.Run(ctx, ReenrichRequest{Kind: db.KindAlbum, Field: db.QualityTracks, Limit: 5})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if run.Filled != 1 {
+		t.Fatalf("unexpected run %+v", run)
+	}
+	album, _ := store.GetAlbum(ctx, testAlbumID)
+	if len(album.Tracks) != 1 {
+		t.Errorf("expected tracks to be filled, got %+v", album.Tracks)
+	}
+}
+
+func TestReenricherRejectsConcurrentRuns(t *testing.T) {
+	reenricher := NewReenricher(Deps{}, nil, 0)
+	if _, err := reenricher.begin(ReenrichRequest{}); err != nil {
+		t.Fatalf("begin returned error: %v", err)
+	}
+	if _, err := reenricher.Run(context.Background(), ReenrichRequest{}); !errors.Is(err, ErrJobRunning) {
+		t.Fatalf("expected ErrJobRunning, got %v", err)
+	}
+}
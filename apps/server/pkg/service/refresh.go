@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpcache"
+)
+
+const (
+	// refreshRetryDelay spaces out refresh attempts for a record whose upstream is failing, so
+	// an outage costs one failed call per record every few minutes rather than one per request.
+	refreshRetryDelay = 5 * time.Minute
+	// maxRefreshEntries bounds the refresh bookkeeping; past it, entries old enough to be due
+	// again anyway are dropped.
+	maxRefreshEntries = 10000
+)
+
+type forceRefreshKey struct{}
+
+// WithForceRefresh makes artist and album lookups made with ctx skip the cache, refetch from
+// upstream (revalidating any cached HTTP responses), and overwrite the stored record. It is
+// the way to repair a stale or partial record without clearing the whole store.
+func WithForceRefresh(ctx context.Context) context.Context {
+	return httpcache.WithRevalidate(context.WithValue(ctx, forceRefreshKey{}, true))
+}
+
+func forceRefresh(ctx context.Context) bool {
+	on, _ := ctx.Value(forceRefreshKey{}).(bool)
+	return on
+}
+
+// refreshTracker decides when a cached record is due a refetch. Saves that change nothing
+// leave a record's updated_at alone, so it also remembers recent refreshes itself; otherwise an
+// unchanged record would be refetched on every request once it passed the TTL.
+type refreshTracker struct {
+	mu      sync.Mutex
+	checked map[string]time.Time
+}
+
+// stale reports whether the cached record is older than ttl and claims the refresh, so
+// concurrent requests keep serving the cached copy while one of them refetches.
+func (t *refreshTracker) stale(ctx context.Context, deps Deps, kind, id string, ttl time.Duration) bool {
+	if ttl <= 0 || deps.Modified == nil || deps.MusicBrainz == nil {
+		return false
+	}
+
+	var modified time.Time
+	var err error
+	switch kind {
+	case db.KindArtist:
+		modified, err = deps.Modified.ArtistModified(ctx, id)
+	case db.KindAlbum:
+		modified, err = deps.Modified.AlbumModified(ctx, id)
+	}
+	if err != nil || modified.IsZero() {
+		return false
+	}
+	return t.due(id, modified, ttl, time.Now())
+}
+
+func (t *refreshTracker) due(id string, modified time.Time, ttl time.Duration, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	last := modified
+	if checked := t.checked[id]; checked.After(last) {
+		last = checked
+	}
+	if now.Sub(last) < ttl {
+		return false
+	}
+
+	if t.checked == nil {
+		t.checked = make(map[string]time.Time)
+	}
+	if len(t.checked) >= maxRefreshEntries {
+		for key, checked := range t.checked {
+			if now.Sub(checked) >= ttl {
+				delete(t.checked, key)
+			}
+		}
+	}
+	t.checked[id] = now
+	return true
+}
+
+// failed schedules the next attempt refreshRetryDelay from now rather than a full TTL, since
+// the cached copy being served is already stale.
+func (t *refreshTracker) failed(id string, ttl time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.checked[id]; ok {
+		t.checked[id] = time.Now().Add(refreshRetryDelay - ttl)
+	}
+}
+
+// missingTracker is the negative cache: it remembers IDs MusicBrainz reported missing, so
+// repeated lookups of a mistyped or deleted ID are answered without another upstream call.
+type missingTracker struct {
+	mu    sync.Mutex
+	until map[string]time.Time
+}
+
+// missing reports whether id was reported missing within the negative-cache TTL.
+func (m *missingTracker) missing(id string, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return now.Before(m.until[id])
+}
+
+// remember records id as missing for ttl. When the tracker is full of unexpired entries the
+// ID is simply not remembered, so a flood of bad IDs costs lookups rather than memory.
+func (m *missingTracker) remember(id string, ttl time.Duration, now time.Time) {
+	if ttl <= 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.until == nil {
+		m.until = make(map[string]time.Time)
+	}
+	if len(m.until) >= maxRefreshEntries {
+		for key, until := range m.until {
+			if !now.Before(until) {
+				delete(m.until, key)
+			}
+		}
+		if len(m.until) >= maxRefreshEntries {
+			return
+		}
+	}
+	m.until[id] = now.Add(ttl)
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+func newRefreshStore(t *testing.T) *db.MemoryStore {
+	t.Helper()
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	if err := store.SaveArtist(context.Background(), &data.Artist{ID: testArtistID, Name: "Cached"}); err != nil {
+		t.Fatalf("SaveArtist: %v", err)
+	}
+	if err := store.SaveAlbum(context.Background(), &data.Album{ID: testAlbumID, Title: "Cached"}); err != nil {
+		t.Fatalf("SaveAlbum: %v", err)
+	}
+	return store
+}
+
+func TestGetArtistRefreshesPastTTL(t *testing.T) {
+	store := newRefreshStore(t)
+	calls := 0
+	mb := &stubMusicBrainz{
+		lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			calls++
+			return &musicbrainz.Artist{ID: id, Name: "Fresh"}, nil
+		},
+	}
+	deps := Deps{Artists: store, Albums: store, MusicBrainz: mb, Modified: store}
+
+	deps.CacheTTL.Artists = time.Hour
+	artist, err := NewArtistService(deps).GetArtist(context.Background(), testArtistID)
+	if err != nil || artist.Name != "Cached" || calls != 0 {
+		t.Fatalf("expected the fresh cache to be served, got %+v (%v) after %d calls", artist, err, calls)
+	}
+
+	deps.CacheTTL.Artists = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	artist, err = NewArtistService(deps).GetArtist(context.Background(), testArtistID)
+	if err != nil || artist.Name != "Fresh" || calls != 1 {
+		t.Fatalf("expected a refetch past the TTL, got %+v (%v) after %d calls", artist, err, calls)
+	}
+	if cached, _ := store.GetArtist(context.Background(), testArtistID); cached.Name != "Fresh" {
+		t.Fatalf("expected the refetched artist to be cached, got %q", cached.Name)
+	}
+}
+
+func TestGetAlbumServesStaleCopyWhenUpstreamFails(t *testing.T) {
+	store := newRefreshStore(t)
+	calls := 0
+	mb := &stubMusicBrainz{
+		lookupReleaseGroupFunc: func(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error) {
+			calls++
+			return nil, errors.New("upstream down")
+		},
+	}
+	svc := NewAlbumService(Deps{Albums: store, MusicBrainz: mb, Modified: store, CacheTTL: CacheTTLs{Albums: time.Nanosecond}})
+	time.Sleep(time.Millisecond)
+
+	for range 2 {
+		album, err := svc.GetAlbum(context.Background(), testAlbumID)
+		if err != nil || album.Title != "Cached" {
+			t.Fatalf("expected the stale album, got %+v (%v)", album, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected one refresh attempt before backing off, got %d", calls)
+	}
+}
+
+type countingReviews struct {
+	calls   int
+	reviews []data.Review
+}
+
+func (c *countingReviews) GetAlbumReviews(ctx context.Context, artistName, albumTitle string) ([]data.Review, error) {
+	c.calls++
+	return c.reviews, nil
+}
+
+func TestGetAlbumRefreshesOnlyReviewsPastTheirTTL(t *testing.T) {
+	store := newRefreshStore(t)
+	lookups := 0
+	mb := &stubMusicBrainz{
+		lookupReleaseGroupFunc: func(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error) {
+			lookups++
+			return nil, errors.New("unexpected full refresh")
+		},
+	}
+	reviews := &countingReviews{reviews: []data.Review{{Source: "discogs", Rating: 4}}}
+	svc := NewAlbumService(Deps{Albums: store, MusicBrainz: mb, Reviews: reviews, Modified: store, CacheTTL: CacheTTLs{Albums: time.Hour, Reviews: time.Nanosecond}})
+	time.Sleep(time.Millisecond)
+
+	album, err := svc.GetAlbum(context.Background(), testAlbumID)
+	if err != nil || len(album.Reviews) != 1 || reviews.calls != 1 || lookups != 0 {
+		t.Fatalf("expected only the reviews to be refetched, got %+v (%v) after %d review calls and %d lookups", album, err, reviews.calls, lookups)
+	}
+	if cached, _ := store.GetAlbum(context.Background(), testAlbumID); len(cached.Reviews) != 1 || cached.Title != "Cached" {
+		t.Fatalf("expected the refreshed reviews to be cached on the album, got %+v", cached)
+	}
+}
+
+func TestGetArtistRemembersMissingIDs(t *testing.T) {
+	calls := 0
+	mb := &stubMusicBrainz{
+		lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			calls++
+			return nil, musicbrainz.ErrNotFound
+		},
+	}
+	svc := NewArtistService(Deps{MusicBrainz: mb, CacheTTL: CacheTTLs{NotFound: time.Hour}})
+
+	for range 3 {
+		if _, err := svc.GetArtist(context.Background(), testArtistID); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected not found, got %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected the miss to be remembered after one lookup, got %d lookups", calls)
+	}
+}
+
+func TestMissingTrackerExpires(t *testing.T) {
+	var tracker missingTracker
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tracker.remember("gone", time.Minute, now)
+	tracker.remember("ignored", 0, now)
+	if !tracker.missing("gone", now.Add(30*time.Second)) {
+		t.Fatal("expected a remembered miss inside its TTL")
+	}
+	if tracker.missing("gone", now.Add(time.Minute)) || tracker.missing("ignored", now) {
+		t.Fatal("expected misses to expire, and a zero TTL to remember nothing")
+	}
+}
+
+func TestRefreshTrackerDue(t *testing.T) {
+	var tracker refreshTracker
+	modified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ttl := time.Hour
+
+	if tracker.due("id", modified, ttl, modified.Add(30*time.Minute)) {
+		t.Fatal("expected a record inside its TTL not to be due")
+	}
+	now := modified.Add(2 * time.Hour)
+	if !tracker.due("id", modified, ttl, now) {
+		t.Fatal("expected a record past its TTL to be due")
+	}
+	if tracker.due("id", modified, ttl, now.Add(time.Minute)) {
+		t.Fatal("expected an unchanged record not to be due again right after a refresh")
+	}
+	if !tracker.due("id", modified, ttl, now.Add(ttl)) {
+		t.Fatal("expected an unchanged record to be due again a TTL after its refresh")
+	}
+}
+
+func TestGetArtistForceRefreshOverwritesFreshCache(t *testing.T) {
+	store := newRefreshStore(t)
+	calls := 0
+	mb := &stubMusicBrainz{
+		lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			calls++
+			return &musicbrainz.Artist{ID: id, Name: "Fresh"}, nil
+		},
+	}
+	svc := NewArtistService(Deps{Artists: store, Albums: store, MusicBrainz: mb, Modified: store, CacheTTL: CacheTTLs{Artists: time.Hour}})
+
+	artist, err := svc.GetArtist(WithForceRefresh(context.Background()), testArtistID)
+	if err != nil || artist.Name != "Fresh" || calls != 1 {
+		t.Fatalf("expected a forced refetch, got %+v (%v) after %d calls", artist, err, calls)
+	}
+	if cached, _ := store.GetArtist(context.Background(), testArtistID); cached.Name != "Fresh" {
+		t.Fatalf("expected the refetched artist to overwrite the cache, got %q", cached.Name)
+	}
+}
+
+func TestGetAlbumForceRefreshOverwritesFreshCache(t *testing.T) {
+	store := newRefreshStore(t)
+	calls := 0
+	mb := &stubMusicBrainz{
+		lookupReleaseGroupFunc: func(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error) {
+			calls++
+			return &musicbrainz.ReleaseGroup{ID: id, Title: "Fresh"}, nil
+		},
+	}
+	svc := NewAlbumService(Deps{Albums: store, MusicBrainz: mb, Modified: store, CacheTTL: CacheTTLs{Albums: time.Hour}})
+
+	album, err := svc.GetAlbum(WithForceRefresh(context.Background()), testAlbumID)
+	if err != nil || album.Title != "Fresh" || calls != 1 {
+		t.Fatalf("expected a forced refetch, got %+v (%v) after %d calls", album, err, calls)
+	}
+	if cached, _ := store.GetAlbum(context.Background(), testAlbumID); cached.Title != "Fresh" {
+		t.Fatalf("expected the refetched album to overwrite the cache, got %q", cached.Title)
+	}
+}
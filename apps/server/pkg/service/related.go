@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/lastfm"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+// similarArtistLimit caps the similar artists asked of Last.fm per artist.
+const similarArtistLimit = 20
+
+// RelatedService lists the artists related to an artist.
+type RelatedService interface {
+	// GetRelated returns the artists related to id: those MusicBrainz links it to first, then
+	// Last.fm's similar artists, most similar first. The list is built when the artist is
+	// fetched and cached with it. A merged MBID yields a *MovedError; other failures are
+	// *Error values.
+	GetRelated(ctx context.Context, id string) (*data.RelatedArtists, error)
+}
+
+type relatedService struct {
+	artists ArtistService
+}
+
+// NewRelatedService builds a RelatedService over deps.
+func NewRelatedService(deps Deps) RelatedService {
+	return &relatedService{artists: NewArtistService(deps)}
+}
+
+func (s *relatedService) GetRelated(ctx context.Context, id string) (*data.RelatedArtists, error) {
+	artist, err := s.artists.GetArtist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &data.RelatedArtists{
+		ArtistID:   artist.ID,
+		ArtistName: artist.Name,
+		Related:    append([]data.RelatedArtist{}, artist.Related...),
+	}, nil
+}
+
+// relatedFromMusicBrainz lists the artists MusicBrainz relates src to, band members and bands
+// first, one entry per artist.
+func relatedFromMusicBrainz(src *musicbrainz.Artist) []data.RelatedArtist {
+	var related []data.RelatedArtist
+	seen := map[string]bool{src.ID: true}
+	for _, rel := range slices.Concat(src.Memberships, src.Associations) {
+		if seen[rel.ArtistID] {
+			continue
+		}
+		seen[rel.ArtistID] = true
+		related = append(related, data.RelatedArtist{
+			ID:       rel.ArtistID,
+			Name:     rel.ArtistName,
+			Relation: rel.Type,
+			Source:   sourceMusicBrainz,
+		})
+	}
+	return related
+}
+
+// musicBrainzRelated returns the MusicBrainz entries of related without Last.fm's match
+// scores, as a lookup that skipped Last.fm would have built them.
+func musicBrainzRelated(related []data.RelatedArtist) []data.RelatedArtist {
+	var kept []data.RelatedArtist
+	for _, rel := range related {
+		if rel.Source == sourceMusicBrainz {
+			rel.Match = 0
+			kept = append(kept, rel)
+		}
+	}
+	return kept
+}
+
+// fetchSimilarArtists appends Last.fm's similar artists to artist.Related. An artist already
+// related through MusicBrainz gains Last.fm's match score instead of a second entry.
+// stepsLeft is as for enrichmentStep.
+func fetchSimilarArtists(ctx context.Context, client LastfmClient, artist *data.Artist, stepsLeft int, warned *warnings) {
+	if client == nil {
+		return
+	}
+	source := sourceName(client, sourceLastfm)
+	if !sourceAvailable(client) {
+		warned.unavailable(fieldRelated, source)
+		return
+	}
+	stepCtx, cancel := enrichmentStep(ctx, stepsLeft)
+	defer cancel()
+	similar, err := client.GetSimilarArtists(stepCtx, artist.ID, artist.Name, similarArtistLimit)
+	if err != nil {
+		warned.add(fieldRelated, source, err)
+		return
+	}
+	artist.Related = mergeSimilarArtists(artist.Related, artist.ID, similar)
+}
+
+// mergeSimilarArtists returns related with similar appended, matching entries by MBID or by
+// name. related itself is left untouched.
+func mergeSimilarArtists(related []data.RelatedArtist, subjectID string, similar []lastfm.SimilarArtist) []data.RelatedArtist {
+	merged := append([]data.RelatedArtist(nil), related...)
+	byID := make(map[string]int, len(merged))
+	byName := make(map[string]int, len(merged))
+	for i, rel := range merged {
+		if rel.ID != "" {
+			byID[rel.ID] = i
+		}
+		byName[strings.ToLower(rel.Name)] = i
+	}
+	for _, artist := range similar {
+		if artist.MBID != "" && artist.MBID == subjectID {
+			continue
+		}
+		i, ok := byID[artist.MBID]
+		if !ok {
+			i, ok = byName[strings.ToLower(artist.Name)]
+		}
+		if ok {
+			if merged[i].Match == 0 {
+				merged[i].Match = artist.Match
+			}
+			continue
+		}
+		if artist.MBID != "" {
+			byID[artist.MBID] = len(merged)
+		}
+		byName[strings.ToLower(artist.Name)] = len(merged)
+		merged = append(merged, data.RelatedArtist{
+			ID:     artist.MBID,
+			Name:   artist.Name,
+			Match:  artist.Match,
+			Source: sourceLastfm,
+		})
+	}
+	return merged
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/lastfm"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+// countingLastfm counts similar-artist lookups so tests can tell cached answers from fresh ones.
+type countingLastfm struct {
+	stubLastfm
+	similarCalls int
+}
+
+func (c *countingLastfm) GetSimilarArtists(ctx context.Context, mbid, name string, limit int) ([]lastfm.SimilarArtist, error) {
+	c.similarCalls++
+	return c.stubLastfm.GetSimilarArtists(ctx, mbid, name, limit)
+}
+
+func TestGetRelatedCombinesMusicBrainzAndLastfmAndCaches(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.NewMemoryStore(ctx)
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	mb := &stubMusicBrainz{
+		lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			return &musicbrainz.Artist{
+				ID:           id,
+				Name:         "Radiohead",
+				Memberships:  []musicbrainz.ArtistRelation{{Type: "member of band", ArtistID: "thom", ArtistName: "Thom Yorke"}},
+				Associations: []musicbrainz.ArtistRelation{{Type: "collaboration", ArtistID: "smile", ArtistName: "The Smile"}},
+			}, nil
+		},
+	}
+	client := &countingLastfm{stubLastfm: stubLastfm{artist: &lastfm.ArtistInfo{Name: "Radiohead"}, similar: []lastfm.SimilarArtist{
+		{Name: "Radiohead", MBID: testArtistID, Match: 1},
+		{Name: "The Smile", MBID: "smile", Match: 0.9},
+		{Name: "Portishead", MBID: "portishead", Match: 0.6},
+		{Name: "Thom Yorke", Match: 0.5},
+	}}}
+	related := NewRelatedService(Deps{Artists: store, MusicBrainz: mb, Lastfm: client})
+
+	listing, err := related.GetRelated(ctx, testArtistID)
+	if err != nil {
+		t.Fatalf("GetRelated returned error: %v", err)
+	}
+	want := []data.RelatedArtist{
+		{ID: "thom", Name: "Thom Yorke", Relation: "member of band", Match: 0.5, Source: sourceMusicBrainz},
+		{ID: "smile", Name: "The Smile", Relation: "collaboration", Match: 0.9, Source: sourceMusicBrainz},
+		{ID: "portishead", Name: "Portishead", Match: 0.6, Source: sourceLastfm},
+	}
+	if len(listing.Related) != len(want) {
+		t.Fatalf("Related = %+v, want %+v", listing.Related, want)
+	}
+	for i := range want {
+		if listing.Related[i] != want[i] {
+			t.Errorf("Related[%d] = %+v, want %+v", i, listing.Related[i], want[i])
+		}
+	}
+
+	if _, err := related.GetRelated(ctx, testArtistID); err != nil {
+		t.Fatalf("second GetRelated returned error: %v", err)
+	}
+	if client.similarCalls != 1 {
+		t.Errorf("expected the cached artist to answer the second lookup, got %d Last.fm calls", client.similarCalls)
+	}
+}
+
+func TestTrimArtistKeepsMusicBrainzRelatedBelowFullDepth(t *testing.T) {
+	cached := []data.RelatedArtist{
+		{ID: "smile", Name: "The Smile", Relation: "collaboration", Match: 0.9, Source: sourceMusicBrainz},
+		{ID: "portishead", Name: "Portishead", Match: 0.6, Source: sourceLastfm},
+	}
+	artist := &data.Artist{Related: cached}
+
+	trimArtist(artist, DepthStandard)
+
+	if len(artist.Related) != 1 || artist.Related[0].ID != "smile" || artist.Related[0].Match != 0 {
+		t.Errorf("expected only the MusicBrainz relation without a match score, got %+v", artist.Related)
+	}
+	if cached[0].Match != 0.9 {
+		t.Error("expected the cached related list untouched")
+	}
+}
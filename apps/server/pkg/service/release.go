@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/reviews"
+)
+
+// ReleaseReviewsClient is implemented by review sources that can also describe the release
+// their reviews were read from, without further requests.
+type ReleaseReviewsClient interface {
+	GetAlbumReviewsAndRelease(ctx context.Context, artistName, albumTitle string) ([]data.Review, *reviews.Release, error)
+}
+
+// albumReviews fetches an album's reviews and, from sources that can describe it, the release
+// they came from.
+func albumReviews(ctx context.Context, client ReviewsClient, artistName, albumTitle string) ([]data.Review, *reviews.Release, error) {
+	if withRelease, ok := client.(ReleaseReviewsClient); ok {
+		return withRelease.GetAlbumReviewsAndRelease(ctx, artistName, albumTitle)
+	}
+	found, err := client.GetAlbumReviews(ctx, artistName, albumTitle)
+	return found, nil, err
+}
+
+// fetchedReviews is the outcome of a background albumReviews call.
+type fetchedReviews struct {
+	reviews []data.Review
+	release *reviews.Release
+	err     error
+}
+
+// startReviews fetches an album's reviews in the background and returns the channel that
+// delivers them, or nil when there is no reviews source or it is down.
+func (s *albumService) startReviews(ctx context.Context, artistName, albumTitle string) <-chan fetchedReviews {
+	client := s.deps.Reviews
+	if client == nil || !sourceAvailable(client) {
+		return nil
+	}
+	stepCtx, cancel := enrichmentStep(ctx, 5)
+	done := make(chan fetchedReviews, 1)
+	go func() {
+		defer cancel()
+		found, release, err := albumReviews(stepCtx, client, artistName, albumTitle)
+		done <- fetchedReviews{reviews: found, release: release, err: err}
+	}()
+	return done
+}
+
+// applyRelease fills the genre, styles, label, and catalog number MusicBrainz left empty from
+// the release a review came from. The catalog number is only taken with the label it belongs
+// to, so it never ends up beside a different label MusicBrainz credited.
+func applyRelease(album *data.Album, release *reviews.Release) {
+	if release == nil {
+		return
+	}
+	if album.Genre == "" && len(release.Genres) > 0 {
+		album.Genre = release.Genres[0]
+	}
+	if len(album.Styles) == 0 {
+		album.Styles = release.Styles
+	}
+	if album.Label == "" {
+		album.Label = release.Label
+	}
+	if album.CatalogNumber == "" && strings.EqualFold(album.Label, release.Label) {
+		album.CatalogNumber = release.CatalogNumber
+	}
+}
+
+// fetchMarketplace reads the marketplace stats of the release an album's reviews came from.
+// It returns nil when stats are disabled, there is no release, or the lookup fails. stepsLeft
+// is as for enrichmentStep.
+func fetchMarketplace(ctx context.Context, client MarketplaceClient, release *reviews.Release, stepsLeft int) *data.Marketplace {
+	if client == nil || release == nil || release.ID == 0 || !sourceAvailable(client) {
+		return nil
+	}
+	stepCtx, cancel := enrichmentStep(ctx, stepsLeft)
+	defer cancel()
+	marketplace, err := client.GetMarketplaceStats(stepCtx, release.ID)
+	if err != nil {
+		return nil
+	}
+	return marketplace
+}
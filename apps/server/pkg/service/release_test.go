@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/reviews"
+)
+
+type releaseReviews struct {
+	release *reviews.Release
+}
+
+type stubMarketplace struct {
+	releaseIDs []int
+}
+
+func (m *stubMarketplace) GetMarketplaceStats(ctx context.Context, releaseID int) (*data.Marketplace, error) {
+	m.releaseIDs = append(m.releaseIDs, releaseID)
+	return &data.Marketplace{Currency: "USD", LowestPrice: 12.5, NumForSale: 42}, nil
+}
+
+func (r *releaseReviews) GetAlbumReviews(ctx context.Context, artistName, albumTitle string) ([]data.Review, error) {
+	found, _, err := r.GetAlbumReviewsAndRelease(ctx, artistName, albumTitle)
+	return found, err
+}
+
+func (r *releaseReviews) GetAlbumReviewsAndRelease(ctx context.Context, artistName, albumTitle string) ([]data.Review, *reviews.Release, error) {
+	return []data.Review{{Source: "Discogs", Rating: 4.5}}, r.release, nil
+}
+
+func TestGetAlbumFillsGenreAndLabelFromDiscogsRelease(t *testing.T) {
+	mb := &stubMusicBrainz{
+		lookupReleaseGroupFunc: func(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error) {
+			return &musicbrainz.ReleaseGroup{ID: id, Title: "Nevermind"}, nil
+		},
+	}
+	release := &reviews.Release{Genres: []string{"Rock"}, Styles: []string{"Grunge"}, Label: "DGC", CatalogNumber: "DGC-24425"}
+	svc := NewAlbumService(Deps{MusicBrainz: mb, Reviews: &releaseReviews{release: release}})
+
+	album, err := svc.GetAlbum(context.Background(), testAlbumID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if album.Genre != "Rock" || len(album.Styles) != 1 || album.Styles[0] != "Grunge" {
+		t.Errorf("expected Discogs genre and styles, got %q %v", album.Genre, album.Styles)
+	}
+	if album.Label != "DGC" || album.CatalogNumber != "DGC-24425" {
+		t.Errorf("expected Discogs label and catalog number, got %q %q", album.Label, album.CatalogNumber)
+	}
+}
+
+func TestApplyReleaseKeepsMusicBrainzValues(t *testing.T) {
+	album := &data.Album{Genre: "grunge", Label: "Sub Pop", LabelID: "sub-pop"}
+	applyRelease(album, &reviews.Release{Genres: []string{"Rock"}, Label: "DGC", CatalogNumber: "DGC-24425"})
+
+	if album.Genre != "grunge" || album.Label != "Sub Pop" || album.LabelID != "sub-pop" {
+		t.Errorf("expected MusicBrainz genre and label kept, got %+v", album)
+	}
+	if album.CatalogNumber != "" {
+		t.Errorf("expected no catalog number from a different label, got %q", album.CatalogNumber)
+	}
+
+	applyRelease(album, &reviews.Release{Label: "sub pop", CatalogNumber: "SP 34"})
+	if album.CatalogNumber != "SP 34" {
+		t.Errorf("expected the catalog number for the same label, got %q", album.CatalogNumber)
+	}
+}
+
+func TestGetAlbumAddsMarketplaceStatsWhenEnabled(t *testing.T) {
+	mb := &stubMusicBrainz{
+		lookupReleaseGroupFunc: func(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error) {
+			return &musicbrainz.ReleaseGroup{ID: id, Title: "Nevermind"}, nil
+		},
+	}
+	release := &reviews.Release{ID: 249504}
+
+	album, err := NewAlbumService(Deps{MusicBrainz: mb, Reviews: &releaseReviews{release: release}}).GetAlbum(context.Background(), testAlbumID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if album.Marketplace != nil {
+		t.Errorf("expected no marketplace stats while disabled, got %+v", album.Marketplace)
+	}
+
+	marketplace := &stubMarketplace{}
+	album, err = NewAlbumService(Deps{MusicBrainz: mb, Reviews: &releaseReviews{release: release}, Marketplace: marketplace}).GetAlbum(context.Background(), testAlbumID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if album.Marketplace == nil || album.Marketplace.NumForSale != 42 {
+		t.Errorf("expected marketplace stats, got %+v", album.Marketplace)
+	}
+	if len(marketplace.releaseIDs) != 1 || marketplace.releaseIDs[0] != 249504 {
+		t.Errorf("expected stats for the reviewed release, got %v", marketplace.releaseIDs)
+	}
+}
+
+// waitingReviews only answers once the track listing has been requested, so it fails when
+// reviews are fetched after the tracks instead of alongside them.
+type waitingReviews struct {
+	tracksRequested chan struct{}
+}
+
+func (w *waitingReviews) GetAlbumReviews(ctx context.Context, artistName, albumTitle string) ([]data.Review, error) {
+	select {
+	case <-w.tracksRequested:
+		return []data.Review{{Source: "Discogs", Rating: 4.5}}, nil
+	case <-time.After(time.Second):
+		return nil, errors.New("tracks were not requested while reviews were pending")
+	}
+}
+
+func TestGetAlbumFetchesReviewsAlongsideTracks(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	reviewsClient := &waitingReviews{tracksRequested: make(chan struct{})}
+	mb := &stubMusicBrainz{
+		lookupReleaseGroupFunc: func(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error) {
+			return &musicbrainz.ReleaseGroup{ID: id, Title: "Nevermind"}, nil
+		},
+		getReleaseGroupTracksFunc: func(ctx context.Context, releaseGroupID string) ([]musicbrainz.Track, error) {
+			close(reviewsClient.tracksRequested)
+			return []musicbrainz.Track{{Number: 1, Title: "Smells Like Teen Spirit"}}, nil
+		},
+	}
+
+	album, err := NewAlbumService(Deps{Albums: store, MusicBrainz: mb, Reviews: reviewsClient}).GetAlbum(context.Background(), testAlbumID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(album.Reviews) != 1 || len(album.Tracks) != 1 || len(album.Warnings) != 0 {
+		t.Fatalf("expected reviews and tracks without warnings, got %+v", album)
+	}
+	if cached, _ := store.GetAlbum(context.Background(), testAlbumID); cached == nil || len(cached.Reviews) != 1 {
+		t.Errorf("expected the reviews cached with the album, got %+v", cached)
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/langdetect"
+)
+
+// multipleLanguages tags review text whose paragraphs are in more than one language. It is the
+// ISO 639-2 code for multiple languages.
+const multipleLanguages = "mul"
+
+// tagReviewLanguages records the language each review's text is written in.
+func tagReviewLanguages(reviews []data.Review) {
+	for i := range reviews {
+		reviews[i].Language = textLanguage(reviewParagraphs(reviews[i].Text))
+	}
+}
+
+// textLanguage is the language the paragraphs share, multipleLanguages when they disagree, or
+// "" when none could be detected.
+func textLanguage(paragraphs []string) string {
+	language := ""
+	for _, paragraph := range paragraphs {
+		detected := langdetect.Detect(paragraph)
+		switch {
+		case detected == "" || detected == language:
+		case language == "":
+			language = detected
+		default:
+			return multipleLanguages
+		}
+	}
+	return language
+}
+
+// localizeReviews returns the reviews as a reader preferring tags, BCP 47 language tags in
+// order of preference, should see them. Text written in several languages (Discogs notes often
+// repeat themselves in each) is cut to the paragraphs in the first preferred language present,
+// text written only in other languages is removed, and a review left with no text, rating or
+// summary is dropped. Paragraphs whose language can't be detected, such as credit lists, are
+// always kept. The reviews are copied, so a cached album's are left alone.
+func localizeReviews(reviews []data.Review, tags []string) []data.Review {
+	if len(tags) == 0 || len(reviews) == 0 {
+		return reviews
+	}
+
+	localized := make([]data.Review, 0, len(reviews))
+	for _, review := range reviews {
+		paragraphs := reviewParagraphs(review.Text)
+		languages := make([]string, len(paragraphs))
+		for i, paragraph := range paragraphs {
+			languages[i] = langdetect.Detect(paragraph)
+		}
+
+		chosen, found := preferredLanguage(languages, tags)
+		if !found {
+			localized = append(localized, review)
+			continue
+		}
+
+		var kept []string
+		for i, paragraph := range paragraphs {
+			if languages[i] == "" || languages[i] == chosen {
+				kept = append(kept, paragraph)
+			}
+		}
+		review.Text = strings.Join(kept, "\n\n")
+		review.Language = chosen
+		if review.Text == "" {
+			review.Language = ""
+			if review.Rating == 0 && review.Summary == "" {
+				continue
+			}
+		}
+		localized = append(localized, review)
+	}
+	return localized
+}
+
+// preferredLanguage picks, from the detected paragraph languages, the first one tags asks for.
+// found is false when nothing was detected, leaving the text as it is; chosen is "" when text
+// was detected but only in languages tags doesn't ask for.
+func preferredLanguage(languages []string, tags []string) (chosen string, found bool) {
+	for _, language := range languages {
+		if language != "" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return "", false
+	}
+	for _, tag := range tags {
+		wanted, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		for _, language := range languages {
+			if language == wanted {
+				return wanted, true
+			}
+		}
+	}
+	return "", true
+}
+
+// reviewParagraphs splits review text on blank lines, dropping empty paragraphs.
+func reviewParagraphs(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var paragraphs []string
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return paragraphs
+}
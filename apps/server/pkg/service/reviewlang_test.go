@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+)
+
+const (
+	englishNotes = "Recorded at Hansa Studios in Berlin. This is the second album of the trilogy and was released in 1977."
+	germanNotes  = "Das Album wurde in den Hansa Studios in Berlin aufgenommen und ist der zweite Teil der Trilogie."
+	creditNotes  = "Mastered By – Bob Ludwig"
+)
+
+func TestTagReviewLanguages(t *testing.T) {
+	reviews := []data.Review{
+		{Text: englishNotes},
+		{Text: englishNotes + "\n\n" + germanNotes},
+		{Text: creditNotes},
+	}
+	tagReviewLanguages(reviews)
+
+	for i, want := range []string{"en", multipleLanguages, ""} {
+		if reviews[i].Language != want {
+			t.Errorf("review %d: expected language %q, got %q", i, want, reviews[i].Language)
+		}
+	}
+}
+
+func TestGetAlbumKeepsReviewTextInPreferredLanguage(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	mixed := englishNotes + "\r\n\r\n" + germanNotes + "\r\n\r\n" + creditNotes
+	cached := &data.Album{ID: testAlbumID, Title: "Heroes", Reviews: []data.Review{
+		{Source: "Discogs", Rating: 4.5, Text: mixed},
+		{Source: "Discogs", Text: germanNotes},
+		{Source: "Discogs", Rating: 4, Text: germanNotes},
+	}}
+	if err := store.SaveAlbum(context.Background(), cached); err != nil {
+		t.Fatalf("SaveAlbum: %v", err)
+	}
+	svc := NewAlbumService(Deps{Albums: store})
+
+	ctx := WithLanguages(context.Background(), []string{"de-DE", "en"})
+	album, err := svc.GetAlbum(ctx, testAlbumID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(album.Reviews) != 3 {
+		t.Fatalf("expected every review for German, got %+v", album.Reviews)
+	}
+	if want := germanNotes + "\n\n" + creditNotes; album.Reviews[0].Text != want || album.Reviews[0].Language != "de" {
+		t.Errorf("expected German paragraphs and credits, got %q (%q)", album.Reviews[0].Text, album.Reviews[0].Language)
+	}
+
+	album, err = svc.GetAlbum(WithLanguages(context.Background(), []string{"en-US"}), testAlbumID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(album.Reviews) != 2 {
+		t.Fatalf("expected the unrated German-only review dropped, got %+v", album.Reviews)
+	}
+	if want := englishNotes + "\n\n" + creditNotes; album.Reviews[0].Text != want || album.Reviews[0].Language != "en" {
+		t.Errorf("expected English paragraphs and credits, got %q (%q)", album.Reviews[0].Text, album.Reviews[0].Language)
+	}
+	if album.Reviews[1].Text != "" || album.Reviews[1].Rating != 4 {
+		t.Errorf("expected the rated German review kept without text, got %+v", album.Reviews[1])
+	}
+
+	album, err = svc.GetAlbum(context.Background(), testAlbumID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(album.Reviews) != 3 || album.Reviews[0].Text != mixed {
+		t.Errorf("expected reviews untouched without preferred languages, got %+v", album.Reviews)
+	}
+}
@@ -0,0 +1,221 @@
+// Package service holds the read-through lookups behind the artist and album endpoints:
+// serve from the cache when possible, otherwise fetch from MusicBrainz, enrich from optional
+// sources, and persist the result.
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/wikitext"
+)
+
+// MusicBrainzClient captures the MusicBrainz operations the services rely on.
+type MusicBrainzClient interface {
+	LookupArtist(ctx context.Context, id string) (*musicbrainz.Artist, error)
+	LookupReleaseGroup(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error)
+	GetArtistReleaseGroups(ctx context.Context, artistID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
+	GetArtistReleaseGroupsOfTypes(ctx context.Context, artistID string, types musicbrainz.ReleaseGroupTypes, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
+	GetReleaseGroupTracks(ctx context.Context, releaseGroupID string) ([]musicbrainz.Track, error)
+	GetReleaseGroupEditions(ctx context.Context, releaseGroupID string) ([]musicbrainz.Edition, error)
+	GetReleaseGroupCredits(ctx context.Context, releaseGroupID string) ([]musicbrainz.Credit, error)
+	LookupLabel(ctx context.Context, id string) (*musicbrainz.Label, error)
+	GetLabelReleaseGroups(ctx context.Context, labelID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
+	LookupRecording(ctx context.Context, id string) (*musicbrainz.Recording, error)
+}
+
+// WikipediaClient captures the Wikipedia operations the services rely on.
+type WikipediaClient interface {
+	GetArtistBiography(ctx context.Context, artistName string) (string, error)
+}
+
+// AlbumFactsClient captures the album article parsing the services rely on.
+type AlbumFactsClient interface {
+	GetAlbumFacts(ctx context.Context, artistName, albumTitle, articleURL string) (*wikitext.AlbumFacts, error)
+}
+
+// AwardsClient captures the award lookups the services rely on. entityURL is the Wikidata link
+// MusicBrainz has for the artist or album.
+type AwardsClient interface {
+	GetAwards(ctx context.Context, entityURL string) ([]data.Award, error)
+}
+
+// ReviewsClient captures the reviews operations the services rely on.
+type ReviewsClient interface {
+	GetAlbumReviews(ctx context.Context, artistName, albumTitle string) ([]data.Review, error)
+}
+
+// MarketplaceClient captures the Discogs marketplace stats the services rely on.
+type MarketplaceClient interface {
+	GetMarketplaceStats(ctx context.Context, releaseID int) (*data.Marketplace, error)
+}
+
+// ImageResolver captures the image fallback chain the services rely on.
+type ImageResolver interface {
+	ArtistImages(ctx context.Context, artistID, artistName string) []data.Image
+	AlbumImages(ctx context.Context, albumID, artistName, albumTitle string) []data.Image
+}
+
+// Deps are the stores and upstream clients shared by the services. Any of them may be nil:
+// without a repository nothing is cached, and without MusicBrainz only cached records are served.
+type Deps struct {
+	Artists     db.ArtistRepository
+	Albums      db.AlbumRepository
+	Labels      db.LabelRepository
+	Aliases     db.AliasRepository
+	MusicBrainz MusicBrainzClient
+	Wikipedia   WikipediaClient
+	AlbumFacts  AlbumFactsClient
+	Awards      AwardsClient
+	Reviews     ReviewsClient
+	Lastfm      LastfmClient
+	Images      ImageResolver
+	// Marketplace adds Discogs price stats for the release an album's reviews came from; nil
+	// leaves them out.
+	Marketplace MarketplaceClient
+	// RatingEvents reports cached albums whose aggregate rating changes on a later fetch.
+	RatingEvents RatingEvents
+	// MaxArtistAlbums caps the albums and EPs fetched with an artist; zero uses the default.
+	MaxArtistAlbums int
+	// CacheTTL bounds how long each kind of cached data is served, judged by Modified. Without
+	// Modified, cached records are kept indefinitely.
+	CacheTTL CacheTTLs
+	Modified db.ModificationTracker
+}
+
+// CacheTTLs bounds how long each kind of cached data is served before it is fetched again. A
+// zero TTL keeps that kind indefinitely, or for NotFound, turns negative caching off.
+type CacheTTLs struct {
+	// Artists and Albums are how long cached records are served before MusicBrainz is asked
+	// again. A failed refresh serves the stale copy.
+	Artists time.Duration
+	Albums  time.Duration
+	// Reviews is how long a cached album's reviews are served before the reviews source alone
+	// is asked again. It only matters when shorter than Albums, since a full refresh refetches
+	// reviews too.
+	Reviews time.Duration
+	// NotFound is how long an ID MusicBrainz reported missing is answered as not found
+	// without asking again.
+	NotFound time.Duration
+}
+
+func (d Deps) maxArtistAlbums() int {
+	if d.MaxArtistAlbums > 0 {
+		return d.MaxArtistAlbums
+	}
+	return defaultMaxArtistAlbums
+}
+
+// Sentinel error kinds, matched with errors.Is against errors returned by the services.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrUnavailable = errors.New("unavailable")
+	ErrUpstream    = errors.New("upstream failed")
+	ErrStorage     = errors.New("storage failed")
+	// ErrRateLimited means a required upstream refused the lookup for rate limiting; the
+	// Error's RetryAfter says how long it asked callers to wait.
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// Error carries a client-safe message alongside one of the sentinel kinds.
+type Error struct {
+	Kind       error
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+func newError(kind error, msg string) error {
+	return &Error{Kind: kind, Message: msg}
+}
+
+// upstreamError reports a failed required upstream call as ErrRateLimited when the source was
+// rate limiting us, and as ErrUpstream with msg otherwise.
+func upstreamError(err error, msg string) error {
+	var limited *retry.RateLimitedError
+	if errors.As(err, &limited) {
+		return &Error{Kind: ErrRateLimited, Message: limited.Source + " rate limited", RetryAfter: limited.RetryAfter}
+	}
+	return newError(ErrUpstream, msg)
+}
+
+// MovedError reports that the requested MBID was merged into another entity. Callers should
+// send clients to CanonicalID rather than serving the record under the old ID.
+type MovedError struct {
+	Kind        string
+	ID          string
+	CanonicalID string
+}
+
+func (e *MovedError) Error() string {
+	return e.Kind + " " + e.ID + " moved to " + e.CanonicalID
+}
+
+// resolveMoved reports a known merge for id from the alias table.
+func resolveMoved(ctx context.Context, aliases db.AliasRepository, kind, id string) error {
+	if aliases == nil {
+		return nil
+	}
+	canonicalID, err := aliases.ResolveAlias(ctx, kind, id)
+	if err != nil || canonicalID == "" || canonicalID == id {
+		return nil
+	}
+	return &MovedError{Kind: kind, ID: id, CanonicalID: canonicalID}
+}
+
+// recordAlias remembers a merged MBID detected during an upstream lookup. Failures only cost
+// a repeat upstream lookup, so they are logged rather than surfaced.
+func recordAlias(ctx context.Context, aliases db.AliasRepository, kind, id, canonicalID string) {
+	if aliases == nil || canonicalID == "" || canonicalID == id {
+		return
+	}
+	if err := aliases.SaveAlias(ctx, kind, id, canonicalID); err != nil {
+		logging.FromContext(ctx).Warn("alias save failed", "kind", kind, "id", id, "error", err)
+	}
+}
+
+// healthReporter is implemented by clients that know whether their upstream is currently down.
+type healthReporter interface {
+	Healthy() bool
+}
+
+// sourceAvailable reports false only for optional sources that track health and are known
+// to be down, so enrichment can skip them instead of waiting out their timeout.
+func sourceAvailable(client any) bool {
+	reporter, ok := client.(healthReporter)
+	return !ok || reporter.Healthy()
+}
+
+// fetchAwards looks up awards for an entity with a Wikidata link as the second-to-last
+// enrichment step. Entities without a link yield no awards; failures also add to warned.
+func fetchAwards(ctx context.Context, client AwardsClient, links map[string]string, warned *warnings) []data.Award {
+	entityURL := links[musicbrainz.LinkWikidata]
+	if client == nil || entityURL == "" {
+		return nil
+	}
+	if !sourceAvailable(client) {
+		warned.unavailable(fieldAwards, sourceName(client, sourceWikidata))
+		return nil
+	}
+	stepCtx, cancel := enrichmentStep(ctx, 2)
+	defer cancel()
+	awards, err := client.GetAwards(stepCtx, entityURL)
+	if err != nil {
+		warned.add(fieldAwards, sourceName(client, sourceWikidata), err)
+		return nil
+	}
+	return awards
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+const (
+	testArtistID   = "5b11f4ce-a62d-471e-81fc-a69a8278c7da"
+	testAlbumID    = "1b022e01-4da6-387b-8658-8678046e4cef"
+	unexpectedCall = "unexpected call"
+)
+
+type stubMusicBrainz struct {
+	lookupArtistFunc                  func(ctx context.Context, id string) (*musicbrainz.Artist, error)
+	lookupReleaseGroupFunc            func(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error)
+	getArtistReleaseGroupsFunc        func(ctx context.Context, artistID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
+	getArtistReleaseGroupsOfTypesFunc func(ctx context.Context, artistID string, types musicbrainz.ReleaseGroupTypes, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
+	getReleaseGroupTracksFunc         func(ctx context.Context, releaseGroupID string) ([]musicbrainz.Track, error)
+	lookupLabelFunc                   func(ctx context.Context, id string) (*musicbrainz.Label, error)
+	getLabelReleaseGroupsFunc         func(ctx context.Context, labelID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error)
+	lookupRecordingFunc               func(ctx context.Context, id string) (*musicbrainz.Recording, error)
+}
+
+func (s *stubMusicBrainz) LookupArtist(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+	if s.lookupArtistFunc != nil {
+		return s.lookupArtistFunc(ctx, id)
+	}
+	return nil, errors.New(unexpectedCall)
+}
+
+func (s *stubMusicBrainz) LookupReleaseGroup(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error) {
+	if s.lookupReleaseGroupFunc != nil {
+		return s.lookupReleaseGroupFunc(ctx, id)
+	}
+	return nil, errors.New(unexpectedCall)
+}
+
+func (s *stubMusicBrainz) GetArtistReleaseGroups(ctx context.Context, artistID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+	if s.getArtistReleaseGroupsFunc != nil {
+		return s.getArtistReleaseGroupsFunc(ctx, artistID, limit, offset)
+	}
+	return &musicbrainz.ReleaseGroupSearchResult{}, nil
+}
+
+func (s *stubMusicBrainz) GetArtistReleaseGroupsOfTypes(ctx context.Context, artistID string, types musicbrainz.ReleaseGroupTypes, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+	if s.getArtistReleaseGroupsOfTypesFunc != nil {
+		return s.getArtistReleaseGroupsOfTypesFunc(ctx, artistID, types, limit, offset)
+	}
+	return s.GetArtistReleaseGroups(ctx, artistID, limit, offset)
+}
+
+func (s *stubMusicBrainz) GetReleaseGroupTracks(ctx context.Context, releaseGroupID string) ([]musicbrainz.Track, error) {
+	if s.getReleaseGroupTracksFunc != nil {
+		return s.getReleaseGroupTracksFunc(ctx, releaseGroupID)
+	}
+	return nil, nil
+}
+
+func (s *stubMusicBrainz) GetReleaseGroupEditions(ctx context.Context, releaseGroupID string) ([]musicbrainz.Edition, error) {
+	return nil, nil
+}
+
+func (s *stubMusicBrainz) GetReleaseGroupCredits(ctx context.Context, releaseGroupID string) ([]musicbrainz.Credit, error) {
+	return nil, nil
+}
+
+func (s *stubMusicBrainz) LookupLabel(ctx context.Context, id string) (*musicbrainz.Label, error) {
+	if s.lookupLabelFunc != nil {
+		return s.lookupLabelFunc(ctx, id)
+	}
+	return nil, errors.New(unexpectedCall)
+}
+
+func (s *stubMusicBrainz) GetLabelReleaseGroups(ctx context.Context, labelID string, limit int, offset int) (*musicbrainz.ReleaseGroupSearchResult, error) {
+	if s.getLabelReleaseGroupsFunc != nil {
+		return s.getLabelReleaseGroupsFunc(ctx, labelID, limit, offset)
+	}
+	return &musicbrainz.ReleaseGroupSearchResult{}, nil
+}
+
+func (s *stubMusicBrainz) LookupRecording(ctx context.Context, id string) (*musicbrainz.Recording, error) {
+	if s.lookupRecordingFunc != nil {
+		return s.lookupRecordingFunc(ctx, id)
+	}
+	return nil, errors.New(unexpectedCall)
+}
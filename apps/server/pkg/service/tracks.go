@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+)
+
+// TrackService resolves the recordings behind album tracks by MBID.
+type TrackService interface {
+	// GetTrack returns the recording id names. Recordings are looked up on every call rather
+	// than cached as records; the upstream response cache covers repeat visits. Failures are
+	// *Error values wrapping one of the sentinel kinds.
+	GetTrack(ctx context.Context, id string) (*data.Recording, error)
+}
+
+type trackService struct {
+	deps Deps
+}
+
+// NewTrackService builds a TrackService over deps.
+func NewTrackService(deps Deps) TrackService {
+	return &trackService{deps: deps}
+}
+
+func (s *trackService) GetTrack(ctx context.Context, id string) (*data.Recording, error) {
+	client := s.deps.MusicBrainz
+	if client == nil {
+		return nil, newError(ErrUnavailable, "musicbrainz client unavailable")
+	}
+
+	remote, err := client.LookupRecording(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, musicbrainz.ErrNotFound):
+			return nil, newError(ErrNotFound, "track not found")
+		default:
+			return nil, upstreamError(err, "musicbrainz lookup failed")
+		}
+	}
+
+	recording := transformRecording(remote)
+	if recording.ID == "" {
+		recording.ID = id
+	}
+	return recording, nil
+}
+
+func transformRecording(src *musicbrainz.Recording) *data.Recording {
+	credits := transformCredits(src.ArtistCredit)
+	if credits == nil {
+		credits = []data.ArtistCredit{}
+	}
+	isrcs := append([]string{}, src.ISRCs...)
+	return &data.Recording{
+		ID:               src.ID,
+		Title:            src.Title,
+		Disambiguation:   src.Disambiguation,
+		LengthMs:         src.Length,
+		ISRCs:            isrcs,
+		FirstReleaseDate: data.PartialDateOf(src.FirstReleaseDate),
+		Credits:          credits,
+		ArtistCredit:     musicbrainz.JoinCredits(src.ArtistCredit),
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/lastfm"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/reviews"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/wikidata"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/wikipedia"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/wikitext"
+)
+
+// Source names used in warnings when a client doesn't name itself.
+const (
+	sourceMusicBrainz = "musicbrainz"
+	sourceWikipedia   = "wikipedia"
+	sourceWikidata    = "wikidata"
+	sourceReviews     = "reviews"
+	sourceLastfm      = "lastfm"
+)
+
+// Fields named in warnings that have no quality-report counterpart.
+const (
+	fieldAwards            = "awards"
+	fieldCharts            = "charts"
+	fieldEditions          = "editions"
+	fieldListening         = "listening"
+	fieldRelated           = "related"
+	fieldProductionCredits = "productionCredits"
+)
+
+// namedSource is implemented by clients that know which upstream they front.
+type namedSource interface {
+	Name() string
+}
+
+// sourceName names client for warnings, falling back when it doesn't say.
+func sourceName(client any, fallback string) string {
+	if named, ok := client.(namedSource); ok && named.Name() != "" {
+		return named.Name()
+	}
+	return fallback
+}
+
+// enrichmentWarning describes why an optional source failed to fill field. A source answering
+// that it has nothing is not a failure, so not-found errors report false.
+func enrichmentWarning(field, source string, err error) (data.Warning, bool) {
+	if err == nil || isNotFound(err) {
+		return data.Warning{}, false
+	}
+	warning := data.Warning{Field: field, Source: source, Reason: data.WarningFailed, Retryable: true}
+	var limited *retry.RateLimitedError
+	switch {
+	case errors.As(err, &limited):
+		warning.Reason = data.WarningRateLimited
+		warning.RetryAfter = retry.Seconds(limited.RetryAfter)
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		warning.Reason = data.WarningTimeout
+	case errors.Is(err, reviews.ErrRateLimit), errors.Is(err, lastfm.ErrRateLimit):
+		warning.Reason = data.WarningRateLimited
+	case errors.Is(err, reviews.ErrUnauthorized), errors.Is(err, lastfm.ErrUnauthorized):
+		warning.Reason = data.WarningUnauthorized
+		warning.Retryable = false
+	}
+	return warning, true
+}
+
+// unavailableWarning notes a field skipped because its source is known to be down.
+func unavailableWarning(field, source string) data.Warning {
+	return data.Warning{Field: field, Source: source, Reason: data.WarningUnavailable, Retryable: true}
+}
+
+func isNotFound(err error) bool {
+	for _, notFound := range []error{musicbrainz.ErrNotFound, wikipedia.ErrNotFound, wikitext.ErrNotFound, wikidata.ErrNotFound, reviews.ErrNotFound, lastfm.ErrNotFound} {
+		if errors.Is(err, notFound) {
+			return true
+		}
+	}
+	return false
+}
+
+// warnings collects the warnings raised while fetching one record.
+type warnings []data.Warning
+
+// add records a warning for err unless it is nil or a not-found answer.
+func (w *warnings) add(field, source string, err error) {
+	if warning, ok := enrichmentWarning(field, source, err); ok {
+		*w = append(*w, warning)
+	}
+}
+
+func (w *warnings) unavailable(field, source string) {
+	*w = append(*w, unavailableWarning(field, source))
+}
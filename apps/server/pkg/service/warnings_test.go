@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/db"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/musicbrainz"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/reviews"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/wikipedia"
+)
+
+type failingWikipedia struct {
+	err error
+}
+
+func (f *failingWikipedia) GetArtistBiography(ctx context.Context, artistName string) (string, error) {
+	return "", f.err
+}
+
+type failingReviews struct {
+	err error
+}
+
+func (f *failingReviews) GetAlbumReviews(ctx context.Context, artistName, albumTitle string) ([]data.Review, error) {
+	return nil, f.err
+}
+
+func TestEnrichmentWarningReasons(t *testing.T) {
+	cases := []struct {
+		err       error
+		ok        bool
+		reason    string
+		retryable bool
+	}{
+		{err: nil},
+		{err: fmt.Errorf("lookup: %w", wikipedia.ErrNotFound)},
+		{err: context.DeadlineExceeded, ok: true, reason: data.WarningTimeout, retryable: true},
+		{err: reviews.ErrRateLimit, ok: true, reason: data.WarningRateLimited, retryable: true},
+		{err: reviews.ErrUnauthorized, ok: true, reason: data.WarningUnauthorized},
+		{err: errors.New("boom"), ok: true, reason: data.WarningFailed, retryable: true},
+	}
+	for _, tc := range cases {
+		warning, ok := enrichmentWarning(db.QualityBiography, sourceWikipedia, tc.err)
+		if ok != tc.ok {
+			t.Errorf("%v: expected ok=%v, got %v", tc.err, tc.ok, ok)
+			continue
+		}
+		if ok && (warning.Reason != tc.reason || warning.Retryable != tc.retryable) {
+			t.Errorf("%v: unexpected warning %+v", tc.err, warning)
+		}
+	}
+}
+
+func TestGetArtistWarnsAboutMissingBiography(t *testing.T) {
+	mb := &stubMusicBrainz{
+		lookupArtistFunc: func(ctx context.Context, id string) (*musicbrainz.Artist, error) {
+			return &musicbrainz.Artist{ID: id, Name: "Remote"}, nil
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		wiki   WikipediaClient
+		reason string
+	}{
+		"failing":   {&failingWikipedia{err: errors.New("502 from wikipedia")}, data.WarningFailed},
+		"unhealthy": {&downWikipedia{}, data.WarningUnavailable},
+		"not found": {&failingWikipedia{err: wikipedia.ErrNotFound}, ""},
+	} {
+		artist, err := NewArtistService(Deps{MusicBrainz: mb, Wikipedia: tc.wiki}).GetArtist(context.Background(), testArtistID)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if tc.reason == "" {
+			if len(artist.Warnings) != 0 {
+				t.Errorf("%s: expected no warnings, got %+v", name, artist.Warnings)
+			}
+			continue
+		}
+		if len(artist.Warnings) != 1 || artist.Warnings[0].Field != db.QualityBiography || artist.Warnings[0].Reason != tc.reason {
+			t.Errorf("%s: expected a %s biography warning, got %+v", name, tc.reason, artist.Warnings)
+		}
+	}
+}
+
+func TestGetAlbumWarnsAboutRateLimitedReviews(t *testing.T) {
+	store, err := db.NewMemoryStore(context.Background())
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	mb := &stubMusicBrainz{
+		lookupReleaseGroupFunc: func(ctx context.Context, id string) (*musicbrainz.ReleaseGroup, error) {
+			return &musicbrainz.ReleaseGroup{ID: id, Title: "Nevermind"}, nil
+		},
+	}
+	deps := Deps{Albums: store, MusicBrainz: mb, Reviews: &failingReviews{err: reviews.ErrRateLimit}}
+
+	album, err := NewAlbumService(deps).GetAlbum(context.Background(), testAlbumID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(album.Warnings) != 1 || album.Warnings[0].Field != db.QualityReviews || album.Warnings[0].Reason != data.WarningRateLimited || !album.Warnings[0].Retryable {
+		t.Fatalf("expected a retryable rate-limited reviews warning, got %+v", album.Warnings)
+	}
+
+	cached, err := store.GetAlbum(context.Background(), testAlbumID)
+	if err != nil || cached == nil || len(cached.Warnings) != 1 {
+		t.Errorf("expected the warning to be cached with the album, got %+v (%v)", cached, err)
+	}
+}
@@ -0,0 +1,121 @@
+// Package chaos injects latency and failures into upstream calls so retries, circuit breaking,
+// deadlines, and stale-serving paths can be exercised without a misbehaving upstream. It is
+// off unless Configure enables it, which config only allows in development.
+package chaos
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+)
+
+// ErrInjected is returned for requests failed as dropped connections.
+var ErrInjected = errors.New("chaos: injected connection failure")
+
+// Config selects which faults to inject. Rates are fractions of requests from 0 to 1: a
+// LatencyRate share of requests is delayed by Latency, and an ErrorRate share fails with
+// ErrorStatus, or as a dropped connection when ErrorStatus is 0. Sources limits injection to
+// the named upstreams; empty means every upstream.
+type Config struct {
+	Enabled     bool
+	Latency     time.Duration
+	LatencyRate float64
+	ErrorRate   float64
+	ErrorStatus int
+	RetryAfter  time.Duration
+	Sources     []string
+}
+
+var (
+	active atomic.Pointer[Config]
+
+	rngMu sync.Mutex
+	rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+	roll  = func() float64 {
+		rngMu.Lock()
+		defer rngMu.Unlock()
+		return rng.Float64()
+	}
+)
+
+// Configure replaces the faults injected into every client; a disabled config turns
+// injection off.
+func Configure(cfg Config) {
+	if !cfg.Enabled {
+		active.Store(nil)
+		return
+	}
+	cfg.Sources = slices.Clone(cfg.Sources)
+	active.Store(&cfg)
+}
+
+func (c *Config) applies(source string) bool {
+	return len(c.Sources) == 0 || slices.Contains(c.Sources, source)
+}
+
+// Transport wraps base so round trips to source suffer the configured faults.
+func Transport(source string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{source: source, base: base}
+}
+
+type transport struct {
+	source string
+	base   http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cfg := active.Load()
+	if cfg == nil || !cfg.applies(t.source) {
+		return t.base.RoundTrip(req)
+	}
+
+	if cfg.Latency > 0 && cfg.LatencyRate > 0 && roll() < cfg.LatencyRate {
+		timer := time.NewTimer(cfg.Latency)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	if cfg.ErrorRate > 0 && roll() < cfg.ErrorRate {
+		if cfg.ErrorStatus == 0 {
+			return nil, fmt.Errorf("%s: %w", t.source, ErrInjected)
+		}
+		return injectedResponse(req, cfg), nil
+	}
+	return t.base.RoundTrip(req)
+}
+
+func injectedResponse(req *http.Request, cfg *Config) *http.Response {
+	body := []byte(`{"error":"chaos: injected failure"}`)
+	header := http.Header{"Content-Type": {"application/json"}}
+	if cfg.RetryAfter > 0 && (cfg.ErrorStatus == http.StatusTooManyRequests || cfg.ErrorStatus == http.StatusServiceUnavailable) {
+		header.Set("Retry-After", strconv.Itoa(retry.Seconds(cfg.RetryAfter)))
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cfg.ErrorStatus, http.StatusText(cfg.ErrorStatus)),
+		StatusCode:    cfg.ErrorStatus,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,115 @@
+package chaos
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newUpstream(t *testing.T) (*httptest.Server, *int) {
+	t.Helper()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func withRoll(t *testing.T, value float64) {
+	t.Helper()
+	previous := roll
+	roll = func() float64 { return value }
+	t.Cleanup(func() {
+		roll = previous
+		Configure(Config{})
+	})
+}
+
+func TestTransportPassesThroughWhenDisabled(t *testing.T) {
+	server, calls := newUpstream(t)
+	withRoll(t, 0)
+	Configure(Config{Enabled: false, ErrorRate: 1, ErrorStatus: http.StatusBadGateway})
+
+	client := &http.Client{Transport: Transport("musicbrainz", nil)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || *calls != 1 {
+		t.Fatalf("status = %d, calls = %d; want 200 from the upstream", resp.StatusCode, *calls)
+	}
+}
+
+func TestTransportInjectsStatus(t *testing.T) {
+	server, calls := newUpstream(t)
+	withRoll(t, 0.1)
+	Configure(Config{Enabled: true, ErrorRate: 0.5, ErrorStatus: http.StatusTooManyRequests, RetryAfter: 1500 * time.Millisecond})
+
+	client := &http.Client{Transport: Transport("musicbrainz", nil)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || *calls != 0 {
+		t.Fatalf("status = %d, calls = %d; want an injected 429 without reaching the upstream", resp.StatusCode, *calls)
+	}
+	if got := resp.Header.Get("Retry-After"); got != "2" {
+		t.Fatalf("Retry-After = %q, want 2", got)
+	}
+}
+
+func TestTransportDropsConnections(t *testing.T) {
+	server, _ := newUpstream(t)
+	withRoll(t, 0)
+	Configure(Config{Enabled: true, ErrorRate: 1})
+
+	client := &http.Client{Transport: Transport("wikipedia", nil)}
+	_, err := client.Get(server.URL)
+	if !errors.Is(err, ErrInjected) {
+		t.Fatalf("error = %v, want ErrInjected", err)
+	}
+}
+
+func TestTransportSkipsOtherSourcesAndUnluckyRolls(t *testing.T) {
+	server, calls := newUpstream(t)
+	withRoll(t, 0.9)
+	Configure(Config{Enabled: true, ErrorRate: 0.5, ErrorStatus: http.StatusBadGateway, Sources: []string{"discogs"}})
+
+	for _, source := range []string{"musicbrainz", "discogs"} {
+		client := &http.Client{Transport: Transport(source, nil)}
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("%s GET failed: %v", source, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s status = %d, want 200", source, resp.StatusCode)
+		}
+	}
+	if *calls != 2 {
+		t.Fatalf("calls = %d, want both requests to reach the upstream", *calls)
+	}
+}
+
+func TestTransportAddsLatency(t *testing.T) {
+	server, _ := newUpstream(t)
+	withRoll(t, 0)
+	Configure(Config{Enabled: true, Latency: 20 * time.Millisecond, LatencyRate: 1})
+
+	client := &http.Client{Transport: Transport("wikidata", nil)}
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Fatalf("elapsed = %v, want at least the injected latency", elapsed)
+	}
+}
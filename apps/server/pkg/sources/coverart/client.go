@@ -0,0 +1,178 @@
+package coverart
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/metrics"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/useragent"
+)
+
+// ErrNotFound indicates the Cover Art Archive has no artwork for the requested entity.
+var ErrNotFound = errors.New("coverart: artwork not found")
+
+// Config describes how to connect to the Cover Art Archive.
+type Config struct {
+	BaseURL   string
+	UserAgent string
+	// HTTP sets the timeout, retries, response cache, and pacing.
+	HTTP httpclient.Options
+}
+
+// Client issues requests against the Cover Art Archive API.
+type Client struct {
+	baseURL    string
+	userAgent  string
+	httpClient *http.Client
+	health     *health.Tracker
+}
+
+// New constructs a Cover Art Archive client.
+func New(_ context.Context, cfg Config) (*Client, error) {
+	baseURL := strings.TrimSpace(cfg.BaseURL)
+	if baseURL == "" {
+		baseURL = "https://coverartarchive.org"
+	}
+
+	userAgent := strings.TrimSpace(cfg.UserAgent)
+	if userAgent == "" {
+		userAgent = useragent.Default()
+	}
+
+	tracker := health.NewTracker()
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		userAgent:  userAgent,
+		httpClient: httpclient.New("coverartarchive", cfg.HTTP, 8*time.Second, tracker),
+		health:     tracker,
+	}, nil
+}
+
+type imagesResponse struct {
+	Images []struct {
+		Types      []string          `json:"types"`
+		Front      bool              `json:"front"`
+		Back       bool              `json:"back"`
+		Image      string            `json:"image"`
+		Thumbnails map[string]string `json:"thumbnails"`
+	} `json:"images"`
+}
+
+// Name identifies this source in image metadata and the fallback chain.
+func (c *Client) Name() string {
+	return "coverartarchive"
+}
+
+// AlbumImages returns the front and back artwork registered for a release group.
+// Artist name and title are unused; the release group ID is authoritative.
+func (c *Client) AlbumImages(ctx context.Context, albumID, _, _ string) ([]data.Image, error) {
+	trimmed := strings.TrimSpace(albumID)
+	if trimmed == "" {
+		return nil, errors.New("coverart: release group id is required")
+	}
+
+	endpoint := fmt.Sprintf("%s/release-group/%s", c.baseURL, url.PathEscape(trimmed))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("coverart: request build failed: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("coverart: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var payload imagesResponse
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			metrics.RecordDecodeError("coverartarchive")
+			return nil, fmt.Errorf("coverart: decode failed: %w", err)
+		}
+		return c.transformImages(payload), nil
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("coverart: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+}
+
+func (c *Client) transformImages(payload imagesResponse) []data.Image {
+	var images []data.Image
+	for _, item := range payload.Images {
+		var imageType string
+		switch {
+		case item.Front:
+			imageType = data.ImageTypeFront
+		case item.Back:
+			imageType = data.ImageTypeBack
+		default:
+			// Booklets, media scans, etc. aren't useful for display.
+			continue
+		}
+
+		// Prefer the 500px thumbnail; originals can be several megabytes.
+		imageURL := item.Thumbnails["500"]
+		if imageURL == "" {
+			imageURL = item.Thumbnails["large"]
+		}
+		if imageURL == "" {
+			imageURL = item.Image
+		}
+		if imageURL == "" {
+			continue
+		}
+
+		images = append(images, data.Image{
+			Type:   imageType,
+			URL:    forceHTTPS(imageURL),
+			Source: c.Name(),
+		})
+	}
+
+	// Front covers first so consumers can take images[0].
+	for i := range images {
+		if images[i].Type == data.ImageTypeFront && i > 0 {
+			images[0], images[i] = images[i], images[0]
+			break
+		}
+	}
+	return images
+}
+
+// forceHTTPS upgrades archive.org URLs, which the API still returns as http.
+func forceHTTPS(raw string) string {
+	if strings.HasPrefix(raw, "http://") {
+		return "https://" + strings.TrimPrefix(raw, "http://")
+	}
+	return raw
+}
+
+// Healthy reports whether recent Cover Art Archive calls have been succeeding.
+func (c *Client) Healthy() bool {
+	return c.health.Healthy()
+}
+
+// Health returns the rolling success rate and failure state for Cover Art Archive calls.
+func (c *Client) Health() health.Status {
+	return c.health.Status()
+}
+
+// Ping checks that Cover Art Archive is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	return health.Ping(ctx, c.httpClient, c.baseURL+"/", c.userAgent)
+}
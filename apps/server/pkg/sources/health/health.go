@@ -0,0 +1,190 @@
+// Package health tracks rolling success rates for upstream source clients so callers can
+// report readiness and skip dependencies that are known to be down.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultWindow is how many recent calls feed the success rate.
+	defaultWindow = 20
+	// defaultFailureThreshold is the consecutive failure count that marks a source down.
+	defaultFailureThreshold = 3
+	// defaultCooldown is how long a down source is skipped before callers try it again.
+	defaultCooldown = 30 * time.Second
+)
+
+// Status is a point-in-time view of a tracker.
+type Status struct {
+	Healthy             bool      `json:"healthy"`
+	SuccessRate         float64   `json:"successRate"`
+	Samples             int       `json:"samples"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	LastFailure         time.Time `json:"lastFailure,omitempty"`
+	// Disabled marks a source switched off by configuration rather than failing.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// Tracker records the outcome of recent upstream calls. The zero value is not usable; use NewTracker.
+type Tracker struct {
+	mu                  sync.Mutex
+	outcomes            []bool
+	next                int
+	filled              int
+	consecutiveFailures int
+	lastFailure         time.Time
+	disabled            bool
+	threshold           int
+	cooldown            time.Duration
+	now                 func() time.Time
+}
+
+// NewTracker constructs a tracker with the default window, threshold, and cooldown.
+func NewTracker() *Tracker {
+	return &Tracker{
+		outcomes:  make([]bool, defaultWindow),
+		threshold: defaultFailureThreshold,
+		cooldown:  defaultCooldown,
+		now:       time.Now,
+	}
+}
+
+// Record adds the outcome of one call.
+func (t *Tracker) Record(success bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.outcomes[t.next] = success
+	t.next = (t.next + 1) % len(t.outcomes)
+	if t.filled < len(t.outcomes) {
+		t.filled++
+	}
+	if success {
+		t.consecutiveFailures = 0
+		return
+	}
+	t.consecutiveFailures++
+	t.lastFailure = t.now()
+}
+
+// Healthy reports whether callers should use the source. A source that has failed repeatedly is
+// skipped until the cooldown passes, after which the next call acts as a probe.
+func (t *Tracker) Healthy() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.healthyLocked()
+}
+
+// Disable reports the source unhealthy from now on, so callers skip it without trying.
+func (t *Tracker) Disable() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.disabled = true
+}
+
+func (t *Tracker) healthyLocked() bool {
+	if t.disabled {
+		return false
+	}
+	if t.consecutiveFailures < t.threshold {
+		return true
+	}
+	return t.now().Sub(t.lastFailure) >= t.cooldown
+}
+
+// SuccessRate returns the share of successful calls in the window, or 1 with no samples.
+func (t *Tracker) SuccessRate() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.successRateLocked()
+}
+
+func (t *Tracker) successRateLocked() float64 {
+	if t.filled == 0 {
+		return 1
+	}
+	successes := 0
+	for i := 0; i < t.filled; i++ {
+		if t.outcomes[i] {
+			successes++
+		}
+	}
+	return float64(successes) / float64(t.filled)
+}
+
+// Status snapshots the tracker.
+func (t *Tracker) Status() Status {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return Status{
+		Healthy:             t.healthyLocked(),
+		SuccessRate:         t.successRateLocked(),
+		Samples:             t.filled,
+		ConsecutiveFailures: t.consecutiveFailures,
+		LastFailure:         t.lastFailure,
+		Disabled:            t.disabled,
+	}
+}
+
+// Transport wraps base so every round trip is recorded on the tracker. Transport errors and
+// 5xx responses count as failures; calls abandoned because the caller's context was canceled
+// are not counted.
+func Transport(tracker *Tracker, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &trackingTransport{tracker: tracker, base: base}
+}
+
+type trackingTransport struct {
+	tracker *Tracker
+	base    http.RoundTripper
+}
+
+func (t *trackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	switch {
+	case err != nil:
+		if !errors.Is(req.Context().Err(), context.Canceled) {
+			t.tracker.Record(false)
+		}
+	case resp.StatusCode >= http.StatusInternalServerError:
+		t.tracker.Record(false)
+	default:
+		t.tracker.Record(true)
+	}
+	return resp, err
+}
+
+// Ping issues a GET to endpoint and returns an error for transport failures or 5xx responses.
+// Any other status means the upstream is reachable.
+func Ping(ctx context.Context, client *http.Client, endpoint, userAgent string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+	// Probes must reach the upstream rather than a response cache.
+	req.Header.Set("Cache-Control", "no-cache")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("health: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
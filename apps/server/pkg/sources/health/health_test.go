@@ -0,0 +1,60 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTrackerMarksDownAfterConsecutiveFailures(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewTracker()
+	tracker.now = func() time.Time { return now }
+
+	tracker.Record(true)
+	for i := 0; i < defaultFailureThreshold; i++ {
+		tracker.Record(false)
+	}
+
+	if tracker.Healthy() {
+		t.Fatal("expected tracker to be unhealthy after repeated failures")
+	}
+	if rate := tracker.SuccessRate(); rate != 0.25 {
+		t.Errorf("expected success rate 0.25, got %f", rate)
+	}
+
+	now = now.Add(defaultCooldown)
+	if !tracker.Healthy() {
+		t.Error("expected tracker to allow a probe after the cooldown")
+	}
+
+	tracker.Record(true)
+	if status := tracker.Status(); !status.Healthy || status.ConsecutiveFailures != 0 {
+		t.Errorf("expected recovery after a success, got %+v", status)
+	}
+}
+
+func TestTransportRecordsServerErrors(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	tracker := NewTracker()
+	client := &http.Client{Transport: Transport(tracker, nil)}
+
+	if err := Ping(context.Background(), client, server.URL, "test"); err != nil {
+		t.Fatalf("expected ping to succeed, got %v", err)
+	}
+	status = http.StatusServiceUnavailable
+	if err := Ping(context.Background(), client, server.URL, "test"); err == nil {
+		t.Fatal("expected ping to fail on 503")
+	}
+
+	if got := tracker.Status(); got.Samples != 2 || got.SuccessRate != 0.5 {
+		t.Errorf("unexpected status %+v", got)
+	}
+}
@@ -0,0 +1,106 @@
+package health
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// rankSmoothing is the weight each new outcome carries in a source's moving averages.
+	rankSmoothing = 0.2
+	// rankLatencyScale is the latency at which a perfectly reliable source scores one half.
+	rankLatencyScale = time.Second
+	// rankRecovery is how long a source's record stands without new outcomes. A demoted source
+	// the chain has stopped reaching gets its optimistic score back and is probed again.
+	rankRecovery = 5 * time.Minute
+)
+
+// Ranker orders interchangeable sources for the same field (images, reviews, biography) by
+// recent hit rate and latency. Sources start with an optimistic score, so the static priority
+// order holds until outcomes are recorded and a source that starts failing or slowing down
+// drops behind the others until it has gone unsampled for rankRecovery.
+type Ranker struct {
+	mu    sync.Mutex
+	stats []rankStats
+	now   func() time.Time
+}
+
+type rankStats struct {
+	hitRate  float64
+	latency  float64
+	samples  int
+	lastSeen time.Time
+}
+
+// NewRanker tracks size sources, indexed in static priority order.
+func NewRanker(size int) *Ranker {
+	return &Ranker{stats: make([]rankStats, size), now: time.Now}
+}
+
+// Record adds the outcome of one call to source i. hit means the source produced a usable value.
+func (r *Ranker) Record(i int, hit bool, elapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i < 0 || i >= len(r.stats) {
+		return
+	}
+	s := &r.stats[i]
+	if r.stale(s) {
+		*s = rankStats{}
+	}
+	outcome := 0.0
+	if hit {
+		outcome = 1
+	}
+	seconds := elapsed.Seconds()
+	if s.samples == 0 {
+		s.hitRate = 1
+		s.latency = seconds
+	} else {
+		s.latency += rankSmoothing * (seconds - s.latency)
+	}
+	s.hitRate += rankSmoothing * (outcome - s.hitRate)
+	s.samples++
+	s.lastSeen = r.now()
+}
+
+func (r *Ranker) stale(s *rankStats) bool {
+	return s.samples > 0 && r.now().Sub(s.lastSeen) >= rankRecovery
+}
+
+// Order returns source indexes from best to worst score, keeping static priority on ties.
+// Unsampled sources are scored as reliable and as fast as the fastest sampled source, so they
+// move ahead only once the sources above them start missing.
+func (r *Ranker) Order() []int {
+	r.mu.Lock()
+	prior := -1.0
+	for i := range r.stats {
+		s := &r.stats[i]
+		if s.samples > 0 && !r.stale(s) && (prior < 0 || s.latency < prior) {
+			prior = s.latency
+		}
+	}
+	if prior < 0 {
+		prior = 0
+	}
+	scores := make([]float64, len(r.stats))
+	for i := range r.stats {
+		s := &r.stats[i]
+		hitRate, latency := s.hitRate, s.latency
+		if s.samples == 0 || r.stale(s) {
+			hitRate, latency = 1, prior
+		}
+		scores[i] = hitRate / (1 + latency/rankLatencyScale.Seconds())
+	}
+	r.mu.Unlock()
+
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]] > scores[order[b]]
+	})
+	return order
+}
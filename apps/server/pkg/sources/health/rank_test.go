@@ -0,0 +1,61 @@
+package health
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRankerKeepsPriorityWithoutSamples(t *testing.T) {
+	order := NewRanker(3).Order()
+	if order[0] != 0 || order[1] != 1 || order[2] != 2 {
+		t.Fatalf("expected static priority order, got %v", order)
+	}
+}
+
+func TestRankerDemotesFailingSource(t *testing.T) {
+	ranker := NewRanker(2)
+	for i := 0; i < 5; i++ {
+		ranker.Record(0, false, 100*time.Millisecond)
+		ranker.Record(1, true, 100*time.Millisecond)
+	}
+	if order := ranker.Order(); order[0] != 1 {
+		t.Fatalf("expected reliable source first, got %v", order)
+	}
+}
+
+func TestRankerDemotesSlowSource(t *testing.T) {
+	ranker := NewRanker(2)
+	for i := 0; i < 5; i++ {
+		ranker.Record(0, true, 3*time.Second)
+		ranker.Record(1, true, 50*time.Millisecond)
+	}
+	if order := ranker.Order(); order[0] != 1 {
+		t.Fatalf("expected faster source first, got %v", order)
+	}
+}
+
+func TestRankerRestoresStaleSource(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ranker := NewRanker(2)
+	ranker.now = func() time.Time { return now }
+	for i := 0; i < 5; i++ {
+		ranker.Record(0, false, 100*time.Millisecond)
+		ranker.Record(1, true, 100*time.Millisecond)
+	}
+
+	// Only the promoted source keeps getting called.
+	now = now.Add(rankRecovery)
+	ranker.Record(1, true, 100*time.Millisecond)
+
+	if order := ranker.Order(); order[0] != 0 {
+		t.Fatalf("expected stale demoted source to regain priority, got %v", order)
+	}
+}
+
+func TestRankerKeepsPriorityAfterPrimaryHits(t *testing.T) {
+	ranker := NewRanker(2)
+	ranker.Record(0, true, 200*time.Millisecond)
+	if order := ranker.Order(); order[0] != 0 {
+		t.Fatalf("expected healthy primary to stay first, got %v", order)
+	}
+}
@@ -0,0 +1,293 @@
+// Package httpcache provides a RoundTripper that caches upstream GET responses according to
+// their Cache-Control, Expires, ETag, and Last-Modified headers, so cached responses survive
+// server restarts when backed by disk.
+package httpcache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// XFromCache is set on responses served from the cache, fresh or revalidated.
+	XFromCache = "X-From-Cache"
+	// storedAtHeader records when a response entered the cache.
+	storedAtHeader = "X-Httpcache-Stored-At"
+)
+
+// Cache stores serialized responses by key.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+	Delete(key string)
+}
+
+// DiskCache stores each entry as a file named by the SHA-256 of its key.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache creates dir if needed and returns a cache rooted there.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return nil, errors.New("httpcache: directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:]))
+}
+
+// Get returns the entry for key, if present.
+func (d *DiskCache) Get(key string) ([]byte, bool) {
+	b, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+// Set writes the entry atomically so concurrent readers never see a partial file.
+func (d *DiskCache) Set(key string, value []byte) {
+	tmp, err := os.CreateTemp(d.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), d.path(key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// Delete removes the entry for key.
+func (d *DiskCache) Delete(key string) {
+	_ = os.Remove(d.path(key))
+}
+
+type revalidateKey struct{}
+
+// WithRevalidate makes requests sent with ctx check cached entries with the upstream even while
+// they are fresh, so a forced refresh sees current data and a 304 still saves the body.
+func WithRevalidate(ctx context.Context) context.Context {
+	return context.WithValue(ctx, revalidateKey{}, true)
+}
+
+func revalidating(ctx context.Context) bool {
+	on, _ := ctx.Value(revalidateKey{}).(bool)
+	return on
+}
+
+// Transport wraps base with cache. A nil cache returns base unchanged.
+func Transport(cache Cache, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if cache == nil {
+		return base
+	}
+	return &transport{cache: cache, base: base, now: time.Now}
+}
+
+type transport struct {
+	cache Cache
+	base  http.RoundTripper
+	now   func() time.Time
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || strings.Contains(req.Header.Get("Cache-Control"), "no-cache") {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	cached := t.load(key, req)
+	if cached != nil && !revalidating(req.Context()) && t.fresh(cached) {
+		cached.Header.Set(XFromCache, "1")
+		return cached, nil
+	}
+
+	outgoing := req
+	if cached != nil {
+		outgoing = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			outgoing.Header.Set("If-None-Match", etag)
+		}
+		if modified := cached.Header.Get("Last-Modified"); modified != "" {
+			outgoing.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(outgoing)
+	if err != nil {
+		if cached != nil {
+			cached.Body.Close()
+		}
+		return nil, err
+	}
+
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		// Refresh freshness headers from the 304 and restart the entry's age.
+		for _, name := range []string{"Cache-Control", "Expires", "Date", "ETag", "Last-Modified"} {
+			if value := resp.Header.Get(name); value != "" {
+				cached.Header.Set(name, value)
+			}
+		}
+		cached.Header.Del("Age")
+		t.store(key, cached)
+		cached.Header.Set(XFromCache, "1")
+		return cached, nil
+	}
+	if cached != nil {
+		cached.Body.Close()
+	}
+
+	if resp.StatusCode == http.StatusOK && cacheable(resp) {
+		t.store(key, resp)
+	} else if resp.StatusCode == http.StatusOK || noStore(resp) {
+		t.cache.Delete(key)
+	}
+	return resp, nil
+}
+
+// load rebuilds a cached response, with its body buffered in memory.
+func (t *transport) load(key string, req *http.Request) *http.Response {
+	raw, ok := t.cache.Get(key)
+	if !ok {
+		return nil
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(raw)), req)
+	if err != nil {
+		t.cache.Delete(key)
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.cache.Delete(key)
+		return nil
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	return resp
+}
+
+// store serializes resp into the cache, leaving resp readable by the caller.
+func (t *transport) store(key string, resp *http.Response) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	clone := *resp
+	clone.Header = resp.Header.Clone()
+	clone.Header.Del(XFromCache)
+	clone.Header.Set(storedAtHeader, t.now().UTC().Format(http.TimeFormat))
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	clone.ContentLength = int64(len(body))
+	clone.TransferEncoding = nil
+
+	raw, err := httputil.DumpResponse(&clone, true)
+	if err != nil {
+		return
+	}
+	t.cache.Set(key, raw)
+}
+
+// fresh applies max-age (or Expires) against the entry's age.
+func (t *transport) fresh(resp *http.Response) bool {
+	directives := parseCacheControl(resp.Header.Get("Cache-Control"))
+	if _, ok := directives["no-cache"]; ok {
+		return false
+	}
+
+	storedAt, err := http.ParseTime(resp.Header.Get(storedAtHeader))
+	if err != nil {
+		return false
+	}
+	age := t.now().Sub(storedAt)
+	if raw := resp.Header.Get("Age"); raw != "" {
+		if seconds, err := strconv.Atoi(raw); err == nil {
+			age += time.Duration(seconds) * time.Second
+		}
+	}
+
+	if raw, ok := directives["max-age"]; ok {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil {
+			return false
+		}
+		return age < time.Duration(seconds)*time.Second
+	}
+
+	if raw := resp.Header.Get("Expires"); raw != "" {
+		expires, err := http.ParseTime(raw)
+		if err != nil {
+			return false
+		}
+		date, err := http.ParseTime(resp.Header.Get("Date"))
+		if err != nil {
+			date = storedAt
+		}
+		return age < expires.Sub(date)
+	}
+	return false
+}
+
+// cacheable accepts responses that carry freshness or validator headers and don't opt out.
+func cacheable(resp *http.Response) bool {
+	if noStore(resp) || resp.Header.Get("Vary") == "*" {
+		return false
+	}
+	directives := parseCacheControl(resp.Header.Get("Cache-Control"))
+	if _, ok := directives["max-age"]; ok {
+		return true
+	}
+	return resp.Header.Get("Expires") != "" || resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""
+}
+
+func noStore(resp *http.Response) bool {
+	_, ok := parseCacheControl(resp.Header.Get("Cache-Control"))["no-store"]
+	return ok
+}
+
+func parseCacheControl(raw string) map[string]string {
+	directives := make(map[string]string)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(part, "=")
+		directives[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return directives
+}
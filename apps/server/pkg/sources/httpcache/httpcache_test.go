@@ -0,0 +1,152 @@
+package httpcache
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func get(t *testing.T, client *http.Client, url string) (*http.Response, string) {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	return resp, string(body)
+}
+
+func TestTransportServesFreshResponsesFromDisk(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte("payload"))
+	}))
+	defer server.Close()
+
+	cache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache failed: %v", err)
+	}
+
+	get(t, &http.Client{Transport: Transport(cache, nil)}, server.URL)
+
+	// A new transport over the same directory simulates a restart.
+	resp, body := get(t, &http.Client{Transport: Transport(cache, nil)}, server.URL)
+	if body != "payload" || resp.Header.Get(XFromCache) != "1" {
+		t.Errorf("expected cached payload, got %q (from cache %q)", body, resp.Header.Get(XFromCache))
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 upstream call, got %d", calls)
+	}
+}
+
+func TestTransportRevalidatesWithETag(t *testing.T) {
+	var calls, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte("payload"))
+	}))
+	defer server.Close()
+
+	cache, _ := NewDiskCache(t.TempDir())
+	client := &http.Client{Transport: Transport(cache, nil)}
+
+	get(t, client, server.URL)
+	resp, body := get(t, client, server.URL)
+
+	if calls != 2 || notModified != 1 {
+		t.Errorf("expected a conditional revalidation, got %d calls and %d 304s", calls, notModified)
+	}
+	if body != "payload" || resp.StatusCode != http.StatusOK || resp.Header.Get(XFromCache) != "1" {
+		t.Errorf("expected revalidated cached payload, got %d %q", resp.StatusCode, body)
+	}
+}
+
+func TestTransportRevalidatesFreshEntriesOnRequest(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte("v" + strconv.Itoa(int(n))))
+	}))
+	defer server.Close()
+
+	cache, _ := NewDiskCache(t.TempDir())
+	client := &http.Client{Transport: Transport(cache, nil)}
+	get(t, client, server.URL)
+
+	req, _ := http.NewRequestWithContext(WithRevalidate(context.Background()), http.MethodGet, server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if calls != 2 {
+		t.Fatalf("expected the fresh entry to be revalidated, got %d upstream calls", calls)
+	}
+
+	if _, body := get(t, client, server.URL); body != "v2" || calls != 2 {
+		t.Errorf("expected the refetched response to replace the entry, got %q after %d calls", body, calls)
+	}
+}
+
+func TestTransportHonorsNoStore(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Cache-Control", "no-store, max-age=60")
+		w.Write([]byte("payload"))
+	}))
+	defer server.Close()
+
+	cache, _ := NewDiskCache(t.TempDir())
+	client := &http.Client{Transport: Transport(cache, nil)}
+
+	get(t, client, server.URL)
+	get(t, client, server.URL)
+
+	if calls != 2 {
+		t.Errorf("expected no-store responses to bypass the cache, got %d calls", calls)
+	}
+}
+
+func TestTransportExpiresStaleEntries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte("payload"))
+	}))
+	defer server.Close()
+
+	cache, _ := NewDiskCache(t.TempDir())
+	now := time.Now()
+	rt := Transport(cache, nil).(*transport)
+	rt.now = func() time.Time { return now }
+	client := &http.Client{Transport: rt}
+
+	get(t, client, server.URL)
+	now = now.Add(2 * time.Minute)
+	get(t, client, server.URL)
+
+	if calls != 2 {
+		t.Errorf("expected stale entry to be refetched, got %d calls", calls)
+	}
+}
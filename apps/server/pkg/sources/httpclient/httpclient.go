@@ -0,0 +1,76 @@
+// Package httpclient builds the HTTP clients the upstream source packages share, so every
+// source gets the same caching, health tracking, retries, pacing, metrics, debug logging, and
+// fault injection, stacked in the same order.
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/chaos"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpcache"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/metrics"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/upstreamlog"
+)
+
+// ErrDisabled is returned for requests to a source switched off by configuration.
+var ErrDisabled = errors.New("source disabled")
+
+// Options are the connection settings every source client accepts.
+type Options struct {
+	// Timeout bounds each request, including retries; zero uses the client's default.
+	Timeout time.Duration
+	// Retry controls how transient failures are retried; the zero value uses retry's defaults.
+	Retry retry.Policy
+	// RateLimit caps requests per second, queueing calls so they go out one at a time, with
+	// calls marked by WithBackground served after any interactive ones. Zero disables pacing.
+	RateLimit float64
+	// Limiter, when set, paces requests in place of RateLimit. Clients given the same Limiter
+	// share one budget, for an upstream reached through more than one client.
+	Limiter *Limiter
+	// Cache, when set, stores upstream responses according to their caching headers.
+	Cache httpcache.Cache
+	// Disabled fails every request with ErrDisabled and marks the source unhealthy, so callers
+	// that check health skip it.
+	Disabled bool
+}
+
+// New builds the HTTP client for source, recording outcomes on tracker. defaultTimeout applies
+// when opts.Timeout is unset. From the outside in, the response cache answers before anything
+// else, health sees one outcome per logical call, retries repeat the inner stack, pacing covers
+// every attempt, and metrics, debug logging, and chaos see each attempt on the wire.
+func New(source string, opts Options, defaultTimeout time.Duration, tracker *health.Tracker) *http.Client {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	if opts.Disabled {
+		tracker.Disable()
+		return &http.Client{Timeout: timeout, Transport: disabledTransport{source: source}}
+	}
+
+	limiter := newLimiter(opts.RateLimit)
+	if opts.Limiter != nil {
+		limiter = opts.Limiter.limiter
+	}
+	wire := metrics.Transport(source, upstreamlog.Transport(source, chaos.Transport(source, nil)))
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: httpcache.Transport(opts.Cache, health.Transport(tracker, retry.Transport(opts.Retry, withRateLimit(limiter, wire)))),
+	}
+}
+
+type disabledTransport struct {
+	source string
+}
+
+func (t disabledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, fmt.Errorf("%s: %w", t.source, ErrDisabled)
+}
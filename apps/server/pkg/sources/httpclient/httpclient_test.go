@@ -0,0 +1,90 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+)
+
+func TestNewRetriesThroughSharedStack(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tracker := health.NewTracker()
+	client := New("test", Options{Retry: retry.Policy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}}, 3*time.Second, tracker)
+	if client.Timeout != 3*time.Second {
+		t.Errorf("expected the default timeout, got %v", client.Timeout)
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls.Load() != 2 {
+		t.Fatalf("expected one retry to succeed, got status %d after %d calls", resp.StatusCode, calls.Load())
+	}
+	if status := tracker.Status(); !status.Healthy || status.Samples != 1 {
+		t.Errorf("expected one healthy outcome for the logical call, got %+v", status)
+	}
+}
+
+func TestNewDisabledNeverCallsUpstream(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	tracker := health.NewTracker()
+	client := New("test", Options{Disabled: true, Timeout: time.Second}, 3*time.Second, tracker)
+
+	if _, err := client.Get(server.URL); !errors.Is(err, ErrDisabled) {
+		t.Fatalf("expected ErrDisabled, got %v", err)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("expected no upstream calls, got %d", calls.Load())
+	}
+	if status := tracker.Status(); status.Healthy || !status.Disabled {
+		t.Errorf("expected the tracker to report the source disabled, got %+v", status)
+	}
+}
+
+func TestNewSharesLimiterAcrossClients(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	shared := NewLimiter(1)
+	first := New("first", Options{Limiter: shared, RateLimit: 1000}, time.Second, health.NewTracker())
+	second := New("second", Options{Limiter: shared, RateLimit: 1000}, time.Second, health.NewTracker())
+
+	resp, err := first.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if _, err := second.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the second client to wait on the shared budget, got %v", err)
+	}
+}
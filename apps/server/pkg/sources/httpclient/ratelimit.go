@@ -0,0 +1,135 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type backgroundKey struct{}
+
+// WithBackground marks requests made with ctx as background work, such as scheduled
+// re-enrichment. A paced source hands each free slot to interactive callers first, so
+// background work only goes out when no interactive request is waiting.
+func WithBackground(ctx context.Context) context.Context {
+	return context.WithValue(ctx, backgroundKey{}, true)
+}
+
+// IsBackground reports whether ctx was marked by WithBackground.
+func IsBackground(ctx context.Context) bool {
+	background, _ := ctx.Value(backgroundKey{}).(bool)
+	return background
+}
+
+// Limiter is a request budget that several clients can share through Options.Limiter.
+type Limiter struct {
+	limiter *limiter
+}
+
+// NewLimiter returns a Limiter allowing rate requests per second; zero disables pacing.
+func NewLimiter(rate float64) *Limiter {
+	return &Limiter{limiter: newLimiter(rate)}
+}
+
+// limiter is a token bucket holding at most one token, refilled at rate tokens per second.
+// Callers queue for the token, so requests leave one at a time no closer together than the
+// policy allows, and background callers give way while an interactive caller is queued.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+	// interactive counts the interactive callers queued for a token.
+	interactive int
+	// changed is closed and replaced whenever a token is taken or an interactive caller
+	// leaves the queue, waking background callers to check again.
+	changed chan struct{}
+	now     func() time.Time
+}
+
+func newLimiter(rate float64) *limiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &limiter{interval: time.Duration(float64(time.Second) / rate), changed: make(chan struct{}), now: time.Now}
+}
+
+// wait blocks until a token is available to the caller or ctx is done.
+func (l *limiter) wait(ctx context.Context) error {
+	background := IsBackground(ctx)
+
+	l.mu.Lock()
+	if !background {
+		l.interactive++
+		defer func() {
+			l.mu.Lock()
+			l.interactive--
+			l.notify()
+			l.mu.Unlock()
+		}()
+	}
+	for {
+		now := l.now()
+		delay := l.next.Sub(now)
+		yield := background && l.interactive > 0
+		if delay <= 0 && !yield {
+			l.next = now.Add(l.interval)
+			l.notify()
+			l.mu.Unlock()
+			return nil
+		}
+		changed := l.changed
+		l.mu.Unlock()
+
+		if err := sleep(ctx, delay, changed); err != nil {
+			return err
+		}
+		l.mu.Lock()
+	}
+}
+
+// notify wakes every queued caller; l.mu must be held.
+func (l *limiter) notify() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// sleep waits for delay to pass, changed to close, or ctx to end, whichever is first. A
+// non-positive delay waits on changed and ctx alone.
+func sleep(ctx context.Context, delay time.Duration, changed <-chan struct{}) error {
+	var elapsed <-chan time.Time
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		elapsed = timer.C
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-elapsed:
+	case <-changed:
+	}
+	return nil
+}
+
+// rateLimitTransport paces every round trip through the limiter. It sits inside the retry
+// transport so retried attempts are paced too, and inside the response cache so cache hits
+// never wait.
+type rateLimitTransport struct {
+	limiter *limiter
+	base    http.RoundTripper
+}
+
+func withRateLimit(l *limiter, base http.RoundTripper) http.RoundTripper {
+	if l == nil {
+		return base
+	}
+	return &rateLimitTransport{limiter: l, base: base}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,64 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiterGivesUpWhenContextEnds(t *testing.T) {
+	l := newLimiter(1)
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatalf("first wait returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to cut the wait short, got %v", err)
+	}
+}
+
+func TestNewLimiterDisabledWithoutRate(t *testing.T) {
+	if l := newLimiter(0); l != nil {
+		t.Fatalf("expected no limiter for a zero rate, got %+v", l)
+	}
+}
+
+func TestLimiterServesInteractiveCallersBeforeBackground(t *testing.T) {
+	l := newLimiter(20)
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatalf("first wait returned error: %v", err)
+	}
+
+	order := make(chan string, 3)
+	take := func(ctx context.Context, name string) {
+		if err := l.wait(ctx); err != nil {
+			t.Errorf("%s wait returned error: %v", name, err)
+		}
+		order <- name
+	}
+	go take(WithBackground(context.Background()), "background")
+	time.Sleep(5 * time.Millisecond)
+	go take(context.Background(), "interactive")
+	go take(context.Background(), "interactive")
+
+	for i, want := range []string{"interactive", "interactive", "background"} {
+		if got := <-order; got != want {
+			t.Fatalf("slot %d went to %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestLimiterLetsBackgroundCallersThroughWhenIdle(t *testing.T) {
+	l := newLimiter(1000)
+	ctx, cancel := context.WithTimeout(WithBackground(context.Background()), time.Second)
+	defer cancel()
+
+	for i := 0; i < 3; i++ {
+		if err := l.wait(ctx); err != nil {
+			t.Fatalf("background wait %d returned error: %v", i, err)
+		}
+	}
+}
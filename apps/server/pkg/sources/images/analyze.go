@@ -0,0 +1,55 @@
+package images
+
+import (
+	"context"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+)
+
+// AnalyzerConfig describes how images are fetched for analysis and what is derived from them.
+type AnalyzerConfig struct {
+	UserAgent string
+	HTTP      httpclient.Options
+	// Colors adds dominant and accent colors.
+	Colors bool
+	// BlurHash adds a BlurHash placeholder.
+	BlurHash bool
+}
+
+// Analyzer downloads images and derives display metadata, such as theme colors and
+// placeholders, from their pixels.
+type Analyzer struct {
+	fetcher  *fetcher
+	colors   bool
+	blurHash bool
+}
+
+// NewAnalyzer builds an Analyzer.
+func NewAnalyzer(cfg AnalyzerConfig) *Analyzer {
+	return &Analyzer{
+		fetcher:  newFetcher(cfg.UserAgent, cfg.HTTP),
+		colors:   cfg.Colors,
+		blurHash: cfg.BlurHash,
+	}
+}
+
+// Analyze downloads img and fills in the Colors and BlurHash the analyzer was configured for,
+// and its Width and Height when the source didn't report them.
+func (a *Analyzer) Analyze(ctx context.Context, img *data.Image) error {
+	decoded, err := a.fetcher.fetch(ctx, img.URL)
+	if err != nil {
+		return err
+	}
+	bounds := decoded.Bounds()
+	if img.Width == 0 || img.Height == 0 {
+		img.Width, img.Height = bounds.Dx(), bounds.Dy()
+	}
+	if a.colors {
+		img.Colors = Colors(decoded)
+	}
+	if a.blurHash {
+		img.BlurHash = BlurHash(decoded)
+	}
+	return nil
+}
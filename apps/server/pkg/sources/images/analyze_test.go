@@ -0,0 +1,58 @@
+package images
+
+import (
+	"context"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+)
+
+func TestChainAnalyzesPrimaryImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/front.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		png.Encode(w, paint(50, color.NRGBA{0xe0, 0x20, 0x20, 0xff}, color.NRGBA{0x10, 0x20, 0x40, 0xff}))
+	}))
+	defer server.Close()
+
+	source := &stubAlbumSource{name: "stub", images: []data.Image{
+		{Type: data.ImageTypeFront, URL: server.URL + "/front.png"},
+		{Type: data.ImageTypeBack, URL: server.URL + "/back.png"},
+	}}
+	chain := NewChain(nil, []AlbumSource{source}).WithAnalyzer(NewAnalyzer(AnalyzerConfig{Colors: true, BlurHash: true}))
+
+	images := chain.AlbumImages(context.Background(), "rg-1", "Artist", "Title")
+	if len(images) != 2 {
+		t.Fatalf("expected both images, got %+v", images)
+	}
+	front := images[0]
+	if front.Colors == nil || front.Colors.Dominant != "#102040" || front.BlurHash == "" || front.Width != 200 || front.Height != 200 {
+		t.Errorf("expected the front cover analyzed, got %+v", front)
+	}
+	if images[1].Colors != nil || source.images[0].Colors != nil {
+		t.Errorf("expected only a copy of the first image analyzed, got %+v and %+v", images[1], source.images[0])
+	}
+}
+
+func TestChainKeepsImagesWhenAnalysisFails(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	source := &stubAlbumSource{name: "stub", images: []data.Image{{URL: server.URL + "/missing.jpg"}}}
+	analyzer := NewAnalyzer(AnalyzerConfig{HTTP: httpclient.Options{Retry: retry.Policy{MaxAttempts: 1}}, Colors: true})
+	chain := NewChain(nil, []AlbumSource{source}).WithAnalyzer(analyzer)
+
+	images := chain.AlbumImages(context.Background(), "rg-1", "Artist", "Title")
+	if len(images) != 1 || images[0].Colors != nil {
+		t.Fatalf("expected the image as the source gave it, got %+v", images)
+	}
+}
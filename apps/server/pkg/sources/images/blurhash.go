@@ -0,0 +1,127 @@
+package images
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strings"
+)
+
+const (
+	// blurHashSampleSide is how many pixels are sampled along each edge; a placeholder only
+	// keeps the lowest frequencies, so a small grid loses nothing visible.
+	blurHashSampleSide = 32
+	// blurHashComponents is the number of horizontal components; the vertical count follows
+	// the aspect ratio.
+	blurHashComponents = 4
+
+	base83Digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+)
+
+// BlurHash encodes img as a BlurHash (https://blurha.sh), a short string clients decode into
+// a blurred placeholder while the full image loads. It returns "" for an empty image.
+func BlurHash(img image.Image) string {
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return ""
+	}
+	xComponents := blurHashComponents
+	yComponents := min(9, max(1, int(math.Round(float64(blurHashComponents*bounds.Dy())/float64(bounds.Dx())))))
+
+	// Sample a grid of linear RGB values rather than visiting every pixel.
+	width, height := min(bounds.Dx(), blurHashSampleSide), min(bounds.Dy(), blurHashSampleSide)
+	pixels := make([][3]float64, width*height)
+	for y := range height {
+		for x := range width {
+			c := color.NRGBAModel.Convert(img.At(bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height)).(color.NRGBA)
+			pixels[y*width+x] = [3]float64{srgbToLinear(c.R), srgbToLinear(c.G), srgbToLinear(c.B)}
+		}
+	}
+
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for j := range yComponents {
+		for i := range xComponents {
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1
+			}
+			var factor [3]float64
+			for y := range height {
+				for x := range width {
+					basis := normalisation *
+						math.Cos(math.Pi*float64(i)*float64(x)/float64(width)) *
+						math.Cos(math.Pi*float64(j)*float64(y)/float64(height))
+					pixel := pixels[y*width+x]
+					for k := range factor {
+						factor[k] += basis * pixel[k]
+					}
+				}
+			}
+			scale := 1 / float64(width*height)
+			for k := range factor {
+				factor[k] *= scale
+			}
+			factors = append(factors, factor)
+		}
+	}
+
+	var hash strings.Builder
+	writeBase83(&hash, (xComponents-1)+(yComponents-1)*9, 1)
+
+	dc, ac := factors[0], factors[1:]
+	maximum := 1.0
+	if len(ac) > 0 {
+		actual := 0.0
+		for _, factor := range ac {
+			for _, v := range factor {
+				actual = max(actual, math.Abs(v))
+			}
+		}
+		quantised := min(82, max(0, int(math.Floor(actual*166-0.5))))
+		maximum = float64(quantised+1) / 166
+		writeBase83(&hash, quantised, 1)
+	} else {
+		writeBase83(&hash, 0, 1)
+	}
+
+	writeBase83(&hash, linearToSRGB(dc[0])<<16|linearToSRGB(dc[1])<<8|linearToSRGB(dc[2]), 4)
+	for _, factor := range ac {
+		value := 0
+		for _, v := range factor {
+			value = value*19 + min(18, max(0, int(math.Floor(signedSqrt(v/maximum)*9+9.5))))
+		}
+		writeBase83(&hash, value, 2)
+	}
+	return hash.String()
+}
+
+func writeBase83(b *strings.Builder, value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		digit := value
+		for range i {
+			digit /= 83
+		}
+		b.WriteByte(base83Digits[digit%83])
+	}
+}
+
+func srgbToLinear(v uint8) float64 {
+	x := float64(v) / 255
+	if x <= 0.04045 {
+		return x / 12.92
+	}
+	return math.Pow((x+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) int {
+	x := min(1, max(0, v))
+	if x <= 0.0031308 {
+		return int(x*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(x, 1/2.4)-0.055)*255 + 0.5)
+}
+
+// signedSqrt is the square root of |v| carrying v's sign.
+func signedSqrt(v float64) float64 {
+	return math.Copysign(math.Sqrt(math.Abs(v)), v)
+}
@@ -0,0 +1,54 @@
+package images
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func decodeBase83(s string) int {
+	value := 0
+	for _, c := range s {
+		value = value*83 + strings.IndexRune(base83Digits, c)
+	}
+	return value
+}
+
+func TestBlurHashOfSolidImage(t *testing.T) {
+	navy := color.NRGBA{0x10, 0x20, 0x40, 0xff}
+	hash := BlurHash(paint(0, navy, navy))
+
+	// 4x4 components for a square image: a size digit, a maximum digit, four DC digits, and
+	// two digits for each of the fifteen AC components.
+	if len(hash) != 1+1+4+2*15 {
+		t.Fatalf("unexpected length %d for %q", len(hash), hash)
+	}
+	if size := decodeBase83(hash[:1]); size != 3+3*9 {
+		t.Errorf("expected 4x4 components, got size digit %d", size)
+	}
+	if dc := decodeBase83(hash[2:6]); dc != 0x102040 {
+		t.Errorf("expected the average color #102040, got #%06x", dc)
+	}
+	if maximum := decodeBase83(hash[1:2]); maximum != 0 {
+		t.Errorf("expected near-flat AC components, got maximum digit %d", maximum)
+	}
+}
+
+func TestBlurHashFollowsAspectRatio(t *testing.T) {
+	wide := image.NewNRGBA(image.Rect(0, 0, 400, 100))
+	if size := decodeBase83(BlurHash(wide)[:1]); size != 3 {
+		t.Errorf("expected 4x1 components for a 4:1 image, got size digit %d", size)
+	}
+	if hash := BlurHash(image.NewNRGBA(image.Rectangle{})); hash != "" {
+		t.Errorf("expected no hash for an empty image, got %q", hash)
+	}
+}
+
+func TestBlurHashVariesWithContent(t *testing.T) {
+	navy := color.NRGBA{0x10, 0x20, 0x40, 0xff}
+	red := color.NRGBA{0xe0, 0x20, 0x20, 0xff}
+	if BlurHash(paint(100, red, navy)) == BlurHash(paint(100, navy, red)) {
+		t.Error("expected mirrored images to hash differently")
+	}
+}
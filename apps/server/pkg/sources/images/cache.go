@@ -0,0 +1,57 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ErrCacheMiss is returned by a VariantCache that doesn't hold the requested key.
+var ErrCacheMiss = errors.New("images: not cached")
+
+// VariantCache stores derived images by key. Keys are safe as file names and object keys.
+type VariantCache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, content []byte) error
+}
+
+// DiskCache keeps variants as files in a directory.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache returns a DiskCache in dir, creating the directory if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("images: cache dir: %w", err)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+func (c *DiskCache) Get(_ context.Context, key string) ([]byte, error) {
+	content, err := os.ReadFile(filepath.Join(c.dir, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrCacheMiss
+	}
+	return content, err
+}
+
+// Put writes through a temporary file so concurrent readers never see a partial image.
+func (c *DiskCache) Put(_ context.Context, key string, content []byte) error {
+	tmp, err := os.CreateTemp(c.dir, ".variant-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(c.dir, key))
+}
@@ -0,0 +1,137 @@
+package images
+
+import (
+	"context"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+)
+
+// ArtistSource supplies images for an artist.
+type ArtistSource interface {
+	Name() string
+	ArtistImages(ctx context.Context, artistID, artistName string) ([]data.Image, error)
+}
+
+// AlbumSource supplies images for an album (release group).
+type AlbumSource interface {
+	Name() string
+	AlbumImages(ctx context.Context, albumID, artistName, albumTitle string) ([]data.Image, error)
+}
+
+// healthReporter is implemented by sources that track their own upstream availability.
+type healthReporter interface {
+	Healthy() bool
+}
+
+// available reports false only for sources that track health and are currently down.
+func available(src any) bool {
+	reporter, ok := src.(healthReporter)
+	return !ok || reporter.Healthy()
+}
+
+// Chain resolves images by asking each source in turn and returning the first non-empty result.
+// Sources are tried in the order given until their recent hit rates and latencies say otherwise.
+type Chain struct {
+	artistSources []ArtistSource
+	albumSources  []AlbumSource
+	artistRanker  *health.Ranker
+	albumRanker   *health.Ranker
+	analyzer      *Analyzer
+}
+
+// NewChain builds a fallback chain. Nil sources are ignored.
+func NewChain(artistSources []ArtistSource, albumSources []AlbumSource) *Chain {
+	chain := &Chain{}
+	for _, src := range artistSources {
+		if src != nil {
+			chain.artistSources = append(chain.artistSources, src)
+		}
+	}
+	for _, src := range albumSources {
+		if src != nil {
+			chain.albumSources = append(chain.albumSources, src)
+		}
+	}
+	chain.artistRanker = health.NewRanker(len(chain.artistSources))
+	chain.albumRanker = health.NewRanker(len(chain.albumSources))
+	return chain
+}
+
+// WithAnalyzer has the chain run analyzer over the first image it resolves, the one pages
+// display, and returns the chain.
+func (c *Chain) WithAnalyzer(analyzer *Analyzer) *Chain {
+	c.analyzer = analyzer
+	return c
+}
+
+// ArtistImages returns images from the first artist source that has any. Source errors are treated
+// as misses so one failing provider doesn't block the rest of the chain, and sources known to be
+// down are skipped rather than waiting out their timeout.
+func (c *Chain) ArtistImages(ctx context.Context, artistID, artistName string) []data.Image {
+	for _, i := range c.artistRanker.Order() {
+		src := c.artistSources[i]
+		if ctx.Err() != nil {
+			return nil
+		}
+		if !available(src) {
+			continue
+		}
+		start := time.Now()
+		images, err := src.ArtistImages(ctx, artistID, artistName)
+		hit := err == nil && len(images) > 0
+		recordOutcome(ctx, c.artistRanker, i, hit, start)
+		if hit {
+			return c.analyze(ctx, images)
+		}
+	}
+	return nil
+}
+
+// AlbumImages returns images from the first album source that has any.
+func (c *Chain) AlbumImages(ctx context.Context, albumID, artistName, albumTitle string) []data.Image {
+	for _, i := range c.albumRanker.Order() {
+		src := c.albumSources[i]
+		if ctx.Err() != nil {
+			return nil
+		}
+		if !available(src) {
+			continue
+		}
+		start := time.Now()
+		images, err := src.AlbumImages(ctx, albumID, artistName, albumTitle)
+		hit := err == nil && len(images) > 0
+		recordOutcome(ctx, c.albumRanker, i, hit, start)
+		if hit {
+			return c.analyze(ctx, images)
+		}
+	}
+	return nil
+}
+
+// analyze runs the analyzer over a copy of images[0], leaving the image as the source gave it
+// when analysis fails.
+func (c *Chain) analyze(ctx context.Context, images []data.Image) []data.Image {
+	if c.analyzer == nil {
+		return images
+	}
+	primary := images[0]
+	if err := c.analyzer.Analyze(ctx, &primary); err != nil {
+		logging.FromContext(ctx).Debug("image analysis failed", "url", primary.URL, "error", err)
+		return images
+	}
+	analyzed := append([]data.Image(nil), images...)
+	analyzed[0] = primary
+	return analyzed
+}
+
+// recordOutcome feeds a source's result to the ranker unless the caller gave up on the call,
+// which says nothing about the source.
+func recordOutcome(ctx context.Context, ranker *health.Ranker, i int, hit bool, start time.Time) {
+	if ctx.Err() != nil {
+		return
+	}
+	ranker.Record(i, hit, time.Since(start))
+}
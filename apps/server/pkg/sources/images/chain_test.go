@@ -0,0 +1,63 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+type stubAlbumSource struct {
+	name   string
+	images []data.Image
+	err    error
+	calls  int
+}
+
+func (s *stubAlbumSource) Name() string { return s.name }
+
+func (s *stubAlbumSource) AlbumImages(ctx context.Context, albumID, artistName, albumTitle string) ([]data.Image, error) {
+	s.calls++
+	return s.images, s.err
+}
+
+func TestChainAlbumImagesFallsBack(t *testing.T) {
+	failing := &stubAlbumSource{name: "first", err: errors.New("down")}
+	empty := &stubAlbumSource{name: "second"}
+	hit := &stubAlbumSource{name: "third", images: []data.Image{{Type: data.ImageTypeFront, URL: "https://example.com/cover.jpg", Source: "third"}}}
+	unused := &stubAlbumSource{name: "fourth", images: []data.Image{{URL: "https://example.com/other.jpg"}}}
+
+	chain := NewChain(nil, []AlbumSource{failing, empty, hit, unused})
+	images := chain.AlbumImages(context.Background(), "rg-1", "Artist", "Title")
+
+	if len(images) != 1 || images[0].Source != "third" {
+		t.Fatalf("expected image from third source, got %#v", images)
+	}
+	if unused.calls != 0 {
+		t.Errorf("expected chain to stop at first hit, fourth source called %d times", unused.calls)
+	}
+}
+
+func TestChainArtistImagesEmpty(t *testing.T) {
+	chain := NewChain(nil, nil)
+	if images := chain.ArtistImages(context.Background(), "id", "name"); images != nil {
+		t.Fatalf("expected nil images from empty chain, got %#v", images)
+	}
+}
+
+func TestChainPromotesSourceThatKeepsHitting(t *testing.T) {
+	flaky := &stubAlbumSource{name: "flaky", err: errors.New("down")}
+	steady := &stubAlbumSource{name: "steady", images: []data.Image{{URL: "https://example.com/cover.jpg", Source: "steady"}}}
+	chain := NewChain(nil, []AlbumSource{flaky, steady})
+
+	for i := 0; i < 10; i++ {
+		chain.AlbumImages(context.Background(), "rg-1", "Artist", "Title")
+	}
+	callsBefore := flaky.calls
+	chain.AlbumImages(context.Background(), "rg-1", "Artist", "Title")
+
+	if flaky.calls != callsBefore {
+		t.Errorf("expected steady source to be tried first once flaky kept failing")
+	}
+}
@@ -0,0 +1,109 @@
+package images
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+const (
+	// colorSampleSide is roughly how many pixels are sampled along the longer edge; covers
+	// are sampled on a grid rather than read pixel by pixel.
+	colorSampleSide = 100
+	// colorBits is how many bits per channel colors are bucketed by, so near shades count
+	// together.
+	colorBits = 4
+	// minAccentSaturation and minAccentDistance keep washed-out shades and near copies of the
+	// dominant color from being picked as the accent.
+	minAccentSaturation = 0.3
+	minAccentDistance   = 80
+)
+
+type colorBucket struct {
+	r, g, b, count int
+}
+
+func (b colorBucket) average() color.RGBA {
+	return color.RGBA{uint8(b.r / b.count), uint8(b.g / b.count), uint8(b.b / b.count), 0xff}
+}
+
+// Colors picks the dominant and accent colors of img. It returns nil for an image with no
+// opaque pixels.
+func Colors(img image.Image) *data.ImageColors {
+	bounds := img.Bounds()
+	step := max(1, max(bounds.Dx(), bounds.Dy())/colorSampleSide)
+
+	var buckets [1 << (3 * colorBits)]colorBucket
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A < 0x80 {
+				continue
+			}
+			const shift = 8 - colorBits
+			i := int(c.R>>shift)<<(2*colorBits) | int(c.G>>shift)<<colorBits | int(c.B>>shift)
+			bucket := &buckets[i]
+			bucket.r += int(c.R)
+			bucket.g += int(c.G)
+			bucket.b += int(c.B)
+			bucket.count++
+		}
+	}
+
+	dominant := -1
+	for i, bucket := range buckets {
+		if bucket.count > 0 && (dominant < 0 || bucket.count > buckets[dominant].count) {
+			dominant = i
+		}
+	}
+	if dominant < 0 {
+		return nil
+	}
+	base := buckets[dominant].average()
+
+	// The accent favours colors that are both common and vivid.
+	accent, best := -1, 0.0
+	for i, bucket := range buckets {
+		if bucket.count == 0 || i == dominant {
+			continue
+		}
+		avg := bucket.average()
+		saturation := saturationOf(avg)
+		if saturation < minAccentSaturation || distance(avg, base) < minAccentDistance {
+			continue
+		}
+		if score := float64(bucket.count) * saturation; score > best {
+			accent, best = i, score
+		}
+	}
+
+	colors := &data.ImageColors{Dominant: hexColor(base)}
+	if accent >= 0 {
+		colors.Accent = hexColor(buckets[accent].average())
+	}
+	return colors
+}
+
+// saturationOf returns c's HSV saturation from 0 to 1.
+func saturationOf(c color.RGBA) float64 {
+	high := max(c.R, c.G, c.B)
+	if high == 0 {
+		return 0
+	}
+	return float64(high-min(c.R, c.G, c.B)) / float64(high)
+}
+
+// distance is the Euclidean distance between two colors in RGB space.
+func distance(a, b color.RGBA) float64 {
+	dr := float64(a.R) - float64(b.R)
+	dg := float64(a.G) - float64(b.G)
+	db := float64(a.B) - float64(b.B)
+	return math.Sqrt(dr*dr + dg*dg + db*db)
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
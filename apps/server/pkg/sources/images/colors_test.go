@@ -0,0 +1,48 @@
+package images
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// paint fills the top rows of a 200x200 image with top and the rest with bottom.
+func paint(rows int, top, bottom color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 200, 200))
+	for y := range 200 {
+		for x := range 200 {
+			if y < rows {
+				img.Set(x, y, top)
+			} else {
+				img.Set(x, y, bottom)
+			}
+		}
+	}
+	return img
+}
+
+func TestColorsPicksDominantAndAccent(t *testing.T) {
+	navy := color.NRGBA{0x10, 0x20, 0x40, 0xff}
+	red := color.NRGBA{0xe0, 0x20, 0x20, 0xff}
+
+	colors := Colors(paint(50, red, navy))
+	if colors == nil || colors.Dominant != "#102040" || colors.Accent != "#e02020" {
+		t.Fatalf("expected navy with a red accent, got %+v", colors)
+	}
+}
+
+func TestColorsSkipsDullAccents(t *testing.T) {
+	black := color.NRGBA{0x08, 0x08, 0x08, 0xff}
+	grey := color.NRGBA{0x90, 0x90, 0x90, 0xff}
+
+	colors := Colors(paint(50, grey, black))
+	if colors == nil || colors.Dominant != "#080808" || colors.Accent != "" {
+		t.Fatalf("expected black without an accent, got %+v", colors)
+	}
+}
+
+func TestColorsIgnoresTransparentPixels(t *testing.T) {
+	if colors := Colors(image.NewNRGBA(image.Rect(0, 0, 10, 10))); colors != nil {
+		t.Fatalf("expected no colors for a transparent image, got %+v", colors)
+	}
+}
@@ -0,0 +1,83 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	// Decoders for the formats image sources serve.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/useragent"
+)
+
+const (
+	// maxImageBytes caps a download; sources link thumbnails, but some fall back to originals.
+	maxImageBytes = 8 << 20
+	// maxImagePixels refuses images that would take too much memory to decode.
+	maxImagePixels = 25_000_000
+)
+
+// fetcher downloads and decodes images.
+type fetcher struct {
+	userAgent  string
+	httpClient *http.Client
+}
+
+func newFetcher(userAgent string, opts httpclient.Options) *fetcher {
+	userAgent = strings.TrimSpace(userAgent)
+	if userAgent == "" {
+		userAgent = useragent.Default()
+	}
+	return &fetcher{
+		userAgent:  userAgent,
+		httpClient: httpclient.New("images", opts, 10*time.Second, health.NewTracker()),
+	}
+}
+
+func (f *fetcher) fetch(ctx context.Context, imageURL string) (image.Image, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("images: request build failed: %w", err)
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+	req.Header.Set("Accept", "image/jpeg, image/png, image/gif")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("images: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("images: unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("images: read failed: %w", err)
+	}
+	if len(body) > maxImageBytes {
+		return nil, errors.New("images: image too large")
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("images: decode failed: %w", err)
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, fmt.Errorf("images: %dx%d image too large", config.Width, config.Height)
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("images: decode failed: %w", err)
+	}
+	return decoded, nil
+}
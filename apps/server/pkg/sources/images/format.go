@@ -0,0 +1,80 @@
+package images
+
+import (
+	"image"
+	"image/jpeg"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/images/webp"
+)
+
+// Format is an encoding the proxy produces variants in, named by its media type.
+type Format string
+
+const (
+	FormatJPEG Format = "image/jpeg"
+	FormatWebP Format = "image/webp"
+)
+
+// Qualities of derived images. The WebP quality is lower because it matches the JPEG's
+// fidelity at that setting while taking roughly a third fewer bytes.
+const (
+	jpegQuality = 82
+	webpQuality = 50
+)
+
+// variantVersion is part of every cached variant's key. Variants are served as immutable and
+// persisted, so bump it whenever a change to the encoders, qualities, or scaling alters the
+// bytes produced, and clients and caches fetch the new ones under fresh keys.
+const variantVersion = 1
+
+// NegotiateFormat picks the variant format for an Accept header. WebP is served to clients
+// that list it, unless they rank JPEG above it; everyone else gets JPEG, which every client
+// can show. A wildcard alone doesn't count as accepting WebP, since older clients send one
+// without being able to decode it. AVIF is never produced: there is no AV1 encoder in Go's
+// standard library or in the packages this server builds with.
+func NegotiateFormat(accept string) Format {
+	webpQ, jpegQ := 0.0, -1.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				continue
+			}
+			q = parsed
+		}
+		switch strings.ToLower(strings.TrimSpace(mediaType)) {
+		case "image/webp":
+			webpQ = q
+		case "image/jpeg", "image/*", "*/*":
+			jpegQ = max(jpegQ, q)
+		}
+	}
+	if webpQ > 0 && webpQ >= jpegQ {
+		return FormatWebP
+	}
+	return FormatJPEG
+}
+
+func (f Format) valid() bool {
+	return f == FormatJPEG || f == FormatWebP
+}
+
+// extension names cached variants of the format.
+func (f Format) extension() string {
+	if f == FormatWebP {
+		return "webp"
+	}
+	return "jpg"
+}
+
+func (f Format) encode(w io.Writer, img image.Image) error {
+	if f == FormatWebP {
+		return webp.Encode(w, img, &webp.Options{Quality: webpQuality})
+	}
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+}
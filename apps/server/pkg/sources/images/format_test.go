@@ -0,0 +1,24 @@
+package images
+
+import "testing"
+
+func TestNegotiateFormat(t *testing.T) {
+	for _, tc := range []struct {
+		accept string
+		want   Format
+	}{
+		{"", FormatJPEG},
+		{"*/*", FormatJPEG},
+		{"image/avif,image/webp,image/apng,image/svg+xml,image/*,*/*;q=0.8", FormatWebP},
+		{"image/webp,*/*", FormatWebP},
+		{"image/webp;q=0.5, image/jpeg", FormatJPEG},
+		{"image/webp;q=0, */*", FormatJPEG},
+		{"image/webp;q=high, */*", FormatJPEG},
+		{"IMAGE/WEBP", FormatWebP},
+		{"image/avif", FormatJPEG},
+	} {
+		if got := NegotiateFormat(tc.accept); got != tc.want {
+			t.Errorf("NegotiateFormat(%q) = %s, want %s", tc.accept, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+)
+
+// VariantSizes are the longest-edge sizes, in pixels, the proxy serves.
+var VariantSizes = []int{64, 256, 640}
+
+var (
+	// ErrHostNotAllowed is returned for image URLs outside the proxy's allowed hosts.
+	ErrHostNotAllowed = errors.New("images: host not allowed")
+	// ErrInvalidSize is returned for sizes not in VariantSizes.
+	ErrInvalidSize = errors.New("images: unsupported size")
+	// ErrInvalidFormat is returned for formats the proxy doesn't encode.
+	ErrInvalidFormat = errors.New("images: unsupported format")
+)
+
+// ProxyConfig describes where the proxy may fetch images from and where it keeps what it
+// derives from them.
+type ProxyConfig struct {
+	UserAgent string
+	HTTP      httpclient.Options
+	// Hosts lists the image hosts that may be fetched, redirects included; subdomains match
+	// too.
+	Hosts []string
+	// Cache stores derived images; nil derives them on every request.
+	Cache VariantCache
+}
+
+// Proxy serves resized JPEG and WebP variants of upstream images so pages don't download
+// full-size covers for thumbnails.
+type Proxy struct {
+	fetcher *fetcher
+	hosts   []string
+	cache   VariantCache
+}
+
+// NewProxy builds a Proxy.
+func NewProxy(cfg ProxyConfig) *Proxy {
+	p := &Proxy{
+		fetcher: newFetcher(cfg.UserAgent, cfg.HTTP),
+		cache:   cfg.Cache,
+	}
+	for _, host := range cfg.Hosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			p.hosts = append(p.hosts, host)
+		}
+	}
+	// The Cover Art Archive redirects to archive.org storage nodes; every hop must stay on an
+	// allowed host so the proxy can't be pointed at internal addresses.
+	p.fetcher.httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("images: too many redirects")
+		}
+		if !p.allowed(req.URL) {
+			return ErrHostNotAllowed
+		}
+		return nil
+	}
+	return p
+}
+
+// Variant returns imageURL scaled to fit within size pixels on its longest edge, encoded in
+// format. Images already smaller are re-encoded at their own size.
+func (p *Proxy) Variant(ctx context.Context, imageURL string, size int, format Format) ([]byte, error) {
+	if !slices.Contains(VariantSizes, size) {
+		return nil, ErrInvalidSize
+	}
+	if !format.valid() {
+		return nil, ErrInvalidFormat
+	}
+	parsed, err := url.Parse(imageURL)
+	if err != nil || !p.allowed(parsed) {
+		return nil, ErrHostNotAllowed
+	}
+
+	key := variantKey(imageURL, size, format)
+	if p.cache != nil {
+		cached, err := p.cache.Get(ctx, key)
+		if err == nil {
+			return cached, nil
+		}
+		if !errors.Is(err, ErrCacheMiss) {
+			logging.FromContext(ctx).Warn("image variant cache read failed", "key", key, "error", err)
+		}
+	}
+
+	decoded, err := p.fetcher.fetch(ctx, imageURL)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := format.encode(&buf, Resize(decoded, size)); err != nil {
+		return nil, fmt.Errorf("images: encode failed: %w", err)
+	}
+
+	if p.cache != nil {
+		if err := p.cache.Put(ctx, key, buf.Bytes()); err != nil {
+			logging.FromContext(ctx).Warn("image variant cache write failed", "key", key, "error", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (p *Proxy) allowed(u *url.URL) bool {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range p.hosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// variantKey names a cached variant after a hash of the source URL, its size, the variant
+// version and its format.
+func variantKey(imageURL string, size int, format Format) string {
+	sum := sha256.Sum256([]byte(imageURL))
+	return fmt.Sprintf("%s-%d-v%d.%s", hex.EncodeToString(sum[:]), size, variantVersion, format.extension())
+}
@@ -0,0 +1,128 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+
+	xwebp "golang.org/x/image/webp"
+)
+
+func newTestProxy(cache VariantCache, hosts ...string) *Proxy {
+	return NewProxy(ProxyConfig{
+		HTTP:  httpclient.Options{Retry: retry.Policy{MaxAttempts: 1}},
+		Hosts: hosts,
+		Cache: cache,
+	})
+}
+
+func TestProxyServesAndCachesResizedVariants(t *testing.T) {
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		png.Encode(w, paint(100, color.NRGBA{0xe0, 0x20, 0x20, 0xff}, color.NRGBA{0x10, 0x20, 0x40, 0xff}))
+	}))
+	defer server.Close()
+
+	cache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache: %v", err)
+	}
+	proxy := newTestProxy(cache, "127.0.0.1")
+	for _, tc := range []struct {
+		format Format
+		decode func(io.Reader) (image.Image, error)
+	}{
+		{FormatJPEG, jpeg.Decode},
+		{FormatWebP, xwebp.Decode},
+	} {
+		for range 2 {
+			variant, err := proxy.Variant(context.Background(), server.URL+"/cover.png", 64, tc.format)
+			if err != nil {
+				t.Fatalf("Variant: %v", err)
+			}
+			decoded, err := tc.decode(bytes.NewReader(variant))
+			if err != nil {
+				t.Fatalf("expected %s: %v", tc.format, err)
+			}
+			if bounds := decoded.Bounds(); bounds.Dx() != 64 || bounds.Dy() != 64 {
+				t.Errorf("expected a 64x64 variant, got %v", bounds)
+			}
+		}
+	}
+	if got := fetches.Load(); got != 2 {
+		t.Errorf("expected one fetch per format with repeats served from the cache, got %d fetches", got)
+	}
+	if _, err := proxy.Variant(context.Background(), server.URL+"/cover.png", 64, "image/avif"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("expected ErrInvalidFormat, got %v", err)
+	}
+}
+
+func TestProxyRefusesOtherHostsAndSizes(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request to a host outside the allowlist")
+	}))
+	defer target.Close()
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL+"/internal", http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	proxy := newTestProxy(nil, "example.com")
+	if _, err := proxy.Variant(context.Background(), target.URL+"/cover.jpg", 64, FormatJPEG); !errors.Is(err, ErrHostNotAllowed) {
+		t.Errorf("expected ErrHostNotAllowed, got %v", err)
+	}
+	if _, err := proxy.Variant(context.Background(), "https://images.example.com/cover.jpg", 100, FormatJPEG); !errors.Is(err, ErrInvalidSize) {
+		t.Errorf("expected ErrInvalidSize, got %v", err)
+	}
+
+	// The redirecting server is allowed by name; the address it points at is not.
+	proxy = newTestProxy(nil, "localhost")
+	redirectURL := strings.Replace(redirect.URL, "127.0.0.1", "localhost", 1) + "/cover.jpg"
+	if _, err := proxy.Variant(context.Background(), redirectURL, 64, FormatJPEG); !errors.Is(err, ErrHostNotAllowed) {
+		t.Errorf("expected the redirect refused, got %v", err)
+	}
+}
+
+func TestResizeKeepsAspectRatio(t *testing.T) {
+	wide := image.NewNRGBA(image.Rect(0, 0, 1000, 500))
+	if bounds := Resize(wide, 256).Bounds(); bounds.Dx() != 256 || bounds.Dy() != 128 {
+		t.Errorf("expected 256x128, got %v", bounds)
+	}
+	small := image.NewNRGBA(image.Rect(0, 0, 40, 60))
+	if resized := Resize(small, 64); resized != image.Image(small) {
+		t.Errorf("expected a small image left as it is, got %v", resized.Bounds())
+	}
+	tall := paint(100, color.NRGBA{0xff, 0xff, 0xff, 0xff}, color.NRGBA{0, 0, 0, 0xff}).SubImage(image.Rect(0, 0, 100, 200))
+	resized := Resize(tall, 64).(*image.NRGBA)
+	if bounds := resized.Bounds(); bounds.Dx() != 32 || bounds.Dy() != 64 {
+		t.Fatalf("expected 32x64, got %v", bounds)
+	}
+	if top, bottom := resized.NRGBAAt(0, 0), resized.NRGBAAt(0, 63); top.R != 0xff || bottom.R != 0 {
+		t.Errorf("expected the white top and black bottom kept, got %v and %v", top, bottom)
+	}
+}
+
+func TestVariantKeyIncludesVersion(t *testing.T) {
+	key := variantKey("https://images.example.com/cover.jpg", 64, FormatWebP)
+	if want := fmt.Sprintf("-64-v%d.webp", variantVersion); !strings.HasSuffix(key, want) {
+		t.Errorf("expected key %q to end in %q", key, want)
+	}
+	if variantKey("https://images.example.com/cover.jpg", 64, FormatJPEG) == key {
+		t.Error("expected formats to be cached apart")
+	}
+}
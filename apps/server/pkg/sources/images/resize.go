@@ -0,0 +1,47 @@
+package images
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Resize scales img down to fit within size pixels on its longest edge, averaging the source
+// pixels under each destination pixel. Images already within size are returned as they are.
+func Resize(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+	dstWidth, dstHeight := size, max(1, height*size/width)
+	if height > width {
+		dstWidth, dstHeight = max(1, width*size/height), size
+	}
+
+	// Work on a copy in a known layout so each pixel is a plain slice read.
+	src := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := range dstHeight {
+		y0, y1 := y*height/dstHeight, max((y+1)*height/dstHeight, y*height/dstHeight+1)
+		for x := range dstWidth {
+			x0, x1 := x*width/dstWidth, max((x+1)*width/dstWidth, x*width/dstWidth+1)
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride+x0*4 : sy*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					for c := range sum {
+						sum[c] += int(row[i+c])
+					}
+				}
+			}
+			count := (y1 - y0) * (x1 - x0)
+			offset := y*dst.Stride + x*4
+			for c := range sum {
+				dst.Pix[offset+c] = uint8(sum[c] / count)
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,204 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+)
+
+const defaultS3Region = "us-east-1"
+
+// S3Config locates the bucket an S3Cache keeps variants in, and the credentials that sign
+// requests to it.
+type S3Config struct {
+	Bucket string
+	// Region defaults to us-east-1.
+	Region string
+	// Endpoint addresses an S3-compatible service such as MinIO, with the bucket in the path;
+	// empty uses AWS, with the bucket in the host name.
+	Endpoint string
+	// Prefix is prepended to every object key, e.g. "variants/".
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// SessionToken accompanies temporary credentials.
+	SessionToken string
+	HTTP         httpclient.Options
+}
+
+// S3Cache keeps variants as objects in an S3 bucket, so every server behind a load balancer
+// shares one copy of each.
+type S3Cache struct {
+	base       string
+	prefix     string
+	signer     s3Signer
+	httpClient *http.Client
+}
+
+// NewS3Cache builds an S3Cache. It doesn't contact the bucket; a misconfigured one shows up
+// as failed cache reads and writes in the log, while variants are still served.
+func NewS3Cache(cfg S3Config) (*S3Cache, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("images: S3 bucket required")
+	}
+	region := cfg.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+	base := fmt.Sprintf("https://%s.s3.%s.amazonaws.com", cfg.Bucket, region)
+	if cfg.Endpoint != "" {
+		endpoint, err := url.Parse(cfg.Endpoint)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return nil, fmt.Errorf("images: invalid S3 endpoint %q", cfg.Endpoint)
+		}
+		base = strings.TrimSuffix(endpoint.String(), "/") + "/" + cfg.Bucket
+	}
+	return &S3Cache{
+		base:   base,
+		prefix: cfg.Prefix,
+		signer: s3Signer{
+			accessKeyID:     cfg.AccessKeyID,
+			secretAccessKey: cfg.SecretAccessKey,
+			sessionToken:    cfg.SessionToken,
+			region:          region,
+			now:             time.Now,
+		},
+		httpClient: httpclient.New("s3", cfg.HTTP, 10*time.Second, health.NewTracker()),
+	}, nil
+}
+
+func (c *S3Cache) Get(ctx context.Context, key string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.objectURL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("images: request build failed: %w", err)
+	}
+	c.signer.sign(req, emptyPayloadHash)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("images: S3 get failed: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrCacheMiss
+	default:
+		return nil, fmt.Errorf("images: S3 get returned status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("images: S3 read failed: %w", err)
+	}
+	if len(body) > maxImageBytes {
+		return nil, errors.New("images: cached object too large")
+	}
+	return body, nil
+}
+
+func (c *S3Cache) Put(ctx context.Context, key string, content []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.objectURL(key), bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("images: request build failed: %w", err)
+	}
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	sum := sha256.Sum256(content)
+	c.signer.sign(req, hex.EncodeToString(sum[:]))
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("images: S3 put failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("images: S3 put returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *S3Cache) objectURL(key string) string {
+	return c.base + "/" + c.prefix + key
+}
+
+// emptyPayloadHash is the SHA-256 of an empty body.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// s3Signer signs requests with AWS Signature Version 4, covering the host and every header
+// set on the request when it is signed.
+type s3Signer struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	region          string
+	now             func() time.Time
+}
+
+func (s s3Signer) sign(req *http.Request, payloadHash string) {
+	stamp := s.now().UTC().Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", stamp)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		trimmed := make([]string, len(values))
+		for i, value := range values {
+			trimmed[i] = strings.Join(strings.Fields(value), " ")
+		}
+		headers[strings.ToLower(name)] = strings.Join(trimmed, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalPath := req.URL.EscapedPath()
+	if canonicalPath == "" {
+		canonicalPath = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method, canonicalPath, req.URL.Query().Encode(), canonicalHeaders.String(), signedHeaders, payloadHash,
+	}, "\n")
+
+	date := stamp[:8]
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + stamp + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), date)
+	for _, part := range []string{s.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s,SignedHeaders=%s,Signature=%s",
+		s.accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,67 @@
+package webp
+
+// boolEncoder is VP8's boolean entropy coder (RFC 6386 section 7): each bit is coded against
+// the probability, out of 256, that it is zero.
+type boolEncoder struct {
+	out      []byte
+	rng      uint32
+	bottom   uint32
+	bitCount int
+}
+
+func newBoolEncoder() *boolEncoder {
+	return &boolEncoder{rng: 255, bitCount: 24}
+}
+
+func (e *boolEncoder) writeBool(prob uint8, bit bool) {
+	split := 1 + ((e.rng - 1) * uint32(prob) >> 8)
+	if bit {
+		e.bottom += split
+		e.rng -= split
+	} else {
+		e.rng = split
+	}
+	for e.rng < 128 {
+		e.rng <<= 1
+		if e.bottom&(1<<31) != 0 {
+			e.carry()
+		}
+		e.bottom <<= 1
+		e.bitCount--
+		if e.bitCount == 0 {
+			e.out = append(e.out, byte(e.bottom>>24))
+			e.bottom &= 1<<24 - 1
+			e.bitCount = 8
+		}
+	}
+}
+
+// carry propagates an overflow of bottom into the bytes already written.
+func (e *boolEncoder) carry() {
+	for i := len(e.out) - 1; i >= 0; i-- {
+		e.out[i]++
+		if e.out[i] != 0 {
+			return
+		}
+	}
+}
+
+// writeLiteral writes the low n bits of v, most significant first, at even odds.
+func (e *boolEncoder) writeLiteral(v uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		e.writeBool(128, v>>i&1 == 1)
+	}
+}
+
+// writeFlag writes a one-bit flag at even odds.
+func (e *boolEncoder) writeFlag(flag bool) {
+	e.writeBool(128, flag)
+}
+
+// finish flushes the coder, padding with zeros as libvpx does, and returns everything written.
+func (e *boolEncoder) finish() []byte {
+	for range 32 {
+		e.writeBool(128, false)
+	}
+	return e.out
+}
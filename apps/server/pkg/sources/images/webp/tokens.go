@@ -0,0 +1,165 @@
+package webp
+
+// tokenSink receives coefficient tokens as a sequence of binary decisions. The adaptive ones
+// name the probability that codes them; the rest use a fixed probability.
+type tokenSink interface {
+	branch(plane, band, ctx, node int, bit bool)
+	fixed(prob uint8, bit bool)
+}
+
+// tokenCounter tallies how often each adaptive branch goes each way.
+type tokenCounter struct {
+	counts [numPlanes][numBands][numContexts][numProbs][2]int
+}
+
+func (c *tokenCounter) branch(plane, band, ctx, node int, bit bool) {
+	if bit {
+		c.counts[plane][band][ctx][node][1]++
+	} else {
+		c.counts[plane][band][ctx][node][0]++
+	}
+}
+
+func (c *tokenCounter) fixed(uint8, bool) {}
+
+// tokenWriter codes tokens into a partition with the frame's probabilities.
+type tokenWriter struct {
+	enc   *boolEncoder
+	probs *[numPlanes][numBands][numContexts][numProbs]uint8
+}
+
+func (w *tokenWriter) branch(plane, band, ctx, node int, bit bool) {
+	w.enc.writeBool(w.probs[plane][band][ctx][node], bit)
+}
+
+func (w *tokenWriter) fixed(prob uint8, bit bool) {
+	w.enc.writeBool(prob, bit)
+}
+
+// nonZero tracks which neighbouring blocks carried coefficients; each block's token
+// context counts its left and above neighbours (section 13.3).
+type nonZero struct {
+	y2   int
+	y    [4]int
+	u, v [2]int
+}
+
+// putTokens codes the residuals of every macroblock that has any and returns how many
+// macroblocks were skipped for having none.
+func (f *frameEncoder) putTokens(s tokenSink) (skipped int) {
+	above := make([]nonZero, f.src.mbw)
+	for mby := range f.src.mbh {
+		var left nonZero
+		for mbx := range f.src.mbw {
+			mb, top := &f.mbs[mby*f.src.mbw+mbx], &above[mbx]
+			if mb.empty() {
+				left, *top = nonZero{}, nonZero{}
+				skipped++
+				continue
+			}
+			left.y2 = putBlock(s, planeY2, left.y2+top.y2, &mb.y2, 0)
+			top.y2 = left.y2
+			for y := range 4 {
+				for x := range 4 {
+					left.y[y] = putBlock(s, planeYAfterY2, left.y[y]+top.y[x], &mb.y[4*y+x], 1)
+					top.y[x] = left.y[y]
+				}
+			}
+			for c, blocks := range [][4][16]int16{mb.u, mb.v} {
+				l, t := &left.u, &top.u
+				if c == 1 {
+					l, t = &left.v, &top.v
+				}
+				for y := range 2 {
+					for x := range 2 {
+						l[y] = putBlock(s, planeUV, l[y]+t[x], &blocks[2*y+x], 0)
+						t[x] = l[y]
+					}
+				}
+			}
+		}
+	}
+	return skipped
+}
+
+// putBlock codes the levels of one 4x4 block from position first onwards, walking the token
+// tree the way the decoder reads it (section 13.2), and returns 1 if any level was nonzero.
+func putBlock(s tokenSink, plane, ctx int, levels *[16]int16, first int) int {
+	last := -1
+	for n := 15; n >= first; n-- {
+		if levels[n] != 0 {
+			last = n
+			break
+		}
+	}
+	band := coeffBands[first]
+	s.branch(plane, band, ctx, 0, last >= 0)
+	if last < 0 {
+		return 0
+	}
+	for n := first; ; {
+		v := int(levels[n])
+		n++
+		if v == 0 {
+			s.branch(plane, band, ctx, 1, false)
+			band, ctx = coeffBands[n], 0
+			continue
+		}
+		s.branch(plane, band, ctx, 1, true)
+		putValue(s, plane, band, ctx, abs(v))
+		s.fixed(128, v < 0)
+		band, ctx = coeffBands[n], 2
+		if abs(v) == 1 {
+			ctx = 1
+		}
+		if n == 16 {
+			return 1
+		}
+		s.branch(plane, band, ctx, 0, n <= last)
+		if n > last {
+			return 1
+		}
+	}
+}
+
+// putValue codes the magnitude of a nonzero level.
+func putValue(s tokenSink, plane, band, ctx, v int) {
+	branch := func(node int, bit bool) { s.branch(plane, band, ctx, node, bit) }
+	if v == 1 {
+		branch(2, false)
+		return
+	}
+	branch(2, true)
+	switch {
+	case v <= 4:
+		branch(3, false)
+		branch(4, v != 2)
+		if v != 2 {
+			branch(5, v == 4)
+		}
+	case v <= 10:
+		branch(3, true)
+		branch(6, false)
+		branch(7, v > 6)
+		if v <= 6 {
+			s.fixed(159, v == 6)
+		} else {
+			s.fixed(165, (v-7)>>1 == 1)
+			s.fixed(145, (v-7)&1 == 1)
+		}
+	default:
+		branch(3, true)
+		branch(6, true)
+		cat := 0
+		for cat < 3 && v >= 3+(8<<(cat+1)) {
+			cat++
+		}
+		branch(8, cat >= 2)
+		branch(9+cat>>1, cat&1 == 1)
+		extra := v - (3 + 8<<cat)
+		probs := extraBitProbs[cat]
+		for i, p := range probs {
+			s.fixed(p, extra>>(len(probs)-1-i)&1 == 1)
+		}
+	}
+}
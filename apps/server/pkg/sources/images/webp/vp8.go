@@ -0,0 +1,521 @@
+package webp
+
+import (
+	"math"
+)
+
+// The encoder codes every macroblock as 16x16 luma plus 8x8 chroma intra prediction, picks
+// the prediction mode by squared error, and reconstructs each block exactly as a decoder
+// will, so later predictions start from the same samples the decoder sees. The 4x4 luma
+// modes, segmentation and rate-distortion search a full encoder would add are left out;
+// album covers do well enough without them.
+
+// Intra prediction modes, numbered as section 12 numbers them.
+const (
+	modeDC = iota
+	modeTM
+	modeVE
+	modeHE
+	numModes
+)
+
+// maxLevel is the largest quantized coefficient magnitude the token set can carry.
+const maxLevel = 2047
+
+// quantizer holds the DC and AC step sizes for one kind of block, and the rounding bias,
+// out of 256, applied before dividing by them. A bias below a half rounds small
+// coefficients to zero, which is where most of the saving comes from.
+type quantizer struct {
+	step [2]int
+	bias [2]int
+}
+
+func (q *quantizer) quantize(c, i int) int16 {
+	level := (abs(c)*256 + q.step[i]*q.bias[i]) / (q.step[i] * 256)
+	level = min(level, maxLevel)
+	if c < 0 {
+		level = -level
+	}
+	return int16(level)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// macroblock records the decisions for one macroblock. Coefficient levels are kept in
+// zigzag order, the order they are coded in.
+type macroblock struct {
+	lumaMode, chromaMode int
+	y2                   [16]int16
+	// y holds the luma blocks in raster order; their DC levels live in y2, so index 0 is unused.
+	y    [16][16]int16
+	u, v [4][16]int16
+}
+
+func (mb *macroblock) empty() bool {
+	zero := [16]int16{}
+	if mb.y2 != zero {
+		return false
+	}
+	for _, blocks := range [][][16]int16{mb.y[:], mb.u[:], mb.v[:]} {
+		for _, b := range blocks {
+			if b != zero {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type frameEncoder struct {
+	src, rec   *yuv
+	qIndex     int
+	y1, y2, uv quantizer
+	mbs        []macroblock
+}
+
+// encodeFrame returns a VP8 key frame (RFC 6386) holding src.
+func encodeFrame(src *yuv, width, height, quality int) []byte {
+	f := &frameEncoder{
+		src:    src,
+		rec:    &yuv{mbw: src.mbw, mbh: src.mbh},
+		qIndex: qualityToIndex(quality),
+		mbs:    make([]macroblock, src.mbw*src.mbh),
+	}
+	f.rec.y = make([]uint8, len(src.y))
+	f.rec.u = make([]uint8, len(src.u))
+	f.rec.v = make([]uint8, len(src.v))
+	q := f.qIndex
+	f.y1 = quantizer{step: [2]int{int(dcQuant[q]), int(acQuant[q])}, bias: [2]int{96, 110}}
+	f.y2 = quantizer{step: [2]int{int(dcQuant[q]) * 2, max(int(acQuant[q])*155/100, 8)}, bias: [2]int{96, 108}}
+	f.uv = quantizer{step: [2]int{int(dcQuant[min(q, 117)]), int(acQuant[q])}, bias: [2]int{110, 115}}
+
+	for mby := range src.mbh {
+		for mbx := range src.mbw {
+			f.encodeMacroblock(mbx, mby)
+		}
+	}
+
+	// Count the tokens once to tune the coefficient probabilities, then code them for real.
+	counter := &tokenCounter{}
+	skipped := f.putTokens(counter)
+	probs := defaultCoeffProbs
+	var updated [numPlanes][numBands][numContexts][numProbs]bool
+	for i := range probs {
+		for j := range probs[i] {
+			for k := range probs[i][j] {
+				for l := range probs[i][j][k] {
+					n := counter.counts[i][j][k][l]
+					p, ok := chooseProb(probs[i][j][k][l], coeffUpdateProbs[i][j][k][l], n[0], n[1])
+					probs[i][j][k][l], updated[i][j][k][l] = p, ok
+				}
+			}
+		}
+	}
+	tokens := newBoolEncoder()
+	f.putTokens(&tokenWriter{enc: tokens, probs: &probs})
+
+	first := newBoolEncoder()
+	first.writeFlag(false) // Color space: YUV.
+	first.writeFlag(false) // Clamping: required.
+	first.writeFlag(false) // No segmentation.
+	first.writeFlag(false) // The normal loop filter rather than the simple one.
+	first.writeLiteral(uint32(filterLevel(f.qIndex)), 6)
+	first.writeLiteral(0, 3) // Sharpness.
+	first.writeFlag(false)   // No per-mode filter adjustments.
+	first.writeLiteral(0, 2) // One token partition.
+	first.writeLiteral(uint32(f.qIndex), 7)
+	for range 5 {
+		first.writeFlag(false) // No quantizer deltas.
+	}
+	first.writeFlag(false) // Entropy probabilities are not kept; there is no next frame.
+	for i := range probs {
+		for j := range probs[i] {
+			for k := range probs[i][j] {
+				for l := range probs[i][j][k] {
+					first.writeBool(coeffUpdateProbs[i][j][k][l], updated[i][j][k][l])
+					if updated[i][j][k][l] {
+						first.writeLiteral(uint32(probs[i][j][k][l]), 8)
+					}
+				}
+			}
+		}
+	}
+	useSkip := skipped > 0
+	first.writeFlag(useSkip)
+	var skipProb uint8
+	if useSkip {
+		skipProb = clipProb(256 * (len(f.mbs) - skipped) / len(f.mbs))
+		first.writeLiteral(uint32(skipProb), 8)
+	}
+	for i := range f.mbs {
+		mb := &f.mbs[i]
+		if useSkip {
+			first.writeBool(skipProb, mb.empty())
+		}
+		first.writeBool(145, true) // 16x16 luma prediction.
+		switch mb.lumaMode {
+		case modeDC:
+			first.writeBool(156, false)
+			first.writeBool(163, false)
+		case modeVE:
+			first.writeBool(156, false)
+			first.writeBool(163, true)
+		case modeHE:
+			first.writeBool(156, true)
+			first.writeBool(128, false)
+		case modeTM:
+			first.writeBool(156, true)
+			first.writeBool(128, true)
+		}
+		first.writeBool(142, mb.chromaMode != modeDC)
+		if mb.chromaMode != modeDC {
+			first.writeBool(114, mb.chromaMode != modeVE)
+			if mb.chromaMode != modeVE {
+				first.writeBool(183, mb.chromaMode == modeTM)
+			}
+		}
+	}
+
+	firstPart, tokenPart := first.finish(), tokens.finish()
+	out := make([]byte, 10, 10+len(firstPart)+len(tokenPart))
+	// Frame tag: a key frame, version 0, shown, followed by the first partition's length.
+	tag := uint32(1)<<4 | uint32(len(firstPart))<<5
+	out[0], out[1], out[2] = byte(tag), byte(tag>>8), byte(tag>>16)
+	out[3], out[4], out[5] = 0x9d, 0x01, 0x2a
+	out[6], out[7] = byte(width), byte(width>>8)
+	out[8], out[9] = byte(height), byte(height>>8)
+	out = append(out, firstPart...)
+	return append(out, tokenPart...)
+}
+
+// qualityToIndex maps a 1-100 quality onto VP8's 0-127 quantizer index along the curve
+// libwebp uses, so a given quality means roughly what it does in cwebp.
+func qualityToIndex(quality int) int {
+	c := float64(quality) / 100
+	linear := 2*c - 1
+	if c < 0.75 {
+		linear = c * 2 / 3
+	}
+	return min(max(int(127*(1-math.Cbrt(linear))), 0), 127)
+}
+
+// filterLevel picks a loop filter strength that grows with the quantizer, smoothing the
+// block edges coarse quantization leaves behind.
+func filterLevel(qIndex int) int {
+	return min(qIndex/2+4, 63)
+}
+
+// chooseProb decides whether replacing a default probability pays for its own header bits
+// given how often the branch went each way, and returns the probability to code with.
+func chooseProb(old, updateProb uint8, zeros, ones int) (uint8, bool) {
+	if zeros+ones == 0 {
+		return old, false
+	}
+	p := clipProb(int(math.Round(256 * float64(zeros) / float64(zeros+ones))))
+	keep := branchCost(old, zeros, ones) + branchCost(updateProb, 1, 0)
+	replace := branchCost(p, zeros, ones) + branchCost(updateProb, 0, 1) + 8
+	if replace < keep {
+		return p, true
+	}
+	return old, false
+}
+
+// branchCost is the number of bits spent coding zeros and ones at probability p of a zero.
+func branchCost(p uint8, zeros, ones int) float64 {
+	pz := float64(p) / 256
+	return -float64(zeros)*math.Log2(pz) - float64(ones)*math.Log2(1-pz)
+}
+
+func clipProb(p int) uint8 {
+	return uint8(min(max(p, 1), 255))
+}
+
+func (f *frameEncoder) encodeMacroblock(mbx, mby int) {
+	mb := &f.mbs[mby*f.src.mbw+mbx]
+	ys, cs := f.src.yStride(), f.src.cStride()
+
+	var pred [256]uint8
+	edges := blockEdges(f.rec.y, ys, 16, mbx, mby)
+	mb.lumaMode = chooseMode(pred[:], []*plane{{f.src.y, ys, 16 * mbx, 16 * mby, &edges}}, 16)
+
+	// Transform each 4x4 luma block, then gather their DC terms into the second-order block.
+	var coeffs [16][16]int
+	var dcs [16]int
+	for b := range 16 {
+		bx, by := 16*mbx+4*(b%4), 16*mby+4*(b/4)
+		var res [16]int
+		for j := range 4 {
+			for i := range 4 {
+				res[4*j+i] = int(f.src.y[(by+j)*ys+bx+i]) - int(pred[(4*(b/4)+j)*16+4*(b%4)+i])
+			}
+		}
+		coeffs[b] = forwardDCT(&res)
+		dcs[b] = coeffs[b][0]
+	}
+	wht := forwardWHT(&dcs)
+	var dequant [16]int
+	for n, z := range zigzag {
+		i := min(n, 1)
+		mb.y2[n] = f.y2.quantize(wht[z], i)
+		dequant[z] = int(mb.y2[n]) * f.y2.step[i]
+	}
+	dcs = inverseWHT(&dequant)
+	for b := range 16 {
+		dequant = [16]int{0: dcs[b]}
+		for n := 1; n < 16; n++ {
+			z := zigzag[n]
+			mb.y[b][n] = f.y1.quantize(coeffs[b][z], 1)
+			dequant[z] = int(mb.y[b][n]) * f.y1.step[1]
+		}
+		bx, by := 4*(b%4), 4*(b/4)
+		reconstruct(f.rec.y[(16*mby+by)*ys+16*mbx+bx:], ys, pred[by*16+bx:], 16, &dequant)
+	}
+
+	uEdges := blockEdges(f.rec.u, cs, 8, mbx, mby)
+	vEdges := blockEdges(f.rec.v, cs, 8, mbx, mby)
+	var chroma [128]uint8
+	mb.chromaMode = chooseMode(chroma[:], []*plane{
+		{f.src.u, cs, 8 * mbx, 8 * mby, &uEdges},
+		{f.src.v, cs, 8 * mbx, 8 * mby, &vEdges},
+	}, 8)
+	for c, p := range [][]uint8{f.src.u, f.src.v} {
+		rec, levels := f.rec.u, &mb.u
+		if c == 1 {
+			rec, levels = f.rec.v, &mb.v
+		}
+		pred := chroma[64*c:]
+		for b := range 4 {
+			bx, by := 4*(b%2), 4*(b/2)
+			var res [16]int
+			for j := range 4 {
+				for i := range 4 {
+					res[4*j+i] = int(p[(8*mby+by+j)*cs+8*mbx+bx+i]) - int(pred[(by+j)*8+bx+i])
+				}
+			}
+			coeffs := forwardDCT(&res)
+			var dequant [16]int
+			for n, z := range zigzag {
+				i := min(n, 1)
+				levels[b][n] = f.uv.quantize(coeffs[z], i)
+				dequant[z] = int(levels[b][n]) * f.uv.step[i]
+			}
+			reconstruct(rec[(8*mby+by)*cs+8*mbx+bx:], cs, pred[by*8+bx:], 8, &dequant)
+		}
+	}
+}
+
+// plane locates one block of source samples and the reconstructed samples around it.
+type plane struct {
+	pix    []uint8
+	stride int
+	x, y   int
+	edges  *edges
+}
+
+// chooseMode tries each prediction mode on the n×n blocks of planes, keeps the one with the
+// smallest squared error, and leaves its predictions in pred, one block after another.
+func chooseMode(pred []uint8, planes []*plane, n int) int {
+	best, bestErr := modeDC, -1
+	candidate := make([]uint8, len(pred))
+	for mode := range numModes {
+		sse := 0
+		for k, p := range planes {
+			out := candidate[k*n*n : (k+1)*n*n]
+			predict(out, n, mode, p.edges)
+			for j := range n {
+				for i := range n {
+					d := int(p.pix[(p.y+j)*p.stride+p.x+i]) - int(out[j*n+i])
+					sse += d * d
+				}
+			}
+		}
+		if bestErr < 0 || sse < bestErr {
+			best, bestErr = mode, sse
+			copy(pred, candidate)
+		}
+	}
+	return best
+}
+
+// edges are the reconstructed samples above and to the left of a block, or the fixed values
+// the format substitutes along the frame's top and left (section 12.2).
+type edges struct {
+	top, left       [16]uint8
+	corner          uint8
+	hasTop, hasLeft bool
+}
+
+func blockEdges(pix []uint8, stride, n, mbx, mby int) edges {
+	e := edges{hasTop: mby > 0, hasLeft: mbx > 0}
+	x0, y0 := n*mbx, n*mby
+	for i := range n {
+		e.top[i], e.left[i] = 127, 129
+		if e.hasTop {
+			e.top[i] = pix[(y0-1)*stride+x0+i]
+		}
+		if e.hasLeft {
+			e.left[i] = pix[(y0+i)*stride+x0-1]
+		}
+	}
+	switch {
+	case !e.hasTop:
+		e.corner = 127
+	case !e.hasLeft:
+		e.corner = 129
+	default:
+		e.corner = pix[(y0-1)*stride+x0-1]
+	}
+	return e
+}
+
+// predict fills the n×n block dst with the prediction for mode. DC prediction averages
+// whichever edges lie inside the frame, and falls back to 128 when neither does.
+func predict(dst []uint8, n, mode int, e *edges) {
+	shift := 3
+	if n == 16 {
+		shift = 4
+	}
+	for j := range n {
+		for i := range n {
+			var v int
+			switch mode {
+			case modeTM:
+				v = int(e.left[j]) + int(e.top[i]) - int(e.corner)
+			case modeVE:
+				v = int(e.top[i])
+			case modeHE:
+				v = int(e.left[j])
+			}
+			dst[j*n+i] = clip8(v)
+		}
+	}
+	if mode != modeDC {
+		return
+	}
+	sum := 0
+	for i := range n {
+		if e.hasTop {
+			sum += int(e.top[i])
+		}
+		if e.hasLeft {
+			sum += int(e.left[i])
+		}
+	}
+	dc := 128
+	switch {
+	case e.hasTop && e.hasLeft:
+		dc = (sum + n) >> (shift + 1)
+	case e.hasTop || e.hasLeft:
+		dc = (sum + n/2) >> shift
+	}
+	for i := range n * n {
+		dst[i] = uint8(dc)
+	}
+}
+
+// reconstruct adds the inverse transform of coeffs to the 4x4 prediction at pred and writes
+// the result to dst, with the decoder's exact integer arithmetic (section 14.3).
+func reconstruct(dst []uint8, dstStride int, pred []uint8, predStride int, coeffs *[16]int) {
+	const (
+		c1 = 85627 // 65536 * cos(pi/8) * sqrt(2).
+		c2 = 35468 // 65536 * sin(pi/8) * sqrt(2).
+	)
+	var m [4][4]int
+	for i := range 4 {
+		a := coeffs[i] + coeffs[8+i]
+		b := coeffs[i] - coeffs[8+i]
+		c := (coeffs[4+i]*c2)>>16 - (coeffs[12+i]*c1)>>16
+		d := (coeffs[4+i]*c1)>>16 + (coeffs[12+i]*c2)>>16
+		m[i] = [4]int{a + d, b + c, b - c, a - d}
+	}
+	for j := range 4 {
+		dc := m[0][j] + 4
+		a := dc + m[2][j]
+		b := dc - m[2][j]
+		c := (m[1][j]*c2)>>16 - (m[3][j]*c1)>>16
+		d := (m[1][j]*c1)>>16 + (m[3][j]*c2)>>16
+		for i, r := range [4]int{a + d, b + c, b - c, a - d} {
+			dst[j*dstStride+i] = clip8(int(pred[j*predStride+i]) + r>>3)
+		}
+	}
+}
+
+// forwardDCT is libvpx's 4x4 forward transform, the counterpart of reconstruct's inverse.
+func forwardDCT(in *[16]int) [16]int {
+	var tmp, out [16]int
+	for i := range 4 {
+		ip := in[4*i : 4*i+4]
+		a := (ip[0] + ip[3]) * 8
+		b := (ip[1] + ip[2]) * 8
+		c := (ip[1] - ip[2]) * 8
+		d := (ip[0] - ip[3]) * 8
+		tmp[4*i+0] = a + b
+		tmp[4*i+2] = a - b
+		tmp[4*i+1] = (c*2217 + d*5352 + 14500) >> 12
+		tmp[4*i+3] = (d*2217 - c*5352 + 7500) >> 12
+	}
+	for i := range 4 {
+		a := tmp[i] + tmp[12+i]
+		b := tmp[4+i] + tmp[8+i]
+		c := tmp[4+i] - tmp[8+i]
+		d := tmp[i] - tmp[12+i]
+		out[i] = (a + b + 7) >> 4
+		out[8+i] = (a - b + 7) >> 4
+		out[4+i] = (c*2217 + d*5352 + 12000) >> 16
+		if d != 0 {
+			out[4+i]++
+		}
+		out[12+i] = (d*2217 - c*5352 + 51000) >> 16
+	}
+	return out
+}
+
+// hadamard applies the 4-point Walsh-Hadamard butterfly VP8 uses to four values in v at
+// the given stride.
+func hadamard(v []int, stride int) {
+	x0, x1, x2, x3 := v[0], v[stride], v[2*stride], v[3*stride]
+	v[0] = x0 + x1 + x2 + x3
+	v[stride] = x0 + x1 - x2 - x3
+	v[2*stride] = x0 - x1 - x2 + x3
+	v[3*stride] = x0 - x1 + x2 - x3
+}
+
+// forwardWHT transforms the DC terms of the 16 luma blocks, scaled so that inverseWHT
+// returns them.
+func forwardWHT(in *[16]int) [16]int {
+	out := *in
+	for i := range 4 {
+		hadamard(out[4*i:], 1)
+	}
+	for i := range 4 {
+		hadamard(out[i:], 4)
+	}
+	for i, v := range out {
+		if v < 0 {
+			out[i] = -((-v + 1) >> 1)
+		} else {
+			out[i] = (v + 1) >> 1
+		}
+	}
+	return out
+}
+
+// inverseWHT is the decoder's inverse of forwardWHT (section 14.3).
+func inverseWHT(in *[16]int) [16]int {
+	out := *in
+	for i := range 4 {
+		hadamard(out[i:], 4)
+	}
+	for i := range 4 {
+		hadamard(out[4*i:], 1)
+	}
+	for i, v := range out {
+		out[i] = (v + 3) >> 3
+	}
+	return out
+}
@@ -0,0 +1,119 @@
+// Package webp encodes images as lossy WebP: a single VP8 key frame in a RIFF container,
+// readable by every current browser. It sits beside image/jpeg in spirit, with the same
+// Encode(w, img, options) shape, and exists because the standard library has no WebP encoder.
+package webp
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	"io"
+)
+
+// DefaultQuality is the quality used when Options is nil or leaves Quality unset.
+const DefaultQuality = 75
+
+// maxDimension is the largest width or height a VP8 frame can describe.
+const maxDimension = 1<<14 - 1
+
+// Options are the encoding parameters.
+type Options struct {
+	// Quality ranges from 1 to 100 inclusive; higher is better and larger.
+	Quality int
+}
+
+// Encode writes img to w as a lossy WebP. Transparency is not kept: the format's alpha
+// chunk is not written, so transparent pixels come out black, as they do from image/jpeg.
+func Encode(w io.Writer, img image.Image, o *Options) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= 0 || height <= 0 {
+		return errors.New("webp: empty image")
+	}
+	if width > maxDimension || height > maxDimension {
+		return errors.New("webp: image is too large to encode")
+	}
+	quality := DefaultQuality
+	if o != nil && o.Quality != 0 {
+		quality = min(max(o.Quality, 1), 100)
+	}
+
+	frame := encodeFrame(newYUV(img), width, height, quality)
+
+	// The RIFF container: a "VP8 " chunk inside a WEBP form, each chunk padded to even length.
+	padded := len(frame) + len(frame)&1
+	header := make([]byte, 20)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(4+8+padded))
+	copy(header[8:], "WEBPVP8 ")
+	binary.LittleEndian.PutUint32(header[16:], uint32(len(frame)))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.Write(frame); err != nil {
+		return err
+	}
+	if padded != len(frame) {
+		if _, err := w.Write([]byte{0}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// yuv holds an image as 4:2:0 planes padded out to whole 16x16 macroblocks by repeating
+// the last row and column.
+type yuv struct {
+	mbw, mbh int
+	y, u, v  []uint8
+}
+
+func (p *yuv) yStride() int { return 16 * p.mbw }
+func (p *yuv) cStride() int { return 8 * p.mbw }
+
+// newYUV converts img with the limited-range BT.601 coefficients libwebp uses, which is
+// what browsers assume when they turn a VP8 frame back into RGB.
+func newYUV(img image.Image) *yuv {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+
+	p := &yuv{mbw: (width + 15) / 16, mbh: (height + 15) / 16}
+	p.y = make([]uint8, 16*p.mbw*16*p.mbh)
+	p.u = make([]uint8, 8*p.mbw*8*p.mbh)
+	p.v = make([]uint8, 8*p.mbw*8*p.mbh)
+	pixel := func(x, y int) (r, g, b int) {
+		x, y = min(x, width-1), min(y, height-1)
+		i := y*rgba.Stride + 4*x
+		return int(rgba.Pix[i]), int(rgba.Pix[i+1]), int(rgba.Pix[i+2])
+	}
+	for y := range 16 * p.mbh {
+		for x := range 16 * p.mbw {
+			r, g, b := pixel(x, y)
+			p.y[y*p.yStride()+x] = uint8((16839*r + 33059*g + 6420*b + 16<<16 + 1<<15) >> 16)
+		}
+	}
+	for y := range 8 * p.mbh {
+		for x := range 8 * p.mbw {
+			var r, g, b int
+			for _, d := range [4][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+				pr, pg, pb := pixel(2*x+d[0], 2*y+d[1])
+				r, g, b = r+pr, g+pg, b+pb
+			}
+			// r, g and b are sums of four pixels, hence the two extra bits of shift.
+			p.u[y*p.cStride()+x] = clipChroma(-9719*r - 19081*g + 28800*b)
+			p.v[y*p.cStride()+x] = clipChroma(28800*r - 24116*g - 4684*b)
+		}
+	}
+	return p
+}
+
+func clipChroma(c int) uint8 {
+	return clip8((c + 1<<17 + 128<<18) >> 18)
+}
+
+func clip8(v int) uint8 {
+	return uint8(min(max(v, 0), 255))
+}
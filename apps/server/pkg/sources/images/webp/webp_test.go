@@ -0,0 +1,114 @@
+package webp
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math"
+	"testing"
+
+	xwebp "golang.org/x/image/webp"
+)
+
+// cover paints a stand-in album cover: smooth gradients, a few hard-edged shapes and some
+// fine texture, which between them exercise every prediction mode.
+func cover(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			fx, fy := float64(x)/float64(width), float64(y)/float64(height)
+			c := color.NRGBA{
+				R: uint8(200*fx + 40*math.Sin(fy*9)),
+				G: uint8(60 + 120*fy),
+				B: uint8(128 + 100*math.Sin(fx*fy*12)),
+				A: 0xff,
+			}
+			if (x-width/3)*(x-width/3)+(y-height/3)*(y-height/3) < width*height/25 {
+				c = color.NRGBA{0xf0, 0xe0, 0x30, 0xff}
+			}
+			if x > width*2/3 && y > height/2 && (x/3+y/3)%2 == 0 {
+				c = color.NRGBA{0x10, 0x10, 0x10, 0xff}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// psnr compares two planes over the visible width and height.
+func psnr(a []uint8, aStride int, b []uint8, bStride, width, height int) float64 {
+	var sse float64
+	for y := range height {
+		for x := range width {
+			d := float64(a[y*aStride+x]) - float64(b[y*bStride+x])
+			sse += d * d
+		}
+	}
+	if sse == 0 {
+		return math.Inf(1)
+	}
+	return 10 * math.Log10(255*255*float64(width*height)/sse)
+}
+
+func TestEncodeDecodesToTheSourceImage(t *testing.T) {
+	for _, tc := range []struct {
+		width, height, quality int
+		minPSNR                float64
+	}{
+		{256, 256, 75, 32},
+		{250, 170, 75, 32},
+		{64, 64, 95, 38},
+		{37, 5, 50, 28},
+		{1, 1, 75, 30},
+	} {
+		src := cover(tc.width, tc.height)
+		var buf bytes.Buffer
+		if err := Encode(&buf, src, &Options{Quality: tc.quality}); err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+		decoded, err := xwebp.Decode(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("%dx%d: decoding the output: %v", tc.width, tc.height, err)
+		}
+		got, ok := decoded.(*image.YCbCr)
+		if !ok {
+			t.Fatalf("expected a YCbCr image, got %T", decoded)
+		}
+		if bounds := got.Bounds(); bounds.Dx() != tc.width || bounds.Dy() != tc.height {
+			t.Fatalf("expected %dx%d, got %v", tc.width, tc.height, bounds)
+		}
+		want := newYUV(src)
+		if p := psnr(want.y, want.yStride(), got.Y, got.YStride, tc.width, tc.height); p < tc.minPSNR {
+			t.Errorf("%dx%d at quality %d: luma PSNR %.1f dB, want at least %.0f", tc.width, tc.height, tc.quality, p, tc.minPSNR)
+		}
+		cw, ch := (tc.width+1)/2, (tc.height+1)/2
+		if p := psnr(want.u, want.cStride(), got.Cb, got.CStride, cw, ch); p < tc.minPSNR {
+			t.Errorf("%dx%d at quality %d: Cb PSNR %.1f dB, want at least %.0f", tc.width, tc.height, tc.quality, p, tc.minPSNR)
+		}
+		if p := psnr(want.v, want.cStride(), got.Cr, got.CStride, cw, ch); p < tc.minPSNR {
+			t.Errorf("%dx%d at quality %d: Cr PSNR %.1f dB, want at least %.0f", tc.width, tc.height, tc.quality, p, tc.minPSNR)
+		}
+	}
+}
+
+func TestEncodeIsSmallerThanJPEG(t *testing.T) {
+	src := cover(300, 300)
+	var webpBuf, jpegBuf bytes.Buffer
+	if err := Encode(&webpBuf, src, nil); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if err := jpeg.Encode(&jpegBuf, src, &jpeg.Options{Quality: 82}); err != nil {
+		t.Fatalf("jpeg.Encode: %v", err)
+	}
+	t.Logf("webp %d bytes, jpeg %d bytes", webpBuf.Len(), jpegBuf.Len())
+	if webpBuf.Len() >= jpegBuf.Len() {
+		t.Errorf("expected the WebP (%d bytes) smaller than the JPEG (%d bytes)", webpBuf.Len(), jpegBuf.Len())
+	}
+}
+
+func TestEncodeRefusesEmptyImages(t *testing.T) {
+	if err := Encode(&bytes.Buffer{}, image.NewNRGBA(image.Rect(0, 0, 0, 10)), nil); err == nil {
+		t.Error("expected an error for an empty image")
+	}
+}
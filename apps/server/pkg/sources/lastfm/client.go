@@ -0,0 +1,425 @@
+// Package lastfm reads listener-driven data from the Last.fm API: artist tags and listening
+// stats, similar artists, and album wiki text.
+package lastfm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/metrics"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/useragent"
+)
+
+var (
+	// ErrNotFound indicates Last.fm doesn't know the artist or album.
+	ErrNotFound = errors.New("lastfm: not found")
+	// ErrUnauthorized indicates the API key was rejected or suspended.
+	ErrUnauthorized = errors.New("lastfm: invalid API key")
+	// ErrRateLimit indicates the API key has exceeded its rate limit.
+	ErrRateLimit = errors.New("lastfm: rate limit exceeded")
+)
+
+// Last.fm API error codes, returned in the body of failed calls.
+const (
+	errorInvalidParameters = 6
+	errorInvalidKey        = 10
+	errorSuspendedKey      = 26
+	errorRateLimited       = 29
+)
+
+// maxTags bounds the tags kept per artist or album; Last.fm's long tail is mostly noise.
+const maxTags = 5
+
+// noiseTags are popular Last.fm tags that describe listeners rather than the music.
+var noiseTags = map[string]bool{
+	"seen live":    true,
+	"favorites":    true,
+	"favourites":   true,
+	"favorite":     true,
+	"favourite":    true,
+	"awesome":      true,
+	"love":         true,
+	"albums i own": true,
+}
+
+// Config describes how to connect to the Last.fm API.
+type Config struct {
+	BaseURL   string
+	APIKey    string
+	UserAgent string
+	// HTTP sets the timeout, retries, response cache, and pacing.
+	HTTP httpclient.Options
+}
+
+// Client issues requests against the Last.fm API.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	userAgent  string
+	httpClient *http.Client
+	health     *health.Tracker
+}
+
+// New constructs a Last.fm client. Every call needs an API key, so one is required.
+func New(_ context.Context, cfg Config) (*Client, error) {
+	apiKey := strings.TrimSpace(cfg.APIKey)
+	if apiKey == "" {
+		return nil, errors.New("lastfm: API key is required")
+	}
+
+	baseURL := strings.TrimSpace(cfg.BaseURL)
+	if baseURL == "" {
+		baseURL = "https://ws.audioscrobbler.com/2.0/"
+	}
+
+	userAgent := strings.TrimSpace(cfg.UserAgent)
+	if userAgent == "" {
+		userAgent = useragent.Default()
+	}
+
+	tracker := health.NewTracker()
+	return &Client{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		userAgent:  userAgent,
+		httpClient: httpclient.New("lastfm", cfg.HTTP, 8*time.Second, tracker),
+		health:     tracker,
+	}, nil
+}
+
+// ArtistInfo is what Last.fm knows about an artist. Tags are the most applied ones, lowercased
+// and without noise such as "seen live".
+type ArtistInfo struct {
+	Name      string
+	MBID      string
+	URL       string
+	Listeners int64
+	Playcount int64
+	Tags      []string
+	// Bio is the plain-text summary of the artist's Last.fm wiki, licensed CC BY-SA.
+	Bio string
+}
+
+// SimilarArtist is an artist Last.fm listeners also play. Match runs from 0 to 1.
+type SimilarArtist struct {
+	Name  string
+	MBID  string
+	URL   string
+	Match float64
+}
+
+// AlbumInfo is what Last.fm knows about an album.
+type AlbumInfo struct {
+	Name      string
+	Artist    string
+	MBID      string
+	URL       string
+	Listeners int64
+	Playcount int64
+	Tags      []string
+	// Wiki is the plain-text summary of the album's Last.fm wiki, licensed CC BY-SA.
+	Wiki string
+}
+
+// count decodes Last.fm's numbers, which arrive as strings or numbers depending on the method.
+type count float64
+
+func (c *count) UnmarshalJSON(raw []byte) error {
+	text := strings.Trim(string(raw), `"`)
+	if text == "" || text == "null" {
+		*c = 0
+		return nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return fmt.Errorf("lastfm: invalid number %s", raw)
+	}
+	*c = count(value)
+	return nil
+}
+
+// tagList decodes a {"tag": [...]} block, which Last.fm sends as an empty string when there
+// are no tags and as a single object when there is one.
+type tagList []string
+
+func (t *tagList) UnmarshalJSON(raw []byte) error {
+	var block struct {
+		Tag json.RawMessage `json:"tag"`
+	}
+	if json.Unmarshal(raw, &block) != nil || len(block.Tag) == 0 {
+		*t = nil
+		return nil
+	}
+	type tag struct {
+		Name string `json:"name"`
+	}
+	var tags []tag
+	if err := json.Unmarshal(block.Tag, &tags); err != nil {
+		var single tag
+		if err := json.Unmarshal(block.Tag, &single); err != nil {
+			return err
+		}
+		tags = []tag{single}
+	}
+	*t = nil
+	for _, tag := range tags {
+		*t = append(*t, tag.Name)
+	}
+	return nil
+}
+
+type wiki struct {
+	Summary string `json:"summary"`
+}
+
+type artistInfoResponse struct {
+	Artist struct {
+		Name  string `json:"name"`
+		MBID  string `json:"mbid"`
+		URL   string `json:"url"`
+		Stats struct {
+			Listeners count `json:"listeners"`
+			Playcount count `json:"playcount"`
+		} `json:"stats"`
+		Tags tagList `json:"tags"`
+		Bio  wiki    `json:"bio"`
+	} `json:"artist"`
+}
+
+type similarArtistsResponse struct {
+	SimilarArtists struct {
+		Artist []struct {
+			Name  string `json:"name"`
+			MBID  string `json:"mbid"`
+			URL   string `json:"url"`
+			Match count  `json:"match"`
+		} `json:"artist"`
+	} `json:"similarartists"`
+}
+
+type albumInfoResponse struct {
+	Album struct {
+		Name      string  `json:"name"`
+		Artist    string  `json:"artist"`
+		MBID      string  `json:"mbid"`
+		URL       string  `json:"url"`
+		Listeners count   `json:"listeners"`
+		Playcount count   `json:"playcount"`
+		Tags      tagList `json:"tags"`
+		Wiki      wiki    `json:"wiki"`
+	} `json:"album"`
+}
+
+// GetArtistInfo returns Last.fm's tags, listening stats, and bio for an artist, looked up by
+// MusicBrainz ID and, since Last.fm doesn't know every MBID, by name when that fails.
+func (c *Client) GetArtistInfo(ctx context.Context, mbid, name string) (*ArtistInfo, error) {
+	var resp artistInfoResponse
+	if err := c.lookup(ctx, "artist.getinfo", mbid, url.Values{}, url.Values{"artist": {name}}, &resp); err != nil {
+		return nil, err
+	}
+	found := resp.Artist
+	if found.Name == "" {
+		return nil, ErrNotFound
+	}
+	return &ArtistInfo{
+		Name:      found.Name,
+		MBID:      found.MBID,
+		URL:       found.URL,
+		Listeners: int64(found.Stats.Listeners),
+		Playcount: int64(found.Stats.Playcount),
+		Tags:      cleanTags(found.Tags),
+		Bio:       cleanWiki(found.Bio.Summary),
+	}, nil
+}
+
+// GetSimilarArtists returns up to limit artists Last.fm listeners also play, closest first.
+func (c *Client) GetSimilarArtists(ctx context.Context, mbid, name string, limit int) ([]SimilarArtist, error) {
+	params := url.Values{}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	var resp similarArtistsResponse
+	if err := c.lookup(ctx, "artist.getsimilar", mbid, params, url.Values{"artist": {name}}, &resp); err != nil {
+		return nil, err
+	}
+	similar := make([]SimilarArtist, 0, len(resp.SimilarArtists.Artist))
+	for _, artist := range resp.SimilarArtists.Artist {
+		similar = append(similar, SimilarArtist{Name: artist.Name, MBID: artist.MBID, URL: artist.URL, Match: float64(artist.Match)})
+	}
+	if limit > 0 && len(similar) > limit {
+		similar = similar[:limit]
+	}
+	return similar, nil
+}
+
+// GetAlbumInfo returns Last.fm's tags, listening stats, and wiki summary for an album, looked
+// up by release MBID and then by artist and title.
+func (c *Client) GetAlbumInfo(ctx context.Context, mbid, artistName, albumTitle string) (*AlbumInfo, error) {
+	var resp albumInfoResponse
+	if err := c.lookup(ctx, "album.getinfo", mbid, url.Values{}, url.Values{"artist": {artistName}, "album": {albumTitle}}, &resp); err != nil {
+		return nil, err
+	}
+	found := resp.Album
+	if found.Name == "" {
+		return nil, ErrNotFound
+	}
+	return &AlbumInfo{
+		Name:      found.Name,
+		Artist:    found.Artist,
+		MBID:      found.MBID,
+		URL:       found.URL,
+		Listeners: int64(found.Listeners),
+		Playcount: int64(found.Playcount),
+		Tags:      cleanTags(found.Tags),
+		Wiki:      cleanWiki(found.Wiki.Summary),
+	}, nil
+}
+
+// lookup calls method with params by MBID when there is one, retrying by byName (artist and
+// album names) when Last.fm doesn't know the MBID.
+func (c *Client) lookup(ctx context.Context, method, mbid string, params, byName url.Values, target any) error {
+	if mbid = strings.TrimSpace(mbid); mbid != "" {
+		query := maps.Clone(params)
+		query.Set("mbid", mbid)
+		err := c.call(ctx, method, query, target)
+		if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+	query := maps.Clone(params)
+	query.Set("autocorrect", "1")
+	for key, values := range byName {
+		if strings.TrimSpace(values[0]) == "" {
+			return ErrNotFound
+		}
+		query[key] = values
+	}
+	return c.call(ctx, method, query, target)
+}
+
+// apiError is the body Last.fm sends when a call fails.
+type apiError struct {
+	Error   int    `json:"error"`
+	Message string `json:"message"`
+}
+
+func (c *Client) call(ctx context.Context, method string, params url.Values, target any) error {
+	query := maps.Clone(params)
+	query.Set("method", method)
+	query.Set("api_key", c.apiKey)
+	query.Set("format", "json")
+	endpoint := c.baseURL + "?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("lastfm: build request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("lastfm: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return fmt.Errorf("%w: %w", ErrRateLimit, retry.RateLimited("lastfm", resp))
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("lastfm: unexpected status %d", resp.StatusCode)
+		}
+		metrics.RecordDecodeError("lastfm")
+		return fmt.Errorf("lastfm: decode response: %w", err)
+	}
+
+	// Failed calls carry an error code in the body, with a 200 or an error status.
+	var failure apiError
+	if json.Unmarshal(body, &failure) == nil && failure.Error != 0 {
+		switch failure.Error {
+		case errorInvalidParameters:
+			return ErrNotFound
+		case errorInvalidKey, errorSuspendedKey:
+			return ErrUnauthorized
+		case errorRateLimited:
+			return fmt.Errorf("%w: %w", ErrRateLimit, retry.RateLimited("lastfm", resp))
+		default:
+			return fmt.Errorf("lastfm: error %d: %s", failure.Error, failure.Message)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("lastfm: unexpected status %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		metrics.RecordDecodeError("lastfm")
+		return fmt.Errorf("lastfm: decode response: %w", err)
+	}
+	return nil
+}
+
+// cleanTags lowercases tags, drops noise and duplicates, and keeps the first maxTags.
+func cleanTags(tags []string) []string {
+	var cleaned []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || noiseTags[tag] || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		cleaned = append(cleaned, tag)
+		if len(cleaned) == maxTags {
+			break
+		}
+	}
+	return cleaned
+}
+
+var (
+	// readMoreLink is the "Read more on Last.fm" link ending every wiki summary.
+	readMoreLink = regexp.MustCompile(`(?s)<a [^>]*>\s*Read more on Last\.fm\s*</a>\.?`)
+	htmlTag      = regexp.MustCompile(`<[^>]+>`)
+)
+
+// cleanWiki turns a Last.fm wiki summary into plain text.
+func cleanWiki(summary string) string {
+	text := readMoreLink.ReplaceAllString(summary, "")
+	text = html.UnescapeString(htmlTag.ReplaceAllString(text, ""))
+	return strings.TrimSpace(strings.Join(strings.Fields(text), " "))
+}
+
+// Name identifies this source in warnings.
+func (c *Client) Name() string {
+	return "lastfm"
+}
+
+// Healthy reports whether recent Last.fm calls have been succeeding.
+func (c *Client) Healthy() bool {
+	return c.health.Healthy()
+}
+
+// Health returns the rolling success rate and failure state for Last.fm calls.
+func (c *Client) Health() health.Status {
+	return c.health.Status()
+}
+
+// Ping checks that the Last.fm API is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	return health.Ping(ctx, c.httpClient, c.baseURL, c.userAgent)
+}
@@ -0,0 +1,136 @@
+package lastfm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := New(context.Background(), Config{BaseURL: server.URL + "/2.0/", APIKey: "key"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return client
+}
+
+func TestNewRequiresAPIKey(t *testing.T) {
+	if _, err := New(context.Background(), Config{}); err == nil {
+		t.Fatal("expected an error without an API key")
+	}
+}
+
+func TestGetArtistInfoFallsBackToName(t *testing.T) {
+	var calls []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("api_key") != "key" || query.Get("format") != "json" || query.Get("method") != "artist.getinfo" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		calls = append(calls, query.Get("mbid")+query.Get("artist"))
+		if query.Get("mbid") != "" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": 6, "message": "The artist you supplied could not be found"}`))
+			return
+		}
+		w.Write([]byte(`{"artist": {
+			"name": "Radiohead",
+			"mbid": "a74b1b7f-71a5-4011-9441-d0b5e4122711",
+			"url": "https://www.last.fm/music/Radiohead",
+			"stats": {"listeners": "6151740", "playcount": "1098452345"},
+			"tags": {"tag": [{"name": "alternative"}, {"name": "Alternative Rock"}, {"name": "seen live"}, {"name": "rock"}, {"name": "electronic"}, {"name": "experimental"}, {"name": "britpop"}]},
+			"bio": {"summary": "Radiohead are an English rock band from Abingdon, Oxfordshire. <a href=\"https://www.last.fm/music/Radiohead\">Read more on Last.fm</a>"}
+		}}`))
+	})
+
+	info, err := client.GetArtistInfo(context.Background(), "unknown-mbid", "Radiohead")
+	if err != nil {
+		t.Fatalf("GetArtistInfo: %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "unknown-mbid" || calls[1] != "Radiohead" {
+		t.Fatalf("expected an MBID lookup then a name lookup, got %q", calls)
+	}
+	if info.Listeners != 6151740 || info.Playcount != 1098452345 {
+		t.Errorf("unexpected stats %d/%d", info.Listeners, info.Playcount)
+	}
+	want := []string{"alternative", "alternative rock", "rock", "electronic", "experimental"}
+	if len(info.Tags) != len(want) {
+		t.Fatalf("expected tags %q, got %q", want, info.Tags)
+	}
+	for i := range want {
+		if info.Tags[i] != want[i] {
+			t.Fatalf("expected tags %q, got %q", want, info.Tags)
+		}
+	}
+	if info.Bio != "Radiohead are an English rock band from Abingdon, Oxfordshire." {
+		t.Errorf("unexpected bio %q", info.Bio)
+	}
+}
+
+func TestGetAlbumInfoWithoutTags(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("album") != "Kid A" || r.URL.Query().Get("autocorrect") != "1" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"album": {
+			"name": "Kid A", "artist": "Radiohead", "url": "https://www.last.fm/music/Radiohead/Kid+A",
+			"listeners": 1800000, "playcount": "95000000", "tags": "",
+			"wiki": {"summary": "Kid A is the fourth studio album by &quot;Radiohead&quot;. <a href=\"https://www.last.fm/music/Radiohead/Kid+A\">Read more on Last.fm</a>."}
+		}}`))
+	})
+
+	info, err := client.GetAlbumInfo(context.Background(), "", "Radiohead", "Kid A")
+	if err != nil {
+		t.Fatalf("GetAlbumInfo: %v", err)
+	}
+	if info.Listeners != 1800000 || info.Playcount != 95000000 || len(info.Tags) != 0 {
+		t.Errorf("unexpected album info %+v", info)
+	}
+	if info.Wiki != `Kid A is the fourth studio album by "Radiohead".` {
+		t.Errorf("unexpected wiki %q", info.Wiki)
+	}
+}
+
+func TestGetSimilarArtists(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "2" {
+			t.Errorf("expected limit 2, got %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"similarartists": {"artist": [
+			{"name": "Thom Yorke", "mbid": "8ed2e0b3-aa4c-4e13-bec3-dc7393ed4d6b", "match": "1", "url": "https://www.last.fm/music/Thom+Yorke"},
+			{"name": "Atoms for Peace", "match": 0.61, "url": "https://www.last.fm/music/Atoms+for+Peace"}
+		]}}`))
+	})
+
+	similar, err := client.GetSimilarArtists(context.Background(), "a74b1b7f-71a5-4011-9441-d0b5e4122711", "Radiohead", 2)
+	if err != nil {
+		t.Fatalf("GetSimilarArtists: %v", err)
+	}
+	if len(similar) != 2 || similar[0].Match != 1 || similar[1].Match != 0.61 || similar[1].MBID != "" {
+		t.Fatalf("unexpected similar artists %+v", similar)
+	}
+}
+
+func TestCallMapsErrorCodes(t *testing.T) {
+	tests := []struct {
+		body string
+		want error
+	}{
+		{`{"error": 10, "message": "Invalid API key"}`, ErrUnauthorized},
+		{`{"error": 29, "message": "Rate limit exceeded"}`, ErrRateLimit},
+		{`{"error": 6, "message": "Album not found"}`, ErrNotFound},
+	}
+	for _, tt := range tests {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(tt.body))
+		})
+		if _, err := client.GetAlbumInfo(context.Background(), "", "Radiohead", "Kid A"); !errors.Is(err, tt.want) {
+			t.Errorf("body %s: expected %v, got %v", tt.body, tt.want, err)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package localfiles
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// supportedExtensions lists the audio containers readTags understands.
+var supportedExtensions = map[string]bool{
+	".mp3":  true,
+	".flac": true,
+}
+
+// Config describes which directory the scanner should walk.
+type Config struct {
+	Root string
+}
+
+// Scanner walks a music folder and groups tagged files into albums.
+type Scanner struct {
+	root string
+}
+
+// AlbumTags summarizes the files found for a single album on disk.
+type AlbumTags struct {
+	Artist         string `json:"artist"`
+	Album          string `json:"album"`
+	ArtistID       string `json:"artistId,omitempty"`
+	ReleaseID      string `json:"releaseId,omitempty"`
+	ReleaseGroupID string `json:"releaseGroupId,omitempty"`
+	Path           string `json:"path"`
+	TrackCount     int    `json:"trackCount"`
+}
+
+// New constructs a Scanner rooted at the configured directory.
+func New(cfg Config) (*Scanner, error) {
+	root := strings.TrimSpace(cfg.Root)
+	if root == "" {
+		return nil, errors.New("localfiles: root directory is required")
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, errors.New("localfiles: root must be a directory")
+	}
+	return &Scanner{root: root}, nil
+}
+
+// Scan walks the root directory and returns one entry per distinct album, sorted by artist then title.
+// Unreadable or untagged files are skipped.
+func (s *Scanner) Scan(ctx context.Context) ([]AlbumTags, error) {
+	albums := make(map[string]*AlbumTags)
+
+	err := filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Keep walking past unreadable directories.
+			if d != nil && d.IsDir() && path != s.root {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if d.IsDir() || !supportedExtensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+
+		tags, ok := readFileTags(path)
+		if !ok {
+			return nil
+		}
+
+		artist := tags.AlbumArtist
+		if artist == "" {
+			artist = tags.Artist
+		}
+		artistID := tags.AlbumArtistID
+		if artistID == "" {
+			artistID = tags.ArtistID
+		}
+		if tags.Album == "" && tags.ReleaseGroupID == "" && tags.ReleaseID == "" {
+			return nil
+		}
+
+		key := albumKey(tags, artist)
+		entry, exists := albums[key]
+		if !exists {
+			entry = &AlbumTags{
+				Artist:         artist,
+				Album:          tags.Album,
+				ArtistID:       artistID,
+				ReleaseID:      tags.ReleaseID,
+				ReleaseGroupID: tags.ReleaseGroupID,
+				Path:           filepath.Dir(path),
+			}
+			albums[key] = entry
+		}
+		entry.TrackCount++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]AlbumTags, 0, len(albums))
+	for _, album := range albums {
+		result = append(result, *album)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !strings.EqualFold(result[i].Artist, result[j].Artist) {
+			return strings.ToLower(result[i].Artist) < strings.ToLower(result[j].Artist)
+		}
+		return strings.ToLower(result[i].Album) < strings.ToLower(result[j].Album)
+	})
+	return result, nil
+}
+
+func readFileTags(path string) (Tags, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Tags{}, false
+	}
+	defer file.Close()
+
+	tags, err := readTags(file)
+	if err != nil {
+		return Tags{}, false
+	}
+	return tags, true
+}
+
+// albumKey groups files by the most specific identifier available.
+func albumKey(tags Tags, artist string) string {
+	switch {
+	case tags.ReleaseGroupID != "":
+		return "rg:" + tags.ReleaseGroupID
+	case tags.ReleaseID != "":
+		return "rel:" + tags.ReleaseID
+	default:
+		return "name:" + strings.ToLower(artist) + "\x00" + strings.ToLower(tags.Album)
+	}
+}
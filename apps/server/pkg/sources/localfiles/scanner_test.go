@@ -0,0 +1,130 @@
+package localfiles
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func buildFLAC(comments ...string) []byte {
+	var block bytes.Buffer
+	vendor := "test"
+	binary.Write(&block, binary.LittleEndian, uint32(len(vendor)))
+	block.WriteString(vendor)
+	binary.Write(&block, binary.LittleEndian, uint32(len(comments)))
+	for _, c := range comments {
+		binary.Write(&block, binary.LittleEndian, uint32(len(c)))
+		block.WriteString(c)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("fLaC")
+	// STREAMINFO placeholder followed by the final VORBIS_COMMENT block.
+	out.Write([]byte{0x00, 0x00, 0x00, 0x22})
+	out.Write(make([]byte, 0x22))
+	length := block.Len()
+	out.Write([]byte{0x84, byte(length >> 16), byte(length >> 8), byte(length)})
+	out.Write(block.Bytes())
+	return out.Bytes()
+}
+
+func id3Frame(id string, body []byte) []byte {
+	var frame bytes.Buffer
+	frame.WriteString(id)
+	binary.Write(&frame, binary.BigEndian, uint32(len(body)))
+	frame.Write([]byte{0, 0})
+	frame.Write(body)
+	return frame.Bytes()
+}
+
+func buildID3v23(frames ...[]byte) []byte {
+	var body bytes.Buffer
+	for _, f := range frames {
+		body.Write(f)
+	}
+	size := body.Len()
+	var out bytes.Buffer
+	out.WriteString("ID3")
+	out.Write([]byte{3, 0, 0})
+	out.Write([]byte{byte(size >> 21 & 0x7f), byte(size >> 14 & 0x7f), byte(size >> 7 & 0x7f), byte(size & 0x7f)})
+	out.Write(body.Bytes())
+	return out.Bytes()
+}
+
+func TestReadTagsFLAC(t *testing.T) {
+	raw := buildFLAC(
+		"ARTIST=Nirvana",
+		"ALBUM=Nevermind",
+		"MUSICBRAINZ_RELEASEGROUPID=1b022e01-4da6-387b-8658-8678046e4cef",
+	)
+
+	tags, err := readTags(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("readTags returned error: %v", err)
+	}
+	if tags.Artist != "Nirvana" || tags.Album != "Nevermind" {
+		t.Errorf("unexpected artist/album %q/%q", tags.Artist, tags.Album)
+	}
+	if tags.ReleaseGroupID != "1b022e01-4da6-387b-8658-8678046e4cef" {
+		t.Errorf("unexpected release group id %q", tags.ReleaseGroupID)
+	}
+}
+
+func TestReadTagsID3v23(t *testing.T) {
+	raw := buildID3v23(
+		id3Frame("TPE1", append([]byte{3}, "Slowdive"...)),
+		id3Frame("TALB", append([]byte{0}, "Souvlaki"...)),
+		id3Frame("TXXX", append([]byte{3}, "MusicBrainz Album Id\x00release-1"...)),
+	)
+
+	tags, err := readTags(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("readTags returned error: %v", err)
+	}
+	if tags.Artist != "Slowdive" || tags.Album != "Souvlaki" {
+		t.Errorf("unexpected artist/album %q/%q", tags.Artist, tags.Album)
+	}
+	if tags.ReleaseID != "release-1" {
+		t.Errorf("unexpected release id %q", tags.ReleaseID)
+	}
+}
+
+func TestScanGroupsTracksByAlbum(t *testing.T) {
+	root := t.TempDir()
+	albumDir := filepath.Join(root, "Nirvana", "Nevermind")
+	if err := os.MkdirAll(albumDir, 0o755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+
+	track := buildFLAC("ARTIST=Nirvana", "ALBUM=Nevermind")
+	for _, name := range []string{"01.flac", "02.flac"} {
+		if err := os.WriteFile(filepath.Join(albumDir, name), track, 0o644); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(albumDir, "cover.jpg"), []byte("jpeg"), 0o644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	scanner, err := New(Config{Root: root})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	albums, err := scanner.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+	if len(albums) != 1 {
+		t.Fatalf("expected 1 album, got %d", len(albums))
+	}
+	if albums[0].TrackCount != 2 {
+		t.Errorf("expected 2 tracks, got %d", albums[0].TrackCount)
+	}
+	if albums[0].Path != albumDir {
+		t.Errorf("expected path %q, got %q", albumDir, albums[0].Path)
+	}
+}
@@ -0,0 +1,293 @@
+package localfiles
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// errNoTags indicates the file carried no metadata block we know how to read.
+var errNoTags = errors.New("localfiles: no supported tags")
+
+// Tags captures the subset of audio metadata used to match files against MusicBrainz.
+type Tags struct {
+	Artist         string
+	AlbumArtist    string
+	Album          string
+	ArtistID       string
+	AlbumArtistID  string
+	ReleaseID      string
+	ReleaseGroupID string
+}
+
+// readTags dispatches on the file signature rather than the extension, so misnamed files still parse.
+func readTags(r io.Reader) (Tags, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(4)
+	if err != nil {
+		return Tags{}, errNoTags
+	}
+
+	switch {
+	case bytes.Equal(magic[:3], []byte("ID3")):
+		return readID3v2(br)
+	case bytes.Equal(magic, []byte("fLaC")):
+		return readFLAC(br)
+	default:
+		return Tags{}, errNoTags
+	}
+}
+
+// readID3v2 parses ID3v2.3 and ID3v2.4 text frames.
+func readID3v2(r io.Reader) (Tags, error) {
+	header := make([]byte, 10)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return Tags{}, errNoTags
+	}
+	version := header[3]
+	if version != 3 && version != 4 {
+		return Tags{}, errNoTags
+	}
+
+	size := syncsafe(header[6:10])
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return Tags{}, errNoTags
+	}
+
+	// Skip the extended header when present.
+	if header[5]&0x40 != 0 && len(body) >= 4 {
+		extSize := int(binary.BigEndian.Uint32(body[:4]))
+		if version == 4 {
+			extSize = syncsafe(body[:4])
+		} else {
+			extSize += 4
+		}
+		if extSize > len(body) {
+			return Tags{}, errNoTags
+		}
+		body = body[extSize:]
+	}
+
+	var tags Tags
+	for len(body) >= 10 {
+		id := string(body[:4])
+		if id[0] == 0 {
+			break // padding
+		}
+		frameSize := int(binary.BigEndian.Uint32(body[4:8]))
+		if version == 4 {
+			frameSize = syncsafe(body[4:8])
+		}
+		if frameSize <= 0 || 10+frameSize > len(body) {
+			break
+		}
+		frame := body[10 : 10+frameSize]
+		body = body[10+frameSize:]
+
+		switch id {
+		case "TPE1":
+			tags.Artist = decodeID3Text(frame)
+		case "TPE2":
+			tags.AlbumArtist = decodeID3Text(frame)
+		case "TALB":
+			tags.Album = decodeID3Text(frame)
+		case "TXXX":
+			desc, value := splitTXXX(frame)
+			tags.applyMusicBrainz(desc, value)
+		}
+	}
+
+	if tags.empty() {
+		return Tags{}, errNoTags
+	}
+	return tags, nil
+}
+
+func syncsafe(b []byte) int {
+	return int(b[0]&0x7f)<<21 | int(b[1]&0x7f)<<14 | int(b[2]&0x7f)<<7 | int(b[3]&0x7f)
+}
+
+func splitTXXX(frame []byte) (string, string) {
+	if len(frame) < 2 {
+		return "", ""
+	}
+	encoding := frame[0]
+	parts := splitTerminated(frame[1:], encoding)
+	if len(parts) < 2 {
+		return "", ""
+	}
+	return decodeID3String(parts[0], encoding), decodeID3String(parts[1], encoding)
+}
+
+// splitTerminated splits a description/value pair on the encoding-specific null terminator.
+func splitTerminated(b []byte, encoding byte) [][]byte {
+	if encoding == 1 || encoding == 2 {
+		for i := 0; i+1 < len(b); i += 2 {
+			if b[i] == 0 && b[i+1] == 0 {
+				return [][]byte{b[:i], b[i+2:]}
+			}
+		}
+		return [][]byte{b}
+	}
+	return bytes.SplitN(b, []byte{0}, 2)
+}
+
+func decodeID3Text(frame []byte) string {
+	if len(frame) < 1 {
+		return ""
+	}
+	value := decodeID3String(frame[1:], frame[0])
+	// v2.4 allows multiple null-separated values; keep the first.
+	if idx := strings.IndexByte(value, 0); idx >= 0 {
+		value = value[:idx]
+	}
+	return strings.TrimSpace(value)
+}
+
+func decodeID3String(b []byte, encoding byte) string {
+	switch encoding {
+	case 0: // ISO-8859-1
+		runes := make([]rune, 0, len(b))
+		for _, c := range b {
+			if c == 0 {
+				break
+			}
+			runes = append(runes, rune(c))
+		}
+		return string(runes)
+	case 1, 2: // UTF-16 with BOM, UTF-16BE
+		bigEndian := encoding == 2
+		if len(b) >= 2 {
+			switch {
+			case b[0] == 0xFF && b[1] == 0xFE:
+				bigEndian = false
+				b = b[2:]
+			case b[0] == 0xFE && b[1] == 0xFF:
+				bigEndian = true
+				b = b[2:]
+			}
+		}
+		units := make([]uint16, 0, len(b)/2)
+		for i := 0; i+1 < len(b); i += 2 {
+			var u uint16
+			if bigEndian {
+				u = binary.BigEndian.Uint16(b[i:])
+			} else {
+				u = binary.LittleEndian.Uint16(b[i:])
+			}
+			if u == 0 {
+				break
+			}
+			units = append(units, u)
+		}
+		return string(utf16.Decode(units))
+	default: // UTF-8
+		return strings.TrimRight(string(b), "\x00")
+	}
+}
+
+// readFLAC walks FLAC metadata blocks looking for the Vorbis comment block.
+func readFLAC(r io.Reader) (Tags, error) {
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return Tags{}, errNoTags
+	}
+
+	blockHeader := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(r, blockHeader); err != nil {
+			return Tags{}, errNoTags
+		}
+		last := blockHeader[0]&0x80 != 0
+		blockType := blockHeader[0] & 0x7f
+		length := int(blockHeader[1])<<16 | int(blockHeader[2])<<8 | int(blockHeader[3])
+
+		if blockType == 4 {
+			block := make([]byte, length)
+			if _, err := io.ReadFull(r, block); err != nil {
+				return Tags{}, errNoTags
+			}
+			return parseVorbisComments(block)
+		}
+		if _, err := io.CopyN(io.Discard, r, int64(length)); err != nil {
+			return Tags{}, errNoTags
+		}
+		if last {
+			return Tags{}, errNoTags
+		}
+	}
+}
+
+func parseVorbisComments(block []byte) (Tags, error) {
+	if len(block) < 8 {
+		return Tags{}, errNoTags
+	}
+	vendorLen := int(binary.LittleEndian.Uint32(block))
+	offset := 4 + vendorLen
+	if offset+4 > len(block) {
+		return Tags{}, errNoTags
+	}
+	count := int(binary.LittleEndian.Uint32(block[offset:]))
+	offset += 4
+
+	var tags Tags
+	for i := 0; i < count && offset+4 <= len(block); i++ {
+		length := int(binary.LittleEndian.Uint32(block[offset:]))
+		offset += 4
+		if offset+length > len(block) {
+			break
+		}
+		key, value, ok := strings.Cut(string(block[offset:offset+length]), "=")
+		offset += length
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch strings.ToUpper(key) {
+		case "ARTIST":
+			tags.Artist = value
+		case "ALBUMARTIST":
+			tags.AlbumArtist = value
+		case "ALBUM":
+			tags.Album = value
+		case "MUSICBRAINZ_ARTISTID":
+			tags.ArtistID = value
+		case "MUSICBRAINZ_ALBUMARTISTID":
+			tags.AlbumArtistID = value
+		case "MUSICBRAINZ_ALBUMID":
+			tags.ReleaseID = value
+		case "MUSICBRAINZ_RELEASEGROUPID":
+			tags.ReleaseGroupID = value
+		}
+	}
+
+	if tags.empty() {
+		return Tags{}, errNoTags
+	}
+	return tags, nil
+}
+
+// applyMusicBrainz maps Picard's TXXX descriptions onto MBID fields.
+func (t *Tags) applyMusicBrainz(desc, value string) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(strings.TrimSpace(desc)) {
+	case "musicbrainz artist id":
+		t.ArtistID = value
+	case "musicbrainz album artist id":
+		t.AlbumArtistID = value
+	case "musicbrainz album id":
+		t.ReleaseID = value
+	case "musicbrainz release group id":
+		t.ReleaseGroupID = value
+	}
+}
+
+func (t Tags) empty() bool {
+	return t == Tags{}
+}
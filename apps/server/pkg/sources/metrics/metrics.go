@@ -0,0 +1,210 @@
+// Package metrics records per-source upstream latency histograms and categorized error
+// counters, and renders them in the Prometheus text exposition format.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Category classifies an upstream failure so dashboards can tell slowness from errors.
+type Category string
+
+const (
+	CategoryTimeout     Category = "timeout"
+	CategoryNotFound    Category = "not_found"
+	CategoryRateLimited Category = "rate_limited"
+	CategoryServerError Category = "server_error"
+	CategoryDecode      Category = "decode"
+	CategoryTransport   Category = "transport"
+)
+
+// DefaultBuckets are the request duration histogram upper bounds, in seconds.
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Default is the registry upstream transports record into and /metrics serves.
+var Default = NewRegistry(DefaultBuckets)
+
+// Registry holds metrics for every upstream source seen so far.
+type Registry struct {
+	mu      sync.Mutex
+	buckets []float64
+	sources map[string]*sourceMetrics
+}
+
+type sourceMetrics struct {
+	bucketCounts []uint64
+	count        uint64
+	sum          float64
+	errors       map[Category]uint64
+}
+
+// NewRegistry constructs an empty registry with the given histogram buckets.
+func NewRegistry(buckets []float64) *Registry {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &Registry{buckets: sorted, sources: make(map[string]*sourceMetrics)}
+}
+
+func (r *Registry) source(name string) *sourceMetrics {
+	m, ok := r.sources[name]
+	if !ok {
+		m = &sourceMetrics{bucketCounts: make([]uint64, len(r.buckets)), errors: make(map[Category]uint64)}
+		r.sources[name] = m
+	}
+	return m
+}
+
+// ObserveDuration adds one upstream call to the source's duration histogram.
+func (r *Registry) ObserveDuration(source string, elapsed time.Duration) {
+	seconds := elapsed.Seconds()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.source(source)
+	m.count++
+	m.sum += seconds
+	for i, bound := range r.buckets {
+		if seconds <= bound {
+			m.bucketCounts[i]++
+		}
+	}
+}
+
+// RecordError counts one failure of the given category against source.
+func (r *Registry) RecordError(source string, category Category) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.source(source).errors[category]++
+}
+
+// RecordDecodeError counts a response body that could not be decoded on the default registry.
+func RecordDecodeError(source string) {
+	Default.RecordError(source, CategoryDecode)
+}
+
+// Classify maps a round trip outcome to an error category. It reports false for successful
+// responses and for calls abandoned because the caller's context was canceled.
+func Classify(ctx context.Context, resp *http.Response, err error) (Category, bool) {
+	if err != nil {
+		var netErr net.Error
+		switch {
+		case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return CategoryTimeout, true
+		case errors.As(err, &netErr) && netErr.Timeout():
+			return CategoryTimeout, true
+		case errors.Is(ctx.Err(), context.Canceled):
+			return "", false
+		default:
+			return CategoryTransport, true
+		}
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return CategoryNotFound, true
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return CategoryRateLimited, true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return CategoryServerError, true
+	}
+	return "", false
+}
+
+// WritePrometheus renders the registry in the Prometheus text exposition format.
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.sources))
+	for name := range r.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var err error
+	printf := func(format string, args ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("# HELP freqshow_upstream_request_duration_seconds Upstream request latency by source.\n")
+	printf("# TYPE freqshow_upstream_request_duration_seconds histogram\n")
+	for _, name := range names {
+		m := r.sources[name]
+		for i, bound := range r.buckets {
+			printf("freqshow_upstream_request_duration_seconds_bucket{source=%q,le=%q} %d\n", name, formatFloat(bound), m.bucketCounts[i])
+		}
+		printf("freqshow_upstream_request_duration_seconds_bucket{source=%q,le=\"+Inf\"} %d\n", name, m.count)
+		printf("freqshow_upstream_request_duration_seconds_sum{source=%q} %s\n", name, formatFloat(m.sum))
+		printf("freqshow_upstream_request_duration_seconds_count{source=%q} %d\n", name, m.count)
+	}
+
+	printf("# HELP freqshow_upstream_errors_total Upstream failures by source and category.\n")
+	printf("# TYPE freqshow_upstream_errors_total counter\n")
+	for _, name := range names {
+		m := r.sources[name]
+		categories := make([]string, 0, len(m.errors))
+		for category := range m.errors {
+			categories = append(categories, string(category))
+		}
+		sort.Strings(categories)
+		for _, category := range categories {
+			printf("freqshow_upstream_errors_total{source=%q,category=%q} %d\n", name, category, m.errors[Category(category)])
+		}
+	}
+	return err
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// Handler serves the registry for Prometheus scrapes.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = r.WritePrometheus(w)
+	})
+}
+
+// Transport wraps base so every round trip is timed and classified under source on the
+// default registry.
+func Transport(source string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{registry: Default, source: source, base: base}
+}
+
+type transport struct {
+	registry *Registry
+	source   string
+	base     http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	category, failed := Classify(req.Context(), resp, err)
+	if err != nil && !failed {
+		// Abandoned by the caller; the latency says nothing about the upstream.
+		return resp, err
+	}
+	t.registry.ObserveDuration(t.source, time.Since(start))
+	if failed {
+		t.registry.RecordError(t.source, category)
+	}
+	return resp, err
+}
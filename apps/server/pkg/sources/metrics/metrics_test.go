@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClassify(t *testing.T) {
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		status int
+		err    error
+		want   Category
+		failed bool
+	}{
+		{"ok", context.Background(), http.StatusOK, nil, "", false},
+		{"not found", context.Background(), http.StatusNotFound, nil, CategoryNotFound, true},
+		{"rate limited", context.Background(), http.StatusTooManyRequests, nil, CategoryRateLimited, true},
+		{"server error", context.Background(), http.StatusBadGateway, nil, CategoryServerError, true},
+		{"timeout", expired, 0, context.DeadlineExceeded, CategoryTimeout, true},
+		{"canceled", canceled, 0, context.Canceled, "", false},
+		{"transport", context.Background(), 0, errors.New("connection refused"), CategoryTransport, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp *http.Response
+			if tt.err == nil {
+				resp = &http.Response{StatusCode: tt.status}
+			}
+			got, failed := Classify(tt.ctx, resp, tt.err)
+			if got != tt.want || failed != tt.failed {
+				t.Fatalf("Classify = (%q, %v), want (%q, %v)", got, failed, tt.want, tt.failed)
+			}
+		})
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	registry := NewRegistry([]float64{0.1, 1})
+	registry.ObserveDuration("musicbrainz", 50*time.Millisecond)
+	registry.ObserveDuration("musicbrainz", 500*time.Millisecond)
+	registry.RecordError("wikipedia", CategoryServerError)
+
+	var buf bytes.Buffer
+	if err := registry.WritePrometheus(&buf); err != nil {
+		t.Fatalf("WritePrometheus: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`freqshow_upstream_request_duration_seconds_bucket{source="musicbrainz",le="0.1"} 1`,
+		`freqshow_upstream_request_duration_seconds_bucket{source="musicbrainz",le="1"} 2`,
+		`freqshow_upstream_request_duration_seconds_bucket{source="musicbrainz",le="+Inf"} 2`,
+		`freqshow_upstream_request_duration_seconds_count{source="musicbrainz"} 2`,
+		`freqshow_upstream_errors_total{source="wikipedia",category="server_error"} 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestTransportRecordsStatusCategories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	registry := NewRegistry(DefaultBuckets)
+	client := &http.Client{Transport: &transport{registry: registry, source: "discogs", base: http.DefaultTransport}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+
+	var buf bytes.Buffer
+	_ = registry.WritePrometheus(&buf)
+	if !strings.Contains(buf.String(), `freqshow_upstream_errors_total{source="discogs",category="rate_limited"} 1`) {
+		t.Fatalf("expected rate limited error to be counted:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,42 @@
+package musicbrainz
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// benchmarkRelease builds a release payload with discs media of tracks tracks each, every
+// track carrying a two-artist credit.
+func benchmarkRelease(b *testing.B, discs, tracks int) releaseResponse {
+	b.Helper()
+	var media []string
+	for d := 1; d <= discs; d++ {
+		var items []string
+		for t := 1; t <= tracks; t++ {
+			items = append(items, fmt.Sprintf(`{"position":%d,"number":"%d","title":"Track %d-%d","length":%d,"id":"track-%d-%d",
+				"recording":{"id":"rec-%d-%d","title":"Track %d-%d","length":%d},
+				"artist-credit":[{"name":"Lead","joinphrase":" feat. ","artist":{"id":"lead","name":"Lead"}},{"name":"Guest","artist":{"id":"guest","name":"Guest"}}]}`,
+				t, t, d, t, 200000+t, d, t, d, t, d, t, 200000+t))
+		}
+		media = append(media, fmt.Sprintf(`{"position":%d,"tracks":[%s]}`, d, strings.Join(items, ",")))
+	}
+
+	var payload releaseResponse
+	if err := json.Unmarshal([]byte(`{"id":"release","media":[`+strings.Join(media, ",")+`]}`), &payload); err != nil {
+		b.Fatalf("unmarshal: %v", err)
+	}
+	return payload
+}
+
+func BenchmarkTransformReleaseTracks(b *testing.B) {
+	payload := benchmarkRelease(b, 2, 20)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if tracks := transformReleaseTracks(payload); len(tracks) != 40 {
+			b.Fatalf("got %d tracks, want 40", len(tracks))
+		}
+	}
+}
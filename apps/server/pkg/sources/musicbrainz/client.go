@@ -2,15 +2,22 @@ package musicbrainz
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/useragent"
 )
 
 // ErrNotFound indicates the requested resource was not present in MusicBrainz.
@@ -32,7 +39,9 @@ type Config struct {
 	AppName    string
 	AppVersion string
 	Contact    string
-	Timeout    time.Duration
+	Validation ValidationMode
+	// HTTP sets the timeout, retries, cache, and pacing; MusicBrainz allows 1 request per second.
+	HTTP httpclient.Options
 }
 
 // Client issues requests against the MusicBrainz API.
@@ -40,6 +49,8 @@ type Client struct {
 	baseURL    string
 	userAgent  string
 	httpClient *http.Client
+	validation ValidationMode
+	health     *health.Tracker
 }
 
 // New constructs a MusicBrainz API client using the supplied configuration.
@@ -47,37 +58,25 @@ func New(_ context.Context, cfg Config) (*Client, error) {
 	if strings.TrimSpace(cfg.BaseURL) == "" {
 		return nil, errors.New("musicbrainz: base URL is required")
 	}
-	if cfg.Timeout <= 0 {
-		cfg.Timeout = 5 * time.Second
-	}
-
 	contact := strings.TrimSpace(cfg.Contact)
 	if contact == "" {
 		return nil, errors.New("musicbrainz: contact information is required")
 	}
 
-	name := strings.TrimSpace(cfg.AppName)
-	if name == "" {
-		name = "freq-show"
-	}
-	version := strings.TrimSpace(cfg.AppVersion)
-	if version == "" {
-		version = "dev"
-	}
-
 	baseURL := strings.TrimRight(cfg.BaseURL, "/")
 	if _, err := url.Parse(baseURL); err != nil {
 		return nil, fmt.Errorf("musicbrainz: invalid base URL %q: %w", cfg.BaseURL, err)
 	}
 
-	userAgent := fmt.Sprintf("%s/%s (%s)", name, version, contact)
+	userAgent := useragent.Info{Name: cfg.AppName, Version: cfg.AppVersion, Contact: contact}.String()
 
+	tracker := health.NewTracker()
 	return &Client{
-		baseURL:   baseURL,
-		userAgent: userAgent,
-		httpClient: &http.Client{
-			Timeout: cfg.Timeout,
-		},
+		baseURL:    baseURL,
+		userAgent:  userAgent,
+		httpClient: httpclient.New("musicbrainz", cfg.HTTP, 5*time.Second, tracker),
+		validation: cfg.Validation,
+		health:     tracker,
 	}, nil
 }
 
@@ -89,8 +88,23 @@ type Artist struct {
 	Type           string   `json:"type,omitempty"`
 	Disambiguation string   `json:"disambiguation,omitempty"`
 	Aliases        []string `json:"aliases,omitempty"`
-	Tags           []string `json:"tags,omitempty"`
-	LifeSpan       LifeSpan `json:"lifeSpan"`
+	// Genres are the artist's community-voted genres from MusicBrainz's curated list, most
+	// votes first.
+	Genres []string `json:"genres,omitempty"`
+	// Tags are the artist's free-form tags that read as genres, with nationalities, roles,
+	// and the like left out.
+	Tags        []string         `json:"tags,omitempty"`
+	LifeSpan    LifeSpan         `json:"lifeSpan"`
+	Relations   []URLRelation    `json:"relations,omitempty"`
+	Memberships []ArtistRelation `json:"memberships,omitempty"`
+	// Associations are the artist's other artist-artist relationships, such as collaborations,
+	// subgroups, and the person behind a stage name.
+	Associations []ArtistRelation `json:"associations,omitempty"`
+	// LocalizedNames maps a language code ("ja", "de") to the artist's name in that language,
+	// taken from aliases MusicBrainz tags with a locale.
+	LocalizedNames map[string]string `json:"localizedNames,omitempty"`
+	// Score is the search relevance from 0 to 100; lookups leave it zero.
+	Score int `json:"score,omitempty"`
 }
 
 // ReleaseGroup models an album (release group) payload from MusicBrainz.
@@ -101,12 +115,32 @@ type ReleaseGroup struct {
 	SecondaryTypes   []string       `json:"secondaryTypes"`
 	FirstReleaseDate string         `json:"firstReleaseDate"`
 	ArtistCredit     []ArtistCredit `json:"artistCredit"`
+	Score            int            `json:"score,omitempty"`
+	Relations        []URLRelation  `json:"relations,omitempty"`
+	// Genres are the release group's community-voted genres, most votes first.
+	Genres []string `json:"genres,omitempty"`
 }
 
-// ArtistCredit represents a contributing artist on a release group.
+// ArtistCredit represents a contributing artist on a release group. Name is the name as
+// credited, and JoinPhrase the text (" feat. ", " & ") printed between it and the next credit.
 type ArtistCredit struct {
-	Name   string             `json:"name"`
-	Artist ReleaseGroupArtist `json:"artist"`
+	Name       string             `json:"name"`
+	Artist     ReleaseGroupArtist `json:"artist"`
+	JoinPhrase string             `json:"joinPhrase,omitempty"`
+}
+
+// JoinCredits renders credits as MusicBrainz prints them, e.g. "Jay-Z & Kanye West".
+func JoinCredits(credits []ArtistCredit) string {
+	var b strings.Builder
+	for _, credit := range credits {
+		name := credit.Name
+		if name == "" {
+			name = credit.Artist.Name
+		}
+		b.WriteString(name)
+		b.WriteString(credit.JoinPhrase)
+	}
+	return strings.TrimSpace(b.String())
 }
 
 // ReleaseGroupArtist represents artist details within a credit block.
@@ -131,17 +165,19 @@ type Release struct {
 	Tracks []Track `json:"tracks"`
 }
 
-// Track represents a single track/recording within a release.
+// Track represents a single track/recording within a release. Length is in milliseconds.
 type Track struct {
 	Number    int    `json:"number"`
 	Title     string `json:"title"`
-	Length    string `json:"length"`
+	Length    int    `json:"length"`
 	ID        string `json:"id"`
 	Recording struct {
 		ID     string `json:"id"`
 		Title  string `json:"title"`
 		Length int    `json:"length"`
 	} `json:"recording"`
+	// ArtistCredit is the track's own credit, which may differ from the release group's.
+	ArtistCredit []ArtistCredit `json:"artistCredit,omitempty"`
 }
 
 type artistResponse struct {
@@ -151,13 +187,17 @@ type artistResponse struct {
 	Type           string `json:"type"`
 	Disambiguation string `json:"disambiguation"`
 	Aliases        []struct {
-		Name string `json:"name"`
+		Name    string `json:"name"`
+		Locale  string `json:"locale"`
+		Primary bool   `json:"primary"`
 	} `json:"aliases"`
 	Tags []struct {
 		Name  string `json:"name"`
 		Count int    `json:"count"`
 	} `json:"tags"`
-	LifeSpan LifeSpan `json:"life-span"`
+	Genres    []genreResponse    `json:"genres"`
+	LifeSpan  LifeSpan           `json:"life-span"`
+	Relations []relationResponse `json:"relations"`
 }
 
 type releaseGroupResponse struct {
@@ -178,7 +218,18 @@ type releaseGroupResponse struct {
 			ID   string `json:"id"`
 			Name string `json:"name"`
 		} `json:"artist"`
+		JoinPhrase string `json:"joinphrase"`
 	} `json:"artist-credit"`
+	Relations []relationResponse `json:"relations"`
+	Genres    []genreResponse    `json:"genres"`
+}
+
+// genreResponse is one entry of the genres array returned when inc=genres is requested.
+type genreResponse struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Count          int    `json:"count"`
+	Disambiguation string `json:"disambiguation"`
 }
 
 type releaseResponse struct {
@@ -199,6 +250,7 @@ type releaseResponse struct {
 				Title  string `json:"title"`
 				Length int    `json:"length"`
 			} `json:"recording"`
+			ArtistCredit []ArtistCredit `json:"artist-credit"`
 		} `json:"tracks"`
 	} `json:"media"`
 }
@@ -210,7 +262,7 @@ func (c *Client) LookupArtist(ctx context.Context, id string) (*Artist, error) {
 		return nil, errors.New("musicbrainz: artist id is required")
 	}
 
-	endpoint := fmt.Sprintf("%s/artist/%s?fmt=json&inc=tags", c.baseURL, url.PathEscape(trimmed))
+	endpoint := fmt.Sprintf("%s/artist/%s?fmt=json&inc=aliases+genres+tags+url-rels+artist-rels", c.baseURL, url.PathEscape(trimmed))
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf(errRequestBuildFailed, err)
@@ -227,23 +279,37 @@ func (c *Client) LookupArtist(ctx context.Context, id string) (*Artist, error) {
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var payload artistResponse
-		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-			return nil, fmt.Errorf(errDecodeFailed, err)
+		if err := c.decode(ctx, resp.Body, &payload); err != nil {
+			return nil, err
 		}
 		return transformArtist(payload), nil
 	case http.StatusNotFound:
 		return nil, ErrNotFound
 	default:
-		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		return nil, fmt.Errorf(errUnexpectedStatus, resp.StatusCode, strings.TrimSpace(string(snippet)))
+		return nil, statusError(resp)
 	}
 }
 
 func transformArtist(payload artistResponse) *Artist {
 	aliases := make([]string, 0, len(payload.Aliases))
+	var localized map[string]string
 	for _, alias := range payload.Aliases {
-		if alias.Name != "" {
-			aliases = append(aliases, alias.Name)
+		if alias.Name == "" {
+			continue
+		}
+		aliases = append(aliases, alias.Name)
+
+		// Locales may carry a region ("en_US"); names are keyed by language, and the primary
+		// alias for a locale wins over any other.
+		language, _, _ := strings.Cut(strings.ToLower(alias.Locale), "_")
+		if language == "" {
+			continue
+		}
+		if localized == nil {
+			localized = make(map[string]string)
+		}
+		if _, seen := localized[language]; !seen || alias.Primary {
+			localized[language] = alias.Name
 		}
 	}
 
@@ -262,8 +328,13 @@ func transformArtist(payload artistResponse) *Artist {
 		Type:           payload.Type,
 		Disambiguation: payload.Disambiguation,
 		Aliases:        aliases,
+		LocalizedNames: localized,
+		Genres:         transformGenres(payload.Genres),
 		Tags:           tags,
 		LifeSpan:       payload.LifeSpan,
+		Relations:      transformURLRelations(payload.Relations),
+		Memberships:    transformMemberships(payload.Relations),
+		Associations:   transformAssociations(payload.Relations),
 	}
 }
 
@@ -337,7 +408,7 @@ func (c *Client) LookupReleaseGroup(ctx context.Context, id string) (*ReleaseGro
 		return nil, errors.New("musicbrainz: release group id is required")
 	}
 
-	endpoint := fmt.Sprintf("%s/release-group/%s?fmt=json&inc=artists+releases", c.baseURL, url.PathEscape(trimmed))
+	endpoint := fmt.Sprintf("%s/release-group/%s?fmt=json&inc=artists+releases+url-rels+genres", c.baseURL, url.PathEscape(trimmed))
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf(errRequestBuildFailed, err)
@@ -354,15 +425,14 @@ func (c *Client) LookupReleaseGroup(ctx context.Context, id string) (*ReleaseGro
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var payload releaseGroupResponse
-		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-			return nil, fmt.Errorf(errDecodeFailed, err)
+		if err := c.decode(ctx, resp.Body, &payload); err != nil {
+			return nil, err
 		}
 		return transformReleaseGroup(payload), nil
 	case http.StatusNotFound:
 		return nil, ErrNotFound
 	default:
-		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		return nil, fmt.Errorf(errUnexpectedStatus, resp.StatusCode, strings.TrimSpace(string(snippet)))
+		return nil, statusError(resp)
 	}
 }
 
@@ -411,15 +481,14 @@ func (c *Client) fetchReleaseGroupWithReleases(ctx context.Context, releaseGroup
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var payload releaseGroupResponse
-		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-			return nil, fmt.Errorf(errDecodeFailed, err)
+		if err := c.decode(ctx, resp.Body, &payload); err != nil {
+			return nil, err
 		}
 		return &payload, nil
 	case http.StatusNotFound:
 		return nil, ErrNotFound
 	default:
-		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		return nil, fmt.Errorf(errUnexpectedStatus, resp.StatusCode, strings.TrimSpace(string(snippet)))
+		return nil, statusError(resp)
 	}
 }
 
@@ -446,7 +515,7 @@ func (c *Client) selectBestRelease(releases []struct {
 
 // getReleaseRecordings gets the track/recording data for a specific release.
 func (c *Client) getReleaseRecordings(ctx context.Context, releaseID string) ([]Track, error) {
-	endpoint := fmt.Sprintf("%s/release/%s?fmt=json&inc=recordings", c.baseURL, url.PathEscape(releaseID))
+	endpoint := fmt.Sprintf("%s/release/%s?fmt=json&inc=recordings+artist-credits", c.baseURL, url.PathEscape(releaseID))
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf(errRequestBuildFailed, err)
@@ -463,15 +532,14 @@ func (c *Client) getReleaseRecordings(ctx context.Context, releaseID string) ([]
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var payload releaseResponse
-		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-			return nil, fmt.Errorf(errDecodeFailed, err)
+		if err := c.decode(ctx, resp.Body, &payload); err != nil {
+			return nil, err
 		}
 		return transformReleaseTracks(payload), nil
 	case http.StatusNotFound:
 		return nil, ErrNotFound
 	default:
-		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		return nil, fmt.Errorf(errUnexpectedStatus, resp.StatusCode, strings.TrimSpace(string(snippet)))
+		return nil, statusError(resp)
 	}
 }
 
@@ -484,6 +552,7 @@ func transformReleaseGroup(payload releaseGroupResponse) *ReleaseGroup {
 				ID:   credit.Artist.ID,
 				Name: credit.Artist.Name,
 			},
+			JoinPhrase: credit.JoinPhrase,
 		})
 	}
 
@@ -494,20 +563,37 @@ func transformReleaseGroup(payload releaseGroupResponse) *ReleaseGroup {
 		SecondaryTypes:   append([]string(nil), payload.SecondaryTypes...),
 		FirstReleaseDate: payload.FirstReleaseDate,
 		ArtistCredit:     credits,
+		Relations:        transformURLRelations(payload.Relations),
+		Genres:           transformGenres(payload.Genres),
+	}
+}
+
+// transformGenres orders genres by vote count, keeping MusicBrainz order for ties.
+func transformGenres(genres []genreResponse) []string {
+	if len(genres) == 0 {
+		return nil
+	}
+	sorted := append([]genreResponse(nil), genres...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Count > sorted[j].Count
+	})
+	names := make([]string, 0, len(sorted))
+	for _, genre := range sorted {
+		if genre.Name != "" {
+			names = append(names, genre.Name)
+		}
 	}
+	return names
 }
 
 func transformReleaseTracks(payload releaseResponse) []Track {
 	var allTracks []Track
 	for _, medium := range payload.Media {
 		for _, track := range medium.Tracks {
-			// Convert track length from milliseconds to MM:SS format
-			length := ""
-			if track.Length > 0 {
-				seconds := track.Length / 1000
-				minutes := seconds / 60
-				remainingSeconds := seconds % 60
-				length = fmt.Sprintf("%d:%02d", minutes, remainingSeconds)
+			// Fall back to the recording length when the track itself has none.
+			length := track.Length
+			if length <= 0 {
+				length = track.Recording.Length
 			}
 
 			// Parse track number (handle string to int conversion)
@@ -533,12 +619,23 @@ func transformReleaseTracks(payload releaseResponse) []Track {
 					Title:  track.Recording.Title,
 					Length: track.Recording.Length,
 				},
+				ArtistCredit: append([]ArtistCredit(nil), track.ArtistCredit...),
 			})
 		}
 	}
 	return allTracks
 }
 
+// statusError describes an unexpected response. MusicBrainz answers 503 when a client exceeds
+// its rate limit, so 503s are reported as rate limiting along with 429s.
+func statusError(resp *http.Response) error {
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		return retry.RateLimited("musicbrainz", resp)
+	}
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf(errUnexpectedStatus, resp.StatusCode, strings.TrimSpace(string(snippet)))
+}
+
 // PrimaryArtistID returns the ID of the first credited artist, if present.
 func (r *ReleaseGroup) PrimaryArtistID() string {
 	for _, credit := range r.ArtistCredit {
@@ -562,16 +659,10 @@ func (r *ReleaseGroup) PrimaryArtistName() string {
 	return ""
 }
 
-// ReleaseYear attempts to parse the release year from the first release date.
-func (r *ReleaseGroup) ReleaseYear() int {
-	if len(r.FirstReleaseDate) < 4 {
-		return 0
-	}
-	year, err := strconv.Atoi(r.FirstReleaseDate[:4])
-	if err != nil {
-		return 0
-	}
-	return year
+// ReleaseDate parses the first release date into a structured partial date.
+// Malformed dates yield the zero value.
+func (r *ReleaseGroup) ReleaseDate() data.PartialDate {
+	return data.PartialDateOf(r.FirstReleaseDate)
 }
 
 // SearchResult represents a search result container from MusicBrainz.
@@ -638,13 +729,12 @@ func (c *Client) SearchArtists(ctx context.Context, query string, limit int, off
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var payload searchResponse
-		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-			return nil, fmt.Errorf(errDecodeFailed, err)
+		if err := c.decode(ctx, resp.Body, &payload); err != nil {
+			return nil, err
 		}
 		return transformSearchResult(payload), nil
 	default:
-		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		return nil, fmt.Errorf(errUnexpectedStatus, resp.StatusCode, strings.TrimSpace(string(snippet)))
+		return nil, statusError(resp)
 	}
 }
 
@@ -666,6 +756,7 @@ func transformSearchResult(payload searchResponse) *SearchResult {
 			Disambiguation: item.Disambiguation,
 			Aliases:        aliases,
 			LifeSpan:       item.LifeSpan,
+			Score:          item.Score,
 		})
 	}
 
@@ -690,13 +781,28 @@ type releaseGroupSearchResponse struct {
 		PrimaryType      string   `json:"primary-type"`
 		SecondaryTypes   []string `json:"secondary-types"`
 		FirstReleaseDate string   `json:"first-release-date"`
+		ArtistCredit     []struct {
+			Name   string `json:"name"`
+			Artist struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"artist"`
+			JoinPhrase string `json:"joinphrase"`
+		} `json:"artist-credit"`
 	} `json:"release-groups"`
 	Count  int `json:"release-group-count"`
 	Offset int `json:"release-group-offset"`
 }
 
-// GetArtistReleaseGroups retrieves the release groups (albums) for a given artist.
+// GetArtistReleaseGroups retrieves the artist's albums and EPs (DefaultReleaseGroupTypes).
 func (c *Client) GetArtistReleaseGroups(ctx context.Context, artistID string, limit int, offset int) (*ReleaseGroupSearchResult, error) {
+	return c.GetArtistReleaseGroupsOfTypes(ctx, artistID, DefaultReleaseGroupTypes, limit, offset)
+}
+
+// GetArtistReleaseGroupsOfTypes retrieves the artist's release groups of the given types.
+// MusicBrainz filters by primary type; secondary types are filtered here, so a page may hold
+// fewer than limit release groups while Count still reports MusicBrainz's primary-type total.
+func (c *Client) GetArtistReleaseGroupsOfTypes(ctx context.Context, artistID string, types ReleaseGroupTypes, limit int, offset int) (*ReleaseGroupSearchResult, error) {
 	trimmed := strings.TrimSpace(artistID)
 	if trimmed == "" {
 		return nil, errors.New("musicbrainz: artist id is required")
@@ -716,7 +822,8 @@ func (c *Client) GetArtistReleaseGroups(ctx context.Context, artistID string, li
 	params.Set("fmt", "json")
 	params.Set("limit", strconv.Itoa(limit))
 	params.Set("offset", strconv.Itoa(offset))
-	params.Set("type", "album|ep") // Focus on main releases
+	params.Set("type", strings.Join(types.primary(), "|"))
+	params.Set("inc", "artist-credits")
 
 	endpoint := fmt.Sprintf("%s/release-group?artist=%s&%s", c.baseURL, url.QueryEscape(trimmed), params.Encode())
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
@@ -735,37 +842,282 @@ func (c *Client) GetArtistReleaseGroups(ctx context.Context, artistID string, li
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var payload releaseGroupSearchResponse
-		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-			return nil, fmt.Errorf(errDecodeFailed, err)
+		if err := c.decode(ctx, resp.Body, &payload); err != nil {
+			return nil, err
 		}
-		return transformReleaseGroupSearchResult(payload, artistID), nil
+		result := transformReleaseGroupSearchResult(payload, artistID)
+		result.ReleaseGroups = slices.DeleteFunc(result.ReleaseGroups, func(rg ReleaseGroup) bool {
+			return !types.Includes(rg.PrimaryType, rg.SecondaryTypes)
+		})
+		return result, nil
 	default:
-		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		return nil, fmt.Errorf(errUnexpectedStatus, resp.StatusCode, strings.TrimSpace(string(snippet)))
+		return nil, statusError(resp)
 	}
 }
 
 func transformReleaseGroupSearchResult(payload releaseGroupSearchResponse, artistID string) *ReleaseGroupSearchResult {
 	releaseGroups := make([]ReleaseGroup, 0, len(payload.ReleaseGroups))
 	for _, item := range payload.ReleaseGroups {
-		// Create a basic artist credit for the known artist
-		artistCredit := []ArtistCredit{
-			{
-				Name: "", // We don't have the artist name in this response
+		artistCredit := make([]ArtistCredit, 0, len(item.ArtistCredit))
+		for _, credit := range item.ArtistCredit {
+			artistCredit = append(artistCredit, ArtistCredit{
+				Name:       credit.Name,
+				Artist:     ReleaseGroupArtist{ID: credit.Artist.ID, Name: credit.Artist.Name},
+				JoinPhrase: credit.JoinPhrase,
+			})
+		}
+		if len(artistCredit) == 0 {
+			// Older responses omit credits; fall back to the artist we browsed by.
+			artistCredit = append(artistCredit, ArtistCredit{
+				Artist: ReleaseGroupArtist{ID: artistID},
+			})
+		}
+
+		releaseGroups = append(releaseGroups, ReleaseGroup{
+			ID:               item.ID,
+			Title:            item.Title,
+			PrimaryType:      item.PrimaryType,
+			SecondaryTypes:   append([]string(nil), item.SecondaryTypes...),
+			FirstReleaseDate: item.FirstReleaseDate,
+			ArtistCredit:     artistCredit,
+		})
+	}
+
+	return &ReleaseGroupSearchResult{
+		ReleaseGroups: releaseGroups,
+		Count:         payload.Count,
+		Offset:        payload.Offset,
+	}
+}
+
+// Recording models a MusicBrainz recording (a distinct audio track).
+type Recording struct {
+	ID             string         `json:"id"`
+	Title          string         `json:"title"`
+	Disambiguation string         `json:"disambiguation,omitempty"`
+	Length         int            `json:"length"`
+	ArtistCredit   []ArtistCredit `json:"artistCredit"`
+	ISRCs          []string       `json:"isrcs,omitempty"`
+	// FirstReleaseDate is the earliest release date of any release with the recording; only
+	// lookups set it.
+	FirstReleaseDate string `json:"firstReleaseDate,omitempty"`
+	Score            int    `json:"score"`
+}
+
+// PrimaryArtistName returns the display name of the first credited artist, if present.
+func (r *Recording) PrimaryArtistName() string {
+	for _, credit := range r.ArtistCredit {
+		if credit.Artist.Name != "" {
+			return credit.Artist.Name
+		}
+		if credit.Name != "" {
+			return credit.Name
+		}
+	}
+	return ""
+}
+
+// RecordingSearchResult represents a recording search result container from MusicBrainz.
+type RecordingSearchResult struct {
+	Recordings []Recording `json:"recordings"`
+	Offset     int         `json:"offset"`
+	Count      int         `json:"count"`
+}
+
+type recordingSearchResponse struct {
+	Recordings []struct {
+		ID           string   `json:"id"`
+		Title        string   `json:"title"`
+		Length       int      `json:"length"`
+		Score        int      `json:"score"`
+		ISRCs        []string `json:"isrcs"`
+		ArtistCredit []struct {
+			Name   string `json:"name"`
+			Artist struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"artist"`
+			JoinPhrase string `json:"joinphrase"`
+		} `json:"artist-credit"`
+	} `json:"recordings"`
+	Offset int `json:"offset"`
+	Count  int `json:"count"`
+}
+
+// SearchRecordings runs a Lucene-syntax recording search (e.g. `isrc:USSM19000001` or
+// `recording:"Title" AND artist:"Name"`).
+func (c *Client) SearchRecordings(ctx context.Context, query string, limit int, offset int) (*RecordingSearchResult, error) {
+	trimmed := strings.TrimSpace(query)
+	if trimmed == "" {
+		return nil, errors.New("musicbrainz: search query is required")
+	}
+
+	if limit <= 0 {
+		limit = 25
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	params := url.Values{}
+	params.Set("query", trimmed)
+	params.Set("fmt", "json")
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("offset", strconv.Itoa(offset))
+
+	endpoint := fmt.Sprintf("%s/recording/?%s", c.baseURL, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errRequestBuildFailed, err)
+	}
+	req.Header.Set(headerUserAgent, c.userAgent)
+	req.Header.Set(headerAccept, contentTypeJSON)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(errRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var payload recordingSearchResponse
+		if err := c.decode(ctx, resp.Body, &payload); err != nil {
+			return nil, err
+		}
+		return transformRecordingSearchResult(payload), nil
+	default:
+		return nil, statusError(resp)
+	}
+}
+
+func transformRecordingSearchResult(payload recordingSearchResponse) *RecordingSearchResult {
+	recordings := make([]Recording, 0, len(payload.Recordings))
+	for _, item := range payload.Recordings {
+		credits := make([]ArtistCredit, 0, len(item.ArtistCredit))
+		for _, credit := range item.ArtistCredit {
+			credits = append(credits, ArtistCredit{
+				Name: credit.Name,
 				Artist: ReleaseGroupArtist{
-					ID:   artistID,
-					Name: "",
+					ID:   credit.Artist.ID,
+					Name: credit.Artist.Name,
 				},
-			},
+				JoinPhrase: credit.JoinPhrase,
+			})
+		}
+		recordings = append(recordings, Recording{
+			ID:           item.ID,
+			Title:        item.Title,
+			Length:       item.Length,
+			ArtistCredit: credits,
+			ISRCs:        append([]string(nil), item.ISRCs...),
+			Score:        item.Score,
+		})
+	}
+
+	return &RecordingSearchResult{
+		Recordings: recordings,
+		Offset:     payload.Offset,
+		Count:      payload.Count,
+	}
+}
+
+type releaseGroupQueryResponse struct {
+	ReleaseGroups []struct {
+		ID               string   `json:"id"`
+		Title            string   `json:"title"`
+		PrimaryType      string   `json:"primary-type"`
+		SecondaryTypes   []string `json:"secondary-types"`
+		FirstReleaseDate string   `json:"first-release-date"`
+		Score            int      `json:"score"`
+		ArtistCredit     []struct {
+			Name   string `json:"name"`
+			Artist struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"artist"`
+			JoinPhrase string `json:"joinphrase"`
+		} `json:"artist-credit"`
+	} `json:"release-groups"`
+	Offset int `json:"offset"`
+	Count  int `json:"count"`
+}
+
+// SearchReleaseGroups runs a Lucene-syntax release group search (e.g. `releasegroup:"Title" AND artist:"Name"`
+// or `reid:<release mbid>`).
+func (c *Client) SearchReleaseGroups(ctx context.Context, query string, limit int, offset int) (*ReleaseGroupSearchResult, error) {
+	trimmed := strings.TrimSpace(query)
+	if trimmed == "" {
+		return nil, errors.New("musicbrainz: search query is required")
+	}
+
+	if limit <= 0 {
+		limit = 25
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	params := url.Values{}
+	params.Set("query", trimmed)
+	params.Set("fmt", "json")
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("offset", strconv.Itoa(offset))
+
+	endpoint := fmt.Sprintf("%s/release-group/?%s", c.baseURL, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errRequestBuildFailed, err)
+	}
+	req.Header.Set(headerUserAgent, c.userAgent)
+	req.Header.Set(headerAccept, contentTypeJSON)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(errRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var payload releaseGroupQueryResponse
+		if err := c.decode(ctx, resp.Body, &payload); err != nil {
+			return nil, err
 		}
+		return transformReleaseGroupQueryResult(payload), nil
+	default:
+		return nil, statusError(resp)
+	}
+}
 
+func transformReleaseGroupQueryResult(payload releaseGroupQueryResponse) *ReleaseGroupSearchResult {
+	releaseGroups := make([]ReleaseGroup, 0, len(payload.ReleaseGroups))
+	for _, item := range payload.ReleaseGroups {
+		credits := make([]ArtistCredit, 0, len(item.ArtistCredit))
+		for _, credit := range item.ArtistCredit {
+			credits = append(credits, ArtistCredit{
+				Name: credit.Name,
+				Artist: ReleaseGroupArtist{
+					ID:   credit.Artist.ID,
+					Name: credit.Artist.Name,
+				},
+				JoinPhrase: credit.JoinPhrase,
+			})
+		}
 		releaseGroups = append(releaseGroups, ReleaseGroup{
 			ID:               item.ID,
 			Title:            item.Title,
 			PrimaryType:      item.PrimaryType,
 			SecondaryTypes:   append([]string(nil), item.SecondaryTypes...),
 			FirstReleaseDate: item.FirstReleaseDate,
-			ArtistCredit:     artistCredit,
+			ArtistCredit:     credits,
+			Score:            item.Score,
 		})
 	}
 
@@ -775,3 +1127,18 @@ func transformReleaseGroupSearchResult(payload releaseGroupSearchResponse, artis
 		Offset:        payload.Offset,
 	}
 }
+
+// Healthy reports whether recent MusicBrainz calls have been succeeding.
+func (c *Client) Healthy() bool {
+	return c.health.Healthy()
+}
+
+// Health returns the rolling success rate and failure state for MusicBrainz calls.
+func (c *Client) Health() health.Status {
+	return c.health.Status()
+}
+
+// Ping checks that MusicBrainz is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	return health.Ping(ctx, c.httpClient, c.baseURL+"/genre/all?fmt=json&limit=1", c.userAgent)
+}
@@ -0,0 +1,216 @@
+package musicbrainz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+)
+
+func TestTransformArtistLocalizedNames(t *testing.T) {
+	raw := `{"id":"b10bbbfc-cf9e-42e0-be17-e2c3e1d2600d","name":"The Beatles","aliases":[
+		{"name":"Beatles","locale":null,"primary":false},
+		{"name":"ザ・ビートルズ","locale":"ja","primary":false},
+		{"name":"ビートルズ","locale":"ja","primary":true},
+		{"name":"Die Beatles","locale":"de_DE","primary":false}
+	]}`
+	var payload artistResponse
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	artist := transformArtist(payload)
+	if len(artist.Aliases) != 4 {
+		t.Errorf("expected every alias to be kept, got %v", artist.Aliases)
+	}
+	want := map[string]string{"ja": "ビートルズ", "de": "Die Beatles"}
+	if len(artist.LocalizedNames) != len(want) {
+		t.Fatalf("expected %v, got %v", want, artist.LocalizedNames)
+	}
+	for language, name := range want {
+		if got := artist.LocalizedNames[language]; got != name {
+			t.Errorf("LocalizedNames[%q] = %q, want %q", language, got, name)
+		}
+	}
+}
+
+func TestTransformArtistOrdersGenresByVotes(t *testing.T) {
+	raw := `{"id":"a74b1b7f-71a5-4011-9441-d0b5e4122711","name":"Radiohead",
+		"genres":[{"name":"art rock","count":8},{"name":"alternative rock","count":12}],
+		"tags":[{"name":"alternative rock","count":12},{"name":"british","count":5},{"name":"experimental","count":3}]}`
+	var payload artistResponse
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	artist := transformArtist(payload)
+	if len(artist.Genres) != 2 || artist.Genres[0] != "alternative rock" || artist.Genres[1] != "art rock" {
+		t.Errorf("expected genres most voted first, got %q", artist.Genres)
+	}
+	if len(artist.Tags) != 2 || artist.Tags[1] != "experimental" {
+		t.Errorf("expected non-genre tags dropped, got %q", artist.Tags)
+	}
+}
+
+func TestTransformArtistSeparatesAssociationsFromMemberships(t *testing.T) {
+	raw := `{"id":"a74b1b7f-71a5-4011-9441-d0b5e4122711","name":"Radiohead","relations":[
+		{"type":"member of band","target-type":"artist","direction":"backward","artist":{"id":"m1","name":"Thom Yorke"}},
+		{"type":"collaboration","target-type":"artist","direction":"backward","artist":{"id":"c1","name":"The Smile"}},
+		{"type":"subgroup","target-type":"artist","direction":"forward","artist":{"id":"c1","name":"The Smile"}},
+		{"type":"official homepage","target-type":"url","url":{"resource":"https://radiohead.com"}}
+	]}`
+	var payload artistResponse
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	artist := transformArtist(payload)
+	if len(artist.Memberships) != 1 || artist.Memberships[0].ArtistID != "m1" {
+		t.Errorf("expected one membership, got %+v", artist.Memberships)
+	}
+	if len(artist.Associations) != 1 || artist.Associations[0].ArtistID != "c1" || artist.Associations[0].Type != "collaboration" {
+		t.Errorf("expected the collaboration once, got %+v", artist.Associations)
+	}
+}
+
+func TestTransformReleaseGroupJoinPhrases(t *testing.T) {
+	raw := `{"id":"rg","title":"Watch the Throne","artist-credit":[
+		{"name":"JAY Z","joinphrase":" & ","artist":{"id":"jay","name":"JAY-Z"}},
+		{"name":"Kanye West","joinphrase":"","artist":{"id":"kanye","name":"Kanye West"}}
+	]}`
+	var payload releaseGroupResponse
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	rg := transformReleaseGroup(payload)
+	if len(rg.ArtistCredit) != 2 || rg.ArtistCredit[0].JoinPhrase != " & " {
+		t.Fatalf("expected ordered credits with join phrases, got %+v", rg.ArtistCredit)
+	}
+	if got := JoinCredits(rg.ArtistCredit); got != "JAY Z & Kanye West" {
+		t.Errorf("JoinCredits = %q", got)
+	}
+}
+
+func TestTransformReleaseTracksKeepsTrackCredits(t *testing.T) {
+	raw := `{"id":"r","media":[{"position":1,"tracks":[{"position":1,"title":"Otis","artist-credit":[
+		{"name":"JAY Z","joinphrase":" & ","artist":{"id":"jay","name":"JAY-Z"}},
+		{"name":"Kanye West","joinphrase":" feat. ","artist":{"id":"kanye","name":"Kanye West"}},
+		{"name":"Otis Redding","artist":{"id":"otis","name":"Otis Redding"}}
+	]}]}]}`
+	var payload releaseResponse
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	tracks := transformReleaseTracks(payload)
+	if len(tracks) != 1 || len(tracks[0].ArtistCredit) != 3 {
+		t.Fatalf("expected the track credit, got %+v", tracks)
+	}
+	if got := JoinCredits(tracks[0].ArtistCredit); got != "JAY Z & Kanye West feat. Otis Redding" {
+		t.Errorf("JoinCredits = %q", got)
+	}
+}
+
+func TestLookupArtistReportsRateLimiting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL, AppName: "test", AppVersion: "1.0", Contact: "test@example.com", HTTP: httpclient.Options{Retry: retry.Policy{MaxAttempts: 1}}})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	_, err = client.LookupArtist(context.Background(), "artist")
+	var limited *retry.RateLimitedError
+	if !errors.As(err, &limited) {
+		t.Fatalf("expected a rate-limit error, got %v", err)
+	}
+	if limited.Source != "musicbrainz" || limited.RetryAfter != 3*time.Second {
+		t.Errorf("unexpected rate-limit error %+v", limited)
+	}
+}
+
+func TestLookupArtistRetriesBusyResponses(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"artist","name":"Radiohead"}`))
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{
+		BaseURL: server.URL,
+		Contact: "test@example.com",
+		HTTP:    httpclient.Options{Retry: retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}},
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	artist, err := client.LookupArtist(context.Background(), "artist")
+	if err != nil {
+		t.Fatalf("expected the lookup to succeed once MusicBrainz recovers, got %v", err)
+	}
+	if artist.Name != "Radiohead" || calls != 3 {
+		t.Errorf("got %q after %d calls, want Radiohead after 3", artist.Name, calls)
+	}
+}
+
+func TestSearchReleaseGroups(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/release-group/" || query.Get("query") != "nevermind" || query.Get("limit") != "100" || query.Get("offset") != "0" {
+			t.Errorf("unexpected request %s", r.URL.String())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"count": 42,
+			"offset": 0,
+			"release-groups": [{
+				"id": "rg-1",
+				"title": "Nevermind",
+				"primary-type": "Album",
+				"first-release-date": "1991-09-24",
+				"score": 100,
+				"artist-credit": [{"name": "Nirvana", "artist": {"id": "artist-1", "name": "Nirvana"}}]
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL, AppName: "test", AppVersion: "1.0", Contact: "test@example.com", Validation: ValidationReject})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	result, err := client.SearchReleaseGroups(context.Background(), " nevermind ", 500, -1)
+	if err != nil {
+		t.Fatalf("SearchReleaseGroups returned error: %v", err)
+	}
+	if result.Count != 42 || len(result.ReleaseGroups) != 1 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if rg := result.ReleaseGroups[0]; rg.Title != "Nevermind" || rg.Score != 100 || JoinCredits(rg.ArtistCredit) != "Nirvana" {
+		t.Errorf("unexpected release group %+v", rg)
+	}
+
+	if _, err := client.SearchReleaseGroups(context.Background(), "  ", 10, 0); err == nil {
+		t.Error("expected an error for an empty query")
+	}
+}
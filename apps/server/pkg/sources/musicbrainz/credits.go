@@ -0,0 +1,154 @@
+package musicbrainz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// creditRelationTypes are the artist relationship types treated as production and engineering
+// credits. Performer relationships (instrument, vocal) are left out.
+var creditRelationTypes = map[string]bool{
+	"producer":    true,
+	"engineer":    true,
+	"audio":       true,
+	"sound":       true,
+	"recording":   true,
+	"mix":         true,
+	"mastering":   true,
+	"programming": true,
+	"arranger":    true,
+}
+
+// Credit is one artist's production or engineering role on a release. Tracks lists the track
+// numbers the credit applies to; it is empty when the credit covers the whole release.
+type Credit struct {
+	ArtistID   string   `json:"artistId"`
+	ArtistName string   `json:"artistName"`
+	Type       string   `json:"type"`
+	Attributes []string `json:"attributes,omitempty"`
+	Tracks     []int    `json:"tracks,omitempty"`
+}
+
+type releaseCreditsResponse struct {
+	ID        string             `json:"id"`
+	Relations []relationResponse `json:"relations"`
+	Media     []struct {
+		Tracks []struct {
+			Position  int    `json:"position"`
+			Number    string `json:"number"`
+			Recording struct {
+				ID        string             `json:"id"`
+				Relations []relationResponse `json:"relations"`
+			} `json:"recording"`
+		} `json:"tracks"`
+	} `json:"media"`
+}
+
+func (p *releaseCreditsResponse) validate() error {
+	return requireID("release", p.ID)
+}
+
+// GetReleaseGroupCredits lists production and engineering credits from the same representative
+// release used for track listings, combining release-level and per-recording relationships.
+func (c *Client) GetReleaseGroupCredits(ctx context.Context, releaseGroupID string) ([]Credit, error) {
+	trimmed := strings.TrimSpace(releaseGroupID)
+	if trimmed == "" {
+		return nil, errors.New("musicbrainz: release group id is required")
+	}
+
+	releaseID, err := c.findRepresentativeRelease(ctx, trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("musicbrainz: failed to find representative release: %w", err)
+	}
+	if releaseID == "" {
+		return nil, nil
+	}
+
+	endpoint := fmt.Sprintf("%s/release/%s?fmt=json&inc=artist-rels+recordings+recording-level-rels", c.baseURL, url.PathEscape(releaseID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errRequestBuildFailed, err)
+	}
+	req.Header.Set(headerUserAgent, c.userAgent)
+	req.Header.Set(headerAccept, contentTypeJSON)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(errRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var payload releaseCreditsResponse
+		if err := c.decode(ctx, resp.Body, &payload); err != nil {
+			return nil, err
+		}
+		return transformCredits(payload), nil
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, statusError(resp)
+	}
+}
+
+// transformCredits merges release-level and recording-level credits per artist and role. A
+// release-level credit covers every track, so it absorbs any per-track credits for the same role.
+func transformCredits(payload releaseCreditsResponse) []Credit {
+	var credits []Credit
+	index := make(map[string]int)
+	wholeRelease := make(map[string]bool)
+
+	add := func(rel relationResponse, track int) {
+		if rel.TargetType != "artist" || rel.Artist == nil || rel.Artist.ID == "" || !creditRelationTypes[rel.Type] {
+			return
+		}
+		attributes := append([]string(nil), rel.Attributes...)
+		sort.Strings(attributes)
+		key := rel.Artist.ID + "|" + rel.Type + "|" + strings.Join(attributes, ",")
+
+		i, ok := index[key]
+		if !ok {
+			i = len(credits)
+			index[key] = i
+			credits = append(credits, Credit{
+				ArtistID:   rel.Artist.ID,
+				ArtistName: rel.Artist.Name,
+				Type:       rel.Type,
+				Attributes: attributes,
+			})
+		}
+		switch {
+		case track == 0:
+			wholeRelease[key] = true
+			credits[i].Tracks = nil
+		case !wholeRelease[key]:
+			credits[i].Tracks = append(credits[i].Tracks, track)
+		}
+	}
+
+	for _, rel := range payload.Relations {
+		add(rel, 0)
+	}
+	for _, medium := range payload.Media {
+		for _, track := range medium.Tracks {
+			number := track.Position
+			if number == 0 {
+				number, _ = strconv.Atoi(track.Number)
+			}
+			if number == 0 {
+				continue
+			}
+			for _, rel := range track.Recording.Relations {
+				add(rel, number)
+			}
+		}
+	}
+	return credits
+}
@@ -0,0 +1,70 @@
+package musicbrainz
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetReleaseGroupCredits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/release-group/rg-1":
+			w.Write([]byte(`{
+				"id": "rg-1",
+				"title": "Nevermind",
+				"first-release-date": "1991-09-24",
+				"releases": [{"id": "rel-bootleg", "status": "Bootleg"}, {"id": "rel-1", "status": "Official"}]
+			}`))
+		case "/release/rel-1":
+			if got := r.URL.Query().Get("inc"); got != "artist-rels recordings recording-level-rels" {
+				t.Errorf("unexpected inc %q", got)
+			}
+			w.Write([]byte(`{
+				"id": "rel-1",
+				"relations": [
+					{"type": "producer", "target-type": "artist", "artist": {"id": "butch", "name": "Butch Vig"}},
+					{"type": "mastering", "target-type": "artist", "artist": {"id": "howie", "name": "Howie Weinberg"}},
+					{"type": "design", "target-type": "artist", "artist": {"id": "robert", "name": "Robert Fisher"}}
+				],
+				"media": [{"tracks": [
+					{"position": 1, "number": "1", "recording": {"id": "rec-1", "relations": [
+						{"type": "producer", "target-type": "artist", "artist": {"id": "butch", "name": "Butch Vig"}},
+						{"type": "mix", "target-type": "artist", "artist": {"id": "andy", "name": "Andy Wallace"}}
+					]}},
+					{"position": 2, "number": "2", "recording": {"id": "rec-2", "relations": [
+						{"type": "mix", "target-type": "artist", "artist": {"id": "andy", "name": "Andy Wallace"}},
+						{"type": "instrument", "target-type": "artist", "artist": {"id": "kurt", "name": "Kurt Cobain"}}
+					]}}
+				]}]
+			}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL, AppName: "test", AppVersion: "1.0", Contact: "test@example.com", Validation: ValidationReject})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	credits, err := client.GetReleaseGroupCredits(context.Background(), "rg-1")
+	if err != nil {
+		t.Fatalf("GetReleaseGroupCredits returned error: %v", err)
+	}
+	if len(credits) != 3 {
+		t.Fatalf("expected 3 credits, got %+v", credits)
+	}
+	if credits[0].ArtistID != "butch" || credits[0].Type != "producer" || len(credits[0].Tracks) != 0 {
+		t.Errorf("expected release-wide producer credit, got %+v", credits[0])
+	}
+	if credits[1].ArtistID != "howie" || credits[1].Type != "mastering" {
+		t.Errorf("unexpected mastering credit %+v", credits[1])
+	}
+	if credits[2].ArtistID != "andy" || len(credits[2].Tracks) != 2 || credits[2].Tracks[0] != 1 || credits[2].Tracks[1] != 2 {
+		t.Errorf("expected per-track mix credit, got %+v", credits[2])
+	}
+}
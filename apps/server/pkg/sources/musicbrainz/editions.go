@@ -0,0 +1,171 @@
+package musicbrainz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxEditions caps the releases fetched for a release group; MusicBrainz browse pages top out at 100.
+const maxEditions = 100
+
+// Edition is one concrete release of a release group (a pressing, reissue, or digital version).
+type Edition struct {
+	ID         string         `json:"id"`
+	Title      string         `json:"title"`
+	Status     string         `json:"status,omitempty"`
+	Date       string         `json:"date,omitempty"`
+	Country    string         `json:"country,omitempty"`
+	Barcode    string         `json:"barcode,omitempty"`
+	Formats    []string       `json:"formats,omitempty"`
+	Labels     []EditionLabel `json:"labels,omitempty"`
+	TrackCount int            `json:"trackCount,omitempty"`
+}
+
+// EditionLabel is a label credit on a release, with its catalog number.
+type EditionLabel struct {
+	ID            string `json:"id,omitempty"`
+	Name          string `json:"name"`
+	CatalogNumber string `json:"catalogNumber,omitempty"`
+}
+
+// Format summarizes the release media, e.g. "CD", "2×12\" Vinyl", or "CD + DVD".
+func (e Edition) Format() string {
+	if len(e.Formats) == 0 {
+		return ""
+	}
+	var (
+		order  []string
+		counts = make(map[string]int)
+	)
+	for _, format := range e.Formats {
+		if format == "" {
+			format = "Unknown"
+		}
+		if counts[format] == 0 {
+			order = append(order, format)
+		}
+		counts[format]++
+	}
+	parts := make([]string, 0, len(order))
+	for _, format := range order {
+		if counts[format] > 1 {
+			parts = append(parts, strconv.Itoa(counts[format])+"×"+format)
+			continue
+		}
+		parts = append(parts, format)
+	}
+	return strings.Join(parts, " + ")
+}
+
+type releaseListResponse struct {
+	Releases []struct {
+		ID        string `json:"id"`
+		Title     string `json:"title"`
+		Status    string `json:"status"`
+		Date      string `json:"date"`
+		Country   string `json:"country"`
+		Barcode   string `json:"barcode"`
+		LabelInfo []struct {
+			CatalogNumber string `json:"catalog-number"`
+			Label         *struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"label"`
+		} `json:"label-info"`
+		Media []struct {
+			Format     string `json:"format"`
+			TrackCount int    `json:"track-count"`
+		} `json:"media"`
+	} `json:"releases"`
+	Count int `json:"release-count"`
+}
+
+func (p *releaseListResponse) validate() error {
+	for _, release := range p.Releases {
+		if err := requireID("release", release.ID); err != nil {
+			return err
+		}
+		if err := requireDate("release", release.Date); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetReleaseGroupEditions lists the releases of a release group with their format, country,
+// label, and catalog details.
+func (c *Client) GetReleaseGroupEditions(ctx context.Context, releaseGroupID string) ([]Edition, error) {
+	trimmed := strings.TrimSpace(releaseGroupID)
+	if trimmed == "" {
+		return nil, errors.New("musicbrainz: release group id is required")
+	}
+
+	params := url.Values{}
+	params.Set("fmt", "json")
+	params.Set("release-group", trimmed)
+	params.Set("inc", "labels+media")
+	params.Set("limit", strconv.Itoa(maxEditions))
+
+	endpoint := fmt.Sprintf("%s/release?%s", c.baseURL, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errRequestBuildFailed, err)
+	}
+	req.Header.Set(headerUserAgent, c.userAgent)
+	req.Header.Set(headerAccept, contentTypeJSON)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(errRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var payload releaseListResponse
+		if err := c.decode(ctx, resp.Body, &payload); err != nil {
+			return nil, err
+		}
+		return transformEditions(payload), nil
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, statusError(resp)
+	}
+}
+
+func transformEditions(payload releaseListResponse) []Edition {
+	editions := make([]Edition, 0, len(payload.Releases))
+	for _, release := range payload.Releases {
+		edition := Edition{
+			ID:      release.ID,
+			Title:   release.Title,
+			Status:  release.Status,
+			Date:    release.Date,
+			Country: release.Country,
+			Barcode: release.Barcode,
+		}
+		for _, medium := range release.Media {
+			edition.Formats = append(edition.Formats, medium.Format)
+			edition.TrackCount += medium.TrackCount
+		}
+		for _, info := range release.LabelInfo {
+			label := EditionLabel{CatalogNumber: info.CatalogNumber}
+			if info.Label != nil {
+				label.ID = info.Label.ID
+				label.Name = info.Label.Name
+			}
+			if label.Name == "" && label.CatalogNumber == "" {
+				continue
+			}
+			edition.Labels = append(edition.Labels, label)
+		}
+		editions = append(editions, edition)
+	}
+	return editions
+}
@@ -0,0 +1,61 @@
+package musicbrainz
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetReleaseGroupEditions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/release" || r.URL.Query().Get("release-group") != "rg-1" {
+			t.Errorf("unexpected request %s", r.URL.String())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"release-count": 1,
+			"releases": [{
+				"id": "rel-1",
+				"title": "Nevermind",
+				"status": "Official",
+				"date": "1991-09-24",
+				"country": "US",
+				"barcode": "720642442524",
+				"label-info": [{"catalog-number": "DGCD-24425", "label": {"id": "lbl-1", "name": "DGC"}}],
+				"media": [{"format": "12\" Vinyl", "track-count": 6}, {"format": "12\" Vinyl", "track-count": 6}]
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL, AppName: "test", AppVersion: "1.0", Contact: "test@example.com"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	editions, err := client.GetReleaseGroupEditions(context.Background(), "rg-1")
+	if err != nil {
+		t.Fatalf("GetReleaseGroupEditions returned error: %v", err)
+	}
+	if len(editions) != 1 {
+		t.Fatalf("expected 1 edition, got %d", len(editions))
+	}
+	edition := editions[0]
+	if edition.Format() != "2×12\" Vinyl" {
+		t.Errorf("unexpected format %q", edition.Format())
+	}
+	if edition.TrackCount != 12 {
+		t.Errorf("expected 12 tracks, got %d", edition.TrackCount)
+	}
+	if len(edition.Labels) != 1 || edition.Labels[0].CatalogNumber != "DGCD-24425" {
+		t.Errorf("unexpected labels %+v", edition.Labels)
+	}
+}
+
+func TestEditionFormatMixedMedia(t *testing.T) {
+	edition := Edition{Formats: []string{"CD", "DVD-Video"}}
+	if got := edition.Format(); got != "CD + DVD-Video" {
+		t.Errorf("unexpected format %q", got)
+	}
+}
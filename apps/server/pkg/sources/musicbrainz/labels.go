@@ -0,0 +1,222 @@
+package musicbrainz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Label models a subset of the MusicBrainz label payload.
+type Label struct {
+	ID             string        `json:"id"`
+	Name           string        `json:"name"`
+	Type           string        `json:"type,omitempty"`
+	Country        string        `json:"country,omitempty"`
+	Disambiguation string        `json:"disambiguation,omitempty"`
+	LabelCode      int           `json:"labelCode,omitempty"`
+	LifeSpan       LifeSpan      `json:"lifeSpan"`
+	Relations      []URLRelation `json:"relations,omitempty"`
+}
+
+type labelResponse struct {
+	ID             string             `json:"id"`
+	Name           string             `json:"name"`
+	Type           string             `json:"type"`
+	Country        string             `json:"country"`
+	Disambiguation string             `json:"disambiguation"`
+	LabelCode      int                `json:"label-code"`
+	LifeSpan       LifeSpan           `json:"life-span"`
+	Relations      []relationResponse `json:"relations"`
+}
+
+func (p *labelResponse) validate() error {
+	if err := requireID("label", p.ID); err != nil {
+		return err
+	}
+	if err := requireDate("label begin", p.LifeSpan.Begin); err != nil {
+		return err
+	}
+	return requireDate("label end", p.LifeSpan.End)
+}
+
+// labelReleaseResponse is a page of releases browsed by label, each with its release group.
+type labelReleaseResponse struct {
+	Releases []struct {
+		ID           string `json:"id"`
+		ReleaseGroup *struct {
+			ID               string   `json:"id"`
+			Title            string   `json:"title"`
+			PrimaryType      string   `json:"primary-type"`
+			SecondaryTypes   []string `json:"secondary-types"`
+			FirstReleaseDate string   `json:"first-release-date"`
+		} `json:"release-group"`
+		ArtistCredit []struct {
+			Name   string `json:"name"`
+			Artist struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"artist"`
+			JoinPhrase string `json:"joinphrase"`
+		} `json:"artist-credit"`
+	} `json:"releases"`
+	Count  int `json:"release-count"`
+	Offset int `json:"release-offset"`
+}
+
+func (p *labelReleaseResponse) validate() error {
+	for _, release := range p.Releases {
+		if err := requireID("release", release.ID); err != nil {
+			return err
+		}
+		if release.ReleaseGroup == nil {
+			continue
+		}
+		if err := requireID("release group", release.ReleaseGroup.ID); err != nil {
+			return err
+		}
+		if err := requireDate("release group", release.ReleaseGroup.FirstReleaseDate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LookupLabel retrieves a single label record by MusicBrainz ID.
+func (c *Client) LookupLabel(ctx context.Context, id string) (*Label, error) {
+	trimmed := strings.TrimSpace(id)
+	if trimmed == "" {
+		return nil, errors.New("musicbrainz: label id is required")
+	}
+
+	endpoint := fmt.Sprintf("%s/label/%s?fmt=json&inc=url-rels", c.baseURL, url.PathEscape(trimmed))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errRequestBuildFailed, err)
+	}
+	req.Header.Set(headerUserAgent, c.userAgent)
+	req.Header.Set(headerAccept, contentTypeJSON)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(errRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var payload labelResponse
+		if err := c.decode(ctx, resp.Body, &payload); err != nil {
+			return nil, err
+		}
+		return &Label{
+			ID:             payload.ID,
+			Name:           payload.Name,
+			Type:           payload.Type,
+			Country:        payload.Country,
+			Disambiguation: payload.Disambiguation,
+			LabelCode:      payload.LabelCode,
+			LifeSpan:       payload.LifeSpan,
+			Relations:      transformURLRelations(payload.Relations),
+		}, nil
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, statusError(resp)
+	}
+}
+
+// GetLabelReleaseGroups lists the release groups issued on a label. MusicBrainz can only browse
+// releases by label, so each page of releases is collapsed to its distinct release groups;
+// limit, offset, and the returned Count therefore refer to releases rather than release groups.
+func (c *Client) GetLabelReleaseGroups(ctx context.Context, labelID string, limit int, offset int) (*ReleaseGroupSearchResult, error) {
+	trimmed := strings.TrimSpace(labelID)
+	if trimmed == "" {
+		return nil, errors.New("musicbrainz: label id is required")
+	}
+
+	if limit <= 0 {
+		limit = 25
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	params := url.Values{}
+	params.Set("fmt", "json")
+	params.Set("label", trimmed)
+	params.Set("inc", "release-groups+artist-credits")
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("offset", strconv.Itoa(offset))
+
+	endpoint := fmt.Sprintf("%s/release?%s", c.baseURL, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errRequestBuildFailed, err)
+	}
+	req.Header.Set(headerUserAgent, c.userAgent)
+	req.Header.Set(headerAccept, contentTypeJSON)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(errRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var payload labelReleaseResponse
+		if err := c.decode(ctx, resp.Body, &payload); err != nil {
+			return nil, err
+		}
+		return transformLabelReleases(payload), nil
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, statusError(resp)
+	}
+}
+
+// transformLabelReleases keeps the first release seen for each release group, so reissues and
+// regional variants on the same label collapse into one album.
+func transformLabelReleases(payload labelReleaseResponse) *ReleaseGroupSearchResult {
+	seen := make(map[string]bool)
+	releaseGroups := make([]ReleaseGroup, 0, len(payload.Releases))
+	for _, release := range payload.Releases {
+		rg := release.ReleaseGroup
+		if rg == nil || rg.ID == "" || seen[rg.ID] {
+			continue
+		}
+		seen[rg.ID] = true
+
+		artistCredit := make([]ArtistCredit, 0, len(release.ArtistCredit))
+		for _, credit := range release.ArtistCredit {
+			artistCredit = append(artistCredit, ArtistCredit{
+				Name:       credit.Name,
+				Artist:     ReleaseGroupArtist{ID: credit.Artist.ID, Name: credit.Artist.Name},
+				JoinPhrase: credit.JoinPhrase,
+			})
+		}
+
+		releaseGroups = append(releaseGroups, ReleaseGroup{
+			ID:               rg.ID,
+			Title:            rg.Title,
+			PrimaryType:      rg.PrimaryType,
+			SecondaryTypes:   append([]string(nil), rg.SecondaryTypes...),
+			FirstReleaseDate: rg.FirstReleaseDate,
+			ArtistCredit:     artistCredit,
+		})
+	}
+
+	return &ReleaseGroupSearchResult{
+		ReleaseGroups: releaseGroups,
+		Count:         payload.Count,
+		Offset:        payload.Offset,
+	}
+}
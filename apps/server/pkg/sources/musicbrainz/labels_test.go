@@ -0,0 +1,87 @@
+package musicbrainz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLookupLabel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/label/lbl-1" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "lbl-1",
+			"name": "Sub Pop",
+			"type": "Original Production",
+			"country": "US",
+			"label-code": 6269,
+			"life-span": {"begin": "1986", "ended": false},
+			"relations": [{"type": "official homepage", "target-type": "url", "url": {"resource": "https://www.subpop.com/"}}]
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL, AppName: "test", AppVersion: "1.0", Contact: "test@example.com", Validation: ValidationReject})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	label, err := client.LookupLabel(context.Background(), "lbl-1")
+	if err != nil {
+		t.Fatalf("LookupLabel returned error: %v", err)
+	}
+	if label.Name != "Sub Pop" || label.LabelCode != 6269 || label.LifeSpan.Begin != "1986" {
+		t.Errorf("unexpected label %+v", label)
+	}
+	if links := Links(label.Relations); links[LinkHomepage] != "https://www.subpop.com/" {
+		t.Errorf("unexpected links %+v", links)
+	}
+
+	if _, err := client.LookupLabel(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGetLabelReleaseGroupsCollapsesReleases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/release" || r.URL.Query().Get("label") != "lbl-1" {
+			t.Errorf("unexpected request %s", r.URL.String())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"release-count": 3,
+			"release-offset": 0,
+			"releases": [
+				{"id": "rel-1", "release-group": {"id": "rg-1", "title": "Bleach", "primary-type": "Album", "first-release-date": "1989-06-15"},
+				 "artist-credit": [{"name": "Nirvana", "artist": {"id": "nirvana", "name": "Nirvana"}}]},
+				{"id": "rel-2", "release-group": {"id": "rg-1", "title": "Bleach", "primary-type": "Album", "first-release-date": "1989-06-15"},
+				 "artist-credit": [{"name": "Nirvana", "artist": {"id": "nirvana", "name": "Nirvana"}}]},
+				{"id": "rel-3", "release-group": {"id": "rg-2", "title": "Superfuzz Bigmuff", "primary-type": "EP", "first-release-date": "1988-10"},
+				 "artist-credit": [{"name": "Mudhoney", "artist": {"id": "mudhoney", "name": "Mudhoney"}}]}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL, AppName: "test", AppVersion: "1.0", Contact: "test@example.com", Validation: ValidationReject})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	result, err := client.GetLabelReleaseGroups(context.Background(), "lbl-1", 100, 0)
+	if err != nil {
+		t.Fatalf("GetLabelReleaseGroups returned error: %v", err)
+	}
+	if len(result.ReleaseGroups) != 2 || result.Count != 3 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if result.ReleaseGroups[1].PrimaryArtistName() != "Mudhoney" {
+		t.Errorf("unexpected artist credit %+v", result.ReleaseGroups[1].ArtistCredit)
+	}
+}
@@ -0,0 +1,111 @@
+package musicbrainz
+
+import (
+	"net/url"
+	"strings"
+)
+
+// URLRelation is an external link attached to a MusicBrainz entity via url-rels.
+type URLRelation struct {
+	Type  string `json:"type"`
+	URL   string `json:"url"`
+	Ended bool   `json:"ended,omitempty"`
+}
+
+// Link service keys shared with API consumers.
+const (
+	LinkHomepage   = "homepage"
+	LinkBandcamp   = "bandcamp"
+	LinkSpotify    = "spotify"
+	LinkDiscogs    = "discogs"
+	LinkLastFM     = "lastfm"
+	LinkYouTube    = "youtube"
+	LinkWikipedia  = "wikipedia"
+	LinkWikidata   = "wikidata"
+	LinkAllMusic   = "allmusic"
+	LinkSoundCloud = "soundcloud"
+	LinkAppleMusic = "applemusic"
+)
+
+// relationTypeKeys maps MusicBrainz url relationship types onto link keys.
+var relationTypeKeys = map[string]string{
+	"official homepage": LinkHomepage,
+	"bandcamp":          LinkBandcamp,
+	"discogs":           LinkDiscogs,
+	"last.fm":           LinkLastFM,
+	"youtube":           LinkYouTube,
+	"wikipedia":         LinkWikipedia,
+	"wikidata":          LinkWikidata,
+	"allmusic":          LinkAllMusic,
+	"soundcloud":        LinkSoundCloud,
+}
+
+// hostKeys classifies generic relation types ("streaming", "free streaming", etc.) by host.
+var hostKeys = map[string]string{
+	"open.spotify.com": LinkSpotify,
+	"youtube.com":      LinkYouTube,
+	"youtu.be":         LinkYouTube,
+	"bandcamp.com":     LinkBandcamp,
+	"discogs.com":      LinkDiscogs,
+	"last.fm":          LinkLastFM,
+	"music.apple.com":  LinkAppleMusic,
+	"soundcloud.com":   LinkSoundCloud,
+	"allmusic.com":     LinkAllMusic,
+}
+
+// LinkKey classifies a relation into a link key, or returns "" when the service isn't one we expose.
+func LinkKey(rel URLRelation) string {
+	if key, ok := relationTypeKeys[strings.ToLower(rel.Type)]; ok {
+		return key
+	}
+
+	parsed, err := url.Parse(rel.URL)
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if key, ok := hostKeys[host]; ok {
+		return key
+	}
+	// Bandcamp artist pages live on subdomains.
+	if strings.HasSuffix(host, ".bandcamp.com") {
+		return LinkBandcamp
+	}
+	return ""
+}
+
+// Links collapses relations into a service → URL map. The first active relation per service wins.
+func Links(relations []URLRelation) map[string]string {
+	links := make(map[string]string)
+	for _, rel := range relations {
+		if rel.Ended || rel.URL == "" {
+			continue
+		}
+		key := LinkKey(rel)
+		if key == "" {
+			continue
+		}
+		if _, exists := links[key]; !exists {
+			links[key] = rel.URL
+		}
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	return links
+}
+
+func transformURLRelations(relations []relationResponse) []URLRelation {
+	var result []URLRelation
+	for _, rel := range relations {
+		if rel.TargetType != "url" || rel.URL == nil || rel.URL.Resource == "" {
+			continue
+		}
+		result = append(result, URLRelation{
+			Type:  rel.Type,
+			URL:   rel.URL.Resource,
+			Ended: rel.Ended,
+		})
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+package musicbrainz
+
+import "testing"
+
+func TestLinksClassifiesRelations(t *testing.T) {
+	relations := []URLRelation{
+		{Type: "official homepage", URL: "https://nirvana.com"},
+		{Type: "free streaming", URL: "https://open.spotify.com/artist/6olE6TJLqED3rqDCT0FyPh"},
+		{Type: "bandcamp", URL: "https://nirvana.bandcamp.com"},
+		{Type: "discogs", URL: "https://www.discogs.com/artist/125246"},
+		{Type: "youtube", URL: "https://www.youtube.com/channel/old", Ended: true},
+		{Type: "youtube", URL: "https://www.youtube.com/channel/current"},
+		{Type: "social network", URL: "https://example.org/nirvana"},
+	}
+
+	links := Links(relations)
+
+	expected := map[string]string{
+		LinkHomepage: "https://nirvana.com",
+		LinkSpotify:  "https://open.spotify.com/artist/6olE6TJLqED3rqDCT0FyPh",
+		LinkBandcamp: "https://nirvana.bandcamp.com",
+		LinkDiscogs:  "https://www.discogs.com/artist/125246",
+		LinkYouTube:  "https://www.youtube.com/channel/current",
+	}
+	if len(links) != len(expected) {
+		t.Fatalf("expected %d links, got %d: %v", len(expected), len(links), links)
+	}
+	for key, want := range expected {
+		if got := links[key]; got != want {
+			t.Errorf("links[%q] = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestLinksEmpty(t *testing.T) {
+	if links := Links(nil); links != nil {
+		t.Errorf("expected nil links, got %v", links)
+	}
+}
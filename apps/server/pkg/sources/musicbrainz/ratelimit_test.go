@@ -0,0 +1,56 @@
+package musicbrainz
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+)
+
+func TestClientPacesConcurrentRequests(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		arrivals []time.Time
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		arrivals = append(arrivals, time.Now())
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"artist","name":"Radiohead"}`))
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL, Contact: "test@example.com", HTTP: httpclient.Options{RateLimit: 20}})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.LookupArtist(context.Background(), "artist"); err != nil {
+				t.Errorf("LookupArtist returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(arrivals) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(arrivals))
+	}
+	slices.SortFunc(arrivals, func(a, b time.Time) int { return a.Compare(b) })
+	for i := 1; i < len(arrivals); i++ {
+		// Allow for scheduling slop between the limiter releasing a request and its arrival.
+		if gap := arrivals[i].Sub(arrivals[i-1]); gap < 40*time.Millisecond {
+			t.Errorf("requests %d and %d arrived %v apart, want about 50ms", i-1, i, gap)
+		}
+	}
+}
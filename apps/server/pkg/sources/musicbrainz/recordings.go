@@ -0,0 +1,261 @@
+package musicbrainz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	relationTypePerformance = "performance"
+	relationTypeSamples     = "samples material"
+	attributeCover          = "cover"
+)
+
+// maxRecordingWorks bounds how many performed works are followed for one recording; medleys
+// can link dozens.
+const maxRecordingWorks = 5
+
+// RecordingRelationships is a recording's place in song lineage: the originals it covers, later
+// covers of it, and the recordings it samples or is sampled by.
+type RecordingRelationships struct {
+	ID         string             `json:"id"`
+	Title      string             `json:"title"`
+	ArtistName string             `json:"artistName,omitempty"`
+	Cover      bool               `json:"cover"`
+	CoverOf    []RelatedRecording `json:"coverOf"`
+	CoveredBy  []RelatedRecording `json:"coveredBy"`
+	Samples    []RelatedRecording `json:"samples"`
+	SampledBy  []RelatedRecording `json:"sampledBy"`
+}
+
+// RelatedRecording is one side of a cover or sampling link. WorkID and WorkTitle name the shared
+// composition for cover links.
+type RelatedRecording struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	ArtistName string `json:"artistName,omitempty"`
+	WorkID     string `json:"workId,omitempty"`
+	WorkTitle  string `json:"workTitle,omitempty"`
+	Date       string `json:"date,omitempty"`
+}
+
+type recordingResponse struct {
+	ID               string             `json:"id"`
+	Title            string             `json:"title"`
+	Disambiguation   string             `json:"disambiguation"`
+	Length           int                `json:"length"`
+	FirstReleaseDate string             `json:"first-release-date"`
+	ISRCs            []string           `json:"isrcs"`
+	ArtistCredit     []ArtistCredit     `json:"artist-credit"`
+	Relations        []relationResponse `json:"relations"`
+}
+
+func (p *recordingResponse) validate() error {
+	return requireID("recording", p.ID)
+}
+
+type workResponse struct {
+	ID        string             `json:"id"`
+	Title     string             `json:"title"`
+	Relations []relationResponse `json:"relations"`
+}
+
+func (p *workResponse) validate() error {
+	return requireID("work", p.ID)
+}
+
+// LookupRecording retrieves a recording with its credited artists, ISRCs, and first release
+// date.
+func (c *Client) LookupRecording(ctx context.Context, id string) (*Recording, error) {
+	trimmed := strings.TrimSpace(id)
+	if trimmed == "" {
+		return nil, errors.New("musicbrainz: recording id is required")
+	}
+
+	var recording recordingResponse
+	endpoint := fmt.Sprintf("%s/recording/%s?fmt=json&inc=artist-credits+isrcs", c.baseURL, url.PathEscape(trimmed))
+	if err := c.fetchEntity(ctx, endpoint, &recording); err != nil {
+		return nil, err
+	}
+	return &Recording{
+		ID:               recording.ID,
+		Title:            recording.Title,
+		Disambiguation:   recording.Disambiguation,
+		Length:           recording.Length,
+		ArtistCredit:     recording.ArtistCredit,
+		ISRCs:            recording.ISRCs,
+		FirstReleaseDate: recording.FirstReleaseDate,
+	}, nil
+}
+
+// GetRecordingRelationships resolves cover and sampling links for a recording. Sampling is a
+// direct recording relationship; covers are found through the works the recording performs, where
+// MusicBrainz marks cover performances with the "cover" attribute.
+func (c *Client) GetRecordingRelationships(ctx context.Context, recordingID string) (*RecordingRelationships, error) {
+	trimmed := strings.TrimSpace(recordingID)
+	if trimmed == "" {
+		return nil, errors.New("musicbrainz: recording id is required")
+	}
+
+	var recording recordingResponse
+	endpoint := fmt.Sprintf("%s/recording/%s?fmt=json&inc=artist-credits+recording-rels+work-rels", c.baseURL, url.PathEscape(trimmed))
+	if err := c.fetchEntity(ctx, endpoint, &recording); err != nil {
+		return nil, err
+	}
+
+	result := &RecordingRelationships{
+		ID:         recording.ID,
+		Title:      recording.Title,
+		ArtistName: primaryCreditName(recording.ArtistCredit),
+		CoverOf:    []RelatedRecording{},
+		CoveredBy:  []RelatedRecording{},
+		Samples:    []RelatedRecording{},
+		SampledBy:  []RelatedRecording{},
+	}
+
+	works := 0
+	for _, rel := range recording.Relations {
+		switch {
+		case rel.Type == relationTypeSamples && rel.TargetType == "recording" && rel.Recording != nil:
+			related := RelatedRecording{
+				ID:         rel.Recording.ID,
+				Title:      rel.Recording.Title,
+				ArtistName: primaryCreditName(rel.Recording.ArtistCredit),
+				Date:       rel.Begin,
+			}
+			if rel.Direction == "backward" {
+				result.SampledBy = append(result.SampledBy, related)
+			} else {
+				result.Samples = append(result.Samples, related)
+			}
+		case rel.Type == relationTypePerformance && rel.TargetType == "work" && rel.Work != nil && works < maxRecordingWorks:
+			works++
+			cover := hasAttribute(rel.Attributes, attributeCover)
+			result.Cover = result.Cover || cover
+
+			var work workResponse
+			endpoint := fmt.Sprintf("%s/work/%s?fmt=json&inc=recording-rels+artist-credits", c.baseURL, url.PathEscape(rel.Work.ID))
+			if err := c.fetchEntity(ctx, endpoint, &work); err != nil {
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				return nil, fmt.Errorf("musicbrainz: failed to load work %s: %w", rel.Work.ID, err)
+			}
+			coverOf, coveredBy := workPerformances(work, recording.ID, cover)
+			result.CoverOf = append(result.CoverOf, coverOf...)
+			result.CoveredBy = append(result.CoveredBy, coveredBy...)
+		}
+	}
+
+	result.CoverOf = dedupeRelatedRecordings(result.CoverOf)
+	result.CoveredBy = dedupeRelatedRecordings(result.CoveredBy)
+	result.Samples = dedupeRelatedRecordings(result.Samples)
+	result.SampledBy = dedupeRelatedRecordings(result.SampledBy)
+	return result, nil
+}
+
+// workPerformances splits a work's other recordings into originals and covers relative to the
+// subject. A cover lists the work's non-cover recordings as what it covers; an original lists the
+// cover recordings as its covers. Sibling covers of a cover are left out.
+func workPerformances(work workResponse, subjectID string, subjectIsCover bool) (coverOf, coveredBy []RelatedRecording) {
+	for _, rel := range work.Relations {
+		if rel.Type != relationTypePerformance || rel.TargetType != "recording" || rel.Recording == nil {
+			continue
+		}
+		if rel.Recording.ID == "" || rel.Recording.ID == subjectID {
+			continue
+		}
+		related := RelatedRecording{
+			ID:         rel.Recording.ID,
+			Title:      rel.Recording.Title,
+			ArtistName: primaryCreditName(rel.Recording.ArtistCredit),
+			WorkID:     work.ID,
+			WorkTitle:  work.Title,
+			Date:       rel.Begin,
+		}
+		isCover := hasAttribute(rel.Attributes, attributeCover)
+		switch {
+		case subjectIsCover && !isCover:
+			coverOf = append(coverOf, related)
+		case !subjectIsCover && isCover:
+			coveredBy = append(coveredBy, related)
+		}
+	}
+	return coverOf, coveredBy
+}
+
+// dedupeRelatedRecordings drops repeated recordings and orders the rest by date, undated last,
+// then title.
+func dedupeRelatedRecordings(recordings []RelatedRecording) []RelatedRecording {
+	seen := make(map[string]bool, len(recordings))
+	unique := recordings[:0]
+	for _, recording := range recordings {
+		if seen[recording.ID] {
+			continue
+		}
+		seen[recording.ID] = true
+		unique = append(unique, recording)
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		a, b := unique[i], unique[j]
+		if (a.Date == "") != (b.Date == "") {
+			return a.Date != ""
+		}
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+	})
+	return unique
+}
+
+func hasAttribute(attributes []string, want string) bool {
+	for _, attribute := range attributes {
+		if strings.EqualFold(attribute, want) {
+			return true
+		}
+	}
+	return false
+}
+
+func primaryCreditName(credits []ArtistCredit) string {
+	for _, credit := range credits {
+		if credit.Artist.Name != "" {
+			return credit.Artist.Name
+		}
+		if credit.Name != "" {
+			return credit.Name
+		}
+	}
+	return ""
+}
+
+// fetchEntity performs a lookup request and decodes the response into payload.
+func (c *Client) fetchEntity(ctx context.Context, endpoint string, payload any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf(errRequestBuildFailed, err)
+	}
+	req.Header.Set(headerUserAgent, c.userAgent)
+	req.Header.Set(headerAccept, contentTypeJSON)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf(errRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return c.decode(ctx, resp.Body, payload)
+	case http.StatusNotFound:
+		return ErrNotFound
+	default:
+		return statusError(resp)
+	}
+}
@@ -0,0 +1,134 @@
+package musicbrainz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetRecordingRelationships(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/recording/rec-cover":
+			w.Write([]byte(`{
+				"id": "rec-cover",
+				"title": "The Man Who Sold the World",
+				"artist-credit": [{"name": "Nirvana", "artist": {"id": "nirvana", "name": "Nirvana"}}],
+				"relations": [
+					{"type": "performance", "target-type": "work", "direction": "forward", "attributes": ["cover", "live"],
+					 "work": {"id": "work-1", "title": "The Man Who Sold the World"}},
+					{"type": "samples material", "target-type": "recording", "direction": "backward",
+					 "recording": {"id": "rec-sampler", "title": "Sampler", "artist-credit": [{"name": "DJ", "artist": {"id": "dj", "name": "DJ"}}]}}
+				]
+			}`))
+		case "/work/work-1":
+			w.Write([]byte(`{
+				"id": "work-1",
+				"title": "The Man Who Sold the World",
+				"relations": [
+					{"type": "performance", "target-type": "recording", "direction": "backward", "attributes": [], "begin": "1970",
+					 "recording": {"id": "rec-original", "title": "The Man Who Sold the World", "artist-credit": [{"name": "David Bowie", "artist": {"id": "bowie", "name": "David Bowie"}}]}},
+					{"type": "performance", "target-type": "recording", "direction": "backward", "attributes": ["cover"],
+					 "recording": {"id": "rec-cover", "title": "The Man Who Sold the World"}},
+					{"type": "performance", "target-type": "recording", "direction": "backward", "attributes": ["cover"],
+					 "recording": {"id": "rec-lulu", "title": "The Man Who Sold the World"}}
+				]
+			}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL, AppName: "test", AppVersion: "1.0", Contact: "test@example.com", Validation: ValidationReject})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	relationships, err := client.GetRecordingRelationships(context.Background(), "rec-cover")
+	if err != nil {
+		t.Fatalf("GetRecordingRelationships returned error: %v", err)
+	}
+	if !relationships.Cover || relationships.ArtistName != "Nirvana" {
+		t.Errorf("unexpected subject %+v", relationships)
+	}
+	if len(relationships.CoverOf) != 1 || relationships.CoverOf[0].ArtistName != "David Bowie" || relationships.CoverOf[0].WorkID != "work-1" {
+		t.Errorf("expected the Bowie original, got %+v", relationships.CoverOf)
+	}
+	if len(relationships.CoveredBy) != 0 {
+		t.Errorf("sibling covers should be left out, got %+v", relationships.CoveredBy)
+	}
+	if len(relationships.SampledBy) != 1 || relationships.SampledBy[0].ID != "rec-sampler" || len(relationships.Samples) != 0 {
+		t.Errorf("unexpected sampling links %+v / %+v", relationships.Samples, relationships.SampledBy)
+	}
+
+	if _, err := client.GetRecordingRelationships(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestWorkPerformancesListsCoversOfOriginal(t *testing.T) {
+	work := workResponse{ID: "work-1", Title: "Song"}
+	for _, id := range []string{"original", "cover-a", "cover-b"} {
+		rel := relationResponse{Type: relationTypePerformance, TargetType: "recording"}
+		if id != "original" {
+			rel.Attributes = []string{attributeCover}
+		}
+		rel.Recording = &struct {
+			ID           string         `json:"id"`
+			Title        string         `json:"title"`
+			ArtistCredit []ArtistCredit `json:"artist-credit"`
+		}{ID: id, Title: "Song"}
+		work.Relations = append(work.Relations, rel)
+	}
+
+	coverOf, coveredBy := workPerformances(work, "original", false)
+	if len(coverOf) != 0 || len(coveredBy) != 2 {
+		t.Fatalf("expected two covers, got coverOf=%+v coveredBy=%+v", coverOf, coveredBy)
+	}
+}
+
+func TestLookupRecording(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/recording/rec-1" || r.URL.Query().Get("inc") != "artist-credits isrcs" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "rec-1",
+			"title": "Under Pressure",
+			"length": 248000,
+			"first-release-date": "1981-10-26",
+			"isrcs": ["GBUM71029604"],
+			"artist-credit": [
+				{"name": "Queen", "joinphrase": " & ", "artist": {"id": "queen", "name": "Queen"}},
+				{"name": "David Bowie", "artist": {"id": "bowie", "name": "David Bowie"}}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL, AppName: "test", AppVersion: "1.0", Contact: "test@example.com", Validation: ValidationReject})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	recording, err := client.LookupRecording(context.Background(), "rec-1")
+	if err != nil {
+		t.Fatalf("LookupRecording: %v", err)
+	}
+	if recording.Length != 248000 || recording.FirstReleaseDate != "1981-10-26" || len(recording.ISRCs) != 1 {
+		t.Errorf("unexpected recording %+v", recording)
+	}
+	if got := JoinCredits(recording.ArtistCredit); got != "Queen & David Bowie" {
+		t.Errorf("JoinCredits = %q", got)
+	}
+
+	if _, err := client.LookupRecording(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package musicbrainz
+
+// relationTypeMemberOfBand is the artist-artist relationship linking a person to a group.
+const relationTypeMemberOfBand = "member of band"
+
+// relationResponse mirrors the relations array returned when url-rels, artist-rels, work-rels, or
+// recording-rels are included.
+type relationResponse struct {
+	Type       string   `json:"type"`
+	TargetType string   `json:"target-type"`
+	Direction  string   `json:"direction"`
+	Begin      string   `json:"begin"`
+	End        string   `json:"end"`
+	Ended      bool     `json:"ended"`
+	Attributes []string `json:"attributes"`
+	URL        *struct {
+		Resource string `json:"resource"`
+	} `json:"url"`
+	Artist *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"artist"`
+	Work *struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	} `json:"work"`
+	Recording *struct {
+		ID           string         `json:"id"`
+		Title        string         `json:"title"`
+		ArtistCredit []ArtistCredit `json:"artist-credit"`
+	} `json:"recording"`
+}
+
+// ArtistRelation describes an artist-artist link from the looked-up artist's point of view. For
+// "member of band" links, Group is true when the related artist is the band (the looked-up
+// artist is the member).
+type ArtistRelation struct {
+	Type       string   `json:"type,omitempty"`
+	ArtistID   string   `json:"artistId"`
+	ArtistName string   `json:"artistName"`
+	Group      bool     `json:"group"`
+	Attributes []string `json:"attributes,omitempty"`
+	Begin      string   `json:"begin,omitempty"`
+	End        string   `json:"end,omitempty"`
+	Ended      bool     `json:"ended,omitempty"`
+}
+
+// transformMemberships keeps only band membership relations. MusicBrainz stores them as
+// person → group, so a forward relation means the related artist is the group.
+func transformMemberships(relations []relationResponse) []ArtistRelation {
+	var result []ArtistRelation
+	for _, rel := range relations {
+		if rel.TargetType != "artist" || rel.Type != relationTypeMemberOfBand || rel.Artist == nil || rel.Artist.ID == "" {
+			continue
+		}
+		result = append(result, ArtistRelation{
+			Type:       rel.Type,
+			ArtistID:   rel.Artist.ID,
+			ArtistName: rel.Artist.Name,
+			Group:      rel.Direction == "forward",
+			Attributes: append([]string(nil), rel.Attributes...),
+			Begin:      rel.Begin,
+			End:        rel.End,
+			Ended:      rel.Ended,
+		})
+	}
+	return result
+}
+
+// transformAssociations keeps the artist-artist relations other than band membership, one per
+// related artist, in the order MusicBrainz lists them.
+func transformAssociations(relations []relationResponse) []ArtistRelation {
+	var result []ArtistRelation
+	seen := make(map[string]bool)
+	for _, rel := range relations {
+		if rel.TargetType != "artist" || rel.Type == relationTypeMemberOfBand || rel.Artist == nil || rel.Artist.ID == "" || seen[rel.Artist.ID] {
+			continue
+		}
+		seen[rel.Artist.ID] = true
+		result = append(result, ArtistRelation{
+			Type:       rel.Type,
+			ArtistID:   rel.Artist.ID,
+			ArtistName: rel.Artist.Name,
+			Begin:      rel.Begin,
+			End:        rel.End,
+			Ended:      rel.Ended,
+		})
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package musicbrainz
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// primaryReleaseGroupTypes and secondaryReleaseGroupTypes are MusicBrainz's release group
+// type vocabularies, lowercased.
+var (
+	primaryReleaseGroupTypes   = []string{"album", "single", "ep", "broadcast", "other"}
+	secondaryReleaseGroupTypes = []string{
+		"compilation", "soundtrack", "spokenword", "interview", "audiobook", "audio drama",
+		"live", "remix", "dj-mix", "mixtape/street", "demo", "field recording",
+	}
+)
+
+// ReleaseGroupTypes selects release groups by type. A release group is included when its
+// primary type is listed and so is each of its secondary types, so "album" alone means studio
+// albums and "album,live" adds live albums.
+type ReleaseGroupTypes []string
+
+// DefaultReleaseGroupTypes are browsed when no types are requested: albums and EPs, whatever
+// their secondary types.
+var DefaultReleaseGroupTypes = append(ReleaseGroupTypes{"album", "ep"}, secondaryReleaseGroupTypes...)
+
+// AllReleaseGroupTypes selects every release group, singles and broadcasts included.
+var AllReleaseGroupTypes = append(slices.Clone(ReleaseGroupTypes(primaryReleaseGroupTypes)), secondaryReleaseGroupTypes...)
+
+// ParseReleaseGroupTypes reads a comma- or pipe-separated type list in any case. An empty list
+// yields DefaultReleaseGroupTypes; otherwise at least one primary type is required.
+func ParseReleaseGroupTypes(raw string) (ReleaseGroupTypes, error) {
+	fields := strings.FieldsFunc(strings.ToLower(raw), func(r rune) bool { return r == ',' || r == '|' })
+	types := make(ReleaseGroupTypes, 0, len(fields))
+	hasPrimary := false
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		switch {
+		case field == "":
+			continue
+		case slices.Contains(primaryReleaseGroupTypes, field):
+			hasPrimary = true
+		case !slices.Contains(secondaryReleaseGroupTypes, field):
+			return nil, fmt.Errorf("musicbrainz: unknown release group type %q", field)
+		}
+		if !slices.Contains(types, field) {
+			types = append(types, field)
+		}
+	}
+	if len(types) == 0 {
+		return DefaultReleaseGroupTypes, nil
+	}
+	if !hasPrimary {
+		return nil, fmt.Errorf("musicbrainz: release group types need one of %s", strings.Join(primaryReleaseGroupTypes, ", "))
+	}
+	return types, nil
+}
+
+// primary returns the listed primary types, which MusicBrainz filters on.
+func (t ReleaseGroupTypes) primary() []string {
+	var primary []string
+	for _, kind := range t {
+		if slices.Contains(primaryReleaseGroupTypes, kind) {
+			primary = append(primary, kind)
+		}
+	}
+	return primary
+}
+
+// Includes reports whether a release group of the given types is selected.
+func (t ReleaseGroupTypes) Includes(primaryType string, secondaryTypes []string) bool {
+	if !slices.Contains(t, strings.ToLower(primaryType)) {
+		return false
+	}
+	for _, secondary := range secondaryTypes {
+		if !slices.Contains(t, strings.ToLower(secondary)) {
+			return false
+		}
+	}
+	return true
+}
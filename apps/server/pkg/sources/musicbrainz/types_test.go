@@ -0,0 +1,78 @@
+package musicbrainz
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseReleaseGroupTypes(t *testing.T) {
+	types, err := ParseReleaseGroupTypes("Album, live|album")
+	if err != nil {
+		t.Fatalf("ParseReleaseGroupTypes returned error: %v", err)
+	}
+	if len(types) != 2 || types[0] != "album" || types[1] != "live" {
+		t.Errorf("unexpected types %v", types)
+	}
+
+	if defaults, err := ParseReleaseGroupTypes(""); err != nil || len(defaults) != len(DefaultReleaseGroupTypes) {
+		t.Errorf("expected defaults for an empty list, got %v (%v)", defaults, err)
+	}
+	for _, raw := range []string{"live", "album,bootleg"} {
+		if _, err := ParseReleaseGroupTypes(raw); err == nil {
+			t.Errorf("expected %q to be rejected", raw)
+		}
+	}
+}
+
+func TestReleaseGroupTypesIncludes(t *testing.T) {
+	types := ReleaseGroupTypes{"album", "live"}
+	cases := []struct {
+		primary   string
+		secondary []string
+		want      bool
+	}{
+		{"Album", nil, true},
+		{"Album", []string{"Live"}, true},
+		{"Album", []string{"Compilation"}, false},
+		{"Single", nil, false},
+	}
+	for _, tc := range cases {
+		if got := types.Includes(tc.primary, tc.secondary); got != tc.want {
+			t.Errorf("Includes(%q, %v) = %v, want %v", tc.primary, tc.secondary, got, tc.want)
+		}
+	}
+}
+
+func TestGetArtistReleaseGroupsOfTypesFiltersSecondaryTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("type"); got != "album|single" {
+			t.Errorf("expected primary types to be sent upstream, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"release-group-count": 3,
+			"release-group-offset": 0,
+			"release-groups": [
+				{"id": "rg-1", "title": "Nevermind", "primary-type": "Album", "secondary-types": []},
+				{"id": "rg-2", "title": "From the Muddy Banks of the Wishkah", "primary-type": "Album", "secondary-types": ["Live"]},
+				{"id": "rg-3", "title": "Lithium", "primary-type": "Single", "secondary-types": []}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL, AppName: "test", AppVersion: "1.0", Contact: "test@example.com"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	result, err := client.GetArtistReleaseGroupsOfTypes(context.Background(), "nirvana", ReleaseGroupTypes{"album", "single"}, 25, 0)
+	if err != nil {
+		t.Fatalf("GetArtistReleaseGroupsOfTypes returned error: %v", err)
+	}
+	if len(result.ReleaseGroups) != 2 || result.ReleaseGroups[0].ID != "rg-1" || result.ReleaseGroups[1].ID != "rg-3" {
+		t.Fatalf("expected the live album to be filtered out, got %+v", result.ReleaseGroups)
+	}
+}
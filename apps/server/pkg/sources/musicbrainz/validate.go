@@ -0,0 +1,209 @@
+package musicbrainz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/metrics"
+)
+
+// ErrInvalidPayload indicates an upstream response failed semantic validation.
+var ErrInvalidPayload = errors.New("musicbrainz: invalid payload")
+
+// ValidationMode controls how the client reacts to malformed or drifting upstream payloads.
+type ValidationMode int
+
+const (
+	// ValidationOff decodes leniently and performs no additional checks (the default).
+	ValidationOff ValidationMode = iota
+	// ValidationLog validates payloads and also reports fields the response structs don't
+	// model, logging problems but still returning data.
+	ValidationLog
+	// ValidationReject validates payloads, failing the call with ErrInvalidPayload when one is
+	// semantically broken (an empty ID, an unparseable date). Unmodelled fields are allowed:
+	// the response structs only cover the fields the client uses, and every real payload
+	// carries more.
+	ValidationReject
+)
+
+// ParseValidationMode maps "off", "log", or "reject" onto a ValidationMode.
+func ParseValidationMode(raw string) (ValidationMode, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "off":
+		return ValidationOff, nil
+	case "log":
+		return ValidationLog, nil
+	case "reject":
+		return ValidationReject, nil
+	default:
+		return ValidationOff, fmt.Errorf("musicbrainz: unknown validation mode %q", raw)
+	}
+}
+
+// validator is implemented by response payloads that can check their own semantic invariants.
+type validator interface {
+	validate() error
+}
+
+// loggedProblems de-duplicates schema drift warnings so log mode reports each problem once.
+var loggedProblems sync.Map
+
+// decode reads a response body into dst according to the client's validation mode. Payloads
+// that fail to decode or validate are counted as decode errors.
+func (c *Client) decode(ctx context.Context, body io.Reader, dst any) error {
+	err := c.decodePayload(ctx, body, dst)
+	if err != nil {
+		metrics.RecordDecodeError("musicbrainz")
+	}
+	return err
+}
+
+func (c *Client) decodePayload(ctx context.Context, body io.Reader, dst any) error {
+	if c.validation == ValidationOff {
+		if err := json.NewDecoder(body).Decode(dst); err != nil {
+			return fmt.Errorf(errDecodeFailed, err)
+		}
+		return nil
+	}
+
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf(errDecodeFailed, err)
+	}
+
+	if err := json.Unmarshal(raw, dst); err != nil {
+		return fmt.Errorf(errDecodeFailed, err)
+	}
+	if c.validation == ValidationLog {
+		// Decode a second, throwaway copy strictly so new fields upstream show up as drift.
+		strict := json.NewDecoder(bytes.NewReader(raw))
+		strict.DisallowUnknownFields()
+		if err := strict.Decode(reflect.New(reflect.TypeOf(dst).Elem()).Interface()); err != nil {
+			reportProblem(ctx, dst, err)
+		}
+	}
+
+	if v, ok := dst.(validator); ok {
+		if err := v.validate(); err != nil {
+			if c.validation == ValidationReject {
+				return fmt.Errorf("%w: %T: %v", ErrInvalidPayload, dst, err)
+			}
+			reportProblem(ctx, dst, err)
+		}
+	}
+	return nil
+}
+
+func reportProblem(ctx context.Context, dst any, problem error) {
+	key := fmt.Sprintf("%T: %v", dst, problem)
+	if _, seen := loggedProblems.LoadOrStore(key, struct{}{}); seen {
+		return
+	}
+	logging.FromContext(ctx).Warn("musicbrainz payload validation", "payload", fmt.Sprintf("%T", dst), "error", problem)
+}
+
+// validPartialDate accepts the YYYY, YYYY-MM, and YYYY-MM-DD forms MusicBrainz uses (or empty).
+func validPartialDate(value string) bool {
+	_, err := data.ParsePartialDate(value)
+	return err == nil
+}
+
+func requireID(kind, id string) error {
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("%s id is empty", kind)
+	}
+	return nil
+}
+
+func requireDate(kind, value string) error {
+	if !validPartialDate(value) {
+		return fmt.Errorf("%s date %q is not parseable", kind, value)
+	}
+	return nil
+}
+
+func (p *artistResponse) validate() error {
+	if err := requireID("artist", p.ID); err != nil {
+		return err
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("artist name is empty")
+	}
+	if err := requireDate("life-span begin", p.LifeSpan.Begin); err != nil {
+		return err
+	}
+	return requireDate("life-span end", p.LifeSpan.End)
+}
+
+func (p *releaseGroupResponse) validate() error {
+	if err := requireID("release group", p.ID); err != nil {
+		return err
+	}
+	if err := requireDate("first release", p.FirstReleaseDate); err != nil {
+		return err
+	}
+	for _, release := range p.Releases {
+		if err := requireID("release", release.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *releaseResponse) validate() error {
+	if err := requireID("release", p.ID); err != nil {
+		return err
+	}
+	return requireDate("release", p.Date)
+}
+
+func (p *searchResponse) validate() error {
+	for _, artist := range p.Artists {
+		if err := requireID("artist", artist.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *releaseGroupSearchResponse) validate() error {
+	for _, rg := range p.ReleaseGroups {
+		if err := requireID("release group", rg.ID); err != nil {
+			return err
+		}
+		if err := requireDate("first release", rg.FirstReleaseDate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *releaseGroupQueryResponse) validate() error {
+	for _, rg := range p.ReleaseGroups {
+		if err := requireID("release group", rg.ID); err != nil {
+			return err
+		}
+		if err := requireDate("first release", rg.FirstReleaseDate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *recordingSearchResponse) validate() error {
+	for _, recording := range p.Recordings {
+		if err := requireID("recording", recording.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package musicbrainz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func newTestClient(t *testing.T, baseURL string, mode ValidationMode) *Client {
+	t.Helper()
+	client, err := New(context.Background(), Config{
+		BaseURL:    baseURL,
+		Contact:    "test@example.com",
+		Validation: mode,
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	return client
+}
+
+func TestLookupArtistRejectsInvalidPayload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "", "name": "Nameless", "life-span": {"begin": "19xx"}}`))
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(t, server.URL, ValidationReject).LookupArtist(context.Background(), "abc")
+	if !errors.Is(err, ErrInvalidPayload) {
+		t.Fatalf("expected ErrInvalidPayload, got %v", err)
+	}
+}
+
+func TestLookupArtistRejectModeAcceptsFullPayload(t *testing.T) {
+	// testdata/artist.json has the full shape of a ws/2 artist lookup with the includes the
+	// client asks for, most of which the response structs don't model.
+	body, err := os.ReadFile("testdata/artist.json")
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	artist, err := newTestClient(t, server.URL, ValidationReject).LookupArtist(context.Background(), "a74b1b7f-71a5-4011-9441-d0b5e4122711")
+	if err != nil {
+		t.Fatalf("expected reject mode to accept a well-formed payload, got %v", err)
+	}
+	if artist.Name != "Radiohead" {
+		t.Errorf("expected decoded name, got %q", artist.Name)
+	}
+	if len(artist.Genres) != 2 {
+		t.Errorf("expected 2 genres, got %d", len(artist.Genres))
+	}
+}
+
+func TestLookupArtistLogModeReturnsData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "abc", "name": "Drifted", "brand-new-field": true, "life-span": {"begin": "1999-13"}}`))
+	}))
+	defer server.Close()
+
+	artist, err := newTestClient(t, server.URL, ValidationLog).LookupArtist(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("expected log mode to tolerate drift, got %v", err)
+	}
+	if artist.Name != "Drifted" {
+		t.Errorf("expected decoded name, got %q", artist.Name)
+	}
+}
+
+func TestValidPartialDate(t *testing.T) {
+	for value, want := range map[string]bool{
+		"":           true,
+		"1999":       true,
+		"1999-06":    true,
+		"1999-06-01": true,
+		"1999-13":    false,
+		"99":         false,
+		"1999-6-1":   false,
+	} {
+		if got := validPartialDate(value); got != want {
+			t.Errorf("validPartialDate(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
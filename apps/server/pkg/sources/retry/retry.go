@@ -0,0 +1,227 @@
+// Package retry provides the backoff policy shared by the upstream source clients.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBaseDelay   = 200 * time.Millisecond
+	defaultMaxDelay    = 2 * time.Second
+)
+
+// Policy describes how many times a request is attempted and how long to wait between attempts.
+// Zero fields fall back to the defaults; MaxAttempts of 1 disables retries.
+type Policy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Retryable classifies response statuses; nil uses RetryableStatus.
+	Retryable func(status int) bool
+}
+
+// DefaultPolicy returns the policy used when a client is not configured explicitly.
+func DefaultPolicy() Policy {
+	return Policy{}.withDefaults()
+}
+
+func (p Policy) withDefaults() Policy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultMaxAttempts
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = defaultBaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = defaultMaxDelay
+	}
+	if p.MaxDelay < p.BaseDelay {
+		p.MaxDelay = p.BaseDelay
+	}
+	if p.Retryable == nil {
+		p.Retryable = RetryableStatus
+	}
+	return p
+}
+
+// RetryableStatus reports whether a response status is worth retrying: rate limiting and
+// transient gateway or availability errors.
+func RetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// Backoff returns the wait before the given retry (1 for the first retry) using exponential
+// growth capped at MaxDelay, with full jitter.
+func (p Policy) Backoff(retry int) time.Duration {
+	p = p.withDefaults()
+	delay := p.BaseDelay
+	for i := 1; i < retry && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return time.Duration(jitter(int64(delay)))
+}
+
+var (
+	rngMu sync.Mutex
+	rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// jitter picks a delay in [d/2, d] so concurrent callers don't retry in lockstep.
+func jitter(d int64) int64 {
+	if d <= 1 {
+		return d
+	}
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	return d/2 + rng.Int63n(d/2+1)
+}
+
+// Transport wraps base so idempotent requests are retried according to policy. Retry-After
+// headers are honored up to MaxDelay, and waiting stops as soon as the request context is done.
+func Transport(policy Policy, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{policy: policy.withDefaults(), base: base}
+}
+
+type transport struct {
+	policy Policy
+	base   http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !replayable(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.policy.MaxAttempts {
+			return resp, err
+		}
+		if err == nil && !t.policy.Retryable(resp.StatusCode) {
+			return resp, nil
+		}
+		if err != nil && req.Context().Err() != nil {
+			return resp, err
+		}
+
+		wait := t.policy.Backoff(attempt)
+		if err == nil {
+			if after, ok := retryAfter(resp, t.policy.MaxDelay); ok {
+				wait = after
+			}
+			// Drain so the connection can be reused for the next attempt.
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+
+		if !sleep(req.Context(), wait) {
+			return nil, req.Context().Err()
+		}
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+	}
+}
+
+// replayable limits retries to idempotent methods whose body, if any, can be rewound.
+func replayable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func retryAfter(resp *http.Response, limit time.Duration) (time.Duration, bool) {
+	wait, ok := RetryAfter(resp)
+	if wait > limit {
+		wait = limit
+	}
+	return wait, ok
+}
+
+// RetryAfter reads a response's Retry-After header, in either delay-seconds or HTTP-date form.
+func RetryAfter(resp *http.Response) (time.Duration, bool) {
+	raw := resp.Header.Get("Retry-After")
+	if raw == "" {
+		return 0, false
+	}
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(raw); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(raw); err == nil {
+		wait = time.Until(at)
+	} else {
+		return 0, false
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// Seconds rounds a wait up to the whole seconds a Retry-After header carries.
+func Seconds(wait time.Duration) int {
+	if wait <= 0 {
+		return 0
+	}
+	return int((wait + time.Second - 1) / time.Second)
+}
+
+// RateLimitedError reports that an upstream refused a request because of rate limiting, once
+// retries are exhausted. RetryAfter is the wait the upstream asked for, zero when it didn't say.
+type RateLimitedError struct {
+	Source     string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s: rate limited, retry after %s", e.Source, e.RetryAfter)
+	}
+	return e.Source + ": rate limited"
+}
+
+// RateLimited builds a RateLimitedError for source from a rate-limiting response.
+func RateLimited(source string, resp *http.Response) *RateLimitedError {
+	wait, _ := RetryAfter(resp)
+	return &RateLimitedError{Source: source, RetryAfter: wait}
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,118 @@
+package retry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransportRetriesRetryableStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transport(Policy{MaxAttempts: 3, BaseDelay: time.Millisecond}, nil)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 after retries, got %d", resp.StatusCode)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestTransportStopsAtMaxAttempts(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transport(Policy{MaxAttempts: 2, BaseDelay: time.Millisecond}, nil)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadGateway || calls != 2 {
+		t.Errorf("expected final 502 after 2 attempts, got %d after %d", resp.StatusCode, calls)
+	}
+}
+
+func TestTransportDoesNotRetryClientErrorsOrPosts(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transport(Policy{BaseDelay: time.Millisecond}, nil)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	resp, err = client.Post(server.URL, "text/plain", strings.NewReader("x"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if calls != 2 {
+		t.Errorf("expected no retries, got %d calls", calls)
+	}
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	policy := Policy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for retry := 1; retry <= 6; retry++ {
+		if d := policy.Backoff(retry); d > 300*time.Millisecond || d <= 0 {
+			t.Errorf("retry %d: backoff %v out of range", retry, d)
+		}
+	}
+}
+
+func TestRateLimitedReadsRetryAfter(t *testing.T) {
+	for raw, want := range map[string]time.Duration{
+		"":      0,
+		"7":     7 * time.Second,
+		"later": 0,
+	} {
+		resp := &http.Response{Header: http.Header{}}
+		if raw != "" {
+			resp.Header.Set("Retry-After", raw)
+		}
+		if got := RateLimited("musicbrainz", resp).RetryAfter; got != want {
+			t.Errorf("Retry-After %q: expected %s, got %s", raw, want, got)
+		}
+	}
+}
+
+func TestSecondsRoundsUp(t *testing.T) {
+	for wait, want := range map[time.Duration]int{0: 0, time.Millisecond: 1, time.Second: 1, 2500 * time.Millisecond: 3} {
+		if got := Seconds(wait); got != want {
+			t.Errorf("Seconds(%s) = %d, want %d", wait, got, want)
+		}
+	}
+}
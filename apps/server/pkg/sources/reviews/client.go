@@ -7,9 +7,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/metrics"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/useragent"
 )
 
 var (
@@ -23,50 +30,73 @@ type Client struct {
 	httpClient *http.Client
 	userAgent  string
 	discogs    *DiscogsClient
+	health     *health.Tracker
 }
 
 // Config holds configuration for review sources
 type Config struct {
 	UserAgent             string
-	Timeout               time.Duration
 	DiscogsToken          string // Optional: for higher rate limits with personal token
 	DiscogsConsumerKey    string // OAuth consumer key
 	DiscogsConsumerSecret string // OAuth consumer secret
+	DiscogsBaseURL        string // Defaults to https://api.discogs.com
+	// HTTP sets the timeout, retries, response cache, and pacing.
+	HTTP httpclient.Options
 }
 
 // NewClient creates a new review aggregation client
 func NewClient(cfg Config) *Client {
-	if cfg.Timeout == 0 {
-		cfg.Timeout = 10 * time.Second
-	}
-
 	if cfg.UserAgent == "" {
-		cfg.UserAgent = "FreqShow/1.0 +https://github.com/adamlacasse/freq-show"
+		cfg.UserAgent = useragent.Default()
 	}
 
-	httpClient := &http.Client{
-		Timeout: cfg.Timeout,
-	}
+	tracker := health.NewTracker()
+	httpClient := httpclient.New("discogs", cfg.HTTP, 10*time.Second, tracker)
 
 	return &Client{
 		httpClient: httpClient,
 		userAgent:  cfg.UserAgent,
+		health:     tracker,
 		discogs: &DiscogsClient{
 			httpClient:     httpClient,
 			userAgent:      cfg.UserAgent,
 			token:          cfg.DiscogsToken,
 			consumerKey:    cfg.DiscogsConsumerKey,
 			consumerSecret: cfg.DiscogsConsumerSecret,
+			baseURL:        strings.TrimRight(strings.TrimSpace(cfg.DiscogsBaseURL), "/"),
 		},
 	}
 }
 
-// GetAlbumReview fetches and aggregates reviews for an album
-// It tries multiple sources and returns the best available review
-func (c *Client) GetAlbumReview(ctx context.Context, artistName, albumTitle string) (*data.Review, error) {
-	// Try Discogs first (most comprehensive)
-	if review, err := c.discogs.GetAlbumReview(ctx, artistName, albumTitle); err == nil && review != nil {
-		return review, nil
+// Release is catalog metadata from the Discogs release an album's review was read from.
+type Release struct {
+	// ID is the Discogs release ID, for GetMarketplaceStats.
+	ID            int
+	Genres        []string
+	Styles        []string
+	Label         string
+	CatalogNumber string
+}
+
+// GetAlbumReviews collects reviews for an album from every configured source.
+// Sources that fail or have nothing for the album are skipped, so the result may be empty.
+func (c *Client) GetAlbumReviews(ctx context.Context, artistName, albumTitle string) ([]data.Review, error) {
+	reviews, _, err := c.GetAlbumReviewsAndRelease(ctx, artistName, albumTitle)
+	return reviews, err
+}
+
+// GetAlbumReviewsAndRelease is GetAlbumReviews that also returns the genres, styles, and label
+// of the Discogs release the review came from, read from the same responses. release is nil
+// when Discogs had nothing for the album.
+func (c *Client) GetAlbumReviewsAndRelease(ctx context.Context, artistName, albumTitle string) ([]data.Review, *Release, error) {
+	var (
+		reviews []data.Review
+		release *Release
+	)
+
+	if found, err := c.discogs.getBestRelease(ctx, artistName, albumTitle); err == nil {
+		reviews = append(reviews, *c.discogs.convertToReview(found))
+		release = convertToRelease(found)
 	}
 
 	// Future: Add other sources here
@@ -74,8 +104,31 @@ func (c *Client) GetAlbumReview(ctx context.Context, artistName, albumTitle stri
 	// - AI-generated summaries from AllMusic-style data
 	// - MusicBrainz external review links
 
-	// Return empty review if no sources found anything
-	return &data.Review{}, nil
+	return reviews, release, nil
+}
+
+// GetAlbumReview returns the best available review for an album, or an empty review
+// when no source found anything.
+func (c *Client) GetAlbumReview(ctx context.Context, artistName, albumTitle string) (*data.Review, error) {
+	reviews, err := c.GetAlbumReviews(ctx, artistName, albumTitle)
+	if err != nil {
+		return nil, err
+	}
+	if len(reviews) == 0 {
+		return &data.Review{}, nil
+	}
+	return &reviews[0], nil
+}
+
+// Name identifies this source in image metadata and the fallback chain.
+func (c *Client) Name() string {
+	return "discogs"
+}
+
+// AlbumImages returns the cover image of the best Discogs search match for an album.
+// The release group ID is unused; Discogs is searched by artist and title.
+func (c *Client) AlbumImages(ctx context.Context, _ string, artistName, albumTitle string) ([]data.Image, error) {
+	return c.discogs.GetAlbumImages(ctx, artistName, albumTitle)
 }
 
 // DiscogsClient handles Discogs API interactions
@@ -96,6 +149,15 @@ type DiscogsRelease struct {
 	Community    DiscogsCommunityStat `json:"community"`
 	Notes        string               `json:"notes"`
 	ExtraArtists []DiscogsArtist      `json:"extraartists"`
+	Genres       []string             `json:"genres"`
+	Styles       []string             `json:"styles"`
+	Labels       []DiscogsLabel       `json:"labels"`
+}
+
+type DiscogsLabel struct {
+	Name  string `json:"name"`
+	CatNo string `json:"catno"`
+	ID    int    `json:"id"`
 }
 
 type DiscogsArtist struct {
@@ -184,6 +246,18 @@ func (dc *DiscogsClient) buildAuthURL(baseURL string, params map[string]string)
 
 // GetAlbumReview searches for and retrieves review data from Discogs
 func (dc *DiscogsClient) GetAlbumReview(ctx context.Context, artistName, albumTitle string) (*data.Review, error) {
+	release, err := dc.getBestRelease(ctx, artistName, albumTitle)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to our Review format
+	review := dc.convertToReview(release)
+	return review, nil
+}
+
+// getBestRelease fetches the full release of the best search match for an album.
+func (dc *DiscogsClient) getBestRelease(ctx context.Context, artistName, albumTitle string) (*DiscogsRelease, error) {
 	dc.init()
 
 	// First, search for the album
@@ -196,18 +270,30 @@ func (dc *DiscogsClient) GetAlbumReview(ctx context.Context, artistName, albumTi
 		return nil, ErrNotFound
 	}
 
-	// Get the first/best match
-	bestMatch := searchResults[0]
+	// Fetch detailed release information for the first/best match
+	return dc.getRelease(ctx, searchResults[0].ID)
+}
+
+// GetAlbumImages searches Discogs for an album and returns its cover image, if any
+func (dc *DiscogsClient) GetAlbumImages(ctx context.Context, artistName, albumTitle string) ([]data.Image, error) {
+	dc.init()
 
-	// Fetch detailed release information
-	release, err := dc.getRelease(ctx, bestMatch.ID)
+	searchResults, err := dc.searchAlbum(ctx, artistName, albumTitle)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert to our Review format
-	review := dc.convertToReview(release)
-	return review, nil
+	for _, item := range searchResults {
+		// Discogs serves a generic spacer image when a release has no artwork.
+		if item.CoverImage != "" && !strings.Contains(item.CoverImage, "spacer.gif") {
+			return []data.Image{{
+				Type:   data.ImageTypeFront,
+				URL:    item.CoverImage,
+				Source: "discogs",
+			}}, nil
+		}
+	}
+	return nil, ErrNotFound
 }
 
 func (dc *DiscogsClient) searchAlbum(ctx context.Context, artistName, albumTitle string) ([]DiscogsSearchItem, error) {
@@ -240,7 +326,7 @@ func (dc *DiscogsClient) searchAlbum(ctx context.Context, artistName, albumTitle
 	case http.StatusNotFound:
 		return nil, ErrNotFound
 	case http.StatusTooManyRequests:
-		return nil, ErrRateLimit
+		return nil, fmt.Errorf("%w: %w", ErrRateLimit, retry.RateLimited("discogs", resp))
 	case http.StatusUnauthorized:
 		return nil, ErrUnauthorized
 	default:
@@ -249,6 +335,7 @@ func (dc *DiscogsClient) searchAlbum(ctx context.Context, artistName, albumTitle
 
 	var result DiscogsSearchResult
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		metrics.RecordDecodeError("discogs")
 		return nil, fmt.Errorf("failed to decode search response: %w", err)
 	}
 
@@ -256,39 +343,10 @@ func (dc *DiscogsClient) searchAlbum(ctx context.Context, artistName, albumTitle
 }
 
 func (dc *DiscogsClient) getRelease(ctx context.Context, releaseID int) (*DiscogsRelease, error) {
-	releaseURL := dc.buildAuthURL(fmt.Sprintf("%s/releases/%d", dc.baseURL, releaseID), map[string]string{})
-
-	req, err := http.NewRequestWithContext(ctx, "GET", releaseURL, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	dc.setAuthHeaders(req)
-
-	resp, err := dc.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-		// Continue processing
-	case http.StatusNotFound:
-		return nil, ErrNotFound
-	case http.StatusTooManyRequests:
-		return nil, ErrRateLimit
-	case http.StatusUnauthorized:
-		return nil, ErrUnauthorized
-	default:
-		return nil, fmt.Errorf("discogs api error: %d", resp.StatusCode)
-	}
-
 	var release DiscogsRelease
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return nil, fmt.Errorf("failed to decode release response: %w", err)
+	if err := dc.getJSON(ctx, fmt.Sprintf("%s/releases/%d", dc.baseURL, releaseID), &release); err != nil {
+		return nil, err
 	}
-
 	return &release, nil
 }
 
@@ -320,3 +378,40 @@ func (dc *DiscogsClient) convertToReview(release *DiscogsRelease) *data.Review {
 
 	return review
 }
+
+// discogsDisambiguation is the " (2)" Discogs appends to names shared by several labels.
+var discogsDisambiguation = regexp.MustCompile(`\s+\(\d+\)$`)
+
+// convertToRelease reads a release's genres, styles, and first label credit. Discogs writes
+// "none" for releases pressed without a catalog number.
+func convertToRelease(release *DiscogsRelease) *Release {
+	converted := &Release{
+		ID:     release.ID,
+		Genres: append([]string(nil), release.Genres...),
+		Styles: append([]string(nil), release.Styles...),
+	}
+	if len(release.Labels) > 0 {
+		label := release.Labels[0]
+		converted.Label = discogsDisambiguation.ReplaceAllString(strings.TrimSpace(label.Name), "")
+		if catNo := strings.TrimSpace(label.CatNo); !strings.EqualFold(catNo, "none") {
+			converted.CatalogNumber = catNo
+		}
+	}
+	return converted
+}
+
+// Healthy reports whether recent Discogs calls have been succeeding.
+func (c *Client) Healthy() bool {
+	return c.health.Healthy()
+}
+
+// Health returns the rolling success rate and failure state for Discogs calls.
+func (c *Client) Health() health.Status {
+	return c.health.Status()
+}
+
+// Ping checks that Discogs is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	c.discogs.init()
+	return health.Ping(ctx, c.httpClient, c.discogs.baseURL+"/", c.userAgent)
+}
@@ -6,12 +6,14 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
 )
 
 func TestNewClient(t *testing.T) {
 	cfg := Config{
 		UserAgent: "TestAgent/1.0",
-		Timeout:   5 * time.Second,
+		HTTP:      httpclient.Options{Timeout: 5 * time.Second},
 	}
 
 	client := NewClient(cfg)
@@ -243,7 +245,7 @@ func TestGetAlbumReview_Integration(t *testing.T) {
 
 	cfg := Config{
 		UserAgent: "Test/1.0",
-		Timeout:   5 * time.Second,
+		HTTP:      httpclient.Options{Timeout: 5 * time.Second},
 	}
 
 	client := NewClient(cfg)
@@ -265,3 +267,106 @@ func TestGetAlbumReview_Integration(t *testing.T) {
 		t.Errorf("Expected review text, got %q", review.Text)
 	}
 }
+
+func TestGetAlbumReviewsAndRelease(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/database/search":
+			w.Write([]byte(`{"results": [{"id": 249504, "type": "release", "title": "Nevermind"}]}`))
+		case "/releases/249504":
+			w.Write([]byte(`{
+				"id": 249504,
+				"title": "Nevermind",
+				"genres": ["Rock"],
+				"styles": ["Grunge", "Alternative Rock"],
+				"labels": [{"name": "DGC (2)", "catno": "DGC-24425", "id": 1234}],
+				"community": {"rating": {"count": 1000, "average": 4.5}}
+			}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{UserAgent: "Test/1.0"})
+	client.discogs.baseURL = server.URL
+
+	reviews, release, err := client.GetAlbumReviewsAndRelease(context.Background(), "Nirvana", "Nevermind")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(reviews) != 1 || reviews[0].Rating != 4.5 {
+		t.Errorf("Expected the community review, got %+v", reviews)
+	}
+	if release == nil {
+		t.Fatal("Expected release metadata")
+	}
+	if len(release.Genres) != 1 || release.Genres[0] != "Rock" || len(release.Styles) != 2 || release.Styles[0] != "Grunge" {
+		t.Errorf("Expected genres and styles, got %+v", release)
+	}
+	if release.Label != "DGC" || release.CatalogNumber != "DGC-24425" {
+		t.Errorf("Expected label without disambiguation and catalog number, got %q %q", release.Label, release.CatalogNumber)
+	}
+	if requests != 2 {
+		t.Errorf("Expected one search and one release request, got %d", requests)
+	}
+}
+
+func TestConvertToReleaseSkipsMissingCatalogNumber(t *testing.T) {
+	release := convertToRelease(&DiscogsRelease{Labels: []DiscogsLabel{{Name: "Not On Label", CatNo: "none"}}})
+	if release.Label != "Not On Label" || release.CatalogNumber != "" {
+		t.Errorf("Expected label without catalog number, got %+v", release)
+	}
+}
+
+func TestGetMarketplaceStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/marketplace/stats/249504":
+			w.Write([]byte(`{"lowest_price": {"currency": "USD", "value": 12.5}, "num_for_sale": 42, "blocked_from_sale": false}`))
+		case "/marketplace/price_suggestions/249504":
+			if r.Header.Get("Authorization") == "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{
+				"Mint (M)": {"currency": "USD", "value": 40},
+				"Near Mint (NM or M-)": {"currency": "USD", "value": 30},
+				"Very Good Plus (VG+)": {"currency": "USD", "value": 20},
+				"Good (G)": {"currency": "USD", "value": 5}
+			}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	anonymous := NewClient(Config{DiscogsBaseURL: server.URL})
+	stats, err := anonymous.GetMarketplaceStats(context.Background(), 249504)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.Currency != "USD" || stats.LowestPrice != 12.5 || stats.NumForSale != 42 || stats.MedianPrice != 0 {
+		t.Errorf("Expected listing stats without a median, got %+v", stats)
+	}
+	if stats.URL != "https://www.discogs.com/sell/release/249504" {
+		t.Errorf("Expected marketplace URL, got %q", stats.URL)
+	}
+
+	authenticated := NewClient(Config{DiscogsBaseURL: server.URL, DiscogsToken: "token"})
+	stats, err = authenticated.GetMarketplaceStats(context.Background(), 249504)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.MedianPrice != 25 {
+		t.Errorf("Expected median of suggested prices 25, got %v", stats.MedianPrice)
+	}
+
+	if _, err := anonymous.GetMarketplaceStats(context.Background(), 1); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package reviews
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/metrics"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+)
+
+// DiscogsPrice is an amount in a currency, as Discogs' marketplace endpoints write it.
+type DiscogsPrice struct {
+	Currency string  `json:"currency"`
+	Value    float64 `json:"value"`
+}
+
+// DiscogsMarketplaceStats represents a Discogs /marketplace/stats response. LowestPrice is
+// null when nothing is for sale.
+type DiscogsMarketplaceStats struct {
+	LowestPrice     *DiscogsPrice `json:"lowest_price"`
+	NumForSale      int           `json:"num_for_sale"`
+	BlockedFromSale bool          `json:"blocked_from_sale"`
+}
+
+// GetMarketplaceStats reads what Discogs sellers are asking for a release: the lowest price
+// and number of copies for sale and, when a personal token is configured, the median of the
+// prices Discogs suggests for each media condition.
+func (c *Client) GetMarketplaceStats(ctx context.Context, releaseID int) (*data.Marketplace, error) {
+	return c.discogs.getMarketplaceStats(ctx, releaseID)
+}
+
+func (dc *DiscogsClient) getMarketplaceStats(ctx context.Context, releaseID int) (*data.Marketplace, error) {
+	dc.init()
+
+	var stats DiscogsMarketplaceStats
+	if err := dc.getJSON(ctx, fmt.Sprintf("%s/marketplace/stats/%d", dc.baseURL, releaseID), &stats); err != nil {
+		return nil, err
+	}
+
+	marketplace := &data.Marketplace{
+		NumForSale:      stats.NumForSale,
+		BlockedFromSale: stats.BlockedFromSale,
+		URL:             fmt.Sprintf("https://www.discogs.com/sell/release/%d", releaseID),
+	}
+	if stats.LowestPrice != nil {
+		marketplace.Currency = stats.LowestPrice.Currency
+		marketplace.LowestPrice = stats.LowestPrice.Value
+	}
+
+	// Price suggestions are only served to authenticated sellers, and are a bonus: without
+	// them the block still has the listing stats.
+	if dc.token != "" {
+		var suggestions map[string]DiscogsPrice
+		if err := dc.getJSON(ctx, fmt.Sprintf("%s/marketplace/price_suggestions/%d", dc.baseURL, releaseID), &suggestions); err == nil {
+			marketplace.Currency, marketplace.MedianPrice = medianPrice(marketplace.Currency, suggestions)
+		}
+	}
+	return marketplace, nil
+}
+
+// medianPrice is the median of the suggested prices in currency, or in the currency of the
+// first suggestion when currency is empty. It returns the currency it settled on.
+func medianPrice(currency string, suggestions map[string]DiscogsPrice) (string, float64) {
+	conditions := make([]string, 0, len(suggestions))
+	for condition := range suggestions {
+		conditions = append(conditions, condition)
+	}
+	sort.Strings(conditions)
+
+	var values []float64
+	for _, condition := range conditions {
+		price := suggestions[condition]
+		if currency == "" {
+			currency = price.Currency
+		}
+		if price.Currency == currency && price.Value > 0 {
+			values = append(values, price.Value)
+		}
+	}
+	if len(values) == 0 {
+		return currency, 0
+	}
+	sort.Float64s(values)
+	middle := len(values) / 2
+	if len(values)%2 == 0 {
+		return currency, (values[middle-1] + values[middle]) / 2
+	}
+	return currency, values[middle]
+}
+
+// getJSON fetches a Discogs endpoint and decodes its response into target.
+func (dc *DiscogsClient) getJSON(ctx context.Context, endpoint string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", dc.buildAuthURL(endpoint, map[string]string{}), nil)
+	if err != nil {
+		return err
+	}
+
+	dc.setAuthHeaders(req)
+
+	resp, err := dc.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Continue processing
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %w", ErrRateLimit, retry.RateLimited("discogs", resp))
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	default:
+		return fmt.Errorf("discogs api error: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		metrics.RecordDecodeError("discogs")
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,376 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/metrics"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/useragent"
+)
+
+var (
+	// ErrNotFound indicates the requested playlist does not exist or is not visible to the app.
+	ErrNotFound = errors.New("spotify: resource not found")
+	// ErrInvalidPlaylist indicates the supplied playlist reference could not be parsed.
+	ErrInvalidPlaylist = errors.New("spotify: invalid playlist reference")
+)
+
+const (
+	defaultBaseURL = "https://api.spotify.com/v1"
+	defaultAuthURL = "https://accounts.spotify.com/api/token"
+
+	// maxPlaylistTracks bounds how many tracks are read from a single playlist.
+	maxPlaylistTracks = 500
+	pageSize          = 100
+)
+
+// Config describes how to connect to the Spotify Web API.
+type Config struct {
+	BaseURL      string
+	AuthURL      string
+	ClientID     string
+	ClientSecret string
+	UserAgent    string
+	// HTTP sets the timeout, retries, response cache, and pacing.
+	HTTP httpclient.Options
+}
+
+// Client issues requests against the Spotify Web API using the client credentials flow.
+type Client struct {
+	baseURL      string
+	authURL      string
+	clientID     string
+	clientSecret string
+	userAgent    string
+	httpClient   *http.Client
+	health       *health.Tracker
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// New constructs a Spotify API client.
+func New(_ context.Context, cfg Config) (*Client, error) {
+	if strings.TrimSpace(cfg.ClientID) == "" || strings.TrimSpace(cfg.ClientSecret) == "" {
+		return nil, errors.New("spotify: client id and secret are required")
+	}
+
+	baseURL := strings.TrimSpace(cfg.BaseURL)
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	authURL := strings.TrimSpace(cfg.AuthURL)
+	if authURL == "" {
+		authURL = defaultAuthURL
+	}
+
+	userAgent := strings.TrimSpace(cfg.UserAgent)
+	if userAgent == "" {
+		userAgent = useragent.Default()
+	}
+
+	tracker := health.NewTracker()
+	return &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		authURL:      authURL,
+		clientID:     strings.TrimSpace(cfg.ClientID),
+		clientSecret: strings.TrimSpace(cfg.ClientSecret),
+		userAgent:    userAgent,
+		httpClient:   httpclient.New("spotify", cfg.HTTP, 10*time.Second, tracker),
+		health:       tracker,
+	}, nil
+}
+
+// Playlist represents a Spotify playlist and its tracks.
+type Playlist struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Owner       string  `json:"owner"`
+	URL         string  `json:"url"`
+	Tracks      []Track `json:"tracks"`
+}
+
+// Track represents a single playlist entry.
+type Track struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Artists    []string `json:"artists"`
+	Album      string   `json:"album"`
+	ISRC       string   `json:"isrc,omitempty"`
+	DurationMs int      `json:"durationMs"`
+}
+
+// PrimaryArtist returns the first credited artist, if any.
+func (t Track) PrimaryArtist() string {
+	if len(t.Artists) == 0 {
+		return ""
+	}
+	return t.Artists[0]
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type playlistResponse struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	ExternalURLs struct {
+		Spotify string `json:"spotify"`
+	} `json:"external_urls"`
+	Owner struct {
+		DisplayName string `json:"display_name"`
+	} `json:"owner"`
+	Tracks tracksPage `json:"tracks"`
+}
+
+type tracksPage struct {
+	Items []struct {
+		Track *struct {
+			ID         string `json:"id"`
+			Name       string `json:"name"`
+			DurationMs int    `json:"duration_ms"`
+			Artists    []struct {
+				Name string `json:"name"`
+			} `json:"artists"`
+			Album struct {
+				Name string `json:"name"`
+			} `json:"album"`
+			ExternalIDs struct {
+				ISRC string `json:"isrc"`
+			} `json:"external_ids"`
+		} `json:"track"`
+	} `json:"items"`
+	Next  string `json:"next"`
+	Total int    `json:"total"`
+}
+
+// ParsePlaylistID extracts a playlist ID from a share URL, a spotify: URI, or a bare ID.
+func ParsePlaylistID(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "", ErrInvalidPlaylist
+	}
+
+	if strings.HasPrefix(trimmed, "spotify:") {
+		parts := strings.Split(trimmed, ":")
+		if len(parts) >= 3 && parts[len(parts)-2] == "playlist" {
+			return validatePlaylistID(parts[len(parts)-1])
+		}
+		return "", ErrInvalidPlaylist
+	}
+
+	if strings.Contains(trimmed, "/") {
+		parsed, err := url.Parse(trimmed)
+		if err != nil {
+			return "", ErrInvalidPlaylist
+		}
+		segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+		for i := 0; i < len(segments)-1; i++ {
+			if segments[i] == "playlist" {
+				return validatePlaylistID(segments[i+1])
+			}
+		}
+		return "", ErrInvalidPlaylist
+	}
+
+	return validatePlaylistID(trimmed)
+}
+
+func validatePlaylistID(id string) (string, error) {
+	if id == "" {
+		return "", ErrInvalidPlaylist
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return "", ErrInvalidPlaylist
+		}
+	}
+	return id, nil
+}
+
+// GetPlaylist retrieves a playlist and its tracks, following pagination up to an internal cap.
+func (c *Client) GetPlaylist(ctx context.Context, reference string) (*Playlist, error) {
+	id, err := ParsePlaylistID(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("fields", "id,name,description,external_urls,owner(display_name),tracks(items(track(id,name,duration_ms,artists(name),album(name),external_ids)),next,total)")
+	endpoint := fmt.Sprintf("%s/playlists/%s?%s", c.baseURL, url.PathEscape(id), params.Encode())
+
+	var payload playlistResponse
+	if err := c.getJSON(ctx, endpoint, &payload); err != nil {
+		return nil, err
+	}
+
+	playlist := &Playlist{
+		ID:          payload.ID,
+		Name:        payload.Name,
+		Description: payload.Description,
+		Owner:       payload.Owner.DisplayName,
+		URL:         payload.ExternalURLs.Spotify,
+	}
+	playlist.Tracks = appendTracks(playlist.Tracks, payload.Tracks)
+
+	next := payload.Tracks.Next
+	for next != "" && len(playlist.Tracks) < maxPlaylistTracks {
+		var page tracksPage
+		if err := c.getJSON(ctx, next, &page); err != nil {
+			return nil, err
+		}
+		playlist.Tracks = appendTracks(playlist.Tracks, page)
+		next = page.Next
+	}
+
+	if len(playlist.Tracks) > maxPlaylistTracks {
+		playlist.Tracks = playlist.Tracks[:maxPlaylistTracks]
+	}
+	return playlist, nil
+}
+
+func appendTracks(dst []Track, page tracksPage) []Track {
+	for _, item := range page.Items {
+		// Local files and removed tracks come back with a null track object.
+		if item.Track == nil || item.Track.Name == "" {
+			continue
+		}
+		artists := make([]string, 0, len(item.Track.Artists))
+		for _, artist := range item.Track.Artists {
+			if artist.Name != "" {
+				artists = append(artists, artist.Name)
+			}
+		}
+		dst = append(dst, Track{
+			ID:         item.Track.ID,
+			Name:       item.Track.Name,
+			Artists:    artists,
+			Album:      item.Track.Album.Name,
+			ISRC:       strings.ToUpper(strings.TrimSpace(item.Track.ExternalIDs.ISRC)),
+			DurationMs: item.Track.DurationMs,
+		})
+	}
+	return dst
+}
+
+func (c *Client) getJSON(ctx context.Context, endpoint string, dst any) error {
+	token, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("spotify: request build failed: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("spotify: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+			metrics.RecordDecodeError("spotify")
+			return fmt.Errorf("spotify: decode failed: %w", err)
+		}
+		return nil
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized:
+		// Force a token refresh on the next call.
+		c.mu.Lock()
+		c.accessToken = ""
+		c.mu.Unlock()
+		return errors.New("spotify: unauthorized")
+	default:
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("spotify: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+}
+
+// token returns a cached access token, requesting a new one when the current token is near expiry.
+func (c *Client) token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.accessToken != "" && time.Now().Before(c.expiresAt) {
+		return c.accessToken, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.authURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("spotify: token request build failed: %w", err)
+	}
+	req.SetBasicAuth(c.clientID, c.clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("spotify: token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("spotify: token request returned %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	var payload tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		metrics.RecordDecodeError("spotify")
+		return "", fmt.Errorf("spotify: decode token failed: %w", err)
+	}
+	if payload.AccessToken == "" {
+		return "", errors.New("spotify: empty access token")
+	}
+
+	// Refresh a little early so in-flight requests don't race the expiry.
+	lifetime := time.Duration(payload.ExpiresIn)*time.Second - 30*time.Second
+	if lifetime <= 0 {
+		lifetime = time.Minute
+	}
+	c.accessToken = payload.AccessToken
+	c.expiresAt = time.Now().Add(lifetime)
+	return c.accessToken, nil
+}
+
+// Healthy reports whether recent Spotify calls have been succeeding.
+func (c *Client) Healthy() bool {
+	return c.health.Healthy()
+}
+
+// Health returns the rolling success rate and failure state for Spotify calls.
+func (c *Client) Health() health.Status {
+	return c.health.Status()
+}
+
+// Ping checks that Spotify is reachable and the configured credentials are accepted.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.token(ctx)
+	return err
+}
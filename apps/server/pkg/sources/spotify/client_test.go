@@ -0,0 +1,93 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParsePlaylistID(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "37i9dQZF1DXcBWIGoYBM5M", want: "37i9dQZF1DXcBWIGoYBM5M"},
+		{in: "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M?si=abc", want: "37i9dQZF1DXcBWIGoYBM5M"},
+		{in: "spotify:playlist:37i9dQZF1DXcBWIGoYBM5M", want: "37i9dQZF1DXcBWIGoYBM5M"},
+		{in: "https://open.spotify.com/album/123", wantErr: true},
+		{in: "not a playlist", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		got, err := ParsePlaylistID(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("ParsePlaylistID(%q) expected error, got %q", tc.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePlaylistID(%q) returned error: %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParsePlaylistID(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestGetPlaylistFetchesTokenAndTracks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/token":
+			if _, _, ok := r.BasicAuth(); !ok {
+				t.Errorf("expected basic auth on token request")
+			}
+			w.Write([]byte(`{"access_token":"tok","token_type":"Bearer","expires_in":3600}`))
+		case "/playlists/abc123":
+			if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+				t.Errorf("expected bearer token, got %q", got)
+			}
+			w.Write([]byte(`{
+				"id": "abc123",
+				"name": "Road Trip",
+				"tracks": {
+					"items": [
+						{"track": {"id": "t1", "name": "Song", "duration_ms": 180000, "artists": [{"name": "Band"}], "album": {"name": "Record"}, "external_ids": {"isrc": "usabc1234567"}}},
+						{"track": null}
+					],
+					"next": ""
+				}
+			}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{
+		BaseURL:      server.URL,
+		AuthURL:      server.URL + "/token",
+		ClientID:     "id",
+		ClientSecret: "secret",
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	playlist, err := client.GetPlaylist(context.Background(), "abc123")
+	if err != nil {
+		t.Fatalf("GetPlaylist returned error: %v", err)
+	}
+	if len(playlist.Tracks) != 1 {
+		t.Fatalf("expected 1 track, got %d", len(playlist.Tracks))
+	}
+	if playlist.Tracks[0].ISRC != "USABC1234567" {
+		t.Errorf("expected normalized ISRC, got %q", playlist.Tracks[0].ISRC)
+	}
+}
@@ -0,0 +1,171 @@
+// Package upstreamlog logs upstream API calls (URL, status, timing) when debug mode is on and
+// collects per-request upstream timings for request logs. Credentials in query strings are
+// redacted and headers are never logged.
+package upstreamlog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+)
+
+const redacted = "REDACTED"
+
+var enabled atomic.Bool
+
+// sensitiveParams lists query parameter names (lowercased) whose values are never logged.
+var sensitiveParams = map[string]bool{
+	"token":         true,
+	"key":           true,
+	"secret":        true,
+	"api_key":       true,
+	"apikey":        true,
+	"access_token":  true,
+	"client_secret": true,
+	"password":      true,
+	"signature":     true,
+	"sig":           true,
+}
+
+// SetEnabled turns upstream debug logging on or off for every client.
+func SetEnabled(on bool) {
+	enabled.Store(on)
+}
+
+// Enabled reports whether upstream debug logging is on.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Redact returns raw with sensitive query parameter values and URL user info replaced.
+func Redact(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	clean := *u
+	if clean.User != nil {
+		clean.User = url.User(redacted)
+	}
+	if clean.RawQuery != "" {
+		query := clean.Query()
+		for name := range query {
+			if sensitive(name) {
+				query[name] = []string{redacted}
+			}
+		}
+		clean.RawQuery = query.Encode()
+	}
+	return clean.String()
+}
+
+func sensitive(name string) bool {
+	lower := strings.ToLower(name)
+	if sensitiveParams[lower] {
+		return true
+	}
+	return strings.HasSuffix(lower, "_token") || strings.HasSuffix(lower, "_secret") || strings.HasSuffix(lower, "_key")
+}
+
+// Transport wraps base so each round trip is logged under source while debug mode is on.
+func Transport(source string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{source: source, base: base}
+}
+
+type transport struct {
+	source string
+	base   http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timings, _ := req.Context().Value(timingsKey{}).(*Timings)
+	if timings == nil && !Enabled() {
+		return t.base.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if timings != nil {
+		timings.record(t.source, time.Since(start))
+	}
+	if !Enabled() {
+		return resp, err
+	}
+
+	logger := logging.FromContext(req.Context()).With(
+		"source", t.source,
+		"method", req.Method,
+		"url", Redact(req.URL),
+		"duration", time.Since(start).Round(time.Millisecond),
+	)
+	if err != nil {
+		logger.Warn("upstream call failed", "error", err)
+		return resp, err
+	}
+	logger.Info("upstream call", "status", resp.StatusCode)
+	return resp, nil
+}
+
+// SourceTiming is the call count and total time spent on one upstream source.
+type SourceTiming struct {
+	Source string
+	Calls  int
+	Total  time.Duration
+}
+
+// Timings accumulates upstream calls made on behalf of one inbound request.
+type Timings struct {
+	mu      sync.Mutex
+	sources []SourceTiming
+}
+
+type timingsKey struct{}
+
+// WithTimings attaches a fresh Timings to ctx. Transports record into it whenever the
+// outgoing request carries ctx, regardless of debug mode.
+func WithTimings(ctx context.Context) (context.Context, *Timings) {
+	timings := &Timings{}
+	return context.WithValue(ctx, timingsKey{}, timings), timings
+}
+
+func (t *Timings) record(source string, elapsed time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := range t.sources {
+		if t.sources[i].Source == source {
+			t.sources[i].Calls++
+			t.sources[i].Total += elapsed
+			return
+		}
+	}
+	t.sources = append(t.sources, SourceTiming{Source: source, Calls: 1, Total: elapsed})
+}
+
+// Sources returns per-source totals in the order sources were first called.
+func (t *Timings) Sources() []SourceTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]SourceTiming(nil), t.sources...)
+}
+
+// String formats the breakdown as "source=calls/total" pairs, or "none" without upstream calls.
+func (t *Timings) String() string {
+	sources := t.Sources()
+	if len(sources) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(sources))
+	for _, s := range sources {
+		parts = append(parts, fmt.Sprintf("%s=%d/%s", s.Source, s.Calls, s.Total.Round(time.Millisecond)))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,325 @@
+// Package wikidata reads structured statements about artists and albums from Wikidata.
+package wikidata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/metrics"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/useragent"
+)
+
+// ErrNotFound indicates the entity doesn't exist or the link isn't a Wikidata item.
+var ErrNotFound = errors.New("wikidata: entity not found")
+
+// Wikidata properties and limits used when reading awards.
+const (
+	propertyAwardReceived = "P166"
+	propertyPointInTime   = "P585"
+	// labelBatchSize is the most IDs wbgetentities accepts per request for anonymous clients.
+	labelBatchSize = 50
+)
+
+// itemIDPattern matches Wikidata item IDs such as Q11649.
+var itemIDPattern = regexp.MustCompile(`^Q[1-9][0-9]*$`)
+
+// Config describes how to connect to the Wikidata API.
+type Config struct {
+	BaseURL   string
+	UserAgent string
+	// HTTP sets the timeout, retries, response cache, and pacing.
+	HTTP httpclient.Options
+}
+
+// Client reads entities from the Wikidata action API.
+type Client struct {
+	baseURL    string
+	userAgent  string
+	httpClient *http.Client
+	health     *health.Tracker
+}
+
+// New constructs a Wikidata client.
+func New(_ context.Context, cfg Config) (*Client, error) {
+	baseURL := strings.TrimSpace(cfg.BaseURL)
+	if baseURL == "" {
+		baseURL = "https://www.wikidata.org/w/api.php"
+	}
+
+	userAgent := strings.TrimSpace(cfg.UserAgent)
+	if userAgent == "" {
+		userAgent = useragent.Default()
+	}
+
+	tracker := health.NewTracker()
+	return &Client{
+		baseURL:    baseURL,
+		userAgent:  userAgent,
+		httpClient: httpclient.New("wikidata", cfg.HTTP, 8*time.Second, tracker),
+		health:     tracker,
+	}, nil
+}
+
+type entitiesResponse struct {
+	Entities map[string]entity `json:"entities"`
+	Error    *struct {
+		Code string `json:"code"`
+		Info string `json:"info"`
+	} `json:"error"`
+}
+
+type entity struct {
+	Missing   *string             `json:"missing"`
+	Labels    map[string]label    `json:"labels"`
+	Claims    map[string][]claim  `json:"claims"`
+	Sitelinks map[string]sitelink `json:"sitelinks"`
+}
+
+type sitelink struct {
+	Title string `json:"title"`
+}
+
+type label struct {
+	Value string `json:"value"`
+}
+
+type claim struct {
+	Rank       string            `json:"rank"`
+	MainSnak   snak              `json:"mainsnak"`
+	Qualifiers map[string][]snak `json:"qualifiers"`
+}
+
+type snak struct {
+	DataValue struct {
+		Value json.RawMessage `json:"value"`
+	} `json:"datavalue"`
+}
+
+// itemID returns the item a wikibase-item snak points at.
+func (s snak) itemID() string {
+	var value struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(s.DataValue.Value, &value) != nil {
+		return ""
+	}
+	return value.ID
+}
+
+// year returns the year of a time snak such as "+1992-00-00T00:00:00Z".
+func (s snak) year() int {
+	var value struct {
+		Time string `json:"time"`
+	}
+	if json.Unmarshal(s.DataValue.Value, &value) != nil {
+		return 0
+	}
+	digits := strings.TrimLeft(value.Time, "+")
+	if i := strings.IndexByte(digits, '-'); i > 0 {
+		digits = digits[:i]
+	}
+	year, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0
+	}
+	return year
+}
+
+// GetAwards returns the awards ("award received", P166) recorded on the entity a Wikidata link
+// points at, oldest first.
+func (c *Client) GetAwards(ctx context.Context, entityURL string) ([]data.Award, error) {
+	id := ItemID(entityURL)
+	if id == "" {
+		return nil, ErrNotFound
+	}
+
+	entities, err := c.getEntities(ctx, []string{id}, "claims")
+	if err != nil {
+		return nil, err
+	}
+	subject, ok := entities[id]
+	if !ok || subject.Missing != nil {
+		return nil, ErrNotFound
+	}
+
+	var awards []data.Award
+	var awardIDs []string
+	for _, received := range subject.Claims[propertyAwardReceived] {
+		awardID := received.MainSnak.itemID()
+		if awardID == "" || received.Rank == "deprecated" {
+			continue
+		}
+		award := data.Award{ID: awardID}
+		for _, when := range received.Qualifiers[propertyPointInTime] {
+			if year := when.year(); year > 0 {
+				award.Year = year
+				break
+			}
+		}
+		awards = append(awards, award)
+		awardIDs = append(awardIDs, awardID)
+	}
+	if len(awards) == 0 {
+		return nil, nil
+	}
+
+	labels, err := c.labels(ctx, awardIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range awards {
+		awards[i].Name, awards[i].Category = splitAwardLabel(labels[awards[i].ID])
+		if awards[i].Name == "" {
+			awards[i].Name = awards[i].ID
+		}
+	}
+
+	sort.SliceStable(awards, func(i, j int) bool {
+		if awards[i].Year != awards[j].Year {
+			return awards[i].Year < awards[j].Year
+		}
+		return awards[i].Name < awards[j].Name
+	})
+	return awards, nil
+}
+
+// WikipediaTitle returns the title of the article item links to on the language edition of
+// Wikipedia (its "enwiki" sitelink for "en"), or ErrNotFound when there is none.
+func (c *Client) WikipediaTitle(ctx context.Context, itemID, language string) (string, error) {
+	if !itemIDPattern.MatchString(itemID) || language == "" {
+		return "", ErrNotFound
+	}
+
+	entities, err := c.getEntities(ctx, []string{itemID}, "sitelinks")
+	if err != nil {
+		return "", err
+	}
+	subject, ok := entities[itemID]
+	if !ok || subject.Missing != nil {
+		return "", ErrNotFound
+	}
+	link, ok := subject.Sitelinks[language+"wiki"]
+	if !ok || link.Title == "" {
+		return "", ErrNotFound
+	}
+	return link.Title, nil
+}
+
+// splitAwardLabel splits labels such as "Grammy Award for Best Rock Album" into the award and
+// its category. Labels without a category are returned whole.
+func splitAwardLabel(label string) (string, string) {
+	name, category, ok := strings.Cut(label, " for ")
+	if !ok {
+		return strings.TrimSpace(label), ""
+	}
+	return strings.TrimSpace(name), strings.TrimSpace(category)
+}
+
+// labels resolves English labels for the given items, batching to the API's limit.
+func (c *Client) labels(ctx context.Context, ids []string) (map[string]string, error) {
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	labels := make(map[string]string, len(unique))
+	for start := 0; start < len(unique); start += labelBatchSize {
+		end := min(start+labelBatchSize, len(unique))
+		entities, err := c.getEntities(ctx, unique[start:end], "labels")
+		if err != nil {
+			return nil, err
+		}
+		for id, e := range entities {
+			if l, ok := e.Labels["en"]; ok {
+				labels[id] = l.Value
+			}
+		}
+	}
+	return labels, nil
+}
+
+func (c *Client) getEntities(ctx context.Context, ids []string, props string) (map[string]entity, error) {
+	params := url.Values{}
+	params.Set("action", "wbgetentities")
+	params.Set("ids", strings.Join(ids, "|"))
+	params.Set("props", props)
+	params.Set("languages", "en")
+	params.Set("format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("wikidata: request build failed: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("wikidata: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("wikidata: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	var payload entitiesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		metrics.RecordDecodeError("wikidata")
+		return nil, fmt.Errorf("wikidata: decode failed: %w", err)
+	}
+	if payload.Error != nil {
+		if payload.Error.Code == "no-such-entity" {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("wikidata: %s: %s", payload.Error.Code, payload.Error.Info)
+	}
+	return payload.Entities, nil
+}
+
+// ItemID extracts the item ID from a Wikidata URL such as https://www.wikidata.org/wiki/Q11649,
+// returning "" for anything else.
+func ItemID(entityURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(entityURL))
+	if err != nil || !strings.HasSuffix(strings.ToLower(parsed.Hostname()), "wikidata.org") {
+		return ""
+	}
+	id := parsed.Path[strings.LastIndex(parsed.Path, "/")+1:]
+	if !itemIDPattern.MatchString(id) {
+		return ""
+	}
+	return id
+}
+
+// Healthy reports whether recent Wikidata calls have been succeeding.
+func (c *Client) Healthy() bool {
+	return c.health.Healthy()
+}
+
+// Health returns the rolling success rate and failure state for Wikidata calls.
+func (c *Client) Health() health.Status {
+	return c.health.Status()
+}
+
+// Ping checks that the Wikidata API is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	return health.Ping(ctx, c.httpClient, c.baseURL+"?action=query&meta=siteinfo&format=json", c.userAgent)
+}
@@ -0,0 +1,114 @@
+package wikidata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetAwardsResolvesLabelsAndYears(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		switch query.Get("props") {
+		case "claims":
+			if query.Get("ids") != "Q11649" {
+				t.Fatalf("unexpected ids %q", query.Get("ids"))
+			}
+			w.Write([]byte(`{"entities":{"Q11649":{"claims":{"P166":[
+				{"rank":"normal","mainsnak":{"datavalue":{"value":{"id":"Q2"}}},"qualifiers":{"P585":[{"datavalue":{"value":{"time":"+2014-00-00T00:00:00Z"}}}]}},
+				{"rank":"normal","mainsnak":{"datavalue":{"value":{"id":"Q1"}}},"qualifiers":{"P585":[{"datavalue":{"value":{"time":"+1992-09-09T00:00:00Z"}}}]}},
+				{"rank":"deprecated","mainsnak":{"datavalue":{"value":{"id":"Q3"}}}}
+			]}}}}`))
+		case "labels":
+			if query.Get("ids") != "Q2|Q1" {
+				t.Fatalf("unexpected label ids %q", query.Get("ids"))
+			}
+			w.Write([]byte(`{"entities":{
+				"Q1":{"labels":{"en":{"value":"MTV Video Music Award for Best Alternative Video"}}},
+				"Q2":{"labels":{"en":{"value":"Rock and Roll Hall of Fame"}}}
+			}}`))
+		default:
+			t.Fatalf("unexpected props %q", query.Get("props"))
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	awards, err := client.GetAwards(context.Background(), "https://www.wikidata.org/wiki/Q11649")
+	if err != nil {
+		t.Fatalf("GetAwards: %v", err)
+	}
+	if len(awards) != 2 {
+		t.Fatalf("expected 2 awards, got %+v", awards)
+	}
+	first := awards[0]
+	if first.ID != "Q1" || first.Name != "MTV Video Music Award" || first.Category != "Best Alternative Video" || first.Year != 1992 {
+		t.Fatalf("unexpected first award %+v", first)
+	}
+	if second := awards[1]; second.Name != "Rock and Roll Hall of Fame" || second.Category != "" || second.Year != 2014 {
+		t.Fatalf("unexpected second award %+v", second)
+	}
+}
+
+func TestGetAwardsMissingEntity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"error":{"code":"no-such-entity","info":"Could not find an entity with the ID \"Q999\"."}}`))
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if _, err := client.GetAwards(context.Background(), "https://www.wikidata.org/wiki/Q999"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestWikipediaTitle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("props") != "sitelinks" {
+			t.Errorf("expected a sitelinks request, got %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"entities":{"Q11649":{"sitelinks":{"enwiki":{"site":"enwiki","title":"Nirvana (band)"},"dewiki":{"site":"dewiki","title":"Nirvana (US-amerikanische Band)"}}}}}`))
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if title, err := client.WikipediaTitle(context.Background(), "Q11649", "en"); err != nil || title != "Nirvana (band)" {
+		t.Fatalf("WikipediaTitle(en) = %q, %v", title, err)
+	}
+	if _, err := client.WikipediaTitle(context.Background(), "Q11649", "fr"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound without a frwiki sitelink, got %v", err)
+	}
+	if _, err := client.WikipediaTitle(context.Background(), "not-an-item", "en"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a malformed ID, got %v", err)
+	}
+}
+
+func TestItemID(t *testing.T) {
+	cases := map[string]string{
+		"https://www.wikidata.org/wiki/Q11649":      "Q11649",
+		"http://wikidata.org/entity/Q5":             "Q5",
+		"https://www.wikidata.org/wiki/Property:P1": "",
+		"https://en.wikipedia.org/wiki/Q11649":      "",
+		"":                                          "",
+	}
+	for input, want := range cases {
+		if got := ItemID(input); got != want {
+			t.Fatalf("ItemID(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
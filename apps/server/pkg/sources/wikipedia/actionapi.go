@@ -0,0 +1,121 @@
+package wikipedia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/metrics"
+)
+
+// actionQueryResponse is the subset of an action=query response (formatversion=2) that
+// getActionSummary reads.
+type actionQueryResponse struct {
+	Query struct {
+		Pages []actionPage `json:"pages"`
+	} `json:"query"`
+	Error *struct {
+		Code string `json:"code"`
+		Info string `json:"info"`
+	} `json:"error"`
+}
+
+type actionPage struct {
+	Title     string        `json:"title"`
+	Missing   bool          `json:"missing"`
+	Invalid   bool          `json:"invalid"`
+	Extract   string        `json:"extract"`
+	FullURL   string        `json:"fullurl"`
+	LastRevID int64         `json:"lastrevid"`
+	Original  *pageImageRef `json:"original"`
+	PageProps struct {
+		Disambiguation *string `json:"disambiguation"`
+	} `json:"pageprops"`
+}
+
+// actionEndpoint returns the Action API on the same edition as the REST API at base.
+func (c *Client) actionEndpoint(base string) string {
+	if base == c.baseURL {
+		return c.actionURL
+	}
+	edition, err := url.Parse(base)
+	if err != nil {
+		return c.actionURL
+	}
+	endpoint, err := url.Parse(c.actionURL)
+	if err != nil {
+		return c.actionURL
+	}
+	endpoint.Host = edition.Host
+	return endpoint.String()
+}
+
+// getActionSummary reads the plain-text lead section, canonical URL, revision, and lead image of
+// title through the MediaWiki Action API, following redirects the way the REST API does.
+func (c *Client) getActionSummary(ctx context.Context, endpoint, title string) (*Summary, error) {
+	params := url.Values{
+		"action":        {"query"},
+		"format":        {"json"},
+		"formatversion": {"2"},
+		"prop":          {"extracts|info|pageimages|pageprops"},
+		"exintro":       {"1"},
+		"explaintext":   {"1"},
+		"inprop":        {"url"},
+		"piprop":        {"original"},
+		"ppprop":        {"disambiguation"},
+		"redirects":     {"1"},
+		"titles":        {title},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("wikipedia: request build failed: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("wikipedia: action api request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("wikipedia: action api unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	var payload actionQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		metrics.RecordDecodeError("wikipedia")
+		return nil, fmt.Errorf("wikipedia: action api decode failed: %w", err)
+	}
+	if payload.Error != nil {
+		return nil, fmt.Errorf("wikipedia: action api error %s: %s", payload.Error.Code, payload.Error.Info)
+	}
+	if len(payload.Query.Pages) == 0 {
+		return nil, ErrNotFound
+	}
+
+	page := payload.Query.Pages[0]
+	if page.Missing || page.Invalid || page.PageProps.Disambiguation != nil || strings.Contains(strings.ToLower(page.Extract), "may refer to") {
+		return nil, ErrNotFound
+	}
+
+	summary := &Summary{
+		Title:   page.Title,
+		Extract: page.Extract,
+		Type:    "standard",
+		Image:   pageImage(summaryResponse{OriginalImage: page.Original}),
+		URL:     page.FullURL,
+	}
+	if page.LastRevID > 0 {
+		summary.Revision = strconv.FormatInt(page.LastRevID, 10)
+	}
+	return summary, nil
+}
@@ -10,7 +10,14 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/metrics"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/useragent"
 )
 
 // ErrNotFound indicates the requested Wikipedia page was not found.
@@ -18,16 +25,53 @@ var ErrNotFound = errors.New("wikipedia: page not found")
 
 // Config describes how to connect to the Wikipedia API.
 type Config struct {
-	BaseURL   string
+	BaseURL string
+	// ActionURL is the MediaWiki Action API (api.php) used when the REST API at BaseURL fails.
+	// Empty derives it from BaseURL: .../api/rest_v1 becomes .../w/api.php.
+	ActionURL string
+	// Sitelinks, when set, lets GetBiographyByWikidataID find an item's article.
+	Sitelinks SitelinkResolver
 	UserAgent string
-	Timeout   time.Duration
+	// HTTP sets the timeout, retries, response cache, and pacing.
+	HTTP httpclient.Options
 }
 
+// languageCodePattern accepts the language codes Wikipedia uses as subdomains.
+var languageCodePattern = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// summaryCacheTTL bounds how long a resolved artist summary is reused between the biography
+// and image lookups made for the same artist.
+const summaryCacheTTL = 10 * time.Minute
+
+// missingTitleTTL bounds how long a title that resolved to no usable page is skipped. Missing
+// pages rarely appear, so this outlives the summary cache.
+const missingTitleTTL = time.Hour
+
 // Client issues requests against the Wikipedia API.
 type Client struct {
 	baseURL    string
+	actionURL  string
+	sitelinks  SitelinkResolver
 	userAgent  string
 	httpClient *http.Client
+	health     *health.Tracker
+
+	mu        sync.Mutex
+	summaries map[string]cachedSummary
+	missing   map[string]time.Time
+	inflight  map[string]*resolution
+}
+
+// resolution is an artist summary lookup shared by concurrent callers for the same artist.
+type resolution struct {
+	done    chan struct{}
+	summary *Summary
+	err     error
+}
+
+type cachedSummary struct {
+	summary   *Summary
+	expiresAt time.Time
 }
 
 // New constructs a Wikipedia API client.
@@ -38,85 +82,299 @@ func New(_ context.Context, cfg Config) (*Client, error) {
 	}
 	baseURL = strings.TrimRight(baseURL, "/")
 
-	userAgent := strings.TrimSpace(cfg.UserAgent)
-	if userAgent == "" {
-		userAgent = "FreqShow/1.0 (https://github.com/adamlacasse/freq-show)"
+	actionURL := strings.TrimSpace(cfg.ActionURL)
+	if actionURL == "" {
+		actionURL = strings.TrimSuffix(baseURL, "/api/rest_v1") + "/w/api.php"
 	}
 
-	timeout := cfg.Timeout
-	if timeout <= 0 {
-		timeout = 10 * time.Second
+	userAgent := strings.TrimSpace(cfg.UserAgent)
+	if userAgent == "" {
+		userAgent = useragent.Default()
 	}
 
+	tracker := health.NewTracker()
 	return &Client{
-		baseURL:   baseURL,
-		userAgent: userAgent,
-		httpClient: &http.Client{
-			Timeout: timeout,
-		},
+		baseURL:    baseURL,
+		actionURL:  actionURL,
+		sitelinks:  cfg.Sitelinks,
+		userAgent:  userAgent,
+		httpClient: httpclient.New("wikipedia", cfg.HTTP, 10*time.Second, tracker),
+		health:     tracker,
+		summaries:  make(map[string]cachedSummary),
+		missing:    make(map[string]time.Time),
+		inflight:   make(map[string]*resolution),
 	}, nil
 }
 
 // Summary represents a Wikipedia page summary.
 type Summary struct {
-	Title   string `json:"title"`
-	Extract string `json:"extract"`
-	Type    string `json:"type"`
+	Title   string      `json:"title"`
+	Extract string      `json:"extract"`
+	Type    string      `json:"type"`
+	Image   *data.Image `json:"image,omitempty"`
+	// URL is the article's address for readers; Revision identifies the version summarized.
+	URL      string `json:"url,omitempty"`
+	Revision string `json:"revision,omitempty"`
 }
 
 type summaryResponse struct {
-	Type         string `json:"type"`
-	Title        string `json:"title"`
-	Displaytitle string `json:"displaytitle"`
-	Extract      string `json:"extract"`
-	ExtractHTML  string `json:"extract_html"`
+	Type          string        `json:"type"`
+	Title         string        `json:"title"`
+	Displaytitle  string        `json:"displaytitle"`
+	Extract       string        `json:"extract"`
+	ExtractHTML   string        `json:"extract_html"`
+	Thumbnail     *pageImageRef `json:"thumbnail"`
+	OriginalImage *pageImageRef `json:"originalimage"`
+	Revision      string        `json:"revision"`
+	ContentURLs   struct {
+		Desktop struct {
+			Page string `json:"page"`
+		} `json:"desktop"`
+	} `json:"content_urls"`
+}
+
+type pageImageRef struct {
+	Source string `json:"source"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
 }
 
 // GetArtistBiography attempts to fetch a biography for an artist by searching Wikipedia.
 func (c *Client) GetArtistBiography(ctx context.Context, artistName string) (string, error) {
-	if strings.TrimSpace(artistName) == "" {
-		return "", errors.New("wikipedia: artist name is required")
+	summary, err := c.resolveArtistSummary(ctx, c.baseURL, artistName)
+	if err != nil {
+		return "", err
+	}
+	return cleanExtract(summary.Extract), nil
+}
+
+// GetArtistBiographyIn fetches the biography from the Wikipedia edition for language, an ISO
+// 639 code such as "de". It returns ErrNotFound when the configured base URL has no language
+// editions to switch between.
+func (c *Client) GetArtistBiographyIn(ctx context.Context, language, artistName string) (string, error) {
+	base, ok := c.languageBaseURL(language)
+	if !ok {
+		return "", ErrNotFound
+	}
+	summary, err := c.resolveArtistSummary(ctx, base, artistName)
+	if err != nil {
+		return "", err
+	}
+	return cleanExtract(summary.Extract), nil
+}
+
+// ArtistBiographyAttribution credits the page GetArtistBiographyIn reads for artistName on the
+// language edition ("" or "en" for GetArtistBiography's). Pages resolve through the same
+// short-lived cache, so calling it right after the biography lookup makes no extra requests.
+func (c *Client) ArtistBiographyAttribution(ctx context.Context, language, artistName string) (*data.Attribution, error) {
+	base, ok := c.languageBaseURL(language)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	summary, err := c.resolveArtistSummary(ctx, base, artistName)
+	if err != nil {
+		return nil, err
+	}
+	pageURL := summary.URL
+	if pageURL == "" {
+		pageURL = articleURL(base, summary.Title)
+	}
+	return data.NewWikipediaAttribution(summary.Title, pageURL, summary.Revision), nil
+}
+
+// articleURL builds the reader-facing address of title on the edition at base, for responses
+// that omit content_urls.
+func articleURL(base, title string) string {
+	parsed, err := url.Parse(base)
+	if err != nil {
+		return ""
+	}
+	return parsed.Scheme + "://" + parsed.Host + "/wiki/" + url.PathEscape(strings.ReplaceAll(title, " ", "_"))
+}
+
+// languageBaseURL swaps the language subdomain of the configured English endpoint
+// (en.wikipedia.org) for language's.
+func (c *Client) languageBaseURL(language string) (string, bool) {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language == "" || language == "en" {
+		return c.baseURL, true
+	}
+	if !languageCodePattern.MatchString(language) {
+		return "", false
+	}
+	parsed, err := url.Parse(c.baseURL)
+	if err != nil || !strings.HasPrefix(parsed.Host, "en.") {
+		return "", false
+	}
+	parsed.Host = language + parsed.Host[len("en"):]
+	return parsed.String(), true
+}
+
+// Name identifies this source in image metadata and the fallback chain.
+func (c *Client) Name() string {
+	return "wikipedia"
+}
+
+// ArtistImages returns the lead image of the artist's Wikipedia page, if it has one.
+func (c *Client) ArtistImages(ctx context.Context, _ string, artistName string) ([]data.Image, error) {
+	summary, err := c.resolveArtistSummary(ctx, c.baseURL, artistName)
+	if err != nil {
+		return nil, err
 	}
+	if summary.Image == nil {
+		return nil, nil
+	}
+	return []data.Image{*summary.Image}, nil
+}
 
-	// First, try to get the page summary directly
-	summary, err := c.getPageSummary(ctx, artistName)
-	if err == nil && summary.Extract != "" {
-		return c.cleanExtract(summary.Extract), nil
+// resolveArtistSummary finds the artist's page on the edition at base by trying the bare name
+// and then common disambiguation suffixes. Successful resolutions are cached briefly per
+// edition and artist name, and concurrent lookups for the same artist share a single set of
+// upstream requests.
+func (c *Client) resolveArtistSummary(ctx context.Context, base, artistName string) (*Summary, error) {
+	if strings.TrimSpace(artistName) == "" {
+		return nil, errors.New("wikipedia: artist name is required")
 	}
 
-	// If direct lookup fails, try with "band" suffix for groups
-	if err == ErrNotFound {
-		bandName := artistName + " (band)"
-		summary, err = c.getPageSummary(ctx, bandName)
-		if err == nil && summary.Extract != "" {
-			return c.cleanExtract(summary.Extract), nil
+	key := base + "|" + strings.ToLower(strings.TrimSpace(artistName))
+	c.mu.Lock()
+	if cached, ok := c.summaries[key]; ok && time.Now().Before(cached.expiresAt) {
+		c.mu.Unlock()
+		return cached.summary, nil
+	}
+	if pending, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-pending.done:
+			return pending.summary, pending.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
 		}
 	}
+	pending := &resolution{done: make(chan struct{})}
+	c.inflight[key] = pending
+	c.mu.Unlock()
+
+	pending.summary, pending.err = c.fetchArtistSummary(ctx, base, artistName)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if pending.err == nil {
+		c.summaries[key] = cachedSummary{summary: pending.summary, expiresAt: time.Now().Add(summaryCacheTTL)}
+	}
+	c.mu.Unlock()
+	close(pending.done)
+
+	return pending.summary, pending.err
+}
+
+// candidateResult is the outcome of fetching one candidate title.
+type candidateResult struct {
+	index   int
+	summary *Summary
+	err     error
+}
+
+// fetchArtistSummary requests every candidate title not known to be missing in parallel and
+// returns the most specific match: the bare name wins over "band", "musician", and "singer"
+// suffixes. The suffixes are English, so other editions only try the bare name. Outstanding
+// requests are cancelled once no better candidate can succeed.
+func (c *Client) fetchArtistSummary(ctx context.Context, base, artistName string) (*Summary, error) {
+	candidates := []string{artistName}
+	if base == c.baseURL {
+		candidates = append(candidates, artistName+" (band)", artistName+" (musician)", artistName+" (singer)")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	// Try with "musician" suffix
-	if err == ErrNotFound {
-		musicianName := artistName + " (musician)"
-		summary, err = c.getPageSummary(ctx, musicianName)
-		if err == nil && summary.Extract != "" {
-			return c.cleanExtract(summary.Extract), nil
+	// Buffered so abandoned requests can finish after an earlier candidate wins.
+	results := make(chan candidateResult, len(candidates))
+	outcomes := make([]*candidateResult, len(candidates))
+	for i, title := range candidates {
+		if c.knownMissing(base, title) {
+			outcomes[i] = &candidateResult{index: i, err: ErrNotFound}
+			continue
 		}
+		go func(i int, title string) {
+			summary, err := c.getPageSummary(ctx, base, title)
+			if err == nil && summary.Extract == "" {
+				summary, err = nil, ErrNotFound
+			}
+			if errors.Is(err, ErrNotFound) {
+				c.markMissing(base, title)
+			}
+			results <- candidateResult{index: i, summary: summary, err: err}
+		}(i, title)
 	}
 
-	// Try with "singer" suffix
-	if err == ErrNotFound {
-		singerName := artistName + " (singer)"
-		summary, err = c.getPageSummary(ctx, singerName)
-		if err == nil && summary.Extract != "" {
-			return c.cleanExtract(summary.Extract), nil
+	for {
+		var firstErr error
+		settled := true
+		for _, outcome := range outcomes {
+			if outcome == nil {
+				settled = false
+				break
+			}
+			if outcome.err == nil {
+				return outcome.summary, nil
+			}
+			if firstErr == nil && !errors.Is(outcome.err, ErrNotFound) {
+				firstErr = outcome.err
+			}
+		}
+		if settled {
+			if firstErr != nil {
+				return nil, firstErr
+			}
+			return nil, ErrNotFound
 		}
+
+		result := <-results
+		outcomes[result.index] = &result
 	}
+}
 
-	return "", ErrNotFound
+// knownMissing reports whether a title recently resolved to no usable page on the edition at base.
+func (c *Client) knownMissing(base, title string) bool {
+	key := base + "|" + strings.ToLower(title)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expiresAt, ok := c.missing[key]
+	if !ok {
+		return false
+	}
+	if time.Now().After(expiresAt) {
+		delete(c.missing, key)
+		return false
+	}
+	return true
 }
 
-func (c *Client) getPageSummary(ctx context.Context, title string) (*Summary, error) {
+func (c *Client) markMissing(base, title string) {
+	c.mu.Lock()
+	c.missing[base+"|"+strings.ToLower(title)] = time.Now().Add(missingTitleTTL)
+	c.mu.Unlock()
+}
+
+// getPageSummary reads title's summary from the REST API on the edition at base, falling back
+// to the Action API when the REST endpoint errors or can't be reached, as happens on some
+// mirrors and language editions. A missing page is final either way.
+func (c *Client) getPageSummary(ctx context.Context, base, title string) (*Summary, error) {
+	summary, err := c.getRESTSummary(ctx, base, title)
+	if err == nil || errors.Is(err, ErrNotFound) || ctx.Err() != nil {
+		return summary, err
+	}
+	fallback, fallbackErr := c.getActionSummary(ctx, c.actionEndpoint(base), title)
+	if fallbackErr != nil && !errors.Is(fallbackErr, ErrNotFound) {
+		return nil, errors.Join(err, fallbackErr)
+	}
+	return fallback, fallbackErr
+}
+
+func (c *Client) getRESTSummary(ctx context.Context, base, title string) (*Summary, error) {
 	encodedTitle := url.PathEscape(title)
-	endpoint := fmt.Sprintf("%s/page/summary/%s", c.baseURL, encodedTitle)
+	endpoint := fmt.Sprintf("%s/page/summary/%s", base, encodedTitle)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
@@ -135,6 +393,7 @@ func (c *Client) getPageSummary(ctx context.Context, title string) (*Summary, er
 	case http.StatusOK:
 		var payload summaryResponse
 		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			metrics.RecordDecodeError("wikipedia")
 			return nil, fmt.Errorf("wikipedia: decode failed: %w", err)
 		}
 
@@ -144,9 +403,12 @@ func (c *Client) getPageSummary(ctx context.Context, title string) (*Summary, er
 		}
 
 		return &Summary{
-			Title:   payload.Title,
-			Extract: payload.Extract,
-			Type:    payload.Type,
+			Title:    payload.Title,
+			Extract:  payload.Extract,
+			Type:     payload.Type,
+			Image:    pageImage(payload),
+			URL:      payload.ContentURLs.Desktop.Page,
+			Revision: payload.Revision,
 		}, nil
 	case http.StatusNotFound:
 		return nil, ErrNotFound
@@ -156,56 +418,35 @@ func (c *Client) getPageSummary(ctx context.Context, title string) (*Summary, er
 	}
 }
 
-// cleanExtract processes the Wikipedia extract to make it more suitable for display.
-func (c *Client) cleanExtract(extract string) string {
-	if extract == "" {
-		return ""
+// pageImage picks the page's lead image, preferring the original over the thumbnail.
+func pageImage(payload summaryResponse) *data.Image {
+	ref := payload.OriginalImage
+	if ref == nil || ref.Source == "" {
+		ref = payload.Thumbnail
 	}
-
-	// Remove common Wikipedia artifacts
-	cleaned := extract
-
-	// Remove pronunciation guides in parentheses at the start
-	pronounceRegex := regexp.MustCompile(`^[^(]*\([^)]*pronunciation[^)]*\)\s*`)
-	cleaned = pronounceRegex.ReplaceAllString(cleaned, "")
-
-	// Remove "listen" audio links
-	listenRegex := regexp.MustCompile(`\s*\([^)]*listen[^)]*\)\s*`)
-	cleaned = listenRegex.ReplaceAllString(cleaned, " ")
-
-	// Clean up multiple spaces
-	spaceRegex := regexp.MustCompile(`\s+`)
-	cleaned = spaceRegex.ReplaceAllString(cleaned, " ")
-
-	// Trim whitespace
-	cleaned = strings.TrimSpace(cleaned)
-
-	// Limit length to first 3 sentences or 500 characters, whichever comes first
-	sentences := strings.Split(cleaned, ". ")
-	if len(sentences) > 3 {
-		cleaned = strings.Join(sentences[:3], ". ") + "."
+	if ref == nil || ref.Source == "" {
+		return nil
 	}
-
-	if len(cleaned) > 500 {
-		// Find the last sentence that fits within 500 characters
-		parts := strings.Split(cleaned, ". ")
-		result := ""
-		for _, part := range parts {
-			if len(result+part+". ") <= 500 {
-				if result == "" {
-					result = part
-				} else {
-					result += ". " + part
-				}
-			} else {
-				break
-			}
-		}
-		if result != "" && !strings.HasSuffix(result, ".") {
-			result += "."
-		}
-		cleaned = result
+	return &data.Image{
+		Type:   data.ImageTypePhoto,
+		URL:    ref.Source,
+		Width:  ref.Width,
+		Height: ref.Height,
+		Source: "wikipedia",
 	}
+}
+
+// Healthy reports whether recent Wikipedia calls have been succeeding.
+func (c *Client) Healthy() bool {
+	return c.health.Healthy()
+}
+
+// Health returns the rolling success rate and failure state for Wikipedia calls.
+func (c *Client) Health() health.Status {
+	return c.health.Status()
+}
 
-	return cleaned
+// Ping checks that Wikipedia is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	return health.Ping(ctx, c.httpClient, c.baseURL+"/page/summary/Music", c.userAgent)
 }
@@ -0,0 +1,243 @@
+package wikipedia
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+)
+
+func TestResolveArtistSummaryPrefersBareTitle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch strings.TrimPrefix(r.URL.Path, "/page/summary/") {
+		case "Low":
+			w.Write([]byte(`{"type":"standard","title":"Low","extract":"Low are an American band."}`))
+		case "Low (band)":
+			w.Write([]byte(`{"type":"standard","title":"Low (band)","extract":"Low (band) page."}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	summary, err := client.resolveArtistSummary(context.Background(), client.baseURL, "Low")
+	if err != nil {
+		t.Fatalf("resolveArtistSummary: %v", err)
+	}
+	if summary.Title != "Low" {
+		t.Fatalf("expected bare title to win, got %q", summary.Title)
+	}
+}
+
+func TestResolveArtistSummaryCachesMissingTitles(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		title := strings.TrimPrefix(r.URL.Path, "/page/summary/")
+		mu.Lock()
+		requests[title]++
+		mu.Unlock()
+		if title == "Nirvana (band)" {
+			w.Write([]byte(`{"type":"standard","title":"Nirvana (band)","extract":"Nirvana was an American rock band."}`))
+			return
+		}
+		if title == "Nirvana" {
+			w.Write([]byte(`{"type":"disambiguation","title":"Nirvana","extract":"Nirvana may refer to:"}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	summary, err := client.resolveArtistSummary(context.Background(), client.baseURL, "Nirvana")
+	if err != nil {
+		t.Fatalf("resolveArtistSummary: %v", err)
+	}
+	if summary.Title != "Nirvana (band)" {
+		t.Fatalf("expected band page, got %q", summary.Title)
+	}
+
+	// Drop the resolved summary so the next lookup goes back to the candidates.
+	client.mu.Lock()
+	delete(client.summaries, client.baseURL+"|nirvana")
+	client.mu.Unlock()
+
+	if _, err := client.resolveArtistSummary(context.Background(), client.baseURL, "Nirvana"); err != nil {
+		t.Fatalf("second resolveArtistSummary: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests["Nirvana"] != 1 {
+		t.Fatalf("expected disambiguation page to be requested once, got %d", requests["Nirvana"])
+	}
+	if requests["Nirvana (band)"] != 2 {
+		t.Fatalf("expected band page to be requested twice, got %d", requests["Nirvana (band)"])
+	}
+}
+
+func TestResolveArtistSummaryNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if _, err := client.resolveArtistSummary(context.Background(), client.baseURL, "Nobody"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestLanguageBaseURL(t *testing.T) {
+	client := &Client{baseURL: "https://en.wikipedia.org/api/rest_v1"}
+	tests := []struct {
+		language string
+		want     string
+		ok       bool
+	}{
+		{"", "https://en.wikipedia.org/api/rest_v1", true},
+		{"en", "https://en.wikipedia.org/api/rest_v1", true},
+		{"DE", "https://de.wikipedia.org/api/rest_v1", true},
+		{"evil.example.com/", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := client.languageBaseURL(tt.language)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("languageBaseURL(%q) = %q, %v; want %q, %v", tt.language, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	custom := &Client{baseURL: "http://127.0.0.1:9000"}
+	if _, ok := custom.languageBaseURL("de"); ok {
+		t.Fatal("expected a base URL without a language subdomain to be rejected")
+	}
+}
+
+func TestArtistBiographyAttribution(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch strings.TrimPrefix(r.URL.Path, "/page/summary/") {
+		case "Nirvana":
+			requests++
+			w.Write([]byte(`{"type":"standard","title":"Nirvana","extract":"Nirvana was an American rock band.","revision":"1234",` +
+				`"content_urls":{"desktop":{"page":"https://en.wikipedia.org/wiki/Nirvana_(band)"}}}`))
+		case "Low":
+			w.Write([]byte(`{"type":"standard","title":"Low (band)","extract":"Low are an American band."}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if _, err := client.GetArtistBiography(context.Background(), "Nirvana"); err != nil {
+		t.Fatalf("GetArtistBiography: %v", err)
+	}
+	attribution, err := client.ArtistBiographyAttribution(context.Background(), "", "Nirvana")
+	if err != nil {
+		t.Fatalf("ArtistBiographyAttribution: %v", err)
+	}
+	if attribution.URL != "https://en.wikipedia.org/wiki/Nirvana_(band)" || attribution.Revision != "1234" || attribution.License == "" {
+		t.Fatalf("unexpected attribution: %+v", attribution)
+	}
+	if requests != 1 {
+		t.Fatalf("expected the attribution to reuse the cached summary, got %d requests", requests)
+	}
+
+	attribution, err = client.ArtistBiographyAttribution(context.Background(), "en", "Low")
+	if err != nil {
+		t.Fatalf("ArtistBiographyAttribution: %v", err)
+	}
+	if want := server.URL + "/wiki/Low_%28band%29"; attribution.URL != want {
+		t.Fatalf("expected the article URL to be derived as %q, got %q", want, attribution.URL)
+	}
+}
+
+func TestGetArtistBiographyFallsBackToActionAPI(t *testing.T) {
+	var actionQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/w/api.php":
+			actionQuery = r.URL.RawQuery
+			if r.URL.Query().Get("titles") != "Nirvana" {
+				w.Write([]byte(`{"query":{"pages":[{"title":"` + r.URL.Query().Get("titles") + `","missing":true}]}}`))
+				return
+			}
+			w.Write([]byte(`{"batchcomplete":true,"query":{"pages":[{"pageid":21231,"title":"Nirvana (band)",` +
+				`"extract":"Nirvana was an American rock band formed in Aberdeen, Washington, in 1987.",` +
+				`"fullurl":"https://en.wikipedia.org/wiki/Nirvana_(band)","lastrevid":1234,` +
+				`"original":{"source":"https://upload.wikimedia.org/nirvana.jpg","width":800,"height":600}}]}}`))
+		default:
+			http.Error(w, "upstream unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL + "/api/rest_v1", HTTP: httpclient.Options{Retry: retry.Policy{MaxAttempts: 1}}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	biography, err := client.GetArtistBiography(context.Background(), "Nirvana")
+	if err != nil {
+		t.Fatalf("GetArtistBiography: %v", err)
+	}
+	if biography != "Nirvana was an American rock band formed in Aberdeen, Washington, in 1987." {
+		t.Fatalf("unexpected biography %q", biography)
+	}
+	if !strings.Contains(actionQuery, "prop=extracts") || !strings.Contains(actionQuery, "explaintext=1") {
+		t.Errorf("expected a plain-text extracts query, got %q", actionQuery)
+	}
+
+	attribution, err := client.ArtistBiographyAttribution(context.Background(), "", "Nirvana")
+	if err != nil || attribution.Revision != "1234" || attribution.URL != "https://en.wikipedia.org/wiki/Nirvana_(band)" {
+		t.Fatalf("expected the Action API page to be credited, got %+v (%v)", attribution, err)
+	}
+	images, err := client.ArtistImages(context.Background(), "", "Nirvana")
+	if err != nil || len(images) != 1 || images[0].Width != 800 {
+		t.Fatalf("expected the Action API lead image, got %+v (%v)", images, err)
+	}
+
+	if _, err := client.GetArtistBiography(context.Background(), "Nobody"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a page missing from the Action API to be ErrNotFound, got %v", err)
+	}
+}
+
+func TestActionEndpointFollowsLanguageEdition(t *testing.T) {
+	client, err := New(context.Background(), Config{BaseURL: "https://en.wikipedia.org/api/rest_v1"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if got := client.actionEndpoint(client.baseURL); got != "https://en.wikipedia.org/w/api.php" {
+		t.Errorf("actionEndpoint(en) = %q", got)
+	}
+	if got := client.actionEndpoint("https://de.wikipedia.org/api/rest_v1"); got != "https://de.wikipedia.org/w/api.php" {
+		t.Errorf("actionEndpoint(de) = %q", got)
+	}
+}
@@ -0,0 +1,90 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// defaultLanguage is the edition assumed for base URLs that don't name one.
+const defaultLanguage = "en"
+
+// SitelinkResolver maps a Wikidata item onto the title of its article on a Wikipedia edition.
+type SitelinkResolver interface {
+	WikipediaTitle(ctx context.Context, itemID, language string) (string, error)
+}
+
+// PageTitle splits a Wikipedia article URL such as https://en.wikipedia.org/wiki/Nirvana_(band)
+// into its title ("Nirvana (band)") and edition language ("en"). Anything else yields "", "".
+func PageTitle(pageURL string) (title, language string) {
+	parsed, err := url.Parse(strings.TrimSpace(pageURL))
+	if err != nil {
+		return "", ""
+	}
+	host := strings.ToLower(parsed.Hostname())
+	language, rest, ok := strings.Cut(host, ".")
+	if !ok || strings.TrimPrefix(rest, "m.") != "wikipedia.org" || !languageCodePattern.MatchString(language) {
+		return "", ""
+	}
+	title, ok = strings.CutPrefix(parsed.Path, "/wiki/")
+	if !ok || title == "" {
+		return "", ""
+	}
+	return strings.ReplaceAll(title, "_", " "), language
+}
+
+// GetBiographyByPage reads the biography from the article title on the configured edition,
+// typically the page MusicBrainz links an artist to, so no title guessing is involved. The
+// page's attribution comes with it.
+func (c *Client) GetBiographyByPage(ctx context.Context, title string) (string, *data.Attribution, error) {
+	if strings.TrimSpace(title) == "" {
+		return "", nil, ErrNotFound
+	}
+	summary, err := c.getPageSummary(ctx, c.baseURL, title)
+	if err != nil {
+		return "", nil, err
+	}
+	biography := cleanExtract(summary.Extract)
+	if biography == "" {
+		return "", nil, ErrNotFound
+	}
+	pageURL := summary.URL
+	if pageURL == "" {
+		pageURL = articleURL(c.baseURL, summary.Title)
+	}
+	return biography, data.NewWikipediaAttribution(summary.Title, pageURL, summary.Revision), nil
+}
+
+// GetBiographyByWikidataID reads the biography from the article the Wikidata item id (such as
+// Q11649) links to on the configured edition. Without a sitelink resolver, or when the lookup
+// fails, it returns ErrNotFound so callers fall back as they would for an item with no article.
+func (c *Client) GetBiographyByWikidataID(ctx context.Context, id string) (string, *data.Attribution, error) {
+	if c.sitelinks == nil {
+		return "", nil, ErrNotFound
+	}
+	title, err := c.sitelinks.WikipediaTitle(ctx, id, c.Language())
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", nil, ctx.Err()
+		}
+		return "", nil, fmt.Errorf("%w: no article for %s: %v", ErrNotFound, id, err)
+	}
+	return c.GetBiographyByPage(ctx, title)
+}
+
+// Language is the edition the configured base URL reads, taken from its subdomain; base URLs
+// without one (mirrors, test servers) are treated as English.
+func (c *Client) Language() string {
+	parsed, err := url.Parse(c.baseURL)
+	if err != nil {
+		return defaultLanguage
+	}
+	language, _, ok := strings.Cut(parsed.Hostname(), ".")
+	if !ok || !languageCodePattern.MatchString(language) {
+		return defaultLanguage
+	}
+	return language
+}
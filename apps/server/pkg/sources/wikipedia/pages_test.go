@@ -0,0 +1,94 @@
+package wikipedia
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type stubSitelinks map[string]string
+
+func (s stubSitelinks) WikipediaTitle(ctx context.Context, itemID, language string) (string, error) {
+	if title, ok := s[language+":"+itemID]; ok {
+		return title, nil
+	}
+	return "", errors.New("no sitelink")
+}
+
+func TestPageTitle(t *testing.T) {
+	cases := map[string][2]string{
+		"https://en.wikipedia.org/wiki/Nirvana_(band)":       {"Nirvana (band)", "en"},
+		"https://de.m.wikipedia.org/wiki/Die_%C3%84rzte":     {"Die Ärzte", "de"},
+		"https://en.wikipedia.org/w/index.php?title=Nirvana": {"", ""},
+		"https://www.wikidata.org/wiki/Q11649":               {"", ""},
+		"https://en.wikipedia.org.example.com/wiki/Nirvana":  {"", ""},
+		"": {"", ""},
+	}
+	for pageURL, want := range cases {
+		title, language := PageTitle(pageURL)
+		if title != want[0] || language != want[1] {
+			t.Errorf("PageTitle(%q) = %q, %q; want %q, %q", pageURL, title, language, want[0], want[1])
+		}
+	}
+}
+
+func TestGetBiographyByLinkedPage(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		title := strings.TrimPrefix(r.URL.Path, "/page/summary/")
+		requested = append(requested, title)
+		w.Header().Set("Content-Type", "application/json")
+		switch title {
+		case "Low (American band)":
+			w.Write([]byte(`{"type":"standard","title":"Low (American band)","extract":"Low was an American indie rock band from Duluth, Minnesota.","revision":"42"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL, Sitelinks: stubSitelinks{"en:Q1477428": "Low (American band)"}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	biography, attribution, err := client.GetBiographyByPage(context.Background(), "Low (American band)")
+	if err != nil || !strings.HasPrefix(biography, "Low was an American") {
+		t.Fatalf("GetBiographyByPage = %q, %v", biography, err)
+	}
+	if attribution == nil || attribution.Title != "Low (American band)" || attribution.Revision != "42" {
+		t.Fatalf("unexpected attribution %+v", attribution)
+	}
+	if len(requested) != 1 {
+		t.Fatalf("expected the linked page to be read without guessing, got %v", requested)
+	}
+
+	if biography, _, err := client.GetBiographyByWikidataID(context.Background(), "Q1477428"); err != nil || biography == "" {
+		t.Fatalf("GetBiographyByWikidataID = %q, %v", biography, err)
+	}
+	if _, _, err := client.GetBiographyByWikidataID(context.Background(), "Q1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a failed sitelink lookup to be ErrNotFound, got %v", err)
+	}
+	if _, _, err := client.GetBiographyByPage(context.Background(), "Missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing page, got %v", err)
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	for baseURL, want := range map[string]string{
+		"https://en.wikipedia.org/api/rest_v1": "en",
+		"https://de.wikipedia.org/api/rest_v1": "de",
+		"http://127.0.0.1:8090/wikipedia":      "en",
+	} {
+		client, err := New(context.Background(), Config{BaseURL: baseURL})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		if got := client.Language(); got != want {
+			t.Errorf("Language() for %s = %q, want %q", baseURL, got, want)
+		}
+	}
+}
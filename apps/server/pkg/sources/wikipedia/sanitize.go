@@ -0,0 +1,244 @@
+package wikipedia
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Biographies are trimmed to a short introduction: at most maxExtractSentences sentences and
+// maxExtractRunes characters.
+const (
+	maxExtractSentences = 3
+	maxExtractRunes     = 500
+)
+
+var (
+	// footnotePattern matches footnote and maintenance markers such as [1], [a], [note 2], and
+	// [citation needed].
+	footnotePattern = regexp.MustCompile(`(?i)\[(?:\d+|[a-z]{1,2}|(?:note|nb|n) ?\d+|citation needed|clarification needed|better source needed|when\?|who\?)\]`)
+	// ipaPattern matches a phonemic transcription between slashes, e.g. /bjɜːrk/.
+	ipaPattern = regexp.MustCompile(`/[^/\s][^/]*/`)
+	// respellingPattern matches a pronunciation respelling such as BYURK or bee-YON-say: hyphenated
+	// syllables with at least one stressed one in capitals.
+	respellingPattern = regexp.MustCompile(`^(?:[\p{Ll}ˈˌ]+-)*\p{Lu}{2,}(?:-[\p{L}ˈˌ]+)*$`)
+	spacePattern      = regexp.MustCompile(`\s+`)
+	// spaceBeforePunctPattern matches the gaps left where an aside or marker preceded punctuation.
+	spaceBeforePunctPattern = regexp.MustCompile(`\s+([,.;:!?、。，])`)
+)
+
+// pronunciationMarkers are substrings that mark an aside part as pronunciation help.
+var pronunciationMarkers = []string{"pronounc", "listen", "ipa", "ⓘ"}
+
+// abbreviations end in a period without ending a sentence.
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "st": true, "mt": true,
+	"jr": true, "sr": true, "vs": true, "feat": true, "ft": true, "no": true, "vol": true,
+	"approx": true, "ca": true, "c": true, "inc": true, "ltd": true, "co": true, "bros": true,
+}
+
+// cleanExtract turns a page summary into a short biography: it drops footnote markers and the
+// pronunciation guides in parenthetical asides (keeping the rest of the aside, such as birth
+// dates), tidies whitespace, and keeps the first few sentences. It handles nested and full-width
+// parentheses and scripts that end sentences without a space.
+func cleanExtract(extract string) string {
+	cleaned := footnotePattern.ReplaceAllString(extract, "")
+	cleaned = stripPronunciations(cleaned)
+	cleaned = spacePattern.ReplaceAllString(cleaned, " ")
+	cleaned = spaceBeforePunctPattern.ReplaceAllString(cleaned, "$1")
+	cleaned = strings.TrimSpace(cleaned)
+	if cleaned == "" {
+		return ""
+	}
+	return truncateExtract(splitSentences(cleaned))
+}
+
+// stripPronunciations rewrites every parenthetical aside in text without its pronunciation
+// parts, dropping asides left empty. Unbalanced parentheses are left as they are.
+func stripPronunciations(text string) string {
+	var b strings.Builder
+	for {
+		open := strings.IndexAny(text, "(（")
+		if open < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		closeAt := matchingParen(text, open)
+		if closeAt < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+
+		b.WriteString(text[:open])
+		openRune, openSize := utf8.DecodeRuneInString(text[open:])
+		closeRune, closeSize := utf8.DecodeRuneInString(text[closeAt:])
+		if kept := keptAsideParts(text[open+openSize : closeAt]); kept != "" {
+			b.WriteRune(openRune)
+			b.WriteString(kept)
+			b.WriteRune(closeRune)
+		}
+		text = text[closeAt+closeSize:]
+	}
+}
+
+// matchingParen returns the byte offset of the parenthesis closing the one at open, or -1.
+func matchingParen(text string, open int) int {
+	depth := 0
+	for i, r := range text[open:] {
+		switch r {
+		case '(', '（':
+			depth++
+		case ')', '）':
+			depth--
+			if depth == 0 {
+				return open + i
+			}
+		}
+	}
+	return -1
+}
+
+// keptAsideParts splits an aside's contents on top-level semicolons and rejoins the parts that
+// aren't pronunciation help. A lone part that merely
+// looks like a respelling ("USA") is kept, since respellings only appear alongside other parts.
+func keptAsideParts(inner string) string {
+	parts := splitTopLevel(inner)
+	kept := make([]string, 0, len(parts))
+	for _, part := range parts {
+		// Nested asides are cleaned first, so a part is judged by its own words.
+		part = strings.TrimSpace(stripPronunciations(part))
+		if part == "" || isPronunciation(part, len(parts) > 1) {
+			continue
+		}
+		kept = append(kept, part)
+	}
+	return strings.Join(kept, "; ")
+}
+
+// splitTopLevel splits s on semicolons that aren't inside nested parentheses or brackets.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(', '（', '[':
+			depth++
+		case ')', '）', ']':
+			depth--
+		case ';', '；':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + utf8.RuneLen(r)
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// isPronunciation reports whether an aside part is pronunciation help: an IPA transcription in
+// slashes or brackets, a listen link, or, when the aside has other parts, a bare respelling.
+func isPronunciation(part string, hasSiblings bool) bool {
+	lower := strings.ToLower(part)
+	for _, marker := range pronunciationMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	if ipaPattern.MatchString(part) || strings.ContainsAny(part, "[]") {
+		return true
+	}
+	if !hasSiblings {
+		return false
+	}
+	words := strings.Fields(part)
+	for _, word := range words {
+		if !respellingPattern.MatchString(word) {
+			return false
+		}
+	}
+	return len(words) > 0
+}
+
+// splitSentences breaks text after ., !, or ? followed by a space and a word that doesn't start
+// in lowercase, and after CJK full stops, which need no space. Periods after abbreviations and
+// initials ("Dr.", "D.C.", "John F.") don't end a sentence.
+func splitSentences(text string) []string {
+	var sentences []string
+	start := 0
+	for i, r := range text {
+		end := i + utf8.RuneLen(r)
+		switch r {
+		case '。', '！', '？':
+		case '.', '!', '?':
+			rest := text[end:]
+			if !strings.HasPrefix(rest, " ") {
+				continue
+			}
+			next, _ := utf8.DecodeRuneInString(strings.TrimLeft(rest, " "))
+			if unicode.IsLower(next) || (r == '.' && isAbbreviation(text[start:i])) {
+				continue
+			}
+		default:
+			continue
+		}
+		if sentence := strings.TrimSpace(text[start:end]); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end
+	}
+	if rest := strings.TrimSpace(text[start:]); rest != "" {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
+
+// isAbbreviation reports whether the last word of preceding, which is followed by a period, is
+// an abbreviation or initial rather than the end of a sentence.
+func isAbbreviation(preceding string) bool {
+	word := preceding[strings.LastIndexAny(preceding, " (")+1:]
+	if strings.Contains(word, ".") {
+		return true
+	}
+	return utf8.RuneCountInString(word) == 1 || abbreviations[strings.ToLower(word)]
+}
+
+// truncateExtract joins as many leading sentences as fit the sentence and length limits. A
+// first sentence that is too long on its own is cut at a word boundary and marked with an
+// ellipsis.
+func truncateExtract(sentences []string) string {
+	var b strings.Builder
+	for i, sentence := range sentences {
+		if i == maxExtractSentences {
+			break
+		}
+		separator := ""
+		if b.Len() > 0 && !endsWithCJKStop(b.String()) {
+			separator = " "
+		}
+		if utf8.RuneCountInString(b.String()+separator+sentence) > maxExtractRunes {
+			if b.Len() == 0 {
+				return cutAtWord(sentence, maxExtractRunes)
+			}
+			break
+		}
+		b.WriteString(separator + sentence)
+	}
+	return b.String()
+}
+
+func endsWithCJKStop(s string) bool {
+	last, _ := utf8.DecodeLastRuneInString(s)
+	return last == '。' || last == '！' || last == '？'
+}
+
+// cutAtWord shortens s to fewer than limit characters, at the last space when there is one,
+// and appends an ellipsis.
+func cutAtWord(s string, limit int) string {
+	runes := []rune(s)
+	cut := string(runes[:limit-1])
+	if space := strings.LastIndex(cut, " "); space > 0 {
+		cut = cut[:space]
+	}
+	return strings.TrimRight(cut, " ,;:") + "…"
+}
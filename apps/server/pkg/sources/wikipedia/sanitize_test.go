@@ -0,0 +1,102 @@
+package wikipedia
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCleanExtractCorpus(t *testing.T) {
+	tests := []struct {
+		name    string
+		extract string
+		want    string
+	}{
+		{
+			name:    "plain lead",
+			extract: "Nirvana was an American rock band formed in Aberdeen, Washington, in 1987. Founded by lead singer and guitarist Kurt Cobain and bassist Krist Novoselic, the band went through a succession of drummers, most notably Dave Grohl, who joined in 1990.",
+			want:    "Nirvana was an American rock band formed in Aberdeen, Washington, in 1987. Founded by lead singer and guitarist Kurt Cobain and bassist Krist Novoselic, the band went through a succession of drummers, most notably Dave Grohl, who joined in 1990.",
+		},
+		{
+			name:    "IPA and native-language transcription beside birth date",
+			extract: "Björk Guðmundsdóttir (/bjɜːrk/ BYURK; Icelandic: [pjœr̥k ˈkvʏðmʏntsˌtoʊhtɪr̥] ; born 21 November 1965) is an Icelandic singer, songwriter, composer, record producer, and actress.",
+			want:    "Björk Guðmundsdóttir (born 21 November 1965) is an Icelandic singer, songwriter, composer, record producer, and actress.",
+		},
+		{
+			name:    "respelling left behind by the REST summary",
+			extract: "Beyoncé Giselle Knowles-Carter ( bee-YON-say; born September 4, 1981) is an American singer, songwriter and businesswoman.",
+			want:    "Beyoncé Giselle Knowles-Carter (born September 4, 1981) is an American singer, songwriter and businesswoman.",
+		},
+		{
+			name:    "aside that is only pronunciation",
+			extract: "Sigur Rós (Icelandic pronunciation: [ˈsɪːɣʏr ˈrouːs]) is an Icelandic post-rock band from Reykjavík.",
+			want:    "Sigur Rós is an Icelandic post-rock band from Reykjavík.",
+		},
+		{
+			name:    "listen link",
+			extract: "Sade Adu (Yoruba: [ʃadé] ⓘ; born 16 January 1959) is a Nigerian-born British singer.",
+			want:    "Sade Adu (born 16 January 1959) is a Nigerian-born British singer.",
+		},
+		{
+			name:    "nested parentheses",
+			extract: "Bob Dylan (born Robert Allen Zimmerman (pronounced /ˈzɪmərmən/); May 24, 1941) is an American singer-songwriter.",
+			want:    "Bob Dylan (born Robert Allen Zimmerman; May 24, 1941) is an American singer-songwriter.",
+		},
+		{
+			name:    "footnote and maintenance markers",
+			extract: "Motörhead were an English rock band formed in London in 1975.[1][2] They are often considered a precursor to the new wave of British heavy metal.[a][citation needed]",
+			want:    "Motörhead were an English rock band formed in London in 1975. They are often considered a precursor to the new wave of British heavy metal.",
+		},
+		{
+			name:    "ordinary asides survive",
+			extract: "The Beatles were an English rock band formed in Liverpool (UK) in 1960.",
+			want:    "The Beatles were an English rock band formed in Liverpool (UK) in 1960.",
+		},
+		{
+			name:    "abbreviations and initials don't end sentences",
+			extract: "R.E.M. was an American rock band from Athens, Georgia, formed in 1980. The band was founded by Michael Stipe, Peter Buck, Mike Mills, and Bill Berry. They played Washington, D.C. often. Their debut single was released on Hib-Tone.",
+			want:    "R.E.M. was an American rock band from Athens, Georgia, formed in 1980. The band was founded by Michael Stipe, Peter Buck, Mike Mills, and Bill Berry. They played Washington, D.C. often.",
+		},
+		{
+			name:    "Japanese with full-width parentheses",
+			extract: "坂本 龍一（さかもと りゅういち、1952年1月17日 - 2023年3月28日）は、日本の音楽家、作曲家、編曲家、ピアニスト、音楽プロデューサー、俳優。東京都中野区出身。イエロー・マジック・オーケストラのメンバーとして活動した。その後はソロで活動した。",
+			want:    "坂本 龍一（さかもと りゅういち、1952年1月17日 - 2023年3月28日）は、日本の音楽家、作曲家、編曲家、ピアニスト、音楽プロデューサー、俳優。東京都中野区出身。イエロー・マジック・オーケストラのメンバーとして活動した。",
+		},
+		{
+			name:    "Cyrillic",
+			extract: "«Кино́» — советская рок-группа, образованная в 1981 году в Ленинграде. Лидером группы был Виктор Цой.",
+			want:    "«Кино́» — советская рок-группа, образованная в 1981 году в Ленинграде. Лидером группы был Виктор Цой.",
+		},
+		{
+			name:    "unbalanced parenthesis",
+			extract: "Low (band is an American indie rock band.",
+			want:    "Low (band is an American indie rock band.",
+		},
+		{
+			name:    "empty",
+			extract: "  ",
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanExtract(tt.extract); got != tt.want {
+				t.Errorf("cleanExtract() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanExtractLimitsLength(t *testing.T) {
+	sentence := strings.Repeat("長い文章です", 20) + "。"
+	got := cleanExtract(strings.Repeat(sentence, 5))
+	if n := utf8.RuneCountInString(got); n > maxExtractRunes || n == 0 {
+		t.Fatalf("expected a non-empty extract within %d characters, got %d", maxExtractRunes, n)
+	}
+
+	long := strings.Repeat("word ", 150)
+	got = cleanExtract(long)
+	if n := utf8.RuneCountInString(got); n > maxExtractRunes || !strings.HasSuffix(got, "word…") {
+		t.Fatalf("expected an overlong sentence to be cut at a word, got %d characters: %q", n, got)
+	}
+}
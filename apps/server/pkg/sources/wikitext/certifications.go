@@ -0,0 +1,87 @@
+package wikitext
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// certificationRegion is the country code and certifying body behind a
+// {{Certification Table Entry}} region name.
+type certificationRegion struct {
+	country string
+	body    string
+}
+
+// certificationRegions maps lowercased region names to ISO country codes and the body that
+// issues the certification. Regions not listed keep their name with no country or body.
+var certificationRegions = map[string]certificationRegion{
+	"united states":  {"US", "RIAA"},
+	"united kingdom": {"GB", "BPI"},
+	"canada":         {"CA", "Music Canada"},
+	"australia":      {"AU", "ARIA"},
+	"new zealand":    {"NZ", "RMNZ"},
+	"ireland":        {"IE", "IRMA"},
+	"germany":        {"DE", "BVMI"},
+	"austria":        {"AT", "IFPI Austria"},
+	"switzerland":    {"CH", "IFPI Switzerland"},
+	"france":         {"FR", "SNEP"},
+	"belgium":        {"BE", "BRMA"},
+	"netherlands":    {"NL", "NVPI"},
+	"denmark":        {"DK", "IFPI Danmark"},
+	"norway":         {"NO", "IFPI Norway"},
+	"sweden":         {"SE", "GLF"},
+	"finland":        {"FI", "Musiikkituottajat"},
+	"italy":          {"IT", "FIMI"},
+	"spain":          {"ES", "PROMUSICAE"},
+	"portugal":       {"PT", "AFP"},
+	"poland":         {"PL", "ZPAV"},
+	"japan":          {"JP", "RIAJ"},
+	"brazil":         {"BR", "Pro-Música Brasil"},
+	"mexico":         {"MX", "AMPROFON"},
+	"argentina":      {"AR", "CAPIF"},
+	"europe":         {"", "IFPI"},
+}
+
+// parseCertifications reads {{Certification Table Entry}} rows. Entries for other formats, such
+// as a video release covered in the same article, are skipped.
+func parseCertifications(source string) []data.Certification {
+	scope := section(source, "Certifications", "Certifications and sales", "Sales and certifications")
+	if scope == "" {
+		scope = source
+	}
+
+	var certifications []data.Certification
+	for _, t := range findTemplates(scope, "Certification Table Entry") {
+		if kind := strings.ToLower(t.named["type"]); kind != "" && kind != "album" {
+			continue
+		}
+		region := strings.TrimSpace(t.named["region"])
+		award := strings.TrimSpace(t.named["award"])
+		if region == "" || award == "" {
+			continue
+		}
+
+		certification := data.Certification{
+			Region: region,
+			Level:  certificationLevel(award, t.named["number"]),
+			Date:   data.PartialDateOf(t.named["certyear"]),
+		}
+		if info, ok := certificationRegions[strings.ToLower(region)]; ok {
+			certification.Country = info.country
+			certification.Body = info.body
+		}
+		certifications = append(certifications, certification)
+	}
+	return certifications
+}
+
+// certificationLevel renders an award and multiplier the way certification tables do, e.g.
+// "3× Platinum".
+func certificationLevel(award, number string) string {
+	if n, err := strconv.Atoi(strings.TrimSpace(number)); err == nil && n > 1 {
+		return strconv.Itoa(n) + "× " + award
+	}
+	return award
+}
@@ -0,0 +1,29 @@
+package wikitext
+
+import "testing"
+
+func TestParseCertifications(t *testing.T) {
+	source := `==Certifications==
+{{Certification Table Top}}
+{{Certification Table Entry|region=United States|type=album|title=Nevermind|artist=Nirvana|award=Diamond|certyear=1999|relyear=1991}}
+{{Certification Table Entry|region=United Kingdom|type=album|title=Nevermind|artist=Nirvana|award=Platinum|number=3|certyear=2014}}
+{{Certification Table Entry|region=United States|type=video|title=Live! Tonight! Sold Out!!|award=Platinum}}
+{{Certification Table Entry|region=Atlantis|type=album|award=Gold}}
+{{Certification Table Bottom}}`
+
+	certifications := parseCertifications(source)
+	if len(certifications) != 3 {
+		t.Fatalf("expected 3 certifications, got %+v", certifications)
+	}
+
+	us := certifications[0]
+	if us.Country != "US" || us.Body != "RIAA" || us.Level != "Diamond" || us.Date.Year != 1999 {
+		t.Fatalf("unexpected US certification %+v", us)
+	}
+	if uk := certifications[1]; uk.Level != "3× Platinum" || uk.Body != "BPI" {
+		t.Fatalf("unexpected UK certification %+v", uk)
+	}
+	if unknown := certifications[2]; unknown.Region != "Atlantis" || unknown.Country != "" || !unknown.Date.IsZero() {
+		t.Fatalf("unexpected unknown-region certification %+v", unknown)
+	}
+}
@@ -0,0 +1,108 @@
+package wikitext
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+)
+
+// chartInfo names a chart referenced by an {{Album chart}} identifier.
+type chartInfo struct {
+	name    string
+	country string
+}
+
+// albumCharts maps lowercased {{Album chart}} identifiers to display names and ISO country
+// codes. Identifiers not listed here are reported with their raw identifier and no country.
+var albumCharts = map[string]chartInfo{
+	"billboard200":         {"Billboard 200", "US"},
+	"billboardrandbhiphop": {"Top R&B/Hip-Hop Albums", "US"},
+	"billboardrock":        {"Top Rock Albums", "US"},
+	"billboardindependent": {"Independent Albums", "US"},
+	"billboardalternative": {"Top Alternative Albums", "US"},
+	"billboardcountry":     {"Top Country Albums", "US"},
+	"uk2":                  {"UK Albums Chart", "GB"},
+	"ukindependent":        {"UK Independent Albums", "GB"},
+	"scotland":             {"Scottish Albums", "GB"},
+	"australia":            {"ARIA Albums Chart", "AU"},
+	"newzealand":           {"NZ Top 40 Albums", "NZ"},
+	"canada":               {"Canadian Albums", "CA"},
+	"billboardcanada":      {"Canadian Albums", "CA"},
+	"germany2":             {"German Albums", "DE"},
+	"germany4":             {"German Albums", "DE"},
+	"austria":              {"Austrian Albums", "AT"},
+	"switzerland":          {"Swiss Albums", "CH"},
+	"france":               {"French Albums", "FR"},
+	"netherlands":          {"Dutch Albums", "NL"},
+	"flanders":             {"Belgian Albums (Flanders)", "BE"},
+	"wallonia":             {"Belgian Albums (Wallonia)", "BE"},
+	"sweden":               {"Swedish Albums", "SE"},
+	"norway":               {"Norwegian Albums", "NO"},
+	"finland":              {"Finnish Albums", "FI"},
+	"denmark":              {"Danish Albums", "DK"},
+	"ireland":              {"Irish Albums", "IE"},
+	"italy":                {"Italian Albums", "IT"},
+	"spain":                {"Spanish Albums", "ES"},
+	"portugal":             {"Portuguese Albums", "PT"},
+	"poland":               {"Polish Albums", "PL"},
+	"czech":                {"Czech Albums", "CZ"},
+	"hungary":              {"Hungarian Albums", "HU"},
+	"greece":               {"Greek Albums", "GR"},
+	"japan":                {"Japanese Albums (Billboard Japan)", "JP"},
+	"oricon":               {"Japanese Albums (Oricon)", "JP"},
+	"southkorea":           {"South Korean Albums (Circle)", "KR"},
+	"mexico":               {"Mexican Albums", "MX"},
+	"argentina":            {"Argentine Albums", "AR"},
+}
+
+// parseCharts reads peak positions from {{Album chart|<chart>|<peak>|...}} rows. The weekly
+// chart table is preferred so year-end rankings don't masquerade as peaks; articles without
+// one are searched in full. When a chart appears more than once the best peak wins.
+func parseCharts(source string) []data.ChartPosition {
+	scope := section(source, "Weekly charts", "Weekly chart")
+	if scope == "" {
+		scope = section(source, "Charts", "Chart performance", "Chart positions")
+	}
+	if scope == "" {
+		scope = source
+	}
+
+	peaks := make(map[string]data.ChartPosition)
+	for _, t := range findTemplates(scope, "Album chart") {
+		id := strings.ToLower(strings.TrimSpace(t.arg(0)))
+		peak, err := strconv.Atoi(strings.TrimSpace(t.arg(1)))
+		if id == "" || err != nil || peak <= 0 {
+			continue
+		}
+		position := data.ChartPosition{Chart: id, Peak: peak}
+		if info, ok := albumCharts[id]; ok {
+			position.Chart = info.name
+			position.Country = info.country
+		}
+		if existing, ok := peaks[position.Chart]; ok && existing.Peak <= peak {
+			continue
+		}
+		peaks[position.Chart] = position
+	}
+	if len(peaks) == 0 {
+		return nil
+	}
+
+	charts := make([]data.ChartPosition, 0, len(peaks))
+	for _, position := range peaks {
+		charts = append(charts, position)
+	}
+	sort.Slice(charts, func(i, j int) bool {
+		if charts[i].Country != charts[j].Country {
+			// Charts we couldn't place in a country sort after the known ones.
+			if charts[i].Country == "" || charts[j].Country == "" {
+				return charts[j].Country == ""
+			}
+			return charts[i].Country < charts[j].Country
+		}
+		return charts[i].Chart < charts[j].Chart
+	})
+	return charts
+}
@@ -0,0 +1,226 @@
+// Package wikitext reads album articles from Wikipedia as wikitext and extracts structured facts
+// that the REST summary endpoint doesn't carry, such as chart positions and certifications.
+package wikitext
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/health"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/httpclient"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/metrics"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/useragent"
+)
+
+// ErrNotFound indicates no Wikipedia article could be found for the album.
+var ErrNotFound = errors.New("wikitext: article not found")
+
+// Config describes how to connect to the Wikipedia core REST API.
+type Config struct {
+	BaseURL   string
+	UserAgent string
+	// HTTP sets the timeout, retries, response cache, and pacing.
+	HTTP httpclient.Options
+}
+
+// Client fetches article wikitext from Wikipedia.
+type Client struct {
+	baseURL    string
+	userAgent  string
+	httpClient *http.Client
+	health     *health.Tracker
+}
+
+// New constructs a wikitext client.
+func New(_ context.Context, cfg Config) (*Client, error) {
+	baseURL := strings.TrimSpace(cfg.BaseURL)
+	if baseURL == "" {
+		baseURL = "https://en.wikipedia.org/w/rest.php/v1"
+	}
+
+	userAgent := strings.TrimSpace(cfg.UserAgent)
+	if userAgent == "" {
+		userAgent = useragent.Default()
+	}
+
+	tracker := health.NewTracker()
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		userAgent:  userAgent,
+		httpClient: httpclient.New("wikitext", cfg.HTTP, 8*time.Second, tracker),
+		health:     tracker,
+	}, nil
+}
+
+// AlbumFacts are structured details parsed from an album's Wikipedia article.
+type AlbumFacts struct {
+	// Title is the article the facts were read from.
+	Title          string
+	Charts         []data.ChartPosition
+	Certifications []data.Certification
+}
+
+type pageResponse struct {
+	Title  string `json:"title"`
+	Source string `json:"source"`
+}
+
+// maxRedirects bounds how many #REDIRECT pages are followed for one title.
+const maxRedirects = 2
+
+// GetAlbumFacts finds the album's article and parses it. articleURL is the Wikipedia link
+// MusicBrainz has for the album, if any; it is tried before titles guessed from the names.
+func (c *Client) GetAlbumFacts(ctx context.Context, artistName, albumTitle, articleURL string) (*AlbumFacts, error) {
+	for _, title := range candidateTitles(artistName, albumTitle, articleURL) {
+		page, err := c.getPage(ctx, title)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !isAlbumArticle(page.Source, artistName) {
+			continue
+		}
+		return &AlbumFacts{
+			Title:          page.Title,
+			Charts:         parseCharts(page.Source),
+			Certifications: parseCertifications(page.Source),
+		}, nil
+	}
+	return nil, ErrNotFound
+}
+
+// candidateTitles lists article titles to try, most specific first.
+func candidateTitles(artistName, albumTitle, articleURL string) []string {
+	var titles []string
+	if title := titleFromURL(articleURL); title != "" {
+		titles = append(titles, title)
+	}
+	albumTitle = strings.TrimSpace(albumTitle)
+	if albumTitle == "" {
+		return titles
+	}
+	if artistName = strings.TrimSpace(artistName); artistName != "" {
+		titles = append(titles, fmt.Sprintf("%s (%s album)", albumTitle, artistName))
+	}
+	return append(titles, albumTitle+" (album)", albumTitle)
+}
+
+// titleFromURL extracts the article title from an English Wikipedia URL.
+func titleFromURL(raw string) string {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || !strings.EqualFold(parsed.Hostname(), "en.wikipedia.org") {
+		return ""
+	}
+	title, ok := strings.CutPrefix(parsed.Path, "/wiki/")
+	if !ok {
+		return ""
+	}
+	return strings.ReplaceAll(title, "_", " ")
+}
+
+// isAlbumArticle guards against guessed titles landing on a same-named article about something
+// else: the page must carry an album infobox that mentions the artist.
+func isAlbumArticle(source, artistName string) bool {
+	lower := strings.ToLower(source)
+	start := strings.Index(lower, "{{infobox album")
+	if start < 0 {
+		return false
+	}
+	artist := strings.ToLower(strings.TrimSpace(artistName))
+	if artist == "" {
+		return true
+	}
+	end := start + len("{{infobox album") + templateLength(source[start+len("{{infobox album"):])
+	return strings.Contains(lower[start:end], artist)
+}
+
+func (c *Client) getPage(ctx context.Context, title string) (*pageResponse, error) {
+	for i := 0; ; i++ {
+		page, err := c.fetchPage(ctx, title)
+		if err != nil {
+			return nil, err
+		}
+		target, ok := redirectTarget(page.Source)
+		if !ok {
+			return page, nil
+		}
+		if i >= maxRedirects {
+			return nil, ErrNotFound
+		}
+		title = target
+	}
+}
+
+func (c *Client) fetchPage(ctx context.Context, title string) (*pageResponse, error) {
+	endpoint := fmt.Sprintf("%s/page/%s", c.baseURL, url.PathEscape(strings.ReplaceAll(title, " ", "_")))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("wikitext: request build failed: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("wikitext: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var payload pageResponse
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			metrics.RecordDecodeError("wikitext")
+			return nil, fmt.Errorf("wikitext: decode failed: %w", err)
+		}
+		return &payload, nil
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("wikitext: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+}
+
+// redirectTarget returns the target of a "#REDIRECT [[Title]]" page.
+func redirectTarget(source string) (string, bool) {
+	trimmed := strings.TrimSpace(source)
+	if len(trimmed) < len("#redirect") || !strings.EqualFold(trimmed[:len("#redirect")], "#redirect") {
+		return "", false
+	}
+	start := strings.Index(trimmed, "[[")
+	end := strings.Index(trimmed, "]]")
+	if start < 0 || end < start {
+		return "", false
+	}
+	target := trimmed[start+2 : end]
+	if i := strings.IndexAny(target, "#|"); i >= 0 {
+		target = target[:i]
+	}
+	return strings.TrimSpace(target), target != ""
+}
+
+// Healthy reports whether recent Wikipedia wikitext calls have been succeeding.
+func (c *Client) Healthy() bool {
+	return c.health.Healthy()
+}
+
+// Health returns the rolling success rate and failure state for wikitext calls.
+func (c *Client) Health() health.Status {
+	return c.health.Status()
+}
+
+// Ping checks that the Wikipedia core REST API is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	return health.Ping(ctx, c.httpClient, c.baseURL+"/page/Music/bare", c.userAgent)
+}
@@ -0,0 +1,130 @@
+package wikitext
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const nevermindSource = `{{Infobox album
+| name = Nevermind
+| type = studio
+| artist = [[Nirvana (band)|Nirvana]]
+}}
+'''Nevermind''' is the second studio album.
+
+==Charts==
+===Weekly charts===
+{| class="wikitable"
+{{Album chart|Billboard200|1|artist=Nirvana|rowheader=true}}
+{{Album chart|UK2|7|date=19911012|rowheader=true}}
+{{Album chart|UK2|5|date=20110925|rowheader=true}}
+{{Album chart|Germany4|12|id=12345|rowheader=true}}
+{{Album chart|Mongolia|3|rowheader=true}}
+|}
+
+===Year-end charts===
+{{Album chart|Billboard200|40}}
+`
+
+func newPageServer(t *testing.T, pages map[string]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		title := strings.TrimPrefix(r.URL.Path, "/page/")
+		source, ok := pages[title]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pageResponse{Title: strings.ReplaceAll(title, "_", " "), Source: source})
+	}))
+}
+
+func TestGetAlbumFactsFollowsArticleLinkAndRedirect(t *testing.T) {
+	server := newPageServer(t, map[string]string{
+		"Nevermind_(album)":         "#REDIRECT [[Nevermind (Nirvana album)]]",
+		"Nevermind_(Nirvana_album)": nevermindSource,
+	})
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	facts, err := client.GetAlbumFacts(context.Background(), "Nirvana", "Nevermind", "https://en.wikipedia.org/wiki/Nevermind_(album)")
+	if err != nil {
+		t.Fatalf("GetAlbumFacts: %v", err)
+	}
+	if facts.Title != "Nevermind (Nirvana album)" {
+		t.Fatalf("unexpected article %q", facts.Title)
+	}
+	if len(facts.Charts) != 4 {
+		t.Fatalf("expected 4 charts, got %+v", facts.Charts)
+	}
+}
+
+func TestGetAlbumFactsRejectsUnrelatedArticles(t *testing.T) {
+	server := newPageServer(t, map[string]string{
+		"Nevermind":         "'''Nevermind''' may refer to:",
+		"Nevermind_(album)": "{{Infobox album\n| artist = Someone Else\n}}",
+	})
+	defer server.Close()
+
+	client, err := New(context.Background(), Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if _, err := client.GetAlbumFacts(context.Background(), "Nirvana", "Nevermind", ""); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestParseChartsUsesWeeklyPeaks(t *testing.T) {
+	charts := parseCharts(nevermindSource)
+
+	want := []struct {
+		chart, country string
+		peak           int
+	}{
+		{"German Albums", "DE", 12},
+		{"UK Albums Chart", "GB", 5},
+		{"Billboard 200", "US", 1},
+		{"mongolia", "", 3},
+	}
+	if len(charts) != len(want) {
+		t.Fatalf("expected %d charts, got %+v", len(want), charts)
+	}
+	for i, w := range want {
+		if charts[i].Chart != w.chart || charts[i].Country != w.country || charts[i].Peak != w.peak {
+			t.Fatalf("chart %d = %+v, want %+v", i, charts[i], w)
+		}
+	}
+}
+
+func TestParseChartsWithoutChartSection(t *testing.T) {
+	charts := parseCharts("Some prose.\n{{Album chart|Australia|2}}\n{{Album chart|Sweden|—}}")
+	if len(charts) != 1 || charts[0].Country != "AU" || charts[0].Peak != 2 {
+		t.Fatalf("unexpected charts %+v", charts)
+	}
+}
+
+func TestFindTemplatesHandlesNesting(t *testing.T) {
+	source := `{{Album chart|France|4|refname={{cite web|title=a|b}}|note=[[SNEP|x]]}}`
+	found := findTemplates(source, "album_chart")
+	if len(found) != 1 {
+		t.Fatalf("expected one template, got %d", len(found))
+	}
+	if found[0].arg(0) != "France" || found[0].arg(1) != "4" {
+		t.Fatalf("unexpected positional args %q", found[0].positional)
+	}
+	if found[0].named["refname"] != "{{cite web|title=a|b}}" {
+		t.Fatalf("unexpected named args %q", found[0].named)
+	}
+}
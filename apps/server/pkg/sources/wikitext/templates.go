@@ -0,0 +1,142 @@
+package wikitext
+
+import (
+	"strings"
+)
+
+// template is one parsed {{...}} transclusion. Positional arguments keep their order; named
+// arguments are keyed by lowercased name.
+type template struct {
+	positional []string
+	named      map[string]string
+}
+
+// findTemplates returns every top-level or nested use of the named template in source. Names
+// are matched case-insensitively, ignoring spaces and underscores.
+func findTemplates(source, name string) []template {
+	want := normalizeTemplateName(name)
+	var found []template
+	for i := 0; i+2 <= len(source); i++ {
+		if source[i] != '{' || source[i+1] != '{' {
+			continue
+		}
+		length := templateLength(source[i+2:])
+		body := source[i+2 : i+2+length]
+		body = strings.TrimSuffix(body, "}}")
+		parts := splitTopLevel(body)
+		if len(parts) > 0 && normalizeTemplateName(parts[0]) == want {
+			found = append(found, newTemplate(parts[1:]))
+		}
+	}
+	return found
+}
+
+func normalizeTemplateName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, "_", "")
+	return strings.ReplaceAll(name, " ", "")
+}
+
+func newTemplate(args []string) template {
+	t := template{named: make(map[string]string)}
+	for _, arg := range args {
+		if key, value, ok := strings.Cut(arg, "="); ok && !strings.ContainsAny(key, "[{") {
+			t.named[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+			continue
+		}
+		t.positional = append(t.positional, strings.TrimSpace(arg))
+	}
+	return t
+}
+
+// arg returns the positional argument at i, or "" when absent.
+func (t template) arg(i int) string {
+	if i < len(t.positional) {
+		return t.positional[i]
+	}
+	return ""
+}
+
+// templateLength returns how many bytes of rest belong to a template whose opening braces were
+// already consumed, including its closing braces. Unterminated templates run to the end.
+func templateLength(rest string) int {
+	depth := 1
+	for i := 0; i+1 < len(rest); i++ {
+		switch {
+		case rest[i] == '{' && rest[i+1] == '{':
+			depth++
+			i++
+		case rest[i] == '}' && rest[i+1] == '}':
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(rest)
+}
+
+// splitTopLevel splits a template body on pipes that aren't inside nested templates or links.
+func splitTopLevel(body string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(body); i++ {
+		switch {
+		case i+1 < len(body) && (body[i:i+2] == "{{" || body[i:i+2] == "[["):
+			depth++
+			i++
+		case i+1 < len(body) && (body[i:i+2] == "}}" || body[i:i+2] == "]]"):
+			if depth > 0 {
+				depth--
+			}
+			i++
+		case body[i] == '|' && depth == 0:
+			parts = append(parts, body[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, body[start:])
+}
+
+// section returns the body of the first section whose heading matches one of titles, up to the
+// next heading of the same or a higher level. It returns "" when no heading matches.
+func section(source string, titles ...string) string {
+	lines := strings.Split(source, "\n")
+	for i, line := range lines {
+		level, heading := parseHeading(line)
+		if level == 0 || !matchesAny(heading, titles) {
+			continue
+		}
+		end := len(lines)
+		for j := i + 1; j < len(lines); j++ {
+			if next, _ := parseHeading(lines[j]); next > 0 && next <= level {
+				end = j
+				break
+			}
+		}
+		return strings.Join(lines[i+1:end], "\n")
+	}
+	return ""
+}
+
+func parseHeading(line string) (int, string) {
+	trimmed := strings.TrimSpace(line)
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '=' {
+		level++
+	}
+	if level < 2 || !strings.HasSuffix(trimmed, strings.Repeat("=", level)) || len(trimmed) <= 2*level {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(trimmed[level : len(trimmed)-level])
+}
+
+func matchesAny(heading string, titles []string) bool {
+	for _, title := range titles {
+		if strings.EqualFold(heading, title) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+// Package useragent builds the User-Agent header sent to every upstream API.
+package useragent
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Version is the application version reported upstream. Release builds set it with
+//
+//	go build -ldflags "-X github.com/adamlacasse/freq-show/apps/server/pkg/useragent.Version=1.4.0" ./cmd/server
+var Version = "dev"
+
+const (
+	// DefaultName identifies the application when no name is configured.
+	DefaultName = "freq-show"
+	// ProjectURL is included so upstream operators can find the project.
+	ProjectURL = "https://github.com/adamlacasse/freq-show"
+)
+
+// Info describes the application for upstream APIs. Empty fields fall back to the defaults.
+type Info struct {
+	Name    string
+	Version string
+	Contact string
+}
+
+// String formats Info as "name/version ( project-url; contact )", the form MusicBrainz asks for
+// and which Wikimedia and Discogs accept.
+func (i Info) String() string {
+	name := strings.TrimSpace(i.Name)
+	if name == "" {
+		name = DefaultName
+	}
+	version := strings.TrimSpace(i.Version)
+	if version == "" {
+		version = Version
+	}
+
+	details := ProjectURL
+	if contact := strings.TrimSpace(i.Contact); contact != "" {
+		details += "; " + contact
+	}
+	return fmt.Sprintf("%s/%s ( %s )", name, version, details)
+}
+
+// Default returns the user agent for an unconfigured client.
+func Default() string {
+	return Info{}.String()
+}
@@ -0,0 +1,21 @@
+package useragent
+
+import "testing"
+
+func TestInfoString(t *testing.T) {
+	tests := []struct {
+		name string
+		info Info
+		want string
+	}{
+		{"defaults", Info{}, "freq-show/dev ( https://github.com/adamlacasse/freq-show )"},
+		{"full", Info{Name: "FreqShow", Version: "1.4.0", Contact: "ops@example.com"}, "FreqShow/1.4.0 ( https://github.com/adamlacasse/freq-show; ops@example.com )"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.info.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// Package webhook delivers event notifications to subscriber URLs as signed JSON POSTs.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+)
+
+const (
+	// EventRatingChanged is sent when a cached album's aggregate rating changes.
+	EventRatingChanged = "album.rating_changed"
+
+	// EventHeader names the event type of a delivery.
+	EventHeader = "X-FreqShow-Event"
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the body under the shared
+	// secret, when one is configured.
+	SignatureHeader = "X-FreqShow-Signature"
+
+	defaultTimeout = 10 * time.Second
+)
+
+// Config lists where events are delivered and how.
+type Config struct {
+	URLs []string
+	// Secret signs every payload; empty sends them unsigned.
+	Secret    string
+	UserAgent string
+	// Timeout bounds each delivery attempt; zero uses ten seconds.
+	Timeout time.Duration
+	// Retry controls how failed deliveries are retried; the zero value uses retry's defaults.
+	Retry retry.Policy
+}
+
+// Envelope is the JSON body of every delivery.
+type Envelope struct {
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Data       any       `json:"data"`
+}
+
+// Notifier posts events to every configured URL. Deliveries run in the background so the
+// request that caused an event never waits on a subscriber.
+type Notifier struct {
+	urls       []string
+	secret     []byte
+	userAgent  string
+	retry      retry.Policy
+	httpClient *http.Client
+	pending    sync.WaitGroup
+	now        func() time.Time
+}
+
+// New builds a Notifier for cfg. It returns nil when no URLs are configured.
+func New(cfg Config) *Notifier {
+	if len(cfg.URLs) == 0 {
+		return nil
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Notifier{
+		urls:       append([]string(nil), cfg.URLs...),
+		secret:     []byte(cfg.Secret),
+		userAgent:  cfg.UserAgent,
+		retry:      cfg.Retry,
+		httpClient: &http.Client{Timeout: timeout},
+		now:        time.Now,
+	}
+}
+
+// NotifyRatingChange sends change to every subscriber as an EventRatingChanged event.
+func (n *Notifier) NotifyRatingChange(ctx context.Context, change data.RatingChange) {
+	n.Send(ctx, EventRatingChanged, change)
+}
+
+// Send delivers payload to every subscriber in the background. Deliveries outlive ctx's
+// cancellation; failures are logged once retries run out.
+func (n *Notifier) Send(ctx context.Context, eventType string, payload any) {
+	body, err := json.Marshal(Envelope{Type: eventType, OccurredAt: n.now().UTC(), Data: payload})
+	if err != nil {
+		logging.FromContext(ctx).Error("webhook payload encoding failed", "event", eventType, "error", err)
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	for _, target := range n.urls {
+		n.pending.Add(1)
+		go func() {
+			defer n.pending.Done()
+			if err := n.deliver(ctx, target, eventType, body); err != nil {
+				logging.FromContext(ctx).Warn("webhook delivery failed", "event", eventType, "subscriber", subscriber(target), "error", err)
+			}
+		}()
+	}
+}
+
+// Wait blocks until deliveries in flight finish or ctx is done, for graceful shutdown.
+func (n *Notifier) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		n.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// deliver posts body to target, retrying network errors and retryable statuses with backoff.
+func (n *Notifier) deliver(ctx context.Context, target, eventType string, body []byte) error {
+	attempts := n.retry.MaxAttempts
+	if attempts <= 0 {
+		attempts = retry.DefaultPolicy().MaxAttempts
+	}
+	retryable := n.retry.Retryable
+	if retryable == nil {
+		retryable = func(status int) bool { return status >= 500 || retry.RetryableStatus(status) }
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			timer := time.NewTimer(n.retry.Backoff(attempt - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		var status int
+		status, err = n.post(ctx, target, eventType, body)
+		if err == nil && status < 300 {
+			return nil
+		}
+		if err == nil {
+			err = fmt.Errorf("subscriber answered %d", status)
+			if !retryable(status) {
+				return err
+			}
+		}
+		logging.FromContext(ctx).Debug("webhook delivery attempt failed", "subscriber", subscriber(target), "attempt", attempt, "error", err)
+	}
+	return err
+}
+
+func (n *Notifier) post(ctx context.Context, target, eventType string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, withoutURL(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, eventType)
+	if n.userAgent != "" {
+		req.Header.Set("User-Agent", n.userAgent)
+	}
+	if len(n.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(n.secret, body))
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return 0, withoutURL(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// subscriber names target in logs by scheme and host only: subscriber URLs commonly carry a
+// secret in the path or query.
+func subscriber(target string) string {
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" {
+		return "invalid URL"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// withoutURL unwraps the *url.Error that net/http wraps request failures in, since its
+// message repeats the full subscriber URL.
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("%s request: %w", urlErr.Op, urlErr.Err)
+	}
+	return err
+}
+
+// Sign returns the SignatureHeader value for body under secret, so subscribers can verify a
+// delivery by computing the same and comparing with hmac.Equal.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/adamlacasse/freq-show/apps/server/pkg/data"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/logging"
+	"github.com/adamlacasse/freq-show/apps/server/pkg/sources/retry"
+)
+
+func TestNewWithoutURLsReturnsNil(t *testing.T) {
+	if n := New(Config{Secret: "s"}); n != nil {
+		t.Fatalf("expected no notifier without URLs, got %+v", n)
+	}
+}
+
+func TestNotifyRatingChangePostsSignedEnvelope(t *testing.T) {
+	var (
+		body   []byte
+		header http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := New(Config{URLs: []string{server.URL}, Secret: "s3cret", UserAgent: "freqshow-test"})
+	n.NotifyRatingChange(context.Background(), data.RatingChange{
+		AlbumID: "album-1",
+		Current: &data.AggregateRating{Score: 4, Count: 1, Sources: []string{"discogs"}},
+	})
+	if err := n.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	if header.Get(EventHeader) != EventRatingChanged || header.Get("User-Agent") != "freqshow-test" {
+		t.Errorf("unexpected headers %v", header)
+	}
+	if got, want := header.Get(SignatureHeader), Sign([]byte("s3cret"), body); got != want {
+		t.Errorf("expected signature %q, got %q", want, got)
+	}
+	var envelope struct {
+		Type string            `json:"type"`
+		Data data.RatingChange `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if envelope.Type != EventRatingChanged || envelope.Data.AlbumID != "album-1" || envelope.Data.Current.Score != 4 {
+		t.Errorf("unexpected envelope %s", body)
+	}
+}
+
+func TestDeliveryRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(SignatureHeader) != "" {
+			t.Error("expected an unsigned delivery without a secret")
+		}
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	n := New(Config{URLs: []string{server.URL}, Retry: retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond}})
+	n.Send(context.Background(), "test", map[string]string{"ok": "yes"})
+	if err := n.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected two retries, got %d attempts", got)
+	}
+}
+
+func TestDeliveryGivesUpOnClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	n := New(Config{URLs: []string{server.URL}, Retry: retry.Policy{BaseDelay: time.Millisecond}})
+	n.Send(context.Background(), "test", nil)
+	if err := n.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected a single attempt, got %d", got)
+	}
+}
+
+func TestFailedDeliveryLogsOnlySubscriberHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	target := server.URL + "/hooks/s3cret-path?token=s3cret-query"
+	server.Close()
+
+	var logs bytes.Buffer
+	ctx := logging.WithLogger(context.Background(), logging.New(&logs, "text", slog.LevelDebug))
+	n := New(Config{URLs: []string{target}, Retry: retry.Policy{MaxAttempts: 2, BaseDelay: time.Millisecond}})
+	n.Send(ctx, "test", nil)
+	if err := n.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	out := logs.String()
+	if !strings.Contains(out, "webhook delivery failed") || !strings.Contains(out, server.URL) {
+		t.Fatalf("expected the failure logged against %s, got %s", server.URL, out)
+	}
+	if strings.Contains(out, "s3cret") {
+		t.Errorf("expected the subscriber path and query kept out of logs, got %s", out)
+	}
+}